@@ -117,6 +117,23 @@ func (c *CommonFunctions) IsListOfObject(t *introspection.TypeRef) bool {
 	return t.OfType.OfType.IsObject()
 }
 
+func (c *CommonFunctions) IsListOfUnion(t *introspection.TypeRef) bool {
+	return t.OfType.OfType.IsUnion()
+}
+
+// UnionMembers returns the names of the member object types of a union.
+func (c *CommonFunctions) UnionMembers(t *introspection.TypeRef) ([]string, error) {
+	schemaType := GetSchema().Types.Get(c.InnerType(t).Name)
+	if schemaType == nil {
+		return nil, fmt.Errorf("schema type %s is nil", c.InnerType(t).Name)
+	}
+	members := make([]string, 0, len(schemaType.PossibleTypes))
+	for _, member := range schemaType.PossibleTypes {
+		members = append(members, member.Name)
+	}
+	return members, nil
+}
+
 func (c *CommonFunctions) IsListOfEnum(t *introspection.TypeRef) bool {
 	return t.OfType.OfType.IsEnum()
 }
@@ -220,7 +237,7 @@ func (c *CommonFunctions) formatType(r *introspection.TypeRef, scope string, inp
 			default:
 				return ff.FormatKindScalarDefault(representation, ref.Name, input), nil
 			}
-		case introspection.TypeKindObject:
+		case introspection.TypeKindObject, introspection.TypeKindUnion:
 			return ff.FormatKindObject(representation, ref.Name, input), nil
 		case introspection.TypeKindInputObject:
 			return ff.FormatKindInputObject(representation, ref.Name, input), nil
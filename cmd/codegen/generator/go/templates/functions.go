@@ -59,6 +59,8 @@ func (funcs goTemplateFuncs) FuncMap() template.FuncMap {
 		"FormatOutputType":          funcs.FormatOutputType,
 		"GetArrayField":             funcs.GetArrayField,
 		"IsListOfObject":            funcs.IsListOfObject,
+		"IsListOfUnion":             funcs.IsListOfUnion,
+		"UnionMembers":              funcs.UnionMembers,
 		"ToLowerCase":               funcs.ToLowerCase,
 		"ToUpperCase":               funcs.ToUpperCase,
 		"ConvertID":                 funcs.ConvertID,
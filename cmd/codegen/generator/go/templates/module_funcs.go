@@ -247,6 +247,15 @@ func (spec *funcTypeSpec) TypeDefFunc(dag *dagger.Client) (*dagger.Function, err
 			argOpts.Ignore = argSpec.ignore
 		}
 
+		constraints := argSpec.constraints
+		argOpts.Min = dagger.JSON(constraints.min)
+		argOpts.Max = dagger.JSON(constraints.max)
+		argOpts.Pattern = constraints.pattern
+		argOpts.MinLength = constraints.minLength
+		argOpts.MaxLength = constraints.maxLength
+		argOpts.NonEmpty = constraints.nonEmpty
+		argOpts.RequiredPaths = constraints.requiredPaths
+
 		fnTypeDef = fnTypeDef.WithArg(argSpec.name, argTypeDef, argOpts)
 	}
 
@@ -407,11 +416,15 @@ func (ps *parseState) parseParamSpecVar(field *types.Var, astField *ast.Field, d
 		}
 	}
 
+	constraints, err := parseArgConstraints(pragmas)
+	if err != nil {
+		return paramSpec{}, err
+	}
+
 	// ignore ctx arg for parsing type reference
 	isContext := paramType.String() == contextTypename
 	var typeSpec ParsedType
 	if !isContext {
-		var err error
 		typeSpec, err = ps.parseGoTypeReference(baseType, nil, isPtr)
 		if err != nil {
 			return paramSpec{}, fmt.Errorf("failed to parse type reference: %w", err)
@@ -442,9 +455,69 @@ func (ps *parseState) parseParamSpecVar(field *types.Var, astField *ast.Field, d
 		defaultPath:     defaultPath,
 		deprecated:      deprecated,
 		ignore:          ignore,
+		constraints:     constraints,
 	}, nil
 }
 
+// parseArgConstraints parses the pragmas constraining the value of an
+// argument, e.g. `+min=1`, `+pattern=^v[0-9]+$` or `+requiredPaths=["go.mod"]`.
+func parseArgConstraints(pragmas map[string]any) (argConstraints, error) {
+	var constraints argConstraints
+	for _, name := range []string{"min", "max"} {
+		v, ok := pragmas[name]
+		if !ok {
+			continue
+		}
+		if _, isNumber := v.(float64); !isNumber {
+			return constraints, fmt.Errorf("%s pragma %q, must be a number", name, v)
+		}
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			return constraints, fmt.Errorf("%s pragma %q: %w", name, v, err)
+		}
+		if name == "min" {
+			constraints.min = string(encoded)
+		} else {
+			constraints.max = string(encoded)
+		}
+	}
+	if v, ok := pragmas["pattern"]; ok {
+		constraints.pattern, ok = v.(string)
+		if !ok {
+			return constraints, fmt.Errorf("pattern pragma %q, must be a valid string", v)
+		}
+	}
+	for _, name := range []string{"minLength", "maxLength"} {
+		v, ok := pragmas[name]
+		if !ok {
+			continue
+		}
+		n, isNumber := v.(float64)
+		if !isNumber || n != float64(int(n)) {
+			return constraints, fmt.Errorf("%s pragma %q, must be an integer", name, v)
+		}
+		if name == "minLength" {
+			constraints.minLength = int(n)
+		} else {
+			constraints.maxLength = int(n)
+		}
+	}
+	if v, ok := pragmas["nonEmpty"]; ok {
+		if v == nil {
+			constraints.nonEmpty = true
+		} else {
+			constraints.nonEmpty, _ = v.(bool)
+		}
+	}
+	if v, ok := pragmas["requiredPaths"]; ok {
+		err := mapstructure.Decode(v, &constraints.requiredPaths)
+		if err != nil {
+			return constraints, fmt.Errorf("requiredPaths pragma %q, must be a valid JSON array: %w", v, err)
+		}
+	}
+	return constraints, nil
+}
+
 type paramSpec struct {
 	name        string
 	description string
@@ -480,6 +553,22 @@ type paramSpec struct {
 	// The ignore patterns are applied to the input directory, and
 	// matching entries are filtered out, in a cache-efficient manner.
 	ignore []string
+
+	// Constraints on the value of the argument, checked by the engine before
+	// the function is called.
+	constraints argConstraints
+}
+
+type argConstraints struct {
+	// JSON-encoded numbers, so that 0 can be set
+	min string
+	max string
+
+	pattern       string
+	minLength     int
+	maxLength     int
+	nonEmpty      bool
+	requiredPaths []string
 }
 
 func (spec paramSpec) isOptional() bool {
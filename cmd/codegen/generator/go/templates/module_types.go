@@ -121,6 +121,7 @@ func (ps *parseState) parseGoTypeReference(typ types.Type, named *types.Named, i
 			name:       typeName,
 			moduleName: moduleName,
 			isPtr:      isPtr,
+			isUnion:    ps.isUnionStruct(named),
 			goType:     named,
 		}, nil
 
@@ -236,13 +237,18 @@ type parsedObjectTypeReference struct {
 	name       string
 	moduleName string
 
-	isPtr  bool
-	goType types.Type
+	isPtr bool
+	// isUnion is true if the struct is declared with the +union pragma
+	isUnion bool
+	goType  types.Type
 }
 
 var _ NamedParsedType = &parsedObjectTypeReference{}
 
 func (spec *parsedObjectTypeReference) TypeDef(dag *dagger.Client) (*dagger.TypeDef, error) {
+	if spec.isUnion {
+		return dag.TypeDef().WithUnion(spec.name), nil
+	}
 	return dag.TypeDef().WithObject(spec.name), nil
 }
 
@@ -0,0 +1,229 @@
+package templates
+
+import (
+	"fmt"
+	"go/types"
+	"strings"
+
+	"dagger.io/dagger"
+	. "github.com/dave/jennifer/jen" //nolint:staticcheck
+)
+
+const unionPragma = "union"
+
+// isUnionStruct returns true if the given named type is a struct declared
+// with the +union pragma, e.g.:
+//
+//	// +union
+//	type Artifact struct {
+//		Container *dagger.Container
+//		File      *dagger.File
+//	}
+func (ps *parseState) isUnionStruct(named *types.Named) bool {
+	if named == nil || ps.isDaggerGenerated(named.Obj()) {
+		return false
+	}
+	if _, ok := named.Underlying().(*types.Struct); !ok {
+		return false
+	}
+	astSpec, err := ps.astSpecForObj(named.Obj())
+	if err != nil {
+		return false
+	}
+	doc := docForAstSpec(astSpec)
+	if doc == nil {
+		return false
+	}
+	pragmas, _ := parsePragmaComment(doc.Text())
+	_, ok := pragmas[unionPragma]
+	return ok
+}
+
+func (ps *parseState) parseGoUnion(t *types.Struct, named *types.Named) (*parsedUnionType, error) {
+	spec := &parsedUnionType{
+		goType:     t,
+		moduleName: ps.moduleName,
+	}
+
+	if named == nil {
+		return nil, fmt.Errorf("union types must be named")
+	}
+	spec.name = named.Obj().Name()
+	if spec.name == "" {
+		return nil, fmt.Errorf("union types must be named")
+	}
+
+	methodSet := types.NewMethodSet(types.NewPointer(named))
+	for i := range methodSet.Len() {
+		methodObj := methodSet.At(i).Obj()
+		if ps.isDaggerGenerated(methodObj) || !methodObj.Exported() {
+			continue
+		}
+		return nil, fmt.Errorf("cannot define method %s on union %s", methodObj.Name(), spec.name)
+	}
+
+	// get the comment above the union (if any)
+	astSpec, err := ps.astSpecForObj(named.Obj())
+	if err != nil {
+		return nil, fmt.Errorf("failed to find decl for named type %s: %w", spec.name, err)
+	}
+	if doc := docForAstSpec(astSpec); doc != nil {
+		_, docComment := parsePragmaComment(doc.Text())
+		spec.doc = strings.TrimSpace(docComment)
+	}
+	spec.sourceMap = ps.sourceMap(astSpec)
+
+	// every field is a member, set to a non-nil value for a value of that type
+	for i := range t.NumFields() {
+		field := t.Field(i)
+		if !field.Exported() {
+			return nil, fmt.Errorf("union %s field %s must be exported", spec.name, field.Name())
+		}
+		if _, ok := field.Type().(*types.Pointer); !ok {
+			return nil, fmt.Errorf("union %s field %s must be a pointer to an object", spec.name, field.Name())
+		}
+		typeSpec, err := ps.parseGoTypeReference(field.Type(), nil, false)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse union %s field %s: %w", spec.name, field.Name(), err)
+		}
+		objSpec, ok := typeSpec.(*parsedObjectTypeReference)
+		if !ok || objSpec.isUnion {
+			return nil, fmt.Errorf("union %s field %s must be a pointer to an object", spec.name, field.Name())
+		}
+		for _, member := range spec.members {
+			if member.typeSpec.name == objSpec.name {
+				return nil, fmt.Errorf("union %s has more than one field of type %s", spec.name, objSpec.name)
+			}
+		}
+		spec.members = append(spec.members, &unionMemberSpec{
+			goName:   field.Name(),
+			typeSpec: objSpec,
+		})
+	}
+	if len(spec.members) == 0 {
+		return nil, fmt.Errorf("union %s must have at least one field", spec.name)
+	}
+
+	return spec, nil
+}
+
+type parsedUnionType struct {
+	name       string
+	moduleName string
+	doc        string
+	sourceMap  *sourceMap
+
+	members []*unionMemberSpec
+
+	goType *types.Struct
+}
+
+type unionMemberSpec struct {
+	// goName is the name of the field in the Go struct
+	goName   string
+	typeSpec *parsedObjectTypeReference
+}
+
+var _ NamedParsedType = &parsedUnionType{}
+
+func (spec *parsedUnionType) TypeDef(dag *dagger.Client) (*dagger.TypeDef, error) {
+	opts := dagger.TypeDefWithUnionOpts{}
+	if spec.doc != "" {
+		opts.Description = spec.doc
+	}
+	if spec.sourceMap != nil {
+		opts.SourceMap = spec.sourceMap.TypeDef(dag)
+	}
+	typeDefUnion := dag.TypeDef().WithUnion(spec.name, opts)
+
+	for _, member := range spec.members {
+		memberTypeDef, err := member.typeSpec.TypeDef(dag)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert member %s: %w", member.goName, err)
+		}
+		typeDefUnion = typeDefUnion.WithUnionMember(memberTypeDef)
+	}
+
+	return typeDefUnion, nil
+}
+
+func (spec *parsedUnionType) GoType() types.Type {
+	return spec.goType
+}
+
+func (spec *parsedUnionType) GoSubTypes() []types.Type {
+	var subTypes []types.Type
+	for _, member := range spec.members {
+		subTypes = append(subTypes, member.typeSpec.GoSubTypes()...)
+	}
+	return subTypes
+}
+
+func (spec *parsedUnionType) Name() string {
+	return spec.name
+}
+
+func (spec *parsedUnionType) ModuleName() string {
+	return spec.moduleName
+}
+
+// Extra generated code needed for the union implementation.
+func (spec *parsedUnionType) ImplementationCode() (*Statement, error) {
+	return Empty().
+		Add(spec.marshalJSONMethodCode()).Line().Line().
+		Add(spec.unmarshalJSONMethodCode()).Line().Line(), nil
+}
+
+/*
+Union values are serialized keyed by the name of their member type rather than
+by field name, so the engine can tell the members apart. e.g.:
+
+	func (r Artifact) MarshalJSON() ([]byte, error) {
+		var concrete struct {
+			Container *dagger.Container `json:"Container,omitempty"`
+			File      *dagger.File      `json:"File,omitempty"`
+		}
+		concrete.Container = r.Ctr
+		concrete.File = r.File
+		return json.Marshal(&concrete)
+	}
+*/
+func (spec *parsedUnionType) marshalJSONMethodCode() *Statement {
+	return Func().Params(Id("r").Id(spec.name)).
+		Id("MarshalJSON").
+		Params().
+		Params(Id("[]byte"), Id("error")).
+		BlockFunc(func(g *Group) {
+			g.Var().Id("concrete").Struct(spec.concreteFieldsCode()...)
+			for _, member := range spec.members {
+				g.Id("concrete").Dot(member.typeSpec.name).Op("=").Id("r").Dot(member.goName)
+			}
+			g.Return(Id("json").Dot("Marshal").Call(Op("&").Id("concrete")))
+		})
+}
+
+func (spec *parsedUnionType) unmarshalJSONMethodCode() *Statement {
+	return Func().Params(Id("r").Op("*").Id(spec.name)).
+		Id("UnmarshalJSON").
+		Params(Id("bs").Id("[]byte")).
+		Params(Id("error")).
+		BlockFunc(func(g *Group) {
+			g.Var().Id("concrete").Struct(spec.concreteFieldsCode()...)
+			g.Id("err").Op(":=").Id("json").Dot("Unmarshal").Call(Id("bs"), Op("&").Id("concrete"))
+			g.If(Id("err").Op("!=").Nil()).Block(Return(Id("err")))
+			for _, member := range spec.members {
+				g.Id("r").Dot(member.goName).Op("=").Id("concrete").Dot(member.typeSpec.name)
+			}
+			g.Return(Nil())
+		})
+}
+
+func (spec *parsedUnionType) concreteFieldsCode() []Code {
+	fields := make([]Code, 0, len(spec.members))
+	for _, member := range spec.members {
+		fields = append(fields, Id(member.typeSpec.name).
+			Op("*").Id(typeName(member.typeSpec)).
+			Tag(map[string]string{"json": member.typeSpec.name + ",omitempty"}))
+	}
+	return fields
+}
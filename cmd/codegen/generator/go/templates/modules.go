@@ -181,6 +181,16 @@ func (funcs goTemplateFuncs) moduleMainSrc() (string, error) {
 				}
 				implementationCode.Add(implCode).Line()
 
+				return nil
+			},
+			UnionVisitor: func(ps *parseState, named *types.Named, obj *types.TypeName, unionTypeSpec *parsedUnionType, strct *types.Struct) error {
+				// Add the union to the module
+				implCode, err := unionTypeSpec.ImplementationCode()
+				if err != nil {
+					return fmt.Errorf("failed to generate union code for %s: %w", obj.Name(), err)
+				}
+				implementationCode.Add(implCode).Line()
+
 				return nil
 			},
 		},
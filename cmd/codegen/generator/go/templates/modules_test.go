@@ -157,3 +157,25 @@ func TestParsePragmaComment(t *testing.T) {
 		})
 	}
 }
+
+func TestParseArgConstraints(t *testing.T) {
+	pragmas, _ := parsePragmaComment("The version to release\n+min=0\n+max=100\n+pattern=^v[0-9]+$\n+maxLength=8\n+nonEmpty\n+requiredPaths=[\"go.mod\"]")
+	constraints, err := parseArgConstraints(pragmas)
+	require.NoError(t, err)
+	require.Equal(t, argConstraints{
+		min:           "0",
+		max:           "100",
+		pattern:       "^v[0-9]+$",
+		maxLength:     8,
+		nonEmpty:      true,
+		requiredPaths: []string{"go.mod"},
+	}, constraints)
+
+	pragmas, _ = parsePragmaComment("+min=one")
+	_, err = parseArgConstraints(pragmas)
+	require.ErrorContains(t, err, "must be a number")
+
+	pragmas, _ = parsePragmaComment("+minLength=1.5")
+	_, err = parseArgConstraints(pragmas)
+	require.ErrorContains(t, err, "must be an integer")
+}
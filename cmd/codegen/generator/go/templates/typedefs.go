@@ -80,6 +80,15 @@ func (funcs goTemplateFuncs) TypeDefs() (string, error) {
 				module = module.WithEnum(typeDef)
 				return nil
 			},
+			UnionVisitor: func(ps *parseState, named *types.Named, obj *types.TypeName, unionTypeSpec *parsedUnionType, strct *types.Struct) error {
+				var err error
+				typeDef, err := unionTypeSpec.TypeDef(dag)
+				if err != nil {
+					return err
+				}
+				module = module.WithUnion(typeDef)
+				return nil
+			},
 		},
 	)
 	if err != nil {
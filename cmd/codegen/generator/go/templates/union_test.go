@@ -0,0 +1,37 @@
+package templates
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnion(t *testing.T) {
+	schemaJSON := `
+    {
+      "description": "A file or a directory.",
+      "kind": "UNION",
+      "name": "Artifact",
+      "possibleTypes": [
+        {
+          "kind": "OBJECT",
+          "name": "Directory"
+        },
+        {
+          "kind": "OBJECT",
+          "name": "File"
+        }
+      ]
+    }
+`
+
+	schema, union := loadSchemaFromTypeJSON(t, schemaJSON)
+	tmpl := parseTemplateFiles(t, schema, "_types/union.go.tmpl")
+	require.NotNil(t, tmpl)
+
+	got := renderTemplate(t, tmpl, union)
+
+	want := updateAndGetFixture(t, "testdata/union.golden", got)
+
+	require.Equal(t, want, got)
+}
@@ -12,12 +12,14 @@ type (
 	structVisitor func(*parseState, *types.Named, *types.TypeName, *parsedObjectType, *types.Struct) error
 	ifaceVisitor  func(*parseState, *types.Named, *types.TypeName, *parsedIfaceType, *types.Interface) error
 	enumVisitor   func(*parseState, *types.Named, *types.TypeName, *parsedEnumType, *types.Basic) error
+	unionVisitor  func(*parseState, *types.Named, *types.TypeName, *parsedUnionType, *types.Struct) error
 
 	visitorFuncs struct {
 		RootVisitor   rootVisitor
 		StructVisitor structVisitor
 		IfaceVisitor  ifaceVisitor
 		EnumVisitor   enumVisitor
+		UnionVisitor  unionVisitor
 	}
 )
 
@@ -25,7 +27,8 @@ func (v *visitorFuncs) isValid() bool {
 	return v.RootVisitor != nil &&
 		v.StructVisitor != nil &&
 		v.IfaceVisitor != nil &&
-		v.EnumVisitor != nil
+		v.EnumVisitor != nil &&
+		v.UnionVisitor != nil
 }
 
 var (
@@ -105,6 +108,23 @@ func (funcs goTemplateFuncs) visitTypes(
 			switch underlyingObj := named.Underlying().(type) {
 			case *types.Struct:
 				strct := underlyingObj
+				if ps.isUnionStruct(named) {
+					unionTypeSpec, err := ps.parseGoUnion(strct, named)
+					if err != nil {
+						return err
+					}
+
+					if err = visitorFuncs.UnionVisitor(ps, named, obj, unionTypeSpec, strct); err != nil {
+						return err
+					}
+
+					added[obj.Pkg().Path()+"/"+obj.Name()] = struct{}{}
+
+					// add the member types to the list of types to process
+					nextTps = append(nextTps, unionTypeSpec.GoSubTypes()...)
+					continue
+				}
+
 				objTypeSpec, err := ps.parseGoStruct(strct, named)
 				if err != nil {
 					return err
@@ -64,6 +64,8 @@ func (funcs typescriptTemplateFuncs) FuncMap() template.FuncMap {
 		"IsSelfChainable":           commonFunc.IsSelfChainable,
 		"IsListOfObject":            commonFunc.IsListOfObject,
 		"IsListOfEnum":              commonFunc.IsListOfEnum,
+		"IsListOfUnion":             commonFunc.IsListOfUnion,
+		"UnionMembers":              commonFunc.UnionMembers,
 		"GetArrayField":             commonFunc.GetArrayField,
 		"ToLowerCase":               commonFunc.ToLowerCase,
 		"ToUpperCase":               commonFunc.ToUpperCase,
@@ -0,0 +1,112 @@
+
+/**
+ * A file or a note.
+ */
+export class Artifact extends BaseClient {
+  private readonly _typename?: string = undefined
+
+  /**
+   * Constructor is used for internal usage only, do not create object from it.
+   */
+   constructor(
+    ctx?: Context,
+     _typename?: string,
+   ) {
+     super(ctx)
+
+     this._typename = _typename
+   }
+
+  /**
+   * The name of the member type of the value.
+   */
+  typename = async (): Promise<string> => {
+    if (this._typename) {
+      return this._typename
+    }
+
+    const ctx = this._ctx.select(
+      "__typename",
+    )
+
+    const response: Awaited<string> = await ctx.execute()
+
+    return response
+  }
+
+  /**
+   * Return the value as a File, or null if it has a different type.
+   */
+  asFile = async (): Promise<File | null> => {
+    if ((await this.typename()) !== "File") {
+      return null
+    }
+
+    return new File(this._ctx.select("... on File"))
+  }
+
+  /**
+   * Return the value as a Note, or null if it has a different type.
+   */
+  asNote = async (): Promise<Note | null> => {
+    if ((await this.typename()) !== "Note") {
+      return null
+    }
+
+    return new Note(this._ctx.select("... on Note"))
+  }
+}
+
+
+export class Store extends BaseClient {
+
+  /**
+   * Constructor is used for internal usage only, do not create object from it.
+   */
+   constructor(
+    ctx?: Context,
+   ) {
+     super(ctx)
+
+   }
+
+  /**
+   * The latest artifact.
+   */
+  artifact = (): Artifact => {
+
+    const ctx = this._ctx.select(
+      "artifact",
+    )
+    return new Artifact(ctx)
+  }
+
+  /**
+   * All the artifacts.
+   */
+  artifacts = async (): Promise<Artifact[]> => {
+    type artifacts = {
+      __typename: string
+      file?: FileID
+      note?: NoteID
+    }
+
+    const ctx = this._ctx.select(
+      "artifacts",
+    ).select("__typename ... on File { file: id } ... on Note { note: id }")
+
+    const response: Awaited<artifacts[]> = await ctx.execute()
+
+    
+    return response.map((r) => {
+      switch (r.__typename) {
+        case "File":
+          return new Artifact(ctx.copy().select("loadFileFromID", { id: r.file }), r.__typename)
+        case "Note":
+          return new Artifact(ctx.copy().select("loadNoteFromID", { id: r.note }), r.__typename)
+        default:
+          return new Artifact(ctx.copy(), r.__typename)
+      }
+    })
+  }
+}
@@ -0,0 +1,70 @@
+package test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dagger/dagger/cmd/codegen/generator"
+)
+
+func TestUnion(t *testing.T) {
+	tmpl := templateHelper(t)
+
+	objects := objectsInit(t, unionJSON)
+	generator.SetSchema(&objects)
+	t.Cleanup(func() { generator.SetSchema(nil) })
+
+	var b bytes.Buffer
+	err := tmpl.ExecuteTemplate(&b, "objects", objects)
+
+	want := updateAndGetFixtures(t, "testdata/union_test_want.ts", b.String())
+	require.NoError(t, err)
+	require.Equal(t, want, b.String())
+}
+
+var unionJSON = `
+[
+  {
+    "kind": "UNION",
+    "name": "Artifact",
+    "description": "A file or a note.",
+    "possibleTypes": [
+      {"kind": "OBJECT", "name": "File"},
+      {"kind": "OBJECT", "name": "Note"}
+    ]
+  },
+  {
+    "kind": "OBJECT",
+    "name": "Store",
+    "description": "",
+    "fields": [
+      {
+        "name": "artifact",
+        "description": "The latest artifact.",
+        "args": [],
+        "type": {
+          "kind": "NON_NULL",
+          "ofType": {"kind": "UNION", "name": "Artifact"}
+        }
+      },
+      {
+        "name": "artifacts",
+        "description": "All the artifacts.",
+        "args": [],
+        "type": {
+          "kind": "NON_NULL",
+          "ofType": {
+            "kind": "LIST",
+            "ofType": {
+              "kind": "NON_NULL",
+              "ofType": {"kind": "UNION", "name": "Artifact"}
+            }
+          }
+        }
+      }
+    ]
+  }
+]
+`
@@ -110,7 +110,9 @@ type Type struct {
 	InputFields []InputValue `json:"inputFields,omitempty"`
 	EnumValues  []EnumValue  `json:"enumValues,omitempty"`
 	Interfaces  []*Type      `json:"interfaces"`
-	Directives  Directives   `json:"directives"`
+	// PossibleTypes are the member object types of a union.
+	PossibleTypes []*TypeRef `json:"possibleTypes,omitempty"`
+	Directives    Directives `json:"directives"`
 }
 
 // Remove all occurrences of a type from the schema, including
@@ -153,9 +155,18 @@ func (t *Type) ScrubType(typeName string) bool {
 	}
 	t.EnumValues = filteredEnumValues
 
+	filteredPossibleTypes := make([]*TypeRef, 0, len(t.PossibleTypes))
+	for _, p := range t.PossibleTypes {
+		if p.Name == typeName {
+			continue
+		}
+		filteredPossibleTypes = append(filteredPossibleTypes, p)
+	}
+	t.PossibleTypes = filteredPossibleTypes
+
 	// check if we removed everything from it, in which case it should
 	// be removed itself
-	isEmpty := len(t.Fields) == 0 && len(t.InputFields) == 0 && len(t.EnumValues) == 0
+	isEmpty := len(t.Fields) == 0 && len(t.InputFields) == 0 && len(t.EnumValues) == 0 && len(t.PossibleTypes) == 0
 	return t.Name == typeName || isEmpty
 }
 
@@ -231,6 +242,14 @@ func (r TypeRef) IsObject() bool {
 	return false
 }
 
+func (r TypeRef) IsUnion() bool {
+	ref := r
+	if r.Kind == TypeKindNonNull {
+		ref = *ref.OfType
+	}
+	return ref.Kind == TypeKindUnion
+}
+
 func (r TypeRef) IsList() bool {
 	ref := r
 	if r.Kind == TypeKindNonNull {
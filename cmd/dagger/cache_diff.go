@@ -0,0 +1,92 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dagger/dagger/dagql/call"
+)
+
+var debugCmd = &cobra.Command{
+	Use:   "debug",
+	Short: "Debug the Dagger Engine and its cache",
+}
+
+var cacheDiffCmd = &cobra.Command{
+	Use:   "cache-diff [options] <call-a> <call-b>",
+	Short: "Explain why two calls have different cache keys",
+	Long: `Explain why two calls have different cache keys.
+
+Each call is an object ID, as returned by the "id" field of any object. Use "-"
+to read a call from stdin, or "@path" to read it from a file.
+
+The output lists the root causes of the difference: the argument, environment
+variable, file path or content digest that changed, rather than every call
+that depends on it.`,
+	Example: `  dagger debug cache-diff "$(cat before.id)" "$(cat after.id)"
+  dagger debug cache-diff @before.id @after.id`,
+	Args:         cobra.ExactArgs(2),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		a, err := readCallID(cmd.InOrStdin(), args[0])
+		if err != nil {
+			return fmt.Errorf("call A: %w", err)
+		}
+		b, err := readCallID(cmd.InOrStdin(), args[1])
+		if err != nil {
+			return fmt.Errorf("call B: %w", err)
+		}
+		printCallDiff(cmd.OutOrStdout(), call.Diff(a, b))
+		return nil
+	},
+}
+
+func init() {
+	debugCmd.AddCommand(cacheDiffCmd)
+}
+
+func readCallID(stdin io.Reader, arg string) (*call.ID, error) {
+	enc := arg
+	switch {
+	case arg == "-":
+		bs, err := io.ReadAll(stdin)
+		if err != nil {
+			return nil, err
+		}
+		enc = string(bs)
+	case strings.HasPrefix(arg, "@"):
+		bs, err := os.ReadFile(strings.TrimPrefix(arg, "@"))
+		if err != nil {
+			return nil, err
+		}
+		enc = string(bs)
+	}
+	var id call.ID
+	if err := id.Decode(strings.TrimSpace(enc)); err != nil {
+		return nil, fmt.Errorf("decode ID: %w", err)
+	}
+	return &id, nil
+}
+
+func printCallDiff(w io.Writer, diffs []call.Difference) {
+	if len(diffs) == 0 {
+		fmt.Fprintln(w, "calls are identical")
+		return
+	}
+	var lastCall string
+	for _, diff := range diffs {
+		if diff.Call != lastCall {
+			fmt.Fprintln(w, diff.Call)
+			lastCall = diff.Call
+		}
+		what := string(diff.Kind)
+		if diff.Arg != "" {
+			what += " " + diff.Arg
+		}
+		fmt.Fprintf(w, "  %s:\n    - %s\n    + %s\n", what, diff.A, diff.B)
+	}
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vektah/gqlparser/v2/ast"
+
+	"github.com/dagger/dagger/dagql/call"
+)
+
+func TestCacheDiff(t *testing.T) {
+	ctrType := ast.NonNullNamedType("Container", nil)
+	withEnv := func(value string) string {
+		id := call.New().
+			Append(ctrType, "container").
+			Append(ctrType, "withEnvVariable", call.WithArgs(
+				call.NewArgument("name", call.NewLiteralString("FOO"), false),
+				call.NewArgument("value", call.NewLiteralString(value), false),
+			))
+		enc, err := id.Encode()
+		require.NoError(t, err)
+		return enc
+	}
+
+	a, err := readCallID(nil, withEnv("1"))
+	require.NoError(t, err)
+	b, err := readCallID(strings.NewReader(withEnv("2")+"\n"), "-")
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	printCallDiff(&out, call.Diff(a, b))
+	require.Equal(t, `Container.withEnvVariable(name: "FOO", value: "1")
+  argument value:
+    - "1"
+    + "2"
+`, out.String())
+
+	out.Reset()
+	printCallDiff(&out, call.Diff(a, a))
+	require.Equal(t, "calls are identical\n", out.String())
+
+	_, err = readCallID(nil, "not an id")
+	require.Error(t, err)
+}
@@ -43,6 +43,14 @@ var callModCmd = &FuncCommand{
 	},
 }
 
+// breakOn holds the patterns of the `--break-on` flag.
+var breakOn []string
+
+func init() {
+	callModCmd.Command().PersistentFlags().BoolVar(&fileWatch, "watch", false, "Watch the module and the paths used by its defaultPath arguments, and call again when they change")
+	callModCmd.Command().PersistentFlags().StringArrayVar(&breakOn, "break-on", nil, "Pause at calls matching a pattern, like withExec or Container.withDirectory")
+}
+
 var funcListCmd = &cobra.Command{
 	Use:   "functions [options] [function]...",
 	Short: `List available functions`,
@@ -87,63 +87,77 @@ func withEngine(
 			return nil
 		})
 
-		if debugFlag {
-			params.LogLevel = slog.LevelDebug
+		// Connect to and run with the engine
+		sess, err := connectEngine(ctx, params)
+		if err != nil {
+			return cleanup.Run, err
 		}
+		cleanup.Add("close dagger session", sess.Close)
 
-		if useCloudEngine {
-			params.RunnerHost = engine.DefaultCloudRunnerHost
-		} else if params.RunnerHost == "" {
-			params.RunnerHost = RunnerHost
-		}
+		return cleanup.Run, fn(ctx, sess)
+	})
+}
 
-		if RunnerImageLoader != "" {
-			backend, err := imageload.GetBackend(RunnerImageLoader)
-			if err != nil {
-				return cleanup.Run, err
-			}
-			params.ImageLoaderBackend = backend
+// connectEngine starts a new session with the engine, configured from the
+// global flags.
+func connectEngine(ctx context.Context, params client.Params) (*client.Client, error) {
+	if debugFlag {
+		params.LogLevel = slog.LevelDebug
+	}
+
+	if useCloudEngine {
+		params.RunnerHost = engine.DefaultCloudRunnerHost
+	} else if params.RunnerHost == "" {
+		params.RunnerHost = RunnerHost
+	}
+
+	if RunnerImageLoader != "" {
+		backend, err := imageload.GetBackend(RunnerImageLoader)
+		if err != nil {
+			return nil, err
 		}
+		params.ImageLoaderBackend = backend
+	}
 
-		params.DisableHostRW = disableHostRW
-		params.AllowedLLMModules = allowedLLMModules
+	params.DisableHostRW = disableHostRW
+	params.AllowedLLMModules = allowedLLMModules
 
-		params.CloudURLCallback = Frontend.SetCloudURL
+	params.CloudURLCallback = Frontend.SetCloudURL
 
-		params.EngineTrace = telemetry.SpanForwarder{
-			Processors: telemetry.SpanProcessors,
-		}
-		params.EngineLogs = telemetry.LogForwarder{
-			Processors: telemetry.LogProcessors,
-		}
-		params.EngineMetrics = telemetry.MetricExporters
+	params.EngineTrace = telemetry.SpanForwarder{
+		Processors: telemetry.SpanProcessors,
+	}
+	params.EngineLogs = telemetry.LogForwarder{
+		Processors: telemetry.LogProcessors,
+	}
+	params.EngineMetrics = telemetry.MetricExporters
 
-		params.WithTerminal = withTerminal
+	params.Command = rootSpanName()
 
-		params.Interactive = interactive
-		params.InteractiveCommand = interactiveCommandParsed
+	params.WithTerminal = withTerminal
 
-		if hasTTY {
-			params.PromptHandler = Frontend
-		}
+	params.Interactive = interactive
+	params.InteractiveCommand = interactiveCommandParsed
+	params.BreakOn = breakOn
 
-		ca, err := auth.GetCloudAuth(ctx)
-		if err != nil {
-			return cleanup.Run, err
-		}
-		params.CloudAuth = ca
+	if hasTTY {
+		params.PromptHandler = Frontend
+	}
 
-		// Connect to and run with the engine
-		sess, err := client.Connect(ctx, params)
-		if err != nil {
-			return cleanup.Run, err
-		}
-		cleanup.Add("close dagger session", sess.Close)
+	ca, err := auth.GetCloudAuth(ctx)
+	if err != nil {
+		return nil, err
+	}
+	params.CloudAuth = ca
 
-		Frontend.SetClient(sess.Dagger())
+	sess, err := client.Connect(ctx, params)
+	if err != nil {
+		return nil, err
+	}
 
-		return cleanup.Run, fn(ctx, sess)
-	})
+	Frontend.SetClient(sess.Dagger())
+
+	return sess, nil
 }
 
 func initEngineTelemetry(ctx context.Context) (context.Context, func(error)) {
@@ -168,11 +182,7 @@ func initEngineTelemetry(ctx context.Context) (context.Context, func(error)) {
 	// If you pass credentials in plaintext, yes, they will be leaked; don't do
 	// that, since they will also be leaked in various other places (like the
 	// process tree). Use Secret arguments instead.
-	name := spanName(os.Args)
-	if os.Getenv(TraceNameEnv) != "" {
-		name = os.Getenv(TraceNameEnv)
-	}
-	ctx, span := Tracer().Start(ctx, name)
+	ctx, span := Tracer().Start(ctx, rootSpanName())
 
 	// Set up global slog to log to the primary span output.
 	slog.SetDefault(slog.SpanLogger(ctx, InstrumentationLibrary))
@@ -194,3 +204,12 @@ func initEngineTelemetry(ctx context.Context) (context.Context, func(error)) {
 		telemetry.Close()
 	}
 }
+
+// rootSpanName returns a short name for the command being run, used as the
+// name of the root span and to describe the session in `dagger watch`.
+func rootSpanName() string {
+	if name := os.Getenv(TraceNameEnv); name != "" {
+		return name
+	}
+	return spanName(os.Args)
+}
@@ -37,22 +37,23 @@ var (
 )
 
 const (
-	Directory     string = "Directory"
-	Changeset     string = "Changeset"
-	Container     string = "Container"
-	File          string = "File"
-	Secret        string = "Secret"
-	Service       string = "Service"
-	PortForward   string = "PortForward"
-	CacheVolume   string = "CacheVolume"
-	LLM           string = "LLM"
-	ModuleSource  string = "ModuleSource"
-	Module        string = "Module"
-	Platform      string = "Platform"
-	BuildArg      string = "BuildArg"
-	Socket        string = "Socket"
-	GitRepository string = "GitRepository"
-	GitRef        string = "GitRef"
+	Directory           string = "Directory"
+	Changeset           string = "Changeset"
+	Container           string = "Container"
+	ContainerComparison string = "ContainerComparison"
+	File                string = "File"
+	Secret              string = "Secret"
+	Service             string = "Service"
+	PortForward         string = "PortForward"
+	CacheVolume         string = "CacheVolume"
+	LLM                 string = "LLM"
+	ModuleSource        string = "ModuleSource"
+	Module              string = "Module"
+	Platform            string = "Platform"
+	BuildArg            string = "BuildArg"
+	Socket              string = "Socket"
+	GitRepository       string = "GitRepository"
+	GitRef              string = "GitRef"
 )
 
 var (
@@ -179,6 +180,10 @@ func (fc *FuncCommand) Command() *cobra.Command {
 					// withEngine changes the context.
 					c.SetContext(ctx)
 
+					if fileWatch {
+						return fc.watch(c, a)
+					}
+
 					if err := fc.execute(c, a); err != nil {
 						// We've already handled printing the error in `fc.execute`
 						// because we want to show the usage for the right sub-command.
@@ -561,8 +566,6 @@ func (fc *FuncCommand) selectFunc(fn *modFunction, cmd *cobra.Command) error {
 // RunE is the final command in the function chain, where the API request is made.
 func (fc *FuncCommand) RunE(ctx context.Context, fn *modFunction) func(*cobra.Command, []string) error {
 	return func(cmd *cobra.Command, args []string) error {
-		q := handleObjectLeaf(fc.q, fn.ReturnType)
-
 		// Silence usage from this point on as errors don't likely come
 		// from wrong CLI usage.
 		fc.showUsage = false
@@ -570,6 +573,16 @@ func (fc *FuncCommand) RunE(ctx context.Context, fn *modFunction) func(*cobra.Co
 		o := cmd.OutOrStdout()
 		e := cmd.ErrOrStderr()
 
+		if union := fn.ReturnType.AsUnion; union != nil {
+			member, response, err := fc.selectUnionMember(ctx, union)
+			if err != nil {
+				return err
+			}
+			return handleResponse(ctx, fc.c.Dagger(), member, response, o, e, autoApply)
+		}
+
+		q := handleObjectLeaf(fc.q, fn.ReturnType)
+
 		// It's possible that a chain ending in an object doesn't have anything
 		// else to sub-select. In that case `q` will be nil to signal that we
 		// just want to return the object's name, without making an API request.
@@ -587,12 +600,67 @@ func (fc *FuncCommand) RunE(ctx context.Context, fn *modFunction) func(*cobra.Co
 	}
 }
 
+// selectUnionMember sub-selects the value of a union as each of its member
+// types with inline fragments, so the result is handled the same as when
+// returning the member type directly. Only the fragment on the type of the
+// value is evaluated, in the same query as the function call, and the member
+// type is returned along with the response for it.
+func (fc *FuncCommand) selectUnionMember(ctx context.Context, union *modUnion) (*modTypeDef, any, error) {
+	fields := []string{"__typename"}
+	leaves := make(map[string]*querybuilder.Selection, len(union.Members))
+	for _, member := range union.Members {
+		fc.mod.LoadTypeDef(member)
+		typeName := gqlObjectName(member.AsObject.Name)
+		// each member is selected under its own alias, since the same
+		// field can have a different type on each member
+		leaf := selectObjectLeaf(querybuilder.Query().InlineFragment(typeName), member, gqlFieldName(typeName))
+		fragment, err := leaf.Build(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		// keep the fragment only, without the enclosing query
+		fragment = strings.TrimSuffix(strings.TrimPrefix(fragment, "query{"), "}")
+		fields = append(fields, fragment)
+		leaves[typeName] = leaf
+	}
+
+	var response any
+	if err := makeRequest(ctx, fc.q.SelectMultiple(fields...), &response); err != nil {
+		return nil, nil, err
+	}
+	value, ok := response.(map[string]any)
+	if !ok {
+		return nil, nil, fmt.Errorf("unexpected response %T: %+v", response, response)
+	}
+	typeName, _ := value["__typename"].(string)
+	member := union.GetMember(typeName)
+	if member == nil {
+		return nil, nil, fmt.Errorf("type %q is not a member of union %q", typeName, union.Name)
+	}
+	var memberResponse any
+	if err := leaves[gqlObjectName(typeName)].Bind(&memberResponse).Unpack(value); err != nil {
+		return nil, nil, err
+	}
+	return member, memberResponse, nil
+}
+
 func handleObjectLeaf(q *querybuilder.Selection, typeDef *modTypeDef) *querybuilder.Selection {
+	return selectObjectLeaf(q, typeDef, "")
+}
+
+// selectObjectLeaf is like handleObjectLeaf, but selects the leaf under the
+// given alias, if any.
+func selectObjectLeaf(q *querybuilder.Selection, typeDef *modTypeDef, alias string) *querybuilder.Selection {
 	obj := typeDef.AsFunctionProvider()
 	if obj == nil {
 		return q
 	}
 
+	// Print a readable report instead of the ID of container comparisons.
+	if typeDef.Name() == ContainerComparison {
+		return q.SelectWithAlias(alias, "summary")
+	}
+
 	// Use duck typing to detect supported functions.
 	var hasSync bool
 	var hasExport bool
@@ -618,7 +686,7 @@ func handleObjectLeaf(q *querybuilder.Selection, typeDef *modTypeDef) *querybuil
 	// on a core type that supports it.
 	// TODO: Replace with interface when possible.
 	if outputPath != "" && hasExport {
-		q = q.Select("export").Arg("path", outputPath)
+		q = q.SelectWithAlias(alias, "export").Arg("path", outputPath)
 		if hasExportAllowParentDirPath {
 			q = q.Arg("allowParentDirPath", true)
 		}
@@ -627,10 +695,13 @@ func handleObjectLeaf(q *querybuilder.Selection, typeDef *modTypeDef) *querybuil
 
 	// TODO: Replace with interface when possible.
 	if hasSync {
-		return q.SelectWithAlias("id", "sync")
+		if alias == "" {
+			alias = "id"
+		}
+		return q.SelectWithAlias(alias, "sync")
 	}
 
-	return q.Select("id")
+	return q.SelectWithAlias(alias, "id")
 }
 
 func makeRequest(ctx context.Context, q *querybuilder.Selection, response any) error {
@@ -656,6 +727,9 @@ func handleResponse(ctx context.Context, dag *dagger.Client, returnType *modType
 	switch returnType.Name() {
 	case LLM:
 		return startInteractivePromptMode(ctx, dag, response)
+	case ContainerComparison:
+		// The summary was selected instead of the ID in handleObjectLeaf.
+		returnType = &modTypeDef{Kind: dagger.TypeDefKindStringKind}
 	case Changeset:
 		// Handle the `export` convenience, i.e, -o,--output flag.
 		if outputPath == "" {
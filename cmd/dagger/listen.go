@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"net"
 	"net/http"
@@ -15,6 +16,8 @@ import (
 	"golang.org/x/net/http2/h2c"
 
 	"dagger.io/dagger"
+	"github.com/dagger/dagger/dagql/introspection"
+	"github.com/dagger/dagger/engine"
 	"github.com/dagger/dagger/engine/client"
 )
 
@@ -22,6 +25,14 @@ var (
 	listenAddress string
 	disableHostRW bool
 	allowCORS     bool
+
+	listenTokenFile   string
+	listenTLSCert     string
+	listenTLSKey      string
+	listenTLSClientCA string
+	listenReadOnly    bool
+	listenAllowFields []string
+	listenExplorer    bool
 )
 
 var listenCmd = &cobra.Command{
@@ -30,25 +41,93 @@ var listenCmd = &cobra.Command{
 	RunE:    optionalModCmdWrapper(Listen, os.Getenv("DAGGER_SESSION_TOKEN")),
 	Hidden:  true,
 	Short:   "Starts the engine server",
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if listenReadOnly {
+			// the host is outside of the engine too
+			disableHostRW = true
+		}
+		return nil
+	},
 }
 
 func init() {
 	listenCmd.Flags().StringVarP(&listenAddress, "listen", "", "127.0.0.1:8080", "Listen on network address ADDR")
 	listenCmd.Flags().BoolVar(&disableHostRW, "disable-host-read-write", false, "disable host read/write access")
 	listenCmd.Flags().BoolVar(&allowCORS, "allow-cors", false, "allow Cross-Origin Resource Sharing (CORS) requests")
+	listenCmd.Flags().StringVar(&listenTokenFile, "token-file", "", "accept the bearer tokens listed in FILE (one per line)")
+	listenCmd.Flags().StringVar(&listenTLSCert, "tls-cert", "", "serve over TLS with the certificate in FILE")
+	listenCmd.Flags().StringVar(&listenTLSKey, "tls-key", "", "serve over TLS with the private key in FILE")
+	listenCmd.Flags().StringVar(&listenTLSClientCA, "tls-client-ca", "", "require client certificates signed by a CA in FILE (mTLS)")
+	listenCmd.Flags().BoolVar(&listenReadOnly, "read-only", false, "deny fields with side effects outside of the engine, e.g. host, export and publish (implies --disable-host-read-write)")
+	listenCmd.Flags().StringSliceVar(&listenAllowFields, "allow-field", nil, "only allow selecting the given fields, as Type.field or Type.* (repeatable)")
+	listenCmd.Flags().BoolVar(&listenExplorer, "explorer", false, "serve an API explorer page at "+explorerEndpoint)
 }
 
 func Listen(ctx context.Context, engineClient *client.Client, _ *dagger.Module, cmd *cobra.Command, _ []string) error {
 	stderr := cmd.OutOrStderr()
 
+	if (listenTLSCert == "") != (listenTLSKey == "") {
+		return fmt.Errorf("--tls-cert and --tls-key must be set together")
+	}
+	if listenTLSClientCA != "" && listenTLSCert == "" {
+		return fmt.Errorf("--tls-client-ca requires --tls-cert and --tls-key")
+	}
+
+	var handler http.Handler = engineClient
+
+	if listenReadOnly || len(listenAllowFields) > 0 {
+		var schema introspection.Response
+		if err := engineClient.Dagger().Do(ctx, &dagger.Request{
+			Query: introspection.Query,
+		}, &dagger.Response{
+			Data: &schema,
+		}); err != nil {
+			return fmt.Errorf("introspection query: %w", err)
+		}
+		policy, err := newListenFieldPolicy(listenReadOnly, listenAllowFields, schema.Schema)
+		if err != nil {
+			return err
+		}
+		handler = policy.Handler(handler)
+	}
+
+	// the session token is always accepted, as a bearer token too
+	tokens := []string{engineClient.SecretToken}
+	if listenTokenFile != "" {
+		fileTokens, err := loadListenTokens(listenTokenFile)
+		if err != nil {
+			return err
+		}
+		tokens = append(tokens, fileTokens...)
+	}
+	handler = bearerTokenAuth(tokens, engineClient.SecretToken, handler)
+
+	if listenExplorer {
+		explorer, err := explorerHandler()
+		if err != nil {
+			return fmt.Errorf("explorer: %w", err)
+		}
+		mux := http.NewServeMux()
+		mux.Handle("GET "+explorerEndpoint, explorer)
+		mux.Handle("/", handler)
+		handler = mux
+	}
+
+	var tlsConfig *tls.Config
+	if listenTLSCert != "" {
+		var err error
+		tlsConfig, err = listenTLSConfig(listenTLSCert, listenTLSKey, listenTLSClientCA)
+		if err != nil {
+			return err
+		}
+	}
+
 	sessionL, err := net.Listen("tcp", listenAddress)
 	if err != nil {
 		return fmt.Errorf("session listen: %w", err)
 	}
 	defer sessionL.Close()
 
-	var handler http.Handler = engineClient
-
 	if allowCORS {
 		handler = cors.AllowAll().Handler(handler)
 	}
@@ -64,6 +143,7 @@ func Listen(ctx context.Context, engineClient *client.Client, _ *dagger.Module,
 		Handler: handler,
 		// Gosec G112: prevent slowloris attacks
 		ReadHeaderTimeout: 10 * time.Second,
+		TLSConfig:         tlsConfig,
 		BaseContext: func(_ net.Listener) context.Context {
 			return ctx
 		},
@@ -78,7 +158,18 @@ func Listen(ctx context.Context, engineClient *client.Client, _ *dagger.Module,
 		srv.Shutdown(context.Background())
 	}()
 
-	fmt.Fprintf(stderr, "==> server listening on http://%s/query\n", listenAddress)
+	scheme := "http"
+	if tlsConfig != nil {
+		scheme = "https"
+	}
+	fmt.Fprintf(stderr, "==> server listening on %s://%s%s\n", scheme, listenAddress, engine.QueryEndpoint)
+	if listenExplorer {
+		fmt.Fprintf(stderr, "==> explorer available at %s://%s%s\n", scheme, listenAddress, explorerEndpoint)
+	}
 
+	if tlsConfig != nil {
+		// certificates are already loaded in the TLS config
+		return srv.ServeTLS(sessionL, "", "")
+	}
 	return srv.Serve(sessionL)
 }
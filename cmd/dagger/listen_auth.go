@@ -0,0 +1,447 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/subtle"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/parser"
+
+	"github.com/dagger/dagger/dagql/call"
+	"github.com/dagger/dagger/dagql/introspection"
+	"github.com/dagger/dagger/engine"
+)
+
+// maxListenQuerySize is the largest query body accepted when fields are
+// restricted, since the whole body has to be buffered to inspect it.
+const maxListenQuerySize = 16 << 20
+
+// readOnlyDeniedFields are the fields that have side effects outside of the
+// engine, which are denied in read-only mode regardless of their type.
+var readOnlyDeniedFields = map[string]bool{
+	"host":        true,
+	"export":      true,
+	"exportImage": true,
+	"publish":     true,
+	"up":          true,
+	"start":       true,
+	"stop":        true,
+	"terminal":    true,
+	"serve":       true,
+	"prune":       true,
+	"pin":         true,
+	"unpin":       true,
+}
+
+// loadListenTokens reads the bearer tokens accepted by `dagger listen` from a
+// file, one per line. Blank lines and lines starting with # are ignored.
+func loadListenTokens(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open token file: %w", err)
+	}
+	defer f.Close()
+
+	var tokens []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		token := strings.TrimSpace(scanner.Text())
+		if token == "" || strings.HasPrefix(token, "#") {
+			continue
+		}
+		tokens = append(tokens, token)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read token file: %w", err)
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("no tokens found in %s", path)
+	}
+	return tokens, nil
+}
+
+// bearerTokenAuth lets requests authenticate with one of the given bearer
+// tokens, or with a verified client certificate, as an alternative to basic
+// auth with the session token, which is passed through to the engine client
+// as-is.
+func bearerTokenAuth(tokens []string, sessionToken string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+			if !validListenToken(tokens, token) {
+				w.Header().Set("WWW-Authenticate", `Bearer realm="Access to the Dagger API"`)
+				http.Error(w, "invalid token", http.StatusUnauthorized)
+				return
+			}
+			// the engine client only knows about the session token
+			r.SetBasicAuth(sessionToken, "")
+		} else if r.TLS != nil && len(r.TLS.VerifiedChains) > 0 {
+			// the certificate was signed by one of the --tls-client-ca CAs
+			r.SetBasicAuth(sessionToken, "")
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func validListenToken(tokens []string, token string) bool {
+	var valid bool
+	for _, t := range tokens {
+		// check every token, so the time taken doesn't leak which one matched
+		if subtle.ConstantTimeCompare([]byte(t), []byte(token)) == 1 {
+			valid = true
+		}
+	}
+	return valid
+}
+
+// listenTLSConfig loads the server certificate, and if clientCAPath is set,
+// requires clients to present a certificate signed by one of its CAs.
+func listenTLSConfig(certPath, keyPath, clientCAPath string) (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(certPath, keyPath)
+	if err != nil {
+		return nil, fmt.Errorf("load TLS certificate: %w", err)
+	}
+	cfg := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+	if clientCAPath != "" {
+		pem, err := os.ReadFile(clientCAPath)
+		if err != nil {
+			return nil, fmt.Errorf("read client CA: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %s", clientCAPath)
+		}
+		cfg.ClientCAs = pool
+		cfg.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return cfg, nil
+}
+
+// listenFieldPolicy restricts the fields that `dagger listen` clients may
+// select.
+type listenFieldPolicy struct {
+	// ReadOnly denies fields with side effects outside of the engine.
+	ReadOnly bool
+
+	// Allowed is the set of allowed fields, as "Type.field" or "Type.*". If
+	// nil, all fields are allowed.
+	Allowed map[string]bool
+
+	// Schema is used to find the type of each selected field. Only needed
+	// when Allowed is set.
+	Schema *introspection.ResponseSchema
+}
+
+func newListenFieldPolicy(readOnly bool, allowed []string, schema *introspection.ResponseSchema) (*listenFieldPolicy, error) {
+	policy := &listenFieldPolicy{
+		ReadOnly: readOnly,
+		Schema:   schema,
+	}
+	if len(allowed) > 0 {
+		policy.Allowed = make(map[string]bool, len(allowed))
+		for _, field := range allowed {
+			typeName, fieldName, ok := strings.Cut(field, ".")
+			if !ok || typeName == "" || fieldName == "" {
+				return nil, fmt.Errorf("invalid allowed field %q: must be Type.field or Type.*", field)
+			}
+			policy.Allowed[field] = true
+		}
+	}
+	return policy, nil
+}
+
+// Handler only lets through requests whose queries satisfy the policy.
+//
+// Only GraphQL queries and telemetry subscriptions are let through, since
+// other endpoints (e.g. shutting down the session) can't be inspected.
+func (p *listenFieldPolicy) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Header.Get("Upgrade") != "":
+			http.Error(w, "connection upgrades are not allowed", http.StatusForbidden)
+			return
+		case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/v1/"):
+			next.ServeHTTP(w, r)
+			return
+		case r.URL.Path != engine.QueryEndpoint:
+			http.Error(w, "only "+engine.QueryEndpoint+" is allowed", http.StatusForbidden)
+			return
+		}
+
+		var query string
+		var variables map[string]any
+		switch r.Method {
+		case http.MethodGet:
+			query = r.URL.Query().Get("query")
+			if vars := r.URL.Query().Get("variables"); vars != "" {
+				if err := json.Unmarshal([]byte(vars), &variables); err != nil {
+					http.Error(w, fmt.Sprintf("decode variables: %s", err), http.StatusBadRequest)
+					return
+				}
+			}
+		case http.MethodPost:
+			body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxListenQuerySize))
+			if err != nil {
+				http.Error(w, fmt.Sprintf("read request: %s", err), http.StatusBadRequest)
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+			var req struct {
+				Query     string         `json:"query"`
+				Variables map[string]any `json:"variables"`
+			}
+			if err := json.Unmarshal(body, &req); err != nil {
+				http.Error(w, fmt.Sprintf("decode request: %s", err), http.StatusBadRequest)
+				return
+			}
+			query = req.Query
+			variables = req.Variables
+		default:
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		if err := p.Check(query, variables); err != nil {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Check returns an error if the query selects a field denied by the policy,
+// or passes an ID of a call to such a field, either inline or as a variable.
+//
+// IDs encode the calls that constructed them, which the engine replays as
+// needed, so e.g. `loadContainerFromID` would otherwise let clients run calls
+// they can't select.
+func (p *listenFieldPolicy) Check(query string, variables map[string]any) error {
+	doc, err := parser.ParseQuery(&ast.Source{Input: query})
+	if err != nil {
+		return fmt.Errorf("parse query: %w", err)
+	}
+	checked := map[string]bool{}
+	for _, op := range doc.Operations {
+		if op.Operation != ast.Query {
+			return fmt.Errorf("%s operations are not allowed", op.Operation)
+		}
+		if err := p.checkSelections(doc, p.queryType(), op.SelectionSet, checked); err != nil {
+			return err
+		}
+	}
+	return p.checkValues(variables, map[string]bool{})
+}
+
+func (p *listenFieldPolicy) queryType() string {
+	if p.Schema != nil {
+		return p.Schema.QueryType.Name
+	}
+	return "Query"
+}
+
+// checkField returns an error if the policy denies the field.
+func (p *listenFieldPolicy) checkField(typeName, fieldName string) error {
+	if p.ReadOnly && readOnlyDeniedFields[fieldName] {
+		return fmt.Errorf("field %s is not allowed in read-only mode", fieldRef(typeName, fieldName))
+	}
+	if p.Allowed != nil && !p.Allowed[typeName+"."+fieldName] && !p.Allowed[typeName+".*"] {
+		return fmt.Errorf("field %s is not allowed", fieldRef(typeName, fieldName))
+	}
+	return nil
+}
+
+// checkArguments checks the IDs passed inline as arguments.
+func (p *listenFieldPolicy) checkArguments(args ast.ArgumentList, checkedIDs map[string]bool) error {
+	var check func(val *ast.Value) error
+	check = func(val *ast.Value) error {
+		if val == nil {
+			return nil
+		}
+		if val.Kind == ast.StringValue || val.Kind == ast.BlockValue {
+			return p.checkValues(val.Raw, checkedIDs)
+		}
+		for _, child := range val.Children {
+			if err := check(child.Value); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	for _, arg := range args {
+		if err := check(arg.Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkValues checks every string in a decoded JSON value that is an ID.
+// Strings are checked regardless of where they're used, since the policy
+// doesn't know the types of variables; anything that doesn't decode as an ID
+// won't be loaded as one by the engine either.
+func (p *listenFieldPolicy) checkValues(val any, checkedIDs map[string]bool) error {
+	switch val := val.(type) {
+	case string:
+		if checkedIDs[val] {
+			return nil
+		}
+		checkedIDs[val] = true
+		var id call.ID
+		if err := id.Decode(val); err != nil {
+			return nil
+		}
+		return p.checkID(&id, map[string]bool{})
+	case []any:
+		for _, elem := range val {
+			if err := p.checkValues(elem, checkedIDs); err != nil {
+				return err
+			}
+		}
+	case map[string]any:
+		for _, elem := range val {
+			if err := p.checkValues(elem, checkedIDs); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// checkID checks every call in an ID: its receivers, the IDs passed as its
+// arguments, and the modules that implement them.
+func (p *listenFieldPolicy) checkID(id *call.ID, checked map[string]bool) error {
+	for ; id != nil; id = id.Receiver() {
+		if checked[id.Digest().String()] {
+			return nil
+		}
+		checked[id.Digest().String()] = true
+
+		typeName := p.queryType()
+		if id.Receiver() != nil {
+			typeName = id.Receiver().Type().NamedType()
+		}
+		if err := p.checkField(typeName, id.Field()); err != nil {
+			return fmt.Errorf("ID argument: %w", err)
+		}
+		if mod := id.Module(); mod != nil {
+			if err := p.checkID(mod.ID(), checked); err != nil {
+				return err
+			}
+		}
+		for _, arg := range id.Args() {
+			if err := p.checkLiteral(arg.Value(), checked); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (p *listenFieldPolicy) checkLiteral(lit call.Literal, checked map[string]bool) error {
+	switch lit := lit.(type) {
+	case *call.LiteralID:
+		return p.checkID(lit.Value(), checked)
+	case *call.LiteralList:
+		for _, elem := range lit.Values() {
+			if err := p.checkLiteral(elem, checked); err != nil {
+				return err
+			}
+		}
+	case *call.LiteralObject:
+		for _, arg := range lit.Args() {
+			if err := p.checkLiteral(arg.Value(), checked); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (p *listenFieldPolicy) checkSelections(doc *ast.QueryDocument, typeName string, sels ast.SelectionSet, checkedFragments map[string]bool) error {
+	for _, sel := range sels {
+		switch sel := sel.(type) {
+		case *ast.Field:
+			if strings.HasPrefix(sel.Name, "__") {
+				// introspection is always allowed
+				continue
+			}
+			if err := p.checkField(typeName, sel.Name); err != nil {
+				return err
+			}
+			if err := p.checkArguments(sel.Arguments, map[string]bool{}); err != nil {
+				return err
+			}
+			if len(sel.SelectionSet) == 0 {
+				continue
+			}
+			fieldType, err := p.fieldType(typeName, sel.Name)
+			if err != nil {
+				return err
+			}
+			if err := p.checkSelections(doc, fieldType, sel.SelectionSet, checkedFragments); err != nil {
+				return err
+			}
+		case *ast.InlineFragment:
+			fragType := typeName
+			if sel.TypeCondition != "" {
+				fragType = sel.TypeCondition
+			}
+			if err := p.checkSelections(doc, fragType, sel.SelectionSet, checkedFragments); err != nil {
+				return err
+			}
+		case *ast.FragmentSpread:
+			if checkedFragments[sel.Name] {
+				continue
+			}
+			checkedFragments[sel.Name] = true
+			frag := doc.Fragments.ForName(sel.Name)
+			if frag == nil {
+				return fmt.Errorf("unknown fragment %q", sel.Name)
+			}
+			if err := p.checkSelections(doc, frag.TypeCondition, frag.SelectionSet, checkedFragments); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// fieldType returns the name of the type returned by a field, unwrapping
+// lists and non-nulls.
+func (p *listenFieldPolicy) fieldType(typeName, fieldName string) (string, error) {
+	if p.Schema == nil {
+		// only needed for checking the allowlist
+		return "", nil
+	}
+	if typ := p.Schema.Types.Get(typeName); typ != nil {
+		for _, field := range typ.Fields {
+			if field.Name != fieldName {
+				continue
+			}
+			ref := field.TypeRef
+			for ref.OfType != nil {
+				ref = ref.OfType
+			}
+			return ref.Name, nil
+		}
+	}
+	return "", fmt.Errorf("unknown field %s", fieldRef(typeName, fieldName))
+}
+
+func fieldRef(typeName, fieldName string) string {
+	if typeName == "" {
+		return fieldName
+	}
+	return typeName + "." + fieldName
+}
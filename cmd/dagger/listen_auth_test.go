@@ -0,0 +1,187 @@
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vektah/gqlparser/v2/ast"
+
+	"github.com/dagger/dagger/dagql/call"
+	"github.com/dagger/dagger/dagql/introspection"
+)
+
+func TestListenFieldPolicy(t *testing.T) {
+	object := func(name string, fields map[string]string) *introspection.ResponseType {
+		typ := &introspection.ResponseType{
+			Kind: introspection.ResponseTypeKindObject,
+			Name: name,
+		}
+		for field, ret := range fields {
+			typ.Fields = append(typ.Fields, &introspection.ResponseField{
+				Name: field,
+				TypeRef: &introspection.ResponseTypeRef{
+					Kind: introspection.ResponseTypeKindNonNull,
+					OfType: &introspection.ResponseTypeRef{
+						Kind: introspection.ResponseTypeKindObject,
+						Name: ret,
+					},
+				},
+			})
+		}
+		return typ
+	}
+	schema := &introspection.ResponseSchema{
+		QueryType: introspection.ResponseNamedType{Name: "Query"},
+		Types: introspection.ResponseSchemaTypes{
+			object("Query", map[string]string{"container": "Container", "host": "Host", "version": "String", "loadContainerFromID": "Container", "loadDirectoryFromID": "Directory"}),
+			object("Container", map[string]string{"from": "Container", "withExec": "Container", "stdout": "String", "publish": "String"}),
+			object("Host", map[string]string{"directory": "Directory"}),
+		},
+	}
+
+	t.Run("read-only", func(t *testing.T) {
+		policy, err := newListenFieldPolicy(true, nil, schema)
+		require.NoError(t, err)
+
+		require.NoError(t, policy.Check(`{container{from(address:"alpine"){stdout}}}`, nil))
+		require.NoError(t, policy.Check(`{__schema{types{name}}}`, nil))
+		require.ErrorContains(t, policy.Check(`{host{directory(path:"."){id}}}`, nil),
+			"field Query.host is not allowed in read-only mode")
+		require.ErrorContains(t, policy.Check(`{container{...pub}} fragment pub on Container{publish(address:"x")}`, nil),
+			"field Container.publish is not allowed in read-only mode")
+		require.ErrorContains(t, policy.Check(`mutation{container{stdout}}`, nil),
+			"mutation operations are not allowed")
+	})
+
+	t.Run("allowlist", func(t *testing.T) {
+		policy, err := newListenFieldPolicy(false, []string{"Query.container", "Container.*"}, schema)
+		require.NoError(t, err)
+
+		require.NoError(t, policy.Check(`{container{from(address:"alpine"){withExec(args:["true"]){stdout}}}}`, nil))
+		require.NoError(t, policy.Check(`{container{... on Container{stdout}}}`, nil))
+		require.ErrorContains(t, policy.Check(`{version}`, nil), "field Query.version is not allowed")
+
+		_, err = newListenFieldPolicy(false, []string{"container"}, schema)
+		require.ErrorContains(t, err, "must be Type.field or Type.*")
+	})
+
+	t.Run("IDs", func(t *testing.T) {
+		encode := func(id *call.ID) string {
+			enc, err := id.Encode()
+			require.NoError(t, err)
+			return enc
+		}
+		ctrType := ast.NonNullNamedType("Container", nil)
+		dirType := ast.NonNullNamedType("Directory", nil)
+		ctr := call.New().
+			Append(ctrType, "container").
+			Append(ctrType, "from", call.WithArgs(call.NewArgument("address", call.NewLiteralString("alpine"), false)))
+		hostDir := call.New().
+			Append(ast.NonNullNamedType("Host", nil), "host").
+			Append(dirType, "directory", call.WithArgs(call.NewArgument("path", call.NewLiteralString("."), false)))
+		// the denied call is nested in an argument of the ID
+		ctrWithHostDir := ctr.Append(ctrType, "withDirectory", call.WithArgs(
+			call.NewArgument("path", call.NewLiteralString("/src"), false),
+			call.NewArgument("directory", call.NewLiteralID(hostDir), false),
+		))
+
+		policy, err := newListenFieldPolicy(true, nil, schema)
+		require.NoError(t, err)
+
+		query := `query($id: ContainerID!){loadContainerFromID(id: $id){stdout}}`
+		require.NoError(t, policy.Check(query, map[string]any{"id": encode(ctr)}))
+		require.ErrorContains(t, policy.Check(query, map[string]any{"id": encode(ctrWithHostDir)}),
+			"ID argument: field Query.host is not allowed in read-only mode")
+		require.ErrorContains(t, policy.Check(`{loadContainerFromID(id: "`+encode(ctrWithHostDir)+`"){stdout}}`, nil),
+			"ID argument: field Query.host is not allowed in read-only mode")
+		require.ErrorContains(t, policy.Check(`query($ids: [DirectoryID!]!){version}`, map[string]any{"ids": []any{encode(hostDir)}}),
+			"ID argument: field Query.host is not allowed in read-only mode")
+
+		// strings that aren't IDs are left alone
+		require.NoError(t, policy.Check(`{container{from(address:"alpine"){stdout}}}`, map[string]any{"x": "aGVsbG8="}))
+
+		allowlist, err := newListenFieldPolicy(false, []string{"Query.container", "Query.loadContainerFromID", "Container.*"}, schema)
+		require.NoError(t, err)
+		require.NoError(t, allowlist.Check(query, map[string]any{"id": encode(ctr)}))
+		require.ErrorContains(t, allowlist.Check(query, map[string]any{"id": encode(ctrWithHostDir)}),
+			"ID argument: field Host.directory is not allowed")
+	})
+
+	t.Run("handler", func(t *testing.T) {
+		policy, err := newListenFieldPolicy(true, nil, schema)
+		require.NoError(t, err)
+		handler := policy.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+
+		serve := func(method, path, body string) int {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(method, path, strings.NewReader(body)))
+			return rec.Code
+		}
+		require.Equal(t, http.StatusOK, serve(http.MethodPost, "/query", `{"query":"{container{stdout}}"}`))
+		require.Equal(t, http.StatusForbidden, serve(http.MethodPost, "/query", `{"query":"{host{directory(path:\".\"){id}}}"}`))
+		require.Equal(t, http.StatusForbidden, serve(http.MethodPost, "/shutdown", ``))
+
+		hostDirID, err := call.New().
+			Append(ast.NonNullNamedType("Host", nil), "host").
+			Append(ast.NonNullNamedType("Directory", nil), "directory", call.WithArgs(call.NewArgument("path", call.NewLiteralString("."), false))).
+			Encode()
+		require.NoError(t, err)
+		body, err := json.Marshal(map[string]any{
+			"query":     `query($id: DirectoryID!){loadDirectoryFromID(id: $id){entries}}`,
+			"variables": map[string]any{"id": hostDirID},
+		})
+		require.NoError(t, err)
+		require.Equal(t, http.StatusForbidden, serve(http.MethodPost, "/query", string(body)))
+		require.Equal(t, http.StatusOK, serve(http.MethodGet, "/v1/traces", ``))
+	})
+}
+
+func TestBearerTokenAuth(t *testing.T) {
+	var gotUser string
+	handler := bearerTokenAuth([]string{"session", "team"}, "session", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotUser, _, _ = r.BasicAuth()
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	serve := func(auth string, verifiedCert ...bool) int {
+		gotUser = ""
+		req := httptest.NewRequest(http.MethodPost, "/query", nil)
+		if auth != "" {
+			req.Header.Set("Authorization", auth)
+		}
+		if len(verifiedCert) > 0 {
+			req.TLS = &tls.ConnectionState{}
+			if verifiedCert[0] {
+				req.TLS.VerifiedChains = [][]*x509.Certificate{{{}}}
+			}
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	require.Equal(t, http.StatusOK, serve("Bearer team"))
+	require.Equal(t, "session", gotUser)
+
+	require.Equal(t, http.StatusUnauthorized, serve("Bearer nope"))
+	require.Empty(t, gotUser)
+
+	// basic auth is passed through for the engine client to check
+	require.Equal(t, http.StatusOK, serve("Basic c2Vzc2lvbjo="))
+	require.Equal(t, "session", gotUser)
+
+	// a verified client certificate is enough on its own
+	require.Equal(t, http.StatusOK, serve("", true))
+	require.Equal(t, "session", gotUser)
+
+	require.Equal(t, http.StatusOK, serve("", false))
+	require.Empty(t, gotUser)
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"bytes"
+	_ "embed"
+	"html/template"
+	"net/http"
+
+	"github.com/dagger/dagger/dagql/introspection"
+	"github.com/dagger/dagger/engine"
+)
+
+// explorerEndpoint serves the API explorer page of `dagger listen`.
+const explorerEndpoint = "/explorer"
+
+//go:embed listen_explorer.html
+var explorerHTML string
+
+var explorerTmpl = template.Must(template.New("explorer").Parse(explorerHTML))
+
+// explorerHandler serves a page for browsing the schema and running queries
+// against the API. The page itself holds no data, so it's served without
+// authentication; queries are authenticated with a token entered in the page.
+func explorerHandler() (http.Handler, error) {
+	var page bytes.Buffer
+	if err := explorerTmpl.Execute(&page, map[string]string{
+		"QueryEndpoint":      engine.QueryEndpoint,
+		"IntrospectionQuery": introspection.Query,
+	}); err != nil {
+		return nil, err
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write(page.Bytes())
+	}), nil
+}
@@ -131,7 +131,7 @@ func NewLLMSession(
 	}
 
 	// if $agent is set, respect it
-	if value, ok := s.shell.runner.Vars[agentVar]; ok {
+	if value, ok := s.shell.runner.Vars[agentVar]; ok && value.IsSet() {
 		if key := GetStateKey(value.String()); key != "" {
 			st, err := s.shell.state.Load(key)
 			if err != nil {
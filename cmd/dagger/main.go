@@ -138,6 +138,7 @@ func init() {
 		queryCmd,
 		runCmd,
 		watchCmd,
+		debugCmd,
 		configCmd,
 		checksCmd,
 		moduleInitCmd,
@@ -146,11 +147,13 @@ func init() {
 		moduleUpdateCmd,
 		moduleDevelopCmd,
 		modulePublishCmd,
+		moduleCmd,
 		toolchainCmd,
 		funcListCmd,
 		callCoreCmd.Command(),
 		callModCmd.Command(),
 		sessionCmd(),
+		serviceCmd(),
 		newGenCmd(),
 		shellCmd,
 		clientCmd,
@@ -331,7 +334,7 @@ func installGlobalFlags(flags *pflag.FlagSet) {
 	flags.BoolVarP(&silent, "silent", "s", silent, "Do not show progress at all")
 	flags.BoolVarP(&debugFlag, "debug", "d", debugFlag, "Show debug logs and full verbosity")
 	flags.StringVar(&progress, "progress", "auto", "Progress output format (auto, plain, tty, dots)")
-	flags.BoolVarP(&interactive, "interactive", "i", false, "Spawn a terminal on container exec failure")
+	flags.BoolVarP(&interactive, "interactive", "i", false, "Debug container exec failures in a terminal")
 	flags.StringVar(&interactiveCommand, "interactive-command", "/bin/sh", "Change the default command for interactive mode")
 	flags.BoolVarP(&web, "web", "w", false, "Open trace URL in a web browser")
 	flags.BoolVarP(&noExit, "no-exit", "E", false, "Leave the TUI running after completion")
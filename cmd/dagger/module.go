@@ -1,16 +1,20 @@
 package main
 
 import (
+	"cmp"
 	"context"
 	"fmt"
+	"maps"
 	"net/http"
 	"net/url"
 	"os"
 	"path"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strings"
 
+	"github.com/dagger/dagger/util/fsxutil"
 	"github.com/dagger/dagger/util/gitutil"
 	"github.com/dagger/dagger/util/parallel"
 	"github.com/go-git/go-git/v5"
@@ -169,6 +173,7 @@ func init() {
 	moduleDevelopCmd.Flags().StringVar(&developSDK, "sdk", "", "Install the given Dagger SDK. Can be builtin (go, python, typescript) or a module address")
 	moduleDevelopCmd.Flags().StringVar(&developSourcePath, "source", "", "Source directory used by the installed SDK. Defaults to module root")
 	moduleDevelopCmd.Flags().BoolVarP(&developRecursive, "recursive", "r", false, "Develop recursively into local dependencies")
+	moduleDevelopCmd.Flags().BoolVar(&fileWatch, "watch", false, "Watch the module source and develop again when it changes")
 	moduleDevelopCmd.Flags().StringVar(&licenseID, "license", defaultLicense, "License identifier to generate. See https://spdx.org/licenses/")
 	moduleDevelopCmd.Flags().StringVar(&compatVersion, "compat", modules.EngineVersionLatest, "Engine API version to target")
 	moduleDevelopCmd.Flags().Lookup("compat").NoOptDefVal = "skip"
@@ -362,8 +367,11 @@ var moduleInstallCmd = &cobra.Command{
 	Use:     "install [options] <module>",
 	Aliases: []string{"use"},
 	Short:   "Install a dependency",
-	Long:    "Install another module as a dependency to the current module.",
-	Example: "dagger install github.com/shykes/daggerverse/hello@v0.3.0",
+	Long: `Install another module as a dependency to the current module.
+
+The version of a git module may be a semver range, e.g. "^1.4" or ">=1.2 <2", in which case the newest matching tag is installed and "dagger update" updates it to the newest matching tag.`,
+	Example: `dagger install github.com/shykes/daggerverse/hello@v0.3.0
+dagger install "github.com/shykes/daggerverse/hello@^0.3"`,
 	GroupID: moduleGroup.ID,
 	Args:    cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, extraArgs []string) (rerr error) {
@@ -406,6 +414,21 @@ var moduleInstallCmd = &cobra.Command{
 				depSrc = depSrc.WithName(installName)
 			}
 
+			granted, err := confirmModuleCapabilities(ctx, dag, cmp.Or(installName, origDepName), depSrc)
+			if err != nil {
+				return err
+			}
+			if granted != nil {
+				depSrc = depSrc.WithGrantedCapabilities(dagger.ModuleSourceWithGrantedCapabilitiesOpts{
+					HostRead:    granted.HostRead,
+					HostSockets: granted.HostSockets,
+					Network:     granted.Network,
+					LLM:         granted.LLM,
+					Exec:        granted.Exec,
+					Secrets:     granted.Secrets,
+				})
+			}
+
 			modSrc = modSrc.WithDependencies([]*dagger.ModuleSource{depSrc})
 			if engineVersion := getCompatVersion(); engineVersion != "" {
 				modSrc = modSrc.WithEngineVersion(engineVersion)
@@ -454,6 +477,10 @@ var moduleInstallCmd = &cobra.Command{
 				if err != nil {
 					return fmt.Errorf("failed to get git commit: %w", err)
 				}
+				// a ref named like a range resolves to itself rather than to a tag
+				if i := strings.LastIndex(depRefStr, "@"); i != -1 && modules.IsVersionRange(depRefStr[i+1:]) && depRefStr[i+1:] != gitVersion {
+					slog.Info("resolved version range", "module", cmp.Or(installName, origDepName), "range", depRefStr[i+1:], "version", gitVersion)
+				}
 
 				analyticsType := "module_install"
 				analytics.Ctx(ctx).Capture(ctx, analyticsType, map[string]string{
@@ -483,6 +510,8 @@ var moduleUpdateCmd = &cobra.Command{
 To update only specific dependencies, specify their short names or a complete address.
 
 If no dependency is specified, all dependencies are updated, as well as the module's blueprint, if it exists.
+
+Dependencies installed with a semver range are updated to the newest tag matching the range.
 `,
 	Example: `"dagger update" or "dagger update hello" "dagger update github.com/shykes/daggerverse/hello@v0.3.0"`,
 	GroupID: moduleGroup.ID,
@@ -615,141 +644,170 @@ This command is idempotent: you can run it at any time, any number of times. It
 	RunE: func(cmd *cobra.Command, extraArgs []string) (rerr error) {
 		ctx := cmd.Context()
 		return withEngine(ctx, client.Params{}, func(ctx context.Context, engineClient *client.Client) (err error) {
-			dag := engineClient.Dagger()
+			var contextDirPath string
+			var srcRootPaths []string
+			develop := func(ctx context.Context, engineClient *client.Client) (err error) {
+				dag := engineClient.Dagger()
 
-			modRef, err := getModuleSourceRefWithDefault()
-			if err != nil {
-				return err
-			}
-			modSrc := dag.ModuleSource(modRef, dagger.ModuleSourceOpts{
-				// We can only export updated generated files for a local modules
-				RequireKind: dagger.ModuleSourceKindLocalSource,
-			})
-
-			if selfCalls {
-				modSrc = modSrc.WithExperimentalFeatures([]dagger.ModuleSourceExperimentalFeature{dagger.ModuleSourceExperimentalFeatureSelfCalls})
-			} else if noSelfCalls {
-				modSrc = modSrc.WithoutExperimentalFeatures([]dagger.ModuleSourceExperimentalFeature{dagger.ModuleSourceExperimentalFeatureSelfCalls})
-			}
-
-			contextDirPath, err := modSrc.LocalContextDirectoryPath(ctx)
-			if err != nil {
-				return fmt.Errorf("failed to get local context directory path: %w", err)
-			}
-			srcRootSubPath, err := modSrc.SourceRootSubpath(ctx)
-			if err != nil {
-				return fmt.Errorf("failed to get source root subpath: %w", err)
-			}
-			baseSrcRootPath := filepath.Join(contextDirPath, srcRootSubPath)
-
-			modSrcs := make(map[string]*dagger.ModuleSource)
-			if developRecursive {
-				ctx, span := Tracer().Start(ctx, "load module: "+modRef, telemetry.Encapsulate())
-				err := collectLocalModulesRecursive(ctx, modSrc, modSrcs)
-				telemetry.EndWithCause(span, &err)
+				modRef, err := getModuleSourceRefWithDefault()
 				if err != nil {
 					return err
 				}
-			} else {
-				modSrcs[baseSrcRootPath] = modSrc
-			}
+				modSrc := dag.ModuleSource(modRef, dagger.ModuleSourceOpts{
+					// We can only export updated generated files for a local modules
+					RequireKind: dagger.ModuleSourceKindLocalSource,
+				})
 
-			ctx, span := Tracer().Start(ctx, "develop")
-			defer telemetry.EndWithCause(span, &err)
+				if selfCalls {
+					modSrc = modSrc.WithExperimentalFeatures([]dagger.ModuleSourceExperimentalFeature{dagger.ModuleSourceExperimentalFeatureSelfCalls})
+				} else if noSelfCalls {
+					modSrc = modSrc.WithoutExperimentalFeatures([]dagger.ModuleSourceExperimentalFeature{dagger.ModuleSourceExperimentalFeatureSelfCalls})
+				}
 
-			eg, ctx := errgroup.WithContext(ctx)
-			for srcRootPath, modSrc := range modSrcs {
-				name := strings.TrimPrefix(srcRootPath, baseSrcRootPath)
-				name = strings.TrimPrefix(name, "/")
-				if name == "" {
-					name = "."
+				contextDirPath, err = modSrc.LocalContextDirectoryPath(ctx)
+				if err != nil {
+					return fmt.Errorf("failed to get local context directory path: %w", err)
+				}
+				srcRootSubPath, err := modSrc.SourceRootSubpath(ctx)
+				if err != nil {
+					return fmt.Errorf("failed to get source root subpath: %w", err)
 				}
-				ctx, span := Tracer().Start(ctx, "develop "+name, telemetry.Encapsulate())
-				eg.Go(func() (err error) {
-					defer telemetry.EndWithCause(span, &err)
+				baseSrcRootPath := filepath.Join(contextDirPath, srcRootSubPath)
 
-					if engineVersion := getCompatVersion(); engineVersion != "" {
-						modSrc = modSrc.WithEngineVersion(engineVersion)
+				modSrcs := make(map[string]*dagger.ModuleSource)
+				if developRecursive {
+					ctx, span := Tracer().Start(ctx, "load module: "+modRef, telemetry.Encapsulate())
+					err := collectLocalModulesRecursive(ctx, modSrc, modSrcs)
+					telemetry.EndWithCause(span, &err)
+					if err != nil {
+						return err
 					}
+				} else {
+					modSrcs[baseSrcRootPath] = modSrc
+				}
+				srcRootPaths = slices.Collect(maps.Keys(modSrcs))
 
-					modSDK, err := modSrc.SDK().Source(ctx)
-					if err != nil {
-						return fmt.Errorf("failed to get module SDK: %w", err)
+				ctx, span := Tracer().Start(ctx, "develop")
+				defer telemetry.EndWithCause(span, &err)
+
+				eg, ctx := errgroup.WithContext(ctx)
+				for srcRootPath, modSrc := range modSrcs {
+					name := strings.TrimPrefix(srcRootPath, baseSrcRootPath)
+					name = strings.TrimPrefix(name, "/")
+					if name == "" {
+						name = "."
 					}
-					if developSDK != "" {
-						if modSDK != "" && modSDK != developSDK {
-							return fmt.Errorf("cannot update module SDK that has already been set to %q", modSDK)
+					ctx, span := Tracer().Start(ctx, "develop "+name, telemetry.Encapsulate())
+					eg.Go(func() (err error) {
+						defer telemetry.EndWithCause(span, &err)
+
+						if engineVersion := getCompatVersion(); engineVersion != "" {
+							modSrc = modSrc.WithEngineVersion(engineVersion)
 						}
-						modSDK = developSDK
-						modSrc = modSrc.WithSDK(modSDK)
-					}
 
-					modSourcePath, err := modSrc.SourceSubpath(ctx)
-					if err != nil {
-						return fmt.Errorf("failed to get module source subpath: %w", err)
-					}
-					// if SDK is set but source path isn't and the user didn't provide --source, we'll use the default source path
-					if modSDK != "" && modSourcePath == "" && developSourcePath == "" {
-						inferredSourcePath, err := inferSourcePathDir(srcRootPath)
+						modSDK, err := modSrc.SDK().Source(ctx)
 						if err != nil {
-							return err
+							return fmt.Errorf("failed to get module SDK: %w", err)
+						}
+						if developSDK != "" {
+							if modSDK != "" && modSDK != developSDK {
+								return fmt.Errorf("cannot update module SDK that has already been set to %q", modSDK)
+							}
+							modSDK = developSDK
+							modSrc = modSrc.WithSDK(modSDK)
 						}
 
-						developSourcePath = filepath.Join(srcRootPath, inferredSourcePath)
-					}
+						modSourcePath, err := modSrc.SourceSubpath(ctx)
+						if err != nil {
+							return fmt.Errorf("failed to get module source subpath: %w", err)
+						}
+						// if SDK is set but source path isn't and the user didn't provide --source, we'll use the default source path
+						if modSDK != "" && modSourcePath == "" && developSourcePath == "" {
+							inferredSourcePath, err := inferSourcePathDir(srcRootPath)
+							if err != nil {
+								return err
+							}
+
+							developSourcePath = filepath.Join(srcRootPath, inferredSourcePath)
+						}
 
-					clients, err := modSrc.ConfigClients(ctx)
-					if err != nil {
-						return fmt.Errorf("failed to get module clients configuration: %w", err)
-					}
+						clients, err := modSrc.ConfigClients(ctx)
+						if err != nil {
+							return fmt.Errorf("failed to get module clients configuration: %w", err)
+						}
 
-					// if there's no SDK and the user isn't changing the source path, there's nothing to do.
-					// error out rather than silently doing nothing.
-					if modSDK == "" && developSourcePath == "" && len(clients) == 0 {
-						return fmt.Errorf("dagger develop on a module without an SDK or clients requires either --sdk or --source")
-					}
+						// if there's no SDK and the user isn't changing the source path, there's nothing to do.
+						// error out rather than silently doing nothing.
+						if modSDK == "" && developSourcePath == "" && len(clients) == 0 {
+							return fmt.Errorf("dagger develop on a module without an SDK or clients requires either --sdk or --source")
+						}
 
-					if developSourcePath != "" {
-						// ensure source path is relative to the source root
-						sourceAbsPath, err := pathutil.Abs(developSourcePath)
+						if developSourcePath != "" {
+							// ensure source path is relative to the source root
+							sourceAbsPath, err := pathutil.Abs(developSourcePath)
+							if err != nil {
+								return fmt.Errorf("failed to get absolute source path for %s: %w", developSourcePath, err)
+							}
+							developSourcePath, err = filepath.Rel(srcRootPath, sourceAbsPath)
+							if err != nil {
+								return fmt.Errorf("failed to get relative source path: %w", err)
+							}
+
+							if modSourcePath != "" && modSourcePath != developSourcePath {
+								return fmt.Errorf("cannot update module source path that has already been set to %q", modSourcePath)
+							}
+
+							modSourcePath = developSourcePath
+							modSrc = modSrc.WithSourceSubpath(modSourcePath)
+						}
+
+						contextDirPath, err := modSrc.LocalContextDirectoryPath(ctx)
 						if err != nil {
-							return fmt.Errorf("failed to get absolute source path for %s: %w", developSourcePath, err)
+							return fmt.Errorf("failed to get local context directory path: %w", err)
 						}
-						developSourcePath, err = filepath.Rel(srcRootPath, sourceAbsPath)
+						_, err = modSrc.
+							GeneratedContextDirectory().
+							Export(ctx, contextDirPath)
 						if err != nil {
-							return fmt.Errorf("failed to get relative source path: %w", err)
+							return fmt.Errorf("failed to generate code: %w", err)
 						}
 
-						if modSourcePath != "" && modSourcePath != developSourcePath {
-							return fmt.Errorf("cannot update module source path that has already been set to %q", modSourcePath)
+						// If no license has been created yet, and SDK is set, we should create one.
+						if developSDK != "" {
+							searchExisting := !cmd.Flags().Lookup("license").Changed
+							if err := findOrCreateLicense(ctx, srcRootPath, searchExisting); err != nil {
+								return err
+							}
 						}
+						return nil
+					})
+				}
+				return eg.Wait()
+			}
 
-						modSourcePath = developSourcePath
-						modSrc = modSrc.WithSourceSubpath(modSourcePath)
-					}
-
-					contextDirPath, err := modSrc.LocalContextDirectoryPath(ctx)
-					if err != nil {
-						return fmt.Errorf("failed to get local context directory path: %w", err)
-					}
-					_, err = modSrc.
-						GeneratedContextDirectory().
-						Export(ctx, contextDirPath)
-					if err != nil {
-						return fmt.Errorf("failed to generate code: %w", err)
+			if !fileWatch {
+				return develop(ctx, engineClient)
+			}
+			return watchAndRerun(ctx, engineClient, client.Params{},
+				func() (string, fsxutil.WatchOpt, error) {
+					if contextDirPath == "" {
+						// the first run failed before loading the module, so
+						// watch its directory until it loads
+						root, err := moduleDirWatchRoot()
+						return root, fsxutil.WatchOpt{GitIgnore: true}, err
 					}
-
-					// If no license has been created yet, and SDK is set, we should create one.
-					if developSDK != "" {
-						searchExisting := !cmd.Flags().Lookup("license").Changed
-						if err := findOrCreateLicense(ctx, srcRootPath, searchExisting); err != nil {
-							return err
-						}
+					opt, err := moduleSourceWatchOpt(contextDirPath, srcRootPaths)
+					return contextDirPath, opt, err
+				},
+				func(ctx context.Context, engineClient *client.Client) error {
+					if err := develop(ctx, engineClient); err != nil {
+						return err
 					}
+					// the SDK and source path are saved to dagger.json by now
+					developSDK, developSourcePath = "", ""
 					return nil
-				})
-			}
-			return eg.Wait()
+				},
+			)
 		})
 	},
 }
@@ -0,0 +1,259 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/huh"
+	"github.com/juju/ansiterm/tabwriter"
+	"github.com/muesli/termenv"
+	"github.com/spf13/cobra"
+
+	"dagger.io/dagger"
+	"github.com/dagger/dagger/core/modules"
+	"github.com/dagger/dagger/dagql/idtui"
+	"github.com/dagger/dagger/engine/client"
+	"github.com/dagger/dagger/engine/slog"
+)
+
+var installGrantCapabilities bool
+
+var moduleCmd = &cobra.Command{
+	Use:     "module",
+	Short:   "Inspect modules",
+	GroupID: moduleGroup.ID,
+}
+
+var moduleInspectCmd = &cobra.Command{
+	Use:     "inspect [options]",
+	Short:   "Show the capabilities of a module and its dependencies",
+	Long:    "Show the capabilities declared by a module and each of its dependencies, and the capabilities granted to each dependency when it was installed. Modules that declare no capabilities require all of them.",
+	Example: "dagger module inspect",
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, extraArgs []string) (rerr error) {
+		ctx := cmd.Context()
+		return withEngine(ctx, client.Params{}, func(ctx context.Context, engineClient *client.Client) (err error) {
+			dag := engineClient.Dagger()
+			modRef, err := getModuleSourceRefWithDefault()
+			if err != nil {
+				return err
+			}
+			modSrc := dag.ModuleSource(modRef)
+			info, err := loadModuleCapabilities(ctx, dag, modSrc)
+			if err != nil {
+				return err
+			}
+			sort.Slice(info.Dependencies, func(i, j int) bool {
+				return info.Dependencies[i].Name < info.Dependencies[j].Name
+			})
+
+			tw := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 3, ' ', tabwriter.DiscardEmptyColumns)
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n",
+				termenv.String("Name").Bold(),
+				termenv.String("Source").Bold(),
+				termenv.String("Capabilities").Bold(),
+				termenv.String("Granted").Bold(),
+			)
+			for _, mod := range append([]moduleCapabilitiesInfo{info.moduleCapabilitiesInfo}, info.Dependencies...) {
+				granted := "-"
+				if mod.GrantedCapabilities != nil {
+					granted = formatCapabilities(mod.GrantedCapabilities)
+				}
+				fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n",
+					mod.Name,
+					mod.Source,
+					formatCapabilities(mod.Capabilities),
+					granted)
+			}
+			return tw.Flush()
+		})
+	},
+}
+
+func init() {
+	moduleInstallCmd.Flags().BoolVar(&installGrantCapabilities, "grant-capabilities", false, "Grant the capabilities required by the dependency without prompting, all of them if it declares none")
+
+	moduleAddFlags(moduleInspectCmd, moduleInspectCmd.Flags(), false)
+	moduleCmd.AddCommand(moduleInspectCmd)
+}
+
+type moduleCapabilitiesInfo struct {
+	Name                string `json:"moduleName"`
+	Source              string `json:"asString"`
+	Capabilities        *modules.ModuleCapabilities
+	GrantedCapabilities *modules.ModuleCapabilities
+}
+
+type moduleCapabilitiesWithDeps struct {
+	moduleCapabilitiesInfo
+	Dependencies []moduleCapabilitiesInfo
+}
+
+const loadModCapabilitiesQuery = `
+query ModuleCapabilities($source: ModuleSourceID!) {
+	source: loadModuleSourceFromID(id: $source) {
+		...ModuleCapabilities
+		dependencies {
+			...ModuleCapabilities
+		}
+	}
+}
+
+fragment ModuleCapabilities on ModuleSource {
+	moduleName
+	asString
+	capabilities {
+		...Capabilities
+	}
+	grantedCapabilities {
+		...Capabilities
+	}
+}
+
+fragment Capabilities on ModuleCapabilities {
+	hostRead
+	hostSockets
+	network
+	llm
+	exec
+	secrets
+}
+`
+
+// loadModuleCapabilities loads the capabilities of the given module source
+// and its direct dependencies.
+func loadModuleCapabilities(ctx context.Context, dag *dagger.Client, src *dagger.ModuleSource) (*moduleCapabilitiesWithDeps, error) {
+	id, err := src.ID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var res struct {
+		Source moduleCapabilitiesWithDeps
+	}
+	err = dag.Do(ctx, &dagger.Request{
+		Query: loadModCapabilitiesQuery,
+		Variables: map[string]any{
+			"source": id,
+		},
+	}, &dagger.Response{
+		Data: &res,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("query module capabilities: %w", err)
+	}
+	return &res.Source, nil
+}
+
+// describeCapabilities returns a human readable description of each
+// capability granted.
+func describeCapabilities(caps *modules.ModuleCapabilities) []string {
+	var descs []string
+	for _, path := range caps.HostRead {
+		descs = append(descs, fmt.Sprintf("read %s from the host", path))
+	}
+	if caps.HostSockets {
+		descs = append(descs, "use host sockets and tunnels")
+	}
+	if caps.Network {
+		descs = append(descs, "run commands with full network access and download files over HTTP")
+	}
+	if caps.LLM {
+		descs = append(descs, "use LLMs")
+	}
+	if caps.Exec {
+		descs = append(descs, "run commands with access to the Dagger API")
+	}
+	if caps.Secrets {
+		descs = append(descs, "load secrets from the host")
+	}
+	return descs
+}
+
+// formatCapabilities returns the capabilities as listed in dagger.json.
+func formatCapabilities(caps *modules.ModuleCapabilities) string {
+	if caps == nil {
+		return "all"
+	}
+	var names []string
+	if len(caps.HostRead) > 0 {
+		names = append(names, fmt.Sprintf("hostRead(%s)", strings.Join(caps.HostRead, ", ")))
+	}
+	if caps.HostSockets {
+		names = append(names, "hostSockets")
+	}
+	if caps.Network {
+		names = append(names, "network")
+	}
+	if caps.LLM {
+		names = append(names, "llm")
+	}
+	if caps.Exec {
+		names = append(names, "exec")
+	}
+	if caps.Secrets {
+		names = append(names, "secrets")
+	}
+	if len(names) == 0 {
+		return "none"
+	}
+	return strings.Join(names, ", ")
+}
+
+// confirmModuleCapabilities asks the user to grant the capabilities the given
+// dependency requires before installing it, and returns them so they're
+// recorded in dagger.json. A dependency that declares no capabilities requires
+// all of them. Without a terminal, the capabilities are granted with a
+// warning. Local dependencies are part of the user's own context, so they
+// aren't prompted for and no grant is recorded.
+func confirmModuleCapabilities(ctx context.Context, dag *dagger.Client, name string, src *dagger.ModuleSource) (*modules.ModuleCapabilities, error) {
+	kind, err := src.Kind(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if kind == dagger.ModuleSourceKindLocalSource {
+		return nil, nil
+	}
+	info, err := loadModuleCapabilities(ctx, dag, src)
+	if err != nil {
+		return nil, err
+	}
+	caps := info.Capabilities
+	title := fmt.Sprintf("Grant capabilities to %s?", name)
+	if caps == nil {
+		caps = modules.AllModuleCapabilities()
+		title = fmt.Sprintf("%s declares no capabilities. Grant it all capabilities?", name)
+	}
+	descs := describeCapabilities(caps)
+	if len(descs) == 0 || installGrantCapabilities {
+		return caps, nil
+	}
+	if !hasTTY {
+		// don't break scripted installs; the grant is recorded in dagger.json
+		// for review, and can be narrowed there
+		slog.Warn("granting capabilities without prompting, since there is no terminal",
+			"module", name,
+			"capabilities", formatCapabilities(caps))
+		return caps, nil
+	}
+
+	var confirm bool
+	form := idtui.NewForm(
+		huh.NewGroup(
+			huh.NewConfirm().
+				Title(title).
+				Description(fmt.Sprintf("%s will be able to:\n- %s", name, strings.Join(descs, "\n- "))).
+				Affirmative("Grant").
+				Negative("Cancel").
+				Value(&confirm),
+		),
+	)
+	if err := Frontend.HandleForm(ctx, form); err != nil {
+		return nil, err
+	}
+	if !confirm {
+		return nil, fmt.Errorf("capabilities of %q were not granted", name)
+	}
+	return caps, nil
+}
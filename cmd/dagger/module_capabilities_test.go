@@ -0,0 +1,35 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dagger/dagger/core/modules"
+)
+
+func TestFormatCapabilities(t *testing.T) {
+	require.Equal(t, "all", formatCapabilities(nil))
+	require.Equal(t, "none", formatCapabilities(&modules.ModuleCapabilities{}))
+	require.Equal(t, "hostRead(./src, /docs), network, llm", formatCapabilities(&modules.ModuleCapabilities{
+		HostRead: []string{"./src", "/docs"},
+		Network:  true,
+		LLM:      true,
+	}))
+	require.Equal(t, "hostRead(/), hostSockets, network, llm, exec, secrets", formatCapabilities(modules.AllModuleCapabilities()))
+}
+
+func TestDescribeCapabilities(t *testing.T) {
+	require.Empty(t, describeCapabilities(&modules.ModuleCapabilities{}))
+	require.Equal(t, []string{
+		"read ./src from the host",
+		"use host sockets and tunnels",
+		"run commands with access to the Dagger API",
+		"load secrets from the host",
+	}, describeCapabilities(&modules.ModuleCapabilities{
+		HostRead:    []string{"./src"},
+		HostSockets: true,
+		Exec:        true,
+		Secrets:     true,
+	}))
+}
@@ -143,6 +143,7 @@ type moduleDef struct {
 	Interfaces  []*modTypeDef
 	Enums       []*modTypeDef
 	Inputs      []*modTypeDef
+	Unions      []*modTypeDef
 
 	// the ModuleSource definition for the module, needed by some arg types
 	// applying module-specific configs to the arg value.
@@ -295,7 +296,12 @@ func (m *moduleDef) loadTypeDefs(ctx context.Context, dag *dagger.Client) (rerr
 		return fmt.Errorf("query module objects: %w", err)
 	}
 
-	for _, typeDef := range res.TypeDefs {
+	return m.setTypeDefs(res.TypeDefs)
+}
+
+// setTypeDefs indexes the loaded type definitions, and finds the main object.
+func (m *moduleDef) setTypeDefs(typeDefs []*modTypeDef) error {
+	for _, typeDef := range typeDefs {
 		switch typeDef.Kind {
 		case dagger.TypeDefKindObjectKind:
 			m.Objects = append(m.Objects, typeDef)
@@ -305,6 +311,8 @@ func (m *moduleDef) loadTypeDefs(ctx context.Context, dag *dagger.Client) (rerr
 			m.Enums = append(m.Enums, typeDef)
 		case dagger.TypeDefKindInputKind:
 			m.Inputs = append(m.Inputs, typeDef)
+		case dagger.TypeDefKindUnionKind:
+			m.Unions = append(m.Unions, typeDef)
 		}
 	}
 
@@ -403,6 +411,16 @@ func (m *moduleDef) AsInputs() []*modInput {
 	return defs
 }
 
+func (m *moduleDef) AsUnions() []*modUnion {
+	var defs []*modUnion
+	for _, typeDef := range m.Unions {
+		if typeDef.AsUnion != nil {
+			defs = append(defs, typeDef.AsUnion)
+		}
+	}
+	return defs
+}
+
 // GetObject retrieves a saved object type definition from the module.
 func (m *moduleDef) GetObject(name string) *modObject {
 	for _, obj := range m.AsObjects() {
@@ -454,6 +472,17 @@ func (m *moduleDef) GetEnum(name string) *modEnum {
 	return nil
 }
 
+// GetUnion retrieves a saved union type definition from the module.
+func (m *moduleDef) GetUnion(name string) *modUnion {
+	for _, union := range m.AsUnions() {
+		// Normalize name in case an SDK uses a different convention for union names.
+		if gqlObjectName(union.Name) == gqlObjectName(name) {
+			return union
+		}
+	}
+	return nil
+}
+
 // GetFunctionProvider retrieves a saved object or interface type definition from the module as a functionProvider.
 func (m *moduleDef) GetFunctionProvider(name string) functionProvider {
 	if obj := m.GetObject(name); obj != nil {
@@ -571,6 +600,12 @@ func (m *moduleDef) LoadTypeDef(typeDef *modTypeDef) {
 				typeDef.AsInput = input
 			}
 		}
+		if typeDef.AsUnion != nil && typeDef.AsUnion.Members == nil {
+			union := m.GetUnion(typeDef.AsUnion.Name)
+			if union != nil {
+				typeDef.AsUnion = union
+			}
+		}
 		if typeDef.AsList != nil {
 			m.LoadTypeDef(typeDef.AsList.ElementTypeDef)
 		}
@@ -596,6 +631,7 @@ type modTypeDef struct {
 	AsList      *modList
 	AsScalar    *modScalar
 	AsEnum      *modEnum
+	AsUnion     *modUnion
 
 	// once protects concurrent update from LoadTypeDef
 	once sync.Once
@@ -623,6 +659,8 @@ func (t *modTypeDef) String() string {
 		return t.AsObject.Name
 	case dagger.TypeDefKindInterfaceKind:
 		return t.AsInterface.Name
+	case dagger.TypeDefKindUnionKind:
+		return t.AsUnion.Name
 	case dagger.TypeDefKindListKind:
 		return "[]" + t.AsList.ElementTypeDef.String()
 	default:
@@ -650,6 +688,8 @@ func (t *modTypeDef) KindDisplay() string {
 		return "Object"
 	case dagger.TypeDefKindInterfaceKind:
 		return "Interface"
+	case dagger.TypeDefKindUnionKind:
+		return "Union"
 	case dagger.TypeDefKindListKind:
 		return "List of " + strings.ToLower(t.AsList.ElementTypeDef.KindDisplay()) + "s"
 	default:
@@ -676,6 +716,8 @@ func (t *modTypeDef) Description() string {
 		return t.AsObject.Description
 	case dagger.TypeDefKindInterfaceKind:
 		return t.AsInterface.Description
+	case dagger.TypeDefKindUnionKind:
+		return t.AsUnion.Description
 	case dagger.TypeDefKindListKind:
 		return t.AsList.ElementTypeDef.Description()
 	default:
@@ -866,6 +908,24 @@ type modEnumMember struct {
 	Description string
 }
 
+// modUnion is a representation of dagger.UnionTypeDef.
+type modUnion struct {
+	Name             string
+	Description      string
+	Members          []*modTypeDef
+	SourceModuleName string
+}
+
+// GetMember returns the member of the union with the given type name.
+func (u *modUnion) GetMember(name string) *modTypeDef {
+	for _, member := range u.Members {
+		if member.AsObject != nil && gqlObjectName(member.AsObject.Name) == gqlObjectName(name) {
+			return member
+		}
+	}
+	return nil
+}
+
 type modInput struct {
 	Name        string
 	Description string
@@ -906,6 +966,7 @@ type modFunction struct {
 	Description string
 	ReturnType  *modTypeDef
 	Args        []*modFunctionArg
+	SourceMap   *modSourceMap
 	cmdName     string
 	once        sync.Once
 }
@@ -986,6 +1047,15 @@ func (f *modFunction) ReturnsCoreObject() bool {
 	return false
 }
 
+// modSourceMap is a representation of dagger.SourceMap.
+type modSourceMap struct {
+	Module   string
+	Filename string
+	Line     int
+	Column   int
+	URL      string
+}
+
 // modFunctionArg is a representation of dagger.FunctionArg.
 type modFunctionArg struct {
 	Name         string
@@ -994,10 +1064,54 @@ type modFunctionArg struct {
 	DefaultValue dagger.JSON
 	DefaultPath  string
 	Ignore       []string
+	SourceMap    *modSourceMap
+	Constraints  *modArgConstraints
 	flagName     string
 	once         sync.Once
 }
 
+// modArgConstraints is a representation of dagger.FunctionArgConstraints.
+type modArgConstraints struct {
+	Min           dagger.JSON
+	Max           dagger.JSON
+	Pattern       string
+	MinLength     int
+	MaxLength     int
+	NonEmpty      bool
+	RequiredPaths []string
+}
+
+// String returns a short description of the constraints, for the usage
+// message.
+func (c *modArgConstraints) String() string {
+	if c == nil {
+		return ""
+	}
+	var desc []string
+	if c.Min != "" {
+		desc = append(desc, fmt.Sprintf(">= %s", c.Min))
+	}
+	if c.Max != "" {
+		desc = append(desc, fmt.Sprintf("<= %s", c.Max))
+	}
+	if c.Pattern != "" {
+		desc = append(desc, fmt.Sprintf("pattern: %s", c.Pattern))
+	}
+	if c.MinLength != 0 {
+		desc = append(desc, fmt.Sprintf("min length: %d", c.MinLength))
+	}
+	if c.MaxLength != 0 {
+		desc = append(desc, fmt.Sprintf("max length: %d", c.MaxLength))
+	}
+	if c.NonEmpty {
+		desc = append(desc, "non-empty")
+	}
+	if len(c.RequiredPaths) > 0 {
+		desc = append(desc, fmt.Sprintf("requires: %s", strings.Join(c.RequiredPaths, ", ")))
+	}
+	return strings.Join(desc, ", ")
+}
+
 // FlagName returns the name of the argument using CLI naming conventions.
 func (r *modFunctionArg) FlagName() string {
 	r.once.Do(func() {
@@ -1041,6 +1155,15 @@ func (r *modFunctionArg) Long() string {
 		fmt.Fprintf(sb, "(possible values: %s)", names)
 	}
 
+	if constraints := r.Constraints.String(); constraints != "" {
+		if multiline {
+			sb.WriteString("\n\n")
+		} else if sb.Len() > 0 {
+			sb.WriteString(" ")
+		}
+		fmt.Fprintf(sb, "(constraints: %s)", constraints)
+	}
+
 	return sb.String()
 }
 
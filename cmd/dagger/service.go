@@ -0,0 +1,172 @@
+package main
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"dagger.io/dagger"
+	"github.com/dagger/dagger/engine/client"
+)
+
+var (
+	serviceJSONOutput bool
+	serviceStopKill   bool
+)
+
+func serviceCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use: "service",
+		Annotations: map[string]string{
+			"experimental": "true",
+		},
+		Short: "Manage persistent services running in the engine",
+		Long: `Manage persistent services running in the engine.
+
+Persistent services are started with a name, e.g. with
+` + "`service.withName(\"pg\").start(persistent: true)`" + `, and keep running after
+the session that started them ends. Other sessions can attach to them with
+` + "`runningService(name: \"pg\")`" + `.`,
+	}
+
+	lsCmd := &cobra.Command{
+		Use:     "ls",
+		Aliases: []string{"list"},
+		Short:   "List the persistent services running in the engine",
+		Args:    cobra.NoArgs,
+		RunE:    ListServices,
+	}
+	lsCmd.Flags().BoolVar(&serviceJSONOutput, "json", false, "Print the services in JSON format")
+
+	stopCmd := &cobra.Command{
+		Use:     "stop [options] <name>...",
+		Short:   "Stop persistent services running in the engine",
+		Args:    cobra.MinimumNArgs(1),
+		Example: "dagger service stop pg",
+		RunE:    StopServices,
+	}
+	stopCmd.Flags().BoolVar(&serviceStopKill, "kill", false, "Immediately kill the services without waiting for a graceful exit")
+
+	cmd.AddCommand(lsCmd, stopCmd)
+	return cmd
+}
+
+//go:embed services.graphql
+var loadEngineServicesQuery string
+
+// engineService mirrors the EngineService API type.
+type engineService struct {
+	Name              string `json:"name"`
+	Hostname          string `json:"hostname"`
+	Ports             []int  `json:"ports"`
+	StartedUnixNano   int64  `json:"startedUnixNano"`
+	IdleTimeout       string `json:"idleTimeout"`
+	IdleSinceUnixNano int64  `json:"idleSinceUnixNano"`
+	Bindings          int    `json:"bindings"`
+}
+
+func (svc engineService) Started() time.Time {
+	return time.Unix(0, svc.StartedUnixNano)
+}
+
+// Status describes whether the service is in use, or since when it's idle.
+func (svc engineService) Status(now time.Time) string {
+	if svc.IdleSinceUnixNano == 0 {
+		return fmt.Sprintf("%d %s", svc.Bindings, plural(svc.Bindings, "binding", "bindings"))
+	}
+	idle := now.Sub(time.Unix(0, svc.IdleSinceUnixNano)).Truncate(time.Second)
+	if svc.IdleTimeout != "" {
+		return fmt.Sprintf("idle %s (timeout %s)", idle, svc.IdleTimeout)
+	}
+	return fmt.Sprintf("idle %s", idle)
+}
+
+func ListServices(cmd *cobra.Command, _ []string) error {
+	return withEngine(cmd.Context(), client.Params{}, func(ctx context.Context, engineClient *client.Client) error {
+		services, err := loadEngineServices(ctx, engineClient.Dagger())
+		if err != nil {
+			return err
+		}
+		if serviceJSONOutput {
+			enc := json.NewEncoder(cmd.OutOrStdout())
+			enc.SetIndent("", "  ")
+			return enc.Encode(services)
+		}
+		return printEngineServices(cmd.OutOrStdout(), services, time.Now())
+	})
+}
+
+func StopServices(cmd *cobra.Command, args []string) error {
+	return withEngine(cmd.Context(), client.Params{}, func(ctx context.Context, engineClient *client.Client) error {
+		dag := engineClient.Dagger()
+		for _, name := range args {
+			var res struct {
+				RunningService struct {
+					Stop string
+				}
+			}
+			err := dag.Do(ctx, &dagger.Request{
+				Query: `query StopService($name: String!, $kill: Boolean!) {
+					runningService(name: $name) {
+						stop(kill: $kill)
+					}
+				}`,
+				Variables: map[string]any{
+					"name": name,
+					"kill": serviceStopKill,
+				},
+			}, &dagger.Response{
+				Data: &res,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to stop service %q: %w", name, err)
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "stopped %s\n", name)
+		}
+		return nil
+	})
+}
+
+func loadEngineServices(ctx context.Context, dag *dagger.Client) ([]engineService, error) {
+	var res struct {
+		Engine struct {
+			Services []engineService
+		}
+	}
+	err := dag.Do(ctx, &dagger.Request{
+		Query: loadEngineServicesQuery,
+	}, &dagger.Response{
+		Data: &res,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load engine services: %w", err)
+	}
+	return res.Engine.Services, nil
+}
+
+// printEngineServices prints the services as a table.
+func printEngineServices(w io.Writer, services []engineService, now time.Time) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+	fmt.Fprintf(tw, "NAME\tHOSTNAME\tPORTS\tSTARTED\tSTATUS\n")
+	for _, svc := range services {
+		ports := make([]string, len(svc.Ports))
+		for i, port := range svc.Ports {
+			ports[i] = strconv.Itoa(port)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s ago\t%s\n",
+			svc.Name,
+			svc.Hostname,
+			strings.Join(ports, ","),
+			now.Sub(svc.Started()).Truncate(time.Second),
+			svc.Status(now))
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrintEngineServices(t *testing.T) {
+	now := time.Now()
+	var buf strings.Builder
+	err := printEngineServices(&buf, []engineService{
+		{
+			Name:            "pg",
+			Hostname:        "abc123.dagger.local",
+			Ports:           []int{5432},
+			StartedUnixNano: now.Add(-time.Hour).UnixNano(),
+			Bindings:        2,
+		},
+		{
+			Name:              "redis",
+			Hostname:          "def456.dagger.local",
+			Ports:             []int{6379, 16379},
+			StartedUnixNano:   now.Add(-time.Minute).UnixNano(),
+			IdleTimeout:       "30m0s",
+			IdleSinceUnixNano: now.Add(-10 * time.Second).UnixNano(),
+		},
+	}, now)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 3)
+	require.Equal(t, []string{"NAME", "HOSTNAME", "PORTS", "STARTED", "STATUS"}, strings.Fields(lines[0]))
+	require.Equal(t, []string{"pg", "abc123.dagger.local", "5432", "1h0m0s", "ago", "2", "bindings"}, strings.Fields(lines[1]))
+	require.Equal(t, []string{"redis", "def456.dagger.local", "6379,16379", "1m0s", "ago", "idle", "10s", "(timeout", "30m0s)"}, strings.Fields(lines[2]))
+}
@@ -34,22 +34,46 @@ var (
 	shellCode string
 
 	llmModel string
+
+	// shellLSP runs a language server instead of the shell
+	shellLSP bool
 )
 
 func shellAddFlags(cmd *cobra.Command) {
 	cmd.Flags().StringVarP(&shellCode, "command", "c", "", "Execute a dagger shell command")
 	cmd.Flags().StringVar(&llmModel, "model", "", "LLM model to use (e.g., 'claude-sonnet-4-5', 'gpt-4.1')")
+	cmd.Flags().BoolVar(&shellLSP, "lsp", false, "Run a language server for dagger shell scripts on standard input/output")
 }
 
 var shellCmd = &cobra.Command{
 	Use:   "shell [options] [file...]",
 	Short: "Run an interactive dagger shell",
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if !shellLSP {
+			return nil
+		}
+		if progress == "tty" {
+			return fmt.Errorf("cannot use tty progress output: it interferes with the language server stdio")
+		}
+		if progress == "auto" && hasTTY {
+			Frontend = idtui.NewPlain(stderr)
+		}
+		return nil
+	},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		cmd.SetContext(idtui.WithPrintTraceLink(cmd.Context(), true))
 		return withEngine(cmd.Context(), initModuleParams(args), func(ctx context.Context, engineClient *client.Client) error {
 			dag := engineClient.Dagger()
 			handler := newShellCallHandler(dag, Frontend)
 
+			if shellLSP {
+				// Example: `dagger shell --lsp`
+				if err := handler.Initialize(ctx); err != nil {
+					return err
+				}
+				return handler.ServeLSP(ctx, stdin, stdout)
+			}
+
 			err := handler.RunAll(ctx, args)
 
 			// Don't bother printing the error message if the TUI is enabled.
@@ -18,6 +18,7 @@ const (
 	shellStdlibCmdName = ".stdlib"
 	shellDepsCmdName   = ".deps"
 	shellCoreCmdName   = ".core"
+	shellLoadCmdName   = ".load"
 )
 
 // ShellCommand is a Dagger Shell builtin or stdlib command
@@ -546,6 +547,29 @@ Without arguments, the current working directory is replaced by the initial cont
 				return h.ChangeDir(ctx, path)
 			},
 		},
+		&ShellCommand{
+			Use: ".save <file>",
+			Description: `Save the shell session to a file
+
+Saves the variables, the current working directory and the LLM model, so the
+session can be resumed later, or shared, with .load. Objects in variables are
+saved as IDs, which resolve against the engine's cache.
+`,
+			Args:  ExactArgs(1),
+			State: NoState,
+			Run: func(ctx context.Context, cmd *ShellCommand, args []string, _ *ShellState) error {
+				return h.SaveSession(ctx, args[0])
+			},
+		},
+		&ShellCommand{
+			// Executed by the call handler, since it assigns variables
+			Use: shellLoadCmdName + " <file>",
+			Description: `Load a shell session saved with .save
+
+Restores the variables, the working directory and the LLM conversation of the
+session. Existing variables with the same names are replaced.
+`,
+		},
 		&ShellCommand{
 			Use:         ".pwd",
 			Description: "Print the current working directory's absolute path",
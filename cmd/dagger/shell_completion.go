@@ -21,9 +21,27 @@ func (h *shellAutoComplete) Do(entireInput [][]rune, row, col int) (msg string,
 	line, pos := computil.Flatten(entireInput, row, col)
 	line = line[:pos]
 
+	prefix, matches, ok := h.complete(line)
+	if !ok {
+		return "", nil
+	}
+	return "", editline.SimpleWordsCompletion(
+		matches,
+		"completion",
+		col,
+		pos-len(prefix),
+		pos,
+	)
+}
+
+// complete returns the completions for the word being written at the end of
+// the input, along with the part of the word that's already written.
+func (h *shellAutoComplete) complete(line string) (prefix string, matches []string, ok bool) {
+	pos := len(line)
+
 	file, err := parseShell(strings.NewReader(line), "", syntax.RecoverErrors(5))
 	if err != nil {
-		return "", nil
+		return "", nil, false
 	}
 
 	// find the smallest stmt next to the cursor - this allows accurate
@@ -107,11 +125,10 @@ func (h *shellAutoComplete) Do(entireInput [][]rune, row, col int) (msg string,
 		shctx = h.dispatch(shctx, stmt, cursor)
 	}
 	if shctx == nil {
-		return "", nil
+		return "", nil, false
 	}
 
 	completions := shctx.completions(inprogressPrefix)
-	var matches []string
 	suggested := map[string]struct{}{}
 	for _, c := range completions {
 		if strings.HasPrefix(c, inprogressPrefix) {
@@ -122,13 +139,7 @@ func (h *shellAutoComplete) Do(entireInput [][]rune, row, col int) (msg string,
 			suggested[c] = struct{}{}
 		}
 	}
-	return "", editline.SimpleWordsCompletion(
-		matches,
-		"completion",
-		col,
-		pos-len(inprogressPrefix),
-		pos,
-	)
+	return inprogressPrefix, matches, true
 }
 
 func (h *shellAutoComplete) dispatch(previous *CompletionContext, stmt *syntax.Stmt, cursor uint) *CompletionContext {
@@ -96,6 +96,19 @@ func (h *shellCallHandler) Call(ctx context.Context, args []string) ([]string, e
 		return args, fmt.Errorf("command %q is reserved for internal use", shellInternalCmd)
 	}
 
+	// Loading a session assigns variables, which handlers can't do, so let
+	// the interpreter evaluate the assignments instead.
+	if args[0] == shellLoadCmdName {
+		if len(args) != 2 {
+			return args, fmt.Errorf("usage: %s <file>", shellLoadCmdName)
+		}
+		code, err := h.LoadSession(ctx, args[1])
+		if err != nil {
+			return args, err
+		}
+		return []string{"eval", code}, nil
+	}
+
 	// If command has an interpolated state token, make sure to resolve it.
 	// If it's a single token let it pass through so the handler just pipes it.
 	// Example: `.$FOO | .help`
@@ -0,0 +1,763 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/textproto"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"dagger.io/dagger"
+	"github.com/dagger/dagger/engine"
+	"mvdan.cc/sh/v3/interp"
+	"mvdan.cc/sh/v3/syntax"
+)
+
+// JSON-RPC error codes used by the language server
+const (
+	lspMethodNotFound = -32601
+	lspInvalidParams  = -32602
+)
+
+// LSP diagnostic severities
+const (
+	lspSeverityError = 1
+)
+
+type lspMessage struct {
+	JSONRPC string           `json:"jsonrpc"`
+	ID      *json.RawMessage `json:"id,omitempty"`
+	Method  string           `json:"method,omitempty"`
+	Params  json.RawMessage  `json:"params,omitempty"`
+	Result  json.RawMessage  `json:"result,omitempty"`
+	Error   *lspError        `json:"error,omitempty"`
+}
+
+type lspError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type lspPosition struct {
+	Line      int `json:"line"`
+	Character int `json:"character"`
+}
+
+type lspRange struct {
+	Start lspPosition `json:"start"`
+	End   lspPosition `json:"end"`
+}
+
+type lspLocation struct {
+	URI   string   `json:"uri"`
+	Range lspRange `json:"range"`
+}
+
+type lspDiagnostic struct {
+	Range    lspRange `json:"range"`
+	Severity int      `json:"severity"`
+	Source   string   `json:"source"`
+	Message  string   `json:"message"`
+}
+
+type lspTextDocumentPositionParams struct {
+	TextDocument struct {
+		URI string `json:"uri"`
+	} `json:"textDocument"`
+	Position lspPosition `json:"position"`
+}
+
+type lspCompletionItem struct {
+	Label         string         `json:"label"`
+	Detail        string         `json:"detail,omitempty"`
+	Documentation *lspMarkupText `json:"documentation,omitempty"`
+	TextEdit      *lspTextEdit   `json:"textEdit,omitempty"`
+}
+
+type lspTextEdit struct {
+	Range   lspRange `json:"range"`
+	NewText string   `json:"newText"`
+}
+
+type lspMarkupText struct {
+	Kind  string `json:"kind"`
+	Value string `json:"value"`
+}
+
+type lspHover struct {
+	Contents lspMarkupText `json:"contents"`
+	Range    lspRange      `json:"range"`
+}
+
+// shellLanguageServer implements the Language Server Protocol for Dagger
+// shell scripts, over a stream such as standard input/output.
+//
+// It reuses the shell's completion contexts to resolve what each command in
+// a script refers to, against the type definitions of the loaded module and
+// the core API.
+type shellLanguageServer struct {
+	h *shellCallHandler
+
+	out   io.Writer
+	outMu sync.Mutex
+
+	// docs are the contents of the open documents, by URI
+	docs map[string]string
+
+	// contextDir is the local context directory of the loaded module, which
+	// its source maps are relative to
+	contextDir     string
+	contextDirOnce sync.Once
+
+	shutdown bool
+}
+
+// ServeLSP runs a language server for Dagger shell scripts until the client
+// asks it to exit or closes the input.
+func (h *shellCallHandler) ServeLSP(ctx context.Context, in io.Reader, out io.Writer) error {
+	s := &shellLanguageServer{
+		h:    h,
+		out:  out,
+		docs: map[string]string{},
+	}
+	r := bufio.NewReader(in)
+	for {
+		msg, err := readLSPMessage(r)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		if msg.Method == "exit" {
+			if !s.shutdown {
+				return errors.New("language server exited without shutdown")
+			}
+			return nil
+		}
+		result, err := s.handle(ctx, msg)
+		if msg.ID == nil {
+			// notifications don't have a response
+			continue
+		}
+		if err := s.respond(msg.ID, result, err); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *shellLanguageServer) handle(ctx context.Context, msg *lspMessage) (any, error) {
+	switch msg.Method {
+	case "initialize":
+		return map[string]any{
+			"capabilities": map[string]any{
+				// full document sync
+				"textDocumentSync": 1,
+				"completionProvider": map[string]any{
+					"triggerCharacters": []string{"|", "-", " "},
+				},
+				"hoverProvider":      true,
+				"definitionProvider": true,
+			},
+			"serverInfo": map[string]any{
+				"name":    "dagger shell",
+				"version": engine.Version,
+			},
+		}, nil
+
+	case "shutdown":
+		s.shutdown = true
+		return nil, nil
+
+	case "textDocument/didOpen":
+		var params struct {
+			TextDocument struct {
+				URI  string `json:"uri"`
+				Text string `json:"text"`
+			} `json:"textDocument"`
+		}
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return nil, err
+		}
+		return nil, s.update(params.TextDocument.URI, params.TextDocument.Text)
+
+	case "textDocument/didChange":
+		var params struct {
+			TextDocument struct {
+				URI string `json:"uri"`
+			} `json:"textDocument"`
+			ContentChanges []struct {
+				Text string `json:"text"`
+			} `json:"contentChanges"`
+		}
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return nil, err
+		}
+		if len(params.ContentChanges) == 0 {
+			return nil, nil
+		}
+		// with full document sync, the last change has the whole content
+		text := params.ContentChanges[len(params.ContentChanges)-1].Text
+		return nil, s.update(params.TextDocument.URI, text)
+
+	case "textDocument/didClose":
+		var params struct {
+			TextDocument struct {
+				URI string `json:"uri"`
+			} `json:"textDocument"`
+		}
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return nil, err
+		}
+		delete(s.docs, params.TextDocument.URI)
+		return nil, s.notify("textDocument/publishDiagnostics", map[string]any{
+			"uri":         params.TextDocument.URI,
+			"diagnostics": []lspDiagnostic{},
+		})
+
+	case "textDocument/completion":
+		var params lspTextDocumentPositionParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return nil, err
+		}
+		text := s.docs[params.TextDocument.URI]
+		return s.completion(text, lspOffset(text, params.Position)), nil
+
+	case "textDocument/hover":
+		var params lspTextDocumentPositionParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return nil, err
+		}
+		text := s.docs[params.TextDocument.URI]
+		return s.hover(text, lspOffset(text, params.Position)), nil
+
+	case "textDocument/definition":
+		var params lspTextDocumentPositionParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return nil, err
+		}
+		text := s.docs[params.TextDocument.URI]
+		return s.definition(ctx, text, lspOffset(text, params.Position)), nil
+	}
+
+	if msg.ID != nil && !strings.HasPrefix(msg.Method, "$/") {
+		return nil, &lspError{
+			Code:    lspMethodNotFound,
+			Message: fmt.Sprintf("method %q not supported", msg.Method),
+		}
+	}
+	return nil, nil
+}
+
+func (e *lspError) Error() string {
+	return e.Message
+}
+
+// update stores the new content of a document and publishes its
+// diagnostics.
+func (s *shellLanguageServer) update(uri, text string) error {
+	s.docs[uri] = text
+	diagnostics := s.diagnostics(text)
+	if diagnostics == nil {
+		diagnostics = []lspDiagnostic{}
+	}
+	return s.notify("textDocument/publishDiagnostics", map[string]any{
+		"uri":         uri,
+		"diagnostics": diagnostics,
+	})
+}
+
+func (s *shellLanguageServer) completion(text string, offset int) []lspCompletionItem {
+	prefix, matches, ok := (&shellAutoComplete{s.h}).complete(text[:offset])
+	if !ok {
+		return []lspCompletionItem{}
+	}
+	rng := lspRange{
+		Start: lspPos(text, offset-len(prefix)),
+		End:   lspPos(text, offset),
+	}
+	items := make([]lspCompletionItem, 0, len(matches))
+	for _, match := range matches {
+		items = append(items, lspCompletionItem{
+			Label:    match,
+			TextEdit: &lspTextEdit{Range: rng, NewText: match},
+		})
+	}
+	return items
+}
+
+func (s *shellLanguageServer) hover(text string, offset int) *lspHover {
+	call, i := s.callAt(text, offset)
+	if call == nil {
+		return nil
+	}
+	word := call.Call.Args[i]
+	var doc string
+	if fn := call.function(); fn != nil {
+		def := s.h.GetDef(nil)
+		if i == 0 {
+			doc = markdownDoc(s.h.FunctionFullUseLine(def, fn), fn.Description)
+		} else if arg := call.flag(i); arg != nil {
+			doc = markdownDoc(arg.Usage(), arg.Description)
+		}
+	} else if i == 0 {
+		if cmd := s.command(word.Lit()); cmd != nil {
+			doc = markdownDoc(cmd.Use, cmd.Description)
+		}
+	}
+	if doc == "" {
+		return nil
+	}
+	return &lspHover{
+		Contents: lspMarkupText{Kind: "markdown", Value: doc},
+		Range:    wordRange(text, word),
+	}
+}
+
+func (s *shellLanguageServer) definition(ctx context.Context, text string, offset int) []lspLocation {
+	call, i := s.callAt(text, offset)
+	if call == nil {
+		return nil
+	}
+	fn := call.function()
+	if fn == nil {
+		return nil
+	}
+	sourceMap := fn.SourceMap
+	if i > 0 {
+		arg := call.flag(i)
+		if arg == nil {
+			return nil
+		}
+		sourceMap = arg.SourceMap
+	}
+	if sourceMap == nil {
+		return nil
+	}
+
+	var uri string
+	if md, _ := s.h.GetModuleDef(nil); md != nil && md.Name == sourceMap.Module {
+		if dir := s.localContextDir(ctx, md); dir != "" {
+			uri = (&url.URL{Scheme: "file", Path: filepath.ToSlash(filepath.Join(dir, sourceMap.Filename))}).String()
+		}
+	}
+	if uri == "" {
+		// a remote module, which can only be linked to
+		uri = sourceMap.URL
+	}
+	if uri == "" {
+		return nil
+	}
+	pos := lspPosition{Line: max(sourceMap.Line-1, 0), Character: max(sourceMap.Column-1, 0)}
+	return []lspLocation{{URI: uri, Range: lspRange{Start: pos, End: pos}}}
+}
+
+func (s *shellLanguageServer) localContextDir(ctx context.Context, md *moduleDef) string {
+	s.contextDirOnce.Do(func() {
+		if md.SourceKind != dagger.ModuleSourceKindLocalSource {
+			return
+		}
+		s.contextDir, _ = md.Source.LocalContextDirectoryPath(ctx)
+	})
+	return s.contextDir
+}
+
+// command returns the builtin or standard library command with the given
+// name.
+func (s *shellLanguageServer) command(name string) *ShellCommand {
+	if cmd, _ := s.h.BuiltinCommand(name); cmd != nil {
+		return cmd
+	}
+	if cmd, _ := s.h.StdlibCommand(name); cmd != nil {
+		return cmd
+	}
+	return nil
+}
+
+// callAt returns the command with a word at the given offset, and the
+// index of that word.
+func (s *shellLanguageServer) callAt(text string, offset int) (*shellScriptCall, int) {
+	file, err := parseShell(strings.NewReader(text), "", syntax.RecoverErrors(5))
+	if err != nil {
+		return nil, 0
+	}
+	for _, call := range (&shellAutoComplete{s.h}).scriptCalls(file) {
+		for i, word := range call.Call.Args {
+			if !word.Pos().IsValid() {
+				continue
+			}
+			if int(word.Pos().Offset()) <= offset && offset <= int(word.End().Offset()) {
+				return &call, i
+			}
+		}
+	}
+	return nil, 0
+}
+
+func (s *shellLanguageServer) diagnostics(text string) []lspDiagnostic {
+	file, err := syntax.NewParser(syntax.Variant(syntax.LangPOSIX)).Parse(strings.NewReader(text), "")
+	if err != nil {
+		var perr syntax.ParseError
+		if errors.As(err, &perr) {
+			pos := lspPos(text, int(perr.Pos.Offset()))
+			return []lspDiagnostic{{
+				Range:    lspRange{Start: pos, End: pos},
+				Severity: lspSeverityError,
+				Source:   "dagger",
+				Message:  perr.Text,
+			}}
+		}
+		return nil
+	}
+
+	// shell functions declared in the script can be called like commands
+	declared := map[string]bool{}
+	syntax.Walk(file, func(node syntax.Node) bool {
+		if decl, ok := node.(*syntax.FuncDecl); ok {
+			declared[decl.Name.Value] = true
+		}
+		return true
+	})
+
+	var diagnostics []lspDiagnostic
+	report := func(word *syntax.Word, format string, args ...any) {
+		diagnostics = append(diagnostics, lspDiagnostic{
+			Range:    wordRange(text, word),
+			Severity: lspSeverityError,
+			Source:   "dagger",
+			Message:  fmt.Sprintf(format, args...),
+		})
+	}
+
+	def := s.h.GetDef(nil)
+	for _, call := range (&shellAutoComplete{s.h}).scriptCalls(file) {
+		if call.Prev == nil {
+			continue
+		}
+		nameWord := call.Call.Args[0]
+		name := nameWord.Lit()
+		if name == "" || strings.HasPrefix(name, ".") {
+			continue
+		}
+		switch {
+		case call.Prev.ModType != nil:
+			if call.Next == nil {
+				report(nameWord, "no function %q in type %q", name, call.Prev.ModType.ProviderName())
+			}
+		case call.Prev.root:
+			if call.Next == nil && !declared[name] && !s.knownCommand(name) {
+				report(nameWord, "function or module %q not found", name)
+			}
+		}
+
+		fn := call.function()
+		if fn == nil {
+			continue
+		}
+		reqs := fn.RequiredArgs()
+		var positional int
+		words := call.Call.Args[1:]
+		for i := 0; i < len(words); i++ {
+			word := words[i]
+			lit := word.Lit()
+			flag, isFlag := strings.CutPrefix(lit, "--")
+			if !isFlag || flag == "" {
+				if lit != "" && positional < len(reqs) {
+					if err := checkArgValue(def, reqs[positional], lit); err != nil {
+						report(word, "%s", err)
+					}
+				}
+				positional++
+				continue
+			}
+			flag, value, hasValue := strings.Cut(flag, "=")
+			arg, err := fn.GetArg(flag)
+			if err != nil {
+				report(word, "%s", err)
+				continue
+			}
+			if !hasValue && arg.TypeDef.Kind != dagger.TypeDefKindBooleanKind && i+1 < len(words) {
+				i++
+				word = words[i]
+				value = word.Lit()
+				hasValue = value != ""
+			}
+			if hasValue {
+				if err := checkArgValue(def, arg, value); err != nil {
+					report(word, "%s", err)
+				}
+			}
+		}
+	}
+	return diagnostics
+}
+
+// knownCommand returns whether a command at the start of a pipeline can be
+// resolved at runtime, even though it has no type definition.
+func (s *shellLanguageServer) knownCommand(name string) bool {
+	if interp.IsBuiltin(name) || strings.HasPrefix(name, shellInterpBuiltinPrefix) {
+		return true
+	}
+	if cmd, _ := s.h.StdlibCommand(name); cmd != nil {
+		return true
+	}
+	if md, _ := s.h.GetModuleDef(nil); md != nil {
+		if md.Name == name || md.GetDependency(name) != nil {
+			return true
+		}
+	}
+	// references to modules, loaded when the script runs
+	return strings.ContainsAny(name, "/.:@") || s.h.IsDefaultModule(name)
+}
+
+// checkArgValue validates a literal argument value against the argument's
+// type.
+func checkArgValue(def *moduleDef, arg *modFunctionArg, value string) error {
+	var expected string
+	switch arg.TypeDef.Kind {
+	case dagger.TypeDefKindIntegerKind:
+		if _, err := strconv.Atoi(value); err == nil {
+			return nil
+		}
+		expected = "an integer"
+	case dagger.TypeDefKindFloatKind:
+		if _, err := strconv.ParseFloat(value, 64); err == nil {
+			return nil
+		}
+		expected = "a number"
+	case dagger.TypeDefKindBooleanKind:
+		if _, err := strconv.ParseBool(value); err == nil {
+			return nil
+		}
+		expected = "a boolean"
+	case dagger.TypeDefKindEnumKind:
+		def.LoadTypeDef(arg.TypeDef)
+		enum := arg.TypeDef.AsEnum
+		if enum == nil || len(enum.Members) == 0 {
+			return nil
+		}
+		for _, name := range enum.ValueNames() {
+			if strings.EqualFold(name, value) {
+				return nil
+			}
+		}
+		expected = "one of " + strings.Join(enum.ValueNames(), ", ")
+	default:
+		return nil
+	}
+	return fmt.Errorf("invalid value %q for argument %q: expected %s", value, arg.FlagName(), expected)
+}
+
+// shellScriptCall is a command in a script, with the completion contexts
+// it's resolved from and to.
+type shellScriptCall struct {
+	Call *syntax.CallExpr
+
+	// Prev is the context the command is resolved in, or nil if it's
+	// unknown, e.g. after an unresolved command in a pipeline
+	Prev *CompletionContext
+
+	// Next is the context the command resolves to, or nil if it can't be
+	// resolved
+	Next *CompletionContext
+}
+
+// function returns the function the command calls, if any.
+func (c *shellScriptCall) function() *modFunction {
+	if c.Next == nil {
+		return nil
+	}
+	return c.Next.ModFunction
+}
+
+// flag returns the argument of the function set by the flag at the given
+// word index, if any.
+func (c *shellScriptCall) flag(i int) *modFunctionArg {
+	fn := c.function()
+	if fn == nil {
+		return nil
+	}
+	name, ok := strings.CutPrefix(c.Call.Args[i].Lit(), "--")
+	if !ok {
+		return nil
+	}
+	name, _, _ = strings.Cut(name, "=")
+	arg, _ := fn.GetArg(name)
+	return arg
+}
+
+// scriptCalls resolves all the commands in a script, including those in
+// command substitutions.
+func (h *shellAutoComplete) scriptCalls(file *syntax.File) []shellScriptCall {
+	var calls []shellScriptCall
+
+	var resolve func(prev *CompletionContext, stmt *syntax.Stmt) *CompletionContext
+	resolve = func(prev *CompletionContext, stmt *syntax.Stmt) *CompletionContext {
+		switch cmd := stmt.Cmd.(type) {
+		case *syntax.CallExpr:
+			if len(cmd.Args) == 0 || !cmd.Args[0].Pos().IsValid() {
+				// assignments, or commands added by the parser
+				return prev
+			}
+			var next *CompletionContext
+			if name := cmd.Args[0].Lit(); prev != nil && name != "" {
+				args := make([]string, 0, len(cmd.Args)-1)
+				for _, arg := range cmd.Args[1:] {
+					args = append(args, arg.Lit())
+				}
+				next = prev.lookupField(name, args)
+			}
+			calls = append(calls, shellScriptCall{
+				Call: cmd,
+				Prev: prev,
+				Next: next,
+			})
+			return next
+		case *syntax.BinaryCmd:
+			if cmd.Op != syntax.Pipe {
+				return nil
+			}
+			next := resolve(prev, cmd.X)
+			if next != nil {
+				next = next.lookupType()
+			}
+			return resolve(next, cmd.Y)
+		}
+		return nil
+	}
+
+	// statements in pipes are resolved with their pipeline
+	piped := map[*syntax.Stmt]struct{}{}
+	syntax.Walk(file, func(node syntax.Node) bool {
+		switch node := node.(type) {
+		case *syntax.BinaryCmd:
+			if node.Op == syntax.Pipe {
+				piped[node.X] = struct{}{}
+				piped[node.Y] = struct{}{}
+			}
+		case *syntax.Stmt:
+			if _, ok := piped[node]; !ok {
+				resolve(h.root(), node)
+			}
+		}
+		return true
+	})
+	return calls
+}
+
+func markdownDoc(usage, description string) string {
+	doc := "```\n" + usage + "\n```"
+	if description != "" {
+		doc += "\n\n" + description
+	}
+	return doc
+}
+
+func wordRange(text string, word *syntax.Word) lspRange {
+	return lspRange{
+		Start: lspPos(text, int(word.Pos().Offset())),
+		End:   lspPos(text, int(word.End().Offset())),
+	}
+}
+
+// lspOffset returns the byte offset in text of a position, whose character
+// is counted in UTF-16 code units.
+func lspOffset(text string, pos lspPosition) int {
+	offset := 0
+	for line := 0; line < pos.Line; line++ {
+		i := strings.IndexByte(text[offset:], '\n')
+		if i < 0 {
+			return len(text)
+		}
+		offset += i + 1
+	}
+	for units := 0; units < pos.Character && offset < len(text); {
+		r, size := utf8.DecodeRuneInString(text[offset:])
+		if r == '\n' {
+			break
+		}
+		units += utf16.RuneLen(r)
+		offset += size
+	}
+	return offset
+}
+
+// lspPos returns the position of a byte offset in text.
+func lspPos(text string, offset int) lspPosition {
+	offset = min(offset, len(text))
+	before := text[:offset]
+	line := strings.Count(before, "\n")
+	lineStart := strings.LastIndexByte(before, '\n') + 1
+	var character int
+	for _, r := range before[lineStart:] {
+		character += utf16.RuneLen(r)
+	}
+	return lspPosition{Line: line, Character: character}
+}
+
+func readLSPMessage(r *bufio.Reader) (*lspMessage, error) {
+	header, err := textproto.NewReader(r).ReadMIMEHeader()
+	if err != nil {
+		return nil, err
+	}
+	length, err := strconv.Atoi(header.Get("Content-Length"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid Content-Length header: %w", err)
+	}
+	body := make([]byte, length)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return nil, err
+	}
+	var msg lspMessage
+	if err := json.Unmarshal(body, &msg); err != nil {
+		return nil, fmt.Errorf("invalid message: %w", err)
+	}
+	return &msg, nil
+}
+
+func (s *shellLanguageServer) write(msg *lspMessage) error {
+	msg.JSONRPC = "2.0"
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	s.outMu.Lock()
+	defer s.outMu.Unlock()
+	if _, err := fmt.Fprintf(s.out, "Content-Length: %d\r\n\r\n", len(body)); err != nil {
+		return err
+	}
+	_, err = s.out.Write(body)
+	return err
+}
+
+func (s *shellLanguageServer) respond(id *json.RawMessage, result any, err error) error {
+	if err != nil {
+		var lerr *lspError
+		if !errors.As(err, &lerr) {
+			lerr = &lspError{Code: lspInvalidParams, Message: err.Error()}
+		}
+		return s.write(&lspMessage{ID: id, Error: lerr})
+	}
+	dt, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+	return s.write(&lspMessage{ID: id, Result: dt})
+}
+
+func (s *shellLanguageServer) notify(method string, params any) error {
+	dt, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+	return s.write(&lspMessage{Method: method, Params: dt})
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const lspTestTypeDefs = `[
+	{"kind": "OBJECT_KIND", "asObject": {"name": "Query", "functions": [
+		{"name": "container", "description": "Creates a scratch container.", "returnType": {"kind": "OBJECT_KIND", "asObject": {"name": "Container"}}, "args": []}
+	]}},
+	{"kind": "OBJECT_KIND", "asObject": {"name": "Container", "functions": [
+		{"name": "from", "description": "Initializes this container from a pulled base image.", "returnType": {"kind": "OBJECT_KIND", "asObject": {"name": "Container"}}, "args": [
+			{"name": "address", "description": "Image's address from its registry.", "typeDef": {"kind": "STRING_KIND"}}
+		]},
+		{"name": "withExposedPort", "returnType": {"kind": "OBJECT_KIND", "asObject": {"name": "Container"}}, "args": [
+			{"name": "port", "typeDef": {"kind": "INTEGER_KIND"}},
+			{"name": "protocol", "typeDef": {"kind": "ENUM_KIND", "optional": true, "asEnum": {"name": "NetworkProtocol"}}},
+			{"name": "experimentalSkipHealthcheck", "description": "Skip the health check when run as a service.", "typeDef": {"kind": "BOOLEAN_KIND", "optional": true}}
+		]},
+		{"name": "stdout", "returnType": {"kind": "STRING_KIND"}, "args": []}
+	]}},
+	{"kind": "ENUM_KIND", "asEnum": {"name": "NetworkProtocol", "members": [{"name": "TCP"}, {"name": "UDP"}]}}
+]`
+
+func newLSPTestServer(t *testing.T) *shellLanguageServer {
+	t.Helper()
+	var typeDefs []*modTypeDef
+	require.NoError(t, json.Unmarshal([]byte(lspTestTypeDefs), &typeDefs))
+	def := &moduleDef{}
+	require.NoError(t, def.setTypeDefs(typeDefs))
+
+	h := &shellCallHandler{}
+	h.modDefs.Store("", def)
+	h.registerCommands()
+	return &shellLanguageServer{h: h, docs: map[string]string{}}
+}
+
+func TestShellLSPDiagnostics(t *testing.T) {
+	s := newLSPTestServer(t)
+
+	for _, tc := range []struct {
+		script  string
+		message string
+		line    int
+	}{
+		{`container | from alpine | stdout`, "", 0},
+		{"f() { container; }\nf | stdout", "", 0},
+		{`container | with-exposed-port 80 --protocol udp --experimental-skip-healthcheck`, "", 0},
+		{`echo hello`, "", 0},
+		{`github.com/dagger/dagger | foo`, "", 0},
+		{"container |\n  frm alpine", `no function "frm" in type "Container"`, 1},
+		{`contaner | from alpine`, `function or module "contaner" not found`, 0},
+		{`container | from --addr alpine`, `no argument "addr" in function "from"`, 0},
+		{`container | with-exposed-port eighty`, `invalid value "eighty" for argument "port": expected an integer`, 0},
+		{`container | with-exposed-port 80 --protocol=sctp`, `invalid value "sctp" for argument "protocol": expected one of TCP, UDP`, 0},
+		{`container | from "alpine`, `reached EOF without closing quote "`, 0},
+	} {
+		t.Run(tc.script, func(t *testing.T) {
+			diagnostics := s.diagnostics(tc.script)
+			if tc.message == "" {
+				require.Empty(t, diagnostics)
+				return
+			}
+			require.Len(t, diagnostics, 1)
+			require.Equal(t, tc.message, diagnostics[0].Message)
+			require.Equal(t, tc.line, diagnostics[0].Range.Start.Line)
+		})
+	}
+}
+
+func TestShellLSPHover(t *testing.T) {
+	s := newLSPTestServer(t)
+	script := "container |\n  from --address alpine"
+
+	hover := s.hover(script, lspOffset(script, lspPosition{Line: 1, Character: 3}))
+	require.NotNil(t, hover)
+	require.Contains(t, hover.Contents.Value, "from <address>")
+	require.Contains(t, hover.Contents.Value, "Initializes this container from a pulled base image.")
+	require.Equal(t, lspRange{
+		Start: lspPosition{Line: 1, Character: 2},
+		End:   lspPosition{Line: 1, Character: 6},
+	}, hover.Range)
+
+	hover = s.hover(script, lspOffset(script, lspPosition{Line: 1, Character: 10}))
+	require.NotNil(t, hover)
+	require.Contains(t, hover.Contents.Value, "Image's address from its registry.")
+
+	require.Nil(t, s.hover(script, lspOffset(script, lspPosition{Line: 1, Character: 20})))
+}
+
+func TestShellLSPCompletion(t *testing.T) {
+	s := newLSPTestServer(t)
+
+	script := "container | with-exposed-port 80 --exp"
+	items := s.completion(script, len(script))
+	require.Len(t, items, 1)
+	require.Equal(t, "--experimental-skip-healthcheck", items[0].Label)
+	require.Equal(t, lspPosition{Line: 0, Character: 33}, items[0].TextEdit.Range.Start)
+
+	script = "container | st"
+	items = s.completion(script, len(script))
+	require.Len(t, items, 1)
+	require.Equal(t, "stdout", items[0].Label)
+}
+
+func TestShellLSPPositions(t *testing.T) {
+	text := "a\n😀b|c\n"
+	for offset, pos := range map[int]lspPosition{
+		0:  {0, 0},
+		2:  {1, 0},
+		6:  {1, 2},
+		8:  {1, 4},
+		10: {2, 0},
+	} {
+		require.Equal(t, pos, lspPos(text, offset), "offset %d", offset)
+		require.Equal(t, offset, lspOffset(text, pos), "position %v", pos)
+	}
+}
+
+func TestShellLSPServe(t *testing.T) {
+	s := newLSPTestServer(t)
+
+	in := new(bytes.Buffer)
+	send := func(msg string) {
+		fmt.Fprintf(in, "Content-Length: %d\r\n\r\n%s", len(msg), msg)
+	}
+	send(`{"jsonrpc": "2.0", "id": 1, "method": "initialize", "params": {}}`)
+	send(`{"jsonrpc": "2.0", "method": "initialized", "params": {}}`)
+	send(`{"jsonrpc": "2.0", "method": "textDocument/didOpen", "params": {"textDocument": {"uri": "file:///ci.dagger", "text": "contaner"}}}`)
+	send(`{"jsonrpc": "2.0", "id": 2, "method": "textDocument/foo", "params": {}}`)
+	send(`{"jsonrpc": "2.0", "id": 3, "method": "shutdown"}`)
+	send(`{"jsonrpc": "2.0", "method": "exit"}`)
+
+	out := new(bytes.Buffer)
+	require.NoError(t, s.h.ServeLSP(context.Background(), in, out))
+
+	r := bufio.NewReader(out)
+	var msgs []*lspMessage
+	for {
+		msg, err := readLSPMessage(r)
+		if err != nil {
+			break
+		}
+		msgs = append(msgs, msg)
+	}
+	require.Len(t, msgs, 4)
+
+	require.JSONEq(t, `1`, string(*msgs[0].ID))
+	require.Contains(t, string(msgs[0].Result), `"hoverProvider":true`)
+
+	require.Equal(t, "textDocument/publishDiagnostics", msgs[1].Method)
+	require.Contains(t, string(msgs[1].Params), `function or module \"contaner\" not found`)
+
+	require.JSONEq(t, `2`, string(*msgs[2].ID))
+	require.Equal(t, lspMethodNotFound, msgs[2].Error.Code)
+
+	require.JSONEq(t, `3`, string(*msgs[3].ID))
+	require.JSONEq(t, `null`, string(msgs[3].Result))
+}
@@ -0,0 +1,280 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+
+	"dagger.io/dagger"
+	"mvdan.cc/sh/v3/expand"
+	"mvdan.cc/sh/v3/syntax"
+)
+
+// shellSessionVersion is the version of the session file format
+const shellSessionVersion = 1
+
+// shellSessionSkipVars are variables set by the interpreter, which aren't
+// part of a session.
+var shellSessionSkipVars = map[string]bool{
+	"HOME":       true,
+	"IFS":        true,
+	"OLDPWD":     true,
+	"OPTIND":     true,
+	"PPID":       true,
+	"PWD":        true,
+	lastValueVar: true,
+	// saved as the session's LLM instead
+	agentVar: true,
+}
+
+// shellSession is the saved state of a shell session, that can be loaded
+// back into another one.
+//
+// Objects are saved as IDs, so they resolve against the same cache when
+// loaded, as long as the engine still has it.
+type shellSession struct {
+	Version int `json:"version"`
+
+	// Workdir is the working directory, as changed with `.cd`
+	Workdir shellSessionWorkdir `json:"workdir"`
+
+	// Model is the LLM model of the session
+	Model string `json:"model,omitempty"`
+
+	// LLM is the ID of the session's LLM, with its conversation so far
+	LLM string `json:"llm,omitempty"`
+
+	// Variables are the shell variables, with state tokens referring to
+	// Objects
+	Variables map[string]string `json:"variables"`
+
+	// Objects are the states referred to by variables, by state key
+	Objects map[string]shellSessionObject `json:"objects,omitempty"`
+}
+
+type shellSessionWorkdir struct {
+	// Module is the reference to the module loaded in the working directory
+	Module string `json:"module,omitempty"`
+
+	// Path is the absolute path of the working directory, in its context
+	Path string `json:"path"`
+}
+
+type shellSessionObject struct {
+	// Type is the name of the object's type
+	Type string `json:"type,omitempty"`
+
+	// ID is the object's ID
+	ID string `json:"id,omitempty"`
+
+	// Module is the reference to the module the object's type comes from
+	Module string `json:"module,omitempty"`
+
+	// Cmd is set instead of an object for a namespace like `.stdlib`
+	Cmd string `json:"cmd,omitempty"`
+}
+
+// SaveSession writes the variables, working directory and LLM of the shell
+// session to a file.
+func (h *shellCallHandler) SaveSession(ctx context.Context, path string) error {
+	session := shellSession{
+		Version:   shellSessionVersion,
+		Variables: map[string]string{},
+		Objects:   map[string]shellSessionObject{},
+	}
+
+	wd := h.Workdir()
+	session.Workdir.Path = wd.Path
+	if wd.Module != "" && wd.Context != nil {
+		session.Workdir.Module = wd.Context.ModRef("")
+	}
+
+	h.llmL.Lock()
+	session.Model = h.llmModel
+	llm := h.llmSession
+	h.llmL.Unlock()
+	if llm != nil && llm.llm != nil {
+		id, err := llm.llm.ID(ctx)
+		if err != nil {
+			return fmt.Errorf("save LLM: %w", err)
+		}
+		session.LLM = string(id)
+	}
+
+	hctx := HandlerCtx(ctx)
+	if hctx == nil {
+		return fmt.Errorf("no shell environment to save")
+	}
+	for name, v := range hctx.Env.Each {
+		if !v.IsSet() || v.Kind != expand.String || v.Exported || v.ReadOnly || shellSessionSkipVars[name] {
+			continue
+		}
+		value := v.String()
+		for _, token := range FindStateTokens(value) {
+			key := GetStateKey(token)
+			if _, ok := session.Objects[key]; ok {
+				continue
+			}
+			obj, resolved, err := h.sessionObject(ctx, key)
+			if err != nil {
+				return fmt.Errorf("save variable %q: %w", name, err)
+			}
+			if obj == nil {
+				// not an object, so save the value itself
+				value = strings.ReplaceAll(value, token, resolved)
+				continue
+			}
+			session.Objects[key] = *obj
+		}
+		session.Variables[name] = value
+	}
+
+	dt, err := json.MarshalIndent(session, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(dt, '\n'), 0o600)
+}
+
+// sessionObject returns how to save a state. If the state doesn't return
+// an object, it's resolved to its value instead.
+func (h *shellCallHandler) sessionObject(ctx context.Context, key string) (*shellSessionObject, string, error) {
+	st, err := h.state.Load(key)
+	if err != nil {
+		return nil, "", err
+	}
+	if st.IsCommandRoot() {
+		return &shellSessionObject{Cmd: st.Cmd}, "", nil
+	}
+
+	def := h.GetDef(st)
+	if def.HasModule() && st.IsEmpty() {
+		st, err = h.constructorCall(ctx, def, st, nil)
+		if err != nil {
+			return nil, "", err
+		}
+	}
+	fn, err := st.Function().GetDef(def)
+	if err != nil {
+		return nil, "", err
+	}
+	if fn.ReturnType.AsFunctionProvider() == nil || fn.ReturnType.AsList != nil {
+		r, err := h.StateResult(ctx, st)
+		if err != nil {
+			return nil, "", err
+		}
+		value, err := r.String()
+		return nil, value, err
+	}
+
+	obj := &shellSessionObject{
+		Type: fn.ReturnType.Name(),
+	}
+	if err := makeRequest(ctx, st.QueryBuilder(h.dag).Select("id"), &obj.ID); err != nil {
+		return nil, "", err
+	}
+	if st.ModDigest != "" {
+		obj.Module = def.SourceRoot
+	}
+	return obj, "", nil
+}
+
+// LoadSession restores the working directory, LLM and objects of a saved
+// session, and returns the shell code that assigns its variables.
+//
+// Handlers can't change the interpreter's variables so the code needs to be
+// evaluated by the interpreter itself.
+func (h *shellCallHandler) LoadSession(ctx context.Context, path string) (string, error) {
+	dt, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	var session shellSession
+	if err := json.Unmarshal(dt, &session); err != nil {
+		return "", fmt.Errorf("invalid session file %q: %w", path, err)
+	}
+	if session.Version != shellSessionVersion {
+		return "", fmt.Errorf("unsupported session file version %d", session.Version)
+	}
+
+	wd := h.Workdir()
+	if mod := session.Workdir.Module; mod != "" && (wd.Context == nil || mod != wd.Context.ModRef("")) {
+		if err := h.ChangeDir(ctx, mod); err != nil {
+			return "", fmt.Errorf("restore module: %w", err)
+		}
+	}
+	if session.Workdir.Path != "" && session.Workdir.Path != h.Workdir().Path {
+		if err := h.ChangeDir(ctx, session.Workdir.Path); err != nil {
+			return "", fmt.Errorf("restore workdir: %w", err)
+		}
+	}
+
+	// don't keep the LLM of the current session around, since it would
+	// carry on with another conversation
+	h.llmL.Lock()
+	h.llmSession = nil
+	h.llmErr = nil
+	if session.Model != "" {
+		h.llmModel = session.Model
+	}
+	h.llmL.Unlock()
+
+	var code strings.Builder
+	if session.LLM != "" {
+		llm, err := h.llm(ctx)
+		if err != nil {
+			return "", fmt.Errorf("restore LLM: %w", err)
+		}
+		if err := llm.updateLLMAndAgentVar(h.dag.LoadLLMFromID(dagger.LLMID(session.LLM))); err != nil {
+			return "", fmt.Errorf("restore LLM: %w", err)
+		}
+	} else {
+		fmt.Fprintf(&code, "%sunset %s\n", shellInterpBuiltinPrefix, agentVar)
+	}
+
+	// objects get new state keys in this session
+	replacements := make([]string, 0, len(session.Objects)*2)
+	for key, obj := range session.Objects {
+		st := ShellState{Cmd: obj.Cmd}
+		if obj.ID != "" {
+			if obj.Module != "" {
+				def, _, err := h.maybeLoadModule(ctx, obj.Module)
+				if err != nil {
+					return "", err
+				}
+				if def == nil {
+					return "", fmt.Errorf("module %q not found", obj.Module)
+				}
+				st.ModDigest = def.SourceDigest
+			}
+			st.Calls = []FunctionCall{
+				{
+					Object: "Query",
+					Name:   "load" + obj.Type + "FromID",
+					Arguments: map[string]any{
+						"id": obj.ID,
+					},
+					ReturnObject: obj.Type,
+				},
+			}
+		}
+		replacements = append(replacements, newStateToken(key), newStateToken(h.state.Store(st)))
+	}
+	replacer := strings.NewReplacer(replacements...)
+
+	for _, name := range slices.Sorted(maps.Keys(session.Variables)) {
+		if !syntax.ValidName(name) {
+			return "", fmt.Errorf("invalid variable name %q", name)
+		}
+		quoted, err := syntax.Quote(replacer.Replace(session.Variables[name]), syntax.LangBash)
+		if err != nil {
+			return "", fmt.Errorf("variable %q: %w", name, err)
+		}
+		fmt.Fprintf(&code, "%s=%s\n", name, quoted)
+	}
+	return code.String(), nil
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"mvdan.cc/sh/v3/interp"
+	"mvdan.cc/sh/v3/syntax"
+)
+
+func TestShellSession(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "session.json")
+
+	newHandler := func(h *shellCallHandler) *shellCallHandler {
+		t.Helper()
+		r, err := interp.New(
+			interp.CallHandler(h.Call),
+			interp.ExecHandlers(func(next interp.ExecHandlerFunc) interp.ExecHandlerFunc {
+				return func(ctx context.Context, args []string) error {
+					if args[0] == shellInternalCmd {
+						args = args[1:]
+					}
+					require.Equal(t, ".save", args[0])
+					return h.SaveSession(ctx, args[1])
+				}
+			}),
+		)
+		require.NoError(t, err)
+		r.Reset()
+		h.runner = r
+		h.state = NewStateStore(r)
+		return h
+	}
+	run := func(h *shellCallHandler, code string) *interp.Runner {
+		t.Helper()
+		file, err := syntax.NewParser().Parse(strings.NewReader(code), "")
+		require.NoError(t, err)
+		require.NoError(t, h.runner.Run(ctx, file))
+		return h.runner
+	}
+
+	saved := newHandler(&shellCallHandler{llmModel: "some-model"})
+	stdlib := newStateToken(saved.state.Store(saved.NewStdlibState()))
+	run(saved, `
+		greeting='hello world'
+		lib=`+stdlib+`
+		export EXPORTED=1
+		.save `+path+`
+	`)
+
+	dt, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var session shellSession
+	require.NoError(t, json.Unmarshal(dt, &session))
+	require.Equal(t, shellSessionVersion, session.Version)
+	require.Equal(t, "some-model", session.Model)
+	require.Equal(t, map[string]string{
+		"greeting": "hello world",
+		"lib":      stdlib,
+	}, session.Variables)
+	require.Equal(t, map[string]shellSessionObject{
+		GetStateKey(stdlib): {Cmd: shellStdlibCmdName},
+	}, session.Objects)
+
+	// the LLM of the current session isn't carried over
+	loaded := newHandler(&shellCallHandler{llmSession: &LLMSession{}})
+	r := run(loaded, `agent=stale; .load `+path)
+	require.Equal(t, "some-model", loaded.llmModel)
+	require.Nil(t, loaded.llmSession)
+	require.False(t, r.Vars[agentVar].IsSet())
+	require.Equal(t, "hello world", r.Vars["greeting"].String())
+
+	// objects are stored in the new session with a new key
+	lib := r.Vars["lib"].String()
+	require.NotEqual(t, stdlib, lib)
+	st, err := loaded.state.Load(GetStateKey(lib))
+	require.NoError(t, err)
+	require.True(t, st.IsStdlib())
+
+	// object IDs are loaded as the first call of the state
+	session.Objects["CTR"] = shellSessionObject{Type: "Container", ID: "some-id"}
+	session.Variables["ctr"] = newStateToken("CTR")
+	dt, err = json.Marshal(session)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, dt, 0o600))
+
+	r = run(loaded, `.load `+path)
+	st, err = loaded.state.Load(GetStateKey(r.Vars["ctr"].String()))
+	require.NoError(t, err)
+	require.Equal(t, []FunctionCall{{
+		Object:       "Query",
+		Name:         "loadContainerFromID",
+		Arguments:    map[string]any{"id": "some-id"},
+		ReturnObject: "Container",
+	}}, st.Calls)
+}
@@ -2,34 +2,281 @@ package main
 
 import (
 	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
 
+	"github.com/muesli/termenv"
 	"github.com/spf13/cobra"
-	"go.opentelemetry.io/otel/trace"
 
+	"dagger.io/dagger"
+	"github.com/dagger/dagger/dagql/idtui"
 	"github.com/dagger/dagger/engine/client"
 )
 
+var (
+	watchJSONOutput bool
+	watchInterval   time.Duration
+)
+
 var watchCmd = &cobra.Command{
-	Use:    "watch",
+	Use:    "watch [options] [session]",
 	Hidden: true,
-	Args:   cobra.NoArgs,
+	Args:   cobra.MaximumNArgs(1),
 	Annotations: map[string]string{
 		"experimental": "true",
 	},
 	Aliases: []string{"w"},
 	Short:   "Watch activity across all Dagger sessions.",
-	Example: `dagger watch`,
-	RunE:    Watch,
+	Long: `Watch activity across all Dagger sessions connected to the engine.
+
+Without arguments, a dashboard of every active session is shown, with the
+command it is running, its modules, its elapsed time, its running execs and
+its cache usage.
+
+Pass a session ID (or a unique prefix of one) to follow that session's live
+span tree instead.`,
+	Example: `dagger watch
+dagger watch --json
+dagger watch 2c1rd8v8`,
+	RunE: Watch,
+}
+
+func init() {
+	watchCmd.Flags().BoolVar(&watchJSONOutput, "json", false, "Print the active sessions in JSON format and exit")
+	watchCmd.Flags().DurationVar(&watchInterval, "interval", time.Second, "How often to refresh the list of sessions")
+}
+
+//go:embed sessions.graphql
+var loadEngineSessionsQuery string
+
+// engineSession mirrors the EngineSession API type.
+type engineSession struct {
+	ID              string   `json:"id"`
+	ClientID        string   `json:"clientID"`
+	ClientHostname  string   `json:"clientHostname"`
+	ClientVersion   string   `json:"clientVersion"`
+	Command         string   `json:"command"`
+	Modules         []string `json:"modules"`
+	StartedUnixNano int64    `json:"startedUnixNano"`
+	RunningExecs    int      `json:"runningExecs"`
+	CacheHits       int      `json:"cacheHits"`
+	CacheMisses     int      `json:"cacheMisses"`
 }
 
-func Watch(cmd *cobra.Command, _ []string) error {
-	// HACK: the PubSub service treats the 000000000 trace ID as "subscribe to
-	// everything", and the client subscribes to its current trace ID, so let's
-	// just zero it out.
-	ctx := trace.ContextWithSpanContext(cmd.Context(), trace.SpanContext{})
+func (sess engineSession) Started() time.Time {
+	return time.Unix(0, sess.StartedUnixNano)
+}
+
+// CacheRatio returns the percentage of cacheable calls that hit the cache.
+func (sess engineSession) CacheRatio() int {
+	total := sess.CacheHits + sess.CacheMisses
+	if total == 0 {
+		return 0
+	}
+	return sess.CacheHits * 100 / total
+}
+
+func Watch(cmd *cobra.Command, args []string) error {
+	return withEngine(cmd.Context(), client.Params{}, func(ctx context.Context, engineClient *client.Client) error {
+		dag := engineClient.Dagger()
+
+		if watchJSONOutput {
+			sessions, err := loadEngineSessions(ctx, dag, engineClient.SessionID)
+			if err != nil {
+				return err
+			}
+			enc := json.NewEncoder(cmd.OutOrStdout())
+			enc.SetIndent("", "  ")
+			return enc.Encode(sessions)
+		}
+
+		if len(args) > 0 {
+			sessions, err := loadEngineSessions(ctx, dag, engineClient.SessionID)
+			if err != nil {
+				return err
+			}
+			sess, err := findEngineSession(sessions, args[0])
+			if err != nil {
+				return err
+			}
+			// the watched session's spans aren't beneath our own primary span
+			Frontend.RevealAllSpans()
+			return engineClient.WatchSession(ctx, sess.ID, sess.ClientID)
+		}
+
+		return watchEngineSessions(ctx, dag, engineClient.SessionID, cmd.OutOrStdout())
+	})
+}
 
-	return withEngine(ctx, client.Params{}, func(ctx context.Context, engineClient *client.Client) error {
-		<-ctx.Done()
-		return ctx.Err()
+func loadEngineSessions(ctx context.Context, dag *dagger.Client, ownSessionID string) ([]engineSession, error) {
+	var res struct {
+		Engine struct {
+			Sessions []engineSession
+		}
+	}
+	err := dag.Do(ctx, &dagger.Request{
+		Query: loadEngineSessionsQuery,
+	}, &dagger.Response{
+		Data: &res,
 	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load engine sessions: %w", err)
+	}
+	sessions := make([]engineSession, 0, len(res.Engine.Sessions))
+	for _, sess := range res.Engine.Sessions {
+		if sess.ID == ownSessionID {
+			// don't show ourselves
+			continue
+		}
+		sessions = append(sessions, sess)
+	}
+	return sessions, nil
+}
+
+// findEngineSession finds the session with the given ID, or the only session
+// whose ID starts with it.
+func findEngineSession(sessions []engineSession, id string) (engineSession, error) {
+	var matches []engineSession
+	for _, sess := range sessions {
+		if sess.ID == id {
+			return sess, nil
+		}
+		if strings.HasPrefix(sess.ID, id) {
+			matches = append(matches, sess)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return engineSession{}, fmt.Errorf("no active session matches %q", id)
+	case 1:
+		return matches[0], nil
+	default:
+		ids := make([]string, len(matches))
+		for i, sess := range matches {
+			ids[i] = sess.ID
+		}
+		return engineSession{}, fmt.Errorf("ambiguous session %q matches: %s", id, strings.Join(ids, ", "))
+	}
+}
+
+// watchEngineSessions polls the engine for active sessions until ctx is
+// canceled, showing them in the sidebar of the TUI, or as a table on the
+// given writer when there's no TTY.
+func watchEngineSessions(ctx context.Context, dag *dagger.Client, ownSessionID string, w io.Writer) error {
+	var mu sync.Mutex
+	var sessions []engineSession
+
+	if hasTTY {
+		Frontend.SetSidebarContent(idtui.SidebarSection{
+			Title: "Sessions",
+			ContentFunc: func(width int) string {
+				mu.Lock()
+				defer mu.Unlock()
+				return renderEngineSessions(sessions, width, time.Now())
+			},
+		})
+	}
+
+	var lastTable string
+	ticker := time.NewTicker(watchInterval)
+	defer ticker.Stop()
+	for {
+		latest, err := loadEngineSessions(ctx, dag, ownSessionID)
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		sessions = latest
+		mu.Unlock()
+
+		if !hasTTY {
+			var buf strings.Builder
+			if err := printEngineSessions(&buf, latest); err != nil {
+				return err
+			}
+			if table := buf.String(); table != lastTable {
+				fmt.Fprint(w, table)
+				lastTable = table
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// renderEngineSessions renders the sessions for display in the sidebar.
+func renderEngineSessions(sessions []engineSession, width int, now time.Time) string {
+	if len(sessions) == 0 {
+		return termenv.String("No active sessions").Faint().String()
+	}
+	var lines []string
+	for i, sess := range sessions {
+		if i > 0 {
+			lines = append(lines, "")
+		}
+		command := sess.Command
+		if command == "" {
+			command = "(unknown command)"
+		}
+		if len(command) > width {
+			command = command[:max(width-1, 0)] + "…"
+		}
+		lines = append(lines, termenv.String(command).Bold().String())
+		lines = append(lines, fmt.Sprintf("  %s · %s",
+			shortSessionID(sess.ID),
+			now.Sub(sess.Started()).Truncate(time.Second)))
+		if sess.ClientHostname != "" {
+			lines = append(lines, "  "+termenv.String(sess.ClientHostname).Faint().String())
+		}
+		if len(sess.Modules) > 0 {
+			lines = append(lines, "  mod: "+strings.Join(sess.Modules, ", "))
+		}
+		lines = append(lines, fmt.Sprintf("  %d running %s · %d%% cached",
+			sess.RunningExecs,
+			plural(sess.RunningExecs, "exec", "execs"),
+			sess.CacheRatio()))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// printEngineSessions prints the sessions as a table. Only the start time is
+// shown, not the elapsed time, so that the table only changes when the
+// sessions do.
+func printEngineSessions(w io.Writer, sessions []engineSession) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', tabwriter.DiscardEmptyColumns)
+	fmt.Fprintf(tw, "SESSION\tCOMMAND\tMODULES\tSTARTED\tEXECS\tCACHED\n")
+	for _, sess := range sessions {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%d\t%d%%\n",
+			shortSessionID(sess.ID),
+			sess.Command,
+			strings.Join(sess.Modules, ","),
+			sess.Started().Format(time.TimeOnly),
+			sess.RunningExecs,
+			sess.CacheRatio())
+	}
+	return tw.Flush()
+}
+
+func shortSessionID(id string) string {
+	if len(id) > 8 {
+		return id[:8]
+	}
+	return id
+}
+
+func plural(n int, singular, plural string) string {
+	if n == 1 {
+		return singular
+	}
+	return plural
 }
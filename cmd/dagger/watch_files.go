@@ -0,0 +1,305 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"dagger.io/dagger"
+	"dagger.io/dagger/querybuilder"
+	"dagger.io/dagger/telemetry"
+	"github.com/dagger/dagger/core/modules"
+	"github.com/dagger/dagger/engine/client"
+	"github.com/dagger/dagger/engine/slog"
+	"github.com/dagger/dagger/util/fsxutil"
+)
+
+// fileWatch is true if the `--watch` flag is used.
+var fileWatch bool
+
+// fileWatchInterval is how often watched files are checked for changes.
+const fileWatchInterval = 500 * time.Millisecond
+
+// watchAndRerun calls run, then calls it again every time a watched file
+// changes, until ctx is canceled. A failed run doesn't stop watching. The
+// files to watch are returned by target after every run, since the run may
+// change them.
+//
+// Every rerun gets a new session so that the module and host directories are
+// loaded again, while the engine cache still skips the steps that weren't
+// affected by the changes.
+func watchAndRerun(
+	ctx context.Context,
+	engineClient *client.Client,
+	params client.Params,
+	target func() (root string, opt fsxutil.WatchOpt, err error),
+	run runClientCallback,
+) error {
+	runOnce(ctx, "run", func(ctx context.Context) error {
+		return run(ctx, engineClient)
+	})
+	for {
+		root, opt, err := target()
+		if err != nil {
+			return fmt.Errorf("failed to watch: %w", err)
+		}
+
+		// take the snapshot after running, so that files written by the run
+		// itself (e.g. generated code) don't trigger another run
+		snapshot, err := fsxutil.TakeSnapshot(ctx, root, opt)
+		if err != nil {
+			return fmt.Errorf("failed to watch %s: %w", root, err)
+		}
+		slog.Info("watching for changes", "path", root)
+
+		_, changes, err := fsxutil.WaitForChanges(ctx, root, opt, snapshot, fileWatchInterval)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("failed to watch %s: %w", root, err)
+		}
+
+		runOnce(ctx, "rerun: "+describeChanges(changes), func(ctx context.Context) error {
+			sess, err := connectEngine(ctx, params)
+			if err != nil {
+				return err
+			}
+			defer sess.Close()
+			return run(ctx, sess)
+		})
+	}
+}
+
+// watch calls the functions, then calls them again every time the module
+// changes. Only the source of the module, its local dependencies and the paths
+// used by its defaultPath arguments are watched, since its context directory
+// is usually a whole git repository.
+func (fc *FuncCommand) watch(c *cobra.Command, a []string) error {
+	ctx := c.Context()
+	loadModule := !fc.DisableModuleLoad && !moduleNoURL
+	root, err := moduleWatchRoot(ctx, fc.c.Dagger(), loadModule)
+	if err != nil {
+		return err
+	}
+	// watch everything until the module loads
+	opt := fsxutil.WatchOpt{GitIgnore: true}
+	target := func() (string, fsxutil.WatchOpt, error) {
+		return root, opt, nil
+	}
+	return watchAndRerun(ctx, fc.c, initModuleParams(a), target, func(ctx context.Context, engineClient *client.Client) error {
+		mod, err := fc.rerun(ctx, c, a, engineClient)
+		if loadModule && mod != nil {
+			// the module may have changed, along with what it uses
+			modOpt, err := moduleWatchOpt(ctx, root, mod)
+			if err != nil {
+				slog.Warn("failed to narrow the watched paths", "error", err)
+				modOpt = fsxutil.WatchOpt{GitIgnore: true}
+			}
+			opt = modOpt
+		}
+		return err
+	})
+}
+
+// rerun executes the command again in a new command tree, since the functions
+// of the module and their arguments may have changed. It returns the module
+// if it was loaded, even if the call failed.
+func (fc *FuncCommand) rerun(ctx context.Context, c *cobra.Command, a []string, engineClient *client.Client) (*moduleDef, error) {
+	run := &FuncCommand{
+		Name:              fc.Name,
+		Annotations:       fc.Annotations,
+		DisableModuleLoad: fc.DisableModuleLoad,
+		c:                 engineClient,
+		q:                 querybuilder.Query().Client(engineClient.Dagger().GraphQLClient()),
+	}
+	run.cmd = &cobra.Command{
+		Use:         fc.Name,
+		Annotations: map[string]string{},
+	}
+	cmd := run.cmd
+	cmd.SetGlobalNormalizationFunc(c.GlobalNormalizationFunc())
+	cmd.PersistentFlags().AddFlagSet(c.PersistentFlags())
+	cmd.Flags().SetInterspersed(false)
+	cmd.InitDefaultHelpFlag()
+
+	// add to the same parent to inherit the global flags
+	c.Parent().AddCommand(cmd)
+	defer c.Parent().RemoveCommand(cmd)
+
+	// show the output of each run in its own span
+	stdio := telemetry.SpanStdio(ctx, InstrumentationLibrary)
+	defer stdio.Close()
+	cmd.SetOut(stdio.Stdout)
+	cmd.SetErr(stdio.Stderr)
+	cmd.SetContext(ctx)
+
+	err := run.execute(cmd, a)
+	return run.mod, err
+}
+
+// runOnce calls run in its own span, which reports the error if it fails.
+func runOnce(ctx context.Context, name string, run func(context.Context) error) {
+	ctx, span := Tracer().Start(ctx, name)
+	err := run(ctx)
+	telemetry.EndWithCause(span, &err)
+}
+
+func describeChanges(changes []string) string {
+	switch len(changes) {
+	case 1:
+		return changes[0] + " changed"
+	case 2:
+		return fmt.Sprintf("%s and 1 other file changed", changes[0])
+	default:
+		return fmt.Sprintf("%s and %d other files changed", changes[0], len(changes)-1)
+	}
+}
+
+// moduleWatchRoot returns the directory to watch for a command loading the
+// default module: the context directory of the module, which contains both its
+// source and the directories used by its defaultPath arguments. Without a
+// module, the current directory is watched.
+func moduleWatchRoot(ctx context.Context, dag *dagger.Client, loadModule bool) (string, error) {
+	if !loadModule {
+		return os.Getwd()
+	}
+	modRef, err := getModuleSourceRefWithDefault()
+	if err != nil {
+		return "", err
+	}
+	kind, err := dag.ModuleSource(modRef).Kind(ctx)
+	if err != nil {
+		return "", err
+	}
+	if kind != dagger.ModuleSourceKindLocalSource {
+		return "", fmt.Errorf("--watch requires a local module, got %s", strings.ToLower(string(kind)))
+	}
+	return dag.ModuleSource(modRef).LocalContextDirectoryPath(ctx)
+}
+
+// moduleDirWatchRoot returns the directory to watch for a module that couldn't
+// be loaded: the directory of its ref if it's local, or else the current
+// directory.
+func moduleDirWatchRoot() (string, error) {
+	modRef, err := getModuleSourceRefWithDefault()
+	if err != nil {
+		return "", err
+	}
+	if info, err := os.Stat(modRef); err == nil && info.IsDir() {
+		return filepath.Abs(modRef)
+	}
+	return os.Getwd()
+}
+
+// moduleWatchOpt returns the options to watch, in the context directory of the
+// given module, its source, the sources of its local dependencies and the paths
+// used by its defaultPath arguments.
+func moduleWatchOpt(ctx context.Context, contextDirPath string, mod *moduleDef) (fsxutil.WatchOpt, error) {
+	srcRootPaths := []string{filepath.Join(contextDirPath, mod.SourceRootSubpath)}
+	for _, dep := range mod.Dependencies {
+		kind, err := dep.Source.Kind(ctx)
+		if err != nil {
+			return fsxutil.WatchOpt{}, err
+		}
+		if kind != dagger.ModuleSourceKindLocalSource {
+			continue
+		}
+		// local dependencies are always within the same context directory
+		depSrcRootSubpath, err := dep.Source.SourceRootSubpath(ctx)
+		if err != nil {
+			return fsxutil.WatchOpt{}, err
+		}
+		srcRootPaths = append(srcRootPaths, filepath.Join(contextDirPath, depSrcRootSubpath))
+	}
+	opt, err := moduleSourceWatchOpt(contextDirPath, srcRootPaths)
+	if err != nil || opt.IncludePatterns == nil {
+		return opt, err
+	}
+	for _, defaultPath := range moduleDefaultPaths(mod) {
+		// relative paths are relative to the source root of the module,
+		// absolute paths to its context directory
+		var subpath string
+		if filepath.IsAbs(defaultPath) {
+			subpath = filepath.Clean(strings.TrimPrefix(defaultPath, "/"))
+		} else {
+			subpath = filepath.Join(strings.TrimPrefix(mod.SourceRootSubpath, "/"), defaultPath)
+		}
+		if subpath == "." {
+			opt.IncludePatterns = nil
+			break
+		}
+		opt.IncludePatterns = append(opt.IncludePatterns, subpath)
+	}
+	return opt, nil
+}
+
+// moduleDefaultPaths returns the defaultPath of every argument of the
+// functions of the given module, excluding its dependencies.
+func moduleDefaultPaths(mod *moduleDef) []string {
+	var paths []string
+	for _, obj := range mod.Objects {
+		if obj.AsObject == nil || obj.AsObject.SourceModuleName != mod.Name {
+			continue
+		}
+		fns := obj.AsObject.Functions
+		if obj.AsObject.Constructor != nil {
+			fns = append([]*modFunction{obj.AsObject.Constructor}, fns...)
+		}
+		for _, fn := range fns {
+			for _, arg := range fn.Args {
+				if arg.DefaultPath != "" && !slices.Contains(paths, arg.DefaultPath) {
+					paths = append(paths, arg.DefaultPath)
+				}
+			}
+		}
+	}
+	return paths
+}
+
+// moduleSourceWatchOpt returns the options to watch the sources of the modules
+// with the given source root directories, including the extra files they
+// include in their dagger.json, relative to their context directory.
+func moduleSourceWatchOpt(contextDirPath string, srcRootPaths []string) (fsxutil.WatchOpt, error) {
+	opt := fsxutil.WatchOpt{GitIgnore: true}
+	var watchAll bool
+	for _, srcRootPath := range srcRootPaths {
+		srcRootSubpath, err := filepath.Rel(contextDirPath, srcRootPath)
+		if err != nil {
+			return opt, err
+		}
+		if srcRootSubpath == "." {
+			watchAll = true
+		}
+		opt.IncludePatterns = append(opt.IncludePatterns, srcRootSubpath)
+
+		cfgBytes, err := os.ReadFile(filepath.Join(srcRootPath, modules.Filename))
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return opt, err
+		}
+		cfg, err := modules.ParseModuleConfig(cfgBytes)
+		if err != nil {
+			return opt, err
+		}
+		for _, pattern := range cfg.Include {
+			if exclude, ok := strings.CutPrefix(pattern, "!"); ok {
+				opt.ExcludePatterns = append(opt.ExcludePatterns, filepath.Join(srcRootSubpath, exclude))
+			} else {
+				opt.IncludePatterns = append(opt.IncludePatterns, filepath.Join(srcRootSubpath, pattern))
+			}
+		}
+	}
+	if watchAll {
+		opt.IncludePatterns = nil
+	}
+	return opt, nil
+}
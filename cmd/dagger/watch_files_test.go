@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dagger/dagger/util/fsxutil"
+)
+
+func TestDescribeChanges(t *testing.T) {
+	require.Equal(t, "main.go changed", describeChanges([]string{"main.go"}))
+	require.Equal(t, "go.mod and 1 other file changed", describeChanges([]string{"go.mod", "main.go"}))
+	require.Equal(t, "go.mod and 2 other files changed", describeChanges([]string{"go.mod", "go.sum", "main.go"}))
+}
+
+func TestModuleSourceWatchOpt(t *testing.T) {
+	contextDir := t.TempDir()
+	for path, contents := range map[string]string{
+		".gitignore":             "/mod/internal/\n",
+		"mod/dagger.json":        `{"name": "mod", "include": ["../shared", "!secret.txt"]}`,
+		"mod/main.go":            "",
+		"mod/secret.txt":         "",
+		"mod/internal/dagger.go": "",
+		"shared/lib.go":          "",
+		"other/main.go":          "",
+	} {
+		path = filepath.Join(contextDir, path)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(contents), 0o600))
+	}
+
+	opt, err := moduleSourceWatchOpt(contextDir, []string{filepath.Join(contextDir, "mod")})
+	require.NoError(t, err)
+	require.Equal(t, fsxutil.WatchOpt{
+		IncludePatterns: []string{"mod", "shared"},
+		ExcludePatterns: []string{"mod/secret.txt"},
+		GitIgnore:       true,
+	}, opt)
+
+	snapshot, err := fsxutil.TakeSnapshot(context.Background(), contextDir, opt)
+	require.NoError(t, err)
+	var files []string
+	for _, path := range slices.Sorted(maps.Keys(snapshot)) {
+		if filepath.Ext(path) != "" {
+			files = append(files, path)
+		}
+	}
+	require.Equal(t, []string{"mod/dagger.json", "mod/main.go", "shared/lib.go"}, files)
+
+	// the whole context directory is watched for a module at its root
+	opt, err = moduleSourceWatchOpt(contextDir, []string{contextDir, filepath.Join(contextDir, "mod")})
+	require.NoError(t, err)
+	require.Empty(t, opt.IncludePatterns)
+}
+
+func TestModuleWatchOpt(t *testing.T) {
+	contextDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(contextDir, "ci"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(contextDir, "ci", "dagger.json"), []byte(`{"name": "ci"}`), 0o600))
+
+	fn := func(defaultPaths ...string) *modFunction {
+		f := &modFunction{}
+		for _, path := range defaultPaths {
+			f.Args = append(f.Args, &modFunctionArg{DefaultPath: path})
+		}
+		return f
+	}
+	mod := &moduleDef{
+		Name:              "ci",
+		SourceRootSubpath: "/ci",
+		Objects: []*modTypeDef{
+			{AsObject: &modObject{
+				SourceModuleName: "ci",
+				Constructor:      fn("/src"),
+				Functions:        []*modFunction{fn("testdata", "/src"), fn()},
+			}},
+			// functions of dependencies resolve their own paths
+			{AsObject: &modObject{
+				SourceModuleName: "dep",
+				Functions:        []*modFunction{fn("/other")},
+			}},
+		},
+	}
+
+	opt, err := moduleWatchOpt(context.Background(), contextDir, mod)
+	require.NoError(t, err)
+	require.Equal(t, fsxutil.WatchOpt{
+		IncludePatterns: []string{"ci", "src", "ci/testdata"},
+		GitIgnore:       true,
+	}, opt)
+
+	// a default path at the root of the context directory needs all of it
+	mod.Objects[0].AsObject.Constructor = fn("/")
+	opt, err = moduleWatchOpt(context.Background(), contextDir, mod)
+	require.NoError(t, err)
+	require.Empty(t, opt.IncludePatterns)
+}
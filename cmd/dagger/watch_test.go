@@ -0,0 +1,65 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindEngineSession(t *testing.T) {
+	sessions := []engineSession{
+		{ID: "abc123"},
+		{ID: "abd456"},
+		{ID: "xyz789"},
+	}
+
+	sess, err := findEngineSession(sessions, "abc123")
+	require.NoError(t, err)
+	require.Equal(t, "abc123", sess.ID)
+
+	sess, err = findEngineSession(sessions, "x")
+	require.NoError(t, err)
+	require.Equal(t, "xyz789", sess.ID)
+
+	_, err = findEngineSession(sessions, "ab")
+	require.ErrorContains(t, err, "ambiguous")
+
+	_, err = findEngineSession(sessions, "nope")
+	require.ErrorContains(t, err, "no active session")
+}
+
+func TestRenderEngineSessions(t *testing.T) {
+	now := time.Now()
+	out := renderEngineSessions([]engineSession{
+		{
+			ID:              "2c1rd8v8cgx5f3bb",
+			Command:         "build --source .",
+			Modules:         []string{"ci", "go"},
+			StartedUnixNano: now.Add(-3 * time.Minute).UnixNano(),
+			RunningExecs:    2,
+			CacheHits:       3,
+			CacheMisses:     1,
+		},
+	}, 40, now)
+	require.Contains(t, out, "build --source .")
+	require.Contains(t, out, "2c1rd8v8 · 3m0s")
+	require.Contains(t, out, "mod: ci, go")
+	require.Contains(t, out, "2 running execs · 75% cached")
+
+	require.Contains(t, renderEngineSessions(nil, 40, now), "No active sessions")
+}
+
+func TestPrintEngineSessions(t *testing.T) {
+	var buf strings.Builder
+	err := printEngineSessions(&buf, []engineSession{
+		{ID: "2c1rd8v8cgx5f3bb", Command: "test", RunningExecs: 1},
+	})
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+	require.Contains(t, lines[0], "SESSION")
+	require.Contains(t, lines[1], "2c1rd8v8")
+	require.Contains(t, lines[1], "test")
+}
@@ -58,7 +58,7 @@ func setDefaultBuildkitConfig(cfg *bkconfig.Config, netConf *networkConfig) {
 
 	if netConf != nil {
 		// set dnsmasq as the default nameserver
-		cfg.DNS.Nameservers = []string{netConf.Bridge.String()}
+		cfg.DNS.Nameservers = netConf.Nameservers()
 
 		if netConf.CNIConfigPath != "" {
 			setNetworkDefaults(&cfg.Workers.OCI.NetworkConfig, netConf.CNIConfigPath)
@@ -160,6 +160,10 @@ func addFlags(app *cli.App) {
 			Usage: "address range to use for networked containers",
 			Value: network.DefaultCIDR,
 		},
+		cli.StringFlag{
+			Name:  "network-ipv6-cidr",
+			Usage: "IPv6 address range to use for networked containers, enabling dual-stack networking",
+		},
 		cli.StringSliceFlag{
 			Name:  "oci-worker-labels",
 			Usage: "user-specific annotation labels (com.example.foo=bar)",
@@ -295,9 +299,20 @@ func main() { //nolint:gocyclo
 		bklog.G(ctx).Debug("setting up engine networking")
 		networkContext, cancelNetworking := context.WithCancelCause(context.Background())
 		defer cancelNetworking(errors.New("main done"))
+		netCIDR := c.GlobalString("network-cidr")
+		netIPv6CIDR := c.GlobalString("network-ipv6-cidr")
+		if cfg.Network != nil {
+			if cfg.Network.CIDR != "" && !c.GlobalIsSet("network-cidr") {
+				netCIDR = cfg.Network.CIDR
+			}
+			if cfg.Network.IPv6CIDR != "" && !c.GlobalIsSet("network-ipv6-cidr") {
+				netIPv6CIDR = cfg.Network.IPv6CIDR
+			}
+		}
 		netConf, err := setupNetwork(networkContext,
 			c.GlobalString("network-name"),
-			c.GlobalString("network-cidr"),
+			netCIDR,
+			netIPv6CIDR,
 		)
 		if err != nil {
 			return err
@@ -808,17 +823,47 @@ type networkConfig struct {
 	NetCIDR       string
 	Bridge        net.IP
 	CNIConfigPath string
+
+	// set when dual-stack networking is enabled
+	NetIPv6CIDR string
+	BridgeIPv6  net.IP
+}
+
+// Nameservers returns the addresses of dnsmasq on the bridge.
+func (netConf *networkConfig) Nameservers() []string {
+	nameservers := []string{netConf.Bridge.String()}
+	if netConf.BridgeIPv6 != nil {
+		nameservers = append(nameservers, netConf.BridgeIPv6.String())
+	}
+	return nameservers
 }
 
-func setupNetwork(ctx context.Context, netName, netCIDR string) (*networkConfig, error) {
-	bridge, err := network.BridgeFromCIDR(netCIDR)
+func setupNetwork(ctx context.Context, netName, netCIDR, netIPv6CIDR string) (*networkConfig, error) {
+	netConf := &networkConfig{
+		NetName:     netName,
+		NetCIDR:     netCIDR,
+		NetIPv6CIDR: netIPv6CIDR,
+	}
+
+	var err error
+	netConf.Bridge, err = network.BridgeFromCIDR(netCIDR)
 	if err != nil {
 		return nil, fmt.Errorf("bridge from cidr: %w", err)
 	}
 
+	if netIPv6CIDR != "" {
+		if err := network.ValidateIPv6CIDR(netIPv6CIDR); err != nil {
+			return nil, fmt.Errorf("ipv6 cidr: %w", err)
+		}
+		netConf.BridgeIPv6, err = network.BridgeFromCIDR(netIPv6CIDR)
+		if err != nil {
+			return nil, fmt.Errorf("bridge from ipv6 cidr: %w", err)
+		}
+	}
+
 	// NB: this is needed for the Dagger shim worker at the moment for host alias
 	// resolution
-	err = netinst.InstallResolvconf(netName, bridge.String())
+	err = netinst.InstallResolvconf(netName, netConf.Nameservers()...)
 	if err != nil {
 		return nil, fmt.Errorf("install resolv.conf: %w", err)
 	}
@@ -828,15 +873,10 @@ func setupNetwork(ctx context.Context, netName, netCIDR string) (*networkConfig,
 		return nil, fmt.Errorf("install dnsmasq: %w", err)
 	}
 
-	cniConfigPath, err := netinst.InstallCNIConfig(netName, netCIDR)
+	netConf.CNIConfigPath, err = netinst.InstallCNIConfig(netName, netCIDR, netIPv6CIDR)
 	if err != nil {
 		return nil, fmt.Errorf("install cni: %w", err)
 	}
 
-	return &networkConfig{
-		NetName:       netName,
-		NetCIDR:       netCIDR,
-		Bridge:        bridge,
-		CNIConfigPath: cniConfigPath,
-	}, nil
+	return netConf, nil
 }
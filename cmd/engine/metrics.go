@@ -9,6 +9,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 
+	"github.com/dagger/dagger/engine/metrics"
 	"github.com/dagger/dagger/engine/server"
 	"github.com/dagger/dagger/engine/slog"
 )
@@ -49,6 +50,9 @@ func setupMetricsServer(ctx context.Context, srv *server.Server, addr string) er
 	if err := prometheus.Register(localCacheCorruptDBResetGauge); err != nil {
 		return err
 	}
+	if err := metrics.Register(prometheus.DefaultRegisterer); err != nil {
+		return err
+	}
 
 	// Only update local cache metrics at most every 5 minutes to avoid excessive holding
 	// of buildkit's DiskUsage lock.
@@ -97,6 +101,7 @@ func setupMetricsServer(ctx context.Context, srv *server.Server, addr string) er
 			dbReset = 1
 		}
 		localCacheCorruptDBResetGauge.Set(dbReset)
+		metrics.SetActiveServices(srv.ActiveServices())
 
 		promhttp.Handler().ServeHTTP(w, r)
 	})
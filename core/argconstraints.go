@@ -0,0 +1,253 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"unicode/utf8"
+
+	"github.com/vektah/gqlparser/v2/ast"
+
+	"github.com/dagger/dagger/dagql"
+)
+
+// FunctionArgConstraints are declarative constraints on the value of a
+// function argument. The engine checks them before invoking the function, so
+// modules don't need to validate their arguments by hand.
+type FunctionArgConstraints struct {
+	Min           JSON     `field:"true" doc:"Only applies to Integer and Float arguments. The minimum value, inclusive, as a JSON number."`
+	Max           JSON     `field:"true" doc:"Only applies to Integer and Float arguments. The maximum value, inclusive, as a JSON number."`
+	Pattern       string   `field:"true" doc:"Only applies to String arguments. A regular expression (RE2 syntax) that the whole value must match."`
+	MinLength     int      `field:"true" doc:"Only applies to String and list arguments. The minimum length of the value."`
+	MaxLength     int      `field:"true" doc:"Only applies to String and list arguments. The maximum length of the value, or 0 for no maximum."`
+	NonEmpty      bool     `field:"true" doc:"Only applies to list arguments. Whether the list must contain at least one element."`
+	RequiredPaths []string `field:"true" doc:"Only applies to arguments of type Directory. Paths that must exist in the directory."`
+}
+
+func (*FunctionArgConstraints) Type() *ast.Type {
+	return &ast.Type{
+		NamedType: "FunctionArgConstraints",
+		NonNull:   true,
+	}
+}
+
+func (*FunctionArgConstraints) TypeDescription() string {
+	return dagql.FormatDescription(
+		`Constraints on the value of a function argument.`,
+		`They are checked by the engine before the function is called.`)
+}
+
+func (c FunctionArgConstraints) Clone() *FunctionArgConstraints {
+	cp := c
+	cp.RequiredPaths = slices.Clone(c.RequiredPaths)
+	return &cp
+}
+
+// IsEmpty returns true if no constraint is set.
+func (c *FunctionArgConstraints) IsEmpty() bool {
+	return c == nil ||
+		c.Min == nil &&
+			c.Max == nil &&
+			c.Pattern == "" &&
+			c.MinLength == 0 &&
+			c.MaxLength == 0 &&
+			!c.NonEmpty &&
+			len(c.RequiredPaths) == 0
+}
+
+// Check returns an error if the constraints are invalid, or don't apply to
+// an argument of the given type.
+func (c *FunctionArgConstraints) Check(typeDef *TypeDef) error {
+	kind := typeDef.Kind
+	if c.Min != nil || c.Max != nil {
+		if kind != TypeDefKindInteger && kind != TypeDefKindFloat {
+			return fmt.Errorf("can only set min and max for Integer or Float, not %s", typeDef.ToType())
+		}
+		minVal, hasMin, err := c.min()
+		if err != nil {
+			return err
+		}
+		maxVal, hasMax, err := c.max()
+		if err != nil {
+			return err
+		}
+		if hasMin && hasMax && minVal > maxVal {
+			return fmt.Errorf("min %v is greater than max %v", minVal, maxVal)
+		}
+	}
+	if c.Pattern != "" {
+		if kind != TypeDefKindString {
+			return fmt.Errorf("can only set pattern for String, not %s", typeDef.ToType())
+		}
+		if _, err := c.compiledPattern(); err != nil {
+			return err
+		}
+	}
+	if c.MinLength != 0 || c.MaxLength != 0 {
+		if kind != TypeDefKindString && kind != TypeDefKindList {
+			return fmt.Errorf("can only set min and max length for String or list, not %s", typeDef.ToType())
+		}
+		if c.MinLength < 0 || c.MaxLength < 0 {
+			return fmt.Errorf("min and max length must not be negative")
+		}
+		if c.MaxLength != 0 && c.MinLength > c.MaxLength {
+			return fmt.Errorf("min length %d is greater than max length %d", c.MinLength, c.MaxLength)
+		}
+	}
+	if c.NonEmpty && kind != TypeDefKindList {
+		return fmt.Errorf("can only set non-empty for list, not %s", typeDef.ToType())
+	}
+	if len(c.RequiredPaths) > 0 {
+		if kind != TypeDefKindObject || typeDef.AsObject.Value.Name != "Directory" {
+			return fmt.Errorf("can only set required paths for Directory, not %s", typeDef.ToType())
+		}
+	}
+	return nil
+}
+
+// Validate returns an *ArgConstraintError if the given JSON-encoded value of
+// the argument doesn't satisfy the constraints. Required paths need to be
+// checked against the directory itself, with ValidatePaths.
+func (c *FunctionArgConstraints) Validate(arg string, value JSON) error {
+	if c.IsEmpty() || value == nil {
+		return nil
+	}
+	var v any
+	if err := json.Unmarshal(value, &v); err != nil {
+		return fmt.Errorf("decode argument %q: %w", arg, err)
+	}
+	fail := func(constraint string, msg string, args ...any) error {
+		return &ArgConstraintError{
+			Arg:        arg,
+			Constraint: constraint,
+			Message:    fmt.Sprintf(msg, args...),
+		}
+	}
+	switch v := v.(type) {
+	case float64:
+		if minVal, ok, _ := c.min(); ok && v < minVal {
+			return fail("min", "must be at least %v, got %v", minVal, v)
+		}
+		if maxVal, ok, _ := c.max(); ok && v > maxVal {
+			return fail("max", "must be at most %v, got %v", maxVal, v)
+		}
+	case string:
+		if c.Pattern != "" {
+			re, err := c.compiledPattern()
+			if err != nil {
+				return err
+			}
+			if !re.MatchString(v) {
+				return fail("pattern", "must match pattern %q, got %q", c.Pattern, v)
+			}
+		}
+		if err := c.validateLength(fail, utf8.RuneCountInString(v), "characters"); err != nil {
+			return err
+		}
+	case []any:
+		if c.NonEmpty && len(v) == 0 {
+			return fail("nonEmpty", "must not be empty")
+		}
+		if err := c.validateLength(fail, len(v), "elements"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidatePaths returns an *ArgConstraintError if one of the required paths
+// doesn't exist in the given directory.
+func (c *FunctionArgConstraints) ValidatePaths(ctx context.Context, srv *dagql.Server, arg string, dir *Directory) error {
+	if c == nil {
+		return nil
+	}
+	for _, p := range c.RequiredPaths {
+		exists, err := dir.Exists(ctx, srv, p, "", false)
+		if err != nil {
+			return fmt.Errorf("check path %q of argument %q: %w", p, arg, err)
+		}
+		if !exists {
+			return &ArgConstraintError{
+				Arg:        arg,
+				Constraint: "requiredPaths",
+				Message:    fmt.Sprintf("must contain %q", p),
+			}
+		}
+	}
+	return nil
+}
+
+func (c *FunctionArgConstraints) validateLength(fail func(string, string, ...any) error, n int, unit string) error {
+	if c.MinLength != 0 && n < c.MinLength {
+		return fail("minLength", "must be at least %d %s long, got %d", c.MinLength, unit, n)
+	}
+	if c.MaxLength != 0 && n > c.MaxLength {
+		return fail("maxLength", "must be at most %d %s long, got %d", c.MaxLength, unit, n)
+	}
+	return nil
+}
+
+func (c *FunctionArgConstraints) min() (float64, bool, error) {
+	return decodeConstraintNumber("min", c.Min)
+}
+
+func (c *FunctionArgConstraints) max() (float64, bool, error) {
+	return decodeConstraintNumber("max", c.Max)
+}
+
+func (c *FunctionArgConstraints) compiledPattern() (*regexp.Regexp, error) {
+	// the whole value must match, not just a part of it
+	re, err := regexp.Compile(`^(?:` + c.Pattern + `)$`)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern %q: %w", c.Pattern, err)
+	}
+	return re, nil
+}
+
+func decodeConstraintNumber(name string, value JSON) (float64, bool, error) {
+	if value == nil {
+		return 0, false, nil
+	}
+	var n float64
+	if err := json.Unmarshal(value, &n); err != nil {
+		return 0, false, fmt.Errorf("%s must be a number, got %s", name, value)
+	}
+	return n, true, nil
+}
+
+// ArgConstraintError is returned when the value of a function argument
+// doesn't satisfy its constraints.
+//
+// It supports being serialized/deserialized through graphql.
+type ArgConstraintError struct {
+	// Function is the name of the function, if known.
+	Function string
+
+	// Arg is the name of the argument.
+	Arg string
+
+	// Constraint is the name of the constraint that isn't satisfied, as in
+	// FunctionArgConstraints, e.g. "pattern".
+	Constraint string
+
+	// Message describes how the value doesn't satisfy the constraint.
+	Message string
+}
+
+func (e *ArgConstraintError) Error() string {
+	if e.Function != "" {
+		return fmt.Sprintf("invalid argument %q of function %q: %s", e.Arg, e.Function, e.Message)
+	}
+	return fmt.Sprintf("invalid argument %q: %s", e.Arg, e.Message)
+}
+
+func (e *ArgConstraintError) Extensions() map[string]any {
+	return map[string]any{
+		"_type":      "ARG_CONSTRAINT_ERROR",
+		"function":   e.Function,
+		"argument":   e.Arg,
+		"constraint": e.Constraint,
+	}
+}
@@ -0,0 +1,75 @@
+package core
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFunctionArgConstraintsCheck(t *testing.T) {
+	intType := (&TypeDef{}).WithKind(TypeDefKindInteger)
+	stringType := (&TypeDef{}).WithKind(TypeDefKindString)
+	listType := (&TypeDef{}).WithListOf(stringType)
+	dirType := (&TypeDef{}).WithObject("Directory", "", nil, nil)
+
+	for _, tc := range []struct {
+		name        string
+		constraints FunctionArgConstraints
+		typeDef     *TypeDef
+		err         string
+	}{
+		{"range", FunctionArgConstraints{Min: JSON("1"), Max: JSON("100")}, intType, ""},
+		{"pattern", FunctionArgConstraints{Pattern: `v\d+`}, stringType, ""},
+		{"list length", FunctionArgConstraints{MinLength: 1, MaxLength: 3, NonEmpty: true}, listType, ""},
+		{"required paths", FunctionArgConstraints{RequiredPaths: []string{"go.mod"}}, dirType, ""},
+		{"min on string", FunctionArgConstraints{Min: JSON("1")}, stringType, "can only set min and max for Integer or Float"},
+		{"min not a number", FunctionArgConstraints{Min: JSON(`"one"`)}, intType, "min must be a number"},
+		{"min greater than max", FunctionArgConstraints{Min: JSON("10"), Max: JSON("1")}, intType, "min 10 is greater than max 1"},
+		{"invalid pattern", FunctionArgConstraints{Pattern: `v(`}, stringType, "invalid pattern"},
+		{"non-empty string", FunctionArgConstraints{NonEmpty: true}, stringType, "can only set non-empty for list"},
+		{"required paths on string", FunctionArgConstraints{RequiredPaths: []string{"go.mod"}}, stringType, "can only set required paths for Directory"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.constraints.Check(tc.typeDef)
+			if tc.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tc.err)
+			}
+		})
+	}
+}
+
+func TestFunctionArgConstraintsValidate(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		constraints FunctionArgConstraints
+		value       JSON
+		constraint  string
+	}{
+		{"in range", FunctionArgConstraints{Min: JSON("1"), Max: JSON("100")}, JSON("100"), ""},
+		{"below min", FunctionArgConstraints{Min: JSON("1"), Max: JSON("100")}, JSON("0"), "min"},
+		{"above max", FunctionArgConstraints{Min: JSON("1"), Max: JSON("100")}, JSON("101"), "max"},
+		{"matches pattern", FunctionArgConstraints{Pattern: `v\d+`}, JSON(`"v12"`), ""},
+		{"partially matches pattern", FunctionArgConstraints{Pattern: `v\d+`}, JSON(`"v12-rc"`), "pattern"},
+		{"string length in runes", FunctionArgConstraints{MaxLength: 2}, JSON(`"é😀"`), ""},
+		{"string too short", FunctionArgConstraints{MinLength: 3}, JSON(`"ab"`), "minLength"},
+		{"list too long", FunctionArgConstraints{MaxLength: 1}, JSON(`["a","b"]`), "maxLength"},
+		{"empty list", FunctionArgConstraints{NonEmpty: true}, JSON(`[]`), "nonEmpty"},
+		{"unset optional", FunctionArgConstraints{NonEmpty: true}, JSON(`null`), ""},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.constraints.Validate("arg", tc.value)
+			if tc.constraint == "" {
+				require.NoError(t, err)
+				return
+			}
+			var constraintErr *ArgConstraintError
+			require.True(t, errors.As(err, &constraintErr), "unexpected error: %v", err)
+			require.Equal(t, "arg", constraintErr.Arg)
+			require.Equal(t, tc.constraint, constraintErr.Constraint)
+			require.Equal(t, tc.constraint, constraintErr.Extensions()["constraint"])
+		})
+	}
+}
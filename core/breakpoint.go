@@ -0,0 +1,225 @@
+package core
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"reflect"
+	"strings"
+
+	"github.com/iancoleman/strcase"
+	"github.com/muesli/termenv"
+
+	bkgwpb "github.com/dagger/dagger/internal/buildkit/frontend/gateway/pb"
+
+	"github.com/dagger/dagger/dagql"
+	"github.com/dagger/dagger/dagql/call"
+	"github.com/dagger/dagger/dagql/idtui"
+	"github.com/dagger/dagger/engine/buildkit"
+)
+
+// ErrBreakpointAbort is returned by a call the user chose to abort at a
+// breakpoint.
+var ErrBreakpointAbort = errors.New("aborted at breakpoint")
+
+type breakpointKey struct{}
+
+// MatchCallPattern returns whether a call matches one of the given patterns. A
+// pattern matches the field of the call, e.g. "withExec", or its type and
+// field, e.g. "Container.withDirectory", and may use glob wildcards, e.g.
+// "Container.with*".
+func MatchCallPattern(patterns []string, typeName, field string) bool {
+	for _, pattern := range patterns {
+		name := field
+		if strings.Contains(pattern, ".") {
+			name = typeName + "." + field
+		}
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// breakpointsEnabled returns whether the session pauses at breakpoints.
+func breakpointsEnabled(bk *buildkit.Client) bool {
+	return bk.Interactive || len(bk.BreakOn) > 0
+}
+
+// breakOnCall pauses at the result of a call if it matches one of the
+// --break-on patterns of the session.
+func breakOnCall(ctx context.Context, id *call.ID, res dagql.AnyResult) error {
+	if res == nil || ctx.Value(breakpointKey{}) != nil {
+		// don't pause again for the calls made while paused
+		return nil
+	}
+	if dagql.IsInternal(ctx) {
+		// only pause at calls made by the user
+		return nil
+	}
+	query, err := CurrentQuery(ctx)
+	if err != nil {
+		return nil
+	}
+	bk, err := query.Buildkit(ctx)
+	if err != nil || len(bk.BreakOn) == 0 {
+		return nil
+	}
+	typeName := "Query"
+	if id.Receiver() != nil {
+		typeName = id.Receiver().Type().ToAST().Name()
+	}
+	if !MatchCallPattern(bk.BreakOn, typeName, id.Field()) {
+		return nil
+	}
+
+	ctx = context.WithValue(ctx, breakpointKey{}, true)
+	switch self := res.Unwrap().(type) {
+	case *Container:
+		return self.breakpoint(ctx, id)
+	case *Directory:
+		if dir, ok := res.(dagql.ObjectResult[*Directory]); ok {
+			return self.Terminal(ctx, id, nil, &TerminalArgs{
+				ExecTerminalArgs: ExecTerminalArgs{Cmd: []string{"sh"}},
+			}, dir)
+		}
+	}
+	return inspectBreakpoint(ctx, id, res)
+}
+
+// Breakpoint opens a terminal for the container and waits until the user exits
+// it, if the session pauses at breakpoints.
+func (container *Container) Breakpoint(ctx context.Context, svcID *call.ID) error {
+	query, err := CurrentQuery(ctx)
+	if err != nil {
+		return err
+	}
+	bk, err := query.Buildkit(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get buildkit client: %w", err)
+	}
+	if !breakpointsEnabled(bk) {
+		return nil
+	}
+	return container.breakpoint(context.WithValue(ctx, breakpointKey{}, true), svcID)
+}
+
+func (container *Container) breakpoint(ctx context.Context, svcID *call.ID) error {
+	args := &TerminalArgs{
+		ExecTerminalArgs:              ExecTerminalArgs{Cmd: container.DefaultTerminalCmd.Args},
+		ExperimentalPrivilegedNesting: container.DefaultTerminalCmd.ExperimentalPrivilegedNesting,
+		InsecureRootCapabilities:      container.DefaultTerminalCmd.InsecureRootCapabilities,
+	}
+	if len(args.Cmd) == 0 {
+		args.Cmd = []string{"sh"}
+	}
+	return container.Terminal(ctx, svcID, args)
+}
+
+// inspectBreakpoint shows the result of a call and its fields, and waits for
+// the user to continue or abort.
+func inspectBreakpoint(ctx context.Context, id *call.ID, res dagql.AnyResult) error {
+	query, err := CurrentQuery(ctx)
+	if err != nil {
+		return err
+	}
+	bk, err := query.Buildkit(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get buildkit client: %w", err)
+	}
+	term, err := bk.OpenTerminal(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to open terminal: %w", err)
+	}
+	defer term.Close(bkgwpb.UnknownExitStatus) // always close term; it's wrapped in a once so it won't be called multiple times
+
+	output := idtui.NewOutput(term.Stderr)
+	stderr := rawTermWriter{term.Stderr}
+	fmt.Fprint(stderr, output.String(idtui.DotFilled).Foreground(termenv.ANSIYellow).String()+" Paused at breakpoint: \n")
+	dump := idtui.Dump{Newline: "\r\n", Prefix: "    "}
+	if err := dump.DumpID(output, id); err != nil {
+		return fmt.Errorf("failed to serialize ID: %w", err)
+	}
+	fmt.Fprint(stderr, "\n\n")
+	for _, field := range inspectFields(res.Unwrap()) {
+		fmt.Fprintf(stderr, "  %s: %s\n", output.String(field[0]).Bold(), field[1])
+	}
+	fmt.Fprintf(stderr, "\n  %s  continue\n  %s  abort\n", output.String("c").Bold(), output.String("q").Bold())
+	fmt.Fprint(stderr, output.String("? ").Foreground(termenv.ANSIYellow).String())
+
+	stdin := newDebugStdin(term.Stdin)
+	defer stdin.Close()
+	key, err := stdin.ReadKey(ctx, "cq")
+	if err != nil {
+		// the terminal was closed; carry on
+		return nil
+	}
+	fmt.Fprintf(stderr, "%c\n", key)
+	if key == 'q' {
+		term.Close(1)
+		return ErrBreakpointAbort
+	}
+	term.Close(0)
+	return nil
+}
+
+// inspectFields returns the names and values of the scalar fields of an
+// object, or the value itself for a scalar.
+func inspectFields(val any) [][2]string {
+	v := reflect.ValueOf(val)
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if s, ok := inspectValue(v); ok {
+		return [][2]string{{"value", s}}
+	}
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+	var fields [][2]string
+	for i := range v.NumField() {
+		fieldT := v.Type().Field(i)
+		if !fieldT.IsExported() {
+			continue
+		}
+		if fieldT.Anonymous {
+			fields = append(fields, inspectFields(v.Field(i).Interface())...)
+			continue
+		}
+		if fieldT.Tag.Get("field") != "true" {
+			continue
+		}
+		name := cmp.Or(fieldT.Tag.Get("name"), strcase.ToLowerCamel(fieldT.Name))
+		if s, ok := inspectValue(v.Field(i)); ok {
+			fields = append(fields, [2]string{name, s})
+		}
+	}
+	return fields
+}
+
+func inspectValue(v reflect.Value) (string, bool) {
+	switch v.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return fmt.Sprint(v.Interface()), true
+	case reflect.Slice:
+		elems := make([]string, 0, v.Len())
+		for i := range v.Len() {
+			s, ok := inspectValue(v.Index(i))
+			if !ok {
+				return "", false
+			}
+			elems = append(elems, s)
+		}
+		return "[" + strings.Join(elems, ", ") + "]", true
+	default:
+		return "", false
+	}
+}
@@ -0,0 +1,65 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchCallPattern(t *testing.T) {
+	for _, tc := range []struct {
+		patterns []string
+		typeName string
+		field    string
+		match    bool
+	}{
+		{[]string{"withExec"}, "Container", "withExec", true},
+		{[]string{"withExec"}, "Container", "withDirectory", false},
+		{[]string{"Container.withDirectory"}, "Container", "withDirectory", true},
+		{[]string{"Container.withDirectory"}, "Directory", "withDirectory", false},
+		{[]string{"Container.with*"}, "Container", "withEnvVariable", true},
+		{[]string{"*.withDirectory"}, "Directory", "withDirectory", true},
+		{[]string{"from", "withExec"}, "Container", "withExec", true},
+		{nil, "Container", "withExec", false},
+	} {
+		require.Equal(t, tc.match, MatchCallPattern(tc.patterns, tc.typeName, tc.field),
+			"%v %s.%s", tc.patterns, tc.typeName, tc.field)
+	}
+}
+
+func TestInspectFields(t *testing.T) {
+	type embedded struct {
+		Hidden string `field:"true"`
+	}
+	type Embedded struct {
+		Platform string `field:"true"`
+	}
+	type object struct {
+		embedded
+		Embedded
+
+		Name     string   `field:"true"`
+		Size     int      `field:"true" name:"sizeBytes"`
+		Tags     []string `field:"true"`
+		Internal string
+		Nested   *object `field:"true"`
+	}
+
+	require.Equal(t, [][2]string{
+		{"platform", "linux/amd64"},
+		{"name", "foo"},
+		{"sizeBytes", "42"},
+		{"tags", "[a, b]"},
+	}, inspectFields(&object{
+		embedded: embedded{Hidden: "x"},
+		Embedded: Embedded{Platform: "linux/amd64"},
+		Name:     "foo",
+		Size:     42,
+		Tags:     []string{"a", "b"},
+		Internal: "y",
+		Nested:   &object{},
+	}))
+
+	require.Equal(t, [][2]string{{"value", "hello"}}, inspectFields("hello"))
+	require.Nil(t, inspectFields((*object)(nil)))
+}
@@ -1,9 +1,11 @@
 package core
 
 import (
+	"context"
 	"crypto/sha256"
 	"encoding/base64"
 	"encoding/json"
+	"fmt"
 	"slices"
 	"strings"
 
@@ -11,11 +13,20 @@ import (
 
 	"github.com/dagger/dagger/dagql"
 	"github.com/dagger/dagger/dagql/call"
+	bkcache "github.com/dagger/dagger/internal/buildkit/cache"
+	bkmounts "github.com/dagger/dagger/internal/buildkit/solver/llbsolver/mounts"
 )
 
+// CacheVolumeLabel is set on the cache records of a cache volume to the key
+// the volume was constructed with, so that GC policies can target it.
+const CacheVolumeLabel = "dagger.io/cache.volume"
+
 // CacheVolume is a persistent volume with a globally scoped identifier.
 type CacheVolume struct {
 	Keys []string
+
+	// Key is the key the volume was constructed with, without its namespace.
+	Key string
 }
 
 func (*CacheVolume) Type() *ast.Type {
@@ -49,6 +60,77 @@ func (cache *CacheVolume) Sum() string {
 	return base64.StdEncoding.EncodeToString(hash.Sum(nil))
 }
 
+// SetPinned pins the cache records of the volume, so that they're never pruned
+// by garbage collection, or unpins them.
+func (cache *CacheVolume) SetPinned(ctx context.Context, pinned bool) error {
+	query, err := CurrentQuery(ctx)
+	if err != nil {
+		return err
+	}
+	if err := query.RequireMainClient(ctx); err != nil {
+		return err
+	}
+	id := cache.Sum()
+	// remembered with the cache metadata, so that records created for the
+	// volume later get pinned too, even after an engine restart
+	if err := query.BuildkitCache().SetCacheDirPinned(id, pinned); err != nil {
+		return fmt.Errorf("update cache volume: %w", err)
+	}
+	mds, err := cacheVolumeRecords(ctx, query.BuildkitCache(), id)
+	if err != nil {
+		return err
+	}
+	for _, md := range mds {
+		if err := md.SetPinned(pinned); err != nil {
+			return fmt.Errorf("update cache record %s: %w", md.ID(), err)
+		}
+	}
+	return nil
+}
+
+// tagCacheVolumeRecords labels the cache records of a mounted cache volume
+// with its key, and pins them if the volume has been pinned.
+func tagCacheVolumeRecords(ctx context.Context, cache bkcache.Manager, src *CacheMountSource) error {
+	pinned, err := cache.CacheDirPinned(src.ID)
+	if err != nil {
+		return err
+	}
+	if src.Key == "" && !pinned {
+		return nil
+	}
+	mds, err := cacheVolumeRecords(ctx, cache, src.ID)
+	if err != nil {
+		return err
+	}
+	for _, md := range mds {
+		if src.Key != "" {
+			if err := md.SetLabel(CacheVolumeLabel, src.Key); err != nil {
+				return err
+			}
+		}
+		if pinned && !md.GetPinned() {
+			if err := md.SetPinned(true); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// cacheVolumeRecords returns the cache records of the cache volume with the
+// given ID, both with and without base contents.
+func cacheVolumeRecords(ctx context.Context, store bkcache.MetadataStore, id string) ([]bkmounts.CacheRefMetadata, error) {
+	mds, err := bkmounts.SearchCacheDir(ctx, store, id, false)
+	if err != nil {
+		return nil, err
+	}
+	nested, err := bkmounts.SearchCacheDir(ctx, store, id, true)
+	if err != nil {
+		return nil, err
+	}
+	return append(mds, nested...), nil
+}
+
 type CacheSharingMode string
 
 var CacheSharingModes = dagql.NewEnum[CacheSharingMode]()
@@ -0,0 +1,183 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/dagger/dagger/core/modules"
+)
+
+// ModuleCapability is a capability that a module declaring capabilities in
+// its dagger.json needs to be granted to make some calls.
+type ModuleCapability string
+
+const (
+	ModuleCapabilityHostRead    ModuleCapability = "hostRead"
+	ModuleCapabilityHostSockets ModuleCapability = "hostSockets"
+	ModuleCapabilityNetwork     ModuleCapability = "network"
+	ModuleCapabilityLLM         ModuleCapability = "llm"
+	ModuleCapabilityExec        ModuleCapability = "exec"
+	ModuleCapabilitySecrets     ModuleCapability = "secrets"
+)
+
+func (capability ModuleCapability) action() string {
+	switch capability {
+	case ModuleCapabilityHostRead:
+		return "read from the host"
+	case ModuleCapabilityHostSockets:
+		return "use host sockets"
+	case ModuleCapabilityNetwork:
+		return "use full network access"
+	case ModuleCapabilityLLM:
+		return "use LLMs"
+	case ModuleCapabilityExec:
+		return "run commands with access to the Dagger API"
+	case ModuleCapabilitySecrets:
+		return "load secrets from the host"
+	default:
+		return fmt.Sprintf("use capability %q", string(capability))
+	}
+}
+
+// CapabilityError is returned when a module makes a call requiring a
+// capability it did not declare, or that it was not granted when installed.
+type CapabilityError struct {
+	Module     string
+	Capability ModuleCapability
+	// Detail optionally describes the denied call further, e.g. a host path
+	Detail string
+	// NotGranted is set if the module declares the capability, but it was
+	// not granted when the module was installed as a dependency
+	NotGranted bool
+}
+
+func (err *CapabilityError) Error() string {
+	msg := fmt.Sprintf("module %q is not allowed to %s", err.Module, err.Capability.action())
+	if err.Detail != "" {
+		msg += " " + err.Detail
+	}
+	if err.NotGranted {
+		return fmt.Sprintf("%s: %q was not granted when it was installed, reinstall it with \"dagger install\" to grant it", msg, string(err.Capability))
+	}
+	return fmt.Sprintf("%s: add %q to the capabilities in its %s", msg, string(err.Capability), modules.Filename)
+}
+
+// CheckModuleCapability returns an error if the current client is a module
+// function that may not use the given capability. Clients that aren't
+// modules are unrestricted.
+func CheckModuleCapability(ctx context.Context, query *Query, capability ModuleCapability) error {
+	mod, err := query.CurrentModule(ctx)
+	if err != nil {
+		if errors.Is(err, ErrNoCurrentModule) {
+			return nil
+		}
+		return err
+	}
+	return mod.CheckCapability(capability)
+}
+
+// Capabilities returns the capabilities declared by the module, or nil if it
+// requires all of them.
+func (mod *Module) Capabilities() *modules.ModuleCapabilities {
+	src := mod.GetSource()
+	if src == nil {
+		return nil
+	}
+	return src.Capabilities
+}
+
+// GrantedCapabilities returns the capabilities granted to the module when it
+// was installed as a dependency, or nil if none were recorded.
+func (mod *Module) GrantedCapabilities() *modules.ModuleCapabilities {
+	src := mod.GetSource()
+	if src == nil {
+		return nil
+	}
+	return src.GrantedCapabilities
+}
+
+// CheckCapability returns an error if the module may not use the given
+// capability, i.e. if it doesn't both declare it and was granted it.
+func (mod *Module) CheckCapability(capability ModuleCapability) error {
+	if !capabilityAllowed(mod.Capabilities(), capability) {
+		return &CapabilityError{Module: mod.Name(), Capability: capability}
+	}
+	if !capabilityAllowed(mod.GrantedCapabilities(), capability) {
+		return &CapabilityError{Module: mod.Name(), Capability: capability, NotGranted: true}
+	}
+	return nil
+}
+
+// CheckHostRead returns an error if the module may not read the given
+// defaultPath from its context directory on the host.
+func (mod *Module) CheckHostRead(path string) error {
+	var sourceRootSubpath string
+	if mod.ContextSource.Valid {
+		sourceRootSubpath = mod.ContextSource.Value.Self().SourceRootSubpath
+	}
+	for _, check := range []struct {
+		caps       *modules.ModuleCapabilities
+		notGranted bool
+	}{
+		{mod.Capabilities(), false},
+		{mod.GrantedCapabilities(), true},
+	} {
+		if check.caps == nil || hostReadAllowed(check.caps.HostRead, path, sourceRootSubpath) {
+			continue
+		}
+		return &CapabilityError{
+			Module:     mod.Name(),
+			Capability: ModuleCapabilityHostRead,
+			Detail:     fmt.Sprintf("path %q", path),
+			NotGranted: check.notGranted,
+		}
+	}
+	return nil
+}
+
+// capabilityAllowed returns whether the given capabilities include the
+// capability. Nil capabilities include all of them.
+func capabilityAllowed(caps *modules.ModuleCapabilities, capability ModuleCapability) bool {
+	if caps == nil {
+		return true
+	}
+	switch capability {
+	case ModuleCapabilityHostRead:
+		return len(caps.HostRead) > 0
+	case ModuleCapabilityHostSockets:
+		return caps.HostSockets
+	case ModuleCapabilityNetwork:
+		return caps.Network
+	case ModuleCapabilityLLM:
+		return caps.LLM
+	case ModuleCapabilityExec:
+		return caps.Exec
+	case ModuleCapabilitySecrets:
+		return caps.Secrets
+	default:
+		return false
+	}
+}
+
+// hostReadAllowed returns whether path is one of the allowed paths or is
+// within one of them. Paths are resolved the same way as defaultPath: relative
+// to the module root directory, or to the context directory if absolute.
+func hostReadAllowed(allowed []string, path, sourceRootSubpath string) bool {
+	resolve := func(path string) string {
+		if filepath.IsAbs(path) {
+			return filepath.Clean(strings.TrimPrefix(path, "/"))
+		}
+		return filepath.Join(sourceRootSubpath, path)
+	}
+	path = resolve(path)
+	for _, allowedPath := range allowed {
+		allowedPath = resolve(allowedPath)
+		if allowedPath == "." || path == allowedPath || strings.HasPrefix(path, allowedPath+"/") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,56 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dagger/dagger/core/modules"
+)
+
+func TestHostReadAllowed(t *testing.T) {
+	for _, tc := range []struct {
+		name              string
+		allowed           []string
+		path              string
+		sourceRootSubpath string
+		expected          bool
+	}{
+		{"same path", []string{"./src"}, "src", "", true},
+		{"subpath", []string{"src"}, "./src/pkg", "", true},
+		{"sibling with prefix", []string{"src"}, "src2", "", false},
+		{"parent", []string{"src"}, ".", "", false},
+		{"whole context", []string{"/"}, "../docs", "ci", true},
+		{"relative to module root", []string{"src"}, "/ci/src", "ci", true},
+		{"absolute outside module root", []string{"src"}, "/src", "ci", false},
+		{"absolute allowed", []string{"/docs"}, "../docs/site", "ci", true},
+		{"none allowed", nil, ".", "", false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, hostReadAllowed(tc.allowed, tc.path, tc.sourceRootSubpath))
+		})
+	}
+}
+
+func TestCapabilityError(t *testing.T) {
+	err := &CapabilityError{Module: "foo", Capability: ModuleCapabilityNetwork}
+	require.EqualError(t, err, `module "foo" is not allowed to use full network access: add "network" to the capabilities in its dagger.json`)
+
+	err = &CapabilityError{Module: "foo", Capability: ModuleCapabilityHostRead, Detail: `path "/secrets"`}
+	require.EqualError(t, err, `module "foo" is not allowed to read from the host path "/secrets": add "hostRead" to the capabilities in its dagger.json`)
+
+	err = &CapabilityError{Module: "foo", Capability: ModuleCapabilitySecrets, NotGranted: true}
+	require.EqualError(t, err, `module "foo" is not allowed to load secrets from the host: "secrets" was not granted when it was installed, reinstall it with "dagger install" to grant it`)
+}
+
+func TestCapabilityAllowed(t *testing.T) {
+	require.True(t, capabilityAllowed(nil, ModuleCapabilitySecrets))
+	require.True(t, capabilityAllowed(modules.AllModuleCapabilities(), ModuleCapabilitySecrets))
+	require.True(t, capabilityAllowed(modules.AllModuleCapabilities(), ModuleCapabilityHostRead))
+
+	caps := &modules.ModuleCapabilities{Network: true}
+	require.True(t, capabilityAllowed(caps, ModuleCapabilityNetwork))
+	require.False(t, capabilityAllowed(caps, ModuleCapabilityHostRead))
+	require.False(t, capabilityAllowed(caps, ModuleCapabilitySecrets))
+	require.False(t, capabilityAllowed(caps, ModuleCapability("unknown")))
+}
@@ -8,6 +8,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
 	"strings"
 	"syscall"
 
@@ -161,6 +162,64 @@ type Changeset struct {
 	allRemovedPaths []string
 }
 
+// Conflicts returns the files that both changesets change, or that one of
+// them changes and the other removes, directly or with a parent directory.
+// Both changesets are expected to compare against the same directory.
+func (ch *Changeset) Conflicts(other *Changeset) []string {
+	changed := func(ch *Changeset) map[string]bool {
+		paths := map[string]bool{}
+		for _, path := range append(slices.Clone(ch.AddedPaths), ch.ModifiedPaths...) {
+			if !strings.HasSuffix(path, "/") {
+				paths[path] = true
+			}
+		}
+		return paths
+	}
+	removed := func(ch *Changeset) (map[string]bool, []string) {
+		paths := map[string]bool{}
+		var dirs []string
+		for _, path := range ch.allRemovedPaths {
+			if strings.HasSuffix(path, "/") {
+				dirs = append(dirs, path)
+			} else {
+				paths[path] = true
+			}
+		}
+		return paths, dirs
+	}
+	// a change conflicts with the removal of the file, or of a directory
+	// containing it, such as an addition under a removed directory
+	isRemoved := func(path string, removals map[string]bool, removedDirs []string) bool {
+		if removals[path] {
+			return true
+		}
+		for _, dir := range removedDirs {
+			if strings.HasPrefix(path, dir) {
+				return true
+			}
+		}
+		return false
+	}
+	ourChanges, theirChanges := changed(ch), changed(other)
+	ourRemovals, ourRemovedDirs := removed(ch)
+	theirRemovals, theirRemovedDirs := removed(other)
+
+	var conflicts []string
+	for path := range ourChanges {
+		if theirChanges[path] || isRemoved(path, theirRemovals, theirRemovedDirs) {
+			conflicts = append(conflicts, path)
+		}
+	}
+	for path := range theirChanges {
+		// changes to the same file were already counted above
+		if !ourChanges[path] && isRemoved(path, ourRemovals, ourRemovedDirs) {
+			conflicts = append(conflicts, path)
+		}
+	}
+	slices.Sort(conflicts)
+	return conflicts
+}
+
 func (*Changeset) Type() *ast.Type {
 	return &ast.Type{
 		NamedType: "Changeset",
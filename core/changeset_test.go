@@ -0,0 +1,48 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChangesetConflicts(t *testing.T) {
+	ours := &Changeset{
+		AddedPaths:      []string{"new/", "new/a.go"},
+		ModifiedPaths:   []string{"main.go", "go.mod"},
+		RemovedPaths:    []string{"old/"},
+		allRemovedPaths: []string{"old/", "old/b.go", "gone.go"},
+	}
+
+	t.Run("no conflicts", func(t *testing.T) {
+		theirs := &Changeset{
+			// adding to the same new directory is fine
+			AddedPaths:    []string{"new/", "new/c.go"},
+			ModifiedPaths: []string{"README.md"},
+			// so is removing the same file
+			allRemovedPaths: []string{"gone.go"},
+		}
+		require.Empty(t, ours.Conflicts(theirs))
+		require.Empty(t, theirs.Conflicts(ours))
+	})
+
+	t.Run("conflicts", func(t *testing.T) {
+		theirs := &Changeset{
+			AddedPaths:      []string{"new/a.go"},
+			ModifiedPaths:   []string{"go.mod", "old/b.go"},
+			allRemovedPaths: []string{"main.go"},
+		}
+		expected := []string{"go.mod", "main.go", "new/a.go", "old/b.go"}
+		require.Equal(t, expected, ours.Conflicts(theirs))
+		require.Equal(t, expected, theirs.Conflicts(ours))
+	})
+
+	t.Run("added under removed directory", func(t *testing.T) {
+		theirs := &Changeset{
+			AddedPaths: []string{"old/c.go", "old/sub/", "old/sub/d.go"},
+		}
+		expected := []string{"old/c.go", "old/sub/d.go"}
+		require.Equal(t, expected, ours.Conflicts(theirs))
+		require.Equal(t, expected, theirs.Conflicts(ours))
+	})
+}
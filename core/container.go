@@ -63,6 +63,14 @@ type Container struct {
 	// The container's root filesystem.
 	FS *dagql.ObjectResult[*Directory]
 
+	// The root filesystem of the image the container was created from, whose
+	// layers are kept when squashing.
+	BaseFS *dagql.ObjectResult[*Directory]
+
+	// Snapshots of the root filesystem at the end of each layer, as marked
+	// with withLayerBoundary.
+	LayerBoundaries []dagql.ObjectResult[*Directory]
+
 	// Image configuration (env, workdir, etc)
 	Config specs.ImageConfig
 
@@ -187,6 +195,7 @@ func (container *Container) Clone() *Container {
 	cp.Ports = slices.Clone(cp.Ports)
 	cp.Services = slices.Clone(cp.Services)
 	cp.SystemEnvNames = slices.Clone(cp.SystemEnvNames)
+	cp.LayerBoundaries = slices.Clone(cp.LayerBoundaries)
 	return &cp
 }
 
@@ -194,6 +203,8 @@ func (container *Container) WithoutInputs() *Container {
 	container = container.Clone()
 
 	container.FS = nil
+	container.BaseFS = nil
+	container.LayerBoundaries = nil
 	container.Meta = nil
 
 	for i, mount := range container.Mounts {
@@ -344,6 +355,9 @@ type CacheMountSource struct {
 	// The ID of the cache mount
 	ID string
 
+	// The key of the cache volume, without its namespace
+	Key string
+
 	// The sharing mode of the cache mount
 	SharingMode CacheSharingMode
 }
@@ -603,6 +617,8 @@ func (container *Container) FromCanonicalRef(
 	if err != nil {
 		return nil, fmt.Errorf("failed to create rootfs directory: %w", err)
 	}
+	container.BaseFS = container.FS
+	container.LayerBoundaries = nil
 	return container, nil
 }
 
@@ -767,6 +783,8 @@ func (container *Container) Build(
 	if err != nil {
 		return nil, fmt.Errorf("failed to create rootfs directory: %w", err)
 	}
+	container.BaseFS = nil
+	container.LayerBoundaries = nil
 
 	cfgBytes, found := res.Metadata[exptypes.ExporterImageConfigKey]
 	if found {
@@ -792,6 +810,10 @@ func (container *Container) WithRootFS(ctx context.Context, dir dagql.ObjectResu
 	container = container.Clone()
 	container.FS = &dir
 
+	// the layers of the previous rootfs don't apply to the new one
+	container.BaseFS = nil
+	container.LayerBoundaries = nil
+
 	// set image ref to empty string
 	container.ImageRef = ""
 
@@ -1258,6 +1280,7 @@ func (container *Container) WithMountedCache(
 		Target: target,
 		CacheSource: &CacheMountSource{
 			ID:          cache.Sum(),
+			Key:         cache.Key,
 			SharingMode: sharingMode,
 		},
 	}
@@ -1926,6 +1949,7 @@ func (container *Container) Publish(
 	platformVariants []*Container,
 	forcedCompression ImageLayerCompression,
 	mediaTypes ImageMediaTypes,
+	attestations ImageAttestationOpts,
 ) (string, error) {
 	if mediaTypes == "" {
 		// Modern registry implementations support oci types and docker daemons
@@ -1999,7 +2023,12 @@ func (container *Container) Publish(
 		return "", fmt.Errorf("failed to get buildkit client: %w", err)
 	}
 
-	resp, err := bk.PublishContainerImage(ctx, inputByPlatform, opts)
+	referrers, err := attestations.Referrers(ctx, ref, variants)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := bk.PublishContainerImage(ctx, inputByPlatform, opts, referrers)
 	if err != nil {
 		return "", err
 	}
@@ -2034,6 +2063,7 @@ type ExportOpts struct {
 	MediaTypes        ImageMediaTypes
 	Tar               bool
 	LeaseID           string
+	Attestations      ImageAttestationOpts
 }
 
 func (container *Container) Export(
@@ -2119,7 +2149,12 @@ func (container *Container) Export(
 		return nil, errors.New("no containers to export")
 	}
 
-	resp, err := bk.ExportContainerImage(ctx, inputByPlatform, opts.Dest, bkopts)
+	referrers, err := opts.Attestations.Referrers(ctx, "", variants)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := bk.ExportContainerImage(ctx, inputByPlatform, opts.Dest, bkopts, referrers)
 	if err != nil {
 		return nil, err
 	}
@@ -2222,6 +2257,10 @@ func (container *Container) FromInternal(
 	if err != nil {
 		return nil, fmt.Errorf("updated rootfs: %w", err)
 	}
+	// the loaded image replaces the previous rootfs and its layers, just as
+	// from does
+	container.BaseFS = container.FS
+	container.LayerBoundaries = nil
 
 	// eagerly evaluate the OCI reference so Buildkit sets up a long-term lease
 	_, err = bk.Solve(ctx, bkgw.SolveRequest{
@@ -2354,6 +2393,13 @@ type ContainerAsServiceArgs struct {
 	// Skip the init process injected into containers by default so that the
 	// user's process is PID 1
 	NoInit bool `default:"false"`
+
+	// Restrict the network access of the service
+	NetworkMode NetworkMode `default:"FULL"`
+
+	// Hostnames and CIDRs the service may connect to, on top of what its
+	// network mode allows
+	EgressAllowlist []string `default:"[]"`
 }
 
 func (container *Container) AsService(ctx context.Context, args ContainerAsServiceArgs) (*Service, error) {
@@ -2363,6 +2409,10 @@ func (container *Container) AsService(ctx context.Context, args ContainerAsServi
 		return nil, ErrNoSvcCommand
 	}
 
+	if _, err := args.NetworkMode.NetworkPolicy(args.EgressAllowlist, args.InsecureRootCapabilities); err != nil {
+		return nil, err
+	}
+
 	useEntrypoint := args.UseEntrypoint
 	if len(container.Config.Entrypoint) > 0 && !container.DefaultArgs {
 		useEntrypoint = true
@@ -2387,6 +2437,8 @@ func (container *Container) AsService(ctx context.Context, args ContainerAsServi
 		ExperimentalPrivilegedNesting: args.ExperimentalPrivilegedNesting,
 		InsecureRootCapabilities:      args.InsecureRootCapabilities,
 		NoInit:                        args.NoInit,
+		NetworkMode:                   args.NetworkMode,
+		EgressAllowlist:               args.EgressAllowlist,
 	}, nil
 }
 
@@ -2715,6 +2767,72 @@ func (expect ReturnTypes) ReturnCodes() []int {
 	}
 }
 
+type NetworkMode string
+
+var NetworkModesEnum = dagql.NewEnum[NetworkMode]()
+
+var (
+	NetworkModeFull = NetworkModesEnum.Register("FULL",
+		`Unrestricted network access`,
+	)
+	NetworkModeServicesOnly = NetworkModesEnum.Register("SERVICES_ONLY",
+		`Network access to services only, e.g. those bound with "withServiceBinding"`,
+	)
+	NetworkModeNone = NetworkModesEnum.Register("NONE",
+		`No network access, apart from loopback`,
+	)
+)
+
+func (mode NetworkMode) Type() *ast.Type {
+	return &ast.Type{
+		NamedType: "NetworkMode",
+		NonNull:   true,
+	}
+}
+
+func (mode NetworkMode) TypeDescription() string {
+	return "Network access granted to an execution"
+}
+
+func (mode NetworkMode) Decoder() dagql.InputDecoder {
+	return NetworkModesEnum
+}
+
+func (mode NetworkMode) ToLiteral() call.Literal {
+	return NetworkModesEnum.Literal(mode)
+}
+
+// NetworkPolicy returns the engine network policy enforcing the mode, with
+// the given hostnames and CIDRs allowed on top of it. A nil policy grants
+// full network access.
+//
+// The policy is enforced from within the container's network namespace, so
+// it can't be combined with insecure root capabilities, which would let the
+// container remove it.
+func (mode NetworkMode) NetworkPolicy(egressAllowlist []string, insecureRootCapabilities bool) (*buildkit.NetworkPolicy, error) {
+	if insecureRootCapabilities && mode != NetworkModeFull && mode != "" {
+		return nil, fmt.Errorf("the %s network mode can't be used with insecure root capabilities", mode)
+	}
+	switch mode {
+	case NetworkModeFull, "":
+		if len(egressAllowlist) > 0 {
+			return nil, fmt.Errorf("an egress allowlist requires the %s or %s network mode", NetworkModeNone, NetworkModeServicesOnly)
+		}
+		return nil, nil
+	case NetworkModeServicesOnly:
+		return &buildkit.NetworkPolicy{
+			AllowServices: true,
+			AllowEgress:   egressAllowlist,
+		}, nil
+	case NetworkModeNone:
+		return &buildkit.NetworkPolicy{
+			AllowEgress: egressAllowlist,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown network mode %q", mode)
+	}
+}
+
 type TerminalLegacy struct{}
 
 func (*TerminalLegacy) Type() *ast.Type {
@@ -0,0 +1,364 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/dustin/go-humanize"
+	specs "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/vektah/gqlparser/v2/ast"
+
+	"github.com/dagger/dagger/dagql"
+	"github.com/dagger/dagger/dagql/call"
+)
+
+type ContainerChangeKind string
+
+var ContainerChangeKinds = dagql.NewEnum[ContainerChangeKind]()
+
+var (
+	ContainerChangeAdded = ContainerChangeKinds.Register("ADDED",
+		`The entry only exists in the newer container.`,
+	)
+	ContainerChangeRemoved = ContainerChangeKinds.Register("REMOVED",
+		`The entry only exists in the older container.`,
+	)
+	ContainerChangeModified = ContainerChangeKinds.Register("MODIFIED",
+		`The entry exists in both containers, with different values.`,
+	)
+	ContainerChangeUnchanged = ContainerChangeKinds.Register("UNCHANGED",
+		`The entry is the same in both containers.`,
+	)
+)
+
+func (kind ContainerChangeKind) Type() *ast.Type {
+	return &ast.Type{
+		NamedType: "ContainerChangeKind",
+		NonNull:   true,
+	}
+}
+
+func (kind ContainerChangeKind) TypeDescription() string {
+	return "How an entry differs between two containers"
+}
+
+func (kind ContainerChangeKind) Decoder() dagql.InputDecoder {
+	return ContainerChangeKinds
+}
+
+func (kind ContainerChangeKind) ToLiteral() call.Literal {
+	return ContainerChangeKinds.Literal(kind)
+}
+
+// symbol is the prefix of the kind in comparison summaries.
+func (kind ContainerChangeKind) symbol() string {
+	switch kind {
+	case ContainerChangeAdded:
+		return "+"
+	case ContainerChangeRemoved:
+		return "-"
+	case ContainerChangeModified:
+		return "~"
+	default:
+		return "="
+	}
+}
+
+// ContainerComparison is a comparison of the image configuration and layers
+// of two containers.
+type ContainerComparison struct {
+	Before dagql.ObjectResult[*Container] `field:"true" doc:"The older container to compare against."`
+	After  dagql.ObjectResult[*Container] `field:"true" doc:"The newer container."`
+
+	ConfigChanges []*ContainerConfigChange    `field:"true" doc:"Changes to the image configuration, e.g. environment variables, entrypoint, user, labels and exposed ports."`
+	Layers        []*ContainerLayerComparison `field:"true" doc:"Comparison of the layers of the root filesystems, by position from the base layer."`
+}
+
+func (*ContainerComparison) Type() *ast.Type {
+	return &ast.Type{
+		NamedType: "ContainerComparison",
+		NonNull:   true,
+	}
+}
+
+func (*ContainerComparison) TypeDescription() string {
+	return "A comparison between the image configuration, layers and root filesystem of two containers."
+}
+
+type ContainerConfigChange struct {
+	Field  string              `field:"true" doc:"The changed setting, named after the API that sets it, e.g. \"envVariable\", \"entrypoint\" or \"label\"."`
+	Key    string              `field:"true" doc:"The changed entry of settings that have several, i.e. the name of an environment variable or label, or an exposed port such as \"8080/tcp\"."`
+	Kind   ContainerChangeKind `field:"true" doc:"How the setting changed."`
+	Before string              `field:"true" doc:"The value in the older container. Lists are encoded as JSON arrays."`
+	After  string              `field:"true" doc:"The value in the newer container. Lists are encoded as JSON arrays."`
+}
+
+func (*ContainerConfigChange) Type() *ast.Type {
+	return &ast.Type{
+		NamedType: "ContainerConfigChange",
+		NonNull:   true,
+	}
+}
+
+func (*ContainerConfigChange) TypeDescription() string {
+	return "A change to the image configuration between two containers."
+}
+
+type ContainerLayerComparison struct {
+	Index        int                 `field:"true" doc:"The position of the layer, starting from the base layer at 0."`
+	Kind         ContainerChangeKind `field:"true" doc:"Whether the layer differs between the containers."`
+	BeforeDigest string              `field:"true" doc:"The digest of the layer in the older container, if it has one at this position."`
+	BeforeSize   int                 `field:"true" doc:"The compressed size of the layer in the older container, in bytes."`
+	AfterDigest  string              `field:"true" doc:"The digest of the layer in the newer container, if it has one at this position."`
+	AfterSize    int                 `field:"true" doc:"The compressed size of the layer in the newer container, in bytes."`
+}
+
+func (*ContainerLayerComparison) Type() *ast.Type {
+	return &ast.Type{
+		NamedType: "ContainerLayerComparison",
+		NonNull:   true,
+	}
+}
+
+func (*ContainerLayerComparison) TypeDescription() string {
+	return "A comparison of the layers of two containers at the same position."
+}
+
+// NewContainerComparison compares the image configuration and layers of two
+// containers.
+func NewContainerComparison(ctx context.Context, before, after dagql.ObjectResult[*Container]) (*ContainerComparison, error) {
+	beforeLayers, err := before.Self().Layers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("layers of older container: %w", err)
+	}
+	afterLayers, err := after.Self().Layers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("layers of newer container: %w", err)
+	}
+	return &ContainerComparison{
+		Before:        before,
+		After:         after,
+		ConfigChanges: compareContainerConfigs(before.Self(), after.Self()),
+		Layers:        compareLayers(beforeLayers, afterLayers),
+	}, nil
+}
+
+// Layers returns the descriptors of the layers of the container's root
+// filesystem, as they would be published.
+func (container *Container) Layers(ctx context.Context) ([]specs.Descriptor, error) {
+	if container.FS == nil {
+		return nil, nil
+	}
+	res, err := container.FS.Self().Evaluate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ref, err := res.SingleRef()
+	if err != nil {
+		return nil, err
+	}
+	if ref == nil {
+		// scratch
+		return nil, nil
+	}
+	return ref.Layers(ctx)
+}
+
+func compareContainerConfigs(before, after *Container) []*ContainerConfigChange {
+	var changes []*ContainerConfigChange
+	compare := func(field, key, beforeVal, afterVal string, beforeOK, afterOK bool) {
+		change := &ContainerConfigChange{
+			Field:  field,
+			Key:    key,
+			Before: beforeVal,
+			After:  afterVal,
+		}
+		switch {
+		case beforeOK && afterOK:
+			if beforeVal == afterVal {
+				return
+			}
+			change.Kind = ContainerChangeModified
+		case afterOK:
+			change.Kind = ContainerChangeAdded
+		case beforeOK:
+			change.Kind = ContainerChangeRemoved
+		default:
+			return
+		}
+		changes = append(changes, change)
+	}
+	compareValue := func(field, beforeVal, afterVal string) {
+		compare(field, "", beforeVal, afterVal, beforeVal != "", afterVal != "")
+	}
+	compareList := func(field string, beforeVal, afterVal []string) {
+		compare(field, "", jsonList(beforeVal), jsonList(afterVal), beforeVal != nil, afterVal != nil)
+	}
+	compareMap := func(field string, beforeVals, afterVals map[string]string) {
+		keys := slices.Collect(maps.Keys(beforeVals))
+		for key := range afterVals {
+			if _, ok := beforeVals[key]; !ok {
+				keys = append(keys, key)
+			}
+		}
+		slices.Sort(keys)
+		for _, key := range keys {
+			beforeVal, beforeOK := beforeVals[key]
+			afterVal, afterOK := afterVals[key]
+			compare(field, key, beforeVal, afterVal, beforeOK, afterOK)
+		}
+	}
+
+	compareValue("platform", before.Platform.Format(), after.Platform.Format())
+	compareMap("envVariable", envMap(before.Config.Env), envMap(after.Config.Env))
+	compareList("entrypoint", before.Config.Entrypoint, after.Config.Entrypoint)
+	compareList("defaultArgs", before.Config.Cmd, after.Config.Cmd)
+	compareValue("user", before.Config.User, after.Config.User)
+	compareValue("workdir", before.Config.WorkingDir, after.Config.WorkingDir)
+	compareMap("label", before.Config.Labels, after.Config.Labels)
+	compareMap("exposedPort", portMap(before.Config.ExposedPorts), portMap(after.Config.ExposedPorts))
+	return changes
+}
+
+func envMap(env []string) map[string]string {
+	vals := make(map[string]string, len(env))
+	for _, kv := range env {
+		k, v, _ := strings.Cut(kv, "=")
+		vals[k] = v
+	}
+	return vals
+}
+
+func portMap(ports map[string]struct{}) map[string]string {
+	vals := make(map[string]string, len(ports))
+	for port := range ports {
+		vals[port] = ""
+	}
+	return vals
+}
+
+func jsonList(vals []string) string {
+	if vals == nil {
+		return ""
+	}
+	dt, err := json.Marshal(vals)
+	if err != nil {
+		// can't happen with strings
+		panic(err)
+	}
+	return string(dt)
+}
+
+func compareLayers(before, after []specs.Descriptor) []*ContainerLayerComparison {
+	layers := make([]*ContainerLayerComparison, max(len(before), len(after)))
+	for i := range layers {
+		layer := &ContainerLayerComparison{Index: i}
+		if i < len(before) {
+			layer.BeforeDigest = before[i].Digest.String()
+			layer.BeforeSize = int(before[i].Size)
+		}
+		if i < len(after) {
+			layer.AfterDigest = after[i].Digest.String()
+			layer.AfterSize = int(after[i].Size)
+		}
+		switch {
+		case i >= len(before):
+			layer.Kind = ContainerChangeAdded
+		case i >= len(after):
+			layer.Kind = ContainerChangeRemoved
+		case layer.BeforeDigest != layer.AfterDigest:
+			layer.Kind = ContainerChangeModified
+		default:
+			layer.Kind = ContainerChangeUnchanged
+		}
+		layers[i] = layer
+	}
+	return layers
+}
+
+// maxSummaryPaths is the number of paths listed per kind of filesystem change
+// in comparison summaries.
+const maxSummaryPaths = 50
+
+// Summary renders the comparison in a human-readable format, including the
+// given changes to the root filesystem.
+func (cmp *ContainerComparison) Summary(changes *Changeset) string {
+	var b strings.Builder
+
+	b.WriteString("Config:\n")
+	if len(cmp.ConfigChanges) == 0 {
+		b.WriteString("  no changes\n")
+	}
+	for _, change := range cmp.ConfigChanges {
+		name := change.Field
+		if change.Key != "" {
+			name += " " + change.Key
+		}
+		switch change.Kind {
+		case ContainerChangeAdded:
+			fmt.Fprintf(&b, "  %s %s: %s\n", change.Kind.symbol(), name, change.After)
+		case ContainerChangeRemoved:
+			fmt.Fprintf(&b, "  %s %s: %s\n", change.Kind.symbol(), name, change.Before)
+		default:
+			fmt.Fprintf(&b, "  %s %s: %s -> %s\n", change.Kind.symbol(), name, change.Before, change.After)
+		}
+	}
+
+	b.WriteString("\nLayers:\n")
+	if len(cmp.Layers) == 0 {
+		b.WriteString("  none\n")
+	}
+	var beforeTotal, afterTotal int
+	for _, layer := range cmp.Layers {
+		beforeTotal += layer.BeforeSize
+		afterTotal += layer.AfterSize
+		before := layerSummary(layer.BeforeDigest, layer.BeforeSize)
+		after := layerSummary(layer.AfterDigest, layer.AfterSize)
+		switch layer.Kind {
+		case ContainerChangeAdded:
+			fmt.Fprintf(&b, "  %s %d %s\n", layer.Kind.symbol(), layer.Index, after)
+		case ContainerChangeModified:
+			fmt.Fprintf(&b, "  %s %d %s -> %s\n", layer.Kind.symbol(), layer.Index, before, after)
+		default:
+			fmt.Fprintf(&b, "  %s %d %s\n", layer.Kind.symbol(), layer.Index, before)
+		}
+	}
+	fmt.Fprintf(&b, "  total: %s -> %s\n",
+		humanize.Bytes(uint64(beforeTotal)), humanize.Bytes(uint64(afterTotal)))
+
+	if changes != nil {
+		b.WriteString("\nFilesystem:\n")
+		fmt.Fprintf(&b, "  %d added, %d modified, %d removed\n",
+			len(changes.AddedPaths), len(changes.ModifiedPaths), len(changes.RemovedPaths))
+		for _, paths := range []struct {
+			symbol string
+			paths  []string
+		}{
+			{ContainerChangeAdded.symbol(), changes.AddedPaths},
+			{ContainerChangeModified.symbol(), changes.ModifiedPaths},
+			{ContainerChangeRemoved.symbol(), changes.RemovedPaths},
+		} {
+			for i, p := range paths.paths {
+				if i == maxSummaryPaths {
+					fmt.Fprintf(&b, "  %s ... and %d more\n", paths.symbol, len(paths.paths)-maxSummaryPaths)
+					break
+				}
+				fmt.Fprintf(&b, "  %s %s\n", paths.symbol, p)
+			}
+		}
+	}
+
+	return b.String()
+}
+
+func layerSummary(dgst string, size int) string {
+	short, _ := strings.CutPrefix(dgst, "sha256:")
+	if len(short) > 12 {
+		short = short[:12]
+	}
+	return fmt.Sprintf("%s (%s)", short, humanize.Bytes(uint64(size)))
+}
@@ -0,0 +1,95 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	specs "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompareContainerConfigs(t *testing.T) {
+	before := &Container{
+		Platform: Platform{OS: "linux", Architecture: "amd64"},
+		Config: specs.ImageConfig{
+			Env:          []string{"PATH=/bin", "FOO=bar", "GONE=1"},
+			Entrypoint:   []string{"/bin/sh", "-c"},
+			User:         "root",
+			Labels:       map[string]string{"version": "1", "same": "x"},
+			ExposedPorts: map[string]struct{}{"80/tcp": {}},
+		},
+	}
+	after := &Container{
+		Platform: Platform{OS: "linux", Architecture: "amd64"},
+		Config: specs.ImageConfig{
+			Env:          []string{"PATH=/bin", "FOO=baz", "NEW=a=b"},
+			Entrypoint:   []string{"/entrypoint"},
+			Cmd:          []string{"serve"},
+			WorkingDir:   "/app",
+			Labels:       map[string]string{"version": "2", "same": "x"},
+			ExposedPorts: map[string]struct{}{"8080/tcp": {}},
+		},
+	}
+
+	require.Equal(t, []*ContainerConfigChange{
+		{Field: "envVariable", Key: "FOO", Kind: ContainerChangeModified, Before: "bar", After: "baz"},
+		{Field: "envVariable", Key: "GONE", Kind: ContainerChangeRemoved, Before: "1"},
+		{Field: "envVariable", Key: "NEW", Kind: ContainerChangeAdded, After: "a=b"},
+		{Field: "entrypoint", Kind: ContainerChangeModified, Before: `["/bin/sh","-c"]`, After: `["/entrypoint"]`},
+		{Field: "defaultArgs", Kind: ContainerChangeAdded, After: `["serve"]`},
+		{Field: "user", Kind: ContainerChangeRemoved, Before: "root"},
+		{Field: "workdir", Kind: ContainerChangeAdded, After: "/app"},
+		{Field: "label", Key: "version", Kind: ContainerChangeModified, Before: "1", After: "2"},
+		{Field: "exposedPort", Key: "80/tcp", Kind: ContainerChangeRemoved},
+		{Field: "exposedPort", Key: "8080/tcp", Kind: ContainerChangeAdded},
+	}, compareContainerConfigs(before, after))
+
+	require.Empty(t, compareContainerConfigs(before, before))
+}
+
+func TestContainerComparisonSummary(t *testing.T) {
+	layer := func(content string, size int64) specs.Descriptor {
+		return specs.Descriptor{Digest: digest.FromString(content), Size: size}
+	}
+	base := layer("base", 3_000_000)
+	cmp := &ContainerComparison{
+		ConfigChanges: []*ContainerConfigChange{
+			{Field: "envVariable", Key: "FOO", Kind: ContainerChangeModified, Before: "bar", After: "baz"},
+			{Field: "user", Kind: ContainerChangeRemoved, Before: "root"},
+		},
+		Layers: compareLayers(
+			[]specs.Descriptor{base, layer("app", 1000)},
+			[]specs.Descriptor{base, layer("app2", 2000), layer("extra", 500)},
+		),
+	}
+
+	require.Equal(t, []ContainerChangeKind{
+		ContainerChangeUnchanged,
+		ContainerChangeModified,
+		ContainerChangeAdded,
+	}, []ContainerChangeKind{cmp.Layers[0].Kind, cmp.Layers[1].Kind, cmp.Layers[2].Kind})
+	require.Empty(t, cmp.Layers[2].BeforeDigest)
+	require.Equal(t, 500, cmp.Layers[2].AfterSize)
+
+	short := func(content string) string {
+		return digest.FromString(content).Encoded()[:12]
+	}
+	require.Equal(t, `Config:
+  ~ envVariable FOO: bar -> baz
+  - user: root
+
+Layers:
+  = 0 `+short("base")+` (3.0 MB)
+  ~ 1 `+short("app")+` (1.0 kB) -> `+short("app2")+` (2.0 kB)
+  + 2 `+short("extra")+` (500 B)
+  total: 3.0 MB -> 3.0 MB
+
+Filesystem:
+  1 added, 0 modified, 1 removed
+  + etc/new
+  - etc/old
+`, cmp.Summary(&Changeset{
+		AddedPaths:   []string{"etc/new"},
+		RemovedPaths: []string{"etc/old"},
+	}))
+}
@@ -73,6 +73,13 @@ type ContainerExecOpts struct {
 	// Skip the init process injected into containers by default so that the
 	// user's process is PID 1
 	NoInit bool `default:"false"`
+
+	// Restrict the network access of the command
+	NetworkMode NetworkMode `default:"FULL"`
+
+	// Hostnames and CIDRs the command may connect to, on top of what its
+	// network mode allows
+	EgressAllowlist []string `default:"[]"`
 }
 
 func (container *Container) execMeta(ctx context.Context, opts ContainerExecOpts, parent *buildkit.ExecutionMetadata) (*buildkit.ExecutionMetadata, error) {
@@ -111,6 +118,10 @@ func (container *Container) execMeta(ctx context.Context, opts ContainerExecOpts
 	if opts.NoInit {
 		execMD.NoInit = true
 	}
+	execMD.NetworkPolicy, err = opts.NetworkMode.NetworkPolicy(opts.EgressAllowlist, opts.InsecureRootCapabilities)
+	if err != nil {
+		return nil, err
+	}
 
 	var callerModID *call.ID
 	if execMD.EncodedModuleID != "" {
@@ -210,11 +221,16 @@ func (container *Container) secretEnvs() (secretEnvs []*pb.SecretEnv) {
 	return secretEnvs
 }
 
+// WithExec runs the command in the container. cacheKey identifies the
+// withExec call, to run it again in later sessions if its failure was skipped
+// or retried from the debugger.
+//
 //nolint:gocyclo
 func (container *Container) WithExec(
 	ctx context.Context,
 	opts ContainerExecOpts,
 	execMD *buildkit.ExecutionMetadata,
+	cacheKey string,
 ) (_ *Container, rerr error) {
 	container = container.Clone()
 
@@ -413,6 +429,16 @@ func (container *Container) WithExec(
 		return nil, err
 	}
 
+	for _, ctrMnt := range container.Mounts {
+		if ctrMnt.CacheSource == nil {
+			continue
+		}
+		if err := tagCacheVolumeRecords(ctx, cache, ctrMnt.CacheSource); err != nil {
+			// only affects GC, so don't fail the exec over it
+			slog.Warn("failed to tag cache volume records", "volume", ctrMnt.CacheSource.Key, "error", err)
+		}
+	}
+
 	// NOTE: seems to be a longstanding bug in buildkit that selector on root mount doesn't work, fix here
 	for _, mnt := range mounts.Mounts {
 		if mnt.Dest != "/" {
@@ -462,6 +488,40 @@ func (container *Container) WithExec(
 		procInfo.Stdin = io.NopCloser(strings.NewReader(opts.Stdin))
 	}
 	_, execErr := exec.Run(ctx, "", p.Root, p.Mounts, procInfo, nil)
+	if execErr != nil && bk.ShouldDebugExec(execMD) {
+		dbg := &execDebugger{
+			exec:   exec,
+			root:   p.Root,
+			mounts: p.Mounts,
+			meta:   meta,
+			stdin:  opts.Stdin,
+			shell:  []string{"/bin/sh"},
+			execMD: execMD,
+			group:  bkSessionGroup,
+		}
+		if len(bk.InteractiveCommand) > 0 {
+			dbg.shell = bk.InteractiveCommand
+		}
+		if emu != nil {
+			dbg.shell = append([]string{buildkit.BuildkitQemuEmulatorMountPoint}, dbg.shell...)
+		}
+		for _, ref := range p.OutputRefs {
+			if ref.MountIndex == 0 {
+				dbg.rootRef, _ = ref.Ref.(bkcache.MutableRef)
+			}
+		}
+		var skipped bool
+		skipped, execErr = dbg.debug(ctx, execErr)
+		if skipped || execErr == nil {
+			// the result doesn't match the command, so don't reuse it later
+			query.DebuggedExecs().Add(cacheKey, execMD.SessionID)
+		}
+		if skipped {
+			// leave the container as it was before the exec; the outputs are
+			// released by the defer
+			return container, nil
+		}
+	}
 
 	for i, ref := range p.OutputRefs {
 		// commit all refs
@@ -0,0 +1,356 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/containerd/containerd/v2/core/mount"
+	containerdfs "github.com/containerd/continuity/fs"
+	"github.com/muesli/termenv"
+
+	bkcache "github.com/dagger/dagger/internal/buildkit/cache"
+	bkclient "github.com/dagger/dagger/internal/buildkit/client"
+	"github.com/dagger/dagger/internal/buildkit/executor"
+	bkgwpb "github.com/dagger/dagger/internal/buildkit/frontend/gateway/pb"
+	"github.com/dagger/dagger/internal/buildkit/identity"
+	bksession "github.com/dagger/dagger/internal/buildkit/session"
+	"github.com/dagger/dagger/util/hashutil"
+
+	"github.com/dagger/dagger/engine"
+	"github.com/dagger/dagger/engine/buildkit"
+	"github.com/dagger/dagger/engine/slog"
+)
+
+// DebuggedExecs records the withExec calls whose failure was skipped or
+// retried from the debugger. Their results are cached like any other, so
+// other sessions get a new cache key for them to run them again.
+type DebuggedExecs struct {
+	mu    sync.Mutex
+	execs map[string]debuggedExec
+}
+
+type debuggedExec struct {
+	sessionID string
+	nonce     string
+}
+
+func NewDebuggedExecs() *DebuggedExecs {
+	return &DebuggedExecs{execs: map[string]debuggedExec{}}
+}
+
+// Add records that the result of the call with the given cache key was
+// skipped or retried from the debugger in the given session.
+func (d *DebuggedExecs) Add(key, sessionID string) {
+	if d == nil {
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.execs[key] = debuggedExec{sessionID: sessionID, nonce: identity.NewID()}
+}
+
+// CacheKey returns the cache key of a call in the given session: the given key,
+// or a key derived from it if its result was debugged in another session. The
+// derived key may have been debugged too, in which case it's derived again.
+func (d *DebuggedExecs) CacheKey(key, sessionID string) string {
+	if d == nil {
+		return key
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for {
+		exec, ok := d.execs[key]
+		if !ok || exec.sessionID == sessionID {
+			return key
+		}
+		key = hashutil.HashStrings(key, exec.nonce).String()
+	}
+}
+
+// execDebugger lets the user step through a failed exec from a terminal. The
+// terminal runs in the mounts of the exec, before they're committed, so that
+// changes made there are kept when the exec is retried.
+type execDebugger struct {
+	exec   executor.Executor
+	root   executor.Mount
+	mounts []executor.Mount
+	meta   executor.Meta
+
+	// stdin is the content written to the stdin of the exec
+	stdin string
+
+	// shell is the command run in the terminal
+	shell []string
+
+	// rootRef is the root filesystem of the exec, or nil if it's read-only
+	rootRef bkcache.MutableRef
+
+	execMD *buildkit.ExecutionMetadata
+	group  bksession.Group
+}
+
+type execDebugAction struct {
+	key  byte
+	desc string
+}
+
+var execDebugActions = []execDebugAction{
+	{'t', "open a terminal"},
+	{'r', "retry the exec"},
+	{'s', "skip the exec"},
+	{'e', "export the root filesystem to the host"},
+	{'q', "give up"},
+}
+
+const execDebugKeys = "trseq"
+
+// debug opens a terminal in the failed exec, then lets the user choose what to
+// do next until the exec succeeds, is skipped, or they give up. It returns
+// whether the exec was skipped, and its latest error if it wasn't fixed.
+func (dbg *execDebugger) debug(ctx context.Context, execErr error) (skipped bool, _ error) {
+	term, output, err := prepTerminal(ctx, dbg.execMD.CallID, execErr)
+	if err != nil {
+		slog.Warn("failed to open debugger", "error", err)
+		return false, execErr
+	}
+	defer term.Close(bkgwpb.UnknownExitStatus) // always close term; it's wrapped in a once so it won't be called multiple times
+
+	stdin := newDebugStdin(term.Stdin)
+	defer stdin.Close()
+	stdout := rawTermWriter{term.Stdout}
+	stderr := rawTermWriter{term.Stderr}
+
+	// start with a terminal, like --interactive always did
+	key := byte('t')
+	for {
+		switch key {
+		case 't':
+			if err := dbg.runShell(ctx, term, stdin, output); err != nil {
+				fmt.Fprintf(stderr, output.String("! %s").Foreground(termenv.ANSIYellow).String()+"\n", err)
+			}
+		case 'r':
+			fmt.Fprintf(stderr, "Retrying %s\n", strings.Join(dbg.meta.Args, " "))
+			execErr = dbg.retry(ctx, stdout, stderr)
+			if execErr == nil {
+				term.Close(0)
+				return false, nil
+			}
+			fmt.Fprintf(stderr, output.String("! %s").Foreground(termenv.ANSIYellow).String()+"\n", execErr)
+		case 's':
+			term.Close(0)
+			return true, nil
+		case 'e':
+			destPath := "dagger-exec-" + dbg.execMD.ExecID
+			if err := dbg.export(ctx, destPath); err != nil {
+				fmt.Fprintf(stderr, output.String("! %s").Foreground(termenv.ANSIYellow).String()+"\n", err)
+			} else {
+				fmt.Fprintf(stderr, "Exported the root filesystem to %s\n", destPath)
+			}
+		case 'q':
+			term.Close(1)
+			return false, execErr
+		}
+
+		fmt.Fprintln(stderr)
+		for _, action := range execDebugActions {
+			fmt.Fprintf(stderr, "  %s  %s\n", output.String(string(action.key)).Bold(), action.desc)
+		}
+		fmt.Fprint(stderr, output.String("? ").Foreground(termenv.ANSIYellow).String())
+
+		key, err = stdin.ReadKey(ctx, execDebugKeys)
+		if err != nil {
+			return false, execErr
+		}
+		fmt.Fprintf(stderr, "%c\n", key)
+	}
+}
+
+// runShell runs the shell in the mounts of the exec, with the terminal
+// attached.
+func (dbg *execDebugger) runShell(ctx context.Context, term *buildkit.TerminalClient, stdin *debugStdin, output *termenv.Output) error {
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(errors.New("debug shell exited"))
+
+	meta := dbg.meta
+	meta.Args = dbg.shell
+	meta.Env = prepTerminalEnv(output, slices.Clone(meta.Env))
+	meta.Env = addDefaultEnvvar(meta.Env, "TERM", "xterm")
+	meta.Tty = true
+
+	_, err := dbg.exec.Run(ctx, "", dbg.root, dbg.mounts, executor.ProcessInfo{
+		Meta:   meta,
+		Stdin:  stdin.Pipe(ctx),
+		Stdout: term.Stdout,
+		Stderr: term.Stderr,
+		Resize: convertResizeChannel(ctx, term.ResizeCh),
+	}, nil)
+	var exitErr *bkgwpb.ExitError
+	if errors.As(err, &exitErr) {
+		// the shell exiting with the status of the last command isn't an error
+		return nil
+	}
+	return err
+}
+
+// retry runs the exec again in its mounts, which include any changes made from
+// the terminal.
+func (dbg *execDebugger) retry(ctx context.Context, stdout, stderr io.Writer) error {
+	procInfo := executor.ProcessInfo{
+		Meta:   dbg.meta,
+		Stdout: discardOnClose(stdout),
+		Stderr: discardOnClose(stderr),
+	}
+	if dbg.stdin != "" {
+		procInfo.Stdin = io.NopCloser(strings.NewReader(dbg.stdin))
+	}
+	_, err := dbg.exec.Run(ctx, "", dbg.root, dbg.mounts, procInfo, nil)
+	return err
+}
+
+// export copies the root filesystem of the exec to the host of the main
+// client, i.e. the user running the debugger.
+func (dbg *execDebugger) export(ctx context.Context, destPath string) error {
+	if dbg.rootRef == nil {
+		return errors.New("cannot export a read-only root filesystem")
+	}
+	query, err := CurrentQuery(ctx)
+	if err != nil {
+		return err
+	}
+	bk, err := query.Buildkit(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get buildkit client: %w", err)
+	}
+	mainClient, err := query.MainClientCallerMetadata(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get main client caller metadata: %w", err)
+	}
+
+	// the root filesystem is still used by retries, so export a copy of it
+	newRef, err := query.BuildkitCache().New(ctx, nil, dbg.group,
+		bkcache.WithRecordType(bkclient.UsageRecordTypeRegular),
+		bkcache.WithDescription("export of failed exec "+strings.Join(dbg.meta.Args, " ")))
+	if err != nil {
+		return err
+	}
+	defer newRef.Release(context.WithoutCancel(ctx))
+	err = MountRef(ctx, dbg.rootRef, dbg.group, func(src string, _ *mount.Mount) error {
+		return MountRef(ctx, newRef, dbg.group, func(dest string, _ *mount.Mount) error {
+			return containerdfs.CopyDir(dest, src)
+		})
+	}, mountRefAsReadOnly)
+	if err != nil {
+		return fmt.Errorf("failed to copy root filesystem: %w", err)
+	}
+	ref, err := newRef.Commit(ctx)
+	if err != nil {
+		return err
+	}
+	defer ref.Release(context.WithoutCancel(ctx))
+
+	return bk.LocalRefExport(engine.ContextWithClientMetadata(ctx, mainClient), ref, destPath)
+}
+
+// debugStdin reads the stdin of the terminal in the background, so that it can
+// be handed over to each shell in turn and read by the debugger in between.
+type debugStdin struct {
+	ch   chan []byte
+	done chan struct{}
+}
+
+func newDebugStdin(r io.Reader) *debugStdin {
+	stdin := &debugStdin{
+		ch:   make(chan []byte),
+		done: make(chan struct{}),
+	}
+	go func() {
+		defer close(stdin.ch)
+		for {
+			b := make([]byte, 512)
+			n, err := r.Read(b)
+			if n > 0 {
+				select {
+				case stdin.ch <- b[:n]:
+				case <-stdin.done:
+					return
+				}
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+	return stdin
+}
+
+// Pipe returns a reader of stdin, until ctx is done.
+func (stdin *debugStdin) Pipe(ctx context.Context) io.ReadCloser {
+	r, w := io.Pipe()
+	go func() {
+		defer w.Close()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case b, ok := <-stdin.ch:
+				if !ok {
+					return
+				}
+				if _, err := w.Write(b); err != nil {
+					return
+				}
+			}
+		}
+	}()
+	return r
+}
+
+// ReadKey returns the next key pressed among the given keys, ignoring others
+// and whitespace in case the terminal isn't in raw mode. Ctrl+C and Ctrl+D are
+// read as 'q'.
+func (stdin *debugStdin) ReadKey(ctx context.Context, keys string) (byte, error) {
+	for {
+		select {
+		case <-ctx.Done():
+			return 0, context.Cause(ctx)
+		case b, ok := <-stdin.ch:
+			if !ok {
+				return 0, io.EOF
+			}
+			for _, c := range b {
+				switch c {
+				case ' ', '\t', '\r', '\n':
+					continue
+				case 0x03, 0x04:
+					return 'q', nil
+				}
+				if strings.IndexByte(keys, c) >= 0 {
+					return c, nil
+				}
+			}
+		}
+	}
+}
+
+func (stdin *debugStdin) Close() {
+	close(stdin.done)
+}
+
+// rawTermWriter writes to a terminal in raw mode, where a newline doesn't
+// return the cursor to the start of the line.
+type rawTermWriter struct {
+	w io.Writer
+}
+
+func (w rawTermWriter) Write(p []byte) (int, error) {
+	if _, err := w.w.Write(bytes.ReplaceAll(p, []byte("\n"), []byte("\r\n"))); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
@@ -0,0 +1,99 @@
+package core
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDebugStdinReadKey(t *testing.T) {
+	ctx := context.Background()
+
+	r, w := io.Pipe()
+	stdin := newDebugStdin(r)
+	defer stdin.Close()
+
+	go func() {
+		// a terminal that isn't in raw mode sends keys with newlines, and
+		// unknown keys are ignored
+		w.Write([]byte("x\n"))
+		w.Write([]byte(" r\r\n"))
+		w.Write([]byte{0x03})
+		w.Close()
+	}()
+
+	key, err := stdin.ReadKey(ctx, execDebugKeys)
+	require.NoError(t, err)
+	require.Equal(t, byte('r'), key)
+
+	key, err = stdin.ReadKey(ctx, execDebugKeys)
+	require.NoError(t, err)
+	require.Equal(t, byte('q'), key)
+
+	_, err = stdin.ReadKey(ctx, execDebugKeys)
+	require.ErrorIs(t, err, io.EOF)
+}
+
+func TestDebugStdinPipe(t *testing.T) {
+	r, w := io.Pipe()
+	stdin := newDebugStdin(r)
+	defer stdin.Close()
+
+	go func() {
+		w.Write([]byte("ls\n"))
+		w.Write([]byte("exit\n"))
+	}()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	shell := stdin.Pipe(ctx)
+	buf := make([]byte, 3)
+	_, err := io.ReadFull(shell, buf)
+	require.NoError(t, err)
+	require.Equal(t, "ls\n", string(buf))
+	buf = make([]byte, 5)
+	_, err = io.ReadFull(shell, buf)
+	require.NoError(t, err)
+	require.Equal(t, "exit\n", string(buf))
+
+	// once the shell is done, the debugger reads the next key
+	cancel()
+	_, err = io.ReadAll(shell)
+	require.NoError(t, err)
+	go w.Write([]byte("s"))
+	key, err := stdin.ReadKey(context.Background(), execDebugKeys)
+	require.NoError(t, err)
+	require.Equal(t, byte('s'), key)
+}
+
+func TestRawTermWriter(t *testing.T) {
+	buf := new(strings.Builder)
+	n, err := rawTermWriter{buf}.Write([]byte("a\nb\n"))
+	require.NoError(t, err)
+	require.Equal(t, 4, n)
+	require.Equal(t, "a\r\nb\r\n", buf.String())
+}
+
+func TestDebuggedExecsCacheKey(t *testing.T) {
+	execs := NewDebuggedExecs()
+	require.Equal(t, "key", execs.CacheKey("key", "session1"))
+
+	// the debugging session keeps its result, others get a new key
+	execs.Add("key", "session1")
+	require.Equal(t, "key", execs.CacheKey("key", "session1"))
+	derived := execs.CacheKey("key", "session2")
+	require.NotEqual(t, "key", derived)
+	require.Equal(t, derived, execs.CacheKey("key", "session3"))
+
+	// debugging the exec again derives another key
+	execs.Add(derived, "session2")
+	require.Equal(t, derived, execs.CacheKey("key", "session2"))
+	rederived := execs.CacheKey("key", "session3")
+	require.NotEqual(t, "key", rederived)
+	require.NotEqual(t, derived, rederived)
+
+	// other execs keep their key
+	require.Equal(t, "other", execs.CacheKey("other", "session2"))
+}
@@ -0,0 +1,239 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/containerd/containerd/v2/core/mount"
+	containerdfs "github.com/containerd/continuity/fs"
+	"github.com/vektah/gqlparser/v2/ast"
+
+	"github.com/dagger/dagger/dagql"
+	"github.com/dagger/dagger/dagql/call"
+	"github.com/dagger/dagger/engine/buildkit"
+	bkcache "github.com/dagger/dagger/internal/buildkit/cache"
+	bkclient "github.com/dagger/dagger/internal/buildkit/client"
+	bksession "github.com/dagger/dagger/internal/buildkit/session"
+	fscopy "github.com/dagger/dagger/internal/fsutil/copy"
+)
+
+type ImageSquashMode string
+
+var ImageSquashModes = dagql.NewEnum[ImageSquashMode]()
+
+var (
+	ImageSquashAll = ImageSquashModes.Register("ALL",
+		`Squash the whole root filesystem into a single layer.`,
+	)
+	ImageSquashFromBase = ImageSquashModes.Register("FROM_BASE",
+		`Keep the layers of the image the container was created from, and squash everything on top of them into a single layer.`,
+	)
+)
+
+func (mode ImageSquashMode) Type() *ast.Type {
+	return &ast.Type{
+		NamedType: "ImageSquashMode",
+		NonNull:   true,
+	}
+}
+
+func (mode ImageSquashMode) TypeDescription() string {
+	return "How to squash the layers of an image"
+}
+
+func (mode ImageSquashMode) Decoder() dagql.InputDecoder {
+	return ImageSquashModes
+}
+
+func (mode ImageSquashMode) ToLiteral() call.Literal {
+	return ImageSquashModes.Literal(mode)
+}
+
+// WithLayerBoundary marks the end of a layer: the changes made to the root
+// filesystem since the previous boundary, or since the base image, are
+// grouped into a single layer when the container is exported or published.
+func (container *Container) WithLayerBoundary() *Container {
+	container = container.Clone()
+	if container.FS != nil {
+		container.LayerBoundaries = append(container.LayerBoundaries, *container.FS)
+	}
+	return container
+}
+
+// HasLayerGroups returns whether the layers of the container's root
+// filesystem need to be regrouped before exporting it, given the squash mode.
+func (container *Container) HasLayerGroups(squash ImageSquashMode) bool {
+	return container.FS != nil && (squash != "" || len(container.LayerBoundaries) > 0)
+}
+
+// LayeredRootFS returns the container's root filesystem with a single layer
+// per group of changes between its layer boundaries, on top of the layers of
+// its base image. With a squash mode, boundaries are ignored and all the
+// changes are squashed into one layer.
+//
+// The layers are built by copying the changed paths with their metadata, so
+// the same filesystems always produce the same layers.
+func (container *Container) LayeredRootFS(ctx context.Context, squash ImageSquashMode) (*Directory, error) {
+	if container.FS == nil {
+		return nil, errors.New("container has no root filesystem")
+	}
+
+	var base *dagql.ObjectResult[*Directory]
+	var boundaries []dagql.ObjectResult[*Directory]
+	switch squash {
+	case ImageSquashAll:
+	case ImageSquashFromBase:
+		base = container.BaseFS
+	default:
+		base = container.BaseFS
+		boundaries = container.LayerBoundaries
+	}
+
+	query, err := CurrentQuery(ctx)
+	if err != nil {
+		return nil, err
+	}
+	bkSessionGroup, ok := buildkit.CurrentBuildkitSessionGroup(ctx)
+	if !ok {
+		return nil, fmt.Errorf("no buildkit session group in context")
+	}
+	cache := query.BuildkitCache()
+
+	var ref bkcache.ImmutableRef
+	var prevDigest string
+	if base != nil {
+		ref, err = getRefOrEvaluate(ctx, base.Self())
+		if err != nil {
+			return nil, fmt.Errorf("failed to evaluate base image: %w", err)
+		}
+		prevDigest = base.ID().Digest().String()
+	}
+	// the refs created here, which are released once the next layer holds
+	// them
+	var created bkcache.ImmutableRef
+	for _, boundary := range slices.Concat(boundaries, []dagql.ObjectResult[*Directory]{*container.FS}) {
+		if boundary.ID().Digest().String() == prevDigest {
+			// nothing changed since the last boundary
+			continue
+		}
+		prevDigest = boundary.ID().Digest().String()
+
+		target, err := getRefOrEvaluate(ctx, boundary.Self())
+		if err != nil {
+			return nil, fmt.Errorf("failed to evaluate layer boundary: %w", err)
+		}
+		layer, err := squashLayer(ctx, cache, bkSessionGroup, ref, target)
+		if err != nil {
+			return nil, err
+		}
+		if layer == nil {
+			continue
+		}
+		if created != nil {
+			if err := created.Release(context.WithoutCancel(ctx)); err != nil {
+				return nil, err
+			}
+		}
+		ref, created = layer, layer
+	}
+	if created == nil && ref != nil {
+		// the base image is unchanged; don't share its ref with the result
+		ref = ref.Clone()
+	}
+
+	dir := container.FS.Self().Clone()
+	dir.Result = ref
+	return dir, nil
+}
+
+// squashLayer returns a new layer on top of parent with the contents of
+// target, or nil if they're the same.
+func squashLayer(ctx context.Context, cache bkcache.Manager, g bksession.Group, parent, target bkcache.ImmutableRef) (bkcache.ImmutableRef, error) {
+	var only map[string]struct{}
+	var removed []string
+	if parent != nil {
+		err := MountRef(ctx, parent, g, func(parentRoot string, _ *mount.Mount) error {
+			return MountRef(ctx, target, g, func(targetRoot string, _ *mount.Mount) (err error) {
+				only, removed, err = layerChanges(ctx, parentRoot, targetRoot)
+				return err
+			}, mountRefAsReadOnly)
+		}, mountRefAsReadOnly)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compare layers: %w", err)
+		}
+		if len(only) == 0 && len(removed) == 0 {
+			return nil, nil
+		}
+	}
+
+	newRef, err := cache.New(ctx, parent, g,
+		bkcache.WithRecordType(bkclient.UsageRecordTypeRegular),
+		bkcache.WithDescription("squashed layer"))
+	if err != nil {
+		return nil, err
+	}
+	err = MountRef(ctx, newRef, g, func(root string, _ *mount.Mount) error {
+		return MountRef(ctx, target, g, func(targetRoot string, _ *mount.Mount) error {
+			return applyLayerChanges(ctx, root, targetRoot, only, removed)
+		}, mountRefAsReadOnly)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to squash layer: %w", err)
+	}
+	return newRef.Commit(ctx)
+}
+
+// layerChanges returns the paths to copy from upper to turn lower into it,
+// and the paths to remove. Parent directories of changed paths are copied
+// too, since their timestamps change with their entries.
+func layerChanges(ctx context.Context, lower, upper string) (map[string]struct{}, []string, error) {
+	only := map[string]struct{}{}
+	var removed []string
+	err := containerdfs.Changes(ctx, lower, upper, func(kind containerdfs.ChangeKind, p string, _ os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		p = strings.TrimPrefix(filepath.ToSlash(p), "/")
+		switch kind {
+		case containerdfs.ChangeKindUnmodified:
+			return nil
+		case containerdfs.ChangeKindDelete:
+			removed = append(removed, p)
+		default:
+			only[p] = struct{}{}
+		}
+		for dir := path.Dir(p); dir != "."; dir = path.Dir(dir) {
+			only[dir] = struct{}{}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return only, removed, nil
+}
+
+// applyLayerChanges removes the given paths from root and copies the others
+// from src. All of src is copied if only is nil.
+func applyLayerChanges(ctx context.Context, root, src string, only map[string]struct{}, removed []string) error {
+	for _, p := range removed {
+		resolved, err := RootPathWithoutFinalSymlink(root, p)
+		if err != nil {
+			return err
+		}
+		if err := os.RemoveAll(resolved); err != nil {
+			return err
+		}
+	}
+	return fscopy.Copy(ctx, src, ".", root, ".", fscopy.WithCopyInfo(fscopy.CopyInfo{
+		CopyDirContents:                true,
+		AlwaysReplaceExistingDestPaths: true,
+		Only:                           only,
+	}))
+}
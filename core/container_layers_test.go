@@ -0,0 +1,58 @@
+package core
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyLayerChanges(t *testing.T) {
+	ctx := context.Background()
+	mtime := time.Unix(1700000000, 0)
+	write := func(root, p, content string) {
+		require.NoError(t, os.MkdirAll(filepath.Join(root, filepath.Dir(p)), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(root, p), []byte(content), 0o644))
+		// changes are detected by metadata too, so unchanged files need the
+		// same timestamps
+		require.NoError(t, os.Chtimes(filepath.Join(root, p), mtime, mtime))
+	}
+
+	lower := t.TempDir()
+	write(lower, "etc/keep", "same")
+	write(lower, "etc/old", "gone")
+	write(lower, "app/config", "v1")
+
+	upper := t.TempDir()
+	write(upper, "etc/keep", "same")
+	write(upper, "app/config", "v2")
+	write(upper, "app/bin/server", "new")
+
+	only, removed, err := layerChanges(ctx, lower, upper)
+	require.NoError(t, err)
+	require.Equal(t, []string{"etc/old"}, removed)
+	require.Contains(t, only, "app/config")
+	require.Contains(t, only, "app/bin/server")
+	require.Contains(t, only, "app/bin")
+	require.NotContains(t, only, "etc/keep")
+
+	require.NoError(t, applyLayerChanges(ctx, lower, upper, only, removed))
+	require.NoFileExists(t, filepath.Join(lower, "etc/old"))
+	for p, content := range map[string]string{
+		"etc/keep":       "same",
+		"app/config":     "v2",
+		"app/bin/server": "new",
+	} {
+		dt, err := os.ReadFile(filepath.Join(lower, p))
+		require.NoError(t, err)
+		require.Equal(t, content, string(dt))
+	}
+
+	only, removed, err = layerChanges(ctx, lower, upper)
+	require.NoError(t, err)
+	require.Empty(t, only)
+	require.Empty(t, removed)
+}
@@ -928,6 +928,11 @@ func extractContainerBkOutputs(ctx context.Context, container *Container, bk *bu
 		var err error
 		switch mountIdx {
 		case 0:
+			if container.FS == nil {
+				// e.g. a skipped exec in a scratch container
+				ref, err = getResult(nil, nil, "")
+				break
+			}
 			ref, err = getResult(
 				container.FS.Self().LLB,
 				container.FS.Self().Result,
@@ -22,6 +22,51 @@ func (*Engine) TypeDescription() string {
 	return "The Dagger engine configuration and state"
 }
 
+type EngineSession struct {
+	ID              string   `field:"true" doc:"The ID of the session."`
+	ClientID        string   `field:"true" doc:"The ID of the main client that started the session."`
+	ClientHostname  string   `field:"true" doc:"The hostname of the main client."`
+	ClientVersion   string   `field:"true" doc:"The version of the main client."`
+	Command         string   `field:"true" doc:"The command run by the main client, if known."`
+	Modules         []string `field:"true" doc:"The names of the modules with clients connected to the session."`
+	StartedUnixNano int      `field:"true" doc:"The time the session started, in Unix nanoseconds."`
+	RunningExecs    int      `field:"true" doc:"The number of containers currently running in the session."`
+	CacheHits       int      `field:"true" doc:"The number of cacheable calls in the session that were cached."`
+	CacheMisses     int      `field:"true" doc:"The number of cacheable calls in the session that were not cached."`
+}
+
+func (*EngineSession) Type() *ast.Type {
+	return &ast.Type{
+		NamedType: "EngineSession",
+		NonNull:   true,
+	}
+}
+
+func (*EngineSession) TypeDescription() string {
+	return "A session of a client connected to the Dagger engine"
+}
+
+type EngineService struct {
+	Name              string `field:"true" doc:"The name the service was started with."`
+	Hostname          string `field:"true" doc:"The hostname to reach the service from any session."`
+	Ports             []int  `field:"true" doc:"The ports exposed by the service."`
+	StartedUnixNano   int    `field:"true" doc:"The time the service started, in Unix nanoseconds."`
+	IdleTimeout       string `field:"true" doc:"How long the service keeps running while unused (e.g., \"30m\"), or empty if it runs until stopped."`
+	IdleSinceUnixNano int    `field:"true" doc:"The time the service became unused, in Unix nanoseconds, or 0 if it's in use."`
+	Bindings          int    `field:"true" doc:"The number of bindings currently using the service, across sessions."`
+}
+
+func (*EngineService) Type() *ast.Type {
+	return &ast.Type{
+		NamedType: "EngineService",
+		NonNull:   true,
+	}
+}
+
+func (*EngineService) TypeDescription() string {
+	return "A persistent service running in the Dagger engine, shared across sessions"
+}
+
 type EngineCache struct {
 	MaxUsedSpace  int `field:"true" doc:"The maximum bytes to keep in the cache without pruning."`
 	TargetSpace   int `field:"true" doc:"The target number of bytes to keep when pruning."`
@@ -68,6 +113,8 @@ type EngineCacheEntry struct {
 	CreatedTimeUnixNano       int    `field:"true" doc:"The time the cache entry was created, in Unix nanoseconds."`
 	MostRecentUseTimeUnixNano int    `field:"true" doc:"The most recent time the cache entry was used, in Unix nanoseconds."`
 	ActivelyUsed              bool   `field:"true" doc:"Whether the cache entry is actively being used."`
+	Pinned                    bool   `field:"true" doc:"Whether the cache entry is pinned, exempting it from pruning."`
+	RecordID                  string `field:"true" doc:"The ID of the underlying cache record, which stays the same across sessions and engine restarts."`
 }
 
 func (*EngineCacheEntry) Type() *ast.Type {
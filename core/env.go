@@ -133,6 +133,21 @@ func (env *Env) WithOutput(key string, expectedType dagql.Type, description stri
 	return env
 }
 
+// Attach a check to a registered output, which values saved to it must pass
+func (env *Env) WithOutputCheck(key string, check *OutputCheck) (*Env, error) {
+	if check.MaxAttempts < 1 {
+		return nil, fmt.Errorf("max attempts must be at least 1, got %d", check.MaxAttempts)
+	}
+	env = env.Clone()
+	output, exists := env.outputsByName[key]
+	if !exists {
+		return nil, fmt.Errorf("output not found: %s", key)
+	}
+	output.Check = check
+	output.Attempts = 0
+	return env, nil
+}
+
 // Lookup registered outputs in the environment
 func (env *Env) Input(key string) (*Binding, bool) {
 	if input, exists := env.inputsByName[key]; exists {
@@ -190,6 +205,27 @@ type Binding struct {
 	// The expected type
 	// Used when defining an output
 	ExpectedType string
+	// The check that values must pass to be saved to the output
+	Check *OutputCheck
+	// The number of values checked for the output so far
+	Attempts int
+}
+
+// OutputCheck validates the values saved to an output, so that the model can
+// be asked to fix an invalid value instead of finishing with it.
+type OutputCheck struct {
+	// The name of a function of a module installed in the environment, which
+	// is called with the value as its argument
+	Function string
+
+	// The container that runs Args against the value, with the value mounted
+	// at Path, or passed on stdin for a string
+	Container dagql.ObjectResult[*Container]
+	Args      []string
+	Path      string
+
+	// The number of values that may be checked before giving up
+	MaxAttempts int
 }
 
 func (*Binding) Type() *ast.Type {
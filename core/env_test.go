@@ -0,0 +1,31 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dagger/dagger/dagql"
+)
+
+func TestEnvWithOutputCheck(t *testing.T) {
+	env := NewEnv(dagql.ObjectResult[*Directory]{}, nil).
+		WithOutput("greeting", dagql.String(""), "a greeting")
+
+	_, err := env.WithOutputCheck("missing", &OutputCheck{Function: "check", MaxAttempts: 3})
+	require.ErrorContains(t, err, "output not found: missing")
+
+	_, err = env.WithOutputCheck("greeting", &OutputCheck{Function: "check"})
+	require.ErrorContains(t, err, "max attempts must be at least 1")
+
+	checked, err := env.WithOutputCheck("greeting", &OutputCheck{Function: "check", MaxAttempts: 3})
+	require.NoError(t, err)
+	output, ok := checked.Output("greeting")
+	require.True(t, ok)
+	require.Equal(t, "check", output.Check.Function)
+
+	// the original environment is left unchecked
+	output, ok = env.Output("greeting")
+	require.True(t, ok)
+	require.Nil(t, output.Check)
+}
@@ -35,6 +35,7 @@ import (
 	"github.com/dagger/dagger/engine"
 	"github.com/dagger/dagger/engine/buildkit"
 	enginecache "github.com/dagger/dagger/engine/cache"
+	"github.com/dagger/dagger/engine/metrics"
 	"github.com/dagger/dagger/engine/slog"
 	"github.com/dagger/dagger/network"
 	"github.com/dagger/dagger/util/hashutil"
@@ -354,7 +355,7 @@ func (repo *RemoteGitRepository) mount(ctx context.Context, depth int, refs []Gi
 	})
 }
 
-func (repo *RemoteGitRepository) fetch(ctx context.Context, git *gitutil.GitCLI, depth int, refs []*RemoteGitRef) error {
+func (repo *RemoteGitRepository) fetch(ctx context.Context, git *gitutil.GitCLI, depth int, refs []*RemoteGitRef) (rerr error) {
 	query, err := CurrentQuery(ctx)
 	if err != nil {
 		return err
@@ -398,6 +399,11 @@ func (repo *RemoteGitRepository) fetch(ctx context.Context, git *gitutil.GitCLI,
 	}
 	defer detach()
 
+	fetchStart := time.Now()
+	defer func() {
+		metrics.ObserveGitFetch(time.Since(fetchStart), rerr)
+	}()
+
 	if _, err := git.Run(ctx, args...); err != nil {
 		if errors.Is(err, gitutil.ErrShallowNotSupported) {
 			// fallback to full fetch
@@ -0,0 +1,518 @@
+package core
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/distribution/reference"
+	intoto "github.com/in-toto/in-toto-golang/in_toto"
+	slsacommon "github.com/in-toto/in-toto-golang/in_toto/slsa_provenance/common"
+	slsa1 "github.com/in-toto/in-toto-golang/in_toto/slsa_provenance/v1"
+	specs "github.com/opencontainers/image-spec/specs-go/v1"
+	"golang.org/x/crypto/nacl/secretbox"
+	"golang.org/x/crypto/scrypt"
+
+	"github.com/dagger/dagger/dagql/call"
+	"github.com/dagger/dagger/engine"
+	"github.com/dagger/dagger/engine/buildkit"
+)
+
+const (
+	// SigstoreBundleMediaType is the media type of the signatures and signed
+	// attestations attached to images, compatible with cosign.
+	SigstoreBundleMediaType = "application/vnd.dev.sigstore.bundle.v0.3+json"
+
+	// InTotoMediaType is the media type of unsigned attestations.
+	InTotoMediaType = "application/vnd.in-toto+json"
+
+	// ProvenanceBuildType identifies the format of the build definition of
+	// provenance attestations generated for images.
+	ProvenanceBuildType = "https://dagger.io/provenance/container/v1"
+
+	// ProvenanceBuilderID identifies the builder of images in provenance
+	// attestations.
+	ProvenanceBuilderID = "https://dagger.io/engine"
+
+	inTotoStatementV1 = "https://in-toto.io/Statement/v1"
+
+	// cosignSignPredicateType is the predicate of the statements that cosign
+	// signs to sign the image itself, rather than an attestation.
+	cosignSignPredicateType = "https://sigstore.dev/cosign/sign/v1"
+
+	bundleContentAnnotation       = "dev.sigstore.bundle.content"
+	bundlePredicateTypeAnnotation = "dev.sigstore.bundle.predicateType"
+	inTotoPredicateTypeAnnotation = "in-toto.io/predicate-type"
+)
+
+// ImageAttestationOpts configures the signatures and attestations attached
+// to a published or exported image as OCI referrers.
+type ImageAttestationOpts struct {
+	// SigningKey is a PEM encoded private key to sign the image and its
+	// attestations with. Both cosign encrypted keys and unencrypted PKCS#8,
+	// EC and RSA keys are supported.
+	SigningKey []byte
+
+	// SigningKeyPassword decrypts SigningKey, if it's encrypted.
+	SigningKeyPassword []byte
+
+	// Provenance attaches a SLSA provenance attestation, built from the call
+	// that built the image.
+	Provenance bool
+
+	// SBOM attaches a software bill of materials in the given format, if
+	// set.
+	SBOM SBOMFormat
+
+	// ID is the call that built the image.
+	ID *call.ID
+}
+
+// Referrers returns a function building the referrers to attach to an image
+// of the given containers, or nil if there are none to attach.
+func (opts ImageAttestationOpts) Referrers(ctx context.Context, imageName string, containers []*Container) (buildkit.ImageReferrersFunc, error) {
+	if opts.SigningKey == nil && !opts.Provenance && opts.SBOM == "" {
+		return nil, nil
+	}
+
+	var signer *imageSigner
+	if opts.SigningKey != nil {
+		var err error
+		signer, err = parseSigningKey(opts.SigningKey, opts.SigningKeyPassword)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if opts.Provenance && opts.ID == nil {
+		return nil, errors.New("provenance requires the ID of the image")
+	}
+	var sbomDoc []byte
+	if opts.SBOM != "" {
+		distro, pkgs, err := containerPackages(ctx, containers...)
+		if err != nil {
+			return nil, err
+		}
+		sbomDoc, err = opts.SBOM.Encode(imageName, distro, pkgs)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// attestation returns the referrer for an in-toto statement, signed if
+	// there's a key
+	attestation := func(statement []byte, predicateType string) (buildkit.ImageReferrer, error) {
+		if signer == nil {
+			return buildkit.ImageReferrer{
+				ArtifactType: InTotoMediaType,
+				MediaType:    InTotoMediaType,
+				Data:         statement,
+				Annotations: map[string]string{
+					inTotoPredicateTypeAnnotation: predicateType,
+				},
+			}, nil
+		}
+		bundle, err := signer.signStatement(statement)
+		if err != nil {
+			return buildkit.ImageReferrer{}, err
+		}
+		return buildkit.ImageReferrer{
+			ArtifactType: SigstoreBundleMediaType,
+			MediaType:    SigstoreBundleMediaType,
+			Data:         bundle,
+			Annotations: map[string]string{
+				bundleContentAnnotation:       "dsse-envelope",
+				bundlePredicateTypeAnnotation: predicateType,
+			},
+		}, nil
+	}
+
+	return func(ctx context.Context, subject specs.Descriptor, _ []byte) ([]buildkit.ImageReferrer, error) {
+		var referrers []buildkit.ImageReferrer
+		if signer != nil {
+			// like `cosign sign` with the sigstore bundle format, sign a
+			// statement about the image with an empty predicate
+			statement, err := json.Marshal(intoto.Statement{
+				StatementHeader: statementHeader(imageName, subject, cosignSignPredicateType),
+				Predicate:       struct{}{},
+			})
+			if err != nil {
+				return nil, err
+			}
+			referrer, err := attestation(statement, cosignSignPredicateType)
+			if err != nil {
+				return nil, err
+			}
+			referrers = append(referrers, referrer)
+		}
+		if opts.Provenance {
+			statement, err := provenanceStatement(ctx, imageName, subject, opts.ID)
+			if err != nil {
+				return nil, err
+			}
+			referrer, err := attestation(statement, slsa1.PredicateSLSAProvenance)
+			if err != nil {
+				return nil, err
+			}
+			referrers = append(referrers, referrer)
+		}
+		if sbomDoc != nil {
+			statement, err := json.Marshal(intoto.Statement{
+				StatementHeader: statementHeader(imageName, subject, opts.SBOM.PredicateType()),
+				Predicate:       json.RawMessage(sbomDoc),
+			})
+			if err != nil {
+				return nil, err
+			}
+			referrer, err := attestation(statement, opts.SBOM.PredicateType())
+			if err != nil {
+				return nil, err
+			}
+			referrers = append(referrers, referrer)
+		}
+		return referrers, nil
+	}, nil
+}
+
+// provenanceStatement builds an in-toto statement with a SLSA provenance
+// predicate for the image, describing the calls that built it.
+//
+// Attestations are published alongside the image, so the calls are only
+// described by the chain of fields and the digest of the ID: argument values
+// may be anything, including credentials passed as plain strings.
+func provenanceStatement(ctx context.Context, imageName string, subject specs.Descriptor, id *call.ID) ([]byte, error) {
+	var invocationID string
+	if clientMetadata, err := engine.ClientMetadataFromContext(ctx); err == nil {
+		invocationID = clientMetadata.SessionID
+	}
+
+	statement := intoto.ProvenanceStatementSLSA1{
+		StatementHeader: statementHeader(imageName, subject, slsa1.PredicateSLSAProvenance),
+		Predicate: slsa1.ProvenancePredicate{
+			BuildDefinition: slsa1.ProvenanceBuildDefinition{
+				BuildType: ProvenanceBuildType,
+				ExternalParameters: map[string]any{
+					"call":   provenanceCall(id),
+					"digest": id.Digest().String(),
+				},
+				ResolvedDependencies: provenanceDependencies(id),
+			},
+			RunDetails: slsa1.ProvenanceRunDetails{
+				Builder: slsa1.Builder{
+					ID: ProvenanceBuilderID,
+					Version: map[string]string{
+						"dagger": engine.Version,
+					},
+				},
+				BuildMetadata: slsa1.BuildMetadata{
+					InvocationID: invocationID,
+				},
+			},
+		},
+	}
+	return json.Marshal(statement)
+}
+
+// provenanceCall displays the chain of fields selected by the ID, without
+// their arguments.
+func provenanceCall(id *call.ID) string {
+	var fields []string
+	for ; id != nil; id = id.Receiver() {
+		fields = append(fields, id.Field())
+	}
+	slices.Reverse(fields)
+	return strings.Join(fields, ".")
+}
+
+// statementHeader returns the header of an in-toto statement about the image.
+func statementHeader(imageName string, subject specs.Descriptor, predicateType string) intoto.StatementHeader {
+	if imageName != "" {
+		if named, err := reference.ParseNormalizedNamed(imageName); err == nil {
+			imageName = named.Name()
+		}
+	}
+	return intoto.StatementHeader{
+		Type:          inTotoStatementV1,
+		PredicateType: predicateType,
+		Subject: []intoto.Subject{{
+			Name: imageName,
+			Digest: slsacommon.DigestSet{
+				subject.Digest.Algorithm().String(): subject.Digest.Encoded(),
+			},
+		}},
+	}
+}
+
+// provenanceDependencies returns the images, git repositories and HTTP
+// resources that the call depends on.
+func provenanceDependencies(id *call.ID) []slsa1.ResourceDescriptor {
+	var deps []slsa1.ResourceDescriptor
+	seenIDs := map[string]bool{}
+	seenDeps := map[string]bool{}
+	var walk func(*call.ID)
+	var walkLiteral func(call.Literal)
+	walk = func(id *call.ID) {
+		for ; id != nil; id = id.Receiver() {
+			if seenIDs[id.Digest().String()] {
+				return
+			}
+			seenIDs[id.Digest().String()] = true
+
+			if dep, ok := provenanceDependency(id); ok && !seenDeps[dep.URI] {
+				seenDeps[dep.URI] = true
+				deps = append(deps, dep)
+			}
+			for _, arg := range id.Args() {
+				walkLiteral(arg.Value())
+			}
+		}
+	}
+	walkLiteral = func(lit call.Literal) {
+		switch lit := lit.(type) {
+		case *call.LiteralID:
+			walk(lit.Value())
+		case *call.LiteralList:
+			for _, v := range lit.Values() {
+				walkLiteral(v)
+			}
+		case *call.LiteralObject:
+			for _, arg := range lit.Args() {
+				walkLiteral(arg.Value())
+			}
+		}
+	}
+	walk(id)
+	return deps
+}
+
+func provenanceDependency(id *call.ID) (slsa1.ResourceDescriptor, bool) {
+	stringArg := func(name string) string {
+		arg := id.Arg(name)
+		if arg == nil {
+			return ""
+		}
+		lit, ok := arg.Value().(*call.LiteralString)
+		if !ok {
+			return ""
+		}
+		return lit.Value()
+	}
+	switch id.Field() {
+	case "from":
+		address := stringArg("address")
+		if address == "" {
+			return slsa1.ResourceDescriptor{}, false
+		}
+		dep := slsa1.ResourceDescriptor{URI: "docker-image://" + address}
+		if named, err := reference.ParseNormalizedNamed(address); err == nil {
+			dep.URI = "docker-image://" + named.String()
+			if canonical, ok := named.(reference.Canonical); ok {
+				dep.Digest = slsacommon.DigestSet{
+					canonical.Digest().Algorithm().String(): canonical.Digest().Encoded(),
+				}
+			}
+		}
+		return dep, true
+	case "git":
+		if url := stringArg("url"); url != "" {
+			return slsa1.ResourceDescriptor{URI: "git+" + redactURL(url)}, true
+		}
+	case "http":
+		if url := stringArg("url"); url != "" {
+			return slsa1.ResourceDescriptor{URI: redactURL(url)}, true
+		}
+	}
+	return slsa1.ResourceDescriptor{}, false
+}
+
+// redactURL strips the parts of a URL that commonly carry credentials: the
+// user info and the query.
+func redactURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Scheme == "" {
+		// e.g. scp-style git remotes, which only carry a username
+		return rawURL
+	}
+	u.User = nil
+	u.RawQuery = ""
+	return u.String()
+}
+
+// imageSigner signs images and attestations with a private key, producing
+// sigstore bundles verifiable with its public key.
+type imageSigner struct {
+	key crypto.Signer
+	// hint identifies the public key in bundles, as the base64 encoded
+	// SHA-256 of its DER encoding.
+	hint string
+}
+
+func parseSigningKey(pemBytes, password []byte) (*imageSigner, error) {
+	block, _ := pem.Decode(pemBytes)
+	if block == nil {
+		return nil, errors.New("signing key is not PEM encoded")
+	}
+
+	var key any
+	var err error
+	switch block.Type {
+	case "ENCRYPTED SIGSTORE PRIVATE KEY", "ENCRYPTED COSIGN PRIVATE KEY":
+		var der []byte
+		der, err = decryptCosignKey(block.Bytes, password)
+		if err != nil {
+			return nil, err
+		}
+		key, err = x509.ParsePKCS8PrivateKey(der)
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported signing key type %q", block.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parse signing key: %w", err)
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unsupported signing key %T", key)
+	}
+	switch signer.(type) {
+	case *ecdsa.PrivateKey, *rsa.PrivateKey, ed25519.PrivateKey:
+	default:
+		return nil, fmt.Errorf("unsupported signing key %T", key)
+	}
+	pub, err := x509.MarshalPKIXPublicKey(signer.Public())
+	if err != nil {
+		return nil, fmt.Errorf("marshal public key: %w", err)
+	}
+	hint := sha256.Sum256(pub)
+	return &imageSigner{
+		key:  signer,
+		hint: base64.StdEncoding.EncodeToString(hint[:]),
+	}, nil
+}
+
+// cosignEncryptedKey is the format of the keys encrypted by cosign.
+type cosignEncryptedKey struct {
+	KDF struct {
+		Name   string `json:"name"`
+		Params struct {
+			N int `json:"N"`
+			R int `json:"r"`
+			P int `json:"p"`
+		} `json:"params"`
+		Salt []byte `json:"salt"`
+	} `json:"kdf"`
+	Cipher struct {
+		Name  string `json:"name"`
+		Nonce []byte `json:"nonce"`
+	} `json:"cipher"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+func decryptCosignKey(data, password []byte) ([]byte, error) {
+	var enc cosignEncryptedKey
+	if err := json.Unmarshal(data, &enc); err != nil {
+		return nil, fmt.Errorf("decode encrypted signing key: %w", err)
+	}
+	if enc.KDF.Name != "scrypt" {
+		return nil, fmt.Errorf("unsupported key derivation function %q", enc.KDF.Name)
+	}
+	if enc.Cipher.Name != "nacl/secretbox" {
+		return nil, fmt.Errorf("unsupported cipher %q", enc.Cipher.Name)
+	}
+	if len(enc.Cipher.Nonce) != 24 {
+		return nil, errors.New("invalid nonce in encrypted signing key")
+	}
+	derived, err := scrypt.Key(password, enc.KDF.Salt, enc.KDF.Params.N, enc.KDF.Params.R, enc.KDF.Params.P, 32)
+	if err != nil {
+		return nil, fmt.Errorf("derive signing key password: %w", err)
+	}
+	var secretKey [32]byte
+	copy(secretKey[:], derived)
+	var nonce [24]byte
+	copy(nonce[:], enc.Cipher.Nonce)
+	der, ok := secretbox.Open(nil, enc.Ciphertext, &nonce, &secretKey)
+	if !ok {
+		return nil, errors.New("decrypt signing key: incorrect password")
+	}
+	return der, nil
+}
+
+func (s *imageSigner) sign(data []byte) ([]byte, error) {
+	if _, ok := s.key.(ed25519.PrivateKey); ok {
+		return s.key.Sign(rand.Reader, data, crypto.Hash(0))
+	}
+	dgst := sha256.Sum256(data)
+	return s.key.Sign(rand.Reader, dgst[:], crypto.SHA256)
+}
+
+type sigstoreBundle struct {
+	MediaType            string                `json:"mediaType"`
+	VerificationMaterial sigstoreVerification  `json:"verificationMaterial"`
+	DSSEEnvelope         *sigstoreDSSEEnvelope `json:"dsseEnvelope,omitempty"`
+}
+
+type sigstoreVerification struct {
+	PublicKey struct {
+		Hint string `json:"hint"`
+	} `json:"publicKey"`
+}
+
+type sigstoreDSSEEnvelope struct {
+	Payload     []byte                  `json:"payload"`
+	PayloadType string                  `json:"payloadType"`
+	Signatures  []sigstoreDSSESignature `json:"signatures"`
+}
+
+type sigstoreDSSESignature struct {
+	Sig   []byte `json:"sig"`
+	KeyID string `json:"keyid"`
+}
+
+func (s *imageSigner) bundle() sigstoreBundle {
+	bundle := sigstoreBundle{MediaType: SigstoreBundleMediaType}
+	bundle.VerificationMaterial.PublicKey.Hint = s.hint
+	return bundle
+}
+
+// signStatement returns a bundle with a DSSE envelope of the given in-toto
+// statement.
+func (s *imageSigner) signStatement(statement []byte) ([]byte, error) {
+	sig, err := s.sign(dssePAE(InTotoMediaType, statement))
+	if err != nil {
+		return nil, fmt.Errorf("sign statement: %w", err)
+	}
+	bundle := s.bundle()
+	bundle.DSSEEnvelope = &sigstoreDSSEEnvelope{
+		Payload:     statement,
+		PayloadType: InTotoMediaType,
+		Signatures:  []sigstoreDSSESignature{{Sig: sig, KeyID: s.hint}},
+	}
+	return json.Marshal(bundle)
+}
+
+// dssePAE is the pre-authentication encoding of a DSSE payload, which is
+// what's actually signed.
+func dssePAE(payloadType string, payload []byte) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "DSSEv1 %d %s %d ", len(payloadType), payloadType, len(payload))
+	b.Write(payload)
+	return []byte(b.String())
+}
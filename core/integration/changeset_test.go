@@ -455,6 +455,27 @@ func (ChangesetSuite) TestChangeset(ctx context.Context, t *testctx.T) {
 		require.NotContains(t, entries, "root.txt")
 	})
 
+	t.Run("withChangeset with the same base loaded differently", func(ctx context.Context, t *testctx.T) {
+		c := connect(ctx, t)
+
+		// the same content, built by different calls
+		base := c.Directory().WithNewFile("a.txt", "a").WithNewFile("b.txt", "b")
+		sameBase := c.Directory().WithNewFile("b.txt", "b").WithNewFile("a.txt", "a")
+
+		changes := base.WithNewFile("a.txt", "a modified").Changes(base).
+			WithChangeset(sameBase.WithNewFile("c.txt", "c").Changes(sameBase))
+
+		modified, err := changes.ModifiedPaths(ctx)
+		require.NoError(t, err)
+		require.Equal(t, []string{"a.txt"}, modified)
+		added, err := changes.AddedPaths(ctx)
+		require.NoError(t, err)
+		require.Equal(t, []string{"c.txt"}, added)
+
+		_, err = changes.WithChangeset(c.Directory().WithNewFile("d.txt", "d").Changes(c.Directory())).Sync(ctx)
+		requireErrOut(t, err, "cannot merge changesets that compare against different directories")
+	})
+
 	t.Run("test withChanges can be applied on subdir", func(ctx context.Context, t *testctx.T) {
 		c := connect(ctx, t)
 
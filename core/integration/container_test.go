@@ -3,16 +3,22 @@ package core
 import (
 	"bytes"
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
 	"crypto/md5"
 	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
 	_ "embed"
 	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
+	"encoding/pem"
 	"fmt"
 	"net"
 	"net/http"
 	"os"
+	"os/exec"
 	"path"
 	"path/filepath"
 	"runtime"
@@ -2646,6 +2652,376 @@ func (ContainerSuite) TestPublish(ctx context.Context, t *testctx.T) {
 	require.Equal(t, "im-a-default-arg\n", output)
 }
 
+func (ContainerSuite) TestPublishAttestations(ctx context.Context, t *testctx.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+
+	t.Run("publish", func(ctx context.Context, t *testctx.T) {
+		c := connect(ctx, t)
+
+		testRef := registryRef("container-publish-attestations")
+		pushedRef, err := c.Container().
+			From(alpineImage).
+			Publish(ctx, testRef, dagger.ContainerPublishOpts{
+				SigningKey: c.SetSecret("signing-key", string(keyPEM)),
+				Provenance: true,
+			})
+		require.NoError(t, err)
+		require.Contains(t, pushedRef, "@sha256:")
+
+		parsedRef, err := name.ParseReference(pushedRef, name.Insecure)
+		require.NoError(t, err)
+		imgDesc, err := remote.Get(parsedRef, remote.WithTransport(http.DefaultTransport))
+		require.NoError(t, err)
+
+		// the registry doesn't implement the referrers API, so look them up
+		// with the tag schema fallback
+		repo := parsedRef.Context()
+		tagRef := repo.Tag(strings.Replace(imgDesc.Digest.String(), ":", "-", 1))
+		indexDesc, err := remote.Get(tagRef, remote.WithTransport(http.DefaultTransport))
+		require.NoError(t, err)
+		var index ocispecs.Index
+		require.NoError(t, json.Unmarshal(indexDesc.Manifest, &index))
+		require.Len(t, index.Manifests, 2)
+
+		bundles := map[string]map[string]any{}
+		for _, desc := range index.Manifests {
+			require.Equal(t, core.SigstoreBundleMediaType, desc.ArtifactType)
+
+			mfstDesc, err := remote.Get(repo.Digest(desc.Digest.String()), remote.WithTransport(http.DefaultTransport))
+			require.NoError(t, err)
+			var mfst ocispecs.Manifest
+			require.NoError(t, json.Unmarshal(mfstDesc.Manifest, &mfst))
+			require.NotNil(t, mfst.Subject)
+			require.Equal(t, imgDesc.Digest.String(), mfst.Subject.Digest.String())
+			require.Len(t, mfst.Layers, 1)
+
+			layer, err := remote.Layer(repo.Digest(mfst.Layers[0].Digest.String()), remote.WithTransport(http.DefaultTransport))
+			require.NoError(t, err)
+			rc, err := layer.Compressed()
+			require.NoError(t, err)
+			var bundle map[string]any
+			require.NoError(t, json.NewDecoder(rc).Decode(&bundle))
+			rc.Close()
+			bundles[mfst.Annotations["dev.sigstore.bundle.predicateType"]] = bundle
+		}
+
+		sigBundle := bundles["https://sigstore.dev/cosign/sign/v1"]
+		require.NotNil(t, sigBundle)
+		sigEnvelope := sigBundle["dsseEnvelope"].(map[string]any)
+		sigPayload, err := base64.StdEncoding.DecodeString(sigEnvelope["payload"].(string))
+		require.NoError(t, err)
+		require.Contains(t, string(sigPayload), imgDesc.Digest.Hex)
+		sigs := sigEnvelope["signatures"].([]any)
+		require.Len(t, sigs, 1)
+		require.NotEmpty(t, sigs[0].(map[string]any)["keyid"])
+		sig, err := base64.StdEncoding.DecodeString(sigs[0].(map[string]any)["sig"].(string))
+		require.NoError(t, err)
+		pae := fmt.Sprintf("DSSEv1 %d %s %d %s", len(core.InTotoMediaType), core.InTotoMediaType, len(sigPayload), sigPayload)
+		dgst := sha256.Sum256([]byte(pae))
+		require.True(t, ecdsa.VerifyASN1(&key.PublicKey, dgst[:], sig))
+
+		// cosign accepts the signature
+		pubDER, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+		require.NoError(t, err)
+		cosignDir := t.TempDir()
+		pubPath := filepath.Join(cosignDir, "cosign.pub")
+		require.NoError(t, os.WriteFile(pubPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubDER}), 0o600))
+		cosignPath := filepath.Join(cosignDir, "cosign")
+		_, err = c.Container().From(cosignImage).File("/ko-app/cosign").Export(ctx, cosignPath)
+		require.NoError(t, err)
+		cosign := exec.CommandContext(ctx, cosignPath, "verify",
+			"--key", pubPath,
+			"--insecure-ignore-tlog",
+			"--allow-http-registry",
+			"--allow-insecure-registry",
+			pushedRef,
+		)
+		cosign.Env = append(os.Environ(), "TUF_ROOT="+filepath.Join(cosignDir, "tuf"))
+		out, err := cosign.CombinedOutput()
+		require.NoError(t, err, string(out))
+
+		provBundle := bundles["https://slsa.dev/provenance/v1"]
+		require.NotNil(t, provBundle)
+		envelope := provBundle["dsseEnvelope"].(map[string]any)
+		payload, err := base64.StdEncoding.DecodeString(envelope["payload"].(string))
+		require.NoError(t, err)
+		require.Contains(t, string(payload), imgDesc.Digest.Hex)
+		require.Contains(t, string(payload), alpineImage)
+	})
+
+	t.Run("export", func(ctx context.Context, t *testctx.T) {
+		wd := t.TempDir()
+		c := connect(ctx, t, dagger.WithWorkdir(wd))
+
+		_, err := c.Container().
+			From(alpineImage).
+			Export(ctx, "image.tar", dagger.ContainerExportOpts{
+				Provenance: true,
+				Sbom:       dagger.SBOMFormatSpdxJson,
+			})
+		require.NoError(t, err)
+
+		imagePath := filepath.Join(wd, "image.tar")
+		var index ocispecs.Index
+		require.NoError(t, json.Unmarshal(readTarFile(t, imagePath, "index.json"), &index))
+		require.Len(t, index.Manifests, 3)
+
+		statements := map[string]string{}
+		for _, desc := range index.Manifests {
+			if desc.ArtifactType != core.InTotoMediaType {
+				continue
+			}
+			var mfst ocispecs.Manifest
+			require.NoError(t, json.Unmarshal(readTarFile(t, imagePath, "blobs/sha256/"+desc.Digest.Encoded()), &mfst))
+			require.NotNil(t, mfst.Subject)
+			require.Len(t, mfst.Layers, 1)
+			statement := readTarFile(t, imagePath, "blobs/sha256/"+mfst.Layers[0].Digest.Encoded())
+			require.Contains(t, string(statement), mfst.Subject.Digest.Encoded())
+			statements[mfst.Annotations["in-toto.io/predicate-type"]] = string(statement)
+		}
+		require.Len(t, statements, 2)
+		require.Contains(t, statements["https://slsa.dev/provenance/v1"], alpineImage)
+		require.Contains(t, statements["https://spdx.dev/Document"], "pkg:apk/alpine/musl@")
+	})
+}
+
+func (ContainerSuite) TestSBOM(ctx context.Context, t *testctx.T) {
+	c := connect(ctx, t)
+
+	ctr := c.Container().From(alpineImage).
+		WithNewFile("/src/go.mod", "module example.com/app\n\ngo 1.22\n\nrequire github.com/pkg/errors v0.9.1\n")
+
+	spdxContents, err := ctr.Sbom().Contents(ctx)
+	require.NoError(t, err)
+	cdxContents, err := ctr.Sbom(dagger.ContainerSbomOpts{
+		Format: dagger.SBOMFormatCyclonedxJson,
+	}).Contents(ctx)
+	require.NoError(t, err)
+	dirContents, err := ctr.Directory("/src").Sbom().Contents(ctx)
+	require.NoError(t, err)
+
+	var spdx struct {
+		SPDXVersion string `json:"spdxVersion"`
+		Packages    []struct {
+			Name         string `json:"name"`
+			ExternalRefs []struct {
+				ReferenceLocator string `json:"referenceLocator"`
+			} `json:"externalRefs"`
+		} `json:"packages"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(spdxContents), &spdx))
+	require.Equal(t, "SPDX-2.3", spdx.SPDXVersion)
+	var purls []string
+	for _, pkg := range spdx.Packages {
+		for _, ref := range pkg.ExternalRefs {
+			purls = append(purls, ref.ReferenceLocator)
+		}
+	}
+	require.Contains(t, strings.Join(purls, "\n"), "pkg:apk/alpine/musl@")
+	require.Contains(t, purls, "pkg:golang/github.com/pkg/errors@v0.9.1")
+
+	var cdx struct {
+		BOMFormat  string `json:"bomFormat"`
+		Components []struct {
+			PURL string `json:"purl"`
+		} `json:"components"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(cdxContents), &cdx))
+	require.Equal(t, "CycloneDX", cdx.BOMFormat)
+	require.NotEmpty(t, cdx.Components)
+
+	// only the directory is scanned, not the rest of the container
+	require.Contains(t, dirContents, "pkg:golang/github.com/pkg/errors@v0.9.1")
+	require.NotContains(t, dirContents, "pkg:apk/")
+}
+
+func (ContainerSuite) TestCompare(ctx context.Context, t *testctx.T) {
+	c := connect(ctx, t)
+
+	before := c.Container().From(alpineImage).
+		WithEnvVariable("FOO", "bar").
+		WithLabel("version", "1")
+	after := before.
+		WithEnvVariable("FOO", "baz").
+		WithoutLabel("version").
+		WithExposedPort(8080).
+		WithEntrypoint([]string{"/bin/sh"}).
+		WithNewFile("/etc/app.conf", "hello").
+		WithoutFile("/etc/motd")
+	cmp := before.Compare(after)
+
+	type configChange struct {
+		Field  string
+		Key    string
+		Kind   dagger.ContainerChangeKind
+		Before string
+		After  string
+	}
+	changes, err := cmp.ConfigChanges(ctx)
+	require.NoError(t, err)
+	var configChanges []configChange
+	for _, change := range changes {
+		var c configChange
+		c.Field, err = change.Field(ctx)
+		require.NoError(t, err)
+		c.Key, err = change.Key(ctx)
+		require.NoError(t, err)
+		c.Kind, err = change.Kind(ctx)
+		require.NoError(t, err)
+		c.Before, err = change.Before(ctx)
+		require.NoError(t, err)
+		c.After, err = change.After(ctx)
+		require.NoError(t, err)
+		configChanges = append(configChanges, c)
+	}
+	require.Equal(t, []configChange{
+		{Field: "envVariable", Key: "FOO", Kind: dagger.ContainerChangeKindModified, Before: "bar", After: "baz"},
+		{Field: "entrypoint", Kind: dagger.ContainerChangeKindAdded, After: `["/bin/sh"]`},
+		{Field: "label", Key: "version", Kind: dagger.ContainerChangeKindRemoved, Before: "1"},
+		{Field: "exposedPort", Key: "8080/tcp", Kind: dagger.ContainerChangeKindAdded},
+	}, configChanges)
+
+	// the base image layer is shared, and the file changes add layers
+	layers, err := cmp.Layers(ctx)
+	require.NoError(t, err)
+	require.NotEmpty(t, layers)
+	first := layers[0]
+	kind, err := first.Kind(ctx)
+	require.NoError(t, err)
+	require.Equal(t, dagger.ContainerChangeKindUnchanged, kind)
+	beforeDigest, err := first.BeforeDigest(ctx)
+	require.NoError(t, err)
+	afterDigest, err := first.AfterDigest(ctx)
+	require.NoError(t, err)
+	require.Equal(t, beforeDigest, afterDigest)
+	beforeSize, err := first.BeforeSize(ctx)
+	require.NoError(t, err)
+	require.NotZero(t, beforeSize)
+	last := layers[len(layers)-1]
+	kind, err = last.Kind(ctx)
+	require.NoError(t, err)
+	require.NotEqual(t, dagger.ContainerChangeKindUnchanged, kind)
+	afterDigest, err = last.AfterDigest(ctx)
+	require.NoError(t, err)
+	require.NotEmpty(t, afterDigest)
+
+	addedPaths, err := cmp.Changes().AddedPaths(ctx)
+	require.NoError(t, err)
+	require.Contains(t, addedPaths, "etc/app.conf")
+	removedPaths, err := cmp.Changes().RemovedPaths(ctx)
+	require.NoError(t, err)
+	require.Contains(t, removedPaths, "etc/motd")
+
+	summary, err := cmp.Summary(ctx)
+	require.NoError(t, err)
+	require.Contains(t, summary, "~ envVariable FOO: bar -> baz")
+	require.Contains(t, summary, "+ etc/app.conf")
+	require.Contains(t, summary, "- etc/motd")
+}
+
+func (ContainerSuite) TestLayerSquash(ctx context.Context, t *testctx.T) {
+	wd := t.TempDir()
+	c := connect(ctx, t, dagger.WithWorkdir(wd))
+
+	base := c.Container().From(alpineImage)
+	baseLayers := func() int {
+		_, err := base.Export(ctx, "base.tar")
+		require.NoError(t, err)
+		return len(exportedLayers(t, filepath.Join(wd, "base.tar")))
+	}()
+
+	ctr := base.
+		WithNewFile("/a", "a").
+		WithNewFile("/b", "b").
+		WithLayerBoundary().
+		WithNewFile("/c", "c").
+		WithExec([]string{"rm", "/etc/motd"}).
+		WithNewFile("/d", "d")
+
+	export := func(name string, squash dagger.ImageSquashMode) []ocispecs.Descriptor {
+		_, err := ctr.Export(ctx, name, dagger.ContainerExportOpts{Squash: squash})
+		require.NoError(t, err)
+		return exportedLayers(t, filepath.Join(wd, name))
+	}
+
+	t.Run("boundaries", func(ctx context.Context, t *testctx.T) {
+		layers := export("boundaries.tar", "")
+		require.Len(t, layers, baseLayers+2)
+
+		// layers are reproducible: the same pipeline with fixed timestamps,
+		// built twice through different IDs so that neither export is
+		// served from the cache, gets the same layer digests
+		rebuild := func(name string) []ocispecs.Descriptor {
+			_, err := base.
+				WithEnvVariable("CACHEBUSTER", identity.NewID()).
+				WithoutEnvVariable("CACHEBUSTER").
+				WithDirectory("/", c.Directory().
+					WithNewFile("a", "a").
+					WithNewFile("b", "b").
+					WithTimestamps(0)).
+				WithLayerBoundary().
+				WithDirectory("/", c.Directory().
+					WithNewFile("c", "c").
+					WithTimestamps(0)).
+				Export(ctx, name)
+			require.NoError(t, err)
+			return exportedLayers(t, filepath.Join(wd, name))
+		}
+		rebuilt := rebuild("rebuilt.tar")
+		require.Len(t, rebuilt, baseLayers+2)
+		require.Equal(t, rebuilt, rebuild("rebuilt-again.tar"))
+
+		out, err := c.Container().Import(c.Host().File(filepath.Join(wd, "boundaries.tar"))).
+			WithExec([]string{"sh", "-c", "cat /a /b /c /d; test ! -e /etc/motd"}).
+			Stdout(ctx)
+		require.NoError(t, err)
+		require.Equal(t, "abcd", out)
+	})
+
+	t.Run("squash from base", func(ctx context.Context, t *testctx.T) {
+		require.Len(t, export("from-base.tar", dagger.ImageSquashModeFromBase), baseLayers+1)
+	})
+
+	t.Run("squash all", func(ctx context.Context, t *testctx.T) {
+		require.Len(t, export("all.tar", dagger.ImageSquashModeAll), 1)
+
+		out, err := c.Container().Import(c.Host().File(filepath.Join(wd, "all.tar"))).
+			WithExec([]string{"sh", "-c", "cat /a /b /c /d; test ! -e /etc/motd"}).
+			Stdout(ctx)
+		require.NoError(t, err)
+		require.Equal(t, "abcd", out)
+	})
+
+	t.Run("replaced rootfs", func(ctx context.Context, t *testctx.T) {
+		// the boundaries of the previous rootfs are dropped along with it
+		_, err := ctr.WithRootfs(c.Directory().WithNewFile("e", "e")).
+			Export(ctx, "rootfs.tar", dagger.ContainerExportOpts{Squash: dagger.ImageSquashModeFromBase})
+		require.NoError(t, err)
+		require.Len(t, exportedLayers(t, filepath.Join(wd, "rootfs.tar")), 1)
+
+		// an imported image becomes the new base, like with from
+		_, err = ctr.Import(c.Host().File(filepath.Join(wd, "base.tar"))).
+			WithNewFile("/e", "e").
+			Export(ctx, "imported.tar", dagger.ContainerExportOpts{Squash: dagger.ImageSquashModeFromBase})
+		require.NoError(t, err)
+		require.Len(t, exportedLayers(t, filepath.Join(wd, "imported.tar")), baseLayers+1)
+	})
+}
+
+func exportedLayers(t testing.TB, imagePath string) []ocispecs.Descriptor {
+	t.Helper()
+	var index ocispecs.Index
+	require.NoError(t, json.Unmarshal(readTarFile(t, imagePath, "index.json"), &index))
+	require.Len(t, index.Manifests, 1)
+	var mfst ocispecs.Manifest
+	require.NoError(t, json.Unmarshal(readTarFile(t, imagePath, "blobs/sha256/"+index.Manifests[0].Digest.Encoded()), &mfst))
+	return mfst.Layers
+}
+
 func (ContainerSuite) TestAnnotations(ctx context.Context, t *testctx.T) {
 	build := func(c *dagger.Client, platform dagger.Platform) *dagger.Container {
 		return c.Container(dagger.ContainerOpts{Platform: platform}).
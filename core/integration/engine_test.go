@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"crypto/rand"
+	"encoding/json"
 	"fmt"
 	"net"
 	"net/http"
@@ -255,6 +256,75 @@ func (EngineSuite) TestDaggerRun(ctx context.Context, t *testctx.T) {
 	require.Contains(t, stderr, "Container.from")
 }
 
+func (EngineSuite) TestWatchSessions(ctx context.Context, t *testctx.T) {
+	c := connect(ctx, t)
+
+	devEngine := devEngineContainerAsService(devEngineContainer(c))
+
+	clientCtr := engineClientContainer(ctx, t, c, devEngine)
+
+	// start a long-running session in the background, then wait for it to
+	// show up in the list of sessions
+	stdout, err := clientCtr.
+		WithExec([]string{"sh", "-c", `
+			dagger run sleep 120 >/dev/null 2>&1 &
+			for i in $(seq 60); do
+				out=$(dagger watch --json)
+				if echo "$out" | grep -q '"command": "dagger run sleep 120"'; then
+					echo "$out"
+					exit 0
+				fi
+				sleep 1
+			done
+			echo "session never showed up" >&2
+			exit 1
+		`}).
+		Stdout(ctx)
+	require.NoError(t, err)
+
+	var sessions []struct {
+		ID              string
+		ClientID        string
+		Command         string
+		StartedUnixNano int64
+	}
+	require.NoError(t, json.Unmarshal([]byte(stdout), &sessions))
+	require.Len(t, sessions, 1)
+	require.NotEmpty(t, sessions[0].ID)
+	require.NotEmpty(t, sessions[0].ClientID)
+	require.Equal(t, "dagger run sleep 120", sessions[0].Command)
+	require.NotZero(t, sessions[0].StartedUnixNano)
+}
+
+func (EngineSuite) TestIPv6DualStack(ctx context.Context, t *testctx.T) {
+	c := connect(ctx, t)
+
+	devEngine := devEngineContainerAsService(devEngineContainer(c, engineWithConfig(ctx, t,
+		func(_ context.Context, _ *testctx.T, cfg config.Config) config.Config {
+			cfg.Network = &config.NetworkConfig{IPv6CIDR: "fd89:1::/64"}
+			return cfg
+		},
+	)))
+
+	clientCtr := engineClientContainer(ctx, t, c, devEngine)
+
+	// the client resolves the service's hostname to its IPv6 address only, so
+	// the request can't fall back to IPv4
+	script := fmt.Sprintf(`
+svc=$(container | from %[1]s | with-new-file /srv/index.html hello | with-workdir /srv | with-exposed-port 8000 | as-service --args="python,-m,http.server,--bind,::")
+container | from %[1]s | with-service-binding www $svc | with-exec python -- -c 'import socket, urllib.request; addr = socket.getaddrinfo("www", 8000, socket.AF_INET6)[0][4][0]; print(addr); print(urllib.request.urlopen(f"http://[{addr}]:8000/index.html").read().decode())' | stdout
+`, pythonImage)
+
+	stdout, err := clientCtr.
+		WithExec([]string{"dagger", "-c", script}).
+		Stdout(ctx)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	require.Len(t, lines, 2)
+	require.True(t, strings.HasPrefix(lines[0], "fd89:1::"), "unexpected address %q", lines[0])
+	require.Equal(t, "hello", lines[1])
+}
+
 func (EngineSuite) TestVersionCompat(ctx context.Context, t *testctx.T) {
 	c := connect(ctx, t)
 
@@ -796,6 +866,46 @@ func (EngineSuite) TestPrometheusMetrics(ctx context.Context, t *testctx.T) {
 	require.NoError(t, eg.Wait(), "error from client exec")
 }
 
+func (EngineSuite) TestPrometheusOperationMetrics(ctx context.Context, t *testctx.T) {
+	c := connect(ctx, t)
+
+	devEngineCtr := devEngineContainer(c, func(c *dagger.Container) *dagger.Container {
+		return c.
+			WithEnvVariable("_EXPERIMENTAL_DAGGER_METRICS_ADDR", "0.0.0.0:9090").
+			WithExposedPort(9090, dagger.ContainerWithExposedPortOpts{
+				Protocol: dagger.NetworkProtocolTcp,
+			})
+	})
+	devEngine := devEngineContainerAsService(devEngineCtr)
+
+	out, err := engineClientContainer(ctx, t, c, devEngine).
+		With(daggerNonNestedExec("core",
+			"container",
+			"from", "--address", alpineImage,
+			"with-exec", "--args", "true",
+			"stdout",
+		)).
+		With(daggerNonNestedExec("core", "engine", "local-cache", "prune")).
+		WithExec([]string{"apk", "add", "curl"}).
+		WithEnvVariable("CACHEBUST", rand.Text()).
+		WithExec([]string{"curl", "-s", "http://dev-engine:9090/metrics"}).
+		Stdout(ctx)
+	require.NoError(t, err)
+
+	for _, prefix := range []string{
+		`dagger_dagql_calls_total{cache="miss",field="Container.from"}`,
+		`dagger_dagql_call_duration_seconds_count{cache="miss",field="Container.withExec"}`,
+		`dagger_exec_duration_seconds_count{exit_code="0"}`,
+		`dagger_image_pull_duration_seconds_count{status="ok"}`,
+		`dagger_gc_runs_total{trigger="manual"}`,
+		`dagger_gc_reclaimed_bytes_total{trigger="manual"}`,
+		`dagger_active_services `,
+	} {
+		require.Contains(t, out, "\n"+prefix)
+	}
+	require.Regexp(t, `(?m)^dagger_image_pull_bytes_total [1-9]`, out)
+}
+
 func (EngineSuite) TestClientMetadataReuse(ctx context.Context, t *testctx.T) {
 	c1 := connect(ctx, t)
 	c2 := connect(ctx, t)
@@ -0,0 +1,228 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dagger/dagger/internal/testutil"
+	"github.com/dagger/testctx"
+)
+
+func (ModuleSuite) TestDaggerListenAuth(ctx context.Context, t *testctx.T) {
+	t.Run("token file", func(ctx context.Context, t *testctx.T) {
+		dir := t.TempDir()
+		tokenFile := filepath.Join(dir, "tokens")
+		require.NoError(t, os.WriteFile(tokenFile, []byte("# team tokens\nteam-token\n"), 0o600))
+
+		addr := startDaggerListen(ctx, t, dir, "127.0.0.1:12460", "--token-file", tokenFile)
+		url := "http://" + addr + "/query"
+
+		code, body := listenQuery(t, http.DefaultClient, url, "{version}", func(r *http.Request) {
+			r.Header.Set("Authorization", "Bearer team-token")
+		})
+		require.Equal(t, http.StatusOK, code, body)
+		require.Contains(t, body, `"version"`)
+
+		// the session token is accepted as a bearer token too
+		code, body = listenQuery(t, http.DefaultClient, url, "{version}", func(r *http.Request) {
+			r.Header.Set("Authorization", "Bearer lol")
+		})
+		require.Equal(t, http.StatusOK, code, body)
+
+		code, _ = listenQuery(t, http.DefaultClient, url, "{version}", func(r *http.Request) {
+			r.Header.Set("Authorization", "Bearer nope")
+		})
+		require.Equal(t, http.StatusUnauthorized, code)
+
+		code, _ = listenQuery(t, http.DefaultClient, url, "{version}", nil)
+		require.Equal(t, http.StatusUnauthorized, code)
+	})
+
+	t.Run("mTLS", func(ctx context.Context, t *testctx.T) {
+		dir := t.TempDir()
+		ca, caKey := testCA(t, "listen test CA")
+		writeTestCert(t, filepath.Join(dir, "ca"), ca, nil)
+		serverCert, serverKey := testCert(t, ca, caKey, x509.ExtKeyUsageServerAuth)
+		writeTestCert(t, filepath.Join(dir, "server"), serverCert, serverKey)
+		clientCert, clientKey := testCert(t, ca, caKey, x509.ExtKeyUsageClientAuth)
+		otherCA, otherCAKey := testCA(t, "other CA")
+		otherCert, otherKey := testCert(t, otherCA, otherCAKey, x509.ExtKeyUsageClientAuth)
+
+		addr := startDaggerListen(ctx, t, dir, "127.0.0.1:12461",
+			"--tls-cert", filepath.Join(dir, "server.crt"),
+			"--tls-key", filepath.Join(dir, "server.key"),
+			"--tls-client-ca", filepath.Join(dir, "ca.crt"),
+		)
+		url := "https://" + addr + "/query"
+
+		roots := x509.NewCertPool()
+		roots.AddCert(ca)
+		tlsClient := func(cert *x509.Certificate, key *ecdsa.PrivateKey) *http.Client {
+			cfg := &tls.Config{RootCAs: roots, MinVersion: tls.VersionTLS12}
+			if cert != nil {
+				cfg.Certificates = []tls.Certificate{{Certificate: [][]byte{cert.Raw}, PrivateKey: key}}
+			}
+			return &http.Client{Transport: &http.Transport{TLSClientConfig: cfg}}
+		}
+
+		// a trusted client certificate is enough on its own
+		code, body := listenQuery(t, tlsClient(clientCert, clientKey), url, "{version}", nil)
+		require.Equal(t, http.StatusOK, code, body)
+		require.Contains(t, body, `"version"`)
+
+		_, err := tlsClient(nil, nil).Post(url, "application/json", strings.NewReader(`{"query":"{version}"}`))
+		require.Error(t, err)
+
+		_, err = tlsClient(otherCert, otherKey).Post(url, "application/json", strings.NewReader(`{"query":"{version}"}`))
+		require.Error(t, err)
+	})
+
+	t.Run("read-only", func(ctx context.Context, t *testctx.T) {
+		dir := t.TempDir()
+		addr := startDaggerListen(ctx, t, dir, "127.0.0.1:12462", "--read-only")
+		url := "http://" + addr + "/query"
+		auth := func(r *http.Request) {
+			r.SetBasicAuth("lol", "")
+		}
+
+		code, body := listenQuery(t, http.DefaultClient, url, "{version}", auth)
+		require.Equal(t, http.StatusOK, code, body)
+
+		code, body = listenQuery(t, http.DefaultClient, url, `{host{directory(path:"."){entries}}}`, auth)
+		require.Equal(t, http.StatusForbidden, code)
+		require.Contains(t, body, "field Query.host is not allowed in read-only mode")
+
+		code, body = listenQuery(t, http.DefaultClient, url, `{directory{export(path:"out")}}`, auth)
+		require.Equal(t, http.StatusForbidden, code)
+		require.Contains(t, body, "field Directory.export is not allowed in read-only mode")
+	})
+
+	t.Run("explorer", func(ctx context.Context, t *testctx.T) {
+		dir := t.TempDir()
+		addr := startDaggerListen(ctx, t, dir, "127.0.0.1:12463", "--explorer")
+
+		resp, err := http.Get("http://" + addr + "/explorer")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Contains(t, resp.Header.Get("Content-Type"), "text/html")
+
+		// the API itself still requires auth
+		code, _ := listenQuery(t, http.DefaultClient, "http://"+addr+"/query", "{version}", nil)
+		require.Equal(t, http.StatusUnauthorized, code)
+	})
+}
+
+// startDaggerListen runs `dagger listen` with the session token "lol" and
+// waits until it accepts connections.
+func startDaggerListen(ctx context.Context, t *testctx.T, dir, addr string, args ...string) string {
+	t.Helper()
+	cmd := hostDaggerCommand(ctx, t, dir, append([]string{"listen", "--listen", addr}, args...)...)
+	cmd.Env = append(cmd.Env, "DAGGER_SESSION_TOKEN=lol")
+	cmd.Stdout = testutil.NewTWriter(t)
+	cmd.Stderr = testutil.NewTWriter(t)
+	require.NoError(t, cmd.Start())
+
+	require.Eventually(t, func() bool {
+		conn, err := net.Dial("tcp", addr)
+		if err != nil {
+			return false
+		}
+		conn.Close()
+		return true
+	}, time.Minute, 100*time.Millisecond)
+	return addr
+}
+
+// listenQuery sends a GraphQL query, returning the status code and body.
+func listenQuery(t *testctx.T, client *http.Client, url, query string, setup func(*http.Request)) (int, string) {
+	t.Helper()
+	body, err := json.Marshal(map[string]string{"query": query})
+	require.NoError(t, err)
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	if setup != nil {
+		setup(req)
+	}
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp.StatusCode, string(respBody)
+}
+
+func testCA(t testing.TB, name string) (*x509.Certificate, *ecdsa.PrivateKey) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return cert, key
+}
+
+func testCert(t testing.TB, ca *x509.Certificate, caKey *ecdsa.PrivateKey, usage x509.ExtKeyUsage) (*x509.Certificate, *ecdsa.PrivateKey) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "127.0.0.1"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca, &key.PublicKey, caKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return cert, key
+}
+
+// writeTestCert writes a certificate to path.crt and, if set, its key to
+// path.key, both PEM encoded.
+func writeTestCert(t testing.TB, path string, cert *x509.Certificate, key *ecdsa.PrivateKey) {
+	t.Helper()
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})
+	require.NoError(t, os.WriteFile(path+".crt", certPEM, 0o600))
+	if key == nil {
+		return
+	}
+	der, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der})
+	require.NoError(t, os.WriteFile(path+".key", keyPEM, 0o600))
+}
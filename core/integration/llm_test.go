@@ -3,6 +3,7 @@ package core
 import (
 	"context"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"os"
 	"os/exec"
@@ -361,6 +362,142 @@ func (LLMSuite) TestAllowLLM(ctx context.Context, t *testctx.T) {
 	})
 }
 
+func (LLMSuite) TestOutputCheck(ctx context.Context, t *testctx.T) {
+	c := connect(ctx, t)
+
+	t.Run("string on stdin", func(ctx context.Context, t *testctx.T) {
+		greeting := func(llmFlags string, maxAttempts int, then string) dagger.WithContainerFunc {
+			return daggerShell(fmt.Sprintf(`llm %s | with-env $(.core | env | with-string-output "greeting" "a greeting" | with-output-exec-check "greeting" $(container | from alpine) grep,-q,hello --max-attempts %d) | with-prompt "save the greeting goodbye, and if its check fails, save hello world instead" | loop | %s`, llmFlags, maxAttempts, then))
+		}
+		model := llmRecording(ctx, t, c, "llmtest/output-check.golden", greeting("", 2, "historyJSON"))
+
+		t.Run("failed check is returned to the model", func(ctx context.Context, t *testctx.T) {
+			out, err := daggerCliBase(t, c).
+				With(greeting(model, 2, "historyJSON")).
+				Stdout(ctx)
+			require.NoError(t, err)
+			var history []struct {
+				Content     string `json:"content"`
+				ToolErrored bool   `json:"tool_errored"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(out), &history))
+			var errored []string
+			for _, msg := range history {
+				if msg.ToolErrored {
+					errored = append(errored, msg.Content)
+				}
+			}
+			require.Len(t, errored, 1)
+			require.Contains(t, errored[0], `output "greeting" failed its check (attempt 1 of 2), fix the value and save it again`)
+			require.Contains(t, errored[0], `process "grep -q hello" did not complete successfully`)
+
+			out, err = daggerCliBase(t, c).
+				With(greeting(model, 2, "env | output greeting | as-string")).
+				Stdout(ctx)
+			require.NoError(t, err)
+			require.Equal(t, "hello world", strings.TrimSpace(out))
+		})
+
+		t.Run("gives up after max attempts", func(ctx context.Context, t *testctx.T) {
+			_, err := daggerCliBase(t, c).
+				With(greeting(model, 1, "historyJSON")).
+				Stdout(ctx)
+			requireErrOut(t, err, `output "greeting" failed its check 1 times`)
+		})
+	})
+
+	t.Run("directory is mounted", func(ctx context.Context, t *testctx.T) {
+		result := func(llmFlags, path, then string) dagger.WithContainerFunc {
+			return daggerShell(fmt.Sprintf(`llm %s | with-env $(.core | env | with-directory-input "source" $(directory | with-new-file main.go "package main") "the source code" | with-directory-output "result" "the source code to return" | with-output-exec-check "result" $(container | from alpine) test,-f,%s --path /src --max-attempts 1) | with-prompt "save the source code as the result" | loop | %s`, llmFlags, path, then))
+		}
+		model := llmRecording(ctx, t, c, "llmtest/output-check-mount.golden", result("", "/src/main.go", "historyJSON"))
+
+		out, err := daggerCliBase(t, c).
+			With(result(model, "/src/main.go", "env | output result | as-directory | entries")).
+			Stdout(ctx)
+		require.NoError(t, err)
+		require.Contains(t, out, "main.go")
+
+		_, err = daggerCliBase(t, c).
+			With(result(model, "/src/missing.go", "historyJSON")).
+			Stdout(ctx)
+		requireErrOut(t, err, `output "result" failed its check 1 times`)
+	})
+}
+
+func (LLMSuite) TestFanOut(ctx context.Context, t *testctx.T) {
+	c := connect(ctx, t)
+
+	words := []string{"apple", "banana"}
+	fanOut := func(model string) ([]dagger.LLM, error) {
+		var variations []string
+		for _, word := range words {
+			variations = append(variations, "the word is "+word)
+		}
+		return c.LLM(dagger.LLMOpts{Model: model}).
+			WithEnv(c.Env().WithStringOutput("word", "a word")).
+			WithPrompt("save the word you are given").
+			FanOut(ctx, dagger.LLMFanOutOpts{Variations: variations})
+	}
+
+	// the recording holds the history of each branch
+	recording := "llmtest/fan-out.golden"
+	if golden.FlagUpdate() {
+		// uses the default model, configured in the environment
+		branches, err := fanOut("")
+		require.NoError(t, err)
+		var histories []string
+		for _, branch := range branches {
+			history, err := branch.HistoryJSON(ctx)
+			require.NoError(t, err)
+			histories = append(histories, string(history))
+		}
+
+		if dir := filepath.Dir(recording); dir != "." {
+			err := os.MkdirAll(dir, 0755)
+			require.NoError(t, err)
+		}
+		err = os.WriteFile(recording, []byte("["+strings.Join(histories, ",\n")+"]"), 0644)
+		require.NoError(t, err)
+	}
+
+	replayData, err := os.ReadFile(recording)
+	require.NoError(t, err)
+	branches, err := fanOut("replay/" + base64.StdEncoding.EncodeToString(replayData))
+	require.NoError(t, err)
+	require.Len(t, branches, len(words))
+
+	var total int
+	for i, branch := range branches {
+		// each branch saves its own word, to its own copy of the environment
+		saved, err := branch.Env().Output("word").AsString(ctx)
+		require.NoError(t, err)
+		require.Equal(t, words[i], saved)
+
+		history, err := branch.History(ctx)
+		require.NoError(t, err)
+		for j, word := range words {
+			if i == j {
+				require.Contains(t, strings.Join(history, "\n"), "the word is "+word)
+			} else {
+				require.NotContains(t, strings.Join(history, "\n"), "the word is "+word)
+			}
+		}
+
+		tokens, err := branch.TokenUsage().TotalTokens(ctx)
+		require.NoError(t, err)
+		require.NotZero(t, tokens)
+		total += tokens
+	}
+
+	// every branch accounts for the tokens of the whole fan out
+	for _, branch := range branches {
+		tokens, err := branch.FanOutTokenUsage().TotalTokens(ctx)
+		require.NoError(t, err)
+		require.Equal(t, total, tokens)
+	}
+}
+
 func testGoProgram(ctx context.Context, t *testctx.T, c *dagger.Client, program *dagger.File, re any) {
 	name, err := program.Name(ctx)
 	require.NoError(t, err)
@@ -401,3 +538,28 @@ func daggerForwardSecrets(dag *dagger.Client) dagger.WithContainerFunc {
 	//		return ctr
 	//	}
 }
+
+// llmRecording returns flags for the llm command that replay the given
+// recording, first recording it with the given command when updating golden
+// files.
+func llmRecording(ctx context.Context, t *testctx.T, c *dagger.Client, recording string, record dagger.WithContainerFunc) string {
+	t.Helper()
+	if golden.FlagUpdate() {
+		out, err := daggerCliBase(t, c).
+			With(daggerForwardSecrets(c)).
+			With(record).
+			Stdout(ctx)
+		require.NoError(t, err)
+
+		if dir := filepath.Dir(recording); dir != "." {
+			err := os.MkdirAll(dir, 0755)
+			require.NoError(t, err)
+		}
+		err = os.WriteFile(recording, []byte(out), 0644)
+		require.NoError(t, err)
+	}
+
+	replayData, err := os.ReadFile(recording)
+	require.NoError(t, err)
+	return fmt.Sprintf("--model=\"replay/%s\"", base64.StdEncoding.EncodeToString(replayData))
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	bkconfig "github.com/dagger/dagger/internal/buildkit/cmd/buildkitd/config"
+	"github.com/dagger/dagger/internal/buildkit/identity"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -339,6 +340,134 @@ func (EngineSuite) TestLocalCacheGC(ctx context.Context, t *testctx.T) {
 	}
 }
 
+func (EngineSuite) TestLocalCachePinAndPolicies(ctx context.Context, t *testctx.T) {
+	c := connect(ctx, t)
+
+	// only cache volumes named "throwaway" are covered by the default policy
+	engine := devEngineContainer(c, engineWithConfig(ctx, t, func(ctx context.Context, t *testctx.T, cfg config.Config) config.Config {
+		cfg.GC.Policies = []config.GCPolicy{{
+			All:          true,
+			RecordTypes:  []config.GCRecordType{config.GCRecordTypeCacheMount},
+			CacheVolumes: []string{"throwaway"},
+		}}
+		return cfg
+	}))
+	engineSvc, err := c.Host().Tunnel(devEngineContainerAsService(engine)).Start(ctx)
+	require.NoError(t, err)
+	t.Cleanup(func() { engineSvc.Stop(ctx) })
+
+	endpoint, err := engineSvc.Endpoint(ctx, dagger.ServiceEndpointOpts{Scheme: "tcp"})
+	require.NoError(t, err)
+
+	connectEngine := func() *dagger.Client {
+		c2, err := dagger.Connect(ctx, dagger.WithRunnerHost(endpoint), dagger.WithLogOutput(testutil.NewTWriter(t)))
+		require.NoError(t, err)
+		t.Cleanup(func() { c2.Close() })
+		return c2
+	}
+
+	c2 := connectEngine()
+	_, err = c2.Container().From(alpineImage).
+		WithMountedCache("/throwaway", c2.CacheVolume("throwaway")).
+		WithMountedCache("/kept", c2.CacheVolume("kept")).
+		WithMountedCache("/pinned", c2.CacheVolume("pinned")).
+		WithExec([]string{"sh", "-c", "echo hi | tee /throwaway/f /kept/f /pinned/f"}).
+		Sync(ctx)
+	require.NoError(t, err)
+	require.NoError(t, c2.CacheVolume("pinned").Pin(ctx))
+	require.NoError(t, c2.Close())
+
+	type cacheMount struct {
+		recordID string
+		pinned   bool
+	}
+	cacheMountEntries := func(c *dagger.Client) map[string]cacheMount {
+		t.Helper()
+		entries, err := c.Engine().LocalCache().EntrySet().Entries(ctx)
+		require.NoError(t, err)
+		mounts := map[string]cacheMount{}
+		for _, ent := range entries {
+			desc, err := ent.Description(ctx)
+			require.NoError(t, err)
+			dest, ok := strings.CutPrefix(desc, "cached mount ")
+			if !ok {
+				continue
+			}
+			dest, _, _ = strings.Cut(dest, " ")
+			pinned, err := ent.Pinned(ctx)
+			require.NoError(t, err)
+			recordID, err := ent.RecordID(ctx)
+			require.NoError(t, err)
+			mounts[dest] = cacheMount{recordID: recordID, pinned: pinned}
+		}
+		return mounts
+	}
+	cacheMounts := func(c *dagger.Client) map[string]bool {
+		t.Helper()
+		mounts := map[string]bool{}
+		for dest, mnt := range cacheMountEntries(c) {
+			mounts[dest] = mnt.pinned
+		}
+		return mounts
+	}
+
+	c3 := connectEngine()
+
+	// cleanup after client can be async, which impacts prune, so retry a few times
+	require.Eventually(t, func() bool {
+		require.NoError(t, c3.Engine().LocalCache().Prune(ctx, dagger.EngineCachePruneOpts{
+			UseDefaultPolicy: true,
+		}))
+		_, found := cacheMounts(c3)["/throwaway"]
+		return !found
+	}, time.Minute, time.Second)
+	require.Equal(t, map[string]bool{"/kept": false, "/pinned": true}, cacheMounts(c3))
+
+	// pruning everything still leaves the pinned volume
+	require.Eventually(t, func() bool {
+		require.NoError(t, c3.Engine().LocalCache().Prune(ctx))
+		_, found := cacheMounts(c3)["/kept"]
+		return !found
+	}, time.Minute, time.Second)
+	require.Equal(t, map[string]bool{"/pinned": true}, cacheMounts(c3))
+
+	// entries are unpinned by their record ID, which is stable across clients
+	pinned := cacheMountEntries(c3)["/pinned"]
+	c4 := connectEngine()
+	require.NoError(t, c4.Engine().LocalCache().Unpin(ctx, pinned.recordID))
+	require.Equal(t, map[string]bool{"/pinned": false}, cacheMounts(c4))
+	require.Eventually(t, func() bool {
+		require.NoError(t, c4.Engine().LocalCache().Prune(ctx))
+		_, found := cacheMounts(c4)["/pinned"]
+		return !found
+	}, time.Minute, time.Second)
+
+	// unpinning a volume keeps its records unpinned when it's used again
+	c5 := connectEngine()
+	repinned := func() *dagger.Container {
+		return c5.Container().From(alpineImage).
+			WithMountedCache("/repinned", c5.CacheVolume("repinned")).
+			WithEnvVariable("CACHEBUSTER", identity.NewID())
+	}
+	_, err = repinned().WithExec([]string{"sh", "-c", "echo hi > /repinned/f"}).Sync(ctx)
+	require.NoError(t, err)
+	require.NoError(t, c5.CacheVolume("repinned").Pin(ctx))
+	require.Equal(t, map[string]bool{"/repinned": true}, cacheMounts(c5))
+	require.NoError(t, c5.CacheVolume("repinned").Unpin(ctx))
+	require.Equal(t, map[string]bool{"/repinned": false}, cacheMounts(c5))
+	_, err = repinned().WithExec([]string{"cat", "/repinned/f"}).Sync(ctx)
+	require.NoError(t, err)
+	require.NoError(t, c5.Close())
+
+	c6 := connectEngine()
+	require.Equal(t, map[string]bool{"/repinned": false}, cacheMounts(c6))
+	require.Eventually(t, func() bool {
+		require.NoError(t, c6.Engine().LocalCache().Prune(ctx))
+		_, found := cacheMounts(c6)["/repinned"]
+		return !found
+	}, time.Minute, time.Second)
+}
+
 func engineConfigWithEnabled(enabled bool) func(context.Context, *testctx.T, config.Config) config.Config {
 	return func(ctx context.Context, t *testctx.T, cfg config.Config) config.Config {
 		t.Helper()
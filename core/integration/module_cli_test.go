@@ -1538,6 +1538,18 @@ func (CLISuite) TestDaggerUpdate(ctx context.Context, t *testctx.T) {
 		]
 	}`
 
+	depHasRange := `{
+		"name": "foo",
+		"sdk": "go",
+		"dependencies": [
+			{
+				"name": "docker",
+				"source": "github.com/shykes/daggerverse/docker@>=0.4.1 <=0.4.2",
+				"pin": "` + v041DockerPin + `"
+			}
+		]
+	}`
+
 	depIsLocal := `{
 		"name": "foo",
 		"sdk": "go",
@@ -1668,6 +1680,25 @@ func (CLISuite) TestDaggerUpdate(ctx context.Context, t *testctx.T) {
 			contains:    []string{`github.com/shykes/daggerverse/docker@docker/v0.4.2`, v042DockerPin},
 			notContains: []string{`"github.com/shykes/daggerverse/docker@docker/v0.4.1"`, v041DockerPin},
 		},
+		{
+			name:       "existing dep has range, update cmd use name without version",
+			daggerjson: depHasRange,
+			updateCmd:  []string{"update", "docker"},
+			contains:   []string{`"github.com/shykes/daggerverse/docker@>=0.4.1 <=0.4.2"`, v042DockerPin},
+		},
+		{
+			name:       "existing dep has range, update all dependencies",
+			daggerjson: depHasRange,
+			updateCmd:  []string{"update"},
+			contains:   []string{`"github.com/shykes/daggerverse/docker@>=0.4.1 <=0.4.2"`, v042DockerPin},
+		},
+		{
+			name:        "existing dep has version, update cmd has range",
+			daggerjson:  depHasOldVersion,
+			updateCmd:   []string{"update", "docker@~0.4.1"},
+			contains:    []string{`"github.com/shykes/daggerverse/docker@~0.4.1"`},
+			notContains: []string{`github.com/shykes/daggerverse/docker@docker/v0.4.1`, randomMainPin},
+		},
 		{
 			name:          "update a dependency not configured in dagger.json",
 			daggerjson:    noDeps,
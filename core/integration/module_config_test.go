@@ -1700,3 +1700,177 @@ func (ConfigSuite) TestDepWritePins(ctx context.Context, t *testctx.T) {
 		require.Equal(t, commit, dep.Pin)
 	})
 }
+
+func (ConfigSuite) TestCapabilities(ctx context.Context, t *testctx.T) {
+	c := connect(ctx, t)
+
+	ctr := goGitBase(t, c).
+		WithMountedFile(testCLIBinPath, daggerCliFile(t, c)).
+		WithWorkdir("/work").
+		With(daggerExec("init", "--source=.", "--name=test", "--sdk=go")).
+		WithNewFile("allowed/foo", "allowed").
+		WithNewFile("denied/foo", "denied").
+		WithNewFile("main.go", `package main
+
+import (
+	"context"
+
+	"dagger/test/internal/dagger"
+)
+
+type Test struct{}
+
+func (m *Test) Online(ctx context.Context) (string, error) {
+	return dag.Container().From("`+alpineImage+`").WithExec([]string{"echo", "online"}).Stdout(ctx)
+}
+
+func (m *Test) Offline(ctx context.Context) (string, error) {
+	return dag.Container().From("`+alpineImage+`").
+		WithExec([]string{"echo", "offline"}, dagger.ContainerWithExecOpts{NetworkMode: dagger.NetworkModeNone}).
+		Stdout(ctx)
+}
+
+func (m *Test) ReadAllowed(
+	ctx context.Context,
+	// +defaultPath="./allowed"
+	dir *dagger.Directory,
+) (string, error) {
+	return dir.File("foo").Contents(ctx)
+}
+
+func (m *Test) ReadDenied(
+	ctx context.Context,
+	// +defaultPath="./denied"
+	dir *dagger.Directory,
+) (string, error) {
+	return dir.File("foo").Contents(ctx)
+}
+
+func (m *Test) Llm() *dagger.LLM {
+	return dag.LLM()
+}
+
+func (m *Test) LoadSecret(ctx context.Context) (string, error) {
+	return dag.Secret("env://HOME").Plaintext(ctx)
+}
+
+func (m *Test) UseSecret(ctx context.Context, secret *dagger.Secret) (string, error) {
+	return secret.Plaintext(ctx)
+}
+`,
+		)
+
+	modCfgContents, err := ctr.File("dagger.json").Contents(ctx)
+	require.NoError(t, err)
+	var modCfg modules.ModuleConfig
+	require.NoError(t, json.Unmarshal([]byte(modCfgContents), &modCfg))
+	modCfg.Capabilities = &modules.ModuleCapabilities{
+		HostRead: []string{"./allowed"},
+	}
+	rewrittenModCfg, err := json.Marshal(modCfg)
+	require.NoError(t, err)
+	ctr = ctr.WithNewFile("dagger.json", string(rewrittenModCfg))
+
+	t.Run("network", func(ctx context.Context, t *testctx.T) {
+		_, err := ctr.With(daggerCall("online")).Sync(ctx)
+		requireErrOut(t, err, `module "test" is not allowed to use full network access`)
+
+		out, err := ctr.With(daggerCall("offline")).Stdout(ctx)
+		require.NoError(t, err)
+		require.Equal(t, "offline\n", out)
+	})
+
+	t.Run("host read", func(ctx context.Context, t *testctx.T) {
+		out, err := ctr.With(daggerCall("read-allowed")).Stdout(ctx)
+		require.NoError(t, err)
+		require.Equal(t, "allowed", out)
+
+		_, err = ctr.With(daggerCall("read-denied")).Sync(ctx)
+		requireErrOut(t, err, `module "test" is not allowed to read from the host path "./denied"`)
+	})
+
+	t.Run("llm", func(ctx context.Context, t *testctx.T) {
+		_, err := ctr.With(daggerCall("llm")).Sync(ctx)
+		requireErrOut(t, err, `module "test" is not allowed to use LLMs`)
+	})
+
+	t.Run("secrets", func(ctx context.Context, t *testctx.T) {
+		_, err := ctr.With(daggerCall("load-secret")).Sync(ctx)
+		requireErrOut(t, err, `module "test" is not allowed to load secrets from the host`)
+
+		out, err := ctr.
+			WithEnvVariable("TOPSECRET", "hunter2").
+			With(daggerCall("use-secret", "--secret", "env://TOPSECRET")).
+			Stdout(ctx)
+		require.NoError(t, err)
+		require.Contains(t, out, "hunter2")
+	})
+
+	t.Run("inspect", func(ctx context.Context, t *testctx.T) {
+		out, err := ctr.With(daggerExec("module", "inspect")).Stdout(ctx)
+		require.NoError(t, err)
+		require.Contains(t, out, "hostRead(./allowed)")
+	})
+
+	t.Run("granted to dependency", func(ctx context.Context, t *testctx.T) {
+		parent := ctr.
+			WithWorkdir("/work/parent").
+			With(daggerExec("init", "--source=.", "--name=parent", "--sdk=go")).
+			With(daggerExec("install", "..")).
+			WithNewFile("main.go", `package main
+
+import "context"
+
+type Parent struct{}
+
+func (m *Parent) Read(ctx context.Context) (string, error) {
+	return dag.Test().ReadAllowed(ctx)
+}
+`,
+			)
+
+		out, err := parent.With(daggerCall("read")).Stdout(ctx)
+		require.NoError(t, err)
+		require.Equal(t, "allowed", out)
+
+		// the dependency declares hostRead, but wasn't granted it
+		modCfgContents, err := parent.File("dagger.json").Contents(ctx)
+		require.NoError(t, err)
+		var modCfg modules.ModuleConfig
+		require.NoError(t, json.Unmarshal([]byte(modCfgContents), &modCfg))
+		require.Len(t, modCfg.Dependencies, 1)
+		require.Nil(t, modCfg.Dependencies[0].Capabilities)
+		modCfg.Dependencies[0].Capabilities = &modules.ModuleCapabilities{Network: true}
+		rewrittenModCfg, err := json.Marshal(modCfg)
+		require.NoError(t, err)
+		parent = parent.WithNewFile("dagger.json", string(rewrittenModCfg))
+
+		_, err = parent.With(daggerCall("read")).Sync(ctx)
+		requireErrOut(t, err, `module "test" is not allowed to read from the host path "./allowed": "hostRead" was not granted`)
+
+		out, err = parent.With(daggerExec("module", "inspect")).Stdout(ctx)
+		require.NoError(t, err)
+		require.Regexp(t, `test\s+\S+\s+hostRead\(\./allowed\)\s+network`, out)
+	})
+
+	t.Run("install without a terminal", func(ctx context.Context, t *testctx.T) {
+		ctr := goGitBase(t, c).
+			WithMountedFile(testCLIBinPath, daggerCliFile(t, c)).
+			WithWorkdir("/work/dep").
+			With(daggerExec("init", "--source=.", "--name=test", "--sdk=go"))
+
+		// the dependency declares no capabilities, so it requires all of
+		// them; they're granted with a warning since there's no prompt
+		ctr = ctr.With(daggerExec("install", "github.com/dagger/dagger-test-modules"))
+		stderr, err := ctr.Stderr(ctx)
+		require.NoError(t, err)
+		require.Contains(t, stderr, "granting capabilities without prompting")
+
+		modCfgContents, err := ctr.File("dagger.json").Contents(ctx)
+		require.NoError(t, err)
+		var modCfg modules.ModuleConfig
+		require.NoError(t, json.Unmarshal([]byte(modCfgContents), &modCfg))
+		require.Len(t, modCfg.Dependencies, 1)
+		require.Equal(t, modules.AllModuleCapabilities(), modCfg.Dependencies[0].Capabilities)
+	})
+}
@@ -660,6 +660,12 @@ type Test struct {
 		_, err = console.SendLine("exit")
 		require.NoError(t, err)
 
+		_, err = console.ExpectString("give up")
+		require.NoError(t, err)
+
+		_, err = console.Send("q")
+		require.NoError(t, err)
+
 		go console.ExpectEOF()
 
 		err = cmd.Wait()
@@ -684,9 +690,198 @@ type Test struct {
 		_, err = console.ExpectString("/bin/noexist: no such file or directory")
 		require.NoError(t, err)
 
+		_, err = console.ExpectString("give up")
+		require.NoError(t, err)
+
+		_, err = console.Send("q")
+		require.NoError(t, err)
+
+		go console.ExpectEOF()
+
 		err = cmd.Wait()
 		require.Error(t, err)
 	})
+
+	t.Run("retry on failure", func(ctx context.Context, t *testctx.T) {
+		modDir := t.TempDir()
+		err := os.WriteFile(filepath.Join(modDir, "main.go"), fmt.Appendf(nil, `package main
+	import (
+		"context"
+	)
+
+	type Test struct{}
+
+	func (m *Test) Run(ctx context.Context) (string, error) {
+		return dag.Container().
+			From("%s").
+			WithExec([]string{"sh", "-c", "echo attempt >> /attempts && cat /fixed"}).
+			WithExec([]string{"sh", "-c", "echo attempts: $(wc -l < /attempts)"}).
+			Stdout(ctx)
+	}
+	`, alpineImage), 0644)
+		require.NoError(t, err)
+
+		_, err = hostDaggerExec(ctx, t, modDir, "init", "--source=.", "--name=test", "--sdk=go")
+		require.NoError(t, err)
+
+		// cache the module load itself so there's less to wait for in the shell invocation below
+		_, err = hostDaggerExec(ctx, t, modDir, "functions")
+		require.NoError(t, err)
+
+		console, err := newTUIConsole(t, 60*time.Second)
+		require.NoError(t, err)
+		defer console.Close()
+
+		tty := console.Tty()
+		err = pty.Setsize(tty, &pty.Winsize{Rows: 6, Cols: 16})
+		require.NoError(t, err)
+
+		cmd := hostDaggerCommand(ctx, t, modDir, "--interactive", "call", "run")
+		cmd.Stdin = tty
+		cmd.Stdout = tty
+		cmd.Stderr = tty
+
+		err = cmd.Start()
+		require.NoError(t, err)
+
+		prompt := "/ # "
+
+		_, err = console.ExpectString(prompt)
+		require.NoError(t, err)
+
+		_, err = console.SendLine("echo FIXED > /fixed")
+		require.NoError(t, err)
+
+		_, err = console.ExpectString(prompt)
+		require.NoError(t, err)
+
+		_, err = console.SendLine("exit")
+		require.NoError(t, err)
+
+		_, err = console.ExpectString("give up")
+		require.NoError(t, err)
+
+		_, err = console.Send("r")
+		require.NoError(t, err)
+
+		_, err = console.ExpectString("FIXED")
+		require.NoError(t, err)
+
+		// the pipeline continues from the retried exec, which ran on the files
+		// changed in the terminal
+		_, err = console.ExpectString("attempts: 2")
+		require.NoError(t, err)
+
+		go console.ExpectEOF()
+
+		err = cmd.Wait()
+		require.NoError(t, err)
+
+		// the retried result isn't reused by later sessions, so a later run
+		// without the debugger executes the exec again and fails
+		_, err = hostDaggerExec(ctx, t, modDir, "call", "run")
+		require.ErrorContains(t, err, "/fixed")
+	})
+
+	t.Run("execs that aren't debugged are cached", func(ctx context.Context, t *testctx.T) {
+		modDir := t.TempDir()
+		err := os.WriteFile(filepath.Join(modDir, "main.go"), fmt.Appendf(nil, `package main
+	import (
+		"context"
+	)
+
+	type Test struct{}
+
+	func (m *Test) Run(ctx context.Context) (string, error) {
+		return dag.Container().
+			From("%s").
+			WithExec([]string{"cat", "/proc/sys/kernel/random/uuid"}).
+			Stdout(ctx)
+	}
+	`, alpineImage), 0644)
+		require.NoError(t, err)
+
+		_, err = hostDaggerExec(ctx, t, modDir, "init", "--source=.", "--name=test", "--sdk=go")
+		require.NoError(t, err)
+
+		run := func() string {
+			out, err := hostDaggerCommand(ctx, t, modDir, "--interactive", "call", "run").Output()
+			require.NoError(t, err)
+			return string(out)
+		}
+		require.Equal(t, run(), run())
+	})
+
+	t.Run("break on", func(ctx context.Context, t *testctx.T) {
+		modDir := t.TempDir()
+		err := os.WriteFile(filepath.Join(modDir, "main.go"), fmt.Appendf(nil, `package main
+	import (
+		"context"
+	)
+
+	type Test struct{}
+
+	func (m *Test) Run(ctx context.Context) (string, error) {
+		return dag.Container().
+			From("%s").
+			WithExec([]string{"sh", "-c", "echo before > /before"}).
+			WithLabel("stage", "paused").
+			WithExec([]string{"sh", "-c", "echo after: $(cat /before)"}).
+			Stdout(ctx)
+	}
+	`, alpineImage), 0644)
+		require.NoError(t, err)
+
+		_, err = hostDaggerExec(ctx, t, modDir, "init", "--source=.", "--name=test", "--sdk=go")
+		require.NoError(t, err)
+
+		// cache the module load itself so there's less to wait for in the shell invocation below
+		_, err = hostDaggerExec(ctx, t, modDir, "functions")
+		require.NoError(t, err)
+
+		console, err := newTUIConsole(t, 60*time.Second)
+		require.NoError(t, err)
+		defer console.Close()
+
+		tty := console.Tty()
+		err = pty.Setsize(tty, &pty.Winsize{Rows: 6, Cols: 16})
+		require.NoError(t, err)
+
+		cmd := hostDaggerCommand(ctx, t, modDir, "call", "--break-on", "Container.withLabel", "run")
+		cmd.Stdin = tty
+		cmd.Stdout = tty
+		cmd.Stderr = tty
+
+		err = cmd.Start()
+		require.NoError(t, err)
+
+		prompt := "/ # "
+
+		// paused in the container returned by withLabel
+		_, err = console.ExpectString(prompt)
+		require.NoError(t, err)
+
+		_, err = console.SendLine("cat /before")
+		require.NoError(t, err)
+
+		_, err = console.ExpectString("before\r\n")
+		require.NoError(t, err)
+
+		_, err = console.ExpectString(prompt)
+		require.NoError(t, err)
+
+		_, err = console.SendLine("exit")
+		require.NoError(t, err)
+
+		// the pipeline continues once the terminal exits
+		_, err = console.ExpectString("after: before")
+		require.NoError(t, err)
+
+		go console.ExpectEOF()
+
+		err = cmd.Wait()
+		require.NoError(t, err)
+	})
 }
 
 // tuiConsole wraps expect.Console with methods that allow us to enforce
@@ -5621,6 +5621,141 @@ func (t *Test) IgnoreDirButKeepFileInSubdir(
 	})
 }
 
+func (ModuleSuite) TestArgConstraints(ctx context.Context, t *testctx.T) {
+	c := connect(ctx, t)
+
+	modGen := modInit(t, c, "go", `package main
+
+import (
+	"dagger/test/internal/dagger"
+	"strings"
+)
+
+type Test struct{}
+
+func (m *Test) Release(
+	// +pattern=v[0-9]+\.[0-9]+\.[0-9]+
+	version string,
+	// +min=1
+	// +max=100
+	// +default=1
+	replicas int,
+	// +nonEmpty
+	// +optional
+	platforms []string,
+) string {
+	return version + " " + strings.Join(platforms, ",")
+}
+
+func (m *Test) Build(
+	// +requiredPaths=["go.mod"]
+	src *dagger.Directory,
+) *dagger.Directory {
+	return src
+}
+`)
+
+	t.Run("valid arguments", func(ctx context.Context, t *testctx.T) {
+		out, err := modGen.With(daggerCall("release", "--version", "v1.2.3", "--replicas", "100", "--platforms", "linux/amd64")).Stdout(ctx)
+		require.NoError(t, err)
+		require.Equal(t, "v1.2.3 linux/amd64", strings.TrimSpace(out))
+	})
+
+	t.Run("pattern", func(ctx context.Context, t *testctx.T) {
+		_, err := modGen.With(daggerCall("release", "--version", "1.2.3")).Sync(ctx)
+		requireErrOut(t, err, `invalid argument "version" of function "Test.release": must match pattern`)
+	})
+
+	t.Run("range", func(ctx context.Context, t *testctx.T) {
+		_, err := modGen.With(daggerCall("release", "--version", "v1.2.3", "--replicas", "0")).Sync(ctx)
+		requireErrOut(t, err, `invalid argument "replicas" of function "Test.release": must be at least 1, got 0`)
+	})
+
+	t.Run("user default", func(ctx context.Context, t *testctx.T) {
+		_, err := modGen.
+			WithNewFile(".env", `RELEASE_REPLICAS=0`).
+			With(daggerCall("release", "--version", "v1.2.3")).
+			Sync(ctx)
+		requireErrOut(t, err, `invalid argument "replicas" of function "Test.release": must be at least 1, got 0`)
+	})
+
+	t.Run("required paths", func(ctx context.Context, t *testctx.T) {
+		out, err := modGen.
+			WithNewFile("/src/go.mod", "module foo").
+			With(daggerCall("build", "--src", "/src", "entries")).
+			Stdout(ctx)
+		require.NoError(t, err)
+		require.Contains(t, out, "go.mod")
+
+		_, err = modGen.
+			WithNewFile("/src/main.go", "package main").
+			With(daggerCall("build", "--src", "/src", "entries")).
+			Sync(ctx)
+		requireErrOut(t, err, `invalid argument "src" of function "Test.build": must contain "go.mod"`)
+	})
+
+	t.Run("help", func(ctx context.Context, t *testctx.T) {
+		out, err := modGen.With(daggerCall("release", "--help")).Stdout(ctx)
+		require.NoError(t, err)
+		require.Contains(t, out, "(constraints: >= 1, <= 100)")
+		require.Contains(t, out, `(constraints: pattern: v[0-9]+\.[0-9]+\.[0-9]+)`)
+	})
+
+	for _, tc := range []struct {
+		sdk    string
+		source string
+	}{
+		{
+			sdk: "python",
+			source: `from typing import Annotated
+
+import dagger
+from dagger import function, object_type
+
+@object_type
+class Test:
+    @function
+    def release(
+        self,
+        version: Annotated[str, dagger.Constraints(pattern=r"v[0-9]+")],
+        replicas: Annotated[int, dagger.Constraints(min=1, max=100)] = 1,
+    ) -> str:
+        return version
+`,
+		},
+		{
+			sdk: "typescript",
+			source: `import { argument, func, object } from "@dagger.io/dagger"
+
+@object()
+export class Test {
+  @func()
+  release(
+    @argument({ pattern: "v[0-9]+" }) version: string,
+    @argument({ min: 1, max: 100 }) replicas: number = 1,
+  ): string {
+    return version
+  }
+}
+`,
+		},
+	} {
+		t.Run(tc.sdk, func(ctx context.Context, t *testctx.T) {
+			modGen := modInit(t, c, tc.sdk, tc.source)
+
+			out, err := modGen.With(daggerCall("release", "--version", "v1")).Stdout(ctx)
+			require.NoError(t, err)
+			require.Equal(t, "v1", strings.TrimSpace(out))
+
+			_, err = modGen.With(daggerCall("release", "--version", "1")).Sync(ctx)
+			requireErrOut(t, err, `invalid argument "version" of function "Test.release": must match pattern`)
+
+			_, err = modGen.With(daggerCall("release", "--version", "v1", "--replicas", "0")).Sync(ctx)
+			requireErrOut(t, err, `invalid argument "replicas" of function "Test.release": must be at least 1, got 0`)
+		})
+	}
+}
+
 func (ModuleSuite) TestGitignore(ctx context.Context, t *testctx.T) {
 	c := connect(ctx, t)
 
@@ -0,0 +1,301 @@
+package core
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/dagger/testctx"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+)
+
+type UnionSuite struct{}
+
+func TestUnion(t *testing.T) {
+	testctx.New(t, Middleware()...).RunTests(UnionSuite{})
+}
+
+const unionGoSource = `package main
+
+import (
+	"dagger/test/internal/dagger"
+)
+
+type Test struct{}
+
+// A file, a directory or a note
+// +union
+type Artifact struct {
+	File *dagger.File
+	Dir  *dagger.Directory
+	Note *Note
+}
+
+type Note struct {
+	Text string
+}
+
+func (m *Test) Get(kind string) Artifact {
+	switch kind {
+	case "file":
+		return Artifact{File: dag.Directory().WithNewFile("foo", "file contents").File("foo")}
+	case "dir":
+		return Artifact{Dir: dag.Directory().WithNewFile("bar", "")}
+	default:
+		return Artifact{Note: &Note{Text: "note text"}}
+	}
+}
+
+func (m *Test) Describe(artifact Artifact) string {
+	switch {
+	case artifact.File != nil:
+		return "file"
+	case artifact.Dir != nil:
+		return "dir"
+	default:
+		return "note: " + artifact.Note.Text
+	}
+}
+`
+
+func (UnionSuite) TestUnionGo(ctx context.Context, t *testctx.T) {
+	c := connect(ctx, t)
+
+	modGen := modInit(t, c, "go", unionGoSource)
+
+	t.Run("select member", func(ctx context.Context, t *testctx.T) {
+		out, err := modGen.
+			With(daggerQuery(`{test{get(kind: "file"){__typename ... on File{contents} ... on Directory{entries}}}}`)).
+			Stdout(ctx)
+		require.NoError(t, err)
+		require.JSONEq(t, `{"test":{"get":{"__typename":"File","contents":"file contents"}}}`, out)
+
+		out, err = modGen.
+			With(daggerQuery(`{test{get(kind: "note"){__typename ... on TestNote{text}}}}`)).
+			Stdout(ctx)
+		require.NoError(t, err)
+		require.JSONEq(t, `{"test":{"get":{"__typename":"TestNote","text":"note text"}}}`, out)
+	})
+
+	t.Run("possible types", func(ctx context.Context, t *testctx.T) {
+		out, err := modGen.
+			With(daggerQuery(`{__type(name: "TestArtifact"){kind possibleTypes{name}}}`)).
+			Stdout(ctx)
+		require.NoError(t, err)
+		require.JSONEq(t, `{"__type":{"kind":"UNION","possibleTypes":[{"name":"File"},{"name":"Directory"},{"name":"TestNote"}]}}`, out)
+	})
+
+	t.Run("pass member", func(ctx context.Context, t *testctx.T) {
+		out, err := modGen.
+			With(daggerQuery(`{test{get(kind: "note"){... on TestNote{id}}}}`)).
+			Stdout(ctx)
+		require.NoError(t, err)
+		id := gjson.Get(out, "test.get.id").String()
+
+		out, err = modGen.
+			With(daggerQuery(`{test{describe(artifact: {testNote: "%s"})}}`, id)).
+			Stdout(ctx)
+		require.NoError(t, err)
+		require.JSONEq(t, `{"test":{"describe":"note: note text"}}`, out)
+
+		out, err = modGen.
+			With(daggerQuery(`{directory{withNewFile(path: "bar", contents: ""){id}}}`)).
+			Stdout(ctx)
+		require.NoError(t, err)
+		id = gjson.Get(out, "directory.withNewFile.id").String()
+
+		out, err = modGen.
+			With(daggerQuery(`{test{describe(artifact: {directory: "%s"})}}`, id)).
+			Stdout(ctx)
+		require.NoError(t, err)
+		require.JSONEq(t, `{"test":{"describe":"dir"}}`, out)
+	})
+
+	t.Run("pass exactly one member", func(ctx context.Context, t *testctx.T) {
+		_, err := modGen.
+			With(daggerQuery(`{test{describe(artifact: {})}}`)).
+			Stdout(ctx)
+		requireErrOut(t, err, "exactly one field of TestArtifactInput must be set")
+	})
+
+	t.Run("call", func(ctx context.Context, t *testctx.T) {
+		// the member type is only known once called, so the result is
+		// printed the same as when returning the member type directly
+		out, err := modGen.
+			With(daggerCall("get", "--kind=note")).
+			Stdout(ctx)
+		require.NoError(t, err)
+		require.Contains(t, out, "note text")
+
+		out, err = modGen.
+			With(daggerCall("get", "--kind=file")).
+			Stdout(ctx)
+		require.NoError(t, err)
+		require.Contains(t, out, "File@")
+	})
+}
+
+func (UnionSuite) TestUnionClient(ctx context.Context, t *testctx.T) {
+	depSource := `package main
+
+import (
+	"dagger/dep/internal/dagger"
+)
+
+type Dep struct{}
+
+// A file or a note
+// +union
+type Artifact struct {
+	File *dagger.File
+	Note *Note
+}
+
+type Note struct {
+	Text string
+}
+
+func (m *Dep) Get(kind string) Artifact {
+	if kind == "file" {
+		return Artifact{File: dag.Directory().WithNewFile("foo", "file contents").File("foo")}
+	}
+	return Artifact{Note: &Note{Text: "note text"}}
+}
+
+func (m *Dep) All() []Artifact {
+	return []Artifact{m.Get("file"), m.Get("note")}
+}
+
+func (m *Dep) Describe(artifact Artifact) string {
+	if artifact.File != nil {
+		return "file"
+	}
+	return "note: " + artifact.Note.Text
+}
+`
+
+	type testCase struct {
+		sdk    string
+		source string
+	}
+
+	for _, tc := range []testCase{
+		{
+			sdk: "go",
+			source: `package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"dagger/test/internal/dagger"
+)
+
+type Test struct{}
+
+func (m *Test) Roundtrip(ctx context.Context) (string, error) {
+	note, err := dag.Dep().Get("note").AsDepNote(ctx)
+	if err != nil {
+		return "", err
+	}
+	if note == nil {
+		return "", fmt.Errorf("expected a note")
+	}
+	file, err := dag.Dep().Get("note").AsFile(ctx)
+	if err != nil {
+		return "", err
+	}
+	if file != nil {
+		return "", fmt.Errorf("expected no file")
+	}
+	text, err := note.Text(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	all, err := dag.Dep().All(ctx)
+	if err != nil {
+		return "", err
+	}
+	var kinds []string
+	for _, artifact := range all {
+		kind, err := artifact.Typename(ctx)
+		if err != nil {
+			return "", err
+		}
+		kinds = append(kinds, kind)
+	}
+
+	desc, err := dag.Dep().Describe(ctx, dagger.DepArtifactInput{DepNote: note})
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s %s %s", text, strings.Join(kinds, ","), desc), nil
+}
+`,
+		},
+		{
+			sdk: "typescript",
+			source: `import { dag, object, func } from "@dagger.io/dagger"
+
+@object()
+export class Test {
+  @func()
+  async roundtrip(): Promise<string> {
+    const note = await dag.dep().get("note").asDepNote()
+    if (!note) {
+      throw new Error("expected a note")
+    }
+    if (await dag.dep().get("note").asFile()) {
+      throw new Error("expected no file")
+    }
+    const text = await note.text()
+
+    const all = await dag.dep().all()
+    const kinds = await Promise.all(all.map((artifact) => artifact.typename()))
+
+    const desc = await dag.dep().describe({ depNote: note })
+    return ` + "`${text} ${kinds.join(\",\")} ${desc}`" + `
+  }
+}
+`,
+		},
+		{
+			sdk: "python",
+			source: `import dagger
+from dagger import dag
+
+@dagger.object_type
+class Test:
+    @dagger.function
+    async def roundtrip(self) -> str:
+        note = await dag.dep().get("note").as_dep_note()
+        assert note is not None, "expected a note"
+        assert await dag.dep().get("note").as_file() is None, "expected no file"
+        text = await note.text()
+
+        kinds = [await artifact.typename() for artifact in await dag.dep().all()]
+
+        desc = await dag.dep().describe(dagger.DepArtifactInput(dep_note=note))
+        return f"{text} {','.join(kinds)} {desc}"
+`,
+		},
+	} {
+		t.Run(tc.sdk, func(ctx context.Context, t *testctx.T) {
+			c := connect(ctx, t)
+
+			out, err := c.Container().From(golangImage).
+				WithMountedFile(testCLIBinPath, daggerCliFile(t, c)).
+				WithWorkdir("/work").
+				With(withModInitAt("dep", "go", depSource)).
+				With(withModInit(tc.sdk, tc.source)).
+				With(daggerExec("install", "./dep")).
+				With(daggerCall("roundtrip")).
+				Stdout(ctx)
+			require.NoError(t, err)
+			require.Equal(t, "note text File,DepNote note: note text", strings.TrimSpace(out))
+		})
+	}
+}
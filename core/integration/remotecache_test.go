@@ -12,6 +12,7 @@ import (
 	"github.com/tidwall/gjson"
 
 	"dagger.io/dagger"
+	"github.com/dagger/dagger/engine/config"
 )
 
 const cliBinPath = "/.dagger-cli"
@@ -103,6 +104,176 @@ func (RemoteCacheSuite) TestRegistry(ctx context.Context, t *testctx.T) {
 	require.Equal(t, shaA, shaB)
 }
 
+// devEngineWithResultCache starts a fresh dev engine, with an empty local
+// cache, that uses the given remote result cache. The cache backend is bound
+// to the engine as a service under the given hostname.
+func devEngineWithResultCache(ctx context.Context, t *testctx.T, c *dagger.Client, cfg *config.ResultCacheConfig, backendName string, backend *dagger.Service) (*dagger.Service, string) {
+	t.Helper()
+	withResultCache := engineWithConfig(ctx, t, func(_ context.Context, _ *testctx.T, engineCfg config.Config) config.Config {
+		engineCfg.ResultCache = cfg
+		return engineCfg
+	})
+	devEngine := devEngineContainerAsService(devEngineContainer(c, withResultCache, func(c *dagger.Container) *dagger.Container {
+		return c.WithServiceBinding(backendName, backend)
+	}))
+	endpoint, err := devEngine.Endpoint(ctx, dagger.ServiceEndpointOpts{
+		Port:   1234,
+		Scheme: "tcp",
+	})
+	require.NoError(t, err)
+	return devEngine, endpoint
+}
+
+// runResultCacheQuery runs a non-deterministic exec on a fresh dev engine
+// using the given remote result cache, and returns its output.
+func runResultCacheQuery(ctx context.Context, t *testctx.T, c *dagger.Client, cfg *config.ResultCacheConfig, backendName string, backend *dagger.Service) string {
+	t.Helper()
+	devEngine, endpoint := devEngineWithResultCache(ctx, t, c, cfg, backendName, backend)
+
+	// no cache config on the client: the engine config alone is enough
+	output, err := c.Container().From(alpineImage).
+		WithServiceBinding("dev-engine", devEngine).
+		WithMountedFile(cliBinPath, daggerCliFile(t, c)).
+		WithEnvVariable("_EXPERIMENTAL_DAGGER_CLI_BIN", cliBinPath).
+		WithEnvVariable("_EXPERIMENTAL_DAGGER_RUNNER_HOST", endpoint).
+		WithNewFile("/.dagger-query.txt", `{
+			container {
+				from(address: "`+alpineImage+`") {
+					withExec(args: ["sh", "-c", "head -c 128 /dev/random | sha256sum"]) {
+						stdout
+					}
+				}
+			}
+		}`).
+		WithExec([]string{
+			"sh", "-c", cliBinPath + " query --doc .dagger-query.txt",
+		}).Stdout(ctx)
+	require.NoError(t, err)
+	sha := strings.TrimSpace(gjson.Get(output, "container.from.withExec.stdout").String())
+	require.NotEmpty(t, sha)
+	return sha
+}
+
+func (RemoteCacheSuite) TestResultCacheRegistry(ctx context.Context, t *testctx.T) {
+	c := connect(ctx, t)
+
+	registry := c.Container().From("registry:2").
+		WithMountedCache("/var/lib/registry/", c.CacheVolume("remote-cache-registry-"+identity.NewID())).
+		WithExposedPort(5000, dagger.ContainerWithExposedPortOpts{Protocol: dagger.NetworkProtocolTcp}).
+		AsService(dagger.ContainerAsServiceOpts{UseEntrypoint: true})
+
+	cfg := &config.ResultCacheConfig{
+		Registry: &config.RegistryCacheConfig{
+			Ref:      "registry:5000/result-cache:test",
+			Insecure: true,
+		},
+	}
+
+	shaA := runResultCacheQuery(ctx, t, c, cfg, "registry", registry)
+	shaB := runResultCacheQuery(ctx, t, c, cfg, "registry", registry)
+	require.Equal(t, shaA, shaB)
+}
+
+func (RemoteCacheSuite) TestResultCacheS3(ctx context.Context, t *testctx.T) {
+	c := connect(ctx, t)
+
+	bucket := "dagger-test-result-cache-s3-" + identity.NewID()
+
+	s3 := c.Container().From("minio/minio").
+		WithMountedCache("/data", c.CacheVolume("minio-cache")).
+		WithExposedPort(9000, dagger.ContainerWithExposedPortOpts{Protocol: dagger.NetworkProtocolTcp}).
+		WithDefaultArgs([]string{"minio", "server", "/data"}).
+		AsService()
+
+	minioStdout, err := c.Container().From("minio/mc").
+		WithServiceBinding("s3", s3).
+		WithExec([]string{"sh", "-c", "mc alias set minio http://s3:9000 minioadmin minioadmin && mc mb minio/" + bucket}).
+		Stdout(ctx)
+	require.NoError(t, err)
+	require.Contains(t, minioStdout, "Bucket created successfully")
+
+	cfg := &config.ResultCacheConfig{
+		S3: &config.S3CacheConfig{
+			Bucket:          bucket,
+			Region:          "mars",
+			EndpointURL:     "http://s3:9000",
+			UsePathStyle:    true,
+			AccessKeyID:     "minioadmin",
+			SecretAccessKey: "minioadmin",
+		},
+	}
+
+	shaA := runResultCacheQuery(ctx, t, c, cfg, "s3", s3)
+	shaB := runResultCacheQuery(ctx, t, c, cfg, "s3", s3)
+	require.Equal(t, shaA, shaB)
+
+	// the calls are stored alongside the snapshots in the bucket
+	ls, err := c.Container().From("minio/mc").
+		WithServiceBinding("s3", s3).
+		WithExec([]string{"sh", "-c", "mc alias set minio http://s3:9000 minioadmin minioadmin && mc ls --recursive minio/" + bucket}).
+		Stdout(ctx)
+	require.NoError(t, err)
+	require.Contains(t, ls, "dagql/calls.json")
+}
+
+func (RemoteCacheSuite) TestResultCacheFunctionTTL(ctx context.Context, t *testctx.T) {
+	c := connect(ctx, t)
+
+	registry := c.Container().From("registry:2").
+		WithMountedCache("/var/lib/registry/", c.CacheVolume("remote-cache-registry-"+identity.NewID())).
+		WithExposedPort(5000, dagger.ContainerWithExposedPortOpts{Protocol: dagger.NetworkProtocolTcp}).
+		AsService(dagger.ContainerAsServiceOpts{UseEntrypoint: true})
+
+	cfg := &config.ResultCacheConfig{
+		Registry: &config.RegistryCacheConfig{
+			Ref:      "registry:5000/result-cache:ttl",
+			Insecure: true,
+		},
+	}
+
+	modSrc := c.Directory().WithNewFile("main.go", `package main
+
+import (
+	"crypto/rand"
+)
+
+type Test struct{}
+
+// +cache="1h"
+func (m *Test) TestTtl() string {
+	return rand.Text()
+}
+
+// +cache="session"
+func (m *Test) TestCachePerSession() string {
+	return rand.Text()
+}
+`)
+
+	call := func(fn string) string {
+		devEngine, endpoint := devEngineWithResultCache(ctx, t, c, cfg, "registry", registry)
+		out, err := goGitBase(t, c).
+			WithServiceBinding("dev-engine", devEngine).
+			WithEnvVariable("_EXPERIMENTAL_DAGGER_RUNNER_HOST", endpoint).
+			With(daggerNonNestedExec("init", "--sdk=go", "--name=test", "--source=.")).
+			WithDirectory(".", modSrc).
+			With(daggerNonNestedExec("call", fn)).
+			Stdout(ctx)
+		require.NoError(t, err)
+		return strings.TrimSpace(out)
+	}
+
+	// a result with a TTL outlives the engine that computed it...
+	ttlA := call("test-ttl")
+	ttlB := call("test-ttl")
+	require.Equal(t, ttlA, ttlB)
+
+	// ...but per-session results are never shared across engines
+	sessA := call("test-cache-per-session")
+	sessB := call("test-cache-per-session")
+	require.NotEqual(t, sessA, sessB)
+}
+
 /*
 	Regression test for https://github.com/dagger/dagger/pull/5885
 
@@ -1001,6 +1001,148 @@ func (ServiceSuite) TestExecServicesSimple(ctx context.Context, t *testctx.T) {
 	require.Contains(t, stderr, "Host: "+hostname)
 }
 
+func (ServiceSuite) TestPersistentNamedService(ctx context.Context, t *testctx.T) {
+	name := "www-" + identity.NewID()
+	content := identity.NewID()
+
+	c1 := connect(ctx, t)
+	srv, _ := httpService(ctx, t, c1, content)
+	_, err := srv.WithName(name).Start(ctx, dagger.ServiceStartOpts{
+		Persistent:  true,
+		IdleTimeout: "10m",
+	})
+	require.NoError(t, err)
+
+	// the service outlives the session that started it
+	require.NoError(t, c1.Close())
+
+	c2 := connect(ctx, t)
+
+	services, err := c2.Engine().Services(ctx)
+	require.NoError(t, err)
+	var hostname string
+	for _, svc := range services {
+		svcName, err := svc.Name(ctx)
+		require.NoError(t, err)
+		if svcName != name {
+			continue
+		}
+		hostname, err = svc.Hostname(ctx)
+		require.NoError(t, err)
+		ports, err := svc.Ports(ctx)
+		require.NoError(t, err)
+		require.Equal(t, []int{80}, ports)
+		idleTimeout, err := svc.IdleTimeout(ctx)
+		require.NoError(t, err)
+		require.Equal(t, "10m0s", idleTimeout)
+	}
+	require.NotEmpty(t, hostname)
+
+	running := c2.RunningService(name)
+	runningHostname, err := running.Hostname(ctx)
+	require.NoError(t, err)
+	require.Equal(t, hostname, runningHostname)
+
+	out, err := c2.Container().
+		From(alpineImage).
+		WithServiceBinding("www", running).
+		WithEnvVariable("BUST", identity.NewID()).
+		WithExec([]string{"wget", "-qO-", "http://www"}).
+		Stdout(ctx)
+	require.NoError(t, err)
+	require.Equal(t, content, out)
+
+	_, err = c2.RunningService(name).Stop(ctx)
+	require.NoError(t, err)
+
+	_, err = c2.RunningService(name).ID(ctx)
+	require.ErrorContains(t, err, "no persistent service named")
+}
+
+func (ServiceSuite) TestPersistentNamedServiceMainClientOnly(ctx context.Context, t *testctx.T) {
+	c := connect(ctx, t)
+
+	// persistent services are shared across sessions, so modules can't
+	// attach to them or stop them
+	_, err := modInit(t, c, "go", `package main
+
+import "context"
+
+type Test struct{}
+
+func (m *Test) Attach(ctx context.Context, name string) (string, error) {
+	return dag.RunningService(name).Hostname(ctx)
+}
+`).
+		With(daggerCall("attach", "--name", "www-"+identity.NewID())).
+		Stdout(ctx)
+	requireErrOut(t, err, "only the main client can call this function")
+}
+
+func (ServiceSuite) TestExecNetworkMode(ctx context.Context, t *testctx.T) {
+	c := connect(ctx, t)
+
+	srv, _ := httpService(ctx, t, c, "Hello, world!")
+
+	ctr := c.Container().
+		From(alpineImage).
+		WithServiceBinding("www", srv).
+		WithEnvVariable("CACHEBUST", identity.NewID())
+
+	probe := func(t *testctx.T, opts dagger.ContainerWithExecOpts) string {
+		out, err := ctr.
+			WithExec([]string{"sh", "-c", "for url in http://www http://dagger.io; do wget -q -T 5 -O /dev/null $url && echo ok || echo blocked; done"}, opts).
+			Stdout(ctx)
+		require.NoError(t, err)
+		return out
+	}
+
+	t.Run("full", func(ctx context.Context, t *testctx.T) {
+		require.Equal(t, "ok\nok\n", probe(t, dagger.ContainerWithExecOpts{
+			NetworkMode: dagger.NetworkModeFull,
+		}))
+	})
+
+	t.Run("services only", func(ctx context.Context, t *testctx.T) {
+		require.Equal(t, "ok\nblocked\n", probe(t, dagger.ContainerWithExecOpts{
+			NetworkMode: dagger.NetworkModeServicesOnly,
+		}))
+	})
+
+	t.Run("none", func(ctx context.Context, t *testctx.T) {
+		require.Equal(t, "blocked\nblocked\n", probe(t, dagger.ContainerWithExecOpts{
+			NetworkMode: dagger.NetworkModeNone,
+		}))
+	})
+
+	t.Run("none with allowlist", func(ctx context.Context, t *testctx.T) {
+		require.Equal(t, "ok\nblocked\n", probe(t, dagger.ContainerWithExecOpts{
+			NetworkMode:     dagger.NetworkModeNone,
+			EgressAllowlist: []string{"10.0.0.0/8"},
+		}))
+	})
+
+	t.Run("allowlist requires restricted mode", func(ctx context.Context, t *testctx.T) {
+		_, err := ctr.
+			WithExec([]string{"true"}, dagger.ContainerWithExecOpts{
+				EgressAllowlist: []string{"10.0.0.0/8"},
+			}).
+			Sync(ctx)
+		requireErrOut(t, err, "an egress allowlist requires the NONE or SERVICES_ONLY network mode")
+	})
+
+	t.Run("restricted mode refuses insecure root capabilities", func(ctx context.Context, t *testctx.T) {
+		// they would let the exec flush the rules enforcing the mode
+		_, err := ctr.
+			WithExec([]string{"iptables", "-F"}, dagger.ContainerWithExecOpts{
+				NetworkMode:              dagger.NetworkModeNone,
+				InsecureRootCapabilities: true,
+			}).
+			Sync(ctx)
+		requireErrOut(t, err, "the NONE network mode can't be used with insecure root capabilities")
+	})
+}
+
 func (ServiceSuite) TestExecServicesWithDagOpsInChain(ctx context.Context, t *testctx.T) {
 	c := connect(ctx, t)
 
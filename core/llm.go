@@ -81,6 +81,9 @@ type LLM struct {
 
 	// Whether to disable the default system prompt
 	disableDefaultSystemPrompt bool
+
+	// Token usage of the other branches of the fan outs this LLM came from
+	branchTokenUsage LLMTokenUsage
 }
 
 type LLMEndpoint struct {
@@ -113,6 +116,22 @@ type LLMTokenUsage struct {
 	TotalTokens       int64 `field:"true" json:"total_tokens"`
 }
 
+func (usage *LLMTokenUsage) add(other LLMTokenUsage) {
+	usage.InputTokens += other.InputTokens
+	usage.OutputTokens += other.OutputTokens
+	usage.CachedTokenReads += other.CachedTokenReads
+	usage.CachedTokenWrites += other.CachedTokenWrites
+	usage.TotalTokens += other.TotalTokens
+}
+
+func messagesTokenUsage(messages []*ModelMessage) LLMTokenUsage {
+	var usage LLMTokenUsage
+	for _, msg := range messages {
+		usage.add(msg.TokenUsage)
+	}
+	return usage
+}
+
 func (*LLMTokenUsage) Type() *ast.Type {
 	return &ast.Type{
 		NamedType: "LLMTokenUsage",
@@ -188,7 +207,7 @@ func (r *LLMRouter) isReplay(model string) bool {
 	return strings.HasPrefix(model, "replay-") || strings.HasPrefix(model, "replay/")
 }
 
-func (r *LLMRouter) getReplay(model string) (messages []*ModelMessage, _ error) {
+func (r *LLMRouter) getReplay(model string) (histories [][]*ModelMessage, _ error) {
 	model, ok := strings.CutPrefix(model, "replay-")
 	if !ok {
 		model, ok = strings.CutPrefix(model, "replay/")
@@ -201,10 +220,16 @@ func (r *LLMRouter) getReplay(model string) (messages []*ModelMessage, _ error)
 	if err != nil {
 		return nil, err
 	}
+	// a recording is a history, or a list of them, e.g. one for each branch of
+	// a fan out
+	if err := json.Unmarshal(result, &histories); err == nil {
+		return histories, nil
+	}
+	var messages []*ModelMessage
 	if err := json.Unmarshal(result, &messages); err != nil {
 		return nil, err
 	}
-	return messages, nil
+	return [][]*ModelMessage{messages}, nil
 }
 
 func (r *LLMRouter) routeAnthropicModel() *LLMEndpoint {
@@ -634,6 +659,30 @@ func (llm *LLM) WithBlockedFunction(ctx context.Context, typeName, funcName stri
 	return llm, nil
 }
 
+// WithToolPolicy applies a policy to the functions matching a pattern. Later
+// rules take precedence over earlier ones.
+func (llm *LLM) WithToolPolicy(pattern string, policy LLMToolPolicy) *LLM {
+	llm = llm.Clone()
+	llm.mcp.toolRules = append(llm.mcp.toolRules, llmToolRule{Pattern: pattern, Policy: policy})
+	return llm
+}
+
+// WithDryRun configures the LLM to record the calls with side effects that it
+// makes, instead of calling them.
+func (llm *LLM) WithDryRun() *LLM {
+	llm = llm.Clone()
+	llm.mcp.dryRun = true
+	return llm
+}
+
+// DryRunCalls returns the calls recorded in a dry run.
+func (llm *LLM) DryRunCalls(ctx context.Context) ([]string, error) {
+	if err := llm.Sync(ctx); err != nil {
+		return nil, err
+	}
+	return llm.mcp.DryRunCalls(), nil
+}
+
 // Add an external MCP server to the LLM
 func (llm *LLM) WithMCPServer(name string, svc dagql.ObjectResult[*Service]) *LLM {
 	llm = llm.Clone()
@@ -692,6 +741,66 @@ func (llm *LLM) Step() *LLM {
 	return llm
 }
 
+// FanOut runs n branches of the LLM in parallel, each with its own history
+// and a copy of its environment. Each branch is prompted with one of the
+// variations in turn, if any. The branches are returned even if they fail, so
+// that the caller can pick the ones that succeeded.
+func (llm *LLM) FanOut(ctx context.Context, n int, variations []string) ([]*LLM, error) {
+	if n == 0 {
+		n = len(variations)
+	}
+	if n < 1 {
+		return nil, fmt.Errorf("fan out needs a number of branches or variations")
+	}
+	srv, err := CurrentDagqlServer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	branches := make([]*LLM, n)
+	for i := range branches {
+		branch := llm.Clone()
+		// outputs are saved in place, so each branch needs its own environment
+		env := branch.mcp.env
+		branch.mcp.env, err = dagql.NewObjectResultForID(env.Self().Clone(), srv, env.ID())
+		if err != nil {
+			return nil, err
+		}
+		if len(variations) > 0 {
+			branch = branch.WithPrompt(variations[i%len(variations)])
+		}
+		branches[i] = branch
+	}
+
+	var wg sync.WaitGroup
+	for i, branch := range branches {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx, span := Tracer(ctx).Start(ctx, fmt.Sprintf("LLM branch %d of %d", i+1, n), telemetry.Reveal())
+			err := branch.Sync(ctx)
+			telemetry.EndWithCause(span, &err)
+		}()
+	}
+	wg.Wait()
+
+	// keep the tokens of the other branches in each of them, so that the
+	// aggregate usage covers everything it took to get there
+	forked := len(llm.messages)
+	usages := make([]LLMTokenUsage, n)
+	for i, branch := range branches {
+		usages[i] = messagesTokenUsage(branch.messages[forked:])
+	}
+	for i, branch := range branches {
+		for j, usage := range usages {
+			if i != j {
+				branch.branchTokenUsage.add(usage)
+			}
+		}
+	}
+	return branches, nil
+}
+
 // send the context to the LLM endpoint, process replies and tool calls; continue in a loop
 // Synchronize LLM state:
 // 1. Send context to LLM endpoint
@@ -934,6 +1043,10 @@ func (llm *LLM) loop(ctx context.Context) error {
 		// Run tool calls in batch with efficient MCP syncing
 		llm.messages = append(llm.messages, llm.mcp.CallBatch(ctx, tools, res.ToolCalls)...)
 
+		if err := llm.mcp.FailedCheck(); err != nil {
+			// the model couldn't produce a valid output; give up
+			return err
+		}
 		if llm.mcp.Returned() {
 			// we returned; exit the loop, since some models just keep going
 			break
@@ -1103,13 +1216,17 @@ func (llm *LLM) TokenUsage(ctx context.Context, dag *dagql.Server) (*LLMTokenUsa
 	if err := llm.Sync(ctx); err != nil {
 		return nil, err
 	}
-	var res LLMTokenUsage
-	for _, msg := range llm.messages {
-		res.InputTokens += msg.TokenUsage.InputTokens
-		res.OutputTokens += msg.TokenUsage.OutputTokens
-		res.CachedTokenReads += msg.TokenUsage.CachedTokenReads
-		res.CachedTokenWrites += msg.TokenUsage.CachedTokenWrites
-		res.TotalTokens += msg.TokenUsage.TotalTokens
-	}
+	res := messagesTokenUsage(llm.messages)
 	return &res, nil
 }
+
+// FanOutTokenUsage returns the token usage of the LLM along with the other
+// branches of the fan outs it came from.
+func (llm *LLM) FanOutTokenUsage(ctx context.Context, dag *dagql.Server) (*LLMTokenUsage, error) {
+	res, err := llm.TokenUsage(ctx, dag)
+	if err != nil {
+		return nil, err
+	}
+	res.add(llm.branchTokenUsage)
+	return res, nil
+}
@@ -0,0 +1,118 @@
+package core
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/vektah/gqlparser/v2/ast"
+
+	"github.com/dagger/dagger/dagql"
+	"github.com/dagger/dagger/dagql/call"
+)
+
+type LLMToolPolicy string
+
+var LLMToolPolicies = dagql.NewEnum[LLMToolPolicy]()
+
+var (
+	LLMToolPolicyAllow = LLMToolPolicies.Register("ALLOW",
+		`Let the LLM call the tool`,
+	)
+	LLMToolPolicyDeny = LLMToolPolicies.Register("DENY",
+		`Refuse to call the tool`,
+	)
+	LLMToolPolicyAsk = LLMToolPolicies.Register("ASK",
+		`Ask the user to approve each call of the tool, and refuse if they can't be asked, e.g. in CI`,
+	)
+)
+
+func (policy LLMToolPolicy) Type() *ast.Type {
+	return &ast.Type{
+		NamedType: "LLMToolPolicy",
+		NonNull:   true,
+	}
+}
+
+func (policy LLMToolPolicy) TypeDescription() string {
+	return "Whether an LLM may call a tool"
+}
+
+func (policy LLMToolPolicy) Decoder() dagql.InputDecoder {
+	return LLMToolPolicies
+}
+
+func (policy LLMToolPolicy) ToLiteral() call.Literal {
+	return LLMToolPolicies.Literal(policy)
+}
+
+// llmToolRule applies a policy to the tools matching a pattern, as matched by
+// MatchCallPattern.
+type llmToolRule struct {
+	Pattern string
+	Policy  LLMToolPolicy
+}
+
+// sideEffectFunctions match the functions with effects outside of the engine,
+// which dry runs record instead of calling.
+var sideEffectFunctions = []string{
+	"Query.host",
+	"Host.*",
+	"*.export",
+	"*.exportImage",
+	"*.publish",
+	"*.terminal",
+	"*.up",
+}
+
+// toolPolicy returns the policy of the last rule matching a function, or
+// ALLOW if none does.
+func (m *MCP) toolPolicy(typeName, field string) LLMToolPolicy {
+	policy := LLMToolPolicyAllow
+	for _, rule := range m.toolRules {
+		if MatchCallPattern([]string{rule.Pattern}, typeName, field) {
+			policy = rule.Policy
+		}
+	}
+	return policy
+}
+
+// checkToolCall applies the tool policy to a call, asking the user to approve
+// it if a rule requires it. In a dry run, a call with side effects is recorded
+// instead, and the returned message is sent to the model in place of its
+// result.
+//
+// Only the tool calls of the model are checked: the calls made while running a
+// tool, e.g. by a module function, aren't, so a dry run doesn't cover the side
+// effects of the functions it calls.
+func (m *MCP) checkToolCall(ctx context.Context, typeName, field string, args any, sideEffects bool) (dryRunMsg string, _ error) {
+	toolCall := fmt.Sprintf("%s.%s%s", typeName, field, displayArgs(args))
+	switch m.toolPolicy(typeName, field) {
+	case LLMToolPolicyDeny:
+		return "", fmt.Errorf("calling %s.%s is not allowed", typeName, field)
+	case LLMToolPolicyAsk:
+		query, err := CurrentQuery(ctx)
+		if err != nil {
+			return "", err
+		}
+		bk, err := query.Buildkit(ctx)
+		if err != nil {
+			return "", fmt.Errorf("failed to get buildkit client: %w", err)
+		}
+		if err := bk.PromptAllowToolCall(ctx, toolCall); err != nil {
+			return "", err
+		}
+	}
+	if !m.dryRun {
+		return "", nil
+	}
+	if !sideEffects {
+		sideEffects = MatchCallPattern(sideEffectFunctions, typeName, field)
+	}
+	if !sideEffects {
+		return "", nil
+	}
+	m.mu.Lock()
+	m.dryRunCalls = append(m.dryRunCalls, toolCall)
+	m.mu.Unlock()
+	return fmt.Sprintf("Dry run: %s has side effects, so it was recorded instead of being called. Carry on as if it succeeded.", toolCall), nil
+}
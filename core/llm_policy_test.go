@@ -0,0 +1,56 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dagger/dagger/dagql"
+)
+
+func TestLLMToolPolicy(t *testing.T) {
+	m := newMCP(dagql.ObjectResult[*Env]{})
+	require.Equal(t, LLMToolPolicyAllow, m.toolPolicy("Container", "withExec"))
+
+	m.toolRules = []llmToolRule{
+		{Pattern: "*", Policy: LLMToolPolicyDeny},
+		{Pattern: "Container.*", Policy: LLMToolPolicyAllow},
+		{Pattern: "publish", Policy: LLMToolPolicyAsk},
+	}
+	require.Equal(t, LLMToolPolicyDeny, m.toolPolicy("Directory", "withNewFile"))
+	require.Equal(t, LLMToolPolicyAllow, m.toolPolicy("Container", "withExec"))
+	require.Equal(t, LLMToolPolicyAsk, m.toolPolicy("Container", "publish"))
+
+	_, err := m.checkToolCall(context.Background(), "Directory", "withNewFile", nil, false)
+	require.ErrorContains(t, err, "calling Directory.withNewFile is not allowed")
+}
+
+func TestLLMDryRun(t *testing.T) {
+	ctx := context.Background()
+	m := newMCP(dagql.ObjectResult[*Env]{})
+	m.dryRun = true
+
+	msg, err := m.checkToolCall(ctx, "Container", "withExec", map[string]any{"args": []string{"ls"}}, false)
+	require.NoError(t, err)
+	require.Empty(t, msg)
+
+	msg, err = m.checkToolCall(ctx, "Directory", "export", map[string]any{"path": "out"}, false)
+	require.NoError(t, err)
+	require.Contains(t, msg, "Dry run")
+
+	msg, err = m.checkToolCall(ctx, "Host", "directory", map[string]any{"path": "."}, false)
+	require.NoError(t, err)
+	require.Contains(t, msg, "Dry run")
+
+	// MCP server tools that aren't read-only have side effects
+	msg, err = m.checkToolCall(ctx, "github", "create_issue", nil, true)
+	require.NoError(t, err)
+	require.Contains(t, msg, "Dry run")
+
+	require.Equal(t, []string{
+		"Directory.export(path: out)",
+		"Host.directory(path: .)",
+		"github.create_issue",
+	}, m.DryRunCalls())
+}
@@ -2,6 +2,7 @@ package core
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/dagger/dagger/util/scrub"
@@ -9,11 +10,12 @@ import (
 )
 
 type LLMReplayer struct {
-	messages []*ModelMessage
+	// the recorded histories, e.g. one for each branch of a fan out
+	histories [][]*ModelMessage
 }
 
-func newHistoryReplay(messages []*ModelMessage) *LLMReplayer {
-	return &LLMReplayer{messages: messages}
+func newHistoryReplay(histories [][]*ModelMessage) *LLMReplayer {
+	return &LLMReplayer{histories: histories}
 }
 
 func (*LLMReplayer) IsRetryable(err error) bool {
@@ -26,24 +28,40 @@ func (c *LLMReplayer) SendQuery(ctx context.Context, history []*ModelMessage, to
 		// HistoryJSON
 		history = history[1:]
 	}
-	if len(history) >= len(c.messages) {
+	if len(c.histories) == 0 {
+		return nil, fmt.Errorf("no more messages")
+	}
+	// reply from the first recording that the history follows
+	var errs []error
+	for _, messages := range c.histories {
+		msg, err := replayNext(messages, history)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		return &LLMResponse{
+			Content:    msg.Content,
+			ToolCalls:  msg.ToolCalls,
+			TokenUsage: msg.TokenUsage,
+		}, nil
+	}
+	return nil, errors.Join(errs...)
+}
+
+// replayNext returns the recorded message that follows the history.
+func replayNext(messages, history []*ModelMessage) (*ModelMessage, error) {
+	if len(history) >= len(messages) {
 		return nil, fmt.Errorf("no more messages")
 	}
 	for i, message := range history {
 		// TODO: (cwlbraa) is this a complete comparison? also doesn't this end up being O(n^2)?
-		if scrub.Stabilize(message.Content) != scrub.Stabilize(c.messages[i].Content) || message.Role != c.messages[i].Role {
+		if scrub.Stabilize(message.Content) != scrub.Stabilize(messages[i].Content) || message.Role != messages[i].Role {
 			return nil, fmt.Errorf(
 				"message history diverges at index %d:\n%s",
 				i,
-				cmp.Diff(c.messages[i], message),
+				cmp.Diff(messages[i], message),
 			)
 		}
 	}
-	msg := c.messages[len(history)]
-
-	return &LLMResponse{
-		Content:    msg.Content,
-		ToolCalls:  msg.ToolCalls,
-		TokenUsage: msg.TokenUsage,
-	}, nil
+	return messages[len(history)], nil
 }
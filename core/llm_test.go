@@ -2,6 +2,7 @@ package core
 
 import (
 	"context"
+	"encoding/base64"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -203,3 +204,31 @@ GEMINI_MODEL=gemini-model`, nil
 	assert.Equal(t, "gemini-base-url", r.GeminiBaseURL)
 	assert.Equal(t, "gemini-model", r.GeminiModel)
 }
+
+func TestLLMReplayBranches(t *testing.T) {
+	ctx := context.Background()
+	r := &LLMRouter{}
+	model := "replay/" + base64.StdEncoding.EncodeToString([]byte(`[
+  [{"role": "user", "content": "say a"}, {"role": "assistant", "content": "a"}],
+  [{"role": "user", "content": "say b"}, {"role": "assistant", "content": "b"}]
+]`))
+	histories, err := r.getReplay(model)
+	assert.NoError(t, err)
+	replay := newHistoryReplay(histories)
+
+	res, err := replay.SendQuery(ctx, []*ModelMessage{{Role: "user", Content: "say b"}}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "b", res.Content)
+
+	res, err = replay.SendQuery(ctx, []*ModelMessage{{Role: "user", Content: "say a"}}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "a", res.Content)
+
+	_, err = replay.SendQuery(ctx, []*ModelMessage{{Role: "user", Content: "say c"}}, nil)
+	assert.ErrorContains(t, err, "message history diverges at index 0")
+
+	// a single history is still a recording on its own
+	histories, err = r.getReplay("replay/" + base64.StdEncoding.EncodeToString([]byte(`[{"role": "user", "content": "say a"}, {"role": "assistant", "content": "a"}]`)))
+	assert.NoError(t, err)
+	assert.Len(t, histories, 1)
+}
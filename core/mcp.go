@@ -85,10 +85,18 @@ type MCP struct {
 	selectedMethods map[string]bool
 	// Never show these functions, grouped by type
 	blockedMethods map[string][]string
+	// Rules deciding whether functions may be called, the last match winning
+	toolRules []llmToolRule
+	// Record calls with side effects instead of calling them
+	dryRun bool
+	// The calls recorded in a dry run
+	dryRunCalls []string
 	// The last value returned by a function.
 	lastResult dagql.Typed
 	// Indicates that the model has returned
 	returned bool
+	// The error of an output check that ran out of attempts
+	failedCheck error
 	// Saved objects by ID (Foo#123)
 	objsByID map[string]contextualBinding
 	// Auto incrementing number per-type
@@ -192,12 +200,15 @@ func (m *MCP) Clone() *MCP {
 	for typeName, methods := range cp.blockedMethods {
 		cp.blockedMethods[typeName] = slices.Clone(methods)
 	}
+	cp.toolRules = slices.Clone(cp.toolRules)
+	cp.dryRunCalls = slices.Clone(cp.dryRunCalls)
 	cp.objsByID = maps.Clone(cp.objsByID)
 	cp.typeCounts = maps.Clone(cp.typeCounts)
 	cp.idByHash = maps.Clone(cp.idByHash)
 	cp.mcpServers = maps.Clone(cp.mcpServers)
 	cp.mcpSessions = maps.Clone(cp.mcpSessions)
 	cp.returned = false
+	cp.failedCheck = nil
 	cp.mu = &sync.Mutex{}
 	return &cp
 }
@@ -221,6 +232,21 @@ func (m *MCP) Returned() bool {
 	return m.returned
 }
 
+// DryRunCalls returns the calls recorded instead of being called in a dry run.
+func (m *MCP) DryRunCalls() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return slices.Clone(m.dryRunCalls)
+}
+
+// FailedCheck returns the error of an output check that ran out of attempts,
+// if any.
+func (m *MCP) FailedCheck() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.failedCheck
+}
+
 // Get an object saved at a given key
 func (m *MCP) GetObject(ctx context.Context, key, expectedType string) (dagql.AnyObjectResult, error) {
 	if expectedType != "" {
@@ -338,6 +364,9 @@ func (m *MCP) loadMCPTools(ctx context.Context, allTools *LLMToolSet) error {
 				Schema:      schema,
 				ReadOnly:    isReadOnly,
 				Call: func(ctx context.Context, args any) (any, error) {
+					if msg, err := m.checkToolCall(ctx, serverName, tool.Name, args, !isReadOnly); err != nil || msg != "" {
+						return msg, err
+					}
 					res, err := sess.CallTool(ctx, &mcp.CallToolParams{
 						Name:      tool.Name,
 						Arguments: args,
@@ -687,6 +716,10 @@ func (m *MCP) call(ctx context.Context,
 		}
 	}()
 
+	if msg, err := m.checkToolCall(ctx, selfType, fieldDef.Name, args, false); err != nil || msg != "" {
+		return msg, err
+	}
+
 	// 1. CONVERT CALL INPUTS (BRAIN -> BODY)
 	//
 	var target dagql.AnyObjectResult
@@ -1438,10 +1471,13 @@ func (m *MCP) saveTool(srv *dagql.Server) LLMTool {
 			if !ok {
 				return nil, fmt.Errorf("unknown output: %q - please declare it first", args.Name)
 			}
+			var val dagql.Typed
+			var bnd *Binding
 			if output.ExpectedType == "String" {
-				output.Value = dagql.String(args.Value)
+				val = dagql.String(args.Value)
 			} else {
-				bnd, ok, err := m.Input(ctx, args.Value)
+				var err error
+				bnd, ok, err = m.Input(ctx, args.Value)
 				if err != nil {
 					return nil, err
 				}
@@ -1449,17 +1485,39 @@ func (m *MCP) saveTool(srv *dagql.Server) LLMTool {
 					return nil, fmt.Errorf("object not found for argument %s: %s", args.Name, args.Value)
 				}
 
-				obj := bnd.Value
-				actualType := obj.Type().Name()
+				val = bnd.Value
+				actualType := val.Type().Name()
 				if output.ExpectedType != actualType {
 					return nil, fmt.Errorf("incompatible types: %s must be %s, got %s", args.Name, output.ExpectedType, actualType)
 				}
+			}
+
+			if output.Check != nil {
+				// tools may be called in parallel, e.g. saving the same output
+				// twice in one turn
+				m.mu.Lock()
+				output.Attempts++
+				attempt := output.Attempts
+				m.mu.Unlock()
+				if err := m.checkOutput(ctx, srv, output, attempt, val, args.Value); err != nil {
+					if attempt >= output.Check.MaxAttempts {
+						err = fmt.Errorf("output %q failed its check %d times: %w", args.Name, attempt, err)
+						m.mu.Lock()
+						m.failedCheck = err
+						m.mu.Unlock()
+						return nil, err
+					}
+					return nil, fmt.Errorf("output %q failed its check (attempt %d of %d), fix the value and save it again: %w",
+						args.Name, attempt, output.Check.MaxAttempts, err)
+				}
+			}
 
+			if bnd != nil {
 				// Propagate description from output to binding so that outputs are
 				// described under `Available objects:`
 				bnd.Description = output.Description
-				output.Value = obj
 			}
+			output.Value = val
 
 			// If all outputs have been saved, we can flag the MCP as having completed
 			// its task.
@@ -1477,6 +1535,114 @@ func (m *MCP) saveTool(srv *dagql.Server) LLMTool {
 	}
 }
 
+// checkOutput runs the check of an output against a value saved to it. The
+// raw value is the string the model saved, i.e. the value itself for a string
+// or its ID for an object.
+func (m *MCP) checkOutput(ctx context.Context, srv *dagql.Server, output *Binding, attempt int, val dagql.Typed, rawVal string) (rerr error) {
+	check := output.Check
+	ctx, span := Tracer(ctx).Start(ctx,
+		fmt.Sprintf("check %s (attempt %d of %d)", output.Key, attempt, check.MaxAttempts),
+		telemetry.Reveal(),
+		trace.WithAttributes(
+			attribute.String(telemetry.LLMOutputCheckAttr, output.Key),
+			attribute.Int(telemetry.LLMOutputCheckAttemptAttr, attempt),
+			attribute.Int(telemetry.LLMOutputCheckMaxAttemptsAttr, check.MaxAttempts),
+		))
+	defer telemetry.EndWithCause(span, &rerr)
+
+	if check.Function != "" {
+		return m.checkOutputFunction(ctx, srv, check.Function, output.ExpectedType, rawVal)
+	}
+	return checkOutputExec(ctx, srv, check, val)
+}
+
+// checkOutputFunction calls a function of a module installed in the
+// environment, passing the value to its first argument of the output's type.
+func (m *MCP) checkOutputFunction(ctx context.Context, srv *dagql.Server, fnName, typeName, rawVal string) error {
+	tools := NewLLMToolSet()
+	if err := m.loadModuleTools(srv, tools); err != nil {
+		return err
+	}
+	tool, err := m.LookupTool(fnName, tools.Order)
+	if err != nil {
+		return fmt.Errorf("check function: %w", err)
+	}
+	argType := typeName
+	if typeName != "String" {
+		argType += "ID"
+	}
+	var argName string
+	for _, arg := range tool.Field.Arguments {
+		if arg.Type.Name() == argType {
+			argName = arg.Name
+			break
+		}
+	}
+	if argName == "" {
+		return fmt.Errorf("check function %q has no argument of type %s", fnName, typeName)
+	}
+	_, err = tool.Call(EnvIDToContext(ctx, m.env.ID()), map[string]any{argName: rawVal})
+	return err
+}
+
+// checkOutputExec runs the command of a check in its container, with the value
+// mounted in it, or passed on stdin for a string.
+func checkOutputExec(ctx context.Context, srv *dagql.Server, check *OutputCheck, val dagql.Typed) error {
+	var sels []dagql.Selector
+	var stdin string
+	if str, ok := dagql.UnwrapAs[dagql.String](val); ok {
+		stdin = str.String()
+	} else {
+		obj, ok := dagql.UnwrapAs[dagql.AnyObjectResult](val)
+		if !ok {
+			return fmt.Errorf("cannot check a value of type %s", val.Type().Name())
+		}
+		mount := dagql.Selector{
+			View:  srv.View,
+			Field: "withMountedDirectory",
+			Args: []dagql.NamedInput{
+				{Name: "path", Value: dagql.String(check.Path)},
+			},
+		}
+		switch obj.ObjectType().TypeName() {
+		case "Directory":
+			mount.Args = append(mount.Args, dagql.NamedInput{Name: "source", Value: dagql.NewID[*Directory](obj.ID())})
+		case "File":
+			mount.Field = "withMountedFile"
+			mount.Args = append(mount.Args, dagql.NamedInput{Name: "source", Value: dagql.NewID[*File](obj.ID())})
+		case "Container":
+			var rootfs dagql.ObjectResult[*Directory]
+			if err := srv.Select(ctx, obj, &rootfs, dagql.Selector{
+				View:  srv.View,
+				Field: "rootfs",
+			}); err != nil {
+				return err
+			}
+			mount.Args = append(mount.Args, dagql.NamedInput{Name: "source", Value: dagql.NewID[*Directory](rootfs.ID())})
+		default:
+			return fmt.Errorf("cannot check a value of type %s with a command; use a function check instead", obj.ObjectType().TypeName())
+		}
+		sels = append(sels, mount)
+	}
+
+	exec := dagql.Selector{
+		View:  srv.View,
+		Field: "withExec",
+		Args: []dagql.NamedInput{
+			{Name: "args", Value: dagql.ArrayInput[dagql.String](dagql.NewStringArray(check.Args...))},
+		},
+	}
+	if stdin != "" {
+		exec.Args = append(exec.Args, dagql.NamedInput{Name: "stdin", Value: dagql.String(stdin)})
+	}
+	sels = append(sels, exec, dagql.Selector{
+		View:  srv.View,
+		Field: "sync",
+	})
+	var res dagql.AnyResult
+	return srv.Select(ctx, check.Container, &res, sels...)
+}
+
 func (m *MCP) loadBuiltins(srv *dagql.Server, allTools, objectMethods *LLMToolSet) {
 	schema := srv.Schema()
 
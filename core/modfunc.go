@@ -143,9 +143,12 @@ func (fn *ModuleFunction) recordCall(ctx context.Context) {
 // It first loads the argument set by the user.
 // Then the default values.
 // Finally the contextual arguments.
+//
+// Constraints are checked last, so they also apply to default values.
 func (fn *ModuleFunction) setCallInputs(ctx context.Context, opts *CallOpts) ([]*FunctionCallArgValue, error) {
 	callInputs := make([]*FunctionCallArgValue, len(opts.Inputs))
 	hasArg := map[string]bool{}
+	values := map[string]any{}
 
 	for i, input := range opts.Inputs {
 		normalizedName := gqlArgName(input.Name)
@@ -179,6 +182,7 @@ func (fn *ModuleFunction) setCallInputs(ctx context.Context, opts *CallOpts) ([]
 		}
 
 		hasArg[name] = true
+		values[name] = converted
 	}
 
 	// Load default value
@@ -214,6 +218,18 @@ func (fn *ModuleFunction) setCallInputs(ctx context.Context, opts *CallOpts) ([]
 		callInputs = append(callInputs, defaultInput)
 		hasArg[name] = true
 	}
+
+	// Check constraints once all the defaults are resolved, so they apply to
+	// the values the function actually gets
+	for _, input := range callInputs {
+		arg, ok := fn.args[gqlArgName(input.Name)]
+		if !ok || !arg.metadata.Constraints.Valid {
+			continue
+		}
+		if err := fn.validateArg(ctx, opts.Server, arg.metadata, values[input.Name], input.Value); err != nil {
+			return nil, err
+		}
+	}
 	return callInputs, nil
 }
 
@@ -969,6 +985,15 @@ func moduleAnalyticsProps(mod *Module, prefix string, props map[string]string) {
 	}
 }
 
+// checkContextualHostRead returns an error if loading the given contextual
+// path reads from the host and the module may not read it.
+func (fn *ModuleFunction) checkContextualHostRead(path string) error {
+	if fn.mod.ContextSource.Value.Self().Kind != ModuleSourceKindLocal {
+		return nil
+	}
+	return fn.mod.CheckHostRead(path)
+}
+
 // loadContextualArg loads a contextual argument from the module context directory.
 //
 // For Directory, it will load the directory from the module context directory.
@@ -993,6 +1018,10 @@ func (fn *ModuleFunction) loadContextualArg(
 
 	switch arg.TypeDef.AsObject.Value.Name {
 	case "Directory":
+		if err := fn.checkContextualHostRead(arg.DefaultPath); err != nil {
+			return nil, err
+		}
+
 		// only local sources need special handling to prevent errant reloads, other
 		// module types are reproducible and can be called directly
 		if fn.mod.ContextSource.Value.Self().Kind != ModuleSourceKindLocal {
@@ -1036,6 +1065,10 @@ func (fn *ModuleFunction) loadContextualArg(
 		return dagql.NewID[*Directory](dir.ID()), nil
 
 	case "File":
+		if err := fn.checkContextualHostRead(arg.DefaultPath); err != nil {
+			return nil, err
+		}
+
 		// only local sources need special handling to prevent errant reloads, other
 		// module types are reproducible and can be called directly
 		if fn.mod.ContextSource.Value.Self().Kind != ModuleSourceKindLocal {
@@ -1078,6 +1111,9 @@ func (fn *ModuleFunction) loadContextualArg(
 		cleanedPath := filepath.Clean(strings.Trim(arg.DefaultPath, "/"))
 		if cleanedPath == "." || cleanedPath == ".git" {
 			// handle getting the git repo from the current module context
+			if err := fn.checkContextualHostRead(arg.DefaultPath); err != nil {
+				return nil, err
+			}
 			var err error
 			git, err = fn.mod.ContextSource.Value.Self().LoadContextGit(ctx, dag)
 			if err != nil {
@@ -1124,6 +1160,41 @@ func (fn *ModuleFunction) loadContextualArg(
 	return nil, fmt.Errorf("unknown contextual argument type %q", arg.TypeDef.AsObject.Value.Name)
 }
 
+// validateArg checks that the value of an argument satisfies its constraints,
+// before the function is called.
+func (fn *ModuleFunction) validateArg(ctx context.Context, dag *dagql.Server, arg *FunctionArg, value any, encoded JSON) error {
+	constraints := arg.Constraints.Value
+	err := constraints.Validate(arg.Name, encoded)
+	if err == nil && len(constraints.RequiredPaths) > 0 {
+		var dirID *call.ID
+		switch value := value.(type) {
+		case DynamicID:
+			dirID = value.ID()
+		case dagql.ID[*Directory]:
+			dirID = value.ID()
+		case dagql.Optional[dagql.IDType]:
+			if value.Valid {
+				dirID = value.Value.ID()
+			}
+		}
+		if dirID != nil {
+			dir, loadErr := dagql.NewID[*Directory](dirID).Load(ctx, dag)
+			if loadErr != nil {
+				return fmt.Errorf("load arg %q: %w", arg.Name, loadErr)
+			}
+			err = constraints.ValidatePaths(ctx, dag, arg.Name, dir.Self())
+		}
+	}
+	var constraintErr *ArgConstraintError
+	if errors.As(err, &constraintErr) {
+		constraintErr.Function = fn.metadata.Name
+		if fn.objDef != nil {
+			constraintErr.Function = fn.objDef.Name + "." + fn.metadata.Name
+		}
+	}
+	return err
+}
+
 func (fn *ModuleFunction) applyIgnoreOnDir(ctx context.Context, dag *dagql.Server, arg *FunctionArg, value any) (any, error) {
 	if kind := arg.TypeDef.Kind; kind != TypeDefKindObject {
 		return nil, fmt.Errorf("[kind=%v] argument %q must be of type Directory to apply ignore pattern: [%s]", kind, arg.OriginalName, strings.Join(arg.Ignore, ","))
@@ -4,16 +4,20 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/go-git/go-git/v5/plumbing/transport"
 	"golang.org/x/mod/semver"
 
 	"dagger.io/dagger/telemetry"
+	"github.com/dagger/dagger/core/modules"
 	"github.com/dagger/dagger/dagql"
 	"github.com/dagger/dagger/engine/slog"
 	"github.com/dagger/dagger/engine/vcs"
+	"github.com/dagger/dagger/util/gitutil"
 )
 
 func fastModuleSourceKindCheck(
@@ -120,6 +124,9 @@ type ParsedGitRefString struct {
 
 	ModVersion string
 	hasVersion bool
+	// versionIsRef is set by GitRef when a version that looks like a range
+	// names an existing ref instead, e.g. a ">=1.2" branch
+	versionIsRef bool
 
 	RepoRoot       *vcs.RepoRoot
 	RepoRootSubdir string
@@ -241,13 +248,53 @@ func parseScheme(refString string) (SchemeType, string) {
 	return NoScheme, refString
 }
 
+// VersionRange returns the semver range requested in the ref string, or ""
+// if a single version, or none, was requested.
+func (p *ParsedGitRefString) VersionRange() string {
+	if p.hasVersion && !p.versionIsRef && modules.IsVersionRange(p.ModVersion) {
+		return p.ModVersion
+	}
+	return ""
+}
+
 func (p *ParsedGitRefString) GitRef(
 	ctx context.Context,
 	dag *dagql.Server,
 	pinCommitRef string, // "" if none
 ) (inst dagql.ObjectResult[*GitRef], rerr error) {
 	var modTag string
-	if p.hasVersion && semver.IsValid(p.ModVersion) {
+	switch {
+	case p.hasVersion && modules.IsVersionRange(p.ModVersion):
+		rng, err := modules.ParseVersionRange(p.ModVersion)
+		if err != nil {
+			return inst, err
+		}
+
+		var repo dagql.ObjectResult[*GitRepository]
+		err = dag.Select(ctx, dag.Root(), &repo,
+			dagql.Selector{
+				Field: "git",
+				Args: []dagql.NamedInput{
+					{Name: "url", Value: dagql.String(p.cloneRef)},
+				},
+			},
+		)
+		if err != nil {
+			return inst, fmt.Errorf("failed to resolve git tags: %w", err)
+		}
+
+		if modules.MayBeGitRef(p.ModVersion) && hasGitRef(repo.Self().Remote.Refs, p.ModVersion) {
+			// resolve it as the ref below
+			p.versionIsRef = true
+			break
+		}
+
+		matched, err := matchVersionRange(repo.Self().Remote.Refs, rng, p.RepoRootSubdir, pinCommitRef)
+		if err != nil {
+			return inst, fmt.Errorf("matching version range to tags: %w", err)
+		}
+		modTag = matched
+	case p.hasVersion && semver.IsValid(p.ModVersion):
 		var tags dagql.Array[dagql.String]
 		err := dag.Select(ctx, dag.Root(), &tags,
 			dagql.Selector{
@@ -344,3 +391,132 @@ func matchVersion(versions []string, match, subPath string) (string, error) {
 	}
 	return "", fmt.Errorf("unable to find version %s", match)
 }
+
+// hasGitRef returns whether a branch or tag with the given name is in refs.
+func hasGitRef(refs []*gitutil.Ref, name string) bool {
+	return slices.ContainsFunc(refs, func(ref *gitutil.Ref) bool {
+		return ref.Name == "refs/heads/"+name || ref.Name == "refs/tags/"+name
+	})
+}
+
+// Match the newest tag within a version range with optional subPath, the same
+// way as matchVersion. If pin is set, only tags pointing to it are matched.
+// e.g. github.com/foo/daggerverse/mod@^1.2 matches mod/v1.4.0
+// e.g. github.com/foo/mod@^1.2 matches v1.4.0
+func matchVersionRange(refs []*gitutil.Ref, rng *modules.VersionRange, subPath, pin string) (string, error) {
+	var prefixes []string
+	if subPath != "/" && subPath != "" {
+		rawSubPath, _ := strings.CutPrefix(subPath, "/")
+		prefixes = append(prefixes, rawSubPath+"/")
+	}
+	prefixes = append(prefixes, "")
+
+	for _, prefix := range prefixes {
+		var versions []string
+		for _, ref := range refs {
+			tag, ok := strings.CutPrefix(ref.Name, "refs/tags/")
+			if !ok {
+				continue
+			}
+			if pin != "" && ref.SHA != pin {
+				continue
+			}
+			// annotated tags are listed twice, the peeled one points to the commit
+			tag = strings.TrimSuffix(tag, "^{}")
+			version, ok := strings.CutPrefix(tag, prefix)
+			if !ok {
+				continue
+			}
+			versions = append(versions, version)
+		}
+		if latest, ok := rng.Latest(versions); ok {
+			return prefix + latest, nil
+		}
+	}
+	if pin != "" {
+		return "", fmt.Errorf("unable to find version matching %s at commit %s", rng, pin)
+	}
+	return "", fmt.Errorf("unable to find version matching %s", rng)
+}
+
+// CheckDependencyVersions returns an error if the version requirements for a
+// git dependency, across all of the transitive dependencies of src, can't be
+// satisfied together. Only requirements with a semver range are checked, since
+// depending on different exact versions of a module is allowed.
+func CheckDependencyVersions(src *ModuleSource) error {
+	type requirement struct {
+		requirer string
+		version  string
+		rng      *modules.VersionRange
+		isRange  bool
+	}
+	reqs := map[string][]requirement{}
+	visited := map[string]struct{}{}
+
+	var collect func(src *ModuleSource) error
+	collect = func(src *ModuleSource) error {
+		for _, dep := range src.Dependencies {
+			depSrc := dep.Self()
+			if depSrc == nil || depSrc.Kind != ModuleSourceKindGit {
+				continue
+			}
+
+			req := requirement{requirer: src.ModuleName}
+			if depSrc.Git.VersionRange != "" {
+				rng, err := modules.ParseVersionRange(depSrc.Git.VersionRange)
+				if err != nil {
+					return err
+				}
+				req.version, req.rng, req.isRange = depSrc.Git.VersionRange, rng, true
+			} else {
+				req.version = strings.TrimPrefix(depSrc.Git.Version, depSrc.SourceRootSubpath+"/")
+				if semver.IsValid(req.version) {
+					// an exact version only matches itself
+					req.rng, _ = modules.ParseVersionRange("=" + req.version)
+				}
+			}
+			reqs[depSrc.Git.Symbolic] = append(reqs[depSrc.Git.Symbolic], req)
+
+			key := depSrc.Git.Symbolic + "@" + depSrc.Git.Commit
+			if _, ok := visited[key]; ok {
+				continue
+			}
+			visited[key] = struct{}{}
+			if err := collect(depSrc); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := collect(src); err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, symbolic := range slices.Sorted(maps.Keys(reqs)) {
+		modReqs := reqs[symbolic]
+		hasRange := slices.ContainsFunc(modReqs, func(req requirement) bool {
+			return req.isRange
+		})
+		if !hasRange {
+			continue
+		}
+		compatible := true
+		for i, a := range modReqs {
+			for _, b := range modReqs[i+1:] {
+				if a.rng != nil && b.rng != nil && !a.rng.Intersects(b.rng) {
+					compatible = false
+				}
+			}
+		}
+		if compatible {
+			continue
+		}
+		msg := fmt.Sprintf("incompatible version requirements for %s:", symbolic)
+		for _, req := range modReqs {
+			msg += fmt.Sprintf("\n  - %s requires %s", req.requirer, req.version)
+		}
+		errs = append(errs, errors.New(msg))
+	}
+	return errors.Join(errs...)
+}
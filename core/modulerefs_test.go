@@ -5,8 +5,12 @@ import (
 	"os"
 	"testing"
 
+	"github.com/dagger/dagger/core/modules"
+	"github.com/dagger/dagger/dagql"
+	"github.com/dagger/dagger/dagql/call"
 	"github.com/dagger/dagger/engine/vcs"
 	fsutiltypes "github.com/dagger/dagger/internal/fsutil/types"
+	"github.com/dagger/dagger/util/gitutil"
 	"github.com/stretchr/testify/require"
 )
 
@@ -32,6 +36,111 @@ func TestMatchVersion(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestMatchVersionRange(t *testing.T) {
+	refs := []*gitutil.Ref{
+		{Name: "refs/heads/main", SHA: "a"},
+		{Name: "refs/tags/v1.0.0", SHA: "b"},
+		{Name: "refs/tags/v1.4.0", SHA: "c"},
+		{Name: "refs/tags/v1.5.0", SHA: "d0"},
+		{Name: "refs/tags/v1.5.0^{}", SHA: "d"},
+		{Name: "refs/tags/v2.0.0", SHA: "e"},
+		{Name: "refs/tags/path/v1.2.0", SHA: "f"},
+	}
+	rng, err := modules.ParseVersionRange("^1.4")
+	require.NoError(t, err)
+
+	matched, err := matchVersionRange(refs, rng, "/", "")
+	require.NoError(t, err)
+	require.Equal(t, "v1.5.0", matched)
+
+	matched, err = matchVersionRange(refs, rng, "/", "c")
+	require.NoError(t, err)
+	require.Equal(t, "v1.4.0", matched)
+
+	matched, err = matchVersionRange(refs, rng, "/", "d")
+	require.NoError(t, err)
+	require.Equal(t, "v1.5.0", matched)
+
+	_, err = matchVersionRange(refs, rng, "/", "e")
+	require.Error(t, err)
+
+	// monorepo tags are preferred, falling back to root tags
+	rng, err = modules.ParseVersionRange("^1")
+	require.NoError(t, err)
+	matched, err = matchVersionRange(refs, rng, "/path", "")
+	require.NoError(t, err)
+	require.Equal(t, "path/v1.2.0", matched)
+	matched, err = matchVersionRange(refs, rng, "other", "")
+	require.NoError(t, err)
+	require.Equal(t, "v1.5.0", matched)
+}
+
+func TestHasGitRef(t *testing.T) {
+	refs := []*gitutil.Ref{
+		{Name: "refs/heads/main", SHA: "a"},
+		{Name: "refs/heads/>=1.2", SHA: "b"},
+		{Name: "refs/tags/<2", SHA: "c"},
+	}
+	require.True(t, hasGitRef(refs, ">=1.2"))
+	require.True(t, hasGitRef(refs, "<2"))
+	require.False(t, hasGitRef(refs, ">=1"))
+	require.False(t, hasGitRef(refs, "heads/>=1.2"))
+}
+
+func TestCheckDependencyVersions(t *testing.T) {
+	gitDep := func(t *testing.T, name, version, versionRange string, deps ...*ModuleSource) *ModuleSource {
+		return testModuleSource(t, &ModuleSource{
+			ModuleName:        name,
+			Kind:              ModuleSourceKindGit,
+			SourceRootSubpath: name,
+			Git: &GitModuleSource{
+				Symbolic:     "github.com/org/repo/" + name,
+				Version:      version,
+				VersionRange: versionRange,
+				Commit:       name + version,
+			},
+		}, deps...)
+	}
+
+	t.Run("compatible", func(t *testing.T) {
+		src := testModuleSource(t, &ModuleSource{ModuleName: "root"},
+			gitDep(t, "foo", "v1.6.0", "^1.4"),
+			gitDep(t, "bar", "v1.0.0", "", gitDep(t, "foo", "foo/v1.6.3", "")),
+			gitDep(t, "baz", "v1.0.0", "", gitDep(t, "foo", "v1.6.0", "~1.6")),
+		)
+		require.NoError(t, CheckDependencyVersions(src))
+	})
+
+	t.Run("exact versions", func(t *testing.T) {
+		src := testModuleSource(t, &ModuleSource{ModuleName: "root"},
+			gitDep(t, "foo", "v1.6.0", ""),
+			gitDep(t, "bar", "v1.0.0", "", gitDep(t, "foo", "v2.0.0", "")),
+		)
+		require.NoError(t, CheckDependencyVersions(src))
+	})
+
+	t.Run("incompatible", func(t *testing.T) {
+		src := testModuleSource(t, &ModuleSource{ModuleName: "root"},
+			gitDep(t, "foo", "v1.6.0", "^1.4"),
+			gitDep(t, "bar", "v1.0.0", "", gitDep(t, "foo", "v2.1.0", "^2")),
+		)
+		err := CheckDependencyVersions(src)
+		require.EqualError(t, err, `incompatible version requirements for github.com/org/repo/foo:
+  - root requires ^1.4
+  - bar requires ^2`)
+	})
+}
+
+func testModuleSource(t *testing.T, src *ModuleSource, deps ...*ModuleSource) *ModuleSource {
+	t.Helper()
+	for _, dep := range deps {
+		res, err := dagql.NewResultForID(dep, call.New().Append(dep.Type(), "moduleSource"))
+		require.NoError(t, err)
+		src.Dependencies = append(src.Dependencies, dagql.ObjectResult[*ModuleSource]{Result: res})
+	}
+	return src
+}
+
 // Test ParseRefString using an interface to control Host side effect
 func TestParseRefString(t *testing.T) {
 	ctx := context.Background()
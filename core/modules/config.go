@@ -3,6 +3,7 @@ package modules
 import (
 	"encoding/json"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/dagger/dagger/engine"
@@ -84,6 +85,10 @@ type ModuleConfig struct {
 	// If true, disable the new default function caching behavior for this module. Functions will
 	// instead default to the old behavior of per-session caching.
 	DisableDefaultFunctionCaching *bool `json:"disableDefaultFunctionCaching,omitempty"`
+
+	// The capabilities the module requires. If set, calls made by the module are restricted to
+	// these capabilities; otherwise the module requires all of them.
+	Capabilities *ModuleCapabilities `json:"capabilities,omitempty"`
 }
 
 type ModuleConfigUserFields struct {
@@ -198,10 +203,12 @@ type ModuleConfigDependency struct {
 	// but can also be overridden to use a different name.
 	Name string `json:"name"`
 
-	// The source ref of the module dependency.
+	// The source ref of the module dependency. The version of a git source may
+	// be a semver range, e.g. "github.com/org/mod@^1.4".
 	Source string `json:"source"`
 
-	// The pinned version of the module dependency.
+	// The pinned version of the module dependency. For a semver range, the
+	// commit of the newest matching tag when last installed or updated.
 	Pin string `json:"pin,omitempty"`
 
 	// Deprecated: Include in config struct for dagger develop compat for 1 release.
@@ -212,6 +219,10 @@ type ModuleConfigDependency struct {
 	// IgnoreChecks is a list of check patterns to exclude from this toolchain.
 	// Patterns can use glob syntax to match check names.
 	IgnoreChecks []string `json:"ignoreChecks,omitempty"`
+
+	// The capabilities granted to the dependency when it was installed. The dependency may only use
+	// capabilities that it both declares and was granted, so updating it can't widen its access.
+	Capabilities *ModuleCapabilities `json:"capabilities,omitempty"`
 }
 
 // ModuleConfigArgument represents an argument override for a toolchain function
@@ -305,3 +316,55 @@ func (m ModuleConfigClient) Clone() *ModuleConfigClient {
 	cp := m
 	return &cp
 }
+
+// ModuleCapabilities are the capabilities a module requires, as declared in its dagger.json.
+type ModuleCapabilities struct {
+	// Paths the module may read from the host through defaultPath arguments. Relative paths are
+	// relative to the module's dagger.json, absolute paths to its context directory.
+	HostRead []string `field:"true" name:"hostRead" json:"hostRead,omitempty" doc:"Paths the module may read from the host through defaultPath arguments."`
+
+	// Whether the module may use host unix sockets and tunnels.
+	HostSockets bool `field:"true" name:"hostSockets" json:"hostSockets,omitempty" doc:"Whether the module may use host unix sockets and tunnels."`
+
+	// Whether the module may run commands and services with full network access, and download
+	// files with http. Image pulls and git fetches are done by the engine and aren't restricted.
+	Network bool `field:"true" name:"network" json:"network,omitempty" doc:"Whether the module may run commands and services with full network access, and download files with http. Image pulls and git fetches are done by the engine and aren't restricted."`
+
+	// Whether the module may use LLMs.
+	LLM bool `field:"true" name:"llm" json:"llm,omitempty" doc:"Whether the module may use LLMs."`
+
+	// Whether the module may run commands with access to the Dagger API.
+	Exec bool `field:"true" name:"exec" json:"exec,omitempty" doc:"Whether the module may run commands with access to the Dagger API."`
+
+	// Whether the module may load secrets from the host's secret providers.
+	Secrets bool `field:"true" name:"secrets" json:"secrets,omitempty" doc:"Whether the module may load secrets from the host's secret providers."`
+}
+
+// AllModuleCapabilities returns every capability, as required by a module
+// that declares none.
+func AllModuleCapabilities() *ModuleCapabilities {
+	return &ModuleCapabilities{
+		HostRead:    []string{"/"},
+		HostSockets: true,
+		Network:     true,
+		LLM:         true,
+		Exec:        true,
+		Secrets:     true,
+	}
+}
+
+func (*ModuleCapabilities) Type() *ast.Type {
+	return &ast.Type{
+		NamedType: "ModuleCapabilities",
+		NonNull:   false,
+	}
+}
+
+func (*ModuleCapabilities) TypeDescription() string {
+	return "The capabilities a module requires."
+}
+
+func (caps ModuleCapabilities) Clone() *ModuleCapabilities {
+	caps.HostRead = slices.Clone(caps.HostRead)
+	return &caps
+}
@@ -0,0 +1,263 @@
+package modules
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"golang.org/x/mod/semver"
+)
+
+// VersionRange is a set of semver versions a dependency may be resolved to,
+// e.g. "^1.4", "~1.4.2", ">=1.2 <2" or "^1 || ^2".
+//
+// Space separated constraints must all match, while constraints separated by
+// "||" are alternatives. The "v" prefix and missing minor or patch versions
+// are optional, so "^1.4" is the same as "^v1.4.0".
+type VersionRange struct {
+	raw       string
+	intervals []versionInterval
+}
+
+// versionInterval is a contiguous range of versions; empty bounds are
+// unbounded.
+type versionInterval struct {
+	min, max         string
+	minIncl, maxIncl bool
+}
+
+// IsVersionRange returns whether the given version is a range rather than a
+// single version, branch or commit.
+//
+// "^", "~" and spaces aren't valid in git ref names, so a version containing
+// them can only be a range. "<", ">", "=" and "|" are valid in ref names
+// though, so a range like ">=1.2" may also name a ref, see MayBeGitRef.
+func IsVersionRange(version string) bool {
+	if !strings.ContainsAny(version, "^~<>=| ") {
+		return false
+	}
+	_, err := ParseVersionRange(version)
+	return err == nil
+}
+
+// MayBeGitRef returns whether the given version range is also a valid git ref
+// name, as checked by git check-ref-format, in which case an existing ref with
+// that name should take precedence over the range.
+func MayBeGitRef(version string) bool {
+	if strings.ContainsAny(version, "^~ :?*[\\") ||
+		strings.Contains(version, "..") ||
+		strings.Contains(version, "@{") {
+		return false
+	}
+	return !strings.HasSuffix(version, ".") && !strings.HasSuffix(version, ".lock")
+}
+
+// ParseVersionRange parses a semver range.
+func ParseVersionRange(rng string) (*VersionRange, error) {
+	vr := &VersionRange{raw: strings.TrimSpace(rng)}
+	for alt := range strings.SplitSeq(rng, "||") {
+		interval := versionInterval{}
+		fields := strings.Fields(alt)
+		if len(fields) == 0 {
+			return nil, fmt.Errorf("invalid version range %q: empty constraint", rng)
+		}
+		for i := 0; i < len(fields); i++ {
+			constraint := fields[i]
+			// allow a space between the operator and the version, e.g. ">= 1.2"
+			if strings.Trim(constraint, "^~<>=") == "" && i+1 < len(fields) {
+				i++
+				constraint += fields[i]
+			}
+			bounds, err := parseVersionConstraint(constraint)
+			if err != nil {
+				return nil, fmt.Errorf("invalid version range %q: %w", rng, err)
+			}
+			interval = interval.intersect(bounds)
+		}
+		vr.intervals = append(vr.intervals, interval)
+	}
+	return vr, nil
+}
+
+func (vr *VersionRange) String() string {
+	return vr.raw
+}
+
+// Matches returns whether the given version is within the range. Prerelease
+// versions never match.
+func (vr *VersionRange) Matches(version string) bool {
+	version, ok := normalizeVersion(version)
+	if !ok || semver.Prerelease(version) != "" {
+		return false
+	}
+	for _, interval := range vr.intervals {
+		if interval.contains(version) {
+			return true
+		}
+	}
+	return false
+}
+
+// Intersects returns whether some version is within both ranges.
+func (vr *VersionRange) Intersects(other *VersionRange) bool {
+	for _, a := range vr.intervals {
+		for _, b := range other.intervals {
+			if !a.intersect(b).empty() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Latest returns the newest of the given versions within the range, or false
+// if none match.
+func (vr *VersionRange) Latest(versions []string) (string, bool) {
+	var latest, latestNormalized string
+	for _, version := range versions {
+		if !vr.Matches(version) {
+			continue
+		}
+		normalized, _ := normalizeVersion(version)
+		if latest == "" || semver.Compare(normalized, latestNormalized) > 0 {
+			latest, latestNormalized = version, normalized
+		}
+	}
+	return latest, latest != ""
+}
+
+// parseVersionConstraint parses a single constraint, like "^1.4" or "<2".
+func parseVersionConstraint(constraint string) (versionInterval, error) {
+	op := constraint[:len(constraint)-len(strings.TrimLeft(constraint, "^~<>="))]
+	rawVersion := constraint[len(op):]
+	version, ok := normalizeVersion(rawVersion)
+	if !ok {
+		return versionInterval{}, fmt.Errorf("invalid version %q", rawVersion)
+	}
+	parts := versionParts(rawVersion)
+
+	switch op {
+	case "", "=":
+		if parts < 3 {
+			// a partial version matches all versions with that prefix
+			return versionInterval{min: version, minIncl: true, max: bumpVersion(version, parts-1)}, nil
+		}
+		return versionInterval{min: version, minIncl: true, max: version, maxIncl: true}, nil
+	case ">=":
+		return versionInterval{min: version, minIncl: true}, nil
+	case ">":
+		if parts < 3 {
+			return versionInterval{min: bumpVersion(version, parts-1), minIncl: true}, nil
+		}
+		return versionInterval{min: version}, nil
+	case "<=":
+		if parts < 3 {
+			return versionInterval{max: bumpVersion(version, parts-1)}, nil
+		}
+		return versionInterval{max: version, maxIncl: true}, nil
+	case "<":
+		return versionInterval{max: version}, nil
+	case "~":
+		// patch updates if a minor version is given, otherwise minor updates
+		return versionInterval{min: version, minIncl: true, max: bumpVersion(version, min(parts-1, 1))}, nil
+	case "^":
+		// updates that don't change the leftmost non-zero component
+		idx := parts - 1
+		for i, n := range versionNumbers(version)[:parts] {
+			if n != 0 {
+				idx = i
+				break
+			}
+		}
+		return versionInterval{min: version, minIncl: true, max: bumpVersion(version, idx)}, nil
+	default:
+		return versionInterval{}, fmt.Errorf("invalid operator %q", op)
+	}
+}
+
+// normalizeVersion returns the canonical form of a possibly partial version
+// with an optional "v" prefix, e.g. "1.4" becomes "v1.4.0".
+func normalizeVersion(version string) (string, bool) {
+	if !strings.HasPrefix(version, "v") {
+		version = "v" + version
+	}
+	if !semver.IsValid(version) {
+		return "", false
+	}
+	return semver.Canonical(version), true
+}
+
+// versionParts returns the number of major, minor and patch components given
+// in the version.
+func versionParts(version string) int {
+	version, _, _ = strings.Cut(version, "-")
+	version, _, _ = strings.Cut(version, "+")
+	return strings.Count(version, ".") + 1
+}
+
+func versionNumbers(version string) []int {
+	core := strings.TrimPrefix(semver.Canonical(version), "v")
+	core = strings.TrimSuffix(core, semver.Prerelease(version))
+	var nums []int
+	for part := range strings.SplitSeq(core, ".") {
+		n, _ := strconv.Atoi(part)
+		nums = append(nums, n)
+	}
+	return nums
+}
+
+// bumpVersion returns the lowest version greater than all versions sharing
+// the components up to idx (0 for major, 1 for minor, 2 for patch).
+func bumpVersion(version string, idx int) string {
+	nums := versionNumbers(version)
+	nums[idx]++
+	for i := idx + 1; i < len(nums); i++ {
+		nums[i] = 0
+	}
+	return fmt.Sprintf("v%d.%d.%d", nums[0], nums[1], nums[2])
+}
+
+func (interval versionInterval) contains(version string) bool {
+	if interval.min != "" {
+		cmp := semver.Compare(version, interval.min)
+		if cmp < 0 || (cmp == 0 && !interval.minIncl) {
+			return false
+		}
+	}
+	if interval.max != "" {
+		cmp := semver.Compare(version, interval.max)
+		if cmp > 0 || (cmp == 0 && !interval.maxIncl) {
+			return false
+		}
+	}
+	return true
+}
+
+func (interval versionInterval) intersect(other versionInterval) versionInterval {
+	res := interval
+	if other.min != "" {
+		cmp := semver.Compare(other.min, res.min)
+		if res.min == "" || cmp > 0 {
+			res.min, res.minIncl = other.min, other.minIncl
+		} else if cmp == 0 {
+			res.minIncl = res.minIncl && other.minIncl
+		}
+	}
+	if other.max != "" {
+		cmp := semver.Compare(other.max, res.max)
+		if res.max == "" || cmp < 0 {
+			res.max, res.maxIncl = other.max, other.maxIncl
+		} else if cmp == 0 {
+			res.maxIncl = res.maxIncl && other.maxIncl
+		}
+	}
+	return res
+}
+
+func (interval versionInterval) empty() bool {
+	if interval.min == "" || interval.max == "" {
+		return false
+	}
+	cmp := semver.Compare(interval.min, interval.max)
+	return cmp > 0 || (cmp == 0 && !(interval.minIncl && interval.maxIncl))
+}
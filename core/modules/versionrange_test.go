@@ -0,0 +1,134 @@
+package modules
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsVersionRange(t *testing.T) {
+	for _, version := range []string{"^1.4", "~1.4.2", ">=1.2 <2", "^1 || ^2", "=v1.0.0"} {
+		require.True(t, IsVersionRange(version), version)
+	}
+	for _, version := range []string{"", "v1.4.0", "main", "feature/foo", "5f4c0e8", "feat=foo", "a|b"} {
+		require.False(t, IsVersionRange(version), version)
+	}
+}
+
+func TestMayBeGitRef(t *testing.T) {
+	for _, version := range []string{">=1.2", "<2", "=v1.0.0", "<1||>=2"} {
+		require.True(t, MayBeGitRef(version), version)
+	}
+	for _, version := range []string{"^1.4", "~1.4.2", ">=1.2 <2", "^1 || ^2", ">=1.", ">=1.2.lock"} {
+		require.False(t, MayBeGitRef(version), version)
+	}
+}
+
+func TestVersionRangeMatches(t *testing.T) {
+	for _, tc := range []struct {
+		rng        string
+		matches    []string
+		notMatches []string
+	}{
+		{
+			rng:        "^1.4",
+			matches:    []string{"v1.4.0", "v1.4.9", "v1.9.0", "1.5.2"},
+			notMatches: []string{"v1.3.9", "v2.0.0", "v1.5.0-rc.1", "main"},
+		},
+		{
+			rng:        "^0.4.2",
+			matches:    []string{"v0.4.2", "v0.4.10"},
+			notMatches: []string{"v0.4.1", "v0.5.0", "v1.0.0"},
+		},
+		{
+			rng:        "^0.0.3",
+			matches:    []string{"v0.0.3"},
+			notMatches: []string{"v0.0.4", "v0.1.0"},
+		},
+		{
+			rng:        "~1.4.2",
+			matches:    []string{"v1.4.2", "v1.4.9"},
+			notMatches: []string{"v1.4.1", "v1.5.0"},
+		},
+		{
+			rng:        "~1",
+			matches:    []string{"v1.0.0", "v1.9.0"},
+			notMatches: []string{"v2.0.0", "v0.9.0"},
+		},
+		{
+			rng:        ">=1.2 <2",
+			matches:    []string{"v1.2.0", "v1.99.0"},
+			notMatches: []string{"v1.1.9", "v2.0.0"},
+		},
+		{
+			rng:        ">= 1.2 <=1.4",
+			matches:    []string{"v1.2.0", "v1.4.7"},
+			notMatches: []string{"v1.5.0"},
+		},
+		{
+			rng:        "^1 || ^3",
+			matches:    []string{"v1.2.0", "v3.0.0"},
+			notMatches: []string{"v2.0.0", "v4.0.0"},
+		},
+		{
+			rng:        "=1.4",
+			matches:    []string{"v1.4.0", "v1.4.3"},
+			notMatches: []string{"v1.5.0"},
+		},
+	} {
+		t.Run(tc.rng, func(t *testing.T) {
+			rng, err := ParseVersionRange(tc.rng)
+			require.NoError(t, err)
+			for _, version := range tc.matches {
+				require.True(t, rng.Matches(version), version)
+			}
+			for _, version := range tc.notMatches {
+				require.False(t, rng.Matches(version), version)
+			}
+		})
+	}
+}
+
+func TestVersionRangeLatest(t *testing.T) {
+	rng, err := ParseVersionRange("^1.4")
+	require.NoError(t, err)
+
+	latest, ok := rng.Latest([]string{"v1.4.0", "v1.10.0", "v1.9.0", "v2.0.0", "v1.11.0-rc.1"})
+	require.True(t, ok)
+	require.Equal(t, "v1.10.0", latest)
+
+	_, ok = rng.Latest([]string{"v1.3.0", "v2.0.0"})
+	require.False(t, ok)
+}
+
+func TestVersionRangeIntersects(t *testing.T) {
+	for _, tc := range []struct {
+		a, b       string
+		intersects bool
+	}{
+		{"^1.4", "^1.6", true},
+		{"^1.4", "~1.4.2", true},
+		{"^1.4", "^2", false},
+		{"<2", ">=2", false},
+		{"<=2", ">=2", true},
+		{"=1.4.0", "^1.4", true},
+		{"=1.3.0", "^1.4", false},
+		{"^1 || ^3", "^3.2", true},
+	} {
+		t.Run(tc.a+" "+tc.b, func(t *testing.T) {
+			a, err := ParseVersionRange(tc.a)
+			require.NoError(t, err)
+			b, err := ParseVersionRange(tc.b)
+			require.NoError(t, err)
+			require.Equal(t, tc.intersects, a.Intersects(b))
+			require.Equal(t, tc.intersects, b.Intersects(a))
+		})
+	}
+}
+
+func TestParseVersionRangeInvalid(t *testing.T) {
+	for _, rng := range []string{"^", "^foo", "^1 ||", ">=1.2 <two"} {
+		_, err := ParseVersionRange(rng)
+		require.Error(t, err, rng)
+	}
+}
@@ -1,6 +1,7 @@
 package core
 
 import (
+	"cmp"
 	"context"
 	"crypto/rand"
 	"errors"
@@ -145,6 +146,12 @@ type ModuleSource struct {
 	ModuleConfigUserFields        modules.ModuleConfigUserFields
 	DisableDefaultFunctionCaching bool
 
+	// The capabilities the module requires as read from the module's dagger.json, nil if it requires all
+	Capabilities *modules.ModuleCapabilities `field:"true" name:"capabilities" doc:"The capabilities the module requires, as declared in its dagger.json. Null if the module declares none, in which case it requires all capabilities."`
+	// The capabilities granted to the module as a dependency, as read from the parent's dagger.json or
+	// set by withGrantedCapabilities, nil if none were recorded
+	GrantedCapabilities *modules.ModuleCapabilities `field:"true" name:"grantedCapabilities" doc:"The capabilities granted to the module when it was installed as a dependency. Null if none were recorded, in which case it is only restricted by the capabilities it declares."`
+
 	// The SDK configuration of the module as read from the module's dagger.json or set by withSDK
 	SDK *SDKConfig `field:"true" name:"sdk" doc:"The SDK configuration of the module."`
 	// The implementation of the SDK with codegen and related operations. Reloaded when SDK changes.
@@ -211,6 +218,13 @@ func (src ModuleSource) Clone() *ModuleSource {
 		src.SDK = src.SDK.Clone()
 	}
 
+	if src.Capabilities != nil {
+		src.Capabilities = src.Capabilities.Clone()
+	}
+	if src.GrantedCapabilities != nil {
+		src.GrantedCapabilities = src.GrantedCapabilities.Clone()
+	}
+
 	origIncludePaths := src.IncludePaths
 	src.IncludePaths = make([]string, len(origIncludePaths))
 	copy(src.IncludePaths, origIncludePaths)
@@ -279,7 +293,7 @@ func (src *ModuleSource) AsString() string {
 		return filepath.Join(src.Local.ContextDirectoryPath, src.SourceRootSubpath)
 
 	case ModuleSourceKindGit:
-		return GitRefString(src.Git.CloneRef, src.SourceRootSubpath, src.Git.Version)
+		return GitRefString(src.Git.CloneRef, src.SourceRootSubpath, cmp.Or(src.Git.VersionRange, src.Git.Version))
 
 	default:
 		return ""
@@ -524,6 +538,12 @@ func (src *ModuleSource) CalcDigest(ctx context.Context) digest.Digest {
 
 	inputs = append(inputs, src.IncludePaths...)
 
+	// Include granted capabilities in digest so the module is enforced against the right grant
+	if caps := src.GrantedCapabilities; caps != nil {
+		inputs = append(inputs, fmt.Sprintf("grantedCapabilities:%v:%t:%t:%t:%t:%t",
+			caps.HostRead, caps.HostSockets, caps.Network, caps.LLM, caps.Exec, caps.Secrets))
+	}
+
 	for _, dep := range src.Dependencies {
 		if dep.Self() == nil {
 			continue
@@ -992,6 +1012,9 @@ type GitModuleSource struct {
 	// The version of the source; may be a branch, tag, or commit hash
 	Version string
 
+	// The semver range the version was resolved from, if any
+	VersionRange string
+
 	// The resolved commit hash of the source
 	Commit string
 	// The fully resolved git ref string of the source
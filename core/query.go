@@ -145,6 +145,18 @@ type Server interface {
 	// The list of connected client IDs
 	Clients() []string
 
+	// The list of active sessions, i.e. main clients and everything they've
+	// started
+	Sessions() []*EngineSession
+
+	// Calls that recently missed the cache across all sessions, used to
+	// explain cache misses
+	CallHistory() *call.History
+
+	// The execs skipped or retried from the debugger across all sessions,
+	// which other sessions run again rather than reusing from the cache
+	DebuggedExecs() *DebuggedExecs
+
 	// Return a client connected to a cloud engine. If bool return is false, the local engine should be used. Session attachables for the returned client will be proxied back to the calling client.
 	CloudEngineClient(
 		ctx context.Context,
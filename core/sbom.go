@@ -0,0 +1,188 @@
+package core
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"io/fs"
+	"path"
+	"slices"
+	"time"
+
+	"github.com/containerd/containerd/v2/core/mount"
+	containerdfs "github.com/containerd/continuity/fs"
+	"github.com/vektah/gqlparser/v2/ast"
+
+	"github.com/dagger/dagger/core/sbom"
+	"github.com/dagger/dagger/dagql"
+	"github.com/dagger/dagger/dagql/call"
+	"github.com/dagger/dagger/engine"
+)
+
+type SBOMFormat string
+
+var SBOMFormats = dagql.NewEnum[SBOMFormat]()
+
+var (
+	SBOMFormatSPDXJSON = SBOMFormats.Register("SPDX_JSON",
+		`SPDX 2.3 JSON`,
+	)
+	SBOMFormatCycloneDXJSON = SBOMFormats.Register("CYCLONEDX_JSON",
+		`CycloneDX 1.5 JSON`,
+	)
+)
+
+func (format SBOMFormat) Type() *ast.Type {
+	return &ast.Type{
+		NamedType: "SBOMFormat",
+		NonNull:   true,
+	}
+}
+
+func (format SBOMFormat) TypeDescription() string {
+	return "Format of a software bill of materials"
+}
+
+func (format SBOMFormat) Decoder() dagql.InputDecoder {
+	return SBOMFormats
+}
+
+func (format SBOMFormat) ToLiteral() call.Literal {
+	return SBOMFormats.Literal(format)
+}
+
+// Filename is the conventional name of SBOM files in the format.
+func (format SBOMFormat) Filename() string {
+	switch format {
+	case SBOMFormatCycloneDXJSON:
+		return "sbom.cdx.json"
+	default:
+		return "sbom.spdx.json"
+	}
+}
+
+// PredicateType is the in-toto predicate type of SBOMs in the format.
+func (format SBOMFormat) PredicateType() string {
+	switch format {
+	case SBOMFormatCycloneDXJSON:
+		return sbom.CycloneDXPredicateType
+	default:
+		return sbom.SPDXPredicateType
+	}
+}
+
+// Encode encodes an SBOM of the given packages in the format.
+func (format SBOMFormat) Encode(name string, distro *sbom.Distro, pkgs []sbom.Package) ([]byte, error) {
+	doc := &sbom.Document{
+		Name:        name,
+		Distro:      distro,
+		Packages:    pkgs,
+		Created:     time.Now(),
+		ToolName:    "dagger",
+		ToolVersion: engine.Version,
+	}
+	switch format {
+	case SBOMFormatSPDXJSON:
+		return doc.SPDX()
+	case SBOMFormatCycloneDXJSON:
+		return doc.CycloneDX()
+	default:
+		return nil, fmt.Errorf("unsupported SBOM format %q", format)
+	}
+}
+
+// Packages returns the OS packages and language dependencies found in the
+// directory.
+func (dir *Directory) Packages(ctx context.Context) (*sbom.Distro, []sbom.Package, error) {
+	res, err := dir.Evaluate(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	ref, err := res.SingleRef()
+	if err != nil {
+		return nil, nil, err
+	}
+	if ref == nil {
+		// scratch
+		return nil, nil, nil
+	}
+	immutableRef, err := ref.CacheRef(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var distro *sbom.Distro
+	var pkgs []sbom.Package
+	err = MountRef(ctx, immutableRef, nil, func(root string, _ *mount.Mount) error {
+		src, err := containerdfs.RootPath(root, dir.Dir)
+		if err != nil {
+			return err
+		}
+		distro, pkgs, err = sbom.Scan(src)
+		return err
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("scan packages: %w", err)
+	}
+	return distro, pkgs, nil
+}
+
+// SBOM returns a software bill of materials of the packages in the
+// directory.
+func (dir *Directory) SBOM(ctx context.Context, format SBOMFormat) (*File, error) {
+	distro, pkgs, err := dir.Packages(ctx)
+	if err != nil {
+		return nil, err
+	}
+	dt, err := format.Encode(path.Base(dir.Dir), distro, pkgs)
+	if err != nil {
+		return nil, err
+	}
+	return NewFileWithContents(ctx, format.Filename(), dt, fs.FileMode(0o644), nil, dir.Platform)
+}
+
+// SBOM returns a software bill of materials of the packages in the
+// container's root filesystem.
+func (container *Container) SBOM(ctx context.Context, format SBOMFormat) (*File, error) {
+	distro, pkgs, err := containerPackages(ctx, container)
+	if err != nil {
+		return nil, err
+	}
+	dt, err := format.Encode(container.ImageRef, distro, pkgs)
+	if err != nil {
+		return nil, err
+	}
+	return NewFileWithContents(ctx, format.Filename(), dt, fs.FileMode(0o644), nil, container.Platform)
+}
+
+// containerPackages returns the packages of the containers' root
+// filesystems. The packages of platform variants are merged, since they're
+// distinguished by their architecture.
+func containerPackages(ctx context.Context, containers ...*Container) (*sbom.Distro, []sbom.Package, error) {
+	var distro *sbom.Distro
+	var pkgs []sbom.Package
+	seen := map[string]bool{}
+	for _, ctr := range containers {
+		if ctr.FS == nil {
+			continue
+		}
+		ctrDistro, ctrPkgs, err := ctr.FS.Self().Packages(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		if distro == nil {
+			distro = ctrDistro
+		}
+		for _, pkg := range ctrPkgs {
+			key := pkg.PURL() + " " + pkg.Location
+			if !seen[key] {
+				seen[key] = true
+				pkgs = append(pkgs, pkg)
+			}
+		}
+	}
+	slices.SortStableFunc(pkgs, func(a, b sbom.Package) int {
+		return cmp.Compare(a.PURL(), b.PURL())
+	})
+	return distro, pkgs, nil
+}
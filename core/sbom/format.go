@@ -0,0 +1,243 @@
+package sbom
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// SPDXMediaType is the media type of SPDX JSON documents.
+	SPDXMediaType = "application/spdx+json"
+	// SPDXPredicateType is the in-toto predicate type of SPDX documents.
+	SPDXPredicateType = "https://spdx.dev/Document"
+
+	// CycloneDXMediaType is the media type of CycloneDX JSON documents.
+	CycloneDXMediaType = "application/vnd.cyclonedx+json"
+	// CycloneDXPredicateType is the in-toto predicate type of CycloneDX
+	// documents.
+	CycloneDXPredicateType = "https://cyclonedx.org/bom"
+)
+
+// Document describes the packages of a filesystem.
+type Document struct {
+	// Name of the described artifact, e.g. an image reference.
+	Name string
+	// Distro of the filesystem, if known.
+	Distro *Distro
+	// Packages found in the filesystem.
+	Packages []Package
+	// Created is when the document was created.
+	Created time.Time
+	// Tool is the name and version of the tool that created the document.
+	ToolName    string
+	ToolVersion string
+}
+
+// namespace deterministically identifies the document, for the parts of the
+// formats that require a unique ID.
+func (doc *Document) namespace() uuid.UUID {
+	var b strings.Builder
+	b.WriteString(doc.Name)
+	for _, pkg := range doc.Packages {
+		b.WriteString("\n")
+		b.WriteString(pkg.PURL())
+		b.WriteString(" ")
+		b.WriteString(pkg.Location)
+	}
+	return uuid.NewSHA1(uuid.NameSpaceURL, []byte(b.String()))
+}
+
+type spdxDocument struct {
+	SPDXVersion       string             `json:"spdxVersion"`
+	DataLicense       string             `json:"dataLicense"`
+	SPDXID            string             `json:"SPDXID"`
+	Name              string             `json:"name"`
+	DocumentNamespace string             `json:"documentNamespace"`
+	CreationInfo      spdxCreationInfo   `json:"creationInfo"`
+	Packages          []spdxPackage      `json:"packages"`
+	Relationships     []spdxRelationship `json:"relationships"`
+}
+
+type spdxCreationInfo struct {
+	Created  string   `json:"created"`
+	Creators []string `json:"creators"`
+}
+
+type spdxPackage struct {
+	Name             string            `json:"name"`
+	SPDXID           string            `json:"SPDXID"`
+	VersionInfo      string            `json:"versionInfo,omitempty"`
+	DownloadLocation string            `json:"downloadLocation"`
+	FilesAnalyzed    bool              `json:"filesAnalyzed"`
+	LicenseConcluded string            `json:"licenseConcluded"`
+	LicenseDeclared  string            `json:"licenseDeclared"`
+	SourceInfo       string            `json:"sourceInfo,omitempty"`
+	PrimaryPurpose   string            `json:"primaryPackagePurpose,omitempty"`
+	ExternalRefs     []spdxExternalRef `json:"externalRefs,omitempty"`
+}
+
+type spdxExternalRef struct {
+	ReferenceCategory string `json:"referenceCategory"`
+	ReferenceType     string `json:"referenceType"`
+	ReferenceLocator  string `json:"referenceLocator"`
+}
+
+type spdxRelationship struct {
+	SPDXElementID      string `json:"spdxElementId"`
+	RelationshipType   string `json:"relationshipType"`
+	RelatedSPDXElement string `json:"relatedSpdxElement"`
+}
+
+const spdxNoAssertion = "NOASSERTION"
+
+// SPDX encodes the document as SPDX 2.3 JSON.
+func (doc *Document) SPDX() ([]byte, error) {
+	out := spdxDocument{
+		SPDXVersion:       "SPDX-2.3",
+		DataLicense:       "CC0-1.0",
+		SPDXID:            "SPDXRef-DOCUMENT",
+		Name:              doc.Name,
+		DocumentNamespace: "urn:uuid:" + doc.namespace().String(),
+		CreationInfo: spdxCreationInfo{
+			Created:  doc.Created.UTC().Format(time.RFC3339),
+			Creators: []string{fmt.Sprintf("Tool: %s-%s", doc.ToolName, doc.ToolVersion)},
+		},
+		Packages:      []spdxPackage{},
+		Relationships: []spdxRelationship{},
+	}
+	if doc.Distro != nil && doc.Distro.ID != "" {
+		out.Packages = append(out.Packages, spdxPackage{
+			Name:             doc.Distro.ID,
+			SPDXID:           "SPDXRef-OperatingSystem",
+			VersionInfo:      doc.Distro.VersionID,
+			DownloadLocation: spdxNoAssertion,
+			LicenseConcluded: spdxNoAssertion,
+			LicenseDeclared:  spdxNoAssertion,
+			PrimaryPurpose:   "OPERATING-SYSTEM",
+		})
+		out.Relationships = append(out.Relationships, spdxRelationship{
+			SPDXElementID:      out.SPDXID,
+			RelationshipType:   "DESCRIBES",
+			RelatedSPDXElement: "SPDXRef-OperatingSystem",
+		})
+	}
+	for i, pkg := range doc.Packages {
+		license := spdxNoAssertion
+		if len(pkg.Licenses) > 0 {
+			license = strings.Join(pkg.Licenses, " AND ")
+		}
+		id := fmt.Sprintf("SPDXRef-Package-%d", i+1)
+		out.Packages = append(out.Packages, spdxPackage{
+			Name:             pkg.Name,
+			SPDXID:           id,
+			VersionInfo:      pkg.Version,
+			DownloadLocation: spdxNoAssertion,
+			LicenseConcluded: spdxNoAssertion,
+			LicenseDeclared:  license,
+			SourceInfo:       "acquired package info from " + pkg.Location,
+			ExternalRefs: []spdxExternalRef{{
+				ReferenceCategory: "PACKAGE-MANAGER",
+				ReferenceType:     "purl",
+				ReferenceLocator:  pkg.PURL(),
+			}},
+		})
+		out.Relationships = append(out.Relationships, spdxRelationship{
+			SPDXElementID:      out.SPDXID,
+			RelationshipType:   "DESCRIBES",
+			RelatedSPDXElement: id,
+		})
+	}
+	return json.MarshalIndent(out, "", "  ")
+}
+
+type cycloneDXBOM struct {
+	BOMFormat    string               `json:"bomFormat"`
+	SpecVersion  string               `json:"specVersion"`
+	SerialNumber string               `json:"serialNumber"`
+	Version      int                  `json:"version"`
+	Metadata     cycloneDXMetadata    `json:"metadata"`
+	Components   []cycloneDXComponent `json:"components"`
+}
+
+type cycloneDXMetadata struct {
+	Timestamp string `json:"timestamp"`
+	Tools     struct {
+		Components []cycloneDXComponent `json:"components"`
+	} `json:"tools"`
+	Component *cycloneDXComponent `json:"component,omitempty"`
+}
+
+type cycloneDXComponent struct {
+	Type       string              `json:"type"`
+	BOMRef     string              `json:"bom-ref,omitempty"`
+	Name       string              `json:"name"`
+	Version    string              `json:"version,omitempty"`
+	PURL       string              `json:"purl,omitempty"`
+	Licenses   []cycloneDXLicense  `json:"licenses,omitempty"`
+	Properties []cycloneDXProperty `json:"properties,omitempty"`
+}
+
+type cycloneDXLicense struct {
+	Expression string `json:"expression"`
+}
+
+type cycloneDXProperty struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// CycloneDX encodes the document as CycloneDX 1.5 JSON.
+func (doc *Document) CycloneDX() ([]byte, error) {
+	out := cycloneDXBOM{
+		BOMFormat:    "CycloneDX",
+		SpecVersion:  "1.5",
+		SerialNumber: "urn:uuid:" + doc.namespace().String(),
+		Version:      1,
+		Metadata: cycloneDXMetadata{
+			Timestamp: doc.Created.UTC().Format(time.RFC3339),
+		},
+		Components: []cycloneDXComponent{},
+	}
+	out.Metadata.Tools.Components = []cycloneDXComponent{{
+		Type:    "application",
+		Name:    doc.ToolName,
+		Version: doc.ToolVersion,
+	}}
+	if doc.Name != "" {
+		out.Metadata.Component = &cycloneDXComponent{
+			Type: "container",
+			Name: doc.Name,
+		}
+	}
+	if doc.Distro != nil && doc.Distro.ID != "" {
+		out.Components = append(out.Components, cycloneDXComponent{
+			Type:    "operating-system",
+			BOMRef:  "os:" + doc.Distro.ID,
+			Name:    doc.Distro.ID,
+			Version: doc.Distro.VersionID,
+		})
+	}
+	for _, pkg := range doc.Packages {
+		purl := pkg.PURL()
+		component := cycloneDXComponent{
+			Type:    "library",
+			BOMRef:  purl + "#" + pkg.Location,
+			Name:    pkg.Name,
+			Version: pkg.Version,
+			PURL:    purl,
+			Properties: []cycloneDXProperty{{
+				Name:  "dagger:location",
+				Value: pkg.Location,
+			}},
+		}
+		for _, license := range pkg.Licenses {
+			component.Licenses = append(component.Licenses, cycloneDXLicense{Expression: license})
+		}
+		out.Components = append(out.Components, component)
+	}
+	return json.MarshalIndent(out, "", "  ")
+}
@@ -0,0 +1,189 @@
+package sbom
+
+import (
+	"bufio"
+	"encoding/json"
+	"regexp"
+	"strings"
+
+	"github.com/pelletier/go-toml"
+	"golang.org/x/mod/modfile"
+)
+
+// lockfileParsers parse the dependencies from language lockfiles, by file
+// name.
+var lockfileParsers = map[string]func([]byte) ([]Package, error){
+	"go.mod":            parseGoMod,
+	"go.sum":            parseGoSum,
+	"package-lock.json": parsePackageLock,
+	"requirements.txt":  parseRequirements,
+	"poetry.lock":       parsePoetryLock,
+	"Cargo.lock":        parseCargoLock,
+}
+
+func parseGoMod(dt []byte) ([]Package, error) {
+	mod, err := modfile.ParseLax("go.mod", dt, nil)
+	if err != nil {
+		return nil, err
+	}
+	pkgs := make([]Package, 0, len(mod.Require))
+	for _, req := range mod.Require {
+		pkgs = append(pkgs, Package{
+			Name:    req.Mod.Path,
+			Version: req.Mod.Version,
+			Type:    "golang",
+		})
+	}
+	return pkgs, nil
+}
+
+// parseGoSum parses the modules whose content is checksummed in a go.sum,
+// i.e. those that were actually downloaded rather than just their go.mod.
+func parseGoSum(dt []byte) ([]Package, error) {
+	var pkgs []Package
+	scanner := bufio.NewScanner(strings.NewReader(string(dt)))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 3 || strings.HasSuffix(fields[1], "/go.mod") {
+			continue
+		}
+		pkgs = append(pkgs, Package{
+			Name:    fields[0],
+			Version: fields[1],
+			Type:    "golang",
+		})
+	}
+	return pkgs, scanner.Err()
+}
+
+type packageLock struct {
+	LockfileVersion int `json:"lockfileVersion"`
+	Packages        map[string]struct {
+		Version string `json:"version"`
+		License any    `json:"license"`
+		Link    bool   `json:"link"`
+	} `json:"packages"`
+	Dependencies map[string]packageLockDep `json:"dependencies"`
+}
+
+type packageLockDep struct {
+	Version      string                    `json:"version"`
+	Dependencies map[string]packageLockDep `json:"dependencies"`
+}
+
+func parsePackageLock(dt []byte) ([]Package, error) {
+	var lock packageLock
+	if err := json.Unmarshal(dt, &lock); err != nil {
+		return nil, err
+	}
+	var pkgs []Package
+	if len(lock.Packages) > 0 {
+		for key, pkg := range lock.Packages {
+			_, name, ok := cutLast(key, "node_modules/")
+			if !ok || pkg.Link || pkg.Version == "" {
+				// the root project, or a link to a workspace package
+				continue
+			}
+			p := Package{
+				Name:    name,
+				Version: pkg.Version,
+				Type:    "npm",
+			}
+			if license, ok := pkg.License.(string); ok {
+				p.Licenses = []string{license}
+			}
+			pkgs = append(pkgs, p)
+		}
+		return pkgs, nil
+	}
+	// lockfile version 1
+	var walk func(map[string]packageLockDep)
+	walk = func(deps map[string]packageLockDep) {
+		for name, dep := range deps {
+			pkgs = append(pkgs, Package{
+				Name:    name,
+				Version: dep.Version,
+				Type:    "npm",
+			})
+			walk(dep.Dependencies)
+		}
+	}
+	walk(lock.Dependencies)
+	return pkgs, nil
+}
+
+func cutLast(s, sep string) (before, after string, found bool) {
+	if i := strings.LastIndex(s, sep); i >= 0 {
+		return s[:i], s[i+len(sep):], true
+	}
+	return s, "", false
+}
+
+var requirementRe = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9._-]*)(?:\[[^\]]*\])?\s*===?\s*([^\s;#]+)`)
+
+// parseRequirements parses the pinned requirements of a pip requirements
+// file. Unpinned requirements don't identify a version, so they're skipped.
+func parseRequirements(dt []byte) ([]Package, error) {
+	var pkgs []Package
+	scanner := bufio.NewScanner(strings.NewReader(string(dt)))
+	for scanner.Scan() {
+		m := requirementRe.FindStringSubmatch(strings.TrimSpace(scanner.Text()))
+		if m == nil {
+			continue
+		}
+		pkgs = append(pkgs, Package{
+			Name:    normalizePythonName(m[1]),
+			Version: m[2],
+			Type:    "pypi",
+		})
+	}
+	return pkgs, scanner.Err()
+}
+
+func normalizePythonName(name string) string {
+	return strings.ToLower(strings.NewReplacer("_", "-", ".", "-").Replace(name))
+}
+
+type tomlLock struct {
+	Package []struct {
+		Name    string `toml:"name"`
+		Version string `toml:"version"`
+		Source  any    `toml:"source"`
+	} `toml:"package"`
+}
+
+func parsePoetryLock(dt []byte) ([]Package, error) {
+	var lock tomlLock
+	if err := toml.Unmarshal(dt, &lock); err != nil {
+		return nil, err
+	}
+	pkgs := make([]Package, 0, len(lock.Package))
+	for _, pkg := range lock.Package {
+		pkgs = append(pkgs, Package{
+			Name:    normalizePythonName(pkg.Name),
+			Version: pkg.Version,
+			Type:    "pypi",
+		})
+	}
+	return pkgs, nil
+}
+
+func parseCargoLock(dt []byte) ([]Package, error) {
+	var lock tomlLock
+	if err := toml.Unmarshal(dt, &lock); err != nil {
+		return nil, err
+	}
+	pkgs := make([]Package, 0, len(lock.Package))
+	for _, pkg := range lock.Package {
+		if pkg.Source == nil {
+			// a crate of the workspace itself
+			continue
+		}
+		pkgs = append(pkgs, Package{
+			Name:    pkg.Name,
+			Version: pkg.Version,
+			Type:    "cargo",
+		})
+	}
+	return pkgs, nil
+}
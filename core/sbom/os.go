@@ -0,0 +1,313 @@
+package sbom
+
+import (
+	"database/sql"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	containerdfs "github.com/containerd/continuity/fs"
+	_ "modernc.org/sqlite"
+)
+
+const apkDatabase = "/lib/apk/db/installed"
+
+// scanAPK reads the packages installed with apk.
+func scanAPK(root string, distro *Distro) ([]Package, error) {
+	dt, err := readFile(root, apkDatabase)
+	if err != nil || dt == nil {
+		return nil, err
+	}
+	namespace := distro.id()
+	if namespace == "" {
+		namespace = "alpine"
+	}
+	var pkgs []Package
+	for _, para := range paragraphs(dt) {
+		pkg := Package{
+			Type:       "apk",
+			Namespace:  namespace,
+			Qualifiers: map[string]string{"distro": distro.qualifier()},
+			Location:   apkDatabase,
+		}
+		for _, line := range para {
+			k, v, ok := strings.Cut(line, ":")
+			if !ok {
+				continue
+			}
+			switch k {
+			case "P":
+				pkg.Name = v
+			case "V":
+				pkg.Version = v
+			case "A":
+				pkg.Qualifiers["arch"] = v
+			case "L":
+				pkg.Licenses = []string{v}
+			}
+		}
+		if pkg.Name != "" {
+			pkgs = append(pkgs, pkg)
+		}
+	}
+	return pkgs, nil
+}
+
+const (
+	dpkgDatabase = "/var/lib/dpkg/status"
+	// dpkgStatusDir holds a status file per package in distroless images.
+	dpkgStatusDir = "/var/lib/dpkg/status.d"
+)
+
+// scanDpkg reads the packages installed with dpkg.
+func scanDpkg(root string, distro *Distro) ([]Package, error) {
+	namespace := distro.id()
+	if namespace == "" {
+		namespace = "debian"
+	}
+	files := []string{dpkgDatabase}
+	statusDir, err := containerdfs.RootPath(root, dpkgStatusDir)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(statusDir)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	for _, entry := range entries {
+		if entry.Type().IsRegular() && !strings.HasSuffix(entry.Name(), ".md5sums") {
+			files = append(files, filepath.Join(dpkgStatusDir, entry.Name()))
+		}
+	}
+
+	var pkgs []Package
+	for _, file := range files {
+		dt, err := readFile(root, file)
+		if err != nil {
+			return nil, err
+		}
+		for _, para := range paragraphs(dt) {
+			pkg := Package{
+				Type:       "deb",
+				Namespace:  namespace,
+				Qualifiers: map[string]string{"distro": distro.qualifier()},
+				Location:   file,
+			}
+			installed := true
+			for _, line := range para {
+				if strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t") {
+					// continuation of a multi-line field
+					continue
+				}
+				k, v, ok := strings.Cut(line, ":")
+				if !ok {
+					continue
+				}
+				v = strings.TrimSpace(v)
+				switch k {
+				case "Package":
+					pkg.Name = v
+				case "Version":
+					pkg.Version = v
+				case "Architecture":
+					pkg.Qualifiers["arch"] = v
+				case "Status":
+					fields := strings.Fields(v)
+					installed = len(fields) > 0 && fields[len(fields)-1] == "installed"
+				}
+			}
+			if pkg.Name != "" && installed {
+				pkgs = append(pkgs, pkg)
+			}
+		}
+	}
+	return pkgs, nil
+}
+
+// rpmDatabases are the locations of the sqlite rpm database.
+var rpmDatabases = []string{
+	"/var/lib/rpm/rpmdb.sqlite",
+	"/usr/lib/sysimage/rpm/rpmdb.sqlite",
+}
+
+// legacyRPMDatabases are the locations of the Berkeley DB and NDB rpm
+// databases, used by e.g. RHEL 8, CentOS 7, Amazon Linux 2 and SUSE. They
+// can't be read, so finding one is an error rather than an SBOM that
+// silently lacks the OS packages.
+var legacyRPMDatabases = []string{
+	"/var/lib/rpm/Packages",
+	"/var/lib/rpm/Packages.db",
+	"/usr/lib/sysimage/rpm/Packages",
+	"/usr/lib/sysimage/rpm/Packages.db",
+}
+
+// scanRPM reads the packages installed with rpm.
+func scanRPM(root string, distro *Distro) ([]Package, error) {
+	for _, dbPath := range rpmDatabases {
+		resolved, err := containerdfs.RootPath(root, dbPath)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := os.Stat(resolved); err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return nil, err
+		}
+		blobs, err := readRPMDatabase(resolved)
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", dbPath, err)
+		}
+		var pkgs []Package
+		for _, blob := range blobs {
+			hdr, err := parseRPMHeader(blob)
+			if err != nil {
+				return nil, fmt.Errorf("read %s: %w", dbPath, err)
+			}
+			if hdr.name == "" || hdr.name == "gpg-pubkey" {
+				continue
+			}
+			version := hdr.version
+			if hdr.release != "" {
+				version += "-" + hdr.release
+			}
+			pkg := Package{
+				Name:      hdr.name,
+				Version:   version,
+				Type:      "rpm",
+				Namespace: distro.id(),
+				Qualifiers: map[string]string{
+					"arch":   hdr.arch,
+					"distro": distro.qualifier(),
+				},
+				Location: dbPath,
+			}
+			if hdr.epoch != 0 {
+				pkg.Qualifiers["epoch"] = strconv.Itoa(hdr.epoch)
+			}
+			if hdr.license != "" {
+				pkg.Licenses = []string{hdr.license}
+			}
+			pkgs = append(pkgs, pkg)
+		}
+		return pkgs, nil
+	}
+	for _, dbPath := range legacyRPMDatabases {
+		resolved, err := containerdfs.RootPath(root, dbPath)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := os.Stat(resolved); err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return nil, err
+		}
+		return nil, fmt.Errorf("read %s: unsupported rpm database format, only sqlite is supported", dbPath)
+	}
+	return nil, nil
+}
+
+func readRPMDatabase(path string) ([][]byte, error) {
+	db, err := sql.Open("sqlite", "file:"+path+"?mode=ro&immutable=1")
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+	rows, err := db.Query("SELECT blob FROM Packages")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var blobs [][]byte
+	for rows.Next() {
+		var blob []byte
+		if err := rows.Scan(&blob); err != nil {
+			return nil, err
+		}
+		blobs = append(blobs, blob)
+	}
+	return blobs, rows.Err()
+}
+
+type rpmHeader struct {
+	name    string
+	version string
+	release string
+	epoch   int
+	arch    string
+	license string
+}
+
+const (
+	rpmTagName    = 1000
+	rpmTagVersion = 1001
+	rpmTagRelease = 1002
+	rpmTagEpoch   = 1003
+	rpmTagLicense = 1014
+	rpmTagArch    = 1022
+
+	rpmTypeInt32  = 4
+	rpmTypeString = 6
+	rpmTypeI18N   = 9
+)
+
+// parseRPMHeader parses the header of a package as stored in the rpm
+// database: a count of index entries and the size of the data, followed by
+// the index entries and the data they point into.
+func parseRPMHeader(blob []byte) (*rpmHeader, error) {
+	if len(blob) < 8 {
+		return nil, errors.New("rpm header too short")
+	}
+	count := int(binary.BigEndian.Uint32(blob[0:4]))
+	size := int(binary.BigEndian.Uint32(blob[4:8]))
+	indexEnd := 8 + count*16
+	if count < 0 || size < 0 || indexEnd+size > len(blob) {
+		return nil, errors.New("rpm header truncated")
+	}
+	data := blob[indexEnd : indexEnd+size]
+
+	hdr := &rpmHeader{}
+	for i := range count {
+		entry := blob[8+i*16 : 8+(i+1)*16]
+		tag := binary.BigEndian.Uint32(entry[0:4])
+		typ := binary.BigEndian.Uint32(entry[4:8])
+		offset := int(binary.BigEndian.Uint32(entry[8:12]))
+		if offset < 0 || offset >= len(data) {
+			continue
+		}
+		var str string
+		switch typ {
+		case rpmTypeString, rpmTypeI18N:
+			end := offset
+			for end < len(data) && data[end] != 0 {
+				end++
+			}
+			str = string(data[offset:end])
+		case rpmTypeInt32:
+			if tag == rpmTagEpoch && offset+4 <= len(data) {
+				hdr.epoch = int(binary.BigEndian.Uint32(data[offset : offset+4]))
+			}
+			continue
+		default:
+			continue
+		}
+		switch tag {
+		case rpmTagName:
+			hdr.name = str
+		case rpmTagVersion:
+			hdr.version = str
+		case rpmTagRelease:
+			hdr.release = str
+		case rpmTagLicense:
+			hdr.license = str
+		case rpmTagArch:
+			hdr.arch = str
+		}
+	}
+	return hdr, nil
+}
@@ -0,0 +1,297 @@
+// Package sbom builds software bills of materials by detecting the packages
+// installed in a filesystem, without running any external scanner.
+package sbom
+
+import (
+	"bufio"
+	"cmp"
+	"errors"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	containerdfs "github.com/containerd/continuity/fs"
+)
+
+// Package is a software package found in a filesystem.
+type Package struct {
+	// Name of the package, as known to its ecosystem.
+	Name string
+	// Version of the package, as known to its ecosystem.
+	Version string
+	// Type is the package URL type of its ecosystem, e.g. "deb" or "npm".
+	Type string
+	// Namespace is the package URL namespace, e.g. the distro for OS
+	// packages.
+	Namespace string
+	// Qualifiers are extra package URL qualifiers, e.g. the architecture.
+	Qualifiers map[string]string
+	// Licenses are the declared licenses of the package, if known.
+	Licenses []string
+	// Location is the path of the file the package was found in.
+	Location string
+}
+
+// PURL returns the package URL of the package.
+func (pkg Package) PURL() string {
+	var b strings.Builder
+	b.WriteString("pkg:")
+	b.WriteString(pkg.Type)
+	b.WriteString("/")
+	if pkg.Namespace != "" {
+		b.WriteString(purlEscape(pkg.Namespace))
+		b.WriteString("/")
+	}
+	name := pkg.Name
+	if pkg.Type == "npm" || pkg.Type == "golang" {
+		// the namespace is part of the name, e.g. @scope/name
+		segments := strings.Split(name, "/")
+		for i, s := range segments {
+			segments[i] = purlEscape(s)
+		}
+		b.WriteString(strings.Join(segments, "/"))
+	} else {
+		b.WriteString(purlEscape(name))
+	}
+	if pkg.Version != "" {
+		b.WriteString("@")
+		b.WriteString(purlEscape(pkg.Version))
+	}
+	if len(pkg.Qualifiers) > 0 {
+		keys := make([]string, 0, len(pkg.Qualifiers))
+		for k, v := range pkg.Qualifiers {
+			if v != "" {
+				keys = append(keys, k)
+			}
+		}
+		slices.Sort(keys)
+		for i, k := range keys {
+			if i == 0 {
+				b.WriteString("?")
+			} else {
+				b.WriteString("&")
+			}
+			b.WriteString(k)
+			b.WriteString("=")
+			b.WriteString(purlEscape(pkg.Qualifiers[k]))
+		}
+	}
+	return b.String()
+}
+
+func purlEscape(s string) string {
+	var b strings.Builder
+	for _, c := range []byte(s) {
+		switch {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9',
+			c == '.', c == '-', c == '_', c == '~':
+			b.WriteByte(c)
+		default:
+			b.WriteString("%")
+			b.WriteByte("0123456789ABCDEF"[c>>4])
+			b.WriteByte("0123456789ABCDEF"[c&15])
+		}
+	}
+	return b.String()
+}
+
+// Distro is the OS distribution of a filesystem, from /etc/os-release.
+type Distro struct {
+	ID        string
+	VersionID string
+	Name      string
+}
+
+// Scan returns the packages found in the filesystem at root: OS packages
+// installed with apk, dpkg or rpm, and the dependencies in language lockfiles
+// anywhere in the tree.
+func Scan(root string) (*Distro, []Package, error) {
+	distro, err := readDistro(root)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var pkgs []Package
+	for _, scan := range []func(string, *Distro) ([]Package, error){
+		scanAPK,
+		scanDpkg,
+		scanRPM,
+	} {
+		found, err := scan(root, distro)
+		if err != nil {
+			return nil, nil, err
+		}
+		pkgs = append(pkgs, found...)
+	}
+
+	found, err := scanLockfiles(root)
+	if err != nil {
+		return nil, nil, err
+	}
+	pkgs = append(pkgs, found...)
+
+	slices.SortStableFunc(pkgs, func(a, b Package) int {
+		return cmp.Or(
+			cmp.Compare(a.Type, b.Type),
+			cmp.Compare(a.Name, b.Name),
+			cmp.Compare(a.Version, b.Version),
+			cmp.Compare(a.Location, b.Location),
+		)
+	})
+	return distro, pkgs, nil
+}
+
+// skippedDirs are not searched for lockfiles, since they're either virtual
+// or hold the dependencies themselves rather than a project.
+var skippedDirs = map[string]bool{
+	"proc":         true,
+	"sys":          true,
+	"dev":          true,
+	".git":         true,
+	"node_modules": true,
+}
+
+func scanLockfiles(root string) ([]Package, error) {
+	var pkgs []Package
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, os.ErrPermission) || errors.Is(err, os.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() {
+			if p != root && skippedDirs[d.Name()] {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		parse, ok := lockfileParsers[d.Name()]
+		if !ok {
+			return nil
+		}
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+		dt, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		found, err := parse(dt)
+		if err != nil {
+			// a malformed lockfile shouldn't fail the whole scan
+			return nil
+		}
+		for i := range found {
+			found[i].Location = "/" + filepath.ToSlash(rel)
+		}
+		pkgs = append(pkgs, found...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// lockfiles of the same project may list the same packages, e.g. go.mod
+	// and go.sum
+	seen := map[string]bool{}
+	return slices.DeleteFunc(pkgs, func(pkg Package) bool {
+		key := path.Dir(pkg.Location) + " " + pkg.PURL()
+		if seen[key] {
+			return true
+		}
+		seen[key] = true
+		return false
+	}), nil
+}
+
+// readFile reads a file in the filesystem at root, resolving symlinks within
+// root. It returns nil if the file doesn't exist.
+func readFile(root, p string) ([]byte, error) {
+	resolved, err := containerdfs.RootPath(root, p)
+	if err != nil {
+		return nil, err
+	}
+	dt, err := os.ReadFile(resolved)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	return dt, err
+}
+
+func readDistro(root string) (*Distro, error) {
+	for _, p := range []string{"/etc/os-release", "/usr/lib/os-release"} {
+		dt, err := readFile(root, p)
+		if err != nil {
+			return nil, err
+		}
+		if dt == nil {
+			continue
+		}
+		distro := &Distro{}
+		scanner := bufio.NewScanner(strings.NewReader(string(dt)))
+		for scanner.Scan() {
+			k, v, ok := strings.Cut(scanner.Text(), "=")
+			if !ok {
+				continue
+			}
+			v = strings.Trim(v, `"'`)
+			switch k {
+			case "ID":
+				distro.ID = v
+			case "VERSION_ID":
+				distro.VersionID = v
+			case "PRETTY_NAME":
+				distro.Name = v
+			}
+		}
+		return distro, nil
+	}
+	return nil, nil
+}
+
+func (distro *Distro) id() string {
+	if distro == nil {
+		return ""
+	}
+	return distro.ID
+}
+
+// qualifier is the package URL qualifier for the distro of OS packages.
+func (distro *Distro) qualifier() string {
+	if distro == nil || distro.ID == "" {
+		return ""
+	}
+	if distro.VersionID == "" {
+		return distro.ID
+	}
+	return distro.ID + "-" + distro.VersionID
+}
+
+// paragraphs splits a file into blocks separated by blank lines, as used by
+// the apk and dpkg databases.
+func paragraphs(dt []byte) [][]string {
+	var paras [][]string
+	var cur []string
+	for line := range strings.SplitSeq(string(dt), "\n") {
+		if strings.TrimSpace(line) == "" {
+			if len(cur) > 0 {
+				paras = append(paras, cur)
+				cur = nil
+			}
+			continue
+		}
+		cur = append(cur, line)
+	}
+	if len(cur) > 0 {
+		paras = append(paras, cur)
+	}
+	return paras
+}
@@ -0,0 +1,259 @@
+package sbom
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/binary"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func writeFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for p, content := range files {
+		p = filepath.Join(root, p)
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), 0o755))
+		require.NoError(t, os.WriteFile(p, []byte(content), 0o644))
+	}
+}
+
+func purls(pkgs []Package) []string {
+	var out []string
+	for _, pkg := range pkgs {
+		out = append(out, pkg.PURL())
+	}
+	return out
+}
+
+func TestScan(t *testing.T) {
+	t.Run("alpine", func(t *testing.T) {
+		root := t.TempDir()
+		writeFiles(t, root, map[string]string{
+			"etc/os-release": "ID=alpine\nVERSION_ID=3.20.1\nPRETTY_NAME=\"Alpine Linux v3.20\"\n",
+			"lib/apk/db/installed": `C:Q1abc
+P:musl
+V:1.2.5-r0
+A:x86_64
+L:MIT
+
+P:busybox
+V:1.36.1-r29
+A:x86_64
+L:GPL-2.0-only
+`,
+			"app/go.mod": `module example.com/app
+
+go 1.22
+
+require (
+	github.com/pkg/errors v0.9.1
+	golang.org/x/sys v0.20.0 // indirect
+)
+`,
+			"app/go.sum": `github.com/pkg/errors v0.9.1 h1:abc=
+github.com/pkg/errors v0.9.1/go.mod h1:def=
+github.com/other/mod v1.0.0/go.mod h1:ghi=
+`,
+			"app/node_modules/left-pad/package-lock.json": `{"packages":{"node_modules/ignored":{"version":"1.0.0"}}}`,
+		})
+
+		distro, pkgs, err := Scan(root)
+		require.NoError(t, err)
+		require.Equal(t, &Distro{ID: "alpine", VersionID: "3.20.1", Name: "Alpine Linux v3.20"}, distro)
+		require.Equal(t, []string{
+			"pkg:apk/alpine/busybox@1.36.1-r29?arch=x86_64&distro=alpine-3.20.1",
+			"pkg:apk/alpine/musl@1.2.5-r0?arch=x86_64&distro=alpine-3.20.1",
+			"pkg:golang/github.com/pkg/errors@v0.9.1",
+			"pkg:golang/golang.org/x/sys@v0.20.0",
+		}, purls(pkgs))
+		require.Equal(t, []string{"GPL-2.0-only"}, pkgs[0].Licenses)
+		require.Equal(t, "/lib/apk/db/installed", pkgs[0].Location)
+		require.Equal(t, "/app/go.mod", pkgs[2].Location)
+	})
+
+	t.Run("debian", func(t *testing.T) {
+		root := t.TempDir()
+		writeFiles(t, root, map[string]string{
+			"etc/os-release": "ID=debian\nVERSION_ID=\"12\"\n",
+			"var/lib/dpkg/status": `Package: libc6
+Status: install ok installed
+Architecture: amd64
+Version: 2.36-9+deb12u7
+Description: GNU C Library
+ multi-line description
+
+Package: removed
+Status: deinstall ok config-files
+Architecture: amd64
+Version: 1.0
+`,
+			"var/lib/dpkg/status.d/base-files": "Package: base-files\nArchitecture: amd64\nVersion: 12.4\n",
+			"srv/package-lock.json": `{
+  "lockfileVersion": 3,
+  "packages": {
+    "": {"name": "srv"},
+    "node_modules/@scope/pkg": {"version": "1.2.3", "license": "MIT"},
+    "node_modules/a/node_modules/b": {"version": "0.1.0"},
+    "node_modules/local": {"link": true}
+  }
+}`,
+			"srv/requirements.txt": "# deps\nRequests[socks]==2.31.0 ; python_version > '3'\nflask>=2\nNumPy===1.26.4\n",
+			"srv/poetry.lock":      "[[package]]\nname = \"Typing_Extensions\"\nversion = \"4.9.0\"\n",
+			"srv/Cargo.lock": `version = 3
+
+[[package]]
+name = "srv"
+version = "0.1.0"
+
+[[package]]
+name = "serde"
+version = "1.0.197"
+source = "registry+https://github.com/rust-lang/crates.io-index"
+`,
+		})
+
+		_, pkgs, err := Scan(root)
+		require.NoError(t, err)
+		require.Equal(t, []string{
+			"pkg:cargo/serde@1.0.197",
+			"pkg:deb/debian/base-files@12.4?arch=amd64&distro=debian-12",
+			"pkg:deb/debian/libc6@2.36-9%2Bdeb12u7?arch=amd64&distro=debian-12",
+			"pkg:npm/%40scope/pkg@1.2.3",
+			"pkg:npm/b@0.1.0",
+			"pkg:pypi/numpy@1.26.4",
+			"pkg:pypi/requests@2.31.0",
+			"pkg:pypi/typing-extensions@4.9.0",
+		}, purls(pkgs))
+	})
+
+	t.Run("rpm", func(t *testing.T) {
+		root := t.TempDir()
+		writeFiles(t, root, map[string]string{
+			"etc/os-release": "ID=fedora\nVERSION_ID=40\n",
+		})
+		dbPath := filepath.Join(root, "usr/lib/sysimage/rpm/rpmdb.sqlite")
+		require.NoError(t, os.MkdirAll(filepath.Dir(dbPath), 0o755))
+		db, err := sql.Open("sqlite", dbPath)
+		require.NoError(t, err)
+		_, err = db.Exec("CREATE TABLE Packages (hnum INTEGER PRIMARY KEY AUTOINCREMENT, blob BLOB NOT NULL)")
+		require.NoError(t, err)
+		for _, blob := range [][]byte{
+			rpmHeaderBlob(t, "bash", "5.2.26", "3.fc40", "x86_64", "GPL-3.0-or-later", 0),
+			rpmHeaderBlob(t, "shadow-utils", "4.15.1", "1.fc40", "x86_64", "BSD-3-Clause", 2),
+			rpmHeaderBlob(t, "gpg-pubkey", "a15b79cc", "63d04c2c", "", "pubkey", 0),
+		} {
+			_, err = db.Exec("INSERT INTO Packages (blob) VALUES (?)", blob)
+			require.NoError(t, err)
+		}
+		require.NoError(t, db.Close())
+
+		_, pkgs, err := Scan(root)
+		require.NoError(t, err)
+		require.Equal(t, []string{
+			"pkg:rpm/fedora/bash@5.2.26-3.fc40?arch=x86_64&distro=fedora-40",
+			"pkg:rpm/fedora/shadow-utils@4.15.1-1.fc40?arch=x86_64&distro=fedora-40&epoch=2",
+		}, purls(pkgs))
+		require.Equal(t, []string{"GPL-3.0-or-later"}, pkgs[0].Licenses)
+	})
+
+	t.Run("legacy rpm database", func(t *testing.T) {
+		root := t.TempDir()
+		writeFiles(t, root, map[string]string{
+			"etc/os-release":       "ID=centos\nVERSION_ID=7\n",
+			"var/lib/rpm/Packages": "not sqlite",
+		})
+		_, _, err := Scan(root)
+		require.ErrorContains(t, err, "read /var/lib/rpm/Packages: unsupported rpm database format")
+	})
+}
+
+// rpmHeaderBlob builds a package header as stored in the rpm database.
+func rpmHeaderBlob(t *testing.T, name, version, release, arch, license string, epoch int) []byte {
+	t.Helper()
+	var index, data bytes.Buffer
+	var count int
+	addEntry := func(tag, typ uint32, value []byte) {
+		count++
+		for _, v := range []uint32{tag, typ, uint32(data.Len()), 1} {
+			require.NoError(t, binary.Write(&index, binary.BigEndian, v))
+		}
+		data.Write(value)
+	}
+	for tag, value := range map[uint32]string{
+		rpmTagName:    name,
+		rpmTagVersion: version,
+		rpmTagRelease: release,
+		rpmTagArch:    arch,
+		rpmTagLicense: license,
+	} {
+		addEntry(tag, rpmTypeString, append([]byte(value), 0))
+	}
+	if epoch != 0 {
+		addEntry(rpmTagEpoch, rpmTypeInt32, binary.BigEndian.AppendUint32(nil, uint32(epoch)))
+	}
+	var blob bytes.Buffer
+	require.NoError(t, binary.Write(&blob, binary.BigEndian, uint32(count)))
+	require.NoError(t, binary.Write(&blob, binary.BigEndian, uint32(data.Len())))
+	blob.Write(index.Bytes())
+	blob.Write(data.Bytes())
+	return blob.Bytes()
+}
+
+func TestDocument(t *testing.T) {
+	doc := &Document{
+		Name:   "registry.example.com/app",
+		Distro: &Distro{ID: "alpine", VersionID: "3.20.1"},
+		Packages: []Package{{
+			Name:       "musl",
+			Version:    "1.2.5-r0",
+			Type:       "apk",
+			Namespace:  "alpine",
+			Qualifiers: map[string]string{"arch": "x86_64"},
+			Licenses:   []string{"MIT"},
+			Location:   "/lib/apk/db/installed",
+		}},
+		Created:     time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		ToolName:    "dagger",
+		ToolVersion: "v0.0.0",
+	}
+
+	t.Run("spdx", func(t *testing.T) {
+		dt, err := doc.SPDX()
+		require.NoError(t, err)
+		var out spdxDocument
+		require.NoError(t, json.Unmarshal(dt, &out))
+		require.Equal(t, "SPDX-2.3", out.SPDXVersion)
+		require.Equal(t, "2024-01-02T03:04:05Z", out.CreationInfo.Created)
+		require.Equal(t, []string{"Tool: dagger-v0.0.0"}, out.CreationInfo.Creators)
+		require.Len(t, out.Packages, 2)
+		require.Equal(t, "OPERATING-SYSTEM", out.Packages[0].PrimaryPurpose)
+		require.Equal(t, "musl", out.Packages[1].Name)
+		require.Equal(t, "MIT", out.Packages[1].LicenseDeclared)
+		require.Equal(t, "pkg:apk/alpine/musl@1.2.5-r0?arch=x86_64", out.Packages[1].ExternalRefs[0].ReferenceLocator)
+		require.Len(t, out.Relationships, 2)
+
+		// the document is deterministic
+		again, err := doc.SPDX()
+		require.NoError(t, err)
+		require.Equal(t, dt, again)
+	})
+
+	t.Run("cyclonedx", func(t *testing.T) {
+		dt, err := doc.CycloneDX()
+		require.NoError(t, err)
+		var out cycloneDXBOM
+		require.NoError(t, json.Unmarshal(dt, &out))
+		require.Equal(t, "CycloneDX", out.BOMFormat)
+		require.Equal(t, "1.5", out.SpecVersion)
+		require.Equal(t, "registry.example.com/app", out.Metadata.Component.Name)
+		require.Len(t, out.Components, 2)
+		require.Equal(t, "operating-system", out.Components[0].Type)
+		require.Equal(t, "pkg:apk/alpine/musl@1.2.5-r0?arch=x86_64", out.Components[1].PURL)
+		require.Equal(t, []cycloneDXLicense{{Expression: "MIT"}}, out.Components[1].Licenses)
+	})
+}
@@ -396,6 +396,10 @@ func (s *addressSchema) secret(
 	inst dagql.ObjectResult[*core.Secret],
 	err error,
 ) {
+	if err := checkSecretsCapability(ctx); err != nil {
+		return inst, err
+	}
+
 	var cacheKey string
 	addr := r.Self().Value
 	// MY_SECRET -> env://MY_SECRET
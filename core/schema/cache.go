@@ -3,6 +3,7 @@ package schema
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"github.com/dagger/dagger/core"
 	"github.com/dagger/dagger/dagql"
@@ -26,7 +27,15 @@ func (s *cacheSchema) Install(srv *dagql.Server) {
 			),
 	}.Install(srv)
 
-	dagql.Fields[*core.CacheVolume]{}.Install(srv)
+	dagql.Fields[*core.CacheVolume]{
+		dagql.Func("pin", s.pin).
+			DoNotCache("Mutates the engine's local cache").
+			Doc(`Pin the contents of this cache volume, so that they're never pruned by garbage collection.`,
+				`Contents written to the volume later are pinned too, until it's unpinned. Only the main client can pin a cache volume.`),
+		dagql.Func("unpin", s.unpin).
+			DoNotCache("Mutates the engine's local cache").
+			Doc(`Unpin the contents of this cache volume, so that they can be pruned by garbage collection again.`),
+	}.Install(srv)
 }
 
 func (s *cacheSchema) Dependencies() []SchemaResolvers {
@@ -67,7 +76,9 @@ func (s *cacheSchema) cacheVolume(ctx context.Context, parent dagql.ObjectResult
 	}
 
 	if args.Namespace != "" {
-		return dagql.NewResultForCurrentID(ctx, core.NewCache(args.Namespace+":"+args.Key))
+		vol := core.NewCache(args.Namespace + ":" + args.Key)
+		vol.Key = args.Key
+		return dagql.NewResultForCurrentID(ctx, vol)
 	}
 
 	m, err := parent.Self().CurrentModule(ctx)
@@ -115,3 +126,19 @@ func namespaceFromModule(m *core.Module) string {
 
 	return "mod(" + name + symbolic + ")"
 }
+
+func (s *cacheSchema) pin(ctx context.Context, parent *core.CacheVolume, args struct{}) (dagql.Nullable[core.Void], error) {
+	void := dagql.Null[core.Void]()
+	if err := parent.SetPinned(ctx, true); err != nil {
+		return void, fmt.Errorf("failed to pin cache volume: %w", err)
+	}
+	return void, nil
+}
+
+func (s *cacheSchema) unpin(ctx context.Context, parent *core.CacheVolume, args struct{}) (dagql.Nullable[core.Void], error) {
+	void := dagql.Null[core.Void]()
+	if err := parent.SetPinned(ctx, false); err != nil {
+		return void, fmt.Errorf("failed to unpin cache volume: %w", err)
+	}
+	return void, nil
+}
@@ -36,6 +36,7 @@ import (
 
 	"github.com/dagger/dagger/core"
 	"github.com/dagger/dagger/dagql"
+	"github.com/dagger/dagger/engine"
 	"github.com/dagger/dagger/engine/buildkit"
 	"github.com/dagger/dagger/engine/slog"
 )
@@ -454,6 +455,22 @@ func (s *containerSchema) Install(srv *dagql.Server) {
 				dagql.Arg("doNotFollowSymlinks").Doc(`If specified, do not follow symlinks.`),
 			),
 
+		dagql.Func("sbom", s.sbom).
+			Doc(`Return a software bill of materials (SBOM) of the packages in the container's root filesystem.`,
+				`OS packages installed with apk, dpkg or rpm are detected, as well as
+				the dependencies in Go, npm, Python and Cargo lockfiles. Mounts are not
+				scanned.`).
+			Args(
+				dagql.Arg("format").Doc(`Format of the SBOM.`),
+			),
+
+		dagql.NodeFunc("compare", s.compare).
+			Doc(`Compare this container's image configuration, layers and root filesystem with another container, typically a newer version of it.`,
+				`Layers that haven't been exported yet are compressed to compute their digest and size.`).
+			Args(
+				dagql.Arg("other").Doc(`The newer container to compare against.`),
+			),
+
 		dagql.NodeFunc("withError", s.withError).
 			Doc(`Raise an error.`).
 			Args(
@@ -502,6 +519,12 @@ func (s *containerSchema) Install(srv *dagql.Server) {
 					`Skip the automatic init process injected into containers by default.`,
 					`Only use this if you specifically need the command to be pid 1 in the container. Otherwise it may result in unexpected behavior. If you're not sure, you don't need this.`,
 				),
+				dagql.Arg("networkMode").Doc(
+					`Restrict the network access of the command.`,
+					`Blocked connection attempts are reported as events on the command's span.`),
+				dagql.Arg("egressAllowlist").Doc(
+					`Hostnames and CIDRs the command may connect to, on top of what its network mode allows (e.g., ["proxy.golang.org", "10.0.0.0/8"]).`,
+					`Hostnames are resolved when the command starts. Requires a networkMode of NONE or SERVICES_ONLY.`),
 			),
 
 		dagql.Func("stdout", s.stdout).
@@ -551,7 +574,17 @@ func (s *containerSchema) Install(srv *dagql.Server) {
 				dagql.Arg("name").Doc(`The name of the annotation.`),
 			),
 
-		dagql.Func("publish", s.publish).
+		dagql.Func("withLayerBoundary", s.withLayerBoundary).
+			Doc(`Retrieves this container with a layer boundary at the current state of its root filesystem.`,
+				`When the container is published or exported, the changes between
+				consecutive boundaries are grouped into a single layer, on top of the
+				layers of the image the container was created from. Changes after the
+				last boundary make a layer of their own.`),
+
+		dagql.NodeFunc("__layeredRootfs", DagOpDirectoryWrapper(srv, s.layeredRootfs, WithStaticPath[*core.Container, containerLayeredRootfsArgs]("/"))).
+			Doc(`(Internal-only) The root filesystem with its layers grouped at the layer boundaries, or squashed.`),
+
+		dagql.NodeFunc("publish", s.publish).
 			DoNotCache("side effect on an external system (OCI registry)").
 			Doc(`Package the container state as an OCI image, and publish it to a registry`,
 				`Returns the fully qualified address of the published image, with digest`).
@@ -575,12 +608,31 @@ func (s *containerSchema) Install(srv *dagql.Server) {
 					`Defaults to "OCI", which is compatible with most recent
 				registries, but "Docker" may be needed for older registries without OCI
 				support.`),
+				dagql.Arg("signingKey").Doc(
+					`Private key to sign the image with, in PEM format.`,
+					`Both keys generated by "cosign generate-key-pair" and unencrypted
+					PKCS#8, EC and RSA keys are supported. The signature, and the
+					provenance attestation if requested, are attached to the image as
+					OCI referrers in the sigstore bundle format.`),
+				dagql.Arg("signingKeyPassword").Doc(
+					`Password of the signing key, if it's encrypted.`),
+				dagql.Arg("provenance").Doc(
+					`Attach a SLSA provenance attestation describing the calls that
+					built the image.`),
+				dagql.Arg("sbom").Doc(
+					`Attach a software bill of materials (SBOM) attestation in the given
+					format, as returned by "sbom".`),
+				dagql.Arg("squash").Doc(
+					`Squash the layers of the published image.`,
+					`By default, the changes between each layer boundary set with
+					"withLayerBoundary" are grouped into a single layer, and each
+					operation makes its own layer if there are no boundaries.`),
 			),
 
 		dagql.Func("platform", s.platform).
 			Doc(`The platform this container executes and publishes as.`),
 
-		dagql.Func("export", s.export).
+		dagql.NodeFunc("export", s.export).
 			View(AllVersion).
 			DoNotCache("Writes to the local host.").
 			Doc(`Writes the container as an OCI tarball to the destination file path on the host.`,
@@ -607,8 +659,27 @@ func (s *containerSchema) Install(srv *dagql.Server) {
 				dagql.Arg("expand").Doc(
 					`Replace "${VAR}" or "$VAR" in the value of path according to the current `+
 						`environment variables defined in the container (e.g. "/$VAR/foo").`),
+				dagql.Arg("signingKey").Doc(
+					`Private key to sign the image with, in PEM format.`,
+					`Both keys generated by "cosign generate-key-pair" and unencrypted
+					PKCS#8, EC and RSA keys are supported. The signature, and the
+					provenance attestation if requested, are attached to the image as
+					OCI referrers in the sigstore bundle format.`),
+				dagql.Arg("signingKeyPassword").Doc(
+					`Password of the signing key, if it's encrypted.`),
+				dagql.Arg("provenance").Doc(
+					`Attach a SLSA provenance attestation describing the calls that
+					built the image.`),
+				dagql.Arg("sbom").Doc(
+					`Attach a software bill of materials (SBOM) attestation in the given
+					format, as returned by "sbom".`),
+				dagql.Arg("squash").Doc(
+					`Squash the layers of the exported image.`,
+					`By default, the changes between each layer boundary set with
+					"withLayerBoundary" are grouped into a single layer, and each
+					operation makes its own layer if there are no boundaries.`),
 			),
-		dagql.Func("export", s.exportLegacy).
+		dagql.NodeFunc("export", s.exportLegacy).
 			View(BeforeVersion("v0.12.0")).
 			Extend(),
 
@@ -750,6 +821,12 @@ func (s *containerSchema) Install(srv *dagql.Server) {
 				guarantees when using this option. It should only be used when
 				absolutely necessary and only with trusted commands.`),
 			),
+		dagql.NodeFunc("withBreakpoint", s.withBreakpoint).
+			DoNotCache("Pauses every time it's evaluated and then returns the original parent.").
+			Doc(`Pauses the pipeline when this container is evaluated, opening an interactive terminal for it.`,
+				`The pipeline continues when the terminal exits. Breakpoints only pause when
+				the CLI runs with --interactive or --break-on, so they can be left in code,
+				and they don't affect the cache of the pipeline.`),
 		dagql.NodeFunc("terminal", s.terminalLegacy).
 			View(BeforeVersion("v0.12.0")).
 			Doc(`Opens an interactive terminal for this container using its configured default terminal command if not overridden by args (or sh as a fallback default).`).
@@ -779,6 +856,16 @@ func (s *containerSchema) Install(srv *dagql.Server) {
 				`This currently works for Nvidia devices only.`),
 	}.Install(srv)
 
+	dagql.Fields[*core.ContainerComparison]{
+		dagql.NodeFunc("changes", s.comparisonChanges).
+			Doc(`The changes between the root filesystems of the containers.`),
+
+		dagql.NodeFunc("summary", s.comparisonSummary).
+			Doc(`A human-readable summary of the comparison.`),
+	}.Install(srv)
+	dagql.Fields[*core.ContainerConfigChange]{}.Install(srv)
+	dagql.Fields[*core.ContainerLayerComparison]{}.Install(srv)
+
 	dagql.Fields[*core.TerminalLegacy]{
 		Syncer[*core.TerminalLegacy]().
 			Doc(`Forces evaluation of the pipeline in the engine.`,
@@ -994,6 +1081,26 @@ func (s *containerSchema) withError(ctx context.Context, parent dagql.ObjectResu
 	return parent, errors.New(args.Err)
 }
 
+// checkExecCapabilities returns an error if the current client is a module
+// that may not run a command with the given options.
+func checkExecCapabilities(ctx context.Context, privilegedNesting bool, networkMode core.NetworkMode) error {
+	query, err := core.CurrentQuery(ctx)
+	if err != nil {
+		return err
+	}
+	if privilegedNesting {
+		if err := core.CheckModuleCapability(ctx, query, core.ModuleCapabilityExec); err != nil {
+			return err
+		}
+	}
+	if networkMode == core.NetworkModeFull || networkMode == "" {
+		if err := core.CheckModuleCapability(ctx, query, core.ModuleCapabilityNetwork); err != nil {
+			return fmt.Errorf("%w (or restrict the command with networkMode)", err)
+		}
+	}
+	return nil
+}
+
 func (s *containerSchema) withExec(ctx context.Context, parent dagql.ObjectResult[*core.Container], args containerExecArgs) (inst dagql.ObjectResult[*core.Container], _ error) {
 	ctr := parent.Self().Clone()
 
@@ -1007,6 +1114,14 @@ func (s *containerSchema) withExec(ctx context.Context, parent dagql.ObjectResul
 	}
 
 	if !args.IsDagOp {
+		// execs set up by the engine, e.g. to run module functions, are not
+		// subject to the capabilities of the calling module
+		if args.ExecMD.Self == nil {
+			if err := checkExecCapabilities(ctx, args.ExperimentalPrivilegedNesting, args.NetworkMode); err != nil {
+				return inst, err
+			}
+		}
+
 		ctr.Meta = nil
 		ctr, err := DagOpContainer(ctx, srv, ctr, args, s.withExec)
 		if err != nil {
@@ -1038,7 +1153,11 @@ func (s *containerSchema) withExec(ctx context.Context, parent dagql.ObjectResul
 		md = args.ExecMD.Self
 	}
 
-	ctr, err = parent.Self().WithExec(ctx, args.ContainerExecOpts, md)
+	key, err := withExecKey(ctx, parent, args)
+	if err != nil {
+		return inst, err
+	}
+	ctr, err = parent.Self().WithExec(ctx, args.ContainerExecOpts, md, key)
 	if err != nil {
 		return inst, err
 	}
@@ -1051,17 +1170,46 @@ func (s *containerSchema) withExecCacheKey(
 	args containerExecArgs,
 	req dagql.GetCacheConfigRequest,
 ) (*dagql.GetCacheConfigResponse, error) {
-	argDigest, err := args.Digest()
+	key, err := withExecKey(ctx, parent, args)
 	if err != nil {
 		return nil, err
 	}
+	if args.IsDagOp {
+		key = hashutil.HashStrings(key, IsDagOpArgName).String()
+	}
 
 	resp := &dagql.GetCacheConfigResponse{CacheKey: req.CacheKey}
-	resp.CacheKey.CallKey = hashutil.HashStrings(
+	resp.CacheKey.CallKey = key
+	return resp, nil
+}
+
+// withExecKey returns the cache key of the withExec call outside of its DagOp.
+// It's derived again for a session other than the one whose debugger skipped
+// or retried the exec, so that its result isn't reused.
+func withExecKey(
+	ctx context.Context,
+	parent dagql.ObjectResult[*core.Container],
+	args containerExecArgs,
+) (string, error) {
+	args.IsDagOp = false
+	argDigest, err := args.Digest()
+	if err != nil {
+		return "", err
+	}
+	key := hashutil.HashStrings(
 		parent.ID().Digest().String(),
 		string(argDigest),
 	).String()
-	return resp, nil
+
+	query, err := core.CurrentQuery(ctx)
+	if err != nil {
+		return "", err
+	}
+	clientMD, err := engine.ClientMetadataFromContext(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get client metadata: %w", err)
+	}
+	return query.DebuggedExecs().CacheKey(key, clientMD.SessionID), nil
 }
 
 func (s *containerSchema) stdout(ctx context.Context, parent *core.Container, _ struct{}) (string, error) {
@@ -1538,6 +1686,57 @@ func (s *containerSchema) exists(ctx context.Context, parent *core.Container, ar
 	return dagql.NewBoolean(exists), err
 }
 
+type sbomArgs struct {
+	Format core.SBOMFormat `default:"SPDX_JSON"`
+}
+
+func (s *containerSchema) sbom(ctx context.Context, parent *core.Container, args sbomArgs) (*core.File, error) {
+	return parent.SBOM(ctx, args.Format)
+}
+
+type containerCompareArgs struct {
+	Other core.ContainerID
+}
+
+func (s *containerSchema) compare(ctx context.Context, parent dagql.ObjectResult[*core.Container], args containerCompareArgs) (*core.ContainerComparison, error) {
+	srv, err := core.CurrentDagqlServer(ctx)
+	if err != nil {
+		return nil, err
+	}
+	other, err := args.Other.Load(ctx, srv)
+	if err != nil {
+		return nil, err
+	}
+	return core.NewContainerComparison(ctx, parent, other)
+}
+
+func (s *containerSchema) comparisonChanges(ctx context.Context, parent dagql.ObjectResult[*core.ContainerComparison], args struct{}) (*core.Changeset, error) {
+	srv, err := core.CurrentDagqlServer(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var before, after dagql.ObjectResult[*core.Directory]
+	if err := srv.Select(ctx, parent.Self().Before, &before, dagql.Selector{Field: "rootfs"}); err != nil {
+		return nil, err
+	}
+	if err := srv.Select(ctx, parent.Self().After, &after, dagql.Selector{Field: "rootfs"}); err != nil {
+		return nil, err
+	}
+	return core.NewChangeset(ctx, before, after)
+}
+
+func (s *containerSchema) comparisonSummary(ctx context.Context, parent dagql.ObjectResult[*core.ContainerComparison], args struct{}) (dagql.String, error) {
+	srv, err := core.CurrentDagqlServer(ctx)
+	if err != nil {
+		return "", err
+	}
+	var changes dagql.ObjectResult[*core.Changeset]
+	if err := srv.Select(ctx, parent, &changes, dagql.Selector{Field: "changes"}); err != nil {
+		return "", err
+	}
+	return dagql.String(parent.Self().Summary(changes.Self())), nil
+}
+
 func (s *containerSchema) stat(ctx context.Context, parent *core.Container, args statArgs) (*core.Stat, error) {
 	srv, err := core.CurrentDagqlServer(ctx)
 	if err != nil {
@@ -1546,29 +1745,157 @@ func (s *containerSchema) stat(ctx context.Context, parent *core.Container, args
 	return parent.Stat(ctx, srv, args.Path, args.DoNotFollowSymlinks)
 }
 
+func (s *containerSchema) withLayerBoundary(ctx context.Context, parent *core.Container, args struct{}) (*core.Container, error) {
+	return parent.WithLayerBoundary(), nil
+}
+
+type containerLayeredRootfsArgs struct {
+	Squash dagql.Optional[core.ImageSquashMode]
+
+	FSDagOpInternalArgs
+}
+
+func (s *containerSchema) layeredRootfs(ctx context.Context, parent dagql.ObjectResult[*core.Container], args containerLayeredRootfsArgs) (inst dagql.ObjectResult[*core.Directory], _ error) {
+	srv, err := core.CurrentDagqlServer(ctx)
+	if err != nil {
+		return inst, err
+	}
+	dir, err := parent.Self().LayeredRootFS(ctx, args.Squash.Value)
+	if err != nil {
+		return inst, err
+	}
+	return dagql.NewObjectResultForCurrentID(ctx, srv, dir)
+}
+
+// layeredContainers loads the containers to publish or export, with the
+// layers of their root filesystems grouped at their layer boundaries, or
+// squashed.
+func layeredContainers(
+	ctx context.Context,
+	srv *dagql.Server,
+	parent dagql.ObjectResult[*core.Container],
+	variantIDs []core.ContainerID,
+	squash core.ImageSquashMode,
+) (*core.Container, []*core.Container, error) {
+	layered := func(ctr dagql.ObjectResult[*core.Container]) (*core.Container, error) {
+		if !ctr.Self().HasLayerGroups(squash) {
+			return ctr.Self(), nil
+		}
+		sel := dagql.Selector{Field: "__layeredRootfs"}
+		if squash != "" {
+			sel.Args = []dagql.NamedInput{{Name: "squash", Value: dagql.Opt(squash)}}
+		}
+		var rootfs dagql.ObjectResult[*core.Directory]
+		if err := srv.Select(ctx, ctr, &rootfs, sel); err != nil {
+			return nil, fmt.Errorf("failed to group layers: %w", err)
+		}
+		cp := ctr.Self().Clone()
+		cp.FS = &rootfs
+		return cp, nil
+	}
+
+	ctr, err := layered(parent)
+	if err != nil {
+		return nil, nil, err
+	}
+	variants := make([]*core.Container, 0, len(variantIDs))
+	for _, id := range variantIDs {
+		variant, err := id.Load(ctx, srv)
+		if err != nil {
+			return nil, nil, err
+		}
+		layeredVariant, err := layered(variant)
+		if err != nil {
+			return nil, nil, err
+		}
+		variants = append(variants, layeredVariant)
+	}
+	return ctr, variants, nil
+}
+
 type containerPublishArgs struct {
 	Address           dagql.String
 	PlatformVariants  []core.ContainerID `default:"[]"`
 	ForcedCompression dagql.Optional[core.ImageLayerCompression]
 	MediaTypes        core.ImageMediaTypes `default:"OCI"`
+	Squash            dagql.Optional[core.ImageSquashMode]
+	ImageAttestationArgs
+}
+
+// ImageAttestationArgs are the arguments for signing and attesting images
+// that are published or exported.
+type ImageAttestationArgs struct {
+	SigningKey         dagql.Optional[core.SecretID]
+	SigningKeyPassword dagql.Optional[core.SecretID]
+	Provenance         bool                            `default:"false"`
+	SBOM               dagql.Optional[core.SBOMFormat] `name:"sbom"`
+}
+
+func (args ImageAttestationArgs) attestationOpts(ctx context.Context, srv *dagql.Server) (opts core.ImageAttestationOpts, _ error) {
+	opts.Provenance = args.Provenance
+	opts.SBOM = args.SBOM.Value
+	if args.Provenance {
+		// the current call is the publish or export, whose receiver is the
+		// container that was built
+		opts.ID = dagql.CurrentID(ctx).Receiver()
+	}
+	if !args.SigningKey.Valid {
+		if args.SigningKeyPassword.Valid {
+			return opts, errors.New("signingKeyPassword requires signingKey")
+		}
+		return opts, nil
+	}
+
+	query, err := core.CurrentQuery(ctx)
+	if err != nil {
+		return opts, err
+	}
+	secretStore, err := query.Secrets(ctx)
+	if err != nil {
+		return opts, fmt.Errorf("failed to get secret store: %w", err)
+	}
+	key, err := args.SigningKey.Value.Load(ctx, srv)
+	if err != nil {
+		return opts, err
+	}
+	opts.SigningKey, err = secretStore.GetSecretPlaintext(ctx, key.ID().Digest())
+	if err != nil {
+		return opts, err
+	}
+	if args.SigningKeyPassword.Valid {
+		password, err := args.SigningKeyPassword.Value.Load(ctx, srv)
+		if err != nil {
+			return opts, err
+		}
+		opts.SigningKeyPassword, err = secretStore.GetSecretPlaintext(ctx, password.ID().Digest())
+		if err != nil {
+			return opts, err
+		}
+	}
+	return opts, nil
 }
 
-func (s *containerSchema) publish(ctx context.Context, parent *core.Container, args containerPublishArgs) (dagql.String, error) {
+func (s *containerSchema) publish(ctx context.Context, parent dagql.ObjectResult[*core.Container], args containerPublishArgs) (dagql.String, error) {
 	srv, err := core.CurrentDagqlServer(ctx)
 	if err != nil {
 		return "", fmt.Errorf("failed to get server: %w", err)
 	}
 
-	variants, err := dagql.LoadIDs(ctx, srv, args.PlatformVariants)
+	ctr, variants, err := layeredContainers(ctx, srv, parent, args.PlatformVariants, args.Squash.Value)
 	if err != nil {
 		return "", err
 	}
-	ref, err := parent.Publish(
+	attestations, err := args.attestationOpts(ctx, srv)
+	if err != nil {
+		return "", err
+	}
+	ref, err := ctr.Publish(
 		ctx,
 		args.Address.String(),
 		variants,
 		args.ForcedCompression.Value,
 		args.MediaTypes,
+		attestations,
 	)
 	if err != nil {
 		return "", err
@@ -2104,9 +2431,11 @@ type containerExportArgs struct {
 	ForcedCompression dagql.Optional[core.ImageLayerCompression]
 	MediaTypes        core.ImageMediaTypes `default:"OCI"`
 	Expand            bool                 `default:"false"`
+	Squash            dagql.Optional[core.ImageSquashMode]
+	ImageAttestationArgs
 }
 
-func (s *containerSchema) export(ctx context.Context, parent *core.Container, args containerExportArgs) (dagql.String, error) {
+func (s *containerSchema) export(ctx context.Context, parent dagql.ObjectResult[*core.Container], args containerExportArgs) (dagql.String, error) {
 	query, err := core.CurrentQuery(ctx)
 	if err != nil {
 		return "", err
@@ -2116,17 +2445,22 @@ func (s *containerSchema) export(ctx context.Context, parent *core.Container, ar
 		return "", fmt.Errorf("failed to get server: %w", err)
 	}
 
-	variants, err := dagql.LoadIDs(ctx, srv, args.PlatformVariants)
+	ctr, variants, err := layeredContainers(ctx, srv, parent, args.PlatformVariants, args.Squash.Value)
 	if err != nil {
 		return "", err
 	}
 
-	path, err := expandEnvVar(ctx, parent, args.Path, args.Expand)
+	path, err := expandEnvVar(ctx, parent.Self(), args.Path, args.Expand)
+	if err != nil {
+		return "", err
+	}
+
+	attestations, err := args.attestationOpts(ctx, srv)
 	if err != nil {
 		return "", err
 	}
 
-	_, err = parent.Export(
+	_, err = ctr.Export(
 		ctx,
 		core.ExportOpts{
 			Dest:              path,
@@ -2134,6 +2468,7 @@ func (s *containerSchema) export(ctx context.Context, parent *core.Container, ar
 			ForcedCompression: args.ForcedCompression.Value,
 			MediaTypes:        args.MediaTypes,
 			Tar:               true,
+			Attestations:      attestations,
 		},
 	)
 	if err != nil {
@@ -2150,7 +2485,7 @@ func (s *containerSchema) export(ctx context.Context, parent *core.Container, ar
 	return dagql.String(stat.Path), err
 }
 
-func (s *containerSchema) exportLegacy(ctx context.Context, parent *core.Container, args containerExportArgs) (dagql.Boolean, error) {
+func (s *containerSchema) exportLegacy(ctx context.Context, parent dagql.ObjectResult[*core.Container], args containerExportArgs) (dagql.Boolean, error) {
 	_, err := s.export(ctx, parent, args)
 	if err != nil {
 		return false, err
@@ -2180,7 +2515,7 @@ func (s *containerSchema) asTarball(
 		return inst, fmt.Errorf("failed to get server: %w", err)
 	}
 
-	platformVariants, err := dagql.LoadIDs(ctx, srv, args.PlatformVariants)
+	ctr, platformVariants, err := layeredContainers(ctx, srv, parent, args.PlatformVariants, "")
 	if err != nil {
 		return inst, err
 	}
@@ -2207,7 +2542,7 @@ func (s *containerSchema) asTarball(
 	inputByPlatform := map[string]buildkit.ContainerExport{}
 	services := core.ServiceBindings{}
 
-	variants := append([]*core.Container{parent.Self()}, platformVariants...)
+	variants := append([]*core.Container{ctr}, platformVariants...)
 	for _, variant := range variants {
 		if variant.FS == nil {
 			continue
@@ -2335,7 +2670,7 @@ func (s *containerSchema) exportImage(
 	}
 
 	if imageWriter.ContentStore != nil {
-		platformVariants, err := dagql.LoadIDs(ctx, srv, args.PlatformVariants)
+		ctr, platformVariants, err := layeredContainers(ctx, srv, parent, args.PlatformVariants, "")
 		if err != nil {
 			return core.Void{}, err
 		}
@@ -2350,7 +2685,7 @@ func (s *containerSchema) exportImage(
 		leaseID, _ := leases.FromContext(leaseCtx)
 
 		// NB: buildkit loads the "export" ContentStore itself (it's not explicitly passed in)
-		desc, err := parent.Self().Export(ctx, core.ExportOpts{
+		desc, err := ctr.Export(ctx, core.ExportOpts{
 			PlatformVariants:  platformVariants,
 			ForcedCompression: args.ForcedCompression.Value,
 			MediaTypes:        args.MediaTypes,
@@ -2664,6 +2999,17 @@ func (s *containerSchema) terminal(
 	return ctr, nil
 }
 
+func (s *containerSchema) withBreakpoint(
+	ctx context.Context,
+	ctr dagql.ObjectResult[*core.Container],
+	_ struct{},
+) (res dagql.ObjectResult[*core.Container], _ error) {
+	if err := ctr.Self().Breakpoint(ctx, ctr.ID()); err != nil {
+		return res, err
+	}
+	return ctr, nil
+}
+
 func (s *containerSchema) terminalLegacy(
 	ctx context.Context,
 	ctr dagql.ObjectResult[*core.Container],
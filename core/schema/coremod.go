@@ -165,6 +165,10 @@ func (m *CoreMod) ModTypeFor(ctx context.Context, typeDef *core.TypeDef, checkDi
 		// core does not yet define any interfaces
 		return nil, false, nil
 
+	case core.TypeDefKindUnion:
+		// core does not define any unions
+		return nil, false, nil
+
 	default:
 		return nil, false, fmt.Errorf("unexpected type def kind %s", typeDef.Kind)
 	}
@@ -135,6 +135,13 @@ func (s *directorySchema) Install(srv *dagql.Server) {
 				dagql.Arg("path").Doc(`Path to stat (e.g., "/file.txt").`),
 				dagql.Arg("doNotFollowSymlinks").Doc(`If specified, do not follow symlinks.`),
 			),
+		dagql.Func("sbom", s.sbom).
+			Doc(`Return a software bill of materials (SBOM) of the packages in the directory.`,
+				`OS packages installed with apk, dpkg or rpm are detected, as well as
+				the dependencies in Go, npm, Python and Cargo lockfiles.`).
+			Args(
+				dagql.Arg("format").Doc(`Format of the SBOM.`),
+			),
 		dagql.NodeFunc("directory", maintainContentHashing(s.subdirectory)).
 			Doc(`Retrieves a directory at the given path.`).
 			Args(
@@ -302,6 +309,14 @@ func (s *directorySchema) Install(srv *dagql.Server) {
 			),
 		dagql.NodeFunc("isEmpty", s.changesetEmpty).
 			Doc(`Returns true if the changeset is empty (i.e. there are no changes).`),
+		dagql.NodeFunc("withChangeset", s.changesetWithChangeset).
+			Doc(`Add changes to this changeset, if they don't conflict with it.`,
+				`Both changesets must compare against the same directory. The changes
+				conflict if a file is changed by both, or changed by one and removed by the
+				other.`).
+			Args(
+				dagql.Arg("changes").Doc(`The changes to add.`),
+			),
 	}.Install(srv)
 }
 
@@ -867,6 +882,10 @@ func (s *directorySchema) stat(ctx context.Context, parent *core.Directory, args
 	return parent.Stat(ctx, srv, args.Path, args.DoNotFollowSymlinks)
 }
 
+func (s *directorySchema) sbom(ctx context.Context, parent *core.Directory, args sbomArgs) (*core.File, error) {
+	return parent.SBOM(ctx, args.Format)
+}
+
 type diffArgs struct {
 	Other core.DirectoryID
 
@@ -1032,6 +1051,45 @@ func (s *directorySchema) changesetExport(ctx context.Context, parent *core.Chan
 	return dagql.String(stat.Path), err
 }
 
+func (s *directorySchema) changesetWithChangeset(ctx context.Context, parent dagql.ObjectResult[*core.Changeset], args struct {
+	Changes dagql.ID[*core.Changeset]
+}) (*core.Changeset, error) {
+	srv, err := core.CurrentDagqlServer(ctx)
+	if err != nil {
+		return nil, err
+	}
+	changes, err := args.Changes.Load(ctx, srv)
+	if err != nil {
+		return nil, err
+	}
+	// compare the content, since the same directory may be loaded by
+	// different calls
+	beforeDigest, err := parent.Self().Before.Self().Digest(ctx)
+	if err != nil {
+		return nil, err
+	}
+	otherBeforeDigest, err := changes.Self().Before.Self().Digest(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if beforeDigest != otherBeforeDigest {
+		return nil, fmt.Errorf("cannot merge changesets that compare against different directories")
+	}
+	if conflicts := parent.Self().Conflicts(changes.Self()); len(conflicts) > 0 {
+		return nil, fmt.Errorf("conflicting changes to %s", strings.Join(conflicts, ", "))
+	}
+	var after dagql.ObjectResult[*core.Directory]
+	if err := srv.Select(ctx, parent.Self().After, &after, dagql.Selector{
+		Field: "withChanges",
+		Args: []dagql.NamedInput{
+			{Name: "changes", Value: dagql.NewID[*core.Changeset](changes.ID())},
+		},
+	}); err != nil {
+		return nil, err
+	}
+	return core.NewChangeset(ctx, parent.Self().Before, after)
+}
+
 func (s *directorySchema) changesetEmpty(ctx context.Context, parent dagql.ObjectResult[*core.Changeset], args struct{}) (dagql.Boolean, error) {
 	srv, err := core.CurrentDagqlServer(ctx)
 	if err != nil {
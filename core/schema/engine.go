@@ -23,8 +23,18 @@ func (s *engineSchema) Install(srv *dagql.Server) {
 		dagql.Func("clients", s.clients).
 			DoNotCache("Clients can connect and disconnect at any time").
 			Doc("The list of connected client IDs"),
+		dagql.Func("sessions", s.sessions).
+			DoNotCache("Sessions can start and end at any time").
+			Doc("The list of active sessions, across all clients connected to the engine"),
+		dagql.Func("services", s.services).
+			DoNotCache("Services can start and stop at any time").
+			Doc("The list of persistent services running in the engine, shared across sessions"),
 	}.Install(srv)
 
+	dagql.Fields[*core.EngineSession]{}.Install(srv)
+
+	dagql.Fields[*core.EngineService]{}.Install(srv)
+
 	dagql.Fields[*core.Engine]{
 		dagql.Func("localCache", s.localCache).
 			Doc("The local (on-disk) cache for the Dagger engine"),
@@ -39,6 +49,19 @@ func (s *engineSchema) Install(srv *dagql.Server) {
 			Args(
 				dagql.Arg("useDefaultPolicy").Doc("Use the engine-wide default pruning policy if true, otherwise prune the whole cache of any releasable entries."),
 			),
+		dagql.Func("pin", s.cachePin).
+			DoNotCache("Mutates mutable state").
+			Doc("Pin a cache entry, so that it's never pruned").
+			Args(
+				dagql.Arg("recordId").Doc("The record ID of the cache entry to pin."),
+			),
+		dagql.Func("unpin", s.cacheUnpin).
+			DoNotCache("Mutates mutable state").
+			Doc("Unpin a cache entry, so that it can be pruned again",
+				"The entries of a pinned cache volume are pinned again when the volume is used; unpin the volume itself with CacheVolume.unpin instead.").
+			Args(
+				dagql.Arg("recordId").Doc("The record ID of the cache entry to unpin."),
+			),
 	}.Install(srv)
 
 	dagql.Fields[*core.EngineCacheEntrySet]{
@@ -87,6 +110,52 @@ func (s *engineSchema) clients(ctx context.Context, parent *core.Engine, args st
 	return query.Clients(), nil
 }
 
+func (s *engineSchema) sessions(ctx context.Context, parent *core.Engine, args struct{}) (dagql.Array[*core.EngineSession], error) {
+	query, err := core.CurrentQuery(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := query.RequireMainClient(ctx); err != nil {
+		return nil, err
+	}
+	return query.Sessions(), nil
+}
+
+func (s *engineSchema) services(ctx context.Context, parent *core.Engine, args struct{}) (dagql.Array[*core.EngineService], error) {
+	query, err := core.CurrentQuery(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := query.RequireMainClient(ctx); err != nil {
+		return nil, err
+	}
+	svcs, err := query.Services(ctx)
+	if err != nil {
+		return nil, err
+	}
+	named := svcs.Named.List()
+	services := make(dagql.Array[*core.EngineService], 0, len(named))
+	for _, info := range named {
+		svc := &core.EngineService{
+			Name:            info.Name,
+			Hostname:        info.Host,
+			StartedUnixNano: int(info.StartedAt.UnixNano()),
+			Bindings:        info.Bindings,
+		}
+		for _, port := range info.Ports {
+			svc.Ports = append(svc.Ports, port.Port)
+		}
+		if info.IdleTimeout > 0 {
+			svc.IdleTimeout = info.IdleTimeout.String()
+		}
+		if !info.IdleSince.IsZero() {
+			svc.IdleSinceUnixNano = int(info.IdleSince.UnixNano())
+		}
+		services = append(services, svc)
+	}
+	return services, nil
+}
+
 func (s *engineSchema) cacheEntrySet(ctx context.Context, parent dagql.ObjectResult[*core.EngineCache], args struct {
 	Key string `default:""`
 }) (inst dagql.Result[*core.EngineCacheEntrySet], _ error) {
@@ -145,6 +214,35 @@ func (s *engineSchema) cachePrune(ctx context.Context, parent *core.EngineCache,
 	return void, nil
 }
 
+type cachePinArgs struct {
+	// the record ID rather than the entry's dagql ID, since the latter is tied
+	// to the listing it came from, which is never cached
+	RecordID string `name:"recordId"`
+}
+
+func (s *engineSchema) cachePin(ctx context.Context, parent *core.EngineCache, args cachePinArgs) (dagql.Nullable[core.Void], error) {
+	return s.setCachePinned(ctx, args.RecordID, true)
+}
+
+func (s *engineSchema) cacheUnpin(ctx context.Context, parent *core.EngineCache, args cachePinArgs) (dagql.Nullable[core.Void], error) {
+	return s.setCachePinned(ctx, args.RecordID, false)
+}
+
+func (s *engineSchema) setCachePinned(ctx context.Context, recordID string, pinned bool) (dagql.Nullable[core.Void], error) {
+	void := dagql.Null[core.Void]()
+	query, err := core.CurrentQuery(ctx)
+	if err != nil {
+		return void, err
+	}
+	if err := query.RequireMainClient(ctx); err != nil {
+		return void, err
+	}
+	if err := query.BuildkitCache().SetPinned(ctx, recordID, pinned); err != nil {
+		return void, fmt.Errorf("failed to update cache entry: %w", err)
+	}
+	return void, nil
+}
+
 func (s *engineSchema) cacheEntrySetEntries(ctx context.Context, parent *core.EngineCacheEntrySet, args struct{}) (dagql.Array[*core.EngineCacheEntry], error) {
 	return parent.EntriesList, nil
 }
@@ -70,6 +70,28 @@ func (s environmentSchema) Install(srv *dagql.Server) {
 				dagql.Arg("name").Doc("The name of the binding"),
 				dagql.Arg("description").Doc("The description of the output"),
 			),
+		dagql.Func("withOutputExecCheck", s.withOutputExecCheck).
+			Doc(
+				"Checks the values saved to an output by running a command against them",
+				"When the command fails, its error is returned to the model, which is asked to fix the value until the command succeeds or the attempts run out. Directory, File and Container values are mounted at the given path, and String values are passed on stdin.",
+			).
+			Args(
+				dagql.Arg("name").Doc("The name of the output"),
+				dagql.Arg("container").Doc("The container to run the command in"),
+				dagql.Arg("args").Doc("The command to run"),
+				dagql.Arg("path").Doc("The path to mount the value at"),
+				dagql.Arg("maxAttempts").Doc("The number of values to check before giving up"),
+			),
+		dagql.Func("withOutputFunctionCheck", s.withOutputFunctionCheck).
+			Doc(
+				"Checks the values saved to an output by calling a function against them",
+				"The function must belong to a module installed in the environment, and take an argument of the output's type. When it fails, its error is returned to the model, which is asked to fix the value until the function succeeds or the attempts run out.",
+			).
+			Args(
+				dagql.Arg("name").Doc("The name of the output"),
+				dagql.Arg("function").Doc("The name of the function to call"),
+				dagql.Arg("maxAttempts").Doc("The number of values to check before giving up"),
+			),
 	}.Install(srv)
 	dagql.Fields[*core.Binding]{
 		dagql.Func("name", s.bindingName).
@@ -262,6 +284,36 @@ func (s environmentSchema) withStringOutput(ctx context.Context, env *core.Env,
 	return env.WithOutput(args.Name, dagql.String(""), args.Description), nil
 }
 
+func (s environmentSchema) withOutputExecCheck(ctx context.Context, env *core.Env, args struct {
+	Name        string
+	Container   core.ContainerID
+	Args        []string
+	Path        string `default:"/output"`
+	MaxAttempts int    `default:"3"`
+}) (*core.Env, error) {
+	ctr, err := args.Container.Load(ctx, s.srv)
+	if err != nil {
+		return nil, err
+	}
+	return env.WithOutputCheck(args.Name, &core.OutputCheck{
+		Container:   ctr,
+		Args:        args.Args,
+		Path:        args.Path,
+		MaxAttempts: args.MaxAttempts,
+	})
+}
+
+func (s environmentSchema) withOutputFunctionCheck(ctx context.Context, env *core.Env, args struct {
+	Name        string
+	Function    string
+	MaxAttempts int `default:"3"`
+}) (*core.Env, error) {
+	return env.WithOutputCheck(args.Name, &core.OutputCheck{
+		Function:    args.Function,
+		MaxAttempts: args.MaxAttempts,
+	})
+}
+
 func (s environmentSchema) bindingName(ctx context.Context, b *core.Binding, args struct{}) (string, error) {
 	return b.Key, nil
 }
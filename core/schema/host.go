@@ -341,6 +341,9 @@ func (s *hostSchema) socket(ctx context.Context, host dagql.ObjectResult[*core.H
 	if err != nil {
 		return inst, err
 	}
+	if err := core.CheckModuleCapability(ctx, query, core.ModuleCapabilityHostSockets); err != nil {
+		return inst, err
+	}
 
 	socketStore, err := query.Sockets(ctx)
 	if err != nil {
@@ -466,6 +469,14 @@ type hostTunnelArgs struct {
 }
 
 func (s *hostSchema) tunnel(ctx context.Context, parent *core.Host, args hostTunnelArgs) (*core.Service, error) {
+	query, err := core.CurrentQuery(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := core.CheckModuleCapability(ctx, query, core.ModuleCapabilityHostSockets); err != nil {
+		return nil, err
+	}
+
 	srv, err := core.CurrentDagqlServer(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get current dagql server: %w", err)
@@ -619,6 +630,9 @@ func (s *hostSchema) service(ctx context.Context, parent dagql.ObjectResult[*cor
 	if err != nil {
 		return inst, err
 	}
+	if err := core.CheckModuleCapability(ctx, query, core.ModuleCapabilityHostSockets); err != nil {
+		return inst, err
+	}
 	socketStore, err := query.Sockets(ctx)
 	if err != nil {
 		return inst, fmt.Errorf("failed to get socket store: %w", err)
@@ -77,6 +77,10 @@ func (s *httpSchema) http(ctx context.Context, parent dagql.ObjectResult[*core.Q
 		return dagql.NewObjectResultForCurrentID(ctx, srv, f)
 	}
 
+	if err := core.CheckModuleCapability(ctx, parent.Self(), core.ModuleCapabilityNetwork); err != nil {
+		return inst, err
+	}
+
 	filename, err := s.httpPath(ctx, parent.Self(), args)
 	if err != nil {
 		return inst, err
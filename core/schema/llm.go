@@ -87,6 +87,19 @@ func (s llmSchema) Install(srv *dagql.Server) {
 				dagql.Arg("name").Doc("The name of the MCP server"),
 				dagql.Arg("service").Doc("The MCP service to run and communicate with over stdio"),
 			),
+		dagql.Func("withToolPolicy", s.withToolPolicy).
+			Doc(
+				"Return a new LLM that applies a policy to the functions matching a pattern",
+				"A pattern matches a function name, like withExec, or a type and function name, like Container.withExec, and may use * as a wildcard. Tools of MCP servers are matched by server and tool name. When several rules match a function, the last one applies, and functions that match no rule are allowed.",
+			).
+			Args(
+				dagql.Arg("pattern").Doc("The functions to apply the policy to"),
+				dagql.Arg("policy").Doc("Whether the LLM may call the functions"),
+			),
+		dagql.Func("withDryRun", s.withDryRun).
+			Doc("Return a new LLM that records the calls it makes with side effects outside of the engine, such as exports, publishing or host access, instead of calling them"),
+		dagql.Func("dryRunCalls", s.dryRunCalls).
+			Doc("return the calls recorded instead of being called in a dry run"),
 		dagql.NodeFunc("sync", func(ctx context.Context, self dagql.ObjectResult[*core.LLM], _ struct{}) (res dagql.Result[dagql.ID[*core.LLM]], _ error) {
 			var inst dagql.Result[*core.LLM]
 			if err := srv.Select(ctx, self, &inst, dagql.Selector{
@@ -108,12 +121,23 @@ func (s llmSchema) Install(srv *dagql.Server) {
 			Doc("Inner function called by step that configures an LLM to do one iteration"),
 		dagql.Func("attempt", s.attempt).
 			Doc("create a branch in the LLM's history"),
+		dagql.Func("fanOut", s.fanOut).
+			Doc(
+				"Run several branches of the LLM in parallel, each with its own message history and a copy of its environment",
+				"Each branch submits the queued prompt, followed by one of the variations in turn, if any. Branches that fail are still returned, and fail when they are used. The token usage of all the branches is available with fanOutTokenUsage.",
+			).
+			Args(
+				dagql.Arg("n").Doc("The number of branches to run. Defaults to the number of variations."),
+				dagql.Arg("variations").Doc("Prompts to append to the branches, one per branch in turn"),
+			),
 		dagql.Func("tools", s.tools).
 			Doc("print documentation for available tools"),
 		dagql.Func("bindResult", s.bindResult).
 			Doc("returns the type of the current state"),
 		dagql.Func("tokenUsage", s.tokenUsage).
 			Doc("returns the token usage of the current state"),
+		dagql.Func("fanOutTokenUsage", s.fanOutTokenUsage).
+			Doc("returns the token usage of the current state, including the other branches of the fan outs it came from"),
 	}.Install(srv)
 	dagql.Fields[*core.LLMTokenUsage]{}.Install(srv)
 }
@@ -208,6 +232,21 @@ func (s *llmSchema) withMCPServer(ctx context.Context, llm *core.LLM, args struc
 	return llm.WithMCPServer(args.Name, svc), nil
 }
 
+func (s *llmSchema) withToolPolicy(ctx context.Context, llm *core.LLM, args struct {
+	Pattern string
+	Policy  core.LLMToolPolicy
+}) (*core.LLM, error) {
+	return llm.WithToolPolicy(args.Pattern, args.Policy), nil
+}
+
+func (s *llmSchema) withDryRun(ctx context.Context, llm *core.LLM, args struct{}) (*core.LLM, error) {
+	return llm.WithDryRun(), nil
+}
+
+func (s *llmSchema) dryRunCalls(ctx context.Context, llm *core.LLM, _ struct{}) ([]string, error) {
+	return llm.DryRunCalls(ctx)
+}
+
 func (s *llmSchema) withPromptFile(ctx context.Context, llm *core.LLM, args struct {
 	File core.FileID
 }) (*core.LLM, error) {
@@ -247,10 +286,20 @@ func (s *llmSchema) attempt(_ context.Context, llm *core.LLM, _ struct {
 	return llm.Clone(), nil
 }
 
+func (s *llmSchema) fanOut(ctx context.Context, llm *core.LLM, args struct {
+	N          int      `default:"0"`
+	Variations []string `default:"[]"`
+}) (dagql.Array[*core.LLM], error) {
+	return llm.FanOut(ctx, args.N, args.Variations)
+}
+
 func (s *llmSchema) llm(ctx context.Context, parent *core.Query, args struct {
 	Model       dagql.Optional[dagql.String]
 	MaxAPICalls dagql.Optional[dagql.Int] `name:"maxAPICalls"`
 }) (*core.LLM, error) {
+	if err := core.CheckModuleCapability(ctx, parent, core.ModuleCapabilityLLM); err != nil {
+		return nil, err
+	}
 	var model string
 	if args.Model.Valid {
 		model = args.Model.Value.String()
@@ -292,6 +341,10 @@ func (s *llmSchema) tokenUsage(ctx context.Context, llm *core.LLM, _ struct{}) (
 	return llm.TokenUsage(ctx, s.srv)
 }
 
+func (s *llmSchema) fanOutTokenUsage(ctx context.Context, llm *core.LLM, _ struct{}) (*core.LLMTokenUsage, error) {
+	return llm.FanOutTokenUsage(ctx, s.srv)
+}
+
 func (s *llmSchema) withoutMessageHistory(ctx context.Context, llm *core.LLM, _ struct{}) (*core.LLM, error) {
 	return llm.WithoutMessageHistory(), nil
 }
@@ -116,6 +116,9 @@ func (s *moduleSchema) Install(dag *dagql.Server) {
 		dagql.Func("withEnum", s.moduleWithEnum).
 			Doc(`This module plus the given Enum type and associated values`),
 
+		dagql.Func("withUnion", s.moduleWithUnion).
+			Doc(`This module plus the given Union type`),
+
 		dagql.Func("runtime", s.moduleRuntime).
 			Doc(`The container that runs the module's entrypoint. It will fail to execute if the module doesn't compile.`),
 
@@ -190,6 +193,13 @@ func (s *moduleSchema) Install(dag *dagql.Server) {
 				dagql.Arg("ignore").Doc(`Patterns to ignore when loading the contextual argument value.`),
 				dagql.Arg("sourceMap").Doc(`The source map for the argument definition.`),
 				dagql.Arg("deprecated").Doc(`If deprecated, the reason or migration path.`),
+				dagql.Arg("min").Doc(`If the argument is an Integer or Float, the minimum value, inclusive, as a JSON number.`),
+				dagql.Arg("max").Doc(`If the argument is an Integer or Float, the maximum value, inclusive, as a JSON number.`),
+				dagql.Arg("pattern").Doc(`If the argument is a String, a regular expression (RE2 syntax) that the whole value must match.`),
+				dagql.Arg("minLength").Doc(`If the argument is a String or a list, the minimum length of the value.`),
+				dagql.Arg("maxLength").Doc(`If the argument is a String or a list, the maximum length of the value.`),
+				dagql.Arg("nonEmpty").Doc(`If the argument is a list, require it to contain at least one element.`),
+				dagql.Arg("requiredPaths").Doc(`If the argument is a Directory, paths that must exist in it.`),
 			),
 
 		dagql.Func("withCachePolicy", s.functionWithCachePolicy).
@@ -202,6 +212,8 @@ func (s *moduleSchema) Install(dag *dagql.Server) {
 
 	dagql.Fields[*core.FunctionArg]{}.Install(dag)
 
+	dagql.Fields[*core.FunctionArgConstraints]{}.Install(dag)
+
 	dagql.Fields[*core.FunctionCallArgValue]{}.Install(dag)
 
 	dagql.Fields[*core.SourceMap]{}.Install(dag)
@@ -228,6 +240,21 @@ func (s *moduleSchema) Install(dag *dagql.Server) {
 		dagql.Func("withInterface", s.typeDefWithInterface).
 			Doc(`Returns a TypeDef of kind Interface with the provided name.`),
 
+		dagql.Func("withUnion", s.typeDefWithUnion).
+			Doc(`Returns a TypeDef of kind Union with the provided name.`,
+				`Note that a union's members may be omitted if the intent is only to refer to a union.`).
+			Args(
+				dagql.Arg("name").Doc(`The name of the union`),
+				dagql.Arg("description").Doc(`A doc string for the union, if any`),
+				dagql.Arg("sourceMap").Doc(`The source map for the union definition.`),
+			),
+
+		dagql.Func("withUnionMember", s.typeDefWithUnionMember).
+			Doc(`Adds a member type to a Union TypeDef, failing if the type is not a union or the member is not an object.`).
+			Args(
+				dagql.Arg("member").Doc(`The object type to add to the union`),
+			),
+
 		dagql.Func("withField", s.typeDefWithObjectField).
 			Doc(`Adds a static field for an Object TypeDef, failing if the type is not an object.`).
 			Args(
@@ -278,6 +305,7 @@ func (s *moduleSchema) Install(dag *dagql.Server) {
 
 	dagql.Fields[*core.ObjectTypeDef]{}.Install(dag)
 	dagql.Fields[*core.InterfaceTypeDef]{}.Install(dag)
+	dagql.Fields[*core.UnionTypeDef]{}.Install(dag)
 	dagql.Fields[*core.InputTypeDef]{}.Install(dag)
 	dagql.Fields[*core.FieldTypeDef]{}.Install(dag)
 	dagql.Fields[*core.ListTypeDef]{}.Install(dag)
@@ -362,6 +390,36 @@ func (s *moduleSchema) typeDefWithInterface(ctx context.Context, def *core.TypeD
 	return def.WithInterface(args.Name, args.Description, sourceMap), nil
 }
 
+func (s *moduleSchema) typeDefWithUnion(ctx context.Context, def *core.TypeDef, args struct {
+	Name        string
+	Description string `default:""`
+	SourceMap   dagql.Optional[core.SourceMapID]
+}) (*core.TypeDef, error) {
+	if args.Name == "" {
+		return nil, fmt.Errorf("union type def must have a name")
+	}
+	sourceMap, err := s.loadSourceMap(ctx, args.SourceMap)
+	if err != nil {
+		return nil, err
+	}
+	return def.WithUnion(args.Name, args.Description, sourceMap), nil
+}
+
+func (s *moduleSchema) typeDefWithUnionMember(ctx context.Context, def *core.TypeDef, args struct {
+	Member core.TypeDefID
+}) (*core.TypeDef, error) {
+	dag, err := core.CurrentDagqlServer(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dag server: %w", err)
+	}
+
+	member, err := args.Member.Load(ctx, dag)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode member type: %w", err)
+	}
+	return def.WithUnionMember(member.Self())
+}
+
 func (s *moduleSchema) typeDefWithObjectField(ctx context.Context, def *core.TypeDef, args struct {
 	Name        string
 	TypeDef     core.TypeDefID
@@ -542,6 +600,14 @@ func (s *moduleSchema) functionWithArg(ctx context.Context, fn *core.Function, a
 	Ignore       []string  `default:"[]"`
 	SourceMap    dagql.Optional[core.SourceMapID]
 	Deprecated   *string
+
+	Min           core.JSON `default:""`
+	Max           core.JSON `default:""`
+	Pattern       string    `default:""`
+	MinLength     int       `default:"0"`
+	MaxLength     int       `default:"0"`
+	NonEmpty      bool      `default:"false"`
+	RequiredPaths []string  `default:"[]"`
 }) (*core.Function, error) {
 	dag, err := core.CurrentDagqlServer(ctx)
 	if err != nil {
@@ -595,6 +661,21 @@ func (s *moduleSchema) functionWithArg(ctx context.Context, fn *core.Function, a
 		}
 	}
 
+	constraints := &core.FunctionArgConstraints{
+		Min:           args.Min,
+		Max:           args.Max,
+		Pattern:       args.Pattern,
+		MinLength:     args.MinLength,
+		MaxLength:     args.MaxLength,
+		NonEmpty:      args.NonEmpty,
+		RequiredPaths: args.RequiredPaths,
+	}
+	if !constraints.IsEmpty() {
+		if err := constraints.Check(argType.Self()); err != nil {
+			return nil, fmt.Errorf("argument %q: %w", args.Name, err)
+		}
+	}
+
 	// When using a default path SDKs can't set a default value and the argument
 	// may be non-nullable, so we need to enforce it as optional.
 	td := argType.Self()
@@ -602,7 +683,7 @@ func (s *moduleSchema) functionWithArg(ctx context.Context, fn *core.Function, a
 		td = td.WithOptional(true)
 	}
 
-	return fn.WithArg(args.Name, td, args.Description, args.DefaultValue, args.DefaultPath, args.Ignore, sourceMap, args.Deprecated), nil
+	return fn.WithArg(args.Name, td, args.Description, args.DefaultValue, args.DefaultPath, args.Ignore, sourceMap, args.Deprecated, constraints), nil
 }
 
 func (s *moduleSchema) functionWithSourceMap(ctx context.Context, fn *core.Function, args struct {
@@ -858,6 +939,21 @@ func (s *moduleSchema) moduleWithInterface(ctx context.Context, mod *core.Module
 	return mod.WithInterface(ctx, def.Self())
 }
 
+func (s *moduleSchema) moduleWithUnion(ctx context.Context, mod *core.Module, args struct {
+	Union core.TypeDefID
+}) (_ *core.Module, rerr error) {
+	dag, err := core.CurrentDagqlServer(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dag server: %w", err)
+	}
+
+	def, err := args.Union.Load(ctx, dag)
+	if err != nil {
+		return nil, err
+	}
+	return mod.WithUnion(ctx, def.Self())
+}
+
 func (s *moduleSchema) moduleWithEnum(ctx context.Context, mod *core.Module, args struct {
 	Enum core.TypeDefID
 }) (_ *core.Module, rerr error) {
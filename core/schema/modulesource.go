@@ -123,6 +123,17 @@ func (s *moduleSourceSchema) Install(dag *dagql.Server) {
 				dagql.Arg("name").Doc(`The name to set.`),
 			),
 
+		dagql.Func("withGrantedCapabilities", s.moduleSourceWithGrantedCapabilities).
+			Doc(`Record the capabilities granted to the module as a dependency. Recorded in the parent module's dagger.json when installed.`).
+			Args(
+				dagql.Arg("hostRead").Doc(`Paths the module may read from the host through defaultPath arguments.`),
+				dagql.Arg("hostSockets").Doc(`Whether the module may use host unix sockets and tunnels.`),
+				dagql.Arg("network").Doc(`Whether the module may run commands and services with full network access, and download files with http. Image pulls and git fetches are done by the engine and aren't restricted.`),
+				dagql.Arg("llm").Doc(`Whether the module may use LLMs.`),
+				dagql.Arg("exec").Doc(`Whether the module may run commands with access to the Dagger API.`),
+				dagql.Arg("secrets").Doc(`Whether the module may load secrets from the host's secret providers.`),
+			),
+
 		dagql.FuncWithCacheKey("withIncludes", s.moduleSourceWithIncludes, dagql.CachePerClient).
 			Doc(`Update the module source with additional include patterns for files+directories from its context that are required for building it`).
 			Args(
@@ -272,6 +283,7 @@ func (s *moduleSourceSchema) Install(dag *dagql.Server) {
 
 	dagql.Fields[*core.SDKConfig]{}.Install(dag)
 	dagql.Fields[*modules.ModuleConfigClient]{}.Install(dag)
+	dagql.Fields[*modules.ModuleCapabilities]{}.Install(dag)
 
 	dagql.Fields[*core.GeneratedCode]{
 		dagql.Func("withVCSGeneratedPaths", s.generatedCodeWithVCSGeneratedPaths).
@@ -543,6 +555,10 @@ func (s *moduleSourceSchema) localModuleSource(
 				if err != nil {
 					return fmt.Errorf("failed to resolve dep to source: %w", err)
 				}
+				localSrc.Dependencies[i], err = withGrantedCapabilities(ctx, dag, localSrc.Dependencies[i], depCfg.Capabilities)
+				if err != nil {
+					return fmt.Errorf("failed to grant dep capabilities: %w", err)
+				}
 				return nil
 			})
 		}
@@ -550,6 +566,9 @@ func (s *moduleSourceSchema) localModuleSource(
 		if err := eg.Wait(); err != nil {
 			return inst, err
 		}
+		if err := core.CheckDependencyVersions(localSrc); err != nil {
+			return inst, err
+		}
 	}
 
 	if err := localSrc.LoadUserDefaults(ctx); err != nil {
@@ -585,6 +604,7 @@ func (s *moduleSourceSchema) gitModuleSource(
 			HTMLRepoURL:  parsed.RepoRoot.Repo,
 			RepoRootPath: parsed.RepoRoot.Root,
 			Version:      cmp.Or(gitRef.Self().Ref.ShortName(), gitRef.Self().Ref.SHA),
+			VersionRange: parsed.VersionRange(),
 			Commit:       gitRef.Self().Ref.SHA,
 			Ref:          gitRef.Self().Ref.Name,
 			CloneRef:     parsed.SourceCloneRef,
@@ -701,12 +721,19 @@ func (s *moduleSourceSchema) gitModuleSource(
 			if err != nil {
 				return fmt.Errorf("failed to resolve dep to source: %w", err)
 			}
+			gitSrc.Dependencies[i], err = withGrantedCapabilities(ctx, dag, gitSrc.Dependencies[i], depCfg.Capabilities)
+			if err != nil {
+				return fmt.Errorf("failed to grant dep capabilities: %w", err)
+			}
 			return nil
 		})
 	}
 	if err := eg.Wait(); err != nil {
 		return inst, err
 	}
+	if err := core.CheckDependencyVersions(gitSrc); err != nil {
+		return inst, err
+	}
 
 	if err := gitSrc.LoadUserDefaults(ctx); err != nil {
 		return inst, fmt.Errorf("load user defaults: %w", err)
@@ -886,12 +913,19 @@ func (s *moduleSourceSchema) directoryAsModuleSource(
 			if err != nil {
 				return fmt.Errorf("failed to resolve dep to source: %w", err)
 			}
+			dirSrc.Dependencies[i], err = withGrantedCapabilities(ctx, dag, dirSrc.Dependencies[i], depCfg.Capabilities)
+			if err != nil {
+				return fmt.Errorf("failed to grant dep capabilities: %w", err)
+			}
 			return nil
 		})
 	}
 	if err := eg.Wait(); err != nil {
 		return inst, err
 	}
+	if err := core.CheckDependencyVersions(dirSrc); err != nil {
+		return inst, err
+	}
 
 	inst, err = dagql.NewResultForCurrentID(ctx, dirSrc)
 	if err != nil {
@@ -1028,6 +1062,7 @@ func (s *moduleSourceSchema) initFromModConfig(configBytes []byte, src *core.Mod
 	src.ConfigBlueprint = modCfg.Blueprint
 	src.ConfigToolchains = modCfg.Toolchains
 	src.ConfigClients = modCfg.Clients
+	src.Capabilities = modCfg.Capabilities
 
 	engineVersion := modCfg.EngineVersion
 	switch engineVersion {
@@ -1266,6 +1301,62 @@ func (s *moduleSourceSchema) moduleSourceWithName(
 	return src, nil
 }
 
+func (s *moduleSourceSchema) moduleSourceWithGrantedCapabilities(
+	ctx context.Context,
+	src *core.ModuleSource,
+	args struct {
+		HostRead    []string `default:"[]"`
+		HostSockets bool     `default:"false"`
+		Network     bool     `default:"false"`
+		LLM         bool     `name:"llm" default:"false"`
+		Exec        bool     `default:"false"`
+		Secrets     bool     `default:"false"`
+	},
+) (*core.ModuleSource, error) {
+	src = src.Clone()
+	src.GrantedCapabilities = &modules.ModuleCapabilities{
+		HostRead:    args.HostRead,
+		HostSockets: args.HostSockets,
+		Network:     args.Network,
+		LLM:         args.LLM,
+		Exec:        args.Exec,
+		Secrets:     args.Secrets,
+	}
+	src.Digest = src.CalcDigest(ctx).String()
+	return src, nil
+}
+
+// withGrantedCapabilities returns the given module source with the given
+// capabilities granted, or as-is if caps is nil.
+func withGrantedCapabilities(
+	ctx context.Context,
+	dag *dagql.Server,
+	src dagql.ObjectResult[*core.ModuleSource],
+	caps *modules.ModuleCapabilities,
+) (inst dagql.ObjectResult[*core.ModuleSource], _ error) {
+	if caps == nil {
+		return src, nil
+	}
+	hostRead := make(dagql.ArrayInput[dagql.String], len(caps.HostRead))
+	for i, path := range caps.HostRead {
+		hostRead[i] = dagql.String(path)
+	}
+	err := dag.Select(ctx, src, &inst,
+		dagql.Selector{
+			Field: "withGrantedCapabilities",
+			Args: []dagql.NamedInput{
+				{Name: "hostRead", Value: hostRead},
+				{Name: "hostSockets", Value: dagql.Boolean(caps.HostSockets)},
+				{Name: "network", Value: dagql.Boolean(caps.Network)},
+				{Name: "llm", Value: dagql.Boolean(caps.LLM)},
+				{Name: "exec", Value: dagql.Boolean(caps.Exec)},
+				{Name: "secrets", Value: dagql.Boolean(caps.Secrets)},
+			},
+		},
+	)
+	return inst, err
+}
+
 func (s *moduleSourceSchema) moduleSourceWithIncludes(
 	ctx context.Context,
 	src *core.ModuleSource,
@@ -1672,6 +1763,11 @@ func (s *moduleSourceSchema) moduleSourceUpdateItems(
 			if err != nil {
 				return nil, fmt.Errorf("failed to load existing %s: %w", accessor.typ, err)
 			}
+			// keep the capabilities granted at install, so updating can't widen them
+			updatedItem, err = withGrantedCapabilities(ctx, dag, updatedItem, existingItem.Self().GrantedCapabilities)
+			if err != nil {
+				return nil, err
+			}
 
 			newUpdatedArgs = append(newUpdatedArgs, dagql.NewID[*core.ModuleSource](updatedItem.ID()))
 			continue
@@ -1709,7 +1805,8 @@ func (s *moduleSourceSchema) moduleSourceUpdateItems(
 		}
 
 		existingName := existingItem.Self().ModuleName
-		existingVersion := existingItem.Self().Git.Version
+		// keep following the range the item was installed with, if any
+		existingVersion := cmp.Or(existingItem.Self().Git.VersionRange, existingItem.Self().Git.Version)
 		existingSymbolic := existingItem.Self().Git.CloneRef
 		if itemSrcRoot := existingItem.Self().SourceRootSubpath; itemSrcRoot != "" {
 			existingSymbolic += "/" + strings.TrimPrefix(itemSrcRoot, "/")
@@ -1742,6 +1839,10 @@ func (s *moduleSourceSchema) moduleSourceUpdateItems(
 			if err != nil {
 				return nil, fmt.Errorf("failed to load updated %s: %w", accessor.typ, err)
 			}
+			updatedItem, err = withGrantedCapabilities(ctx, dag, updatedItem, existingItem.Self().GrantedCapabilities)
+			if err != nil {
+				return nil, err
+			}
 
 			newUpdatedArgs = append(newUpdatedArgs, dagql.NewID[*core.ModuleSource](updatedItem.ID()))
 		}
@@ -1896,6 +1997,9 @@ func (s *moduleSourceSchema) moduleSourceWithDependencies(
 	}
 
 	accessor.setItems(parentSrc, finalDeps)
+	if err := core.CheckDependencyVersions(parentSrc); err != nil {
+		return nil, err
+	}
 	parentSrc.Digest = parentSrc.CalcDigest(ctx).String()
 	return parentSrc, nil
 }
@@ -2215,6 +2319,7 @@ func (s *moduleSourceSchema) loadModuleSourceConfig(
 	if src.DisableDefaultFunctionCaching {
 		modCfg.DisableDefaultFunctionCaching = ptr(true)
 	}
+	modCfg.Capabilities = src.Capabilities
 
 	if src.SDK != nil {
 		modCfg.SDK = &modules.SDK{
@@ -2262,7 +2367,8 @@ func (s *moduleSourceSchema) loadModuleSourceConfig(
 	modCfg.Dependencies = make([]*modules.ModuleConfigDependency, len(src.Dependencies))
 	for i, depSrc := range src.Dependencies {
 		depCfg := &modules.ModuleConfigDependency{
-			Name: depSrc.Self().ModuleName,
+			Name:         depSrc.Self().ModuleName,
+			Capabilities: depSrc.Self().GrantedCapabilities,
 		}
 
 		// TODO: this is for backwards compatibility until the configuration change is released
@@ -2658,11 +2764,15 @@ func (s *moduleSourceSchema) moduleSourceGeneratedContextDirectory(
 	}
 
 	// write dagger.json to the generated context directory
-	modCfgBytes, err := json.MarshalIndent(modCfg, "", "  ")
-	if err != nil {
+	// don't escape the operators of version ranges, e.g. ">=1.2 <2"
+	var modCfgBuf bytes.Buffer
+	enc := json.NewEncoder(&modCfgBuf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(modCfg); err != nil {
 		return res, fmt.Errorf("failed to encode module config: %w", err)
 	}
-	modCfgBytes = append(modCfgBytes, '\n')
+	modCfgBytes := modCfgBuf.Bytes()
 	modCfgPath := filepath.Join(srcInst.Self().SourceRootSubpath, modules.Filename)
 	err = dag.Select(ctx, genDirInst, &genDirInst,
 		dagql.Selector{
@@ -2814,6 +2924,12 @@ func (s *moduleSourceSchema) runModuleDefInSDK(ctx context.Context, src, srcInst
 			return nil, fmt.Errorf("failed to add enum to module %q: %w", modName, err)
 		}
 	}
+	for _, union := range initialized.UnionDefs {
+		mod, err = mod.WithUnion(ctx, union)
+		if err != nil {
+			return nil, fmt.Errorf("failed to add union to module %q: %w", modName, err)
+		}
+	}
 	err = mod.Patch()
 	if err != nil {
 		return nil, fmt.Errorf("failed to patch module %q: %w", modName, err)
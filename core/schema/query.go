@@ -45,8 +45,13 @@ func (s *querySchema) Install(srv *dagql.Server) {
 	core.TypeDefKinds.Install(srv)
 	core.ModuleSourceKindEnum.Install(srv)
 	core.ReturnTypesEnum.Install(srv)
+	core.NetworkModesEnum.Install(srv)
+	core.SBOMFormats.Install(srv)
+	core.ContainerChangeKinds.Install(srv)
+	core.ImageSquashModes.Install(srv)
 	core.ModuleSourceExperimentalFeatures.Install(srv)
 	core.FunctionCachePolicyEnum.Install(srv)
+	core.LLMToolPolicies.Install(srv)
 
 	dagql.MustInputSpec(PipelineLabel{}).Install(srv)
 	dagql.MustInputSpec(core.PortForward{}).Install(srv)
@@ -59,6 +59,20 @@ func (s *secretSchema) Install(srv *dagql.Server) {
 	}.Install(srv)
 }
 
+// checkSecretsCapability returns an error if the current client is a module
+// function that may not load secrets from the host's secret providers.
+// Secrets loaded by the engine itself, e.g. LLM API keys, are not restricted.
+func checkSecretsCapability(ctx context.Context) error {
+	if dagql.IsInternal(ctx) {
+		return nil
+	}
+	query, err := core.CurrentQuery(ctx)
+	if err != nil {
+		return err
+	}
+	return core.CheckModuleCapability(ctx, query, core.ModuleCapabilitySecrets)
+}
+
 type secretArgs struct {
 	URI      string
 	CacheKey dagql.Optional[dagql.String]
@@ -69,6 +83,10 @@ func (s *secretSchema) secret(
 	parent dagql.ObjectResult[*core.Query],
 	args secretArgs,
 ) (i dagql.ObjectResult[*core.Secret], err error) {
+	if err := checkSecretsCapability(ctx); err != nil {
+		return i, err
+	}
+
 	srv, err := core.CurrentDagqlServer(ctx)
 	if err != nil {
 		return i, fmt.Errorf("failed to get dagql server: %w", err)
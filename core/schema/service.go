@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"runtime/debug"
+	"time"
 
 	"github.com/dagger/dagger/core"
 	"github.com/dagger/dagger/dagql"
@@ -47,6 +48,12 @@ func (s *serviceSchema) Install(srv *dagql.Server) {
 					`This should only be used if the user requires that their exec process be the
 					pid 1 process in the container. Otherwise it may result in unexpected behavior.`,
 				),
+				dagql.Arg("networkMode").Doc(
+					`Restrict the network access of the service.`,
+					`Blocked connection attempts are reported as events on the service's span.`),
+				dagql.Arg("egressAllowlist").Doc(
+					`Hostnames and CIDRs the service may connect to, on top of what its network mode allows (e.g., ["proxy.golang.org", "10.0.0.0/8"]).`,
+					`Hostnames are resolved when the service starts. Requires a networkMode of NONE or SERVICES_ONLY.`),
 			),
 
 		dagql.NodeFunc("up", s.containerUpLegacy).
@@ -90,6 +97,22 @@ func (s *serviceSchema) Install(srv *dagql.Server) {
 					`This should only be used if the user requires that their exec process be the
 					pid 1 process in the container. Otherwise it may result in unexpected behavior.`,
 				),
+				dagql.Arg("networkMode").Doc(
+					`Restrict the network access of the service.`,
+					`Blocked connection attempts are reported as events on the service's span.`),
+				dagql.Arg("egressAllowlist").Doc(
+					`Hostnames and CIDRs the service may connect to, on top of what its network mode allows (e.g., ["proxy.golang.org", "10.0.0.0/8"]).`,
+					`Hostnames are resolved when the service starts. Requires a networkMode of NONE or SERVICES_ONLY.`),
+			),
+	}.Install(srv)
+
+	dagql.Fields[*core.Query]{
+		dagql.Func("runningService", s.runningService).
+			DoNotCache("Persistent services can start and stop at any time.").
+			Doc(`Attach to a persistent service running in the engine under the given name.`,
+				`The service may have been started by another session, with `+"`"+`start(persistent: true)`+"`"+`. Only the main client can attach to it.`).
+			Args(
+				dagql.Arg("name").Doc(`The name the service was started with.`),
 			),
 	}.Install(srv)
 
@@ -106,6 +129,15 @@ func (s *serviceSchema) Install(srv *dagql.Server) {
 				dagql.Arg("hostname").Doc(`The hostname to use.`),
 			),
 
+		dagql.Func("withName", s.withName).
+			Doc(`Configures a name for the service, under which it can be started as a persistent service shared across sessions.`).
+			Args(
+				dagql.Arg("name").Doc(`The name to use.`),
+			),
+
+		dagql.Func("name", s.name).
+			Doc(`The name of the service, if set with withName.`),
+
 		dagql.NodeFunc("ports", s.ports).
 			DoNotCache("A tunnel service's ports can change each time it is restarted.").
 			Doc(`Retrieves the list of ports provided by the service.`),
@@ -123,7 +155,15 @@ func (s *serviceSchema) Install(srv *dagql.Server) {
 		dagql.NodeFunc("start", s.start).
 			DoNotCache("Imperatively mutates runtime state.").
 			Doc(`Start the service and wait for its health checks to succeed.`,
-				`Services bound to a Container do not need to be manually started.`),
+				`Services bound to a Container do not need to be manually started.`).
+			Args(
+				dagql.Arg("persistent").Doc(
+					`Keep the service running after the session that started it ends.`,
+					`The service must have a name, set with withName. Other sessions can attach to it with runningService, or by starting or binding the same service.`),
+				dagql.Arg("idleTimeout").Doc(
+					`Stop a persistent service once nothing has used it for this long, as a duration string (e.g., "30m", "1h").`,
+					`If unset, the service runs until it's stopped.`),
+			),
 
 		dagql.NodeFunc("up", s.up).
 			DoNotCache("Starts a host tunnel, possibly with ports that change each time it's started.").
@@ -136,7 +176,7 @@ func (s *serviceSchema) Install(srv *dagql.Server) {
 
 		dagql.NodeFunc("stop", s.stop).
 			DoNotCache("Imperatively mutates runtime state.").
-			Doc(`Stop the service.`).
+			Doc(`Stop the service.`, `A persistent service can only be stopped by the main client.`).
 			Args(
 				dagql.Arg("kill").Doc(`Immediately kill the service without waiting for a graceful exit`),
 			),
@@ -199,6 +239,8 @@ func (s *serviceSchema) containerAsServiceLegacy(ctx context.Context, parent dag
 		ExperimentalPrivilegedNesting: withExecArgs.ExperimentalPrivilegedNesting,
 		InsecureRootCapabilities:      withExecArgs.InsecureRootCapabilities,
 		NoInit:                        withExecArgs.NoInit,
+		NetworkMode:                   withExecArgs.NetworkMode,
+		EgressAllowlist:               withExecArgs.EgressAllowlist,
 	})
 	if err != nil {
 		return inst, err
@@ -207,6 +249,10 @@ func (s *serviceSchema) containerAsServiceLegacy(ctx context.Context, parent dag
 }
 
 func (s *serviceSchema) containerAsService(ctx context.Context, parent *core.Container, args core.ContainerAsServiceArgs) (*core.Service, error) {
+	if err := checkExecCapabilities(ctx, args.ExperimentalPrivilegedNesting, args.NetworkMode); err != nil {
+		return nil, err
+	}
+
 	expandedArgs := make([]string, len(args.Args))
 	for i, arg := range args.Args {
 		expandedArg, err := expandEnvVar(ctx, parent, arg, args.Expand)
@@ -267,6 +313,18 @@ func (s *serviceSchema) containerUp(ctx context.Context, ctr dagql.ObjectResult[
 			Value: dagql.Boolean(true),
 		})
 	}
+	if args.NetworkMode != "" && args.NetworkMode != core.NetworkModeFull {
+		inputs = append(inputs, dagql.NamedInput{
+			Name:  "networkMode",
+			Value: args.NetworkMode,
+		})
+	}
+	if len(args.EgressAllowlist) > 0 {
+		inputs = append(inputs, dagql.NamedInput{
+			Name:  "egressAllowlist",
+			Value: dagql.ArrayInput[dagql.String](dagql.NewStringArray(args.EgressAllowlist...)),
+		})
+	}
 
 	var svc dagql.ObjectResult[*core.Service]
 	err = srv.Select(ctx, ctr, &svc,
@@ -317,6 +375,42 @@ func (s *serviceSchema) withHostname(ctx context.Context, parent *core.Service,
 	return parent.WithHostname(args.Hostname), nil
 }
 
+func (s *serviceSchema) withName(ctx context.Context, parent *core.Service, args struct {
+	Name string
+}) (*core.Service, error) {
+	return parent.WithName(args.Name), nil
+}
+
+func (s *serviceSchema) name(ctx context.Context, parent *core.Service, args struct{}) (string, error) {
+	return parent.Name, nil
+}
+
+func (s *serviceSchema) runningService(ctx context.Context, parent *core.Query, args struct {
+	Name string
+}) (inst dagql.ObjectResult[*core.Service], _ error) {
+	if err := parent.RequireMainClient(ctx); err != nil {
+		return inst, err
+	}
+	srv, err := core.CurrentDagqlServer(ctx)
+	if err != nil {
+		return inst, err
+	}
+	svcs, err := parent.Services(ctx)
+	if err != nil {
+		return inst, err
+	}
+	named, found := svcs.Named.Get(args.Name)
+	if !found {
+		return inst, fmt.Errorf("no persistent service named %q is running", args.Name)
+	}
+	svc, ok := named.Service.(*core.Service)
+	if !ok {
+		return inst, fmt.Errorf("unexpected service type %T", named.Service)
+	}
+	// keep the original ID, so the service resolves to the running one
+	return dagql.NewObjectResultForID(svc, srv, named.ID)
+}
+
 func (s *serviceSchema) ports(ctx context.Context, parent dagql.ObjectResult[*core.Service], args struct{}) (res dagql.Result[dagql.Array[core.Port]], _ error) {
 	ports, err := parent.Self().Ports(ctx, parent.ID())
 	if err != nil {
@@ -338,7 +432,12 @@ func (s *serviceSchema) endpoint(ctx context.Context, parent dagql.ObjectResult[
 	return dagql.NewResultForCurrentID(ctx, dagql.NewString(str))
 }
 
-func (s *serviceSchema) start(ctx context.Context, parent dagql.ObjectResult[*core.Service], args struct{}) (res dagql.Result[core.ServiceID], _ error) {
+type serviceStartArgs struct {
+	Persistent  bool `default:"false"`
+	IdleTimeout dagql.Optional[dagql.String]
+}
+
+func (s *serviceSchema) start(ctx context.Context, parent dagql.ObjectResult[*core.Service], args serviceStartArgs) (res dagql.Result[core.ServiceID], _ error) {
 	defer func() {
 		if err := recover(); err != nil {
 			debug.PrintStack()
@@ -346,8 +445,28 @@ func (s *serviceSchema) start(ctx context.Context, parent dagql.ObjectResult[*co
 		}
 	}()
 
-	if err := parent.Self().StartAndTrack(ctx, parent.ID()); err != nil {
-		return res, err
+	switch {
+	case args.Persistent:
+		var idleTimeout time.Duration
+		if args.IdleTimeout.Valid {
+			var err error
+			idleTimeout, err = time.ParseDuration(string(args.IdleTimeout.Value))
+			if err != nil {
+				return res, fmt.Errorf("failed to parse idle timeout %q: %w", args.IdleTimeout.Value, err)
+			}
+			if idleTimeout <= 0 {
+				return res, fmt.Errorf("idle timeout must be positive, got %q", args.IdleTimeout.Value)
+			}
+		}
+		if err := parent.Self().StartPersistent(ctx, parent.ID(), idleTimeout); err != nil {
+			return res, err
+		}
+	case args.IdleTimeout.Valid:
+		return res, fmt.Errorf("an idle timeout requires a persistent service")
+	default:
+		if err := parent.Self().StartAndTrack(ctx, parent.ID()); err != nil {
+			return res, err
+		}
 	}
 
 	id := dagql.NewID[*core.Service](parent.ID())
@@ -56,12 +56,18 @@ type Service struct {
 	// A custom hostname set by the user.
 	CustomHostname string
 
+	// A name set by the user, under which the service can be started as a
+	// persistent service shared with other sessions.
+	Name string
+
 	// Container is the container to run as a service.
 	Container                     *Container
 	Args                          []string
 	ExperimentalPrivilegedNesting bool
 	InsecureRootCapabilities      bool
 	NoInit                        bool
+	NetworkMode                   NetworkMode
+	EgressAllowlist               []string
 	ExecMD                        *buildkit.ExecutionMetadata
 	ExecMeta                      *executor.Meta
 
@@ -111,6 +117,12 @@ func (svc *Service) WithHostname(hostname string) *Service {
 	return svc
 }
 
+func (svc *Service) WithName(name string) *Service {
+	svc = svc.Clone()
+	svc.Name = name
+	return svc
+}
+
 func (svc *Service) Hostname(ctx context.Context, id *call.ID) (string, error) {
 	if svc.CustomHostname != "" {
 		return svc.CustomHostname, nil
@@ -121,6 +133,18 @@ func (svc *Service) Hostname(ctx context.Context, id *call.ID) (string, error) {
 		return "", err
 	}
 
+	if svc.Name != "" {
+		svcs, err := query.Services(ctx)
+		if err != nil {
+			return "", err
+		}
+		if named, found := svcs.Named.get(id.Digest()); found {
+			// the service may have been started by another session, so use
+			// its fully qualified hostname
+			return named.Running.Host, nil
+		}
+	}
+
 	switch {
 	case svc.TunnelUpstream.Self() != nil: // host=>container (127.0.0.1)
 		svcs, err := query.Services(ctx)
@@ -228,7 +252,8 @@ func (svc *Service) Endpoint(ctx context.Context, id *call.ID, port int, scheme
 		return "", fmt.Errorf("unknown service type")
 	}
 
-	endpoint := fmt.Sprintf("%s:%d", host, port)
+	// brackets IPv6 addresses, e.g. [fd87::2]:80
+	endpoint := net.JoinHostPort(host, strconv.Itoa(port))
 	if scheme != "" {
 		endpoint = scheme + "://" + endpoint
 	}
@@ -249,6 +274,35 @@ func (svc *Service) StartAndTrack(ctx context.Context, id *call.ID) error {
 	return err
 }
 
+// StartPersistent starts the service under its name, scoped to the engine
+// rather than to the current session. It keeps running after the session ends
+// until it's stopped, or until nothing has used it for idleTimeout, if set.
+func (svc *Service) StartPersistent(ctx context.Context, id *call.ID, idleTimeout time.Duration) error {
+	if svc.Name == "" {
+		return errors.New("a persistent service needs a name; set one with withName")
+	}
+	if svc.Container == nil {
+		// tunnels and host services depend on the client that started them
+		return errors.New("only container services can be persistent")
+	}
+	query, err := CurrentQuery(ctx)
+	if err != nil {
+		return err
+	}
+	svcs, err := query.Services(ctx)
+	if err != nil {
+		return err
+	}
+	for _, bnd := range svc.Container.Services {
+		// services started by the session would stop with it
+		if _, found := svcs.Named.get(bnd.Service.ID().Digest()); !found {
+			return fmt.Errorf("bound service %s must be started as a persistent service first", bnd.Hostname)
+		}
+	}
+	_, err = svcs.Named.Start(ctx, svc.Name, id, svc, idleTimeout)
+	return err
+}
+
 func (svc *Service) Stop(ctx context.Context, id *call.ID, kill bool) error {
 	query, err := CurrentQuery(ctx)
 	if err != nil {
@@ -258,6 +312,13 @@ func (svc *Service) Stop(ctx context.Context, id *call.ID, kill bool) error {
 	if err != nil {
 		return err
 	}
+	// persistent services are shared with other sessions, so only the main
+	// client may stop them
+	if _, found := svcs.Named.get(id.Digest()); found {
+		if err := query.RequireMainClient(ctx); err != nil {
+			return err
+		}
+	}
 	return svcs.Stop(ctx, id, kill, svc.TunnelUpstream.Self() != nil)
 }
 
@@ -344,6 +405,8 @@ func (svc *Service) startContainer(
 		execMD, err = ctr.execMeta(ctx, ContainerExecOpts{
 			ExperimentalPrivilegedNesting: svc.ExperimentalPrivilegedNesting,
 			NoInit:                        svc.NoInit,
+			NetworkMode:                   svc.NetworkMode,
+			EgressAllowlist:               svc.EgressAllowlist,
 		}, nil)
 		if err != nil {
 			return nil, err
@@ -822,9 +885,9 @@ func (svc *Service) startTunnel(ctx context.Context) (running *RunningService, r
 		}
 		res, closeListener, err := bk.ListenHostToContainer(
 			svcCtx,
-			fmt.Sprintf("%s:%d", bindHost, frontend),
+			net.JoinHostPort(bindHost, strconv.Itoa(frontend)),
 			forward.Protocol.Network(),
-			fmt.Sprintf("%s:%d", upstream.Host, forward.Backend),
+			net.JoinHostPort(upstream.Host, strconv.Itoa(forward.Backend)),
 		)
 		if err != nil {
 			return nil, fmt.Errorf("host to container: %w", err)
@@ -840,7 +903,9 @@ func (svc *Service) startTunnel(ctx context.Context) (running *RunningService, r
 			return nil, fmt.Errorf("parse port: %w", err)
 		}
 
-		desc := fmt.Sprintf("tunnel %s:%d -> %s:%d", bindHost, frontend, upstream.Host, forward.Backend)
+		desc := fmt.Sprintf("tunnel %s -> %s",
+			net.JoinHostPort(bindHost, strconv.Itoa(frontend)),
+			net.JoinHostPort(upstream.Host, strconv.Itoa(forward.Backend)))
 
 		ports[i] = Port{
 			Port:        frontend,
@@ -35,6 +35,9 @@ type Services struct {
 	running  map[ServiceKey]*RunningService
 	bindings map[ServiceKey]int
 	l        sync.Mutex
+
+	// Named tracks the persistent services shared with other sessions.
+	Named *NamedServices
 }
 
 // RunningService represents a service that is actively running.
@@ -84,9 +87,17 @@ func NewServices() *Services {
 		starting: map[ServiceKey]*sync.WaitGroup{},
 		running:  map[ServiceKey]*RunningService{},
 		bindings: map[ServiceKey]int{},
+		Named:    NewNamedServices(),
 	}
 }
 
+// Running returns the number of services that are currently running.
+func (ss *Services) Running() int {
+	ss.l.Lock()
+	defer ss.l.Unlock()
+	return len(ss.running)
+}
+
 // Get returns the running service for the given service. If the service is
 // starting, it waits for it and either returns the running service or an error
 // if it failed to start. If the service is not running or starting, an error
@@ -107,6 +118,10 @@ func (ss *Services) Get(ctx context.Context, id *call.ID, clientSpecific bool) (
 		key.ClientID = clientMetadata.ClientID
 	}
 
+	if named, found := ss.Named.get(dig); found {
+		return named.Running, nil
+	}
+
 	notRunningErr := fmt.Errorf("service %s is not running", network.HostHash(dig))
 
 	for {
@@ -163,6 +178,13 @@ func (ss *Services) StartWithIO(
 		key.ClientID = clientMetadata.ClientID
 	}
 
+	if sio == nil {
+		// attach to the service if it's already running as a named service
+		if running, found := ss.Named.attach(dig); found {
+			return running, nil
+		}
+	}
+
 dance:
 	for {
 		ss.l.Lock()
@@ -272,6 +294,10 @@ func (ss *Services) Stop(ctx context.Context, id *call.ID, kill bool, clientSpec
 		key.ClientID = clientMetadata.ClientID
 	}
 
+	if named, found := ss.Named.get(dig); found {
+		return ss.Named.stop(ctx, named, kill)
+	}
+
 	ss.l.Lock()
 	starting, isStarting := ss.starting[key]
 	running, isRunning := ss.running[key]
@@ -334,6 +360,11 @@ func (ss *Services) StopSessionServices(ctx context.Context, sessionID string) e
 // a no-op. If the service is running, it is stopped if there are no other
 // clients using it.
 func (ss *Services) Detach(ctx context.Context, svc *RunningService) {
+	if ss.Named.detach(svc) {
+		// named services are stopped explicitly, or once idle
+		return
+	}
+
 	ss.l.Lock()
 
 	slog := slog.With("service", svc.Host)
@@ -0,0 +1,343 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/opencontainers/go-digest"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/dagger/dagger/dagql/call"
+	"github.com/dagger/dagger/engine/slog"
+)
+
+// NamedServices manages services started with a name as persistent.
+//
+// Unlike the services tracked by Services, they're scoped to the engine rather
+// than to the session that started them: they keep running when that session
+// ends, and later sessions can attach to them by name. They're stopped
+// explicitly, or once they've been idle for longer than their idle timeout.
+type NamedServices struct {
+	starting map[string]*sync.WaitGroup
+	running  map[string]*NamedService
+	l        sync.Mutex
+}
+
+// NamedService is a persistent service running under a name.
+type NamedService struct {
+	// Name is the name the service was started with.
+	Name string
+
+	// ID is the ID of the service, as it was started.
+	ID *call.ID
+
+	// Service is the service that was started.
+	Service Startable
+
+	// Running is the running service.
+	Running *RunningService
+
+	// StartedAt is when the service started.
+	StartedAt time.Time
+
+	// IdleTimeout is how long the service keeps running without any binding
+	// using it. Zero means it runs until it's stopped.
+	IdleTimeout time.Duration
+
+	// bindings is the number of bindings using the service, across sessions.
+	bindings int
+
+	// idleSince is when the last binding detached from the service.
+	idleSince time.Time
+
+	// idleTimer stops the service once it's been idle for IdleTimeout.
+	idleTimer *time.Timer
+}
+
+// NamedServiceInfo is a snapshot of the state of a named service.
+type NamedServiceInfo struct {
+	Name        string
+	Host        string
+	Ports       []Port
+	StartedAt   time.Time
+	IdleTimeout time.Duration
+	IdleSince   time.Time
+	Bindings    int
+}
+
+// NewNamedServices returns a new NamedServices.
+func NewNamedServices() *NamedServices {
+	return &NamedServices{
+		starting: map[string]*sync.WaitGroup{},
+		running:  map[string]*NamedService{},
+	}
+}
+
+// Running returns the number of named services that are currently running.
+func (ns *NamedServices) Running() int {
+	ns.l.Lock()
+	defer ns.l.Unlock()
+	return len(ns.running)
+}
+
+// Start starts the given service under a name. If a service is already
+// running under that name, it is returned as long as it's the same service;
+// a different one needs to be stopped first.
+func (ns *NamedServices) Start(
+	ctx context.Context,
+	name string,
+	id *call.ID,
+	svc Startable,
+	idleTimeout time.Duration,
+) (*RunningService, error) {
+	if name == "" {
+		return nil, fmt.Errorf("a persistent service needs a name")
+	}
+
+	dig := id.Digest()
+
+	for {
+		ns.l.Lock()
+		starting, isStarting := ns.starting[name]
+		existing, isRunning := ns.running[name]
+		switch {
+		case isRunning && existing.ID.Digest() != dig:
+			ns.l.Unlock()
+			return nil, fmt.Errorf("a different service is already running as %q; stop it first", name)
+		case isRunning:
+			if idleTimeout != existing.IdleTimeout {
+				existing.IdleTimeout = idleTimeout
+				ns.resetIdleTimer(existing)
+			}
+			ns.l.Unlock()
+			return existing.Running, nil
+		case isStarting:
+			ns.l.Unlock()
+			starting.Wait()
+			continue
+		}
+		starting = new(sync.WaitGroup)
+		starting.Add(1)
+		defer starting.Done()
+		ns.starting[name] = starting
+		ns.l.Unlock()
+		break
+	}
+
+	// the service outlives the request, and the session, that started it
+	running, err := svc.Start(context.WithoutCancel(ctx), id, nil)
+	if err != nil {
+		ns.l.Lock()
+		delete(ns.starting, name)
+		ns.l.Unlock()
+		return nil, err
+	}
+	running.Key = ServiceKey{Digest: dig}
+
+	named := &NamedService{
+		Name:        name,
+		ID:          id,
+		Service:     svc,
+		Running:     running,
+		StartedAt:   time.Now(),
+		IdleTimeout: idleTimeout,
+		idleSince:   time.Now(),
+	}
+
+	ns.l.Lock()
+	delete(ns.starting, name)
+	ns.running[name] = named
+	ns.resetIdleTimer(named)
+	ns.l.Unlock()
+
+	go func() {
+		// forget about the service if it exits on its own
+		err := running.Wait(context.Background())
+		slog.Info("named service exited", "name", name, "err", err)
+		ns.forget(named)
+	}()
+
+	return running, nil
+}
+
+// Get returns the named service running under the given name.
+func (ns *NamedServices) Get(name string) (*NamedService, bool) {
+	ns.l.Lock()
+	defer ns.l.Unlock()
+	named, found := ns.running[name]
+	return named, found
+}
+
+// List returns the state of all the running named services, sorted by name.
+func (ns *NamedServices) List() []NamedServiceInfo {
+	ns.l.Lock()
+	defer ns.l.Unlock()
+	infos := make([]NamedServiceInfo, 0, len(ns.running))
+	for _, named := range ns.running {
+		info := NamedServiceInfo{
+			Name:        named.Name,
+			Host:        named.Running.Host,
+			Ports:       named.Running.Ports,
+			StartedAt:   named.StartedAt,
+			IdleTimeout: named.IdleTimeout,
+			Bindings:    named.bindings,
+		}
+		if named.bindings == 0 {
+			info.IdleSince = named.idleSince
+		}
+		infos = append(infos, info)
+	}
+	slices.SortFunc(infos, func(a, b NamedServiceInfo) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return infos
+}
+
+// Stop stops the service running under the given name. If no service is
+// running under that name, it is a no-op.
+func (ns *NamedServices) Stop(ctx context.Context, name string, kill bool) error {
+	ns.l.Lock()
+	named, found := ns.running[name]
+	ns.l.Unlock()
+	if !found {
+		return nil
+	}
+	return ns.stop(ctx, named, kill)
+}
+
+// StopAll stops all the named services. It is called when the engine is
+// shutting down.
+func (ns *NamedServices) StopAll(ctx context.Context) error {
+	ns.l.Lock()
+	named := make([]*NamedService, 0, len(ns.running))
+	for _, svc := range ns.running {
+		named = append(named, svc)
+	}
+	ns.l.Unlock()
+
+	eg := new(errgroup.Group)
+	for _, svc := range named {
+		eg.Go(func() error {
+			if err := ns.stop(ctx, svc, true); err != nil {
+				return fmt.Errorf("stop %s: %w", svc.Name, err)
+			}
+			return nil
+		})
+	}
+	return eg.Wait()
+}
+
+// attach returns the named service with the given digest, if any, counting a
+// new binding to it.
+func (ns *NamedServices) attach(dig digest.Digest) (*RunningService, bool) {
+	ns.l.Lock()
+	defer ns.l.Unlock()
+	named, found := ns.byDigest(dig)
+	if !found {
+		return nil, false
+	}
+	named.bindings++
+	ns.resetIdleTimer(named)
+	return named.Running, true
+}
+
+// get returns the named service with the given digest, if any.
+func (ns *NamedServices) get(dig digest.Digest) (*NamedService, bool) {
+	ns.l.Lock()
+	defer ns.l.Unlock()
+	return ns.byDigest(dig)
+}
+
+// detach removes a binding to the given service, if it's a named service. It
+// returns false if it isn't one.
+func (ns *NamedServices) detach(running *RunningService) bool {
+	ns.l.Lock()
+	defer ns.l.Unlock()
+	for _, named := range ns.running {
+		if named.Running != running {
+			continue
+		}
+		if named.bindings > 0 {
+			named.bindings--
+		}
+		if named.bindings == 0 {
+			named.idleSince = time.Now()
+		}
+		ns.resetIdleTimer(named)
+		return true
+	}
+	return false
+}
+
+// requires that ns.l is held
+func (ns *NamedServices) byDigest(dig digest.Digest) (*NamedService, bool) {
+	for _, named := range ns.running {
+		if named.ID.Digest() == dig {
+			return named, true
+		}
+	}
+	return nil, false
+}
+
+// resetIdleTimer stops the service after its idle timeout if nothing is
+// using it, or cancels the pending stop otherwise.
+//
+// requires that ns.l is held
+func (ns *NamedServices) resetIdleTimer(named *NamedService) {
+	if named.idleTimer != nil {
+		named.idleTimer.Stop()
+		named.idleTimer = nil
+	}
+	if named.bindings > 0 || named.IdleTimeout <= 0 {
+		return
+	}
+	timeout := named.IdleTimeout - time.Since(named.idleSince)
+	named.idleTimer = time.AfterFunc(max(timeout, 0), func() {
+		ns.l.Lock()
+		idle := ns.running[named.Name] == named && named.bindings == 0
+		ns.l.Unlock()
+		if !idle {
+			return
+		}
+		slog.Info("stopping idle named service", "name", named.Name, "idleTimeout", named.IdleTimeout)
+		if err := ns.stopGraceful(named); err != nil {
+			slog.Warn("error stopping idle named service", "name", named.Name, "error", err)
+		}
+	})
+}
+
+func (ns *NamedServices) stop(ctx context.Context, named *NamedService, kill bool) error {
+	if err := named.Running.Stop(ctx, kill); err != nil {
+		return fmt.Errorf("stop: %w", err)
+	}
+	ns.forget(named)
+	return nil
+}
+
+func (ns *NamedServices) stopGraceful(named *NamedService) error {
+	ctx, cancel := context.WithTimeout(context.Background(), TerminateGracePeriod)
+	defer cancel()
+	if err := named.Running.Stop(ctx, false); err != nil {
+		if err := named.Running.Stop(context.Background(), true); err != nil {
+			return err
+		}
+	}
+	ns.forget(named)
+	return nil
+}
+
+func (ns *NamedServices) forget(named *NamedService) {
+	ns.l.Lock()
+	defer ns.l.Unlock()
+	if named.idleTimer != nil {
+		named.idleTimer.Stop()
+		named.idleTimer = nil
+	}
+	if ns.running[named.Name] == named {
+		delete(ns.running, named.Name)
+	}
+}
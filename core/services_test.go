@@ -420,3 +420,120 @@ func TestServicesDetachRace(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, runningB, retrieved)
 }
+
+func TestNamedServicesSharedAcrossSessions(t *testing.T) {
+	t.Parallel()
+
+	named := core.NewNamedServices()
+	newSession := func(sessionID string) (context.Context, *core.Services) {
+		ctx := engine.ContextWithClientMetadata(context.Background(), &engine.ClientMetadata{
+			SessionID: sessionID,
+			ClientID:  sessionID + "-client",
+		})
+		services := core.NewServices()
+		services.Named = named
+		return ctx, services
+	}
+
+	ctxA, servicesA := newSession("session-a")
+	stub := newStartable("pg")
+	expected := stub.Succeed()
+	stopped := make(chan bool, 1)
+	expected.Stop = func(ctx context.Context, force bool) error {
+		stopped <- force
+		return nil
+	}
+
+	running, err := named.Start(ctxA, "pg", stub.ID(), stub, 0)
+	require.NoError(t, err)
+	require.Equal(t, expected, running)
+
+	// the service outlives the session that started it
+	require.NoError(t, servicesA.StopSessionServices(ctxA, "session-a"))
+	_, found := named.Get("pg")
+	require.True(t, found)
+
+	// starting it again under the same name returns the running service
+	running, err = named.Start(ctxA, "pg", stub.ID(), stub, 0)
+	require.NoError(t, err)
+	require.Equal(t, expected, running)
+	require.Equal(t, 1, stub.Starts())
+
+	// but a different service can't take its name
+	_, err = named.Start(ctxA, "pg", newStartable("other").ID(), newStartable("other"), 0)
+	require.ErrorContains(t, err, `a different service is already running as "pg"`)
+
+	// another session attaches to it instead of starting a new one
+	ctxB, servicesB := newSession("session-b")
+	running, err = servicesB.Start(ctxB, stub.ID(), stub, false)
+	require.NoError(t, err)
+	require.Equal(t, expected, running)
+	require.Equal(t, 1, stub.Starts())
+	require.Equal(t, 1, named.List()[0].Bindings)
+
+	got, err := servicesB.Get(ctxB, stub.ID(), false)
+	require.NoError(t, err)
+	require.Equal(t, expected, got)
+
+	// detaching leaves it running
+	servicesB.Detach(ctxB, running)
+	infos := named.List()
+	require.Len(t, infos, 1)
+	require.Equal(t, "pg", infos[0].Name)
+	require.Equal(t, "pg-host", infos[0].Host)
+	require.Zero(t, infos[0].Bindings)
+	require.False(t, infos[0].IdleSince.IsZero())
+
+	// stopping it from any session stops it for all of them
+	require.NoError(t, servicesB.Stop(ctxB, stub.ID(), true, false))
+	require.True(t, <-stopped)
+	stub.Exit(nil)
+	_, found = named.Get("pg")
+	require.False(t, found)
+	require.Empty(t, named.List())
+}
+
+func TestNamedServicesIdleTimeout(t *testing.T) {
+	t.Parallel()
+
+	ctx := engine.ContextWithClientMetadata(context.Background(), &engine.ClientMetadata{
+		SessionID: "test-session",
+		ClientID:  "test-client",
+	})
+
+	named := core.NewNamedServices()
+	services := core.NewServices()
+	services.Named = named
+
+	stub := newStartable("idle")
+	expected := stub.Succeed()
+	stopped := make(chan struct{})
+	expected.Stop = func(ctx context.Context, force bool) error {
+		close(stopped)
+		return nil
+	}
+
+	_, err := named.Start(ctx, "idle", stub.ID(), stub, 100*time.Millisecond)
+	require.NoError(t, err)
+
+	// a binding keeps it from being stopped
+	running, err := services.Start(ctx, stub.ID(), stub, false)
+	require.NoError(t, err)
+	select {
+	case <-stopped:
+		t.Fatal("service was stopped while in use")
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	services.Detach(ctx, running)
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("idle service was not stopped")
+	}
+	require.Eventually(t, func() bool {
+		_, found := named.Get("idle")
+		return !found
+	}, 5*time.Second, 10*time.Millisecond)
+	stub.Exit(nil)
+}
@@ -9,6 +9,7 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"strconv"
 	"sync"
 
 	"github.com/dagger/dagger/engine"
@@ -185,7 +186,7 @@ func (store *SocketStore) getSocketURLEncoded(sock *storedSocket) string {
 		u.Path = sock.HostPath
 	default:
 		u.Scheme = sock.PortForward.Protocol.Network()
-		u.Host = fmt.Sprintf("%s:%d", sock.HostEndpoint, sock.PortForward.Backend)
+		u.Host = net.JoinHostPort(sock.HostEndpoint, strconv.Itoa(sock.PortForward.Backend))
 	}
 
 	return u.String()
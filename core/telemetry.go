@@ -120,6 +120,9 @@ func AroundFunc(
 
 	return ctx, func(res dagql.AnyResult, cached bool, err *error) {
 		defer telemetry.EndWithCause(span, err)
+		if *err == nil {
+			*err = breakOnCall(ctx, id, res)
+		}
 		recordStatus(ctx, res, span, cached, err, id)
 		logResult(ctx, res, self, id)
 		collectEffects(ctx, res, span, self)
@@ -237,6 +240,7 @@ func recordStatus(ctx context.Context, res dagql.AnyResult, span trace.Span, cac
 	if cached {
 		span.SetAttributes(attribute.Bool(telemetry.CachedAttr, true))
 	}
+	recordCallHistory(ctx, span, cached, id)
 
 	if ctx.Err() != nil {
 		// If the request was canceled, reflect it on the span.
@@ -431,3 +435,40 @@ func anyReturns(id *call.ID, types ...string) bool {
 		return false
 	}
 }
+
+// recordCallHistory remembers a call that missed the cache for future
+// comparisons, and points its span to the most similar call seen before it,
+// possibly in a previous run, so that users can find out why it had to run
+// again.
+func recordCallHistory(ctx context.Context, span trace.Span, cached bool, id *call.ID) {
+	if cached {
+		return
+	}
+	query, err := CurrentQuery(ctx)
+	if err != nil {
+		return
+	}
+	history := query.CallHistory()
+	if history == nil {
+		return
+	}
+	defer history.Add(id)
+
+	prev, _ := history.Closest(id)
+	if prev == nil {
+		return
+	}
+	// spans are exported, so don't leak argument values through them
+	diffs := call.RedactedDiff(prev, id)
+	if len(diffs) == 0 {
+		return
+	}
+	reasons := make([]string, len(diffs))
+	for i, diff := range diffs {
+		reasons[i] = diff.String()
+	}
+	span.SetAttributes(
+		attribute.String(telemetry.DagCacheMissPreviousAttr, prev.Digest().String()),
+		attribute.StringSlice(telemetry.DagCacheMissReasonsAttr, reasons),
+	)
+}
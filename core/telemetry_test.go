@@ -113,8 +113,11 @@ func (ms *mockServer) FileSyncer() *filesync.FileSyncer            { return nil
 func (ms *mockServer) ClientTelemetry(ctc context.Context, sessID, clientID string) (*clientdb.DB, error) {
 	return nil, nil
 }
-func (ms *mockServer) EngineName() string { return "mockEngine" }
-func (ms *mockServer) Clients() []string  { return []string{} }
+func (ms *mockServer) EngineName() string            { return "mockEngine" }
+func (ms *mockServer) Clients() []string             { return []string{} }
+func (ms *mockServer) Sessions() []*EngineSession    { return nil }
+func (ms *mockServer) CallHistory() *call.History    { return nil }
+func (ms *mockServer) DebuggedExecs() *DebuggedExecs { return nil }
 
 func (ms *mockServer) CloudEngineClient(context.Context, string, string, []string) (*engineclient.Client, bool, error) {
 	return nil, false, nil
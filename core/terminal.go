@@ -65,7 +65,11 @@ func (container *Container) terminal(
 		return fmt.Errorf("failed to evaluate container: %w", err)
 	}
 
-	term, output, err := prepTerminal(ctx, svcID, richErr)
+	var execErr error
+	if richErr != nil {
+		execErr = richErr
+	}
+	term, output, err := prepTerminal(ctx, svcID, execErr)
 	if err != nil {
 		return err
 	}
@@ -198,7 +202,7 @@ func (*Service) Terminal(
 	})
 }
 
-func prepTerminal(ctx context.Context, svcID *call.ID, richErr *buildkit.RichError) (*buildkit.TerminalClient, *termenv.Output, error) {
+func prepTerminal(ctx context.Context, svcID *call.ID, execErr error) (*buildkit.TerminalClient, *termenv.Output, error) {
 	query, err := CurrentQuery(ctx)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to get current query: %w", err)
@@ -214,7 +218,7 @@ func prepTerminal(ctx context.Context, svcID *call.ID, richErr *buildkit.RichErr
 	}
 
 	output := idtui.NewOutput(term.Stderr)
-	if richErr == nil {
+	if execErr == nil {
 		fmt.Fprint(
 			term.Stderr,
 			output.String(idtui.DotFilled).Foreground(termenv.ANSIYellow).String()+" Attaching terminal: ",
@@ -231,9 +235,9 @@ func prepTerminal(ctx context.Context, svcID *call.ID, richErr *buildkit.RichErr
 		return nil, nil, fmt.Errorf("failed to serialize service ID: %w", err)
 	}
 	fmt.Fprint(term.Stderr, dump.Newline)
-	if richErr != nil {
+	if execErr != nil {
 		fmt.Fprintf(term.Stderr,
-			output.String("! %s").Foreground(termenv.ANSIYellow).String(), richErr.Error())
+			output.String("! %s").Foreground(termenv.ANSIYellow).String(), execErr.Error())
 		fmt.Fprint(term.Stderr, dump.Newline)
 	}
 
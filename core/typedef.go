@@ -204,7 +204,7 @@ func (fn *Function) WithCheck() *Function {
 	return fn
 }
 
-func (fn *Function) WithArg(name string, typeDef *TypeDef, desc string, defaultValue JSON, defaultPath string, ignore []string, sourceMap *SourceMap, deprecated *string) *Function {
+func (fn *Function) WithArg(name string, typeDef *TypeDef, desc string, defaultValue JSON, defaultPath string, ignore []string, sourceMap *SourceMap, deprecated *string, constraints *FunctionArgConstraints) *Function {
 	fn = fn.Clone()
 	arg := &FunctionArg{
 		Name:         strcase.ToLowerCamel(name),
@@ -219,6 +219,9 @@ func (fn *Function) WithArg(name string, typeDef *TypeDef, desc string, defaultV
 	if sourceMap != nil {
 		arg.SourceMap = dagql.NonNull(sourceMap)
 	}
+	if !constraints.IsEmpty() {
+		arg.Constraints = dagql.NonNull(constraints)
+	}
 	fn.Args = append(fn.Args, arg)
 	return fn
 }
@@ -316,14 +319,15 @@ func (proto FunctionCachePolicy) ToLiteral() call.Literal {
 
 type FunctionArg struct {
 	// Name is the standardized name of the argument (lowerCamelCase), as used for the resolver in the graphql schema
-	Name         string                     `field:"true" doc:"The name of the argument in lowerCamelCase format."`
-	Description  string                     `field:"true" doc:"A doc string for the argument, if any."`
-	SourceMap    dagql.Nullable[*SourceMap] `field:"true" doc:"The location of this arg declaration."`
-	TypeDef      *TypeDef                   `field:"true" doc:"The type of the argument."`
-	DefaultValue JSON                       `field:"true" doc:"A default value to use for this argument when not explicitly set by the caller, if any."`
-	DefaultPath  string                     `field:"true" doc:"Only applies to arguments of type File or Directory. If the argument is not set, load it from the given path in the context directory"`
-	Ignore       []string                   `field:"true" doc:"Only applies to arguments of type Directory. The ignore patterns are applied to the input directory, and matching entries are filtered out, in a cache-efficient manner."`
-	Deprecated   *string                    `field:"true" doc:"The reason this function is deprecated, if any."`
+	Name         string                                  `field:"true" doc:"The name of the argument in lowerCamelCase format."`
+	Description  string                                  `field:"true" doc:"A doc string for the argument, if any."`
+	SourceMap    dagql.Nullable[*SourceMap]              `field:"true" doc:"The location of this arg declaration."`
+	TypeDef      *TypeDef                                `field:"true" doc:"The type of the argument."`
+	DefaultValue JSON                                    `field:"true" doc:"A default value to use for this argument when not explicitly set by the caller, if any."`
+	DefaultPath  string                                  `field:"true" doc:"Only applies to arguments of type File or Directory. If the argument is not set, load it from the given path in the context directory"`
+	Ignore       []string                                `field:"true" doc:"Only applies to arguments of type Directory. The ignore patterns are applied to the input directory, and matching entries are filtered out, in a cache-efficient manner."`
+	Deprecated   *string                                 `field:"true" doc:"The reason this function is deprecated, if any."`
+	Constraints  dagql.Nullable[*FunctionArgConstraints] `field:"true" doc:"Constraints the value of the argument must satisfy, if any."`
 
 	// Below are not in public API
 
@@ -339,6 +343,9 @@ func (arg FunctionArg) Clone() *FunctionArg {
 	if arg.SourceMap.Valid {
 		cp.SourceMap.Value = arg.SourceMap.Value.Clone()
 	}
+	if arg.Constraints.Valid {
+		cp.Constraints.Value = arg.Constraints.Value.Clone()
+	}
 	// NB(vito): don't bother copying DefaultValue, it's already 'any' so it's
 	// hard to imagine anything actually mutating it at runtime vs. replacing it
 	// wholesale.
@@ -479,6 +486,7 @@ type TypeDef struct {
 	AsInput     dagql.Nullable[*InputTypeDef]     `field:"true" doc:"If kind is INPUT, the input-specific type definition. If kind is not INPUT, this will be null."`
 	AsScalar    dagql.Nullable[*ScalarTypeDef]    `field:"true" doc:"If kind is SCALAR, the scalar-specific type definition. If kind is not SCALAR, this will be null."`
 	AsEnum      dagql.Nullable[*EnumTypeDef]      `field:"true" doc:"If kind is ENUM, the enum-specific type definition. If kind is not ENUM, this will be null."`
+	AsUnion     dagql.Nullable[*UnionTypeDef]     `field:"true" doc:"If kind is UNION, the union-specific type definition. If kind is not UNION, this will be null."`
 }
 
 func (typeDef TypeDef) Clone() *TypeDef {
@@ -501,6 +509,9 @@ func (typeDef TypeDef) Clone() *TypeDef {
 	if typeDef.AsEnum.Valid {
 		cp.AsEnum.Value = typeDef.AsEnum.Value.Clone()
 	}
+	if typeDef.AsUnion.Valid {
+		cp.AsUnion.Value = typeDef.AsUnion.Value.Clone()
+	}
 	return &cp
 }
 
@@ -536,6 +547,8 @@ func (typeDef *TypeDef) ToTyped() dagql.Typed {
 		typed = &ModuleObject{TypeDef: typeDef.AsObject.Value}
 	case TypeDefKindInterface:
 		typed = &InterfaceAnnotatedValue{TypeDef: typeDef.AsInterface.Value}
+	case TypeDefKindUnion:
+		typed = typeDef.AsUnion.Value.ToUnionSpec()
 	case TypeDefKindVoid:
 		typed = Void{}
 	case TypeDefKindInput:
@@ -572,6 +585,8 @@ func (typeDef *TypeDef) ToInput() dagql.Input {
 		typed = DynamicID{typeName: typeDef.AsObject.Value.Name}
 	case TypeDefKindInterface:
 		typed = DynamicID{typeName: typeDef.AsInterface.Value.Name}
+	case TypeDefKindUnion:
+		typed = UnionInput{TypeDef: typeDef.AsUnion.Value}
 	case TypeDefKindVoid:
 		typed = Void{}
 	default:
@@ -628,6 +643,31 @@ func (typeDef *TypeDef) WithInterface(name, desc string, sourceMap *SourceMap) *
 	return typeDef
 }
 
+func (typeDef *TypeDef) WithUnion(name, desc string, sourceMap *SourceMap) *TypeDef {
+	typeDef = typeDef.WithKind(TypeDefKindUnion)
+	typeDef.AsUnion = dagql.NonNull(NewUnionTypeDef(name, desc).WithSourceMap(sourceMap))
+	return typeDef
+}
+
+func (typeDef *TypeDef) WithUnionMember(member *TypeDef) (*TypeDef, error) {
+	if typeDef.Kind != TypeDefKindUnion {
+		return nil, fmt.Errorf("cannot add member to non-union type: %s", typeDef.Kind)
+	}
+	if member.Kind != TypeDefKindObject {
+		return nil, fmt.Errorf("union members must be objects, not %s", member.Kind)
+	}
+	if member.Optional {
+		return nil, fmt.Errorf("union members cannot be optional")
+	}
+	typeDef = typeDef.Clone()
+	union := typeDef.AsUnion.Value
+	if _, ok := union.MemberByName(member.AsObject.Value.Name); ok {
+		return nil, fmt.Errorf("union %q already has member %q", union.OriginalName, member.AsObject.Value.Name)
+	}
+	union.Members = append(union.Members, member)
+	return typeDef, nil
+}
+
 func (typeDef *TypeDef) WithOptional(optional bool) *TypeDef {
 	typeDef = typeDef.Clone()
 	typeDef.Optional = optional
@@ -777,6 +817,9 @@ func (typeDef *TypeDef) IsSubtypeOf(otherDef *TypeDef) bool {
 			return typeDef.AsObject.Value.Name == otherDef.AsObject.Value.Name
 		case TypeDefKindInterface:
 			return typeDef.AsObject.Value.IsSubtypeOf(otherDef.AsInterface.Value)
+		case TypeDefKindUnion:
+			_, ok := otherDef.AsUnion.Value.MemberByName(typeDef.AsObject.Value.Name)
+			return ok
 		default:
 			return false
 		}
@@ -785,6 +828,11 @@ func (typeDef *TypeDef) IsSubtypeOf(otherDef *TypeDef) bool {
 			return false
 		}
 		return typeDef.AsInterface.Value.IsSubtypeOf(otherDef.AsInterface.Value)
+	case TypeDefKindUnion:
+		if otherDef.Kind != TypeDefKindUnion {
+			return false
+		}
+		return typeDef.AsUnion.Value.Name == otherDef.AsUnion.Value.Name
 	default:
 		return false
 	}
@@ -1065,6 +1113,134 @@ func (iface *InterfaceTypeDef) IsSubtypeOf(otherIface *InterfaceTypeDef) bool {
 	return true
 }
 
+type UnionTypeDef struct {
+	// Name is the standardized name of the union (CamelCase), as used for the union in the graphql schema
+	Name        string                     `field:"true" doc:"The name of the union."`
+	Description string                     `field:"true" doc:"The doc string for the union, if any."`
+	SourceMap   dagql.Nullable[*SourceMap] `field:"true" doc:"The location of this union declaration."`
+	Members     []*TypeDef                 `field:"true" doc:"The types a value of this union can have. They are always objects."`
+	// SourceModuleName is currently only set when returning the TypeDef from the Unions field on Module
+	SourceModuleName string `field:"true" doc:"If this UnionTypeDef is associated with a Module, the name of the module. Unset otherwise."`
+
+	// Below are not in public API
+
+	// The original name of the union as provided by the SDK that defined it, used
+	// when invoking the SDK so it doesn't need to think as hard about case conversions
+	OriginalName string
+}
+
+func NewUnionTypeDef(name, description string) *UnionTypeDef {
+	return &UnionTypeDef{
+		Name:         strcase.ToCamel(name),
+		OriginalName: name,
+		Description:  description,
+	}
+}
+
+func (*UnionTypeDef) Type() *ast.Type {
+	return &ast.Type{
+		NamedType: "UnionTypeDef",
+		NonNull:   true,
+	}
+}
+
+func (*UnionTypeDef) TypeDescription() string {
+	return dagql.FormatDescription(
+		`A definition of a custom union defined in a Module.`,
+		`A value of a union has exactly one of the union's member types.`)
+}
+
+func (union UnionTypeDef) Clone() *UnionTypeDef {
+	cp := union
+
+	cp.Members = make([]*TypeDef, len(union.Members))
+	for i, member := range union.Members {
+		cp.Members[i] = member.Clone()
+	}
+	if cp.SourceMap.Valid {
+		cp.SourceMap.Value = cp.SourceMap.Value.Clone()
+	}
+
+	return &cp
+}
+
+func (union *UnionTypeDef) WithSourceMap(sourceMap *SourceMap) *UnionTypeDef {
+	if sourceMap == nil {
+		return union
+	}
+	union = union.Clone()
+	union.SourceMap = dagql.NonNull(sourceMap)
+	return union
+}
+
+// MemberByName returns the member of the union with the given object name,
+// or original name.
+func (union *UnionTypeDef) MemberByName(name string) (*TypeDef, bool) {
+	for _, member := range union.Members {
+		obj := member.AsObject.Value
+		if obj.Name == name || obj.OriginalName == name {
+			return member, true
+		}
+	}
+	return nil, false
+}
+
+// ToUnionSpec returns the GraphQL union of the member object types.
+func (union *UnionTypeDef) ToUnionSpec() dagql.UnionSpec {
+	spec := dagql.UnionSpec{
+		Name:        union.Name,
+		Description: union.Description,
+	}
+	for _, member := range union.Members {
+		spec.Members = append(spec.Members, member.AsObject.Value.Name)
+	}
+	if union.SourceMap.Valid {
+		spec.Directives = append(spec.Directives, union.SourceMap.Value.TypeDirective())
+	}
+	return spec
+}
+
+// InputName returns the name of the input object used to pass a value of the
+// union as an argument.
+func (union *UnionTypeDef) InputName() string {
+	return union.Name + "Input"
+}
+
+// InputFieldName returns the name of the field of the union's input object
+// that holds the ID of a value of the given member type.
+func (union *UnionTypeDef) InputFieldName(member *TypeDef) string {
+	return gqlFieldName(member.AsObject.Value.Name)
+}
+
+// MemberByInputField returns the member of the union whose ID is held by the
+// given field of the union's input object.
+func (union *UnionTypeDef) MemberByInputField(field string) (*TypeDef, bool) {
+	for _, member := range union.Members {
+		if union.InputFieldName(member) == field {
+			return member, true
+		}
+	}
+	return nil, false
+}
+
+// ToInputObjectSpec returns the @oneOf input object used to pass a value of
+// the union as an argument, which has an ID field per member type.
+func (union *UnionTypeDef) ToInputObjectSpec() dagql.InputObjectSpec {
+	spec := dagql.InputObjectSpec{
+		Name:        union.InputName(),
+		Description: fmt.Sprintf("A value of the %s union. Exactly one field must be set.", union.Name),
+		OneOf:       true,
+	}
+	for _, member := range union.Members {
+		spec.Fields.Add(dagql.InputSpec{
+			Name:        union.InputFieldName(member),
+			Description: fmt.Sprintf("A %s value.", member.AsObject.Value.Name),
+			Type:        dagql.DynamicOptional{Elem: member.ToInput()},
+		})
+	}
+	return spec
+}
+
 type ScalarTypeDef struct {
 	Name        string `field:"true" doc:"The name of the scalar."`
 	Description string `field:"true" doc:"A doc string for the scalar, if any."`
@@ -1373,6 +1549,11 @@ var (
 		"Always paired with an EnumTypeDef.",
 	)
 	_ = TypeDefKinds.AliasView("ENUM", "ENUM_KIND", enumView)
+
+	TypeDefKindUnion = TypeDefKinds.Register("UNION_KIND",
+		"Always paired with a UnionTypeDef.",
+		"A named type whose values have exactly one of a set of object types.",
+	)
 )
 
 func (k TypeDefKind) Type() *ast.Type {
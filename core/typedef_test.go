@@ -5,6 +5,8 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/stretchr/testify/require"
+
 	"github.com/dagger/dagger/dagql"
 )
 
@@ -58,6 +60,15 @@ var Samples = map[TypeDefKind]*TypeDef{
 	TypeDefKindVoid: {
 		Kind: TypeDefKindVoid,
 	},
+	TypeDefKindUnion: {
+		Kind: TypeDefKindUnion,
+		AsUnion: dagql.NonNull(&UnionTypeDef{
+			Name: "FooUnion",
+			Members: []*TypeDef{
+				(&TypeDef{}).WithObject("FooObject", "", nil, nil),
+			},
+		}),
+	},
 }
 
 func TestTypeDefConversions(t *testing.T) {
@@ -86,3 +97,38 @@ func TestTypeDefConversions(t *testing.T) {
 		})
 	}
 }
+
+func TestTypeDefWithUnionMember(t *testing.T) {
+	union := (&TypeDef{}).WithUnion("Artifact", "", nil)
+
+	union, err := union.WithUnionMember((&TypeDef{}).WithObject("Container", "", nil, nil))
+	require.NoError(t, err)
+	union, err = union.WithUnionMember((&TypeDef{}).WithObject("File", "", nil, nil))
+	require.NoError(t, err)
+	require.Len(t, union.AsUnion.Value.Members, 2)
+
+	_, err = union.WithUnionMember((&TypeDef{}).WithObject("File", "", nil, nil))
+	require.ErrorContains(t, err, `already has member "File"`)
+	_, err = union.WithUnionMember((&TypeDef{}).WithKind(TypeDefKindString))
+	require.ErrorContains(t, err, "union members must be objects")
+	_, err = (&TypeDef{}).WithKind(TypeDefKindString).WithUnionMember((&TypeDef{}).WithObject("File", "", nil, nil))
+	require.ErrorContains(t, err, "cannot add member to non-union type")
+
+	file := (&TypeDef{}).WithObject("File", "", nil, nil)
+	require.True(t, file.IsSubtypeOf(union))
+	require.False(t, (&TypeDef{}).WithObject("Directory", "", nil, nil).IsSubtypeOf(union))
+	require.True(t, union.IsSubtypeOf((&TypeDef{}).WithUnion("Artifact", "", nil)))
+
+	require.Equal(t, []string{"Container", "File"}, union.AsUnion.Value.ToUnionSpec().Members)
+	input := union.AsUnion.Value.ToInputObjectSpec()
+	require.Equal(t, "ArtifactInput", input.Name)
+	require.True(t, input.OneOf)
+	var fields []string
+	for _, field := range input.Fields.Inputs("") {
+		fields = append(fields, field.Name)
+	}
+	require.Equal(t, []string{"container", "file"}, fields)
+	member, ok := union.AsUnion.Value.MemberByInputField("file")
+	require.True(t, ok)
+	require.Equal(t, "File", member.AsObject.Value.Name)
+}
@@ -0,0 +1,268 @@
+package core
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dagger/dagger/internal/buildkit/util/bklog"
+	"github.com/opencontainers/go-digest"
+	"github.com/vektah/gqlparser/v2/ast"
+
+	"github.com/dagger/dagger/dagql"
+	"github.com/dagger/dagger/dagql/call"
+	"github.com/dagger/dagger/engine/server/resource"
+	"github.com/dagger/dagger/engine/slog"
+)
+
+// UnionType is a union of object types defined by a module.
+//
+// Unions are served as GraphQL unions: a function returning a union returns
+// the value of its member type, which callers select with inline fragments.
+// Values are passed to functions as a @oneOf input object, with an ID field
+// per member type.
+type UnionType struct {
+	mod *Module
+
+	// the type def metadata, with namespacing already applied
+	typeDef *UnionTypeDef
+}
+
+var _ ModType = (*UnionType)(nil)
+
+func (union *UnionType) ConvertFromSDKResult(ctx context.Context, value any) (dagql.AnyResult, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	var member dagql.AnyResult
+	switch value := value.(type) {
+	case string:
+		var id call.ID
+		if err := id.Decode(value); err != nil {
+			return nil, fmt.Errorf("decode ID: %w", err)
+		}
+		loaded, err := union.loadMember(ctx, &id)
+		if err != nil {
+			return nil, err
+		}
+		member = loaded
+	case dagql.IDable:
+		loaded, err := union.loadMember(ctx, value.ID())
+		if err != nil {
+			return nil, err
+		}
+		member = loaded
+	case map[string]any:
+		// SDKs that can't return an ID for every member (i.e. module objects,
+		// which are returned as their fields) return the value keyed by the
+		// name of its member type instead
+		var memberName string
+		var memberValue any
+		for k, v := range value {
+			if v == nil {
+				continue
+			}
+			if memberName != "" {
+				return nil, fmt.Errorf("union %q value must have exactly one member set, got %q and %q", union.typeDef.Name, memberName, k)
+			}
+			memberName, memberValue = k, v
+		}
+		if memberName == "" {
+			return nil, fmt.Errorf("union %q value must have exactly one member set", union.typeDef.Name)
+		}
+		memberDef, ok := union.typeDef.MemberByName(memberName)
+		if !ok {
+			return nil, fmt.Errorf("type %q is not a member of union %q", memberName, union.typeDef.Name)
+		}
+		memberType, err := union.memberModType(ctx, memberDef)
+		if err != nil {
+			return nil, err
+		}
+
+		// the member is loaded by the same call, typed as the member
+		memberID := dagql.CurrentID(ctx).With(call.WithType(memberDef.ToType()))
+		member, err = memberType.ConvertFromSDKResult(dagql.ContextWithID(ctx, memberID), memberValue)
+		if err != nil {
+			return nil, fmt.Errorf("convert %q member of union %q: %w", memberName, union.typeDef.Name, err)
+		}
+		if member == nil {
+			return nil, fmt.Errorf("union %q value must have exactly one member set", union.typeDef.Name)
+		}
+	default:
+		return nil, fmt.Errorf("unexpected union value type for conversion from sdk result %T: %+v", value, value)
+	}
+
+	return member, nil
+}
+
+// loadMember loads the value with the given ID, which must be a value of one
+// of the union's member types.
+func (union *UnionType) loadMember(ctx context.Context, id *call.ID) (dagql.AnyResult, error) {
+	query, err := CurrentQuery(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("current query: %w", err)
+	}
+	deps, err := query.IDDeps(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("schema: %w", err)
+	}
+	dag, err := deps.Schema(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("schema: %w", err)
+	}
+	val, err := dag.Load(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("load union ID %s: %w", id.DisplaySelf(), err)
+	}
+	typeName := val.Type().Name()
+	if _, ok := union.typeDef.MemberByName(typeName); !ok {
+		return nil, fmt.Errorf("type %q is not a member of union %q", typeName, union.typeDef.Name)
+	}
+	return val, nil
+}
+
+func (union *UnionType) memberModType(ctx context.Context, memberDef *TypeDef) (ModType, error) {
+	memberType, ok, err := union.mod.ModTypeFor(ctx, memberDef, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get mod type for union member: %w", err)
+	}
+	if !ok {
+		return nil, fmt.Errorf("failed to find mod type for member %q of union %q", memberDef.AsObject.Value.Name, union.typeDef.Name)
+	}
+	return memberType, nil
+}
+
+func (union *UnionType) CollectCoreIDs(ctx context.Context, value dagql.AnyResult, ids map[digest.Digest]*resource.ID) error {
+	if value == nil {
+		return nil
+	}
+	memberDef, ok := union.typeDef.MemberByName(value.Type().Name())
+	if !ok {
+		return fmt.Errorf("type %q is not a member of union %q", value.Type().Name(), union.typeDef.Name)
+	}
+	memberType, err := union.memberModType(ctx, memberDef)
+	if err != nil {
+		return err
+	}
+	return memberType.CollectCoreIDs(ctx, value, ids)
+}
+
+func (union *UnionType) ConvertToSDKInput(ctx context.Context, value dagql.Typed) (any, error) {
+	if value == nil {
+		return nil, nil
+	}
+	input, ok := value.(UnionInput)
+	if !ok {
+		return nil, fmt.Errorf("unexpected union value type for conversion to sdk input %T", value)
+	}
+	member, err := union.loadMember(ctx, input.ID)
+	if err != nil {
+		return nil, err
+	}
+	memberDef, _ := union.typeDef.MemberByName(member.Type().Name())
+	memberType, err := union.memberModType(ctx, memberDef)
+	if err != nil {
+		return nil, err
+	}
+	memberName := memberDef.AsObject.Value.Name
+	memberInput, err := memberType.ConvertToSDKInput(ctx, DynamicID{
+		typeName: memberName,
+		id:       member.ID(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("convert %q member of union %q: %w", memberName, union.typeDef.Name, err)
+	}
+	// keyed by member type, the same way SDKs return union values
+	return map[string]any{memberDef.AsObject.Value.OriginalName: memberInput}, nil
+}
+
+func (union *UnionType) SourceMod() Mod {
+	return union.mod
+}
+
+func (union *UnionType) TypeDef() *TypeDef {
+	return &TypeDef{
+		Kind:    TypeDefKindUnion,
+		AsUnion: dagql.NonNull(union.typeDef.Clone()),
+	}
+}
+
+func (union *UnionType) Install(ctx context.Context, dag *dagql.Server) error {
+	ctx = bklog.WithLogger(ctx, bklog.G(ctx).WithField("union", union.typeDef.Name))
+	slog.ExtraDebug("installing union")
+
+	if union.mod.ResultID == nil {
+		return fmt.Errorf("installing union %q too early", union.typeDef.Name)
+	}
+
+	union.typeDef.ToUnionSpec().Install(dag)
+	union.typeDef.ToInputObjectSpec().Install(dag)
+	return nil
+}
+
+// UnionInput is a value of a union passed as an argument, set from the
+// union's @oneOf input object.
+type UnionInput struct {
+	TypeDef *UnionTypeDef
+	// Member is the name of the member type of the value.
+	Member string
+	ID     *call.ID
+}
+
+var _ dagql.Input = UnionInput{}
+
+func (input UnionInput) Type() *ast.Type {
+	return &ast.Type{
+		NamedType: input.TypeDef.InputName(),
+		NonNull:   true,
+	}
+}
+
+func (input UnionInput) Decoder() dagql.InputDecoder {
+	return UnionInput{TypeDef: input.TypeDef}
+}
+
+func (input UnionInput) DecodeInput(val any) (dagql.Input, error) {
+	if val, ok := val.(UnionInput); ok {
+		return val, nil
+	}
+	vals, ok := val.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("expected input object for union %q, got %T", input.TypeDef.Name, val)
+	}
+	// enforce @oneOf, since values aren't checked against the schema
+	var memberDef *TypeDef
+	var memberVal any
+	for field, v := range vals {
+		member, ok := input.TypeDef.MemberByInputField(field)
+		if !ok {
+			return nil, fmt.Errorf("%s has no field %q", input.TypeDef.InputName(), field)
+		}
+		if v == nil {
+			continue
+		}
+		if memberDef != nil {
+			return nil, fmt.Errorf("exactly one field of %s must be set", input.TypeDef.InputName())
+		}
+		memberDef, memberVal = member, v
+	}
+	if memberDef == nil {
+		return nil, fmt.Errorf("exactly one field of %s must be set", input.TypeDef.InputName())
+	}
+	id, err := memberDef.ToInput().Decoder().DecodeInput(memberVal)
+	if err != nil {
+		return nil, fmt.Errorf("decode %s.%s: %w", input.TypeDef.InputName(), input.TypeDef.InputFieldName(memberDef), err)
+	}
+	return UnionInput{
+		TypeDef: input.TypeDef,
+		Member:  memberDef.AsObject.Value.Name,
+		ID:      id.(DynamicID).ID(),
+	}, nil
+}
+
+func (input UnionInput) ToLiteral() call.Literal {
+	memberDef, _ := input.TypeDef.MemberByName(input.Member)
+	return call.NewLiteralObject(
+		call.NewArgument(input.TypeDef.InputFieldName(memberDef), call.NewLiteralID(input.ID), false),
+	)
+}
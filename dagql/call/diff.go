@@ -0,0 +1,428 @@
+package call
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/dagger/dagger/util/hashutil"
+)
+
+// DiffKind describes what about a call differs between two IDs.
+type DiffKind string
+
+const (
+	// The calls select different fields, or return different types.
+	DiffField DiffKind = "field"
+	// An argument was set in one call but not the other.
+	DiffArgPresence DiffKind = "argument presence"
+	// An argument value differs.
+	DiffArgValue DiffKind = "argument"
+	// The calls are identical, but their digests differ. This happens when a
+	// call has a content-based digest, e.g. a directory loaded from the host.
+	DiffDigest DiffKind = "content digest"
+	// The calls belong to different modules.
+	DiffModule DiffKind = "module"
+	// The calls were made in different views.
+	DiffView DiffKind = "view"
+	// The calls select a different element of a list.
+	DiffNth DiffKind = "nth"
+)
+
+// Difference is a single reason two IDs have different digests.
+type Difference struct {
+	// Call is a short display of the call in which the difference was found,
+	// e.g. `Container.withEnvVariable(name: "FOO", value: "bar")`.
+	Call string
+
+	// Arg is the path to the differing argument within the call, e.g. "args[1]"
+	// or "opts.name". Empty if the difference isn't in an argument.
+	Arg string
+
+	Kind DiffKind
+
+	// A and B display the differing values.
+	A string
+	B string
+}
+
+func (d Difference) String() string {
+	what := string(d.Kind)
+	if d.Arg != "" {
+		what += " " + d.Arg
+	}
+	return fmt.Sprintf("%s: %s: %s != %s", d.Call, what, d.A, d.B)
+}
+
+// Diff explains why two IDs have different digests, by walking both DAGs in
+// parallel and reporting the innermost calls that differ. Differences in a
+// receiver or an ID argument are reported in place of the calls that depend on
+// them, so each Difference is a root cause rather than a consequence.
+//
+// Diff returns nil if the IDs have the same digest.
+func Diff(a, b *ID) []Difference {
+	var diffs []Difference
+	differ{}.diffIDs(a, b, &diffs)
+	return diffs
+}
+
+// RedactedDiff is like Diff, but never displays argument values: calls are
+// displayed without their arguments, and differing values are displayed as
+// digests. This makes the result safe to send anywhere the IDs' digests may
+// go, e.g. telemetry.
+func RedactedDiff(a, b *ID) []Difference {
+	var diffs []Difference
+	differ{redact: true}.diffIDs(a, b, &diffs)
+	return diffs
+}
+
+type differ struct {
+	redact bool
+}
+
+func (d differ) call(id *ID) string {
+	if !d.redact || id == nil {
+		return shortDisplay(id, nil)
+	}
+	buf := new(strings.Builder)
+	if id.receiver != nil {
+		fmt.Fprintf(buf, "%s.", id.receiver.typ.NamedType())
+	}
+	buf.WriteString(id.pb.Field)
+	if id.pb.Nth != 0 {
+		fmt.Fprintf(buf, "#%d", id.pb.Nth)
+	}
+	return buf.String()
+}
+
+func (d differ) literal(lit Literal) string {
+	if !d.redact {
+		return literalDisplay(lit)
+	}
+	h, err := appendLiteralBytes(lit.pb(), hashutil.NewHasher())
+	if err != nil {
+		return "<invalid>"
+	}
+	return h.DigestAndClose()
+}
+
+func (d differ) arg(arg *Argument) string {
+	if !d.redact {
+		return argDisplay(arg)
+	}
+	if arg == nil {
+		return "<unset>"
+	}
+	return "<set>"
+}
+
+func (d differ) diffIDs(a, b *ID, diffs *[]Difference) {
+	if a.Digest() == b.Digest() {
+		return
+	}
+	if a == nil || b == nil {
+		*diffs = append(*diffs, Difference{
+			Call: d.call(cmp.Or(a, b)),
+			Kind: DiffField,
+			A:    d.call(a),
+			B:    d.call(b),
+		})
+		return
+	}
+	if a.Field() != b.Field() || a.Type().ToAST().String() != b.Type().ToAST().String() {
+		*diffs = append(*diffs, Difference{
+			Call: d.call(a),
+			Kind: DiffField,
+			A:    d.call(a),
+			B:    d.call(b),
+		})
+		return
+	}
+
+	before := len(*diffs)
+
+	d.diffIDs(a.Receiver(), b.Receiver(), diffs)
+
+	self := d.call(a)
+	if a.View() != b.View() {
+		*diffs = append(*diffs, Difference{
+			Call: self,
+			Kind: DiffView,
+			A:    fmt.Sprintf("%q", a.View()),
+			B:    fmt.Sprintf("%q", b.View()),
+		})
+	}
+	if a.Nth() != b.Nth() {
+		*diffs = append(*diffs, Difference{
+			Call: self,
+			Kind: DiffNth,
+			A:    fmt.Sprint(a.Nth()),
+			B:    fmt.Sprint(b.Nth()),
+		})
+	}
+	if modA, modB := a.Module(), b.Module(); (modA == nil) != (modB == nil) ||
+		(modA != nil && modA.ID().Digest() != modB.ID().Digest()) {
+		*diffs = append(*diffs, Difference{
+			Call: self,
+			Kind: DiffModule,
+			A:    moduleDisplay(modA),
+			B:    moduleDisplay(modB),
+		})
+	}
+
+	// sensitive arguments are left out of the digest, so they can't be the
+	// reason for a difference
+	names := map[string]struct{}{}
+	for _, arg := range slices.Concat(a.Args(), b.Args()) {
+		if !arg.IsSensitive() {
+			names[arg.Name()] = struct{}{}
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(names)) {
+		argA, argB := a.Arg(name), b.Arg(name)
+		if argA == nil || argB == nil {
+			*diffs = append(*diffs, Difference{
+				Call: self,
+				Arg:  name,
+				Kind: DiffArgPresence,
+				A:    d.arg(argA),
+				B:    d.arg(argB),
+			})
+			continue
+		}
+		d.diffLiterals(self, name, argA.Value(), argB.Value(), diffs)
+	}
+
+	if len(*diffs) == before {
+		// nothing we can see differs, so the digest must be custom, e.g. derived
+		// from content
+		*diffs = append(*diffs, Difference{
+			Call: self,
+			Kind: DiffDigest,
+			A:    a.Digest().String(),
+			B:    b.Digest().String(),
+		})
+	}
+}
+
+func (d differ) diffLiterals(call, path string, a, b Literal, diffs *[]Difference) {
+	switch litA := a.(type) {
+	case *LiteralID:
+		if litB, ok := b.(*LiteralID); ok {
+			d.diffIDs(litA.Value(), litB.Value(), diffs)
+			return
+		}
+	case *LiteralList:
+		if litB, ok := b.(*LiteralList); ok && litA.Len() == litB.Len() {
+			valsB := make([]Literal, 0, litB.Len())
+			for _, val := range litB.Values() {
+				valsB = append(valsB, val)
+			}
+			for i, val := range litA.Values() {
+				d.diffLiterals(call, fmt.Sprintf("%s[%d]", path, i), val, valsB[i], diffs)
+			}
+			return
+		}
+	case *LiteralObject:
+		if litB, ok := b.(*LiteralObject); ok {
+			fieldsB := map[string]*Argument{}
+			for _, arg := range litB.Args() {
+				fieldsB[arg.Name()] = arg
+			}
+			for _, argA := range litA.Args() {
+				argB, found := fieldsB[argA.Name()]
+				delete(fieldsB, argA.Name())
+				if !found {
+					*diffs = append(*diffs, Difference{
+						Call: call,
+						Arg:  path + "." + argA.Name(),
+						Kind: DiffArgPresence,
+						A:    d.arg(argA),
+						B:    d.arg(nil),
+					})
+					continue
+				}
+				d.diffLiterals(call, path+"."+argA.Name(), argA.Value(), argB.Value(), diffs)
+			}
+			for _, name := range slices.Sorted(maps.Keys(fieldsB)) {
+				*diffs = append(*diffs, Difference{
+					Call: call,
+					Arg:  path + "." + name,
+					Kind: DiffArgPresence,
+					A:    d.arg(nil),
+					B:    d.arg(fieldsB[name]),
+				})
+			}
+			return
+		}
+	}
+
+	dispA, dispB := d.literal(a), d.literal(b)
+	if dispA == dispB {
+		return
+	}
+	*diffs = append(*diffs, Difference{
+		Call: call,
+		Arg:  path,
+		Kind: DiffArgValue,
+		A:    dispA,
+		B:    dispB,
+	})
+}
+
+// shortDisplay displays a single call along with its receiver's type, and
+// with any ID arguments collapsed to their type so that the result stays on
+// one line.
+func shortDisplay(id, fallback *ID) string {
+	if id == nil {
+		id = fallback
+	}
+	if id == nil {
+		return "<nil>"
+	}
+	buf := new(strings.Builder)
+	if id.receiver != nil {
+		fmt.Fprintf(buf, "%s.", id.receiver.typ.NamedType())
+	}
+	buf.WriteString(id.pb.Field)
+	var args []string
+	for _, arg := range id.args {
+		if arg.isSensitive {
+			continue
+		}
+		args = append(args, arg.pb.Name+": "+literalDisplay(arg.value))
+	}
+	if len(args) > 0 {
+		fmt.Fprintf(buf, "(%s)", strings.Join(args, ", "))
+	}
+	if id.pb.Nth != 0 {
+		fmt.Fprintf(buf, "#%d", id.pb.Nth)
+	}
+	return buf.String()
+}
+
+func literalDisplay(lit Literal) string {
+	switch lit := lit.(type) {
+	case *LiteralID:
+		return lit.id.typ.ToAST().String()
+	case *LiteralList:
+		vals := make([]string, 0, lit.Len())
+		for _, val := range lit.Values() {
+			vals = append(vals, literalDisplay(val))
+		}
+		return "[" + strings.Join(vals, ", ") + "]"
+	case *LiteralObject:
+		fields := make([]string, 0, lit.Len())
+		for _, arg := range lit.Args() {
+			fields = append(fields, arg.Name()+": "+argDisplay(arg))
+		}
+		return "{" + strings.Join(fields, ", ") + "}"
+	default:
+		return lit.Display()
+	}
+}
+
+func argDisplay(arg *Argument) string {
+	if arg == nil {
+		return "<unset>"
+	}
+	if arg.IsSensitive() {
+		return "***"
+	}
+	return literalDisplay(arg.Value())
+}
+
+func moduleDisplay(mod *Module) string {
+	if mod == nil {
+		return "<core>"
+	}
+	if mod.pb.Ref != "" {
+		return mod.pb.Ref
+	}
+	return mod.Name()
+}
+
+// History remembers recently seen IDs so that a new ID can be compared
+// against the most similar call seen before it, e.g. to explain a cache miss.
+//
+// IDs are grouped by their shape: the chain of fields from the root of the ID,
+// ignoring arguments. Only a bounded number of shapes and IDs per shape are
+// kept; the oldest are forgotten first.
+type History struct {
+	maxShapes   int
+	maxPerShape int
+
+	byShape map[string][]*ID
+	shapes  []string
+	mu      sync.Mutex
+}
+
+func NewHistory(maxShapes, maxPerShape int) *History {
+	return &History{
+		maxShapes:   maxShapes,
+		maxPerShape: maxPerShape,
+		byShape:     map[string][]*ID{},
+	}
+}
+
+// Closest returns the remembered ID of the same shape with the fewest
+// differences from the given ID, along with those differences. It returns nil
+// if there is no such ID, or if the given ID has been seen before.
+func (h *History) Closest(id *ID) (*ID, []Difference) {
+	h.mu.Lock()
+	candidates := h.byShape[idShape(id)]
+	h.mu.Unlock()
+
+	var closest *ID
+	var closestDiffs []Difference
+	for _, candidate := range candidates {
+		if candidate.Digest() == id.Digest() {
+			return nil, nil
+		}
+		diffs := Diff(candidate, id)
+		if closest == nil || len(diffs) < len(closestDiffs) {
+			closest, closestDiffs = candidate, diffs
+		}
+	}
+	return closest, closestDiffs
+}
+
+// Add remembers the given ID.
+func (h *History) Add(id *ID) {
+	shape := idShape(id)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	// copy on write, since Closest iterates outside the lock
+	ids, found := h.byShape[shape]
+	ids = slices.Clone(ids)
+	if !found {
+		h.shapes = append(h.shapes, shape)
+		if len(h.shapes) > h.maxShapes {
+			delete(h.byShape, h.shapes[0])
+			h.shapes = h.shapes[1:]
+		}
+	}
+	// move any existing entry to the end, so that it's evicted last
+	ids = slices.DeleteFunc(ids, func(existing *ID) bool {
+		return existing.Digest() == id.Digest()
+	})
+	ids = append(ids, id)
+	if len(ids) > h.maxPerShape {
+		ids = ids[1:]
+	}
+	h.byShape[shape] = ids
+}
+
+func idShape(id *ID) string {
+	var fields []string
+	for ; id != nil; id = id.receiver {
+		fields = append(fields, id.pb.Field)
+	}
+	slices.Reverse(fields)
+	return strings.Join(fields, ".")
+}
@@ -0,0 +1,151 @@
+package call
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	"github.com/stretchr/testify/require"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+func testContainer(address string, opts ...IDOpt) *ID {
+	ctrType := ast.NonNullNamedType("Container", nil)
+	return New().
+		Append(ctrType, "container").
+		Append(ctrType, "from", WithArgs(NewArgument("address", NewLiteralString(address), false))).
+		Append(ctrType, "withEnvVariable", opts...)
+}
+
+func envArgs(name, value string) IDOpt {
+	return WithArgs(
+		NewArgument("name", NewLiteralString(name), false),
+		NewArgument("value", NewLiteralString(value), false),
+	)
+}
+
+func TestDiff(t *testing.T) {
+	t.Run("identical", func(t *testing.T) {
+		a := testContainer("alpine", envArgs("FOO", "1"))
+		b := testContainer("alpine", envArgs("FOO", "1"))
+		require.Empty(t, Diff(a, b))
+	})
+
+	t.Run("argument", func(t *testing.T) {
+		a := testContainer("alpine", envArgs("FOO", "1"))
+		b := testContainer("alpine", envArgs("FOO", "2"))
+		require.Equal(t, []Difference{{
+			Call: `Container.withEnvVariable(name: "FOO", value: "1")`,
+			Arg:  "value",
+			Kind: DiffArgValue,
+			A:    `"1"`,
+			B:    `"2"`,
+		}}, Diff(a, b))
+	})
+
+	t.Run("receiver", func(t *testing.T) {
+		a := testContainer("alpine", envArgs("FOO", "1"))
+		b := testContainer("debian", envArgs("FOO", "1"))
+		require.Equal(t, []Difference{{
+			Call: `Container.from(address: "alpine")`,
+			Arg:  "address",
+			Kind: DiffArgValue,
+			A:    `"alpine"`,
+			B:    `"debian"`,
+		}}, Diff(a, b))
+	})
+
+	t.Run("list element", func(t *testing.T) {
+		ctrType := ast.NonNullNamedType("Container", nil)
+		withExec := func(args ...string) *ID {
+			lits := make([]Literal, len(args))
+			for i, arg := range args {
+				lits[i] = NewLiteralString(arg)
+			}
+			return New().Append(ctrType, "container").
+				Append(ctrType, "withExec", WithArgs(NewArgument("args", NewLiteralList(lits...), false)))
+		}
+		diffs := Diff(withExec("echo", "hi"), withExec("echo", "bye"))
+		require.Len(t, diffs, 1)
+		require.Equal(t, "args[1]", diffs[0].Arg)
+	})
+
+	t.Run("sensitive", func(t *testing.T) {
+		ctrType := ast.NonNullNamedType("Container", nil)
+		withSecret := func(val string) *ID {
+			return New().Append(ctrType, "container").
+				Append(ctrType, "withToken", WithArgs(NewArgument("token", NewLiteralString(val), true)))
+		}
+		// sensitive args aren't part of the digest
+		require.Empty(t, Diff(withSecret("hunter2"), withSecret("hunter3")))
+	})
+
+	t.Run("content digest", func(t *testing.T) {
+		dirType := ast.NonNullNamedType("Directory", nil)
+		hostDir := func(content string) *ID {
+			return New().Append(dirType, "host").
+				Append(dirType, "directory", WithArgs(NewArgument("path", NewLiteralString("./src"), false))).
+				WithDigest(digest.FromString(content))
+		}
+		diffs := Diff(hostDir("a"), hostDir("b"))
+		require.Len(t, diffs, 1)
+		require.Equal(t, DiffDigest, diffs[0].Kind)
+		require.Equal(t, `Directory.directory(path: "./src")`, diffs[0].Call)
+	})
+}
+
+func TestRedactedDiff(t *testing.T) {
+	a := testContainer("alpine", envArgs("FOO", "hunter2"))
+	b := testContainer("alpine", envArgs("FOO", "hunter3"))
+	diffs := RedactedDiff(a, b)
+	require.Len(t, diffs, 1)
+	require.Equal(t, "Container.withEnvVariable", diffs[0].Call)
+	require.Equal(t, "value", diffs[0].Arg)
+	require.Equal(t, DiffArgValue, diffs[0].Kind)
+	require.NotEqual(t, diffs[0].A, diffs[0].B)
+	for _, val := range []string{diffs[0].A, diffs[0].B} {
+		require.True(t, strings.HasPrefix(val, "xxh3:"), val)
+		require.NotContains(t, val, "hunter")
+	}
+	require.NotContains(t, diffs[0].String(), "hunter")
+
+	// the same values always redact to the same digests
+	require.Equal(t, diffs, RedactedDiff(a, b))
+
+	c := testContainer("alpine", WithArgs(NewArgument("name", NewLiteralString("FOO"), false)))
+	diffs = RedactedDiff(a, c)
+	require.Len(t, diffs, 1)
+	require.Equal(t, DiffArgPresence, diffs[0].Kind)
+	require.Equal(t, "<set>", diffs[0].A)
+	require.Equal(t, "<unset>", diffs[0].B)
+}
+
+func TestHistory(t *testing.T) {
+	h := NewHistory(10, 2)
+
+	a := testContainer("alpine", envArgs("FOO", "1"))
+	prev, _ := h.Closest(a)
+	require.Nil(t, prev)
+	h.Add(a)
+
+	// a previously seen ID has nothing to explain
+	prev, _ = h.Closest(a)
+	require.Nil(t, prev)
+
+	far := testContainer("debian", envArgs("BAR", "2"))
+	h.Add(far)
+
+	b := testContainer("alpine", envArgs("FOO", "2"))
+	prev, diffs := h.Closest(b)
+	require.Equal(t, a.Digest(), prev.Digest())
+	require.Len(t, diffs, 1)
+	require.Equal(t, "value", diffs[0].Arg)
+
+	// only the most recent IDs per shape are kept
+	h.Add(b)
+	prev, _ = h.Closest(testContainer("alpine", envArgs("FOO", "3")))
+	require.Equal(t, b.Digest(), prev.Digest())
+	h.mu.Lock()
+	require.Len(t, h.byShape[idShape(a)], 2)
+	h.mu.Unlock()
+}
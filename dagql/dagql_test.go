@@ -1211,6 +1211,118 @@ func TestPassingObjectsAround(t *testing.T) {
 	assert.Equal(t, res2.Point.Line.Length, 18)
 }
 
+func TestUnions(t *testing.T) {
+	srv := dagql.NewServer(Query{}, newCache(t))
+	points.Install[Query](srv)
+
+	dagql.UnionSpec{
+		Name:    "Shape",
+		Members: []string{"Point", "Line"},
+	}.Install(srv)
+
+	srv.Root().ObjectType().Extend(
+		dagql.FieldSpec{
+			Name: "shape",
+			Type: dagql.UnionSpec{Name: "Shape"},
+			Args: dagql.NewInputSpecs(dagql.InputSpec{
+				Name: "line",
+				Type: dagql.Boolean(false),
+			}),
+		},
+		func(ctx context.Context, self dagql.AnyResult, args map[string]dagql.Input) (dagql.AnyResult, error) {
+			from := &points.Point{X: 1, Y: 2}
+			if args["line"].(dagql.Boolean) {
+				return dagql.NewResultForCurrentID(ctx, &points.Line{From: from, To: &points.Point{X: 3, Y: 4}})
+			}
+			return dagql.NewResultForCurrentID(ctx, from)
+		},
+	)
+
+	gql := client.New(dagql.NewDefaultHandler(srv))
+
+	t.Run("inline fragments", func(t *testing.T) {
+		type shape struct {
+			Typename string `json:"__typename"`
+			X        *int
+			From     *struct{ X int }
+		}
+		var res struct {
+			Point shape
+			Line  shape
+		}
+		req(t, gql, `query {
+			point: shape(line: false) {
+				__typename
+				... on Point { x }
+				... on Line { from { x } }
+			}
+			line: shape(line: true) {
+				__typename
+				... on Point { x }
+				... on Line { from { x } }
+			}
+		}`, &res)
+		assert.Equal(t, "Point", res.Point.Typename)
+		assert.Assert(t, res.Point.X != nil)
+		assert.Equal(t, 1, *res.Point.X)
+		assert.Assert(t, res.Point.From == nil)
+		assert.Equal(t, "Line", res.Line.Typename)
+		assert.Assert(t, res.Line.X == nil)
+		assert.Assert(t, res.Line.From != nil)
+		assert.Equal(t, 1, res.Line.From.X)
+	})
+
+	t.Run("named fragments", func(t *testing.T) {
+		var res struct {
+			Shape struct {
+				Y int
+			}
+		}
+		req(t, gql, `query {
+			shape(line: false) {
+				...PointFields
+			}
+		}
+
+		fragment PointFields on Point {
+			y
+		}`, &res)
+		assert.Equal(t, 2, res.Shape.Y)
+	})
+
+	t.Run("fields require a fragment", func(t *testing.T) {
+		reqFail(t, gql, `query {
+			shape(line: false) {
+				x
+			}
+		}`, "Did you mean to use an inline fragment")
+	})
+
+	t.Run("possible types", func(t *testing.T) {
+		var res struct {
+			Type struct {
+				Kind          string
+				PossibleTypes []struct {
+					Name string
+				}
+			} `json:"__type"`
+		}
+		introspection.Install[Query](srv)
+		req(t, gql, `query {
+			__type(name: "Shape") {
+				kind
+				possibleTypes { name }
+			}
+		}`, &res)
+		assert.Equal(t, "UNION", res.Type.Kind)
+		names := []string{}
+		for _, pt := range res.Type.PossibleTypes {
+			names = append(names, pt.Name)
+		}
+		assert.DeepEqual(t, []string{"Point", "Line"}, names)
+	})
+}
+
 func TestEnums(t *testing.T) {
 	srv := dagql.NewServer(Query{}, newCache(t))
 	points.Install[Query](srv)
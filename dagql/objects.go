@@ -10,6 +10,7 @@ import (
 	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/iancoleman/strcase"
 	"github.com/opencontainers/go-digest"
@@ -18,6 +19,7 @@ import (
 	"github.com/dagger/dagger/dagql/call"
 	"github.com/dagger/dagger/engine"
 	"github.com/dagger/dagger/engine/cache"
+	"github.com/dagger/dagger/engine/metrics"
 	"github.com/dagger/dagger/engine/slog"
 )
 
@@ -702,6 +704,7 @@ func (r ObjectResult[T]) call(
 		}))
 	}
 
+	startedAt := time.Now()
 	res, err := s.Cache.GetOrInitializeWithCallbacks(ctx, cacheKey, func(ctx context.Context) (*CacheValWithCallbacks, error) {
 		valWithCallbacks, err := r.class.Call(ctx, s, r, newID.Field(), newID.View(), inputArgs)
 		if err != nil {
@@ -736,6 +739,7 @@ func (r ObjectResult[T]) call(
 			SafeToPersistCache: valWithCallbacks.SafeToPersistCache,
 		}, nil
 	}, opts...)
+	observeCall(r.Type().Name(), newID, res, err, time.Since(startedAt))
 
 	if err != nil {
 		return nil, err
@@ -778,6 +782,23 @@ func (r ObjectResult[T]) call(
 	return val, nil
 }
 
+// observeCall records metrics for a call, collapsing module functions into a
+// single field label to keep cardinality bounded.
+func observeCall(typeName string, id *call.ID, res CacheResult, err error, took time.Duration) {
+	field := typeName + "." + id.Field()
+	if id.Module() != nil {
+		field = metrics.ModuleField
+	}
+	result := metrics.CacheMiss
+	switch {
+	case err != nil:
+		result = metrics.CacheError
+	case res.HitCache():
+		result = metrics.CacheHit
+	}
+	metrics.ObserveDagqlCall(field, result, took)
+}
+
 type ViewFilter interface {
 	Contains(call.View) bool
 }
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"reflect"
 	"runtime/debug"
+	"slices"
 	"sync"
 
 	"github.com/99designs/gqlgen/graphql"
@@ -262,6 +263,15 @@ var coreDirectives = []DirectiveSpec{
 			DirectiveLocationInputObject,
 		},
 	},
+	{
+		Name: "oneOf",
+		Description: FormatDescription(
+			`Indicates that exactly one field of an input object must be set.`),
+		Args: NewInputSpecs(), // none
+		Locations: []DirectiveLocation{
+			DirectiveLocationInputObject,
+		},
+	},
 	{
 		Name:        "enumValue",
 		Description: FormatDescription(`Indicates the underlying value of an enum member.`),
@@ -471,11 +481,24 @@ func (s *Server) Schema() *ast.Schema {
 			schema.AddTypes(def)
 			schema.AddPossibleType(def.Name, def)
 		}
+		var unions []*ast.Definition
 		for _, t := range s.typeDefs {
 			def := t.TypeDefinition(view)
 			schema.AddTypes(def)
+			if def.Kind == ast.Union {
+				unions = append(unions, def)
+				continue
+			}
 			schema.AddPossibleType(def.Name, def)
 		}
+		for _, def := range unions {
+			// the possible types of a union are its members
+			for _, member := range def.Types {
+				if memberDef := schema.Types[member]; memberDef != nil {
+					schema.AddPossibleType(def.Name, memberDef)
+				}
+			}
+		}
 		schema.Directives = map[string]*ast.DirectiveDefinition{}
 		for n, d := range s.directives {
 			schema.Directives[n] = d.DirectiveDefinition(view)
@@ -598,6 +621,8 @@ func (s *Server) ExecOp(ctx context.Context, gqlOp *graphql.OperationContext) (m
 // Each selection is resolved in parallel, and the results are returned in a
 // map whose keys correspond to the selection's field name or alias.
 func (s *Server) Resolve(ctx context.Context, self AnyObjectResult, sels ...Selection) (map[string]any, error) {
+	sels = selectionsForType(sels, self.Type().Name())
+
 	if len(sels) == 0 {
 		return nil, nil
 	}
@@ -984,6 +1009,10 @@ func (s *Server) resolvePath(ctx context.Context, self AnyObjectResult, sel Sele
 		}
 	}()
 
+	if sel.Selector.Field == typenameField {
+		return self.Type().Name(), nil
+	}
+
 	if sel.Selector.Nth != 0 {
 		// NOTE: this is explicitly not handled - but it's fine because
 		// resolvePath is called from selectors from field parsing, so we
@@ -1086,18 +1115,35 @@ func (s *Server) toSelectable(val AnyResult) (AnyObjectResult, error) {
 	return nil, fmt.Errorf("toSelectable: unknown type %q", val.Type().Name())
 }
 
+// typenameField is the meta field returning the name of an object's type.
+const typenameField = "__typename"
+
 func (s *Server) parseASTSelections(ctx context.Context, gqlOp *graphql.OperationContext, self *ast.Type, astSels ast.SelectionSet) ([]Selection, error) {
 	vars := gqlOp.Variables
 
 	class := s.objects[self.Name()]
 	if class == nil {
-		return nil, fmt.Errorf("parseASTSelections: not an Object type: %q", self.Name())
+		// a union has no fields of its own, only __typename and fragments on
+		// its members can be selected
+		if def, ok := s.typeDefs[self.Name()]; !ok || def.TypeDefinition(s.View).Kind != ast.Union {
+			return nil, fmt.Errorf("parseASTSelections: not an Object type: %q", self.Name())
+		}
 	}
 
 	sels := []Selection{}
 	for _, sel := range astSels {
 		switch x := sel.(type) {
 		case *ast.Field:
+			if x.Name == typenameField {
+				sels = append(sels, Selection{
+					Alias:    x.Alias,
+					Selector: Selector{Field: typenameField},
+				})
+				continue
+			}
+			if class == nil {
+				return nil, fmt.Errorf("parse field %q: cannot select fields on union %q without a fragment", x.Name, self.Name())
+			}
 			sel, resType, err := class.ParseField(ctx, s.View, x, vars)
 			if err != nil {
 				return nil, fmt.Errorf("parse field %q: %w", x.Name, err)
@@ -1120,13 +1166,17 @@ func (s *Server) parseASTSelections(ctx context.Context, gqlOp *graphql.Operatio
 			if fragment == nil {
 				return nil, fmt.Errorf("unknown fragment: %s", x.Name)
 			}
-			if len(fragment.SelectionSet) > 0 {
-				subsels, err := s.parseASTSelections(ctx, gqlOp, self, fragment.SelectionSet)
-				if err != nil {
-					return nil, err
-				}
-				sels = append(sels, subsels...)
+			subsels, err := s.parseFragment(ctx, gqlOp, self, fragment.TypeCondition, fragment.SelectionSet)
+			if err != nil {
+				return nil, err
 			}
+			sels = append(sels, subsels...)
+		case *ast.InlineFragment:
+			subsels, err := s.parseFragment(ctx, gqlOp, self, x.TypeCondition, x.SelectionSet)
+			if err != nil {
+				return nil, err
+			}
+			sels = append(sels, subsels...)
 		default:
 			return nil, fmt.Errorf("unknown field type: %T", x)
 		}
@@ -1135,11 +1185,57 @@ func (s *Server) parseASTSelections(ctx context.Context, gqlOp *graphql.Operatio
 	return sels, nil
 }
 
+// parseFragment parses the selections of a fragment, which only apply to
+// values of the type named by its type condition.
+func (s *Server) parseFragment(ctx context.Context, gqlOp *graphql.OperationContext, self *ast.Type, typeCondition string, astSels ast.SelectionSet) ([]Selection, error) {
+	if len(astSels) == 0 {
+		return nil, nil
+	}
+	if typeCondition == "" || typeCondition == self.Name() {
+		return s.parseASTSelections(ctx, gqlOp, self, astSels)
+	}
+	if s.objects[typeCondition] == nil {
+		return nil, fmt.Errorf("unsupported fragment type condition %q on %q", typeCondition, self.Name())
+	}
+	sels, err := s.parseASTSelections(ctx, gqlOp, &ast.Type{NamedType: typeCondition, NonNull: true}, astSels)
+	if err != nil {
+		return nil, err
+	}
+	for i := range sels {
+		if sels[i].TypeCondition == "" {
+			sels[i].TypeCondition = typeCondition
+		}
+	}
+	return sels, nil
+}
+
+// selectionsForType filters out the selections of fragments that don't apply
+// to the given type.
+func selectionsForType(sels []Selection, typeName string) []Selection {
+	for i, sel := range sels {
+		if sel.TypeCondition == "" || sel.TypeCondition == typeName {
+			continue
+		}
+		// copy on the first mismatch, keeping the common case allocation-free
+		filtered := slices.Clone(sels[:i])
+		for _, sel := range sels[i+1:] {
+			if sel.TypeCondition == "" || sel.TypeCondition == typeName {
+				filtered = append(filtered, sel)
+			}
+		}
+		return filtered
+	}
+	return sels
+}
+
 // Selection represents a selection of a field on an object.
 type Selection struct {
 	Alias         string
 	Selector      Selector
 	Subselections []Selection
+	// TypeCondition is the name of the type the selection applies to, if it
+	// was made in a fragment, e.g. to select a member of a union.
+	TypeCondition string
 }
 
 // Name returns the name of the selection, which is either the alias or the
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"sync"
+	"sync/atomic"
 
 	"github.com/dagger/dagger/engine/cache"
 )
@@ -31,6 +32,16 @@ type SessionCache struct {
 
 	// noCacheNext keeps track of keys for which the next cache attempt should bypass the cache.
 	noCacheNext sync.Map
+
+	// hits and misses count the cacheable calls made through this session
+	hits   atomic.Int64
+	misses atomic.Int64
+}
+
+// Stats returns the number of cacheable calls made in the session that hit
+// and missed the cache, respectively.
+func (c *SessionCache) Stats() (hits, misses int) {
+	return int(c.hits.Load()), int(c.misses.Load())
 }
 
 func NewSessionCache(
@@ -183,6 +194,14 @@ func (c *SessionCache) GetOrInitializeWithCallbacks(
 	// success: we're in a good state now, allow normal caching again
 	c.noCacheNext.Delete(key.CallKey)
 
+	if !key.DoNotCache {
+		if res.HitCache() {
+			c.hits.Add(1)
+		} else {
+			c.misses.Add(1)
+		}
+	}
+
 	// If we forced DoNotCache due to a prior failure, we need to re-insert the successful
 	// result into the underlying cache under the original key so subsequent calls find it.
 	// The call above used a random storage key (due to DoNotCache=true), so the result
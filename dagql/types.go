@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"math"
 	"reflect"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -1379,6 +1380,9 @@ type InputObjectSpec struct {
 	Name        string
 	Description string
 	Fields      InputSpecs
+	// OneOf marks the input object with the @oneOf directive, meaning exactly
+	// one of its fields must be set.
+	OneOf bool
 }
 
 func (spec InputObjectSpec) Install(srv *Server) {
@@ -1397,10 +1401,64 @@ func (spec InputObjectSpec) TypeName() string {
 }
 
 func (spec InputObjectSpec) TypeDefinition(view call.View) *ast.Definition {
-	return &ast.Definition{
+	def := &ast.Definition{
 		Kind:        ast.InputObject,
 		Name:        spec.Name,
 		Description: spec.Description,
 		Fields:      spec.Fields.FieldDefinitions(view),
 	}
+	if spec.OneOf {
+		def.Directives = append(def.Directives, &ast.Directive{
+			Name: "oneOf",
+		})
+	}
+	return def
+}
+
+// UnionSpec defines a GraphQL union of object types.
+//
+// A field returning a union returns a value of one of its member types, which
+// can be told apart by selecting __typename and sub-selected using inline
+// fragments.
+type UnionSpec struct {
+	Name        string
+	Description string
+	Members     []string
+	Directives  []*ast.Directive
+}
+
+var _ TypeDef = UnionSpec{}
+
+func (spec UnionSpec) Install(srv *Server) {
+	srv.InstallTypeDef(spec)
+}
+
+func (spec UnionSpec) Type() *ast.Type {
+	return &ast.Type{
+		NamedType: spec.Name,
+		NonNull:   true,
+	}
+}
+
+func (spec UnionSpec) TypeName() string {
+	return spec.Name
+}
+
+func (spec UnionSpec) TypeDescription() string {
+	return spec.Description
+}
+
+func (spec UnionSpec) TypeDefinition(view call.View) *ast.Definition {
+	return &ast.Definition{
+		Kind:        ast.Union,
+		Name:        spec.Name,
+		Description: spec.Description,
+		Types:       spec.Members,
+		Directives:  spec.Directives,
+	}
+}
+
+// HasMember returns true if the given type is a member of the union.
+func (spec UnionSpec) HasMember(typeName string) bool {
+	return slices.Contains(spec.Members, typeName)
 }
@@ -80,6 +80,7 @@ type Opts struct {
 
 	Interactive        bool
 	InteractiveCommand []string
+	BreakOn            []string
 
 	ParentClient *Client
 }
@@ -613,6 +614,28 @@ func (c *Client) PromptAllowLLM(ctx context.Context, moduleRepoURL string) error
 	return fmt.Errorf("module %s was denied LLM access; pass --allow-llm=%s or --allow-llm=all to allow", moduleRepoURL, moduleRepoURL)
 }
 
+func (c *Client) PromptAllowToolCall(ctx context.Context, toolCall string) error {
+	caller, err := c.GetMainClientCaller()
+	if err != nil {
+		return fmt.Errorf("failed to get main client caller to prompt for tool call %s: %w", toolCall, err)
+	}
+
+	response, err := prompt.NewPromptClient(caller.Conn()).PromptBool(ctx, &prompt.BoolRequest{
+		Title:   "Allow tool call?",
+		Prompt:  fmt.Sprintf("The LLM wants to call **%s**. Allow it?", toolCall),
+		Default: false,
+	})
+	if err != nil {
+		// nobody can approve the call, e.g. in CI, so don't allow it
+		return fmt.Errorf("tool call %s requires approval, but the user could not be prompted: %w", toolCall, err)
+	}
+	if response.Response {
+		return nil
+	}
+
+	return fmt.Errorf("tool call %s was denied by the user", toolCall)
+}
+
 func (c *Client) PromptHumanHelp(ctx context.Context, title, question string) (string, error) {
 	caller, err := c.GetMainClientCaller()
 	if err != nil {
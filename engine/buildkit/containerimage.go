@@ -14,6 +14,7 @@ import (
 	bkgw "github.com/dagger/dagger/internal/buildkit/frontend/gateway/client"
 	bksolverpb "github.com/dagger/dagger/internal/buildkit/solver/pb"
 	solverresult "github.com/dagger/dagger/internal/buildkit/solver/result"
+	"github.com/dagger/dagger/internal/buildkit/util/leaseutil"
 	specs "github.com/opencontainers/image-spec/specs-go/v1"
 
 	"github.com/dagger/dagger/engine"
@@ -29,6 +30,7 @@ func (c *Client) PublishContainerImage(
 	ctx context.Context,
 	inputByPlatform map[string]ContainerExport,
 	opts map[string]string, // TODO: make this an actual type, this leaks too much untyped buildkit api
+	referrersFn ImageReferrersFunc,
 ) (map[string]string, error) {
 	ctx = buildkitTelemetryProvider(ctx)
 	ctx, cancel, err := c.withClientCloseCancel(ctx)
@@ -57,8 +59,28 @@ func (c *Client) PublishContainerImage(
 	if err != nil {
 		return nil, fmt.Errorf("failed to export: %w", err)
 	}
-	if descRef != nil {
-		descRef.Release()
+	if descRef == nil {
+		return resp, nil
+	}
+	defer descRef.Release()
+
+	if referrersFn != nil {
+		ctx, done, err := leaseutil.WithLease(ctx, c.Worker.LeaseManager(), leaseutil.MakeTemporary)
+		if err != nil {
+			return nil, err
+		}
+		defer done(context.WithoutCancel(ctx))
+
+		subject := descRef.Descriptor()
+		referrers, err := writeReferrers(ctx, c.Worker.ContentStore(), subject, referrersFn)
+		if err != nil {
+			return nil, err
+		}
+		if len(referrers) > 0 {
+			if err := c.pushReferrers(ctx, opts[string(exptypes.OptKeyName)], subject, referrers); err != nil {
+				return nil, err
+			}
+		}
 	}
 	return resp, nil
 }
@@ -68,6 +90,7 @@ func (c *Client) ExportContainerImage(
 	inputByPlatform map[string]ContainerExport,
 	destPath string,
 	opts map[string]string, // TODO: make this an actual type, this leaks too much untyped buildkit api
+	referrersFn ImageReferrersFunc,
 ) (map[string]string, error) {
 	ctx = buildkitTelemetryProvider(ctx)
 	ctx, cancel, err := c.withClientCloseCancel(ctx)
@@ -84,16 +107,23 @@ func (c *Client) ExportContainerImage(
 	}
 
 	variant := ociexporter.VariantDocker
-	if len(combinedResult.Refs) > 1 {
+	if len(combinedResult.Refs) > 1 || referrersFn != nil {
+		// the docker variant has no way to represent referrers
 		variant = ociexporter.VariantOCI
 	}
 
-	exporter, err := ociexporter.New(ociexporter.Opt{
+	expOpt := ociexporter.Opt{
 		SessionManager: c.SessionManager,
 		ImageWriter:    c.Worker.ImageWriter,
 		Variant:        variant,
 		LeaseManager:   c.Worker.LeaseManager(),
-	})
+	}
+	if referrersFn != nil {
+		expOpt.Referrers = func(ctx context.Context, subject specs.Descriptor) ([]specs.Descriptor, error) {
+			return writeReferrers(ctx, c.Worker.ContentStore(), subject, referrersFn)
+		}
+	}
+	exporter, err := ociexporter.New(expOpt)
 	if err != nil {
 		return nil, err
 	}
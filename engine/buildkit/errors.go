@@ -111,20 +111,7 @@ func (e RichError) AsExecErr(ctx context.Context, client *Client) (*ExecError, b
 }
 
 func (e RichError) DebugTerminal(ctx context.Context, client *Client) error {
-	if !client.Interactive {
-		return nil
-	}
-
-	// Ensure we only spawn one terminal per exec.
-	execMD := e.ExecMD
-	if execMD.ExecID != "" {
-		if _, exists := client.execMap.LoadOrStore(execMD.ExecID, struct{}{}); exists {
-			return nil
-		}
-	}
-
-	// If this is the (internal) exec of the module itself, we don't want to spawn a terminal.
-	if execMD.Internal {
+	if !client.ShouldDebugExec(e.ExecMD) {
 		return nil
 	}
 
@@ -138,6 +125,25 @@ func (e RichError) DebugTerminal(ctx context.Context, client *Client) error {
 	return e.Terminal(ctx, &e)
 }
 
+// ShouldDebugExec returns whether the user should be dropped into a terminal
+// to debug the given failed exec. It only returns true once per exec, so that
+// the exec isn't debugged again as its error propagates.
+func (c *Client) ShouldDebugExec(execMD *ExecutionMetadata) bool {
+	if !c.Interactive {
+		return false
+	}
+
+	// Ensure we only spawn one terminal per exec.
+	if execMD.ExecID != "" {
+		if _, exists := c.execMap.LoadOrStore(execMD.ExecID, struct{}{}); exists {
+			return false
+		}
+	}
+
+	// If this is the (internal) exec of the module itself, we don't want to spawn a terminal.
+	return !execMD.Internal
+}
+
 func getExecMeta(ctx context.Context, client *Client, metaMount bksolver.Result) (stdout []byte, stderr []byte, exitCode int, _ error) {
 	workerRef, ok := metaMount.Sys().(*bkworker.WorkerRef)
 	if !ok {
@@ -100,6 +100,10 @@ type ExecutionMetadata struct {
 	// If true, skip injecting dagger-init into the container.
 	NoInit bool
 
+	// Restricts the network access of the container. If nil, the container
+	// has full network access.
+	NetworkPolicy *NetworkPolicy
+
 	// list of remote modules allowed to access LLM APIs
 	// any value of "all" bypasses restrictions, a nil slice imposes them
 	AllowedLLMModules []string
@@ -171,8 +175,12 @@ func (w *Worker) Run(
 	}
 
 	state := newExecState(id, &procInfo, rootMount, mounts, started)
+	if w.execMD != nil {
+		state.sessionID = w.execMD.SessionID
+	}
 	return nil, w.run(ctx, state,
 		w.setupNetwork,
+		w.setupNetworkPolicy,
 		w.injectInit,
 		w.generateBaseSpec,
 		w.filterEnvs,
@@ -228,6 +236,22 @@ func (w *Worker) run(
 	return nil
 }
 
+// RunningExecs returns the number of containers currently running in each
+// dagger session, keyed by session ID.
+func (w *Worker) RunningExecs() map[string]int {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	counts := map[string]int{}
+	for _, state := range w.running {
+		if state.sessionID == "" {
+			// network namespaces and execs not tied to a session
+			continue
+		}
+		counts[state.sessionID]++
+	}
+	return counts
+}
+
 // Namespaced is something that has Linux namespaces set up.
 // Currently this is either a full-blown container or just a raw
 // network namespace that's setns'd into to support service tunneling
@@ -397,6 +421,19 @@ func (w *Worker) validateEntitlements(meta executor.Meta) error {
 	})
 }
 
+// runExitCode returns the exit code of a process run by runc, or -1 if it
+// can't be determined from the error.
+func runExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	var runcExitError *runc.ExitError
+	if errors.As(err, &runcExitError) {
+		return runcExitError.Status
+	}
+	return -1
+}
+
 func exitError(ctx context.Context, exitCodePath string, err error, validExitCodes []int) error {
 	exitErr := &gatewayapi.ExitError{ExitCode: uint32(gatewayapi.UnknownExitStatus), Err: err}
 
@@ -26,6 +26,7 @@ import (
 	runc "github.com/containerd/go-runc"
 	"github.com/dagger/dagger/engine/buildkit/resources"
 	"github.com/dagger/dagger/engine/client/pathutil"
+	"github.com/dagger/dagger/engine/metrics"
 	"github.com/dagger/dagger/engine/slog"
 	"github.com/dagger/dagger/internal/buildkit/executor"
 	"github.com/dagger/dagger/internal/buildkit/executor/oci"
@@ -92,6 +93,7 @@ var removeEnvs = map[string]struct{}{
 
 type execState struct {
 	id        string
+	sessionID string
 	procInfo  *executor.ProcessInfo
 	rootMount executor.Mount
 	mounts    []executor.Mount
@@ -1324,5 +1326,8 @@ func (w *Worker) runContainer(ctx context.Context, state *execState) (rerr error
 		return eg.Wait()
 	}
 
-	return exitError(ctx, state.exitCodePath, w.callWithIO(ctx, state.procInfo, startedCallback, killer, runcCall), state.procInfo.Meta.ValidExitCodes)
+	startedAt := time.Now()
+	err = w.callWithIO(ctx, state.procInfo, startedCallback, killer, runcCall)
+	metrics.ObserveExec(runExitCode(err), time.Since(startedAt))
+	return exitError(ctx, state.exitCodePath, err, state.procInfo.Meta.ValidExitCodes)
 }
@@ -52,6 +52,11 @@ type Opt struct {
 	ImageWriter    *containerimage.ImageWriter
 	Variant        ExporterVariant
 	LeaseManager   leases.Manager
+
+	// Referrers, if set, writes referrers of the exported image (e.g.
+	// signatures and attestations) to the image writer's content store, and
+	// returns their manifest descriptors so they're exported alongside it.
+	Referrers func(ctx context.Context, subject ocispecs.Descriptor) ([]ocispecs.Descriptor, error)
 }
 
 type imageExporter struct {
@@ -217,7 +222,18 @@ func (e *imageExporterInstance) Export(ctx context.Context, src *exporter.Source
 		resp["image.name"] = strings.Join(names, ",")
 	}
 
+	var referrers []ocispecs.Descriptor
+	if e.opt.Referrers != nil {
+		referrers, err = e.opt.Referrers(ctx, *desc)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
 	expOpts := []archiveexporter.ExportOpt{archiveexporter.WithManifest(*desc, names...)}
+	for _, referrer := range referrers {
+		expOpts = append(expOpts, archiveexporter.WithManifest(referrer))
+	}
 	switch e.opt.Variant {
 	case VariantOCI:
 		expOpts = append(expOpts, archiveexporter.WithAllPlatforms(), archiveexporter.WithSkipDockerManifest())
@@ -305,6 +321,11 @@ func (e *imageExporterInstance) Export(ctx context.Context, src *exporter.Source
 		if err != nil {
 			return nil, nil, err
 		}
+		for _, referrer := range referrers {
+			if err := contentutil.CopyChain(ctx, store, mprovider, referrer); err != nil {
+				return nil, nil, err
+			}
+		}
 	}
 
 	return resp, nil, nil
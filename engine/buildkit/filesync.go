@@ -11,11 +11,13 @@ import (
 	"path/filepath"
 
 	"github.com/containerd/continuity/fs"
+	bkcache "github.com/dagger/dagger/internal/buildkit/cache"
 	bkclient "github.com/dagger/dagger/internal/buildkit/client"
 	bkgw "github.com/dagger/dagger/internal/buildkit/frontend/gateway/client"
 	"github.com/dagger/dagger/internal/buildkit/session/filesync"
 	"github.com/dagger/dagger/internal/buildkit/snapshot"
 	bksolverpb "github.com/dagger/dagger/internal/buildkit/solver/pb"
+	solverresult "github.com/dagger/dagger/internal/buildkit/solver/result"
 	"github.com/dagger/dagger/internal/buildkit/util/bklog"
 	fsutiltypes "github.com/dagger/dagger/internal/fsutil/types"
 
@@ -120,7 +122,35 @@ func (c *Client) LocalDirExport(
 	if err != nil {
 		return fmt.Errorf("failed to convert result: %w", err)
 	}
+	return c.localExport(ctx, cacheRes, destPath, merge, removePaths)
+}
+
+// LocalRefExport exports the contents of a ref to a directory on the caller's
+// host, replacing its contents.
+func (c *Client) LocalRefExport(
+	ctx context.Context,
+	ref bkcache.ImmutableRef,
+	destPath string,
+) error {
+	ctx = bklog.WithLogger(ctx, bklog.G(ctx).WithField("export_path", destPath))
+	bklog.G(ctx).Debug("exporting local ref")
+
+	ctx, cancel, err := c.withClientCloseCancel(ctx)
+	if err != nil {
+		return err
+	}
+	defer cancel(errors.New("local ref export done"))
+
+	return c.localExport(ctx, &solverresult.Result[bkcache.ImmutableRef]{Ref: ref}, path.Clean(destPath), false, nil)
+}
 
+func (c *Client) localExport(
+	ctx context.Context,
+	cacheRes *solverresult.Result[bkcache.ImmutableRef],
+	destPath string,
+	merge bool,
+	removePaths []string,
+) error {
 	// TODO: lift this to dagger
 	exporter, err := c.Worker.Exporter(bkclient.ExporterLocal, c.SessionManager)
 	if err != nil {
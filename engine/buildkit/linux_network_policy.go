@@ -0,0 +1,216 @@
+//go:build !darwin && !windows
+
+package buildkit
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/vishvananda/netlink/nl"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/sys/unix"
+)
+
+// nfnetlink_log message types and attributes, see
+// include/uapi/linux/netfilter/nfnetlink_log.h
+const (
+	nfulnlMsgPacket = unix.NFNL_SUBSYS_ULOG<<8 | 0
+	nfulnlMsgConfig = unix.NFNL_SUBSYS_ULOG<<8 | 1
+
+	nfulaPayload = 9
+
+	nfulaCfgCmd  = 1
+	nfulaCfgMode = 2
+
+	nfulnlCfgCmdBind = 1
+	nfulnlCopyPacket = 2
+
+	// nlaTypeMask strips the NLA_F_NESTED and NLA_F_NET_BYTEORDER flags
+	// from an attribute type.
+	nlaTypeMask = 0x3fff
+)
+
+// watchBlockedEgress subscribes to the given NFLOG group in the container's
+// network namespace, and reports every blocked destination as an event on
+// the span in ctx. The returned function stops watching.
+func watchBlockedEgress(ctx context.Context, state *execState, group uint16) (func() error, error) {
+	sock, err := runInNetNS(ctx, state, func() (*nl.NetlinkSocket, error) {
+		return nl.Subscribe(unix.NETLINK_NETFILTER)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("open netfilter socket: %w", err)
+	}
+
+	if err := bindNFLOG(sock, group); err != nil {
+		sock.Close()
+		return nil, err
+	}
+	// wake up regularly to check whether we've been stopped
+	recvTimeout := unix.NsecToTimeval(int64(500 * time.Millisecond))
+	if err := sock.SetReceiveTimeout(&recvTimeout); err != nil {
+		sock.Close()
+		return nil, fmt.Errorf("set netfilter socket timeout: %w", err)
+	}
+
+	span := trace.SpanFromContext(ctx)
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		reported := map[string]bool{}
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			msgs, _, err := sock.Receive()
+			if err != nil {
+				if errors.Is(err, unix.EAGAIN) || errors.Is(err, unix.EINTR) {
+					continue
+				}
+				return
+			}
+			for _, msg := range msgs {
+				if msg.Header.Type != nfulnlMsgPacket {
+					continue
+				}
+				attrs, ok := parseBlockedPacket(msg)
+				if !ok {
+					continue
+				}
+				key := fmt.Sprint(attrs)
+				if reported[key] {
+					continue
+				}
+				reported[key] = true
+				span.AddEvent("egress blocked", trace.WithAttributes(attrs...))
+			}
+		}
+	}()
+
+	return func() error {
+		close(stop)
+		wg.Wait()
+		sock.Close()
+		return nil
+	}, nil
+}
+
+// bindNFLOG binds the socket to the NFLOG group, copying the start of each
+// packet.
+func bindNFLOG(sock *nl.NetlinkSocket, group uint16) error {
+	nfgen := &nl.Nfgenmsg{
+		NfgenFamily: unix.AF_UNSPEC,
+		Version:     unix.NFNETLINK_V0,
+		ResId:       nl.Swap16(group),
+	}
+
+	bind := nl.NewNetlinkRequest(nfulnlMsgConfig, unix.NLM_F_ACK)
+	bind.AddData(nfgen)
+	bind.AddData(nl.NewRtAttr(nfulaCfgCmd, []byte{nfulnlCfgCmdBind}))
+	if err := sendNFLOGConfig(sock, bind); err != nil {
+		return fmt.Errorf("bind NFLOG group %d: %w", group, err)
+	}
+
+	mode := make([]byte, 6)
+	binary.BigEndian.PutUint32(mode, egressLogSize)
+	mode[4] = nfulnlCopyPacket
+	setMode := nl.NewNetlinkRequest(nfulnlMsgConfig, unix.NLM_F_ACK)
+	setMode.AddData(nfgen)
+	setMode.AddData(nl.NewRtAttr(nfulaCfgMode, mode))
+	if err := sendNFLOGConfig(sock, setMode); err != nil {
+		return fmt.Errorf("configure NFLOG group %d: %w", group, err)
+	}
+	return nil
+}
+
+func sendNFLOGConfig(sock *nl.NetlinkSocket, req *nl.NetlinkRequest) error {
+	if err := sock.Send(req); err != nil {
+		return err
+	}
+	msgs, _, err := sock.Receive()
+	if err != nil {
+		return err
+	}
+	for _, msg := range msgs {
+		if msg.Header.Type != unix.NLMSG_ERROR || len(msg.Data) < 4 {
+			continue
+		}
+		if errno := int32(nl.NativeEndian().Uint32(msg.Data[0:4])); errno != 0 {
+			return syscall.Errno(-errno)
+		}
+	}
+	return nil
+}
+
+// parseBlockedPacket extracts the destination of a packet sent to an NFLOG
+// group.
+func parseBlockedPacket(msg syscall.NetlinkMessage) ([]attribute.KeyValue, bool) {
+	if len(msg.Data) < nl.SizeofNfgenmsg {
+		return nil, false
+	}
+	attrs, err := nl.ParseRouteAttr(msg.Data[nl.SizeofNfgenmsg:])
+	if err != nil {
+		return nil, false
+	}
+	var payload []byte
+	for _, attr := range attrs {
+		if attr.Attr.Type&nlaTypeMask == nfulaPayload {
+			payload = attr.Value
+		}
+	}
+	if len(payload) == 0 {
+		return nil, false
+	}
+
+	var dst net.IP
+	var proto byte
+	var l4 []byte
+	switch payload[0] >> 4 {
+	case 4:
+		ihl := int(payload[0]&0x0f) * 4
+		if len(payload) < 20 || len(payload) < ihl {
+			return nil, false
+		}
+		proto = payload[9]
+		dst = net.IP(payload[16:20])
+		l4 = payload[ihl:]
+	case 6:
+		if len(payload) < 40 {
+			return nil, false
+		}
+		proto = payload[6]
+		dst = net.IP(payload[24:40])
+		l4 = payload[40:]
+	default:
+		return nil, false
+	}
+
+	kvs := []attribute.KeyValue{
+		attribute.String("destination.address", dst.String()),
+	}
+	switch proto {
+	case unix.IPPROTO_TCP, unix.IPPROTO_UDP:
+		transport := "tcp"
+		if proto == unix.IPPROTO_UDP {
+			transport = "udp"
+		}
+		kvs = append(kvs, attribute.String("network.transport", transport))
+		if len(l4) >= 4 {
+			kvs = append(kvs, attribute.Int("destination.port", int(binary.BigEndian.Uint16(l4[2:4]))))
+		}
+	default:
+		kvs = append(kvs, attribute.String("network.protocol.number", strconv.Itoa(int(proto))))
+	}
+	return kvs, true
+}
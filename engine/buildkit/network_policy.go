@@ -0,0 +1,260 @@
+package buildkit
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+
+	"github.com/dagger/dagger/engine/slog"
+	"github.com/dagger/dagger/internal/buildkit/solver/pb"
+)
+
+// NetworkPolicy restricts the network access of a container.
+//
+// Loopback traffic and replies to inbound connections are always allowed, so
+// that the container can still serve as a service and talk to the telemetry
+// and nested client proxies running inside its network namespace.
+type NetworkPolicy struct {
+	// AllowServices allows connections to other containers on the engine
+	// network, i.e. services.
+	AllowServices bool
+
+	// AllowEgress is a list of hostnames and CIDRs that the container may
+	// connect to. Hostnames are resolved when the container starts.
+	AllowEgress []string
+}
+
+const (
+	// egressChain is the iptables chain that the network policy is installed
+	// in.
+	egressChain = "DAGGER-EGRESS"
+
+	// egressLogGroup is the NFLOG group that blocked packets are sent to.
+	// NFLOG groups are per network namespace, so a fixed group is fine.
+	egressLogGroup = 100
+
+	// egressLogSize is the number of bytes of each blocked packet sent to
+	// the NFLOG group, enough for the IP and TCP/UDP headers.
+	egressLogSize = 64
+)
+
+func (w *Worker) setupNetworkPolicy(ctx context.Context, state *execState) error {
+	if w.execMD == nil || w.execMD.NetworkPolicy == nil {
+		return nil
+	}
+	policy := w.execMD.NetworkPolicy
+
+	if state.procInfo.Meta.SecurityMode == pb.SecurityMode_INSECURE {
+		// CAP_NET_ADMIN would let the container flush the policy's chain
+		return fmt.Errorf("network policies are not supported with insecure root capabilities")
+	}
+
+	switch state.procInfo.Meta.NetMode {
+	case pb.NetMode_UNSET:
+	case pb.NetMode_NONE:
+		// already isolated from everything
+		return nil
+	default:
+		return fmt.Errorf("network policies are not supported with network mode %s", state.procInfo.Meta.NetMode)
+	}
+
+	allowed, err := resolveEgressAllowlist(ctx, policy.AllowEgress)
+	if err != nil {
+		return err
+	}
+
+	var nameservers []net.IP
+	if policy.AllowServices || len(allowed) > 0 {
+		// allow DNS so that services and allowed hostnames can be resolved
+		nameservers, err = resolvConfNameservers(state.resolvConfPath)
+		if err != nil {
+			return err
+		}
+	}
+
+	localNets, err := runInNetNS(ctx, state, localNetworks)
+	if err != nil {
+		return fmt.Errorf("list container networks: %w", err)
+	}
+
+	logGroup := egressLogGroup
+	stopWatching, err := watchBlockedEgress(ctx, state, egressLogGroup)
+	if err != nil {
+		// still enforce the policy, we just won't know what was blocked
+		slog.WarnContext(ctx, "failed to watch blocked egress", "error", err)
+		logGroup = 0
+	} else {
+		state.cleanups.Add("stop watching blocked egress", stopWatching)
+	}
+
+	rules := map[string]string{
+		"iptables-restore": egressRules(false, policy, localNets, nameservers, allowed, logGroup),
+	}
+	if slices.ContainsFunc(localNets, isIPv6Net) {
+		rules["ip6tables-restore"] = egressRules(true, policy, localNets, nameservers, allowed, logGroup)
+	}
+	_, err = runInNetNS(ctx, state, func() (struct{}, error) {
+		for cmdName, ruleset := range rules {
+			cmd := exec.CommandContext(ctx, cmdName, "--noflush", "--wait")
+			cmd.Stdin = strings.NewReader(ruleset)
+			if out, err := cmd.CombinedOutput(); err != nil {
+				return struct{}{}, fmt.Errorf("%s: %w: %s", cmdName, err, bytes.TrimSpace(out))
+			}
+		}
+		return struct{}{}, nil
+	})
+	if err != nil {
+		return fmt.Errorf("apply network policy: %w", err)
+	}
+	return nil
+}
+
+// egressRules returns the iptables-restore input enforcing the policy for
+// either IPv4 or IPv6. Allowed traffic returns from the egress chain, and
+// everything else is logged to logGroup (if non-zero) and rejected.
+func egressRules(
+	ipv6 bool,
+	policy *NetworkPolicy,
+	localNets []*net.IPNet,
+	nameservers []net.IP,
+	allowed []*net.IPNet,
+	logGroup int,
+) string {
+	var rules strings.Builder
+	rule := func(format string, args ...any) {
+		fmt.Fprintf(&rules, "-A %s "+format+"\n", append([]any{egressChain}, args...)...)
+	}
+
+	fmt.Fprintln(&rules, "*filter")
+	fmt.Fprintf(&rules, ":%s - [0:0]\n", egressChain)
+	fmt.Fprintf(&rules, "-A OUTPUT -j %s\n", egressChain)
+
+	rule("-o lo -j RETURN")
+	rule("-m conntrack --ctstate ESTABLISHED,RELATED -j RETURN")
+	if policy.AllowServices {
+		for _, ipnet := range localNets {
+			if isIPv6Net(ipnet) != ipv6 {
+				continue
+			}
+			rule("-d %s -j RETURN", ipnet)
+		}
+	}
+	for _, ns := range nameservers {
+		if (ns.To4() == nil) != ipv6 {
+			continue
+		}
+		rule("-d %s -p udp --dport 53 -j RETURN", ns)
+		rule("-d %s -p tcp --dport 53 -j RETURN", ns)
+	}
+	for _, ipnet := range allowed {
+		if isIPv6Net(ipnet) != ipv6 {
+			continue
+		}
+		rule("-d %s -j RETURN", ipnet)
+	}
+
+	if logGroup != 0 {
+		rule("-j NFLOG --nflog-group %d --nflog-size %d", logGroup, egressLogSize)
+	}
+	rule("-p tcp -j REJECT --reject-with tcp-reset")
+	rule("-j REJECT")
+
+	fmt.Fprintln(&rules, "COMMIT")
+	return rules.String()
+}
+
+// resolveEgressAllowlist turns a list of hostnames, IPs and CIDRs into the
+// networks they cover.
+func resolveEgressAllowlist(ctx context.Context, allowlist []string) ([]*net.IPNet, error) {
+	var nets []*net.IPNet
+	for _, entry := range allowlist {
+		if _, ipnet, err := net.ParseCIDR(entry); err == nil {
+			nets = append(nets, ipnet)
+			continue
+		}
+		if ip := net.ParseIP(entry); ip != nil {
+			nets = append(nets, hostNet(ip))
+			continue
+		}
+		addrs, err := net.DefaultResolver.LookupIPAddr(ctx, entry)
+		if err != nil {
+			return nil, fmt.Errorf("resolve allowed egress host %q: %w", entry, err)
+		}
+		for _, addr := range addrs {
+			nets = append(nets, hostNet(addr.IP))
+		}
+	}
+	return nets, nil
+}
+
+// resolvConfNameservers returns the nameservers configured in a resolv.conf.
+func resolvConfNameservers(path string) ([]net.IP, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open resolv.conf: %w", err)
+	}
+	defer f.Close()
+
+	var nameservers []net.IP
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 || fields[0] != "nameserver" {
+			continue
+		}
+		if ip := net.ParseIP(fields[1]); ip != nil {
+			nameservers = append(nameservers, ip)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read resolv.conf: %w", err)
+	}
+	return nameservers, nil
+}
+
+// localNetworks returns the networks of the non-loopback interfaces in the
+// current network namespace.
+func localNetworks() ([]*net.IPNet, error) {
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		return nil, err
+	}
+	var nets []*net.IPNet
+	for _, iface := range ifaces {
+		if iface.Flags&net.FlagLoopback != 0 {
+			continue
+		}
+		addrs, err := iface.Addrs()
+		if err != nil {
+			return nil, err
+		}
+		for _, addr := range addrs {
+			ipnet, ok := addr.(*net.IPNet)
+			if !ok {
+				continue
+			}
+			nets = append(nets, &net.IPNet{
+				IP:   ipnet.IP.Mask(ipnet.Mask),
+				Mask: ipnet.Mask,
+			})
+		}
+	}
+	return nets, nil
+}
+
+func hostNet(ip net.IP) *net.IPNet {
+	if ip4 := ip.To4(); ip4 != nil {
+		return &net.IPNet{IP: ip4, Mask: net.CIDRMask(32, 32)}
+	}
+	return &net.IPNet{IP: ip, Mask: net.CIDRMask(128, 128)}
+}
+
+func isIPv6Net(ipnet *net.IPNet) bool {
+	return ipnet.IP.To4() == nil
+}
@@ -0,0 +1,100 @@
+package buildkit
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEgressRules(t *testing.T) {
+	t.Parallel()
+
+	localNets := []*net.IPNet{
+		mustParseCIDR(t, "10.87.0.0/16"),
+		mustParseCIDR(t, "fe80::/64"),
+	}
+	nameservers := []net.IP{net.ParseIP("10.87.0.1")}
+	allowed := []*net.IPNet{
+		mustParseCIDR(t, "192.0.2.0/24"),
+		mustParseCIDR(t, "2001:db8::1/128"),
+	}
+
+	t.Run("none", func(t *testing.T) {
+		t.Parallel()
+		rules := egressRules(false, &NetworkPolicy{}, localNets, nil, nil, egressLogGroup)
+		require.Equal(t, `*filter
+:DAGGER-EGRESS - [0:0]
+-A OUTPUT -j DAGGER-EGRESS
+-A DAGGER-EGRESS -o lo -j RETURN
+-A DAGGER-EGRESS -m conntrack --ctstate ESTABLISHED,RELATED -j RETURN
+-A DAGGER-EGRESS -j NFLOG --nflog-group 100 --nflog-size 64
+-A DAGGER-EGRESS -p tcp -j REJECT --reject-with tcp-reset
+-A DAGGER-EGRESS -j REJECT
+COMMIT
+`, rules)
+	})
+
+	t.Run("services and allowlist", func(t *testing.T) {
+		t.Parallel()
+		policy := &NetworkPolicy{AllowServices: true}
+		rules := egressRules(false, policy, localNets, nameservers, allowed, 0)
+		require.Equal(t, `*filter
+:DAGGER-EGRESS - [0:0]
+-A OUTPUT -j DAGGER-EGRESS
+-A DAGGER-EGRESS -o lo -j RETURN
+-A DAGGER-EGRESS -m conntrack --ctstate ESTABLISHED,RELATED -j RETURN
+-A DAGGER-EGRESS -d 10.87.0.0/16 -j RETURN
+-A DAGGER-EGRESS -d 10.87.0.1 -p udp --dport 53 -j RETURN
+-A DAGGER-EGRESS -d 10.87.0.1 -p tcp --dport 53 -j RETURN
+-A DAGGER-EGRESS -d 192.0.2.0/24 -j RETURN
+-A DAGGER-EGRESS -p tcp -j REJECT --reject-with tcp-reset
+-A DAGGER-EGRESS -j REJECT
+COMMIT
+`, rules)
+	})
+
+	t.Run("ipv6", func(t *testing.T) {
+		t.Parallel()
+		policy := &NetworkPolicy{AllowServices: true}
+		rules := egressRules(true, policy, localNets, nameservers, allowed, 0)
+		require.Equal(t, `*filter
+:DAGGER-EGRESS - [0:0]
+-A OUTPUT -j DAGGER-EGRESS
+-A DAGGER-EGRESS -o lo -j RETURN
+-A DAGGER-EGRESS -m conntrack --ctstate ESTABLISHED,RELATED -j RETURN
+-A DAGGER-EGRESS -d fe80::/64 -j RETURN
+-A DAGGER-EGRESS -d 2001:db8::1/128 -j RETURN
+-A DAGGER-EGRESS -p tcp -j REJECT --reject-with tcp-reset
+-A DAGGER-EGRESS -j REJECT
+COMMIT
+`, rules)
+	})
+}
+
+func TestResolveEgressAllowlist(t *testing.T) {
+	t.Parallel()
+
+	nets, err := resolveEgressAllowlist(context.Background(), []string{
+		"10.0.0.0/8",
+		"192.0.2.1",
+		"2001:db8::1",
+		"localhost",
+	})
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, len(nets), 4)
+	require.Equal(t, "10.0.0.0/8", nets[0].String())
+	require.Equal(t, "192.0.2.1/32", nets[1].String())
+	require.Equal(t, "2001:db8::1/128", nets[2].String())
+
+	_, err = resolveEgressAllowlist(context.Background(), []string{"does-not-exist.invalid"})
+	require.Error(t, err)
+}
+
+func mustParseCIDR(t *testing.T, s string) *net.IPNet {
+	t.Helper()
+	_, ipnet, err := net.ParseCIDR(s)
+	require.NoError(t, err)
+	return ipnet
+}
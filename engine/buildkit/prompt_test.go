@@ -0,0 +1,89 @@
+package buildkit
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/charmbracelet/huh"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/dagger/dagger/engine/session/prompt"
+	bksession "github.com/dagger/dagger/internal/buildkit/session"
+)
+
+type testCaller struct {
+	bksession.Caller
+	conn *grpc.ClientConn
+}
+
+func (c testCaller) Conn() *grpc.ClientConn {
+	return c.conn
+}
+
+type testPromptHandler struct {
+	response bool
+}
+
+func (h testPromptHandler) HandlePrompt(_ context.Context, _, _ string, dest any) error {
+	*dest.(*bool) = h.response
+	return nil
+}
+
+func (h testPromptHandler) HandleForm(context.Context, *huh.Form) error {
+	return nil
+}
+
+// newPromptTestClient returns a client whose main client serves the given
+// attachables, like the session attachables of a dagger client.
+func newPromptTestClient(t *testing.T, register func(*grpc.Server)) *Client {
+	lis := bufconn.Listen(1024 * 1024)
+	srv := grpc.NewServer()
+	register(srv)
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return &Client{Opts: &Opts{
+		GetMainClientCaller: func() (bksession.Caller, error) {
+			return testCaller{conn: conn}, nil
+		},
+	}}
+}
+
+func TestPromptAllowToolCall(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	t.Run("no prompt handler", func(t *testing.T) {
+		t.Parallel()
+		// the client doesn't serve prompts, e.g. in CI, so the call is denied
+		client := newPromptTestClient(t, func(*grpc.Server) {})
+		err := client.PromptAllowToolCall(ctx, "Directory.export")
+		require.ErrorContains(t, err, "tool call Directory.export requires approval, but the user could not be prompted")
+	})
+
+	t.Run("allowed", func(t *testing.T) {
+		t.Parallel()
+		client := newPromptTestClient(t, prompt.NewPromptAttachable(testPromptHandler{response: true}).Register)
+		require.NoError(t, client.PromptAllowToolCall(ctx, "Directory.export"))
+	})
+
+	t.Run("denied", func(t *testing.T) {
+		t.Parallel()
+		client := newPromptTestClient(t, prompt.NewPromptAttachable(testPromptHandler{response: false}).Register)
+		err := client.PromptAllowToolCall(ctx, "Directory.export")
+		require.ErrorContains(t, err, "tool call Directory.export was denied by the user")
+	})
+}
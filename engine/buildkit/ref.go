@@ -11,6 +11,7 @@ import (
 	"github.com/containerd/containerd/v2/core/leases"
 	"github.com/containerd/continuity/fs"
 	bkcache "github.com/dagger/dagger/internal/buildkit/cache"
+	bkcacheconfig "github.com/dagger/dagger/internal/buildkit/cache/config"
 	"github.com/dagger/dagger/internal/buildkit/cache/contenthash"
 	cacheutil "github.com/dagger/dagger/internal/buildkit/cache/util"
 	"github.com/dagger/dagger/internal/buildkit/client/llb"
@@ -23,6 +24,7 @@ import (
 	"github.com/dagger/dagger/internal/buildkit/solver/llbsolver/provenance"
 	solverresult "github.com/dagger/dagger/internal/buildkit/solver/result"
 	"github.com/dagger/dagger/internal/buildkit/util/bklog"
+	"github.com/dagger/dagger/internal/buildkit/util/compression"
 	bkworker "github.com/dagger/dagger/internal/buildkit/worker"
 	"github.com/dagger/dagger/internal/fsutil"
 	fstypes "github.com/dagger/dagger/internal/fsutil/types"
@@ -205,6 +207,29 @@ func (r *ref) AddDependencyBlobs(ctx context.Context, blobs map[digest.Digest]*o
 	return nil
 }
 
+// Layers returns the descriptors of the ref's layers, as they would be
+// exported with the default compression. Layers that don't have a blob yet are
+// compressed.
+func (r *ref) Layers(ctx context.Context) ([]ocispecs.Descriptor, error) {
+	ctx = withOutgoingContext(ctx)
+
+	cacheRef, err := r.CacheRef(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ctx = withDescHandlerCacheOpts(ctx, cacheRef)
+	remotes, err := cacheRef.GetRemotes(ctx, true, bkcacheconfig.RefConfig{
+		Compression: compression.New(compression.Default),
+	}, false, bksession.NewGroup(r.c.ID()))
+	if err != nil {
+		return nil, err
+	}
+	if len(remotes) == 0 {
+		return nil, nil
+	}
+	return remotes[0].Descriptors, nil
+}
+
 func (r *ref) getMountable(ctx context.Context) (snapshot.Mountable, error) {
 	if r == nil {
 		return nil, nil
@@ -0,0 +1,232 @@
+package buildkit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/containerd/containerd/v2/core/content"
+	"github.com/containerd/containerd/v2/core/remotes"
+	cerrdefs "github.com/containerd/errdefs"
+	"github.com/dagger/dagger/internal/buildkit/session"
+	"github.com/dagger/dagger/internal/buildkit/util/push"
+	"github.com/dagger/dagger/internal/buildkit/util/resolver"
+	"github.com/distribution/reference"
+	"github.com/opencontainers/go-digest"
+	specsgo "github.com/opencontainers/image-spec/specs-go"
+	specs "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// ImageReferrer is an artifact attached to an image as an OCI referrer, such
+// as a signature or an attestation.
+type ImageReferrer struct {
+	// ArtifactType is the artifactType of the referrer manifest.
+	ArtifactType string
+	// MediaType is the media type of Data, which is stored as the only layer.
+	MediaType string
+	Data      []byte
+	// Annotations are set on the referrer manifest.
+	Annotations map[string]string
+}
+
+// ImageReferrersFunc returns the referrers to attach to an image, given the
+// descriptor and raw content of its manifest or index.
+type ImageReferrersFunc func(ctx context.Context, subject specs.Descriptor, manifest []byte) ([]ImageReferrer, error)
+
+// emptyJSON is the empty descriptor content recommended by the image spec for
+// the config of artifact manifests.
+var emptyJSON = []byte("{}")
+
+// writeReferrers writes the manifests of the referrers of subject, and the
+// blobs they point to, to the content store. The caller is responsible for
+// holding a lease on ctx.
+func writeReferrers(
+	ctx context.Context,
+	store content.Store,
+	subject specs.Descriptor,
+	referrersFn ImageReferrersFunc,
+) ([]specs.Descriptor, error) {
+	manifest, err := content.ReadBlob(ctx, store, subject)
+	if err != nil {
+		return nil, fmt.Errorf("read image manifest: %w", err)
+	}
+	referrers, err := referrersFn(ctx, subject, manifest)
+	if err != nil {
+		return nil, err
+	}
+	if len(referrers) == 0 {
+		return nil, nil
+	}
+
+	// don't carry over annotations like the creation time from the exporter
+	subject = specs.Descriptor{
+		MediaType: subject.MediaType,
+		Digest:    subject.Digest,
+		Size:      subject.Size,
+	}
+	config, err := writeBlob(ctx, store, specs.MediaTypeEmptyJSON, emptyJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	descs := make([]specs.Descriptor, 0, len(referrers))
+	for _, referrer := range referrers {
+		layer, err := writeBlob(ctx, store, referrer.MediaType, referrer.Data)
+		if err != nil {
+			return nil, err
+		}
+		mfst, err := json.Marshal(specs.Manifest{
+			Versioned:    specsgo.Versioned{SchemaVersion: 2},
+			MediaType:    specs.MediaTypeImageManifest,
+			ArtifactType: referrer.ArtifactType,
+			Config:       config,
+			Layers:       []specs.Descriptor{layer},
+			Subject:      &subject,
+			Annotations:  referrer.Annotations,
+		})
+		if err != nil {
+			return nil, err
+		}
+		desc, err := writeBlob(ctx, store, specs.MediaTypeImageManifest, mfst)
+		if err != nil {
+			return nil, err
+		}
+		desc.ArtifactType = referrer.ArtifactType
+		desc.Annotations = referrer.Annotations
+		descs = append(descs, desc)
+	}
+	return descs, nil
+}
+
+func writeBlob(ctx context.Context, store content.Ingester, mediaType string, data []byte) (specs.Descriptor, error) {
+	desc := specs.Descriptor{
+		MediaType: mediaType,
+		Digest:    digest.FromBytes(data),
+		Size:      int64(len(data)),
+	}
+	if err := content.WriteBlob(ctx, store, desc.Digest.String(), bytes.NewReader(data), desc); err != nil {
+		return specs.Descriptor{}, fmt.Errorf("write %s: %w", mediaType, err)
+	}
+	return desc, nil
+}
+
+// pushReferrers pushes the referrer manifests to the repository of ref.
+//
+// Registries that implement the referrers API index them via their subject,
+// but for those that don't, the referrers tag schema fallback is maintained
+// too: an index of all referrers tagged with the subject's digest.
+func (c *Client) pushReferrers(
+	ctx context.Context,
+	ref string,
+	subject specs.Descriptor,
+	referrers []specs.Descriptor,
+) error {
+	named, err := reference.ParseNormalizedNamed(ref)
+	if err != nil {
+		return err
+	}
+	repo := named.Name()
+	store := c.Worker.ContentStore()
+	hosts := c.Worker.RegistryHosts
+	for _, desc := range referrers {
+		if err := push.Push(ctx, c.SessionManager, c.ID(), store, store, desc.Digest, repo, false, hosts, true, nil); err != nil {
+			return fmt.Errorf("push referrer %s: %w", desc.Digest, err)
+		}
+	}
+
+	tagRef := repo + ":" + referrersTag(subject.Digest)
+	group := session.NewGroup(c.ID())
+	pullResolver := resolver.DefaultPool.GetResolver(hosts, tagRef, "pull", c.SessionManager, group)
+	index, err := fetchReferrersIndex(ctx, pullResolver, tagRef)
+	if err != nil {
+		return err
+	}
+	for _, desc := range referrers {
+		if !hasDescriptor(index.Manifests, desc.Digest) {
+			index.Manifests = append(index.Manifests, desc)
+		}
+	}
+	dt, err := json.Marshal(index)
+	if err != nil {
+		return err
+	}
+	indexDesc := specs.Descriptor{
+		MediaType: specs.MediaTypeImageIndex,
+		Digest:    digest.FromBytes(dt),
+		Size:      int64(len(dt)),
+	}
+
+	pushResolver := resolver.DefaultPool.GetResolver(hosts, tagRef, "push", c.SessionManager, group)
+	pusher, err := pushResolver.Pusher(ctx, tagRef)
+	if err != nil {
+		return err
+	}
+	w, err := pusher.Push(ctx, indexDesc)
+	if err != nil {
+		if cerrdefs.IsAlreadyExists(err) {
+			return nil
+		}
+		return fmt.Errorf("push referrers index: %w", err)
+	}
+	defer w.Close()
+	if err := content.Copy(ctx, w, bytes.NewReader(dt), indexDesc.Size, indexDesc.Digest); err != nil {
+		if cerrdefs.IsAlreadyExists(err) {
+			return nil
+		}
+		return fmt.Errorf("push referrers index: %w", err)
+	}
+	return nil
+}
+
+// fetchReferrersIndex fetches the referrers index at the given tag, or returns
+// an empty one if it doesn't exist yet.
+func fetchReferrersIndex(ctx context.Context, r remotes.Resolver, ref string) (*specs.Index, error) {
+	index := &specs.Index{
+		Versioned: specsgo.Versioned{SchemaVersion: 2},
+		MediaType: specs.MediaTypeImageIndex,
+		Manifests: []specs.Descriptor{},
+	}
+	name, desc, err := r.Resolve(ctx, ref)
+	if err != nil {
+		if errors.Is(err, cerrdefs.ErrNotFound) {
+			return index, nil
+		}
+		return nil, fmt.Errorf("resolve referrers index: %w", err)
+	}
+	fetcher, err := r.Fetcher(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	rc, err := fetcher.Fetch(ctx, desc)
+	if err != nil {
+		return nil, fmt.Errorf("fetch referrers index: %w", err)
+	}
+	defer rc.Close()
+	dt, err := io.ReadAll(rc)
+	if err != nil {
+		return nil, fmt.Errorf("fetch referrers index: %w", err)
+	}
+	if err := json.Unmarshal(dt, index); err != nil {
+		return nil, fmt.Errorf("decode referrers index: %w", err)
+	}
+	return index, nil
+}
+
+// referrersTag is the tag of the referrers index for subject, as defined by
+// the referrers tag schema of the distribution spec.
+func referrersTag(subject digest.Digest) string {
+	return strings.Replace(subject.String(), ":", "-", 1)
+}
+
+func hasDescriptor(descs []specs.Descriptor, dgst digest.Digest) bool {
+	for _, desc := range descs {
+		if desc.Digest == dgst {
+			return true
+		}
+	}
+	return false
+}
@@ -71,3 +71,7 @@ func ShutdownGlobalNamespaceWorkerPool() error {
 func getContainerPID(containerID string) (int, error) {
 	panic("implemented only on linux")
 }
+
+func watchBlockedEgress(ctx context.Context, state *execState, group uint16) (func() error, error) {
+	panic("implemented only on linux")
+}
@@ -46,6 +46,14 @@ type Cache[K KeyType, V any] interface {
 
 	// Run a blocking loop that periodically garbage collects expired entries from the cache db.
 	GCLoop(context.Context)
+
+	// Returns the entries of the cache db that haven't expired yet, e.g. to push them to a
+	// remote cache.
+	ExportCalls(context.Context) ([]*cachedb.Call, error)
+
+	// Merges the given entries into the cache db, e.g. after pulling them from a remote cache.
+	// Existing entries are only replaced by ones that expire later.
+	ImportCalls(context.Context, []*cachedb.Call) error
 }
 
 type Result[K KeyType, V any] interface {
@@ -251,6 +259,29 @@ func (c *cache[K, V]) GCLoop(ctx context.Context) {
 	}
 }
 
+func (c *cache[K, V]) ExportCalls(ctx context.Context) ([]*cachedb.Call, error) {
+	if c.db == nil {
+		return nil, nil
+	}
+	return c.db.SelectUnexpiredCalls(ctx, time.Now().Unix())
+}
+
+func (c *cache[K, V]) ImportCalls(ctx context.Context, calls []*cachedb.Call) error {
+	if c.db == nil {
+		return nil
+	}
+	now := time.Now().Unix()
+	for _, call := range calls {
+		if call.Expiration < now {
+			continue
+		}
+		if err := c.db.MergeCall(ctx, call); err != nil {
+			return fmt.Errorf("merge call %s: %w", call.CallKey, err)
+		}
+	}
+	return nil
+}
+
 func (c *cache[K, V]) Size() int {
 	c.mu.Lock()
 	defer c.mu.Unlock()
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"path/filepath"
 	"sync"
 	"testing"
 	"time"
@@ -11,6 +12,9 @@ import (
 	"golang.org/x/sync/errgroup"
 	"gotest.tools/v3/assert"
 	is "gotest.tools/v3/assert/cmp"
+
+	"github.com/dagger/dagger/engine"
+	cachedb "github.com/dagger/dagger/engine/cache/db"
 )
 
 func TestCacheConcurrent(t *testing.T) {
@@ -427,3 +431,40 @@ func TestSkipDedupe(t *testing.T) {
 		t.Fatal("timed out waiting for resCh2")
 	}
 }
+
+func TestCacheExportImportCalls(t *testing.T) {
+	t.Parallel()
+	ctx := engine.ContextWithClientMetadata(t.Context(), &engine.ClientMetadata{
+		ClientID:  "client",
+		SessionID: "session",
+	})
+
+	src, err := NewCache[string, int](ctx, filepath.Join(t.TempDir(), "src.db"))
+	assert.NilError(t, err)
+	res, err := src.GetOrInitializeWithCallbacks(ctx, CacheKey[string]{CallKey: "ttl", TTL: 60}, func(context.Context) (*ValueWithCallbacks[int], error) {
+		return &ValueWithCallbacks[int]{Value: 1, SafeToPersistCache: true}, nil
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, 1, res.Result())
+
+	calls, err := src.ExportCalls(ctx)
+	assert.NilError(t, err)
+	assert.Assert(t, is.Len(calls, 1))
+	assert.Equal(t, "ttl", calls[0].CallKey)
+
+	dst, err := NewCache[string, int](ctx, filepath.Join(t.TempDir(), "dst.db"))
+	assert.NilError(t, err)
+
+	// expired and older entries are ignored
+	now := time.Now().Unix()
+	assert.NilError(t, dst.ImportCalls(ctx, []*cachedb.Call{
+		{CallKey: "expired", StorageKey: "expired", Expiration: now - 1},
+		{CallKey: "ttl", StorageKey: "newer", Expiration: calls[0].Expiration + 60},
+	}))
+	assert.NilError(t, dst.ImportCalls(ctx, calls))
+
+	imported, err := dst.ExportCalls(ctx)
+	assert.NilError(t, err)
+	assert.Assert(t, is.Len(imported, 1))
+	assert.Equal(t, "newer", imported[0].StorageKey)
+}
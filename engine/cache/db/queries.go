@@ -71,3 +71,39 @@ func (q *Queries) GCExpiredCalls(ctx context.Context, arg GCExpiredCallsParams)
 	}
 	return nil
 }
+
+const selectUnexpiredCalls = `SELECT call_key, storage_key, expiration FROM calls WHERE expiration >= ?`
+
+func (q *Queries) SelectUnexpiredCalls(ctx context.Context, now int64) ([]*Call, error) {
+	rows, err := q.db.QueryContext(ctx, selectUnexpiredCalls, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var calls []*Call
+	for rows.Next() {
+		var i Call
+		if err := rows.Scan(&i.CallKey, &i.StorageKey, &i.Expiration); err != nil {
+			return nil, err
+		}
+		calls = append(calls, &i)
+	}
+	return calls, rows.Err()
+}
+
+// Upsert an entry only if it expires later than the existing one, if any.
+const mergeCall = `
+INSERT INTO calls (call_key, storage_key, expiration)
+VALUES (?, ?, ?)
+ON CONFLICT (call_key) DO UPDATE SET
+	expiration = EXCLUDED.expiration,
+	storage_key = EXCLUDED.storage_key
+WHERE calls.expiration < EXCLUDED.expiration
+`
+
+func (q *Queries) MergeCall(ctx context.Context, arg *Call) error {
+	_, err := q.exec(ctx, nil, mergeCall,
+		arg.CallKey, arg.StorageKey, arg.Expiration,
+	)
+	return err
+}
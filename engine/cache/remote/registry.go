@@ -0,0 +1,96 @@
+package remote
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	ggcrremote "github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+
+	"github.com/dagger/dagger/engine/cache/db"
+	"github.com/dagger/dagger/engine/config"
+)
+
+const (
+	// registryCallsTagSuffix is appended to the tag of the configured ref to
+	// get the tag calls are stored at, next to buildkit's cache manifest.
+	registryCallsTagSuffix = "-dagql-calls"
+
+	callsMediaType types.MediaType = "application/vnd.dagger.dagql.calls.v1+json"
+)
+
+type registryStore struct {
+	ref name.Tag
+}
+
+// NewRegistry returns a store that keeps calls in an OCI registry, as a
+// single-layer artifact.
+func NewRegistry(cfg *config.RegistryCacheConfig) (Store, error) {
+	if cfg.Ref == "" {
+		return nil, errors.New("ref must be set for the registry result cache")
+	}
+	var opts []name.Option
+	if cfg.Insecure {
+		opts = append(opts, name.Insecure)
+	}
+	ref, err := name.NewTag(cfg.Ref, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("parse ref %q: %w", cfg.Ref, err)
+	}
+	return &registryStore{
+		ref: ref.Context().Tag(ref.TagStr() + registryCallsTagSuffix),
+	}, nil
+}
+
+func (s *registryStore) options(ctx context.Context) []ggcrremote.Option {
+	return []ggcrremote.Option{
+		ggcrremote.WithContext(ctx),
+		ggcrremote.WithAuthFromKeychain(authn.DefaultKeychain),
+	}
+}
+
+func (s *registryStore) Pull(ctx context.Context) ([]*db.Call, error) {
+	img, err := ggcrremote.Image(s.ref, s.options(ctx)...)
+	if err != nil {
+		var terr *transport.Error
+		if errors.As(err, &terr) && terr.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	layers, err := img.Layers()
+	if err != nil {
+		return nil, err
+	}
+	if len(layers) != 1 {
+		return nil, fmt.Errorf("expected 1 layer in %s, got %d", s.ref, len(layers))
+	}
+	rc, err := layers[0].Uncompressed()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return decodeCalls(rc)
+}
+
+func (s *registryStore) Push(ctx context.Context, calls []*db.Call) error {
+	data, err := encodeCalls(calls)
+	if err != nil {
+		return err
+	}
+	img, err := mutate.AppendLayers(empty.Image, static.NewLayer(data, callsMediaType))
+	if err != nil {
+		return err
+	}
+	img = mutate.MediaType(img, types.OCIManifestSchema1)
+	img = mutate.ConfigMediaType(img, types.OCIConfigJSON)
+	return ggcrremote.Write(s.ref, img, s.options(ctx)...)
+}
@@ -0,0 +1,118 @@
+// Package remote syncs the call metadata of the dagql cache with remote
+// storage, so that cached function call results can be found again by an
+// engine that starts from scratch.
+//
+// Only the metadata lives here; the results themselves are snapshots that are
+// pushed and pulled by buildkit's remote cache exporters and importers.
+package remote
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/dagger/dagger/engine/cache/db"
+	"github.com/dagger/dagger/engine/config"
+)
+
+// Store is remote storage for call metadata.
+type Store interface {
+	// Pull returns the calls stored remotely, or nil if there are none yet.
+	Pull(context.Context) ([]*db.Call, error)
+
+	// Push replaces the calls stored remotely.
+	Push(context.Context, []*db.Call) error
+}
+
+// New returns the store configured by the given config.
+func New(ctx context.Context, cfg *config.ResultCacheConfig) (Store, error) {
+	switch {
+	case cfg.S3 != nil && cfg.Registry != nil:
+		return nil, errors.New("only one of s3 or registry may be configured for the result cache")
+	case cfg.S3 != nil:
+		return NewS3(ctx, cfg.S3)
+	case cfg.Registry != nil:
+		return NewRegistry(cfg.Registry)
+	default:
+		return nil, errors.New("one of s3 or registry must be configured for the result cache")
+	}
+}
+
+// Push merges the given calls with those already stored remotely and pushes
+// the result, so that concurrent engines don't clobber each other's entries
+// (other than in a race between the pull and the push).
+func Push(ctx context.Context, store Store, calls []*db.Call) error {
+	remoteCalls, err := store.Pull(ctx)
+	if err != nil {
+		return fmt.Errorf("pull: %w", err)
+	}
+	return store.Push(ctx, merge(time.Now(), remoteCalls, calls))
+}
+
+// merge combines sets of calls, keeping the entry that expires last for each
+// call and dropping any that have expired.
+func merge(now time.Time, sets ...[]*db.Call) []*db.Call {
+	byKey := map[string]*db.Call{}
+	var keys []string
+	for _, calls := range sets {
+		for _, call := range calls {
+			if call.Expiration < now.Unix() {
+				continue
+			}
+			existing, found := byKey[call.CallKey]
+			if !found {
+				keys = append(keys, call.CallKey)
+			}
+			if !found || existing.Expiration < call.Expiration {
+				byKey[call.CallKey] = call
+			}
+		}
+	}
+	merged := make([]*db.Call, len(keys))
+	for i, key := range keys {
+		merged[i] = byKey[key]
+	}
+	return merged
+}
+
+// callsFile is the format calls are stored in remotely.
+type callsFile struct {
+	Calls []callEntry `json:"calls"`
+}
+
+type callEntry struct {
+	CallKey    string `json:"callKey"`
+	StorageKey string `json:"storageKey"`
+	Expiration int64  `json:"expiration"`
+}
+
+func encodeCalls(calls []*db.Call) ([]byte, error) {
+	file := callsFile{Calls: make([]callEntry, len(calls))}
+	for i, call := range calls {
+		file.Calls[i] = callEntry{
+			CallKey:    call.CallKey,
+			StorageKey: call.StorageKey,
+			Expiration: call.Expiration,
+		}
+	}
+	return json.Marshal(file)
+}
+
+func decodeCalls(r io.Reader) ([]*db.Call, error) {
+	var file callsFile
+	if err := json.NewDecoder(r).Decode(&file); err != nil {
+		return nil, fmt.Errorf("decode calls: %w", err)
+	}
+	calls := make([]*db.Call, len(file.Calls))
+	for i, entry := range file.Calls {
+		calls[i] = &db.Call{
+			CallKey:    entry.CallKey,
+			StorageKey: entry.StorageKey,
+			Expiration: entry.Expiration,
+		}
+	}
+	return calls, nil
+}
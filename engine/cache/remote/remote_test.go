@@ -0,0 +1,81 @@
+package remote
+
+import (
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dagger/dagger/engine/cache/db"
+	"github.com/dagger/dagger/engine/config"
+)
+
+func TestMerge(t *testing.T) {
+	now := time.Unix(1000, 0)
+	merged := merge(now,
+		[]*db.Call{
+			{CallKey: "a", StorageKey: "a1", Expiration: 2000},
+			{CallKey: "b", StorageKey: "b1", Expiration: 2000},
+			{CallKey: "expired", StorageKey: "x", Expiration: 999},
+		},
+		[]*db.Call{
+			{CallKey: "a", StorageKey: "a2", Expiration: 3000},
+			{CallKey: "b", StorageKey: "b2", Expiration: 1500},
+		},
+	)
+	require.Equal(t, []*db.Call{
+		{CallKey: "a", StorageKey: "a2", Expiration: 3000},
+		{CallKey: "b", StorageKey: "b1", Expiration: 2000},
+	}, merged)
+}
+
+func TestRegistryStore(t *testing.T) {
+	ctx := t.Context()
+
+	srv := httptest.NewServer(registry.New())
+	t.Cleanup(srv.Close)
+	u, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+
+	store, err := New(ctx, &config.ResultCacheConfig{
+		Registry: &config.RegistryCacheConfig{
+			Ref:      u.Host + "/dagger/cache:main",
+			Insecure: true,
+		},
+	})
+	require.NoError(t, err)
+
+	// nothing pushed yet
+	calls, err := store.Pull(ctx)
+	require.NoError(t, err)
+	require.Empty(t, calls)
+
+	exp := time.Now().Add(time.Hour).Unix()
+	require.NoError(t, Push(ctx, store, []*db.Call{
+		{CallKey: "a", StorageKey: "a1", Expiration: exp},
+	}))
+	require.NoError(t, Push(ctx, store, []*db.Call{
+		{CallKey: "b", StorageKey: "b1", Expiration: exp},
+	}))
+
+	calls, err = store.Pull(ctx)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []*db.Call{
+		{CallKey: "a", StorageKey: "a1", Expiration: exp},
+		{CallKey: "b", StorageKey: "b1", Expiration: exp},
+	}, calls)
+}
+
+func TestNewRequiresOneBackend(t *testing.T) {
+	_, err := New(t.Context(), &config.ResultCacheConfig{})
+	require.Error(t, err)
+
+	_, err = New(t.Context(), &config.ResultCacheConfig{
+		S3:       &config.S3CacheConfig{Bucket: "cache"},
+		Registry: &config.RegistryCacheConfig{Ref: "localhost/cache"},
+	})
+	require.Error(t, err)
+}
@@ -0,0 +1,88 @@
+package remote
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+
+	"github.com/dagger/dagger/engine/cache/db"
+	"github.com/dagger/dagger/engine/config"
+)
+
+// s3CallsKey is the key calls are stored at, relative to the configured
+// prefix. It sits alongside the manifests/ and blobs/ written by buildkit's s3
+// cache exporter.
+const s3CallsKey = "dagql/calls.json"
+
+type s3Store struct {
+	client *s3.Client
+	bucket string
+	key    string
+}
+
+// NewS3 returns a store that keeps calls in an S3-compatible bucket.
+func NewS3(ctx context.Context, cfg *config.S3CacheConfig) (Store, error) {
+	if cfg.Bucket == "" {
+		return nil, errors.New("bucket must be set for the s3 result cache")
+	}
+	var opts []func(*awsconfig.LoadOptions) error
+	if cfg.Region != "" {
+		opts = append(opts, awsconfig.WithRegion(cfg.Region))
+	}
+	awsCfg, err := awsconfig.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("load AWS SDK config: %w", err)
+	}
+	client := s3.NewFromConfig(awsCfg, func(options *s3.Options) {
+		if cfg.AccessKeyID != "" && cfg.SecretAccessKey != "" {
+			options.Credentials = credentials.NewStaticCredentialsProvider(cfg.AccessKeyID, cfg.SecretAccessKey, "")
+		}
+		if cfg.EndpointURL != "" {
+			options.UsePathStyle = cfg.UsePathStyle
+			options.BaseEndpoint = aws.String(cfg.EndpointURL)
+		}
+	})
+	return &s3Store{
+		client: client,
+		bucket: cfg.Bucket,
+		key:    cfg.Prefix + s3CallsKey,
+	}, nil
+}
+
+func (s *s3Store) Pull(ctx context.Context) ([]*db.Call, error) {
+	out, err := s.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: &s.bucket,
+		Key:    &s.key,
+	})
+	if err != nil {
+		var nsk *s3types.NoSuchKey
+		var nf *s3types.NotFound
+		if errors.As(err, &nsk) || errors.As(err, &nf) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer out.Body.Close()
+	return decodeCalls(out.Body)
+}
+
+func (s *s3Store) Push(ctx context.Context, calls []*db.Call) error {
+	data, err := encodeCalls(calls)
+	if err != nil {
+		return err
+	}
+	_, err = s.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:      &s.bucket,
+		Key:         &s.key,
+		Body:        bytes.NewReader(data),
+		ContentType: aws.String("application/json"),
+	})
+	return err
+}
@@ -96,6 +96,7 @@ type Params struct {
 
 	Interactive        bool
 	InteractiveCommand []string
+	BreakOn            []string
 
 	WithTerminal terminal.WithTerminalFunc
 
@@ -112,6 +113,10 @@ type Params struct {
 	Function string
 	ExecCmd  []string
 
+	// The command being run by the client, e.g. "dagger call build". Only used
+	// to describe the session to other clients, e.g. `dagger watch`.
+	Command string
+
 	EagerRuntime bool
 
 	CloudAuth           *auth.Cloud
@@ -464,18 +469,51 @@ func (c *Client) subscribeTelemetry(ctx context.Context) (rerr error) {
 
 	c.telemetry = new(errgroup.Group)
 	httpClient := c.newTelemetryHTTPClient()
+
+	// NB: we never actually want to interrupt this, since it's relied upon for
+	// seeing what's going on, even during shutdown
+	ctx = context.WithoutCancel(ctx)
+
+	return c.consumeTelemetry(ctx, httpClient, c.ID, c.telemetry)
+}
+
+// WatchSession streams telemetry emitted for another client connected to the
+// same engine into this client's exporters, until ctx is canceled or the
+// other client shuts down.
+//
+// This is used by `dagger watch` to follow sessions started elsewhere.
+func (c *Client) WatchSession(ctx context.Context, sessionID, clientID string) (rerr error) {
+	ctx, span := Tracer(ctx).Start(ctx, "watching session "+sessionID,
+		telemetry.Encapsulated())
+	defer telemetry.EndWithCause(span, &rerr)
+
+	md := c.clientMetadata()
+	md.SessionID = sessionID
+	md.ClientID = clientID
+
+	httpClient := c.newTelemetryHTTPClient()
+	httpClient.headers = md.AppendToHTTPHeaders(http.Header{})
+
+	eg := new(errgroup.Group)
+	if err := c.consumeTelemetry(ctx, httpClient, clientID, eg); err != nil {
+		return err
+	}
+	return eg.Wait()
+}
+
+func (c *Client) consumeTelemetry(ctx context.Context, httpClient *httpClient, clientID string, eg *errgroup.Group) error {
 	if c.EngineTrace != nil {
-		if err := c.exportTraces(ctx, httpClient); err != nil {
+		if err := c.exportTraces(ctx, httpClient, clientID, eg); err != nil {
 			return fmt.Errorf("export traces: %w", err)
 		}
 	}
 	if c.EngineLogs != nil {
-		if err := c.exportLogs(ctx, httpClient); err != nil {
+		if err := c.exportLogs(ctx, httpClient, clientID, eg); err != nil {
 			return fmt.Errorf("export logs: %w", err)
 		}
 	}
 	if c.EngineMetrics != nil {
-		if err := c.exportMetrics(ctx, httpClient); err != nil {
+		if err := c.exportMetrics(ctx, httpClient, clientID, eg); err != nil {
 			return fmt.Errorf("export metrics: %w", err)
 		}
 	}
@@ -877,17 +915,13 @@ func (c *otlpConsumer) Consume(ctx context.Context, cb func([]byte) error) (rerr
 	return nil
 }
 
-func (c *Client) exportTraces(ctx context.Context, httpClient *httpClient) error {
-	// NB: we never actually want to interrupt this, since it's relied upon for
-	// seeing what's going on, even during shutdown
-	ctx = context.WithoutCancel(ctx)
-
+func (c *Client) exportTraces(ctx context.Context, httpClient *httpClient, clientID string, eg *errgroup.Group) error {
 	exp := &otlpConsumer{
 		path:       "/v1/traces",
 		traceID:    trace.SpanContextFromContext(ctx).TraceID(),
-		clientID:   c.ID,
+		clientID:   clientID,
 		httpClient: httpClient,
-		eg:         c.telemetry,
+		eg:         eg,
 	}
 
 	return exp.Consume(ctx, func(data []byte) error {
@@ -912,17 +946,13 @@ func (c *Client) exportTraces(ctx context.Context, httpClient *httpClient) error
 	})
 }
 
-func (c *Client) exportLogs(ctx context.Context, httpClient *httpClient) error {
-	// NB: we never actually want to interrupt this, since it's relied upon for
-	// seeing what's going on, even during shutdown
-	ctx = context.WithoutCancel(ctx)
-
+func (c *Client) exportLogs(ctx context.Context, httpClient *httpClient, clientID string, eg *errgroup.Group) error {
 	exp := &otlpConsumer{
 		path:       "/v1/logs",
 		traceID:    trace.SpanContextFromContext(ctx).TraceID(),
-		clientID:   c.ID,
+		clientID:   clientID,
 		httpClient: httpClient,
-		eg:         c.telemetry,
+		eg:         eg,
 	}
 
 	return exp.Consume(ctx, func(data []byte) error {
@@ -937,17 +967,13 @@ func (c *Client) exportLogs(ctx context.Context, httpClient *httpClient) error {
 	})
 }
 
-func (c *Client) exportMetrics(ctx context.Context, httpClient *httpClient) error {
-	// NB: we never actually want to interrupt this, since it's relied upon for
-	// seeing what's going on, even during shutdown
-	ctx = context.WithoutCancel(ctx)
-
+func (c *Client) exportMetrics(ctx context.Context, httpClient *httpClient, clientID string, eg *errgroup.Group) error {
 	exp := &otlpConsumer{
 		path:       "/v1/metrics",
 		traceID:    trace.SpanContextFromContext(ctx).TraceID(),
-		clientID:   c.ID,
+		clientID:   clientID,
 		httpClient: httpClient,
-		eg:         c.telemetry,
+		eg:         eg,
 	}
 
 	return exp.Consume(ctx, func(data []byte) error {
@@ -1356,6 +1382,7 @@ func (c *Client) clientMetadata() engine.ClientMetadata {
 	return engine.ClientMetadata{
 		ClientID:                  c.ID,
 		ClientVersion:             clientVersion,
+		ClientCommand:             c.Command,
 		SessionID:                 c.SessionID,
 		ClientSecretToken:         c.SecretToken,
 		ClientHostname:            c.hostname,
@@ -1367,6 +1394,7 @@ func (c *Client) clientMetadata() engine.ClientMetadata {
 		DoNotTrack:                analytics.DoNotTrack(),
 		Interactive:               c.Interactive,
 		InteractiveCommand:        c.InteractiveCommand,
+		BreakOn:                   c.BreakOn,
 		SSHAuthSocketPath:         sshAuthSock,
 		AllowedLLMModules:         c.AllowedLLMModules,
 		EagerRuntime:              c.EagerRuntime,
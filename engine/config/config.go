@@ -33,6 +33,13 @@ type Config struct {
 	// Registries configures custom registry mirrors, root CAs, and
 	// insecure/HTTP access.
 	Registries map[string]RegistryConfig `json:"registries,omitempty"`
+
+	// ResultCache configures a remote cache for function call results and
+	// their snapshots, so that they outlive the engine (e.g. in ephemeral CI).
+	ResultCache *ResultCacheConfig `json:"resultCache,omitempty"`
+
+	// Network configures the engine's container network.
+	Network *NetworkConfig `json:"network,omitempty"`
 }
 
 type LogLevel string
@@ -89,6 +96,65 @@ type RegistryConfig struct {
 	RootCAs   []string `json:"ca"`
 }
 
+type NetworkConfig struct {
+	// CIDR is the IPv4 address range to use for networked containers. It is
+	// overridden by the --network-cidr flag.
+	CIDR string `json:"cidr,omitempty"`
+
+	// IPv6CIDR is an IPv6 address range to use for networked containers, in
+	// addition to the IPv4 one, e.g. "fd87::/64". Setting it enables
+	// dual-stack networking: services get both an IPv4 and an IPv6 address,
+	// and their hostnames resolve to both. It is overridden by the
+	// --network-ipv6-cidr flag.
+	IPv6CIDR string `json:"ipv6CIDR,omitempty"`
+}
+
+type ResultCacheConfig struct {
+	// S3 stores results in an S3-compatible bucket.
+	S3 *S3CacheConfig `json:"s3,omitempty"`
+
+	// Registry stores results in an OCI registry.
+	Registry *RegistryCacheConfig `json:"registry,omitempty"`
+
+	// ReadOnly only pulls results from the remote cache, and never pushes
+	// results to it.
+	ReadOnly bool `json:"readOnly,omitempty"`
+}
+
+type S3CacheConfig struct {
+	// Bucket is the name of the bucket to store results in.
+	Bucket string `json:"bucket"`
+
+	// Region is the region of the bucket.
+	Region string `json:"region,omitempty"`
+
+	// Prefix is prepended to the key of every object stored in the bucket.
+	Prefix string `json:"prefix,omitempty"`
+
+	// EndpointURL points to a custom S3-compatible endpoint, e.g. MinIO.
+	EndpointURL string `json:"endpointURL,omitempty"`
+
+	// UsePathStyle addresses the bucket in the path of the URL rather than
+	// the hostname, as required by most S3-compatible services.
+	UsePathStyle bool `json:"usePathStyle,omitempty"`
+
+	// AccessKeyID is the access key of static credentials for the bucket. If
+	// unset, credentials are loaded from the environment.
+	AccessKeyID string `json:"accessKeyID,omitempty"`
+
+	// SecretAccessKey is the secret key of static credentials for the bucket.
+	SecretAccessKey string `json:"secretAccessKey,omitempty"`
+}
+
+type RegistryCacheConfig struct {
+	// Ref is the image reference to store results at, e.g.
+	// "registry.example.com/dagger/cache:main".
+	Ref string `json:"ref"`
+
+	// Insecure allows connecting to the registry over plain HTTP.
+	Insecure bool `json:"insecure,omitempty"`
+}
+
 type GCConfig struct {
 	// Enabled controls whether the garbage collector is enabled - it is
 	// switched on by default (and generally shouldn't be turned off, except
@@ -111,9 +177,21 @@ type GCPolicy struct {
 
 	// Filters are a list of containerd filters to match specific cache
 	// records. The available filters are: "id", "parents", "description",
-	// "inuse", "mutable", "immutable", "type", "shared", and "private".
+	// "inuse", "mutable", "immutable", "type", "shared", "private", "pinned",
+	// and "labels.<key>".
 	Filters []string `json:"filters,omitempty"`
 
+	// RecordTypes restricts this policy to the given kinds of cache records.
+	RecordTypes []GCRecordType `json:"recordTypes,omitempty" jsonschema:"enum=exec,enum=cacheMount,enum=imagePull,enum=localUpload,enum=gitCheckout"`
+
+	// CacheVolumes restricts this policy to the contents of the cache volumes
+	// with the given keys.
+	CacheVolumes []string `json:"cacheVolumes,omitempty"`
+
+	// Labels restricts this policy to cache records with all of the given
+	// labels.
+	Labels map[string]string `json:"labels,omitempty"`
+
 	// KeepDuration specifies the minimum amount of time to keep records in
 	// this policy.
 	KeepDuration Duration `json:"keepDuration,omitempty"`
@@ -122,6 +200,22 @@ type GCPolicy struct {
 	GCSpace
 }
 
+// GCRecordType is a kind of cache record that a GC policy can target.
+type GCRecordType string
+
+const (
+	// GCRecordTypeExec is the filesystem changes made by container execs.
+	GCRecordTypeExec GCRecordType = "exec"
+	// GCRecordTypeCacheMount is the contents of cache volumes.
+	GCRecordTypeCacheMount GCRecordType = "cacheMount"
+	// GCRecordTypeImagePull is the layers of pulled container images.
+	GCRecordTypeImagePull GCRecordType = "imagePull"
+	// GCRecordTypeLocalUpload is directories uploaded from clients.
+	GCRecordTypeLocalUpload GCRecordType = "localUpload"
+	// GCRecordTypeGitCheckout is checkouts of git repositories.
+	GCRecordTypeGitCheckout GCRecordType = "gitCheckout"
+)
+
 type GCSpace struct {
 	// ReservedSpace is the minimum amount of disk space this policy is guaranteed to retain.
 	// Any usage below this threshold will not be reclaimed during garbage collection.
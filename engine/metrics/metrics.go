@@ -0,0 +1,169 @@
+// Package metrics defines the Prometheus collectors exported by the engine.
+//
+// Collectors are package-level so that they can be updated from anywhere in
+// the engine without threading a registry around; they only show up on the
+// /metrics endpoint once Register has been called.
+//
+// All labels are kept deliberately low-cardinality: never label by digest,
+// client ID, image ref or anything else that grows with usage.
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const namespace = "dagger"
+
+// ModuleField is used as the field label for calls to module-defined
+// functions, which would otherwise have unbounded cardinality.
+const ModuleField = "module"
+
+var (
+	dagqlCallDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "dagql_call_duration_seconds",
+		Help:      "Latency of dagql field calls, by field and cache result",
+		Buckets:   prometheus.ExponentialBuckets(0.001, 4, 10), // 1ms to ~4.4m
+	}, []string{"field", "cache"})
+
+	dagqlCalls = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "dagql_calls_total",
+		Help:      "Number of dagql field calls, by field and cache result",
+	}, []string{"field", "cache"})
+
+	execDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "exec_duration_seconds",
+		Help:      "Duration of container execs, by exit code",
+		Buckets:   prometheus.ExponentialBuckets(0.01, 4, 10), // 10ms to ~44m
+	}, []string{"exit_code"})
+
+	imagePullBytes = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "image_pull_bytes_total",
+		Help:      "Number of bytes of image layers pulled from registries",
+	})
+
+	imagePullDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "image_pull_duration_seconds",
+		Help:      "Duration of image layer pulls, by status",
+		Buckets:   prometheus.ExponentialBuckets(0.01, 4, 10),
+	}, []string{"status"})
+
+	gitFetchDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "git_fetch_duration_seconds",
+		Help:      "Duration of git fetches from remotes, by status",
+		Buckets:   prometheus.ExponentialBuckets(0.01, 4, 10),
+	}, []string{"status"})
+
+	activeServices = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "active_services",
+		Help:      "Number of services currently running across all sessions",
+	})
+
+	gcRuns = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "gc_runs_total",
+		Help:      "Number of local cache garbage collection runs, by trigger",
+	}, []string{"trigger"})
+
+	gcReclaimedBytes = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "gc_reclaimed_bytes_total",
+		Help:      "Number of bytes reclaimed by local cache garbage collection, by trigger",
+	}, []string{"trigger"})
+)
+
+// Register registers all of the engine's collectors with the given registerer.
+func Register(reg prometheus.Registerer) error {
+	for _, c := range []prometheus.Collector{
+		dagqlCallDuration,
+		dagqlCalls,
+		execDuration,
+		imagePullBytes,
+		imagePullDuration,
+		gitFetchDuration,
+		activeServices,
+		gcRuns,
+		gcReclaimedBytes,
+	} {
+		if err := reg.Register(c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Cache results used to label dagql calls.
+const (
+	CacheHit   = "hit"
+	CacheMiss  = "miss"
+	CacheError = "error"
+)
+
+// ObserveDagqlCall records a dagql call of the given field (e.g.
+// "Container.withExec") that took the given duration.
+func ObserveDagqlCall(field string, cache string, took time.Duration) {
+	dagqlCalls.WithLabelValues(field, cache).Inc()
+	dagqlCallDuration.WithLabelValues(field, cache).Observe(took.Seconds())
+}
+
+// ObserveExec records a container exec that exited with the given code. A
+// negative code means the exit code is unknown, e.g. the exec failed to start.
+func ObserveExec(exitCode int, took time.Duration) {
+	execDuration.WithLabelValues(exitCodeLabel(exitCode)).Observe(took.Seconds())
+}
+
+func exitCodeLabel(code int) string {
+	// exit codes are 0-255 on Linux, anything else is collapsed to keep
+	// cardinality bounded
+	if code < 0 || code > 255 {
+		return "unknown"
+	}
+	return strconv.Itoa(code)
+}
+
+// ObserveImagePull records an image layer pull of the given size.
+func ObserveImagePull(bytes int64, took time.Duration, err error) {
+	if err == nil {
+		imagePullBytes.Add(float64(bytes))
+	}
+	imagePullDuration.WithLabelValues(status(err)).Observe(took.Seconds())
+}
+
+// ObserveGitFetch records a fetch from a git remote.
+func ObserveGitFetch(took time.Duration, err error) {
+	gitFetchDuration.WithLabelValues(status(err)).Observe(took.Seconds())
+}
+
+// SetActiveServices sets the number of currently running services.
+func SetActiveServices(n int) {
+	activeServices.Set(float64(n))
+}
+
+// GC triggers used to label garbage collection runs.
+const (
+	GCTriggerAuto   = "auto"
+	GCTriggerManual = "manual"
+)
+
+// ObserveGC records a garbage collection run that reclaimed the given number
+// of bytes.
+func ObserveGC(trigger string, reclaimed int64) {
+	gcRuns.WithLabelValues(trigger).Inc()
+	gcReclaimedBytes.WithLabelValues(trigger).Add(float64(reclaimed))
+}
+
+func status(err error) string {
+	if err != nil {
+		return "error"
+	}
+	return "ok"
+}
@@ -62,6 +62,11 @@ type ClientMetadata struct {
 	// ClientVersion is the version string of the client that make the request.
 	ClientVersion string `json:"client_version"`
 
+	// ClientCommand is the command the client is running, e.g. "dagger call
+	// build". It's used to describe sessions in `dagger watch`; nothing
+	// functional.
+	ClientCommand string `json:"client_command,omitempty"`
+
 	// (Optional) Pipeline labels for e.g. vcs info like branch, commit, etc.
 	Labels map[string]string `json:"labels"`
 
@@ -71,6 +76,10 @@ type ClientMetadata struct {
 	// InteractiveCommand changes the command that is run in interactive mode.
 	InteractiveCommand []string `json:"interactive_command"`
 
+	// BreakOn are patterns of calls to pause at, e.g. "withExec" or
+	// "Container.withDirectory".
+	BreakOn []string `json:"break_on,omitempty"`
+
 	// Import configuration for Buildkit's remote cache
 	UpstreamCacheImportConfig []*controlapi.CacheOptionsEntry `json:"upstream_cache_import_config"`
 
@@ -3,14 +3,20 @@ package server
 import (
 	"context"
 	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
 	"sync"
 
 	"github.com/dagger/dagger/engine/config"
+	"github.com/dagger/dagger/engine/metrics"
 	bkclient "github.com/dagger/dagger/internal/buildkit/client"
 	bkconfig "github.com/dagger/dagger/internal/buildkit/cmd/buildkitd/config"
 	"github.com/dagger/dagger/internal/buildkit/util/bklog"
 	"github.com/dagger/dagger/internal/buildkit/util/disk"
 	"github.com/dagger/dagger/internal/buildkit/util/imageutil"
+	"github.com/sirupsen/logrus"
 	"golang.org/x/sync/errgroup"
 
 	"github.com/dagger/dagger/core"
@@ -33,7 +39,9 @@ func (srv *Server) EngineLocalCacheEntries(ctx context.Context) (*core.EngineCac
 			Description:         r.Description,
 			DiskSpaceBytes:      int(r.Size),
 			ActivelyUsed:        r.InUse,
+			Pinned:              r.Pinned,
 			CreatedTimeUnixNano: int(r.CreatedAt.UnixNano()),
+			RecordID:            r.ID,
 		}
 		if r.LastUsedAt != nil {
 			cacheEnt.MostRecentUseTimeUnixNano = int(r.LastUsedAt.UnixNano())
@@ -81,6 +89,12 @@ func (srv *Server) PruneEngineLocalCacheEntries(ctx context.Context, useDefaultP
 	close(ch)
 	wg.Wait()
 
+	var reclaimed int64
+	for _, r := range pruned {
+		reclaimed += r.Size
+	}
+	metrics.ObserveGC(metrics.GCTriggerManual, reclaimed)
+
 	if len(pruned) == 0 {
 		return &core.EngineCacheEntrySet{}, nil
 	}
@@ -101,6 +115,7 @@ func (srv *Server) PruneEngineLocalCacheEntries(ctx context.Context, useDefaultP
 			DiskSpaceBytes:      int(r.Size),
 			CreatedTimeUnixNano: int(r.CreatedAt.UnixNano()),
 			ActivelyUsed:        r.InUse,
+			RecordID:            r.ID,
 		}
 		if r.LastUsedAt != nil {
 			ent.MostRecentUseTimeUnixNano = int(r.LastUsedAt.UnixNano())
@@ -140,6 +155,7 @@ func (srv *Server) gc() {
 	if err != nil {
 		bklog.G(ctx).Errorf("gc error: %+v", err)
 	}
+	metrics.ObserveGC(metrics.GCTriggerAuto, size)
 	if size > 0 {
 		bklog.G(ctx).Debugf("gc cleaned up %d bytes", size)
 		go srv.throttledReleaseUnreferenced()
@@ -166,8 +182,12 @@ func getGCPolicy(cfg config.Config, bkcfg bkconfig.GCConfig, root string) []bkcl
 
 	out := make([]bkclient.PruneInfo, 0, len(bkcfg.GCPolicy))
 	for _, policy := range policies {
+		filters, ok := gcPolicyFilters(policy)
+		if !ok {
+			continue
+		}
 		info := bkclient.PruneInfo{
-			Filter:        policy.Filters,
+			Filter:        filters,
 			All:           policy.All,
 			KeepDuration:  policy.KeepDuration.Duration,
 			ReservedSpace: policy.ReservedSpace.AsBytes(dstat),
@@ -185,6 +205,74 @@ func getGCPolicy(cfg config.Config, bkcfg bkconfig.GCConfig, root string) []bkcl
 	return out
 }
 
+// gcRecordTypeFilters are the containerd filters matching each kind of cache
+// record. A record is of a kind if it matches any of its filters.
+var gcRecordTypeFilters = map[config.GCRecordType][]string{
+	config.GCRecordTypeExec:        {`type==regular,description~="^mount .* from exec "`},
+	config.GCRecordTypeCacheMount:  {`type==exec.cachemount`},
+	config.GCRecordTypeImagePull:   {`description~="^pulled from "`},
+	config.GCRecordTypeLocalUpload: {`type==source.local`},
+	config.GCRecordTypeGitCheckout: {`type==source.git.checkout`, `description~="^git (checkout|local checkout) "`},
+}
+
+// gcPolicyFilters combines the filters of a policy with its record types,
+// cache volumes and labels into a list of containerd filters, any of which a
+// record must match to be pruned by the policy.
+//
+// It returns false if the policy can't match anything, so should be skipped.
+func gcPolicyFilters(policy config.GCPolicy) ([]string, bool) {
+	filters := policy.Filters
+	if len(policy.RecordTypes) > 0 {
+		var typeFilters []string
+		for _, typ := range policy.RecordTypes {
+			fs, ok := gcRecordTypeFilters[typ]
+			if !ok {
+				logrus.Warnf("ignoring unknown gc record type %q", typ)
+				continue
+			}
+			typeFilters = append(typeFilters, fs...)
+		}
+		if len(typeFilters) == 0 {
+			return nil, false
+		}
+		filters = andFilters(filters, typeFilters)
+	}
+	if len(policy.CacheVolumes) > 0 {
+		volumeFilters := make([]string, 0, len(policy.CacheVolumes))
+		for _, volume := range policy.CacheVolumes {
+			volumeFilters = append(volumeFilters, labelFilter(core.CacheVolumeLabel, volume))
+		}
+		filters = andFilters(filters, volumeFilters)
+	}
+	if len(policy.Labels) > 0 {
+		labelFilters := make([]string, 0, len(policy.Labels))
+		for _, key := range slices.Sorted(maps.Keys(policy.Labels)) {
+			labelFilters = append(labelFilters, labelFilter(key, policy.Labels[key]))
+		}
+		filters = andFilters(filters, []string{strings.Join(labelFilters, ",")})
+	}
+	return filters, true
+}
+
+// andFilters returns filters matching records that match any of as and any
+// of bs.
+func andFilters(as, bs []string) []string {
+	if len(as) == 0 {
+		return bs
+	}
+	out := make([]string, 0, len(as)*len(bs))
+	for _, a := range as {
+		for _, b := range bs {
+			out = append(out, a+","+b)
+		}
+	}
+	return out
+}
+
+func labelFilter(key, value string) string {
+	return "labels." + strconv.Quote(key) + "==" + strconv.Quote(value)
+}
+
 func getDefaultGCPolicy(cfg config.Config, bkcfg bkconfig.GCConfig, root string) *bkclient.PruneInfo {
 	// the last policy is the default one
 	policies := getGCPolicy(cfg, bkcfg, root)
@@ -0,0 +1,102 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	bkgw "github.com/dagger/dagger/internal/buildkit/frontend/gateway/client"
+
+	"github.com/dagger/dagger/engine/cache/remote"
+	"github.com/dagger/dagger/engine/config"
+	"github.com/dagger/dagger/engine/slog"
+)
+
+// resultCachePullTimeout bounds how long engine startup may wait on pulling
+// call metadata from the remote result cache.
+const resultCachePullTimeout = time.Minute
+
+// setupResultCache connects to the remote result cache, if configured, and
+// seeds the dagql cache db with the calls stored there.
+func (srv *Server) setupResultCache(ctx context.Context, cfg *config.ResultCacheConfig) error {
+	if cfg == nil {
+		return nil
+	}
+	store, err := remote.New(ctx, cfg)
+	if err != nil {
+		return fmt.Errorf("result cache: %w", err)
+	}
+	srv.resultCache = store
+	srv.resultCacheCfg = cfg
+
+	ctx, cancel := context.WithTimeout(ctx, resultCachePullTimeout)
+	defer cancel()
+	calls, err := store.Pull(ctx)
+	if err != nil {
+		// the engine is still usable without the remote cache, it'll just be slower
+		slog.Warn("failed to pull calls from remote result cache", "error", err)
+		return nil
+	}
+	if err := srv.baseDagqlCache.ImportCalls(ctx, calls); err != nil {
+		slog.Warn("failed to import calls from remote result cache", "error", err)
+	}
+	return nil
+}
+
+// pushResultCache pushes the calls in the dagql cache db to the remote result
+// cache, if configured. The results themselves are exported separately by the
+// buildkit cache exporter.
+func (srv *Server) pushResultCache(ctx context.Context) error {
+	if srv.resultCache == nil || srv.resultCacheCfg.ReadOnly {
+		return nil
+	}
+	calls, err := srv.baseDagqlCache.ExportCalls(ctx)
+	if err != nil {
+		return fmt.Errorf("export calls: %w", err)
+	}
+	return remote.Push(ctx, srv.resultCache, calls)
+}
+
+// resultCacheOptions returns the buildkit cache import and export options
+// that push and pull the snapshots of results to the remote result cache.
+func resultCacheOptions(cfg *config.ResultCacheConfig) (imp *bkgw.CacheOptionsEntry, exp *bkgw.CacheOptionsEntry) {
+	var entry bkgw.CacheOptionsEntry
+	switch {
+	case cfg.S3 != nil:
+		entry.Type = "s3"
+		entry.Attrs = map[string]string{
+			"bucket": cfg.S3.Bucket,
+			// keep our manifest separate from any that users export themselves
+			"name": "dagger",
+		}
+		if cfg.S3.Region != "" {
+			entry.Attrs["region"] = cfg.S3.Region
+		}
+		if cfg.S3.Prefix != "" {
+			entry.Attrs["prefix"] = cfg.S3.Prefix
+		}
+		if cfg.S3.EndpointURL != "" {
+			entry.Attrs["endpoint_url"] = cfg.S3.EndpointURL
+			entry.Attrs["use_path_style"] = strconv.FormatBool(cfg.S3.UsePathStyle)
+		}
+		if cfg.S3.AccessKeyID != "" {
+			entry.Attrs["access_key_id"] = cfg.S3.AccessKeyID
+			entry.Attrs["secret_access_key"] = cfg.S3.SecretAccessKey
+		}
+	case cfg.Registry != nil:
+		entry.Type = "registry"
+		entry.Attrs = map[string]string{
+			"ref": cfg.Registry.Ref,
+		}
+		if cfg.Registry.Insecure {
+			entry.Attrs["registry.insecure"] = "true"
+		}
+	default:
+		return nil, nil
+	}
+	if cfg.ReadOnly {
+		return &entry, nil
+	}
+	return &entry, &entry
+}
@@ -1,6 +1,7 @@
 package server
 
 import (
+	"cmp"
 	"context"
 	cryptorand "crypto/rand"
 	"errors"
@@ -26,7 +27,9 @@ import (
 	"github.com/containerd/go-runc"
 	"github.com/containerd/platforms"
 	"github.com/dagger/dagger/dagql"
+	"github.com/dagger/dagger/dagql/call"
 	"github.com/dagger/dagger/engine/cache"
+	"github.com/dagger/dagger/engine/cache/remote"
 	"github.com/dagger/dagger/engine/config"
 	"github.com/dagger/dagger/engine/filesync"
 	controlapi "github.com/dagger/dagger/internal/buildkit/api/services/control"
@@ -76,6 +79,7 @@ import (
 	"golang.org/x/sys/unix"
 	"google.golang.org/grpc"
 
+	"github.com/dagger/dagger/core"
 	"github.com/dagger/dagger/engine"
 	"github.com/dagger/dagger/engine/buildkit"
 	daggercache "github.com/dagger/dagger/engine/cache/cachemanager"
@@ -183,6 +187,10 @@ type Server struct {
 	//
 	baseDagqlCache cache.Cache[string, dagql.AnyResult]
 
+	// remote result cache, if configured
+	resultCache    remote.Store
+	resultCacheCfg *config.ResultCacheConfig
+
 	//
 	// session+client state
 	//
@@ -190,9 +198,18 @@ type Server struct {
 	daggerSessionsMu sync.RWMutex
 	clientDBs        *clientdb.DBs
 
+	// persistent services, shared across sessions
+	namedServices *core.NamedServices
+
 	locker *locker.Locker
 
 	secretSalt []byte
+
+	// calls that missed the cache, across all sessions
+	callHistory *call.History
+
+	// execs skipped or retried from the debugger, across all sessions
+	debuggedExecs *core.DebuggedExecs
 }
 
 type NewServerOpts struct {
@@ -226,8 +243,12 @@ func NewServer(ctx context.Context, opts *NewServerOpts) (*Server, error) {
 		},
 
 		daggerSessions: make(map[string]*daggerSession),
+		namedServices:  core.NewNamedServices(),
 
 		locker: locker.New(),
+
+		callHistory:   call.NewHistory(callHistoryShapes, callHistoryPerShape),
+		debuggedExecs: core.NewDebuggedExecs(),
 	}
 
 	// start the global namespace worker pool, which is used for running Go funcs
@@ -622,6 +643,10 @@ func NewServer(ctx context.Context, opts *NewServerOpts) (*Server, error) {
 	}
 	go srv.baseDagqlCache.GCLoop(ctx)
 
+	if err := srv.setupResultCache(ctx, cfg.ResultCache); err != nil {
+		return nil, err
+	}
+
 	// garbage collect client DBs
 	go srv.gcClientDBs()
 
@@ -729,6 +754,53 @@ func (srv *Server) Clients() []string {
 	return slices.Collect(maps.Keys(clients))
 }
 
+func (srv *Server) Sessions() []*core.EngineSession {
+	runningExecs := srv.worker.RunningExecs()
+
+	srv.daggerSessionsMu.RLock()
+	defer srv.daggerSessionsMu.RUnlock()
+
+	sessions := make([]*core.EngineSession, 0, len(srv.daggerSessions))
+	for _, sess := range srv.daggerSessions {
+		sess.stateMu.RLock()
+		initialized := sess.state == sessionStateInitialized
+		sess.stateMu.RUnlock()
+		if !initialized {
+			continue
+		}
+
+		modules := map[string]struct{}{}
+		sess.clientMu.RLock()
+		for _, client := range sess.clients {
+			if client.mod != nil {
+				modules[client.mod.Name()] = struct{}{}
+			}
+		}
+		sess.clientMu.RUnlock()
+
+		hits, misses := sess.dagqlCache.Stats()
+
+		md := sess.mainClientMetadata
+		sessions = append(sessions, &core.EngineSession{
+			ID:              sess.sessionID,
+			ClientID:        sess.mainClientCallerID,
+			ClientHostname:  md.ClientHostname,
+			ClientVersion:   md.ClientVersion,
+			Command:         md.ClientCommand,
+			Modules:         slices.Sorted(maps.Keys(modules)),
+			StartedUnixNano: int(sess.startedAt.UnixNano()),
+			RunningExecs:    runningExecs[sess.sessionID],
+			CacheHits:       hits,
+			CacheMisses:     misses,
+		})
+	}
+
+	slices.SortFunc(sessions, func(a, b *core.EngineSession) int {
+		return cmp.Compare(a.StartedUnixNano, b.StartedUnixNano)
+	})
+	return sessions
+}
+
 // GracefulStop attempts to close all boltdbs and do a final syncfs since all the DBs
 // run with NoSync=true for performance reasons.
 func (srv *Server) GracefulStop(ctx context.Context) error {
@@ -813,6 +885,10 @@ func (srv *Server) Close() error {
 		err = errors.Join(err, srv.removeDaggerSession(context.Background(), s))
 		s.stateMu.Unlock()
 	}
+
+	if stopErr := srv.namedServices.StopAll(context.Background()); stopErr != nil {
+		err = errors.Join(err, fmt.Errorf("stop persistent services: %w", stopErr))
+	}
 	return err
 }
 
@@ -868,6 +944,23 @@ func (srv *Server) ConnectedClients() int {
 	return len(srv.daggerSessions)
 }
 
+// ActiveServices returns the number of services running across all sessions,
+// including persistent services.
+func (srv *Server) ActiveServices() int {
+	srv.daggerSessionsMu.RLock()
+	defer srv.daggerSessionsMu.RUnlock()
+	n := srv.namedServices.Running()
+	for _, sess := range srv.daggerSessions {
+		sess.stateMu.RLock()
+		initialized := sess.state == sessionStateInitialized
+		sess.stateMu.RUnlock()
+		if initialized {
+			n += sess.services.Running()
+		}
+	}
+	return n
+}
+
 func (srv *Server) CorruptDBReset() bool {
 	return srv.corruptDBReset
 }
@@ -876,6 +969,22 @@ func (srv *Server) Locker() *locker.Locker {
 	return srv.locker
 }
 
+// Bounds on the number of calls remembered for explaining cache misses.
+const (
+	callHistoryShapes   = 10000
+	callHistoryPerShape = 4
+)
+
+// The calls that recently missed the cache, across all sessions
+func (srv *Server) CallHistory() *call.History {
+	return srv.callHistory
+}
+
+// The execs skipped or retried from the debugger, across all sessions
+func (srv *Server) DebuggedExecs() *core.DebuggedExecs {
+	return srv.debuggedExecs
+}
+
 func (srv *Server) gcClientDBs() {
 	for range time.NewTicker(time.Minute).C {
 		if err := srv.clientDBs.GC(srv.activeClientIDs()); err != nil {
@@ -63,6 +63,8 @@ import (
 type daggerSession struct {
 	sessionID          string
 	mainClientCallerID string
+	mainClientMetadata *engine.ClientMetadata
+	startedAt          time.Time
 
 	state   daggerSessionState
 	stateMu sync.RWMutex
@@ -100,6 +102,7 @@ type daggerSession struct {
 
 	interactive        bool
 	interactiveCommand []string
+	breakOn            []string
 
 	allowedLLMModules []string
 }
@@ -256,10 +259,13 @@ func (srv *Server) initializeDaggerSession(
 
 	sess.sessionID = clientMetadata.SessionID
 	sess.mainClientCallerID = clientMetadata.ClientID
+	sess.mainClientMetadata = clientMetadata
+	sess.startedAt = time.Now()
 	sess.clients = map[string]*daggerClient{}
 	sess.endpoints = map[string]http.Handler{}
 	sess.shutdownCh = make(chan struct{})
 	sess.services = core.NewServices()
+	sess.services.Named = srv.namedServices
 	sess.authProvider = auth.NewRegistryAuthProvider()
 	sess.refs = map[buildkit.Reference]struct{}{}
 	sess.containers = map[bkgw.Container]struct{}{}
@@ -267,6 +273,7 @@ func (srv *Server) initializeDaggerSession(
 	sess.telemetryPubSub = srv.telemetryPubSub
 	sess.interactive = clientMetadata.Interactive
 	sess.interactiveCommand = clientMetadata.InteractiveCommand
+	sess.breakOn = clientMetadata.BreakOn
 	sess.allowedLLMModules = clientMetadata.AllowedLLMModules
 
 	sess.analytics = analytics.New(analytics.Config{
@@ -303,6 +310,16 @@ func (srv *Server) initializeDaggerSession(
 		})
 	}
 
+	if srv.resultCacheCfg != nil {
+		imp, exp := resultCacheOptions(srv.resultCacheCfg)
+		if imp != nil {
+			sess.cacheImporterCfgs = append(sess.cacheImporterCfgs, *imp)
+		}
+		if exp != nil {
+			sess.cacheExporterCfgs = append(sess.cacheExporterCfgs, *exp)
+		}
+	}
+
 	sess.state = sessionStateInitialized
 	return nil
 }
@@ -583,6 +600,7 @@ func (srv *Server) initializeDaggerClient(
 
 		Interactive:        client.daggerSession.interactive,
 		InteractiveCommand: client.daggerSession.interactiveCommand,
+		BreakOn:            client.daggerSession.breakOn,
 
 		ParentClient: parentBuildkitClient,
 	})
@@ -1239,6 +1257,8 @@ func (srv *Server) serveShutdown(w http.ResponseWriter, r *http.Request, client
 			err := client.bkClient.UpstreamCacheExport(ctx, cacheExporterFuncs)
 			if err != nil {
 				bklog.G(ctx).WithError(err).Errorf("error running cache export for client %s", client.clientID)
+			} else if err := srv.pushResultCache(ctx); err != nil {
+				bklog.G(ctx).WithError(err).Errorf("error pushing calls to remote result cache for client %s", client.clientID)
 			}
 			bklog.G(ctx).Debugf("done running cache export for client %s", client.clientID)
 		}
@@ -30,6 +30,7 @@ import (
 	imagespecidentity "github.com/opencontainers/image-spec/identity"
 	ocispecs "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/pkg/errors"
+	bolt "go.etcd.io/bbolt"
 	"golang.org/x/sync/errgroup"
 )
 
@@ -70,6 +71,11 @@ type Accessor interface {
 type Controller interface {
 	DiskUsage(ctx context.Context, info client.DiskUsageInfo) ([]*client.UsageInfo, error)
 	Prune(ctx context.Context, ch chan client.UsageInfo, info ...client.PruneInfo) error
+	SetPinned(ctx context.Context, id string, pinned bool) error
+	// SetCacheDirPinned records whether the cache mount with the given ID is
+	// pinned, so that records created for it later can be pinned too.
+	SetCacheDirPinned(id string, pinned bool) error
+	CacheDirPinned(id string) (bool, error)
 }
 
 type Manager interface {
@@ -1158,12 +1164,19 @@ func (cm *cacheManager) prune(ctx context.Context, ch chan client.UsageInfo, opt
 				}
 			}
 
+			// pinned records have to be unpinned before they can be pruned
+			if cr.GetPinned() {
+				cr.mu.Unlock()
+				continue
+			}
+
 			c := &client.UsageInfo{
 				ID:          cr.ID(),
 				Mutable:     cr.mutable,
 				RecordType:  recordType,
 				Shared:      shared,
 				Description: cr.GetDescription(),
+				Labels:      cr.GetLabels(),
 			}
 
 			usageCount, lastUsedAt := cr.getLastUsed()
@@ -1360,6 +1373,8 @@ type cacheUsageInfo struct {
 	doubleRef   bool
 	recordType  client.UsageRecordType
 	shared      bool
+	pinned      bool
+	labels      map[string]string
 	parentChain []digest.Digest
 
 	rec *cacheRecord
@@ -1395,6 +1410,8 @@ func (cm *cacheManager) DiskUsage(ctx context.Context, opt client.DiskUsageInfo)
 			description: cr.GetDescription(),
 			doubleRef:   cr.equalImmutable != nil,
 			recordType:  cr.GetRecordType(),
+			pinned:      cr.GetPinned(),
+			labels:      cr.GetLabels(),
 			parentChain: cr.layerDigestChain().digests,
 			rec:         cr,
 		}
@@ -1467,6 +1484,8 @@ func (cm *cacheManager) DiskUsage(ctx context.Context, opt client.DiskUsageInfo)
 			UsageCount:  cr.usageCount,
 			RecordType:  cr.recordType,
 			Shared:      cr.shared,
+			Pinned:      cr.pinned,
+			Labels:      cr.labels,
 		}
 		if filter.Match(adaptUsageInfo(c)) {
 			du = append(du, c)
@@ -1494,6 +1513,46 @@ func (cm *cacheManager) DiskUsage(ctx context.Context, opt client.DiskUsageInfo)
 	return du, nil
 }
 
+// SetPinned pins or unpins the record with the given ID. Pinned records are
+// skipped by Prune.
+func (cm *cacheManager) SetPinned(ctx context.Context, id string, pinned bool) error {
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+	md, ok := cm.getMetadata(id)
+	if !ok || md.getDeleted() {
+		return errors.Wrapf(errNotFound, "%s", id)
+	}
+	return md.SetPinned(pinned)
+}
+
+// pinnedCacheDirsBucket holds the IDs of pinned cache mounts. It's kept apart
+// from the records' buckets since it outlives any of them.
+const pinnedCacheDirsBucket = "_pinnedCacheDirs"
+
+func (cm *cacheManager) SetCacheDirPinned(id string, pinned bool) error {
+	return cm.MetadataStore.DB().Update(func(tx *bolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists([]byte(pinnedCacheDirsBucket))
+		if err != nil {
+			return err
+		}
+		if !pinned {
+			return b.Delete([]byte(id))
+		}
+		return b.Put([]byte(id), []byte{1})
+	})
+}
+
+func (cm *cacheManager) CacheDirPinned(id string) (bool, error) {
+	var pinned bool
+	err := cm.MetadataStore.DB().View(func(tx *bolt.Tx) error {
+		if b := tx.Bucket([]byte(pinnedCacheDirsBucket)); b != nil {
+			pinned = b.Get([]byte(id)) != nil
+		}
+		return nil
+	})
+	return pinned, errors.WithStack(err)
+}
+
 func IsNotFound(err error) bool {
 	return errors.Is(err, errNotFound)
 }
@@ -1638,6 +1697,14 @@ func adaptUsageInfo(info *client.UsageInfo) filters.Adaptor {
 			return "", info.Shared
 		case "private":
 			return "", !info.Shared
+		case "pinned":
+			return "", info.Pinned
+		case "labels":
+			if len(fieldpath) < 2 {
+				return "", len(info.Labels) > 0
+			}
+			value, ok := info.Labels[strings.Join(fieldpath[1:], ".")]
+			return value, ok
 		}
 
 		// TODO: add int/datetime/bytes support for more fields
@@ -38,6 +38,8 @@ const keyImageRefs = "cache.imageRefs"
 const keyDeleted = "cache.deleted"
 const keyBlobSize = "cache.blobsize" // the packed blob size as specified in the oci descriptor
 const keyURLs = "cache.layer.urls"
+const keyPinned = "cache.pinned"
+const keyLabels = "cache.labels"
 
 // Indexes
 const blobchainIndex = "blobchainid:"
@@ -69,6 +71,13 @@ type RefMetadata interface {
 
 	GetEqualMutable() (RefMetadata, bool)
 
+	// pinned records are never pruned
+	GetPinned() bool
+	SetPinned(bool) error
+
+	GetLabels() map[string]string
+	SetLabel(key, value string) error
+
 	// generic getters/setters for external packages
 	GetString(string) string
 	Get(string) *metadata.Value
@@ -180,6 +189,38 @@ func (md *cacheMetadata) queueRecordType(value client.UsageRecordType) error {
 	return md.queueValue(keyRecordType, value, "")
 }
 
+func (md *cacheMetadata) GetPinned() bool {
+	return md.getBool(keyPinned)
+}
+
+func (md *cacheMetadata) SetPinned(b bool) error {
+	return md.setValue(keyPinned, b, "")
+}
+
+func (md *cacheMetadata) GetLabels() map[string]string {
+	v := md.si.Get(keyLabels)
+	if v == nil {
+		return nil
+	}
+	var labels map[string]string
+	if err := v.Unmarshal(&labels); err != nil {
+		return nil
+	}
+	return labels
+}
+
+func (md *cacheMetadata) SetLabel(key, value string) error {
+	labels := md.GetLabels()
+	if cur, ok := labels[key]; ok && cur == value {
+		return nil
+	}
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	labels[key] = value
+	return md.setValue(keyLabels, labels, "")
+}
+
 func (md *cacheMetadata) SetCreatedAt(tm time.Time) error {
 	return md.setTime(keyCreatedAt, tm, "")
 }
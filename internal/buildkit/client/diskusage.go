@@ -22,6 +22,9 @@ type UsageInfo struct {
 	Description string          `json:"description"`
 	RecordType  UsageRecordType `json:"recordType"`
 	Shared      bool            `json:"shared"`
+
+	Pinned bool              `json:"pinned,omitempty"`
+	Labels map[string]string `json:"labels,omitempty"`
 }
 
 func (c *Client) DiskUsage(ctx context.Context, opts ...DiskUsageOption) ([]*UsageInfo, error) {
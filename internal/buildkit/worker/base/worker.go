@@ -437,6 +437,18 @@ func (w *Worker) Prune(ctx context.Context, ch chan client.UsageInfo, opt ...cli
 	return w.CacheMgr.Prune(ctx, ch, opt...)
 }
 
+func (w *Worker) SetPinned(ctx context.Context, id string, pinned bool) error {
+	return w.CacheMgr.SetPinned(ctx, id, pinned)
+}
+
+func (w *Worker) SetCacheDirPinned(id string, pinned bool) error {
+	return w.CacheMgr.SetCacheDirPinned(id, pinned)
+}
+
+func (w *Worker) CacheDirPinned(id string) (bool, error) {
+	return w.CacheMgr.CacheDirPinned(id)
+}
+
 func (w *Worker) Exporter(name string, sm *session.Manager) (exporter.Exporter, error) {
 	switch name {
 	case client.ExporterImage:
@@ -1,16 +1,34 @@
 package network
 
-import "net"
+import (
+	"fmt"
+	"net"
+)
 
+// BridgeFromCIDR returns the address of the bridge for the given subnet,
+// which is the first address in its range. Both IPv4 and IPv6 subnets are
+// supported.
 func BridgeFromCIDR(subnet string) (net.IP, error) {
 	_, ipNet, err := net.ParseCIDR(subnet)
 	if err != nil {
 		return nil, err
 	}
 
-	bridge := make(net.IP, 4)
+	bridge := make(net.IP, len(ipNet.IP))
 	copy(bridge, ipNet.IP)
-	bridge[3] = 1
+	bridge[len(bridge)-1] = 1
 
 	return bridge, nil
 }
+
+// ValidateIPv6CIDR checks that the given subnet is an IPv6 range.
+func ValidateIPv6CIDR(subnet string) error {
+	ip, _, err := net.ParseCIDR(subnet)
+	if err != nil {
+		return err
+	}
+	if ip.To4() != nil {
+		return fmt.Errorf("%s is not an IPv6 range", subnet)
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+package network
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBridgeFromCIDR(t *testing.T) {
+	for subnet, bridge := range map[string]string{
+		"10.87.0.0/16":   "10.87.0.1",
+		"10.89.3.0/24":   "10.89.3.1",
+		"fd87::/64":      "fd87::1",
+		"fd00:89:3::/48": "fd00:89:3::1",
+	} {
+		ip, err := BridgeFromCIDR(subnet)
+		require.NoError(t, err)
+		require.Equal(t, bridge, ip.String(), subnet)
+	}
+
+	_, err := BridgeFromCIDR("10.87.0.0")
+	require.Error(t, err)
+}
+
+func TestValidateIPv6CIDR(t *testing.T) {
+	require.NoError(t, ValidateIPv6CIDR("fd87::/64"))
+	require.ErrorContains(t, ValidateIPv6CIDR("10.87.0.0/16"), "not an IPv6 range")
+	require.Error(t, ValidateIPv6CIDR("fd87::"))
+}
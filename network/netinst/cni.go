@@ -12,8 +12,17 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
-func InstallCNIConfig(name, subnet string) (string, error) {
-	cni, err := cniConfig(name, subnet)
+// InstallCNIConfig writes the CNI configuration for the named network. If
+// ipv6Subnet is set, containers get an address from it too, on top of their
+// IPv4 address.
+func InstallCNIConfig(name, subnet, ipv6Subnet string) (string, error) {
+	if ipv6Subnet != "" {
+		if err := enableIPv6(); err != nil {
+			return "", err
+		}
+	}
+
+	cni, err := cniConfig(name, subnet, ipv6Subnet)
 	if err != nil {
 		return "", err
 	}
@@ -31,7 +40,15 @@ func InstallCNIConfig(name, subnet string) (string, error) {
 	return cniConfigPath, nil
 }
 
-func cniConfig(name, subnet string) ([]byte, error) {
+func cniConfig(name, subnet, ipv6Subnet string) ([]byte, error) {
+	// each range set gives containers one address
+	ranges := []any{
+		[]any{map[string]any{"subnet": subnet}},
+	}
+	if ipv6Subnet != "" {
+		ranges = append(ranges, []any{map[string]any{"subnet": ipv6Subnet}})
+	}
+
 	bridgePlugin := map[string]any{
 		"type":             "bridge",
 		"bridge":           name + "0",
@@ -39,10 +56,8 @@ func cniConfig(name, subnet string) ([]byte, error) {
 		"ipMasq":           true,
 		"hairpinMode":      true,
 		"ipam": map[string]any{
-			"type": "host-local",
-			"ranges": []any{
-				[]any{map[string]any{"subnet": subnet}},
-			},
+			"type":   "host-local",
+			"ranges": ranges,
 		},
 	}
 
@@ -79,6 +94,21 @@ func cniConfig(name, subnet string) ([]byte, error) {
 	})
 }
 
+// enableIPv6 makes sure IPv6 isn't disabled in the engine's network namespace,
+// as container runtimes tend to do when they aren't configured for it.
+func enableIPv6() error {
+	for _, conf := range []string{"all", "default"} {
+		path := filepath.Join("/proc/sys/net/ipv6/conf", conf, "disable_ipv6")
+		if err := os.WriteFile(path, []byte("0"), 0644); err != nil {
+			if os.IsNotExist(err) {
+				return fmt.Errorf("IPv6 is not supported by the kernel")
+			}
+			return fmt.Errorf("enable IPv6: %w", err)
+		}
+	}
+	return nil
+}
+
 func findIfaceWithIP(ip string) (net.Interface, error) {
 	networkIfaces, err := net.Interfaces()
 	if err != nil {
@@ -10,7 +10,7 @@ import (
 
 const resolv = "/etc/resolv.conf"
 
-func InstallResolvconf(name, containerDNS string) error {
+func InstallResolvconf(name string, containerDNS ...string) error {
 	containerDNSResolv := containerResolvPath(name)
 	if err := createIfNeeded(containerDNSResolv); err != nil {
 		return err
@@ -41,7 +41,7 @@ func InstallResolvconf(name, containerDNS string) error {
 	return nil
 }
 
-func replaceNameservers(containerDNS, containerDNSResolve string) error {
+func replaceNameservers(containerDNS []string, containerDNSResolve string) error {
 	src, err := os.Open(resolv)
 	if err != nil {
 		return nil
@@ -55,7 +55,9 @@ func replaceNameservers(containerDNS, containerDNSResolve string) error {
 	defer dst.Close()
 
 	fmt.Fprintln(dst, "# container ns resolver")
-	fmt.Fprintln(dst, "nameserver", containerDNS)
+	for _, ns := range containerDNS {
+		fmt.Fprintln(dst, "nameserver", ns)
+	}
 
 	srcScan := bufio.NewScanner(src)
 
@@ -113,6 +113,48 @@ defmodule Dagger.Binding do
     }
   end
 
+  @doc """
+  Retrieve the binding value, as type ContainerComparison
+  """
+  @spec as_container_comparison(t()) :: Dagger.ContainerComparison.t()
+  def as_container_comparison(%__MODULE__{} = binding) do
+    query_builder =
+      binding.query_builder |> QB.select("asContainerComparison")
+
+    %Dagger.ContainerComparison{
+      query_builder: query_builder,
+      client: binding.client
+    }
+  end
+
+  @doc """
+  Retrieve the binding value, as type ContainerConfigChange
+  """
+  @spec as_container_config_change(t()) :: Dagger.ContainerConfigChange.t()
+  def as_container_config_change(%__MODULE__{} = binding) do
+    query_builder =
+      binding.query_builder |> QB.select("asContainerConfigChange")
+
+    %Dagger.ContainerConfigChange{
+      query_builder: query_builder,
+      client: binding.client
+    }
+  end
+
+  @doc """
+  Retrieve the binding value, as type ContainerLayerComparison
+  """
+  @spec as_container_layer_comparison(t()) :: Dagger.ContainerLayerComparison.t()
+  def as_container_layer_comparison(%__MODULE__{} = binding) do
+    query_builder =
+      binding.query_builder |> QB.select("asContainerLayerComparison")
+
+    %Dagger.ContainerLayerComparison{
+      query_builder: query_builder,
+      client: binding.client
+    }
+  end
+
   @doc """
   Retrieve the binding value, as type Directory
   """
@@ -127,6 +169,34 @@ defmodule Dagger.Binding do
     }
   end
 
+  @doc """
+  Retrieve the binding value, as type EngineService
+  """
+  @spec as_engine_service(t()) :: Dagger.EngineService.t()
+  def as_engine_service(%__MODULE__{} = binding) do
+    query_builder =
+      binding.query_builder |> QB.select("asEngineService")
+
+    %Dagger.EngineService{
+      query_builder: query_builder,
+      client: binding.client
+    }
+  end
+
+  @doc """
+  Retrieve the binding value, as type EngineSession
+  """
+  @spec as_engine_session(t()) :: Dagger.EngineSession.t()
+  def as_engine_session(%__MODULE__{} = binding) do
+    query_builder =
+      binding.query_builder |> QB.select("asEngineSession")
+
+    %Dagger.EngineSession{
+      query_builder: query_builder,
+      client: binding.client
+    }
+  end
+
   @doc """
   Retrieve the binding value, as type Env
   """
@@ -169,6 +239,20 @@ defmodule Dagger.Binding do
     }
   end
 
+  @doc """
+  Retrieve the binding value, as type FunctionArgConstraints
+  """
+  @spec as_function_arg_constraints(t()) :: Dagger.FunctionArgConstraints.t()
+  def as_function_arg_constraints(%__MODULE__{} = binding) do
+    query_builder =
+      binding.query_builder |> QB.select("asFunctionArgConstraints")
+
+    %Dagger.FunctionArgConstraints{
+      query_builder: query_builder,
+      client: binding.client
+    }
+  end
+
   @doc """
   Retrieve the binding value, as type GitRef
   """
@@ -225,6 +309,20 @@ defmodule Dagger.Binding do
     }
   end
 
+  @doc """
+  Retrieve the binding value, as type ModuleCapabilities
+  """
+  @spec as_module_capabilities(t()) :: Dagger.ModuleCapabilities.t() | nil
+  def as_module_capabilities(%__MODULE__{} = binding) do
+    query_builder =
+      binding.query_builder |> QB.select("asModuleCapabilities")
+
+    %Dagger.ModuleCapabilities{
+      query_builder: query_builder,
+      client: binding.client
+    }
+  end
+
   @doc """
   Retrieve the binding value, as type ModuleConfigClient
   """
@@ -334,6 +432,20 @@ defmodule Dagger.Binding do
     Client.execute(binding.client, query_builder)
   end
 
+  @doc """
+  Retrieve the binding value, as type UnionTypeDef
+  """
+  @spec as_union_type_def(t()) :: Dagger.UnionTypeDef.t()
+  def as_union_type_def(%__MODULE__{} = binding) do
+    query_builder =
+      binding.query_builder |> QB.select("asUnionTypeDef")
+
+    %Dagger.UnionTypeDef{
+      query_builder: query_builder,
+      client: binding.client
+    }
+  end
+
   @doc """
   Returns the digest of the binding value
   """
@@ -25,6 +25,36 @@ defmodule Dagger.CacheVolume do
 
     Client.execute(cache_volume.client, query_builder)
   end
+
+  @doc """
+  Pin the contents of this cache volume, so that they're never pruned by garbage collection.
+
+  Contents written to the volume later are pinned too, until it's unpinned. Only the main client can pin a cache volume.
+  """
+  @spec pin(t()) :: :ok | {:error, term()}
+  def pin(%__MODULE__{} = cache_volume) do
+    query_builder =
+      cache_volume.query_builder |> QB.select("pin")
+
+    case Client.execute(cache_volume.client, query_builder) do
+      {:ok, _} -> :ok
+      error -> error
+    end
+  end
+
+  @doc """
+  Unpin the contents of this cache volume, so that they can be pruned by garbage collection again.
+  """
+  @spec unpin(t()) :: :ok | {:error, term()}
+  def unpin(%__MODULE__{} = cache_volume) do
+    query_builder =
+      cache_volume.query_builder |> QB.select("unpin")
+
+    case Client.execute(cache_volume.client, query_builder) do
+      {:ok, _} -> :ok
+      error -> error
+    end
+  end
 end
 
 defimpl Jason.Encoder, for: Dagger.CacheVolume do
@@ -156,6 +156,24 @@ defmodule Dagger.Changeset do
        }}
     end
   end
+
+  @doc """
+  Add changes to this changeset, if they don't conflict with it.
+
+  Both changesets must compare against the same directory. The changes conflict if a file is changed by both, or changed by one and removed by the other.
+  """
+  @spec with_changeset(t(), Dagger.Changeset.t()) :: Dagger.Changeset.t()
+  def with_changeset(%__MODULE__{} = changeset, changes) do
+    query_builder =
+      changeset.query_builder
+      |> QB.select("withChangeset")
+      |> QB.put_arg("changes", Dagger.ID.id!(changes))
+
+    %Dagger.Changeset{
+      query_builder: query_builder,
+      client: changeset.client
+    }
+  end
 end
 
 defimpl Jason.Encoder, for: Dagger.Changeset do
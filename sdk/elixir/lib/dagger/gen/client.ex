@@ -492,6 +492,36 @@ defmodule Dagger.Client do
     }
   end
 
+  @doc """
+  Load a ContainerComparison from its ID.
+  """
+  @spec load_container_comparison_from_id(t(), Dagger.ContainerComparisonID.t()) ::
+          Dagger.ContainerComparison.t()
+  def load_container_comparison_from_id(%__MODULE__{} = client, id) do
+    query_builder =
+      client.query_builder |> QB.select("loadContainerComparisonFromID") |> QB.put_arg("id", id)
+
+    %Dagger.ContainerComparison{
+      query_builder: query_builder,
+      client: client.client
+    }
+  end
+
+  @doc """
+  Load a ContainerConfigChange from its ID.
+  """
+  @spec load_container_config_change_from_id(t(), Dagger.ContainerConfigChangeID.t()) ::
+          Dagger.ContainerConfigChange.t()
+  def load_container_config_change_from_id(%__MODULE__{} = client, id) do
+    query_builder =
+      client.query_builder |> QB.select("loadContainerConfigChangeFromID") |> QB.put_arg("id", id)
+
+    %Dagger.ContainerConfigChange{
+      query_builder: query_builder,
+      client: client.client
+    }
+  end
+
   @doc """
   Load a Container from its ID.
   """
@@ -506,6 +536,23 @@ defmodule Dagger.Client do
     }
   end
 
+  @doc """
+  Load a ContainerLayerComparison from its ID.
+  """
+  @spec load_container_layer_comparison_from_id(t(), Dagger.ContainerLayerComparisonID.t()) ::
+          Dagger.ContainerLayerComparison.t()
+  def load_container_layer_comparison_from_id(%__MODULE__{} = client, id) do
+    query_builder =
+      client.query_builder
+      |> QB.select("loadContainerLayerComparisonFromID")
+      |> QB.put_arg("id", id)
+
+    %Dagger.ContainerLayerComparison{
+      query_builder: query_builder,
+      client: client.client
+    }
+  end
+
   @doc """
   Load a CurrentModule from its ID.
   """
@@ -592,6 +639,34 @@ defmodule Dagger.Client do
     }
   end
 
+  @doc """
+  Load a EngineService from its ID.
+  """
+  @spec load_engine_service_from_id(t(), Dagger.EngineServiceID.t()) :: Dagger.EngineService.t()
+  def load_engine_service_from_id(%__MODULE__{} = client, id) do
+    query_builder =
+      client.query_builder |> QB.select("loadEngineServiceFromID") |> QB.put_arg("id", id)
+
+    %Dagger.EngineService{
+      query_builder: query_builder,
+      client: client.client
+    }
+  end
+
+  @doc """
+  Load a EngineSession from its ID.
+  """
+  @spec load_engine_session_from_id(t(), Dagger.EngineSessionID.t()) :: Dagger.EngineSession.t()
+  def load_engine_session_from_id(%__MODULE__{} = client, id) do
+    query_builder =
+      client.query_builder |> QB.select("loadEngineSessionFromID") |> QB.put_arg("id", id)
+
+    %Dagger.EngineSession{
+      query_builder: query_builder,
+      client: client.client
+    }
+  end
+
   @doc """
   Load a EnumTypeDef from its ID.
   """
@@ -719,6 +794,23 @@ defmodule Dagger.Client do
     }
   end
 
+  @doc """
+  Load a FunctionArgConstraints from its ID.
+  """
+  @spec load_function_arg_constraints_from_id(t(), Dagger.FunctionArgConstraintsID.t()) ::
+          Dagger.FunctionArgConstraints.t()
+  def load_function_arg_constraints_from_id(%__MODULE__{} = client, id) do
+    query_builder =
+      client.query_builder
+      |> QB.select("loadFunctionArgConstraintsFromID")
+      |> QB.put_arg("id", id)
+
+    %Dagger.FunctionArgConstraints{
+      query_builder: query_builder,
+      client: client.client
+    }
+  end
+
   @doc """
   Load a FunctionArg from its ID.
   """
@@ -931,6 +1023,21 @@ defmodule Dagger.Client do
     }
   end
 
+  @doc """
+  Load a ModuleCapabilities from its ID.
+  """
+  @spec load_module_capabilities_from_id(t(), Dagger.ModuleCapabilitiesID.t()) ::
+          Dagger.ModuleCapabilities.t() | nil
+  def load_module_capabilities_from_id(%__MODULE__{} = client, id) do
+    query_builder =
+      client.query_builder |> QB.select("loadModuleCapabilitiesFromID") |> QB.put_arg("id", id)
+
+    %Dagger.ModuleCapabilities{
+      query_builder: query_builder,
+      client: client.client
+    }
+  end
+
   @doc """
   Load a ModuleConfigClient from its ID.
   """
@@ -1143,6 +1250,20 @@ defmodule Dagger.Client do
     }
   end
 
+  @doc """
+  Load a UnionTypeDef from its ID.
+  """
+  @spec load_union_type_def_from_id(t(), Dagger.UnionTypeDefID.t()) :: Dagger.UnionTypeDef.t()
+  def load_union_type_def_from_id(%__MODULE__{} = client, id) do
+    query_builder =
+      client.query_builder |> QB.select("loadUnionTypeDefFromID") |> QB.put_arg("id", id)
+
+    %Dagger.UnionTypeDef{
+      query_builder: query_builder,
+      client: client.client
+    }
+  end
+
   @doc """
   Create a new module.
   """
@@ -1182,6 +1303,22 @@ defmodule Dagger.Client do
     }
   end
 
+  @doc """
+  Attach to a persistent service running in the engine under the given name.
+
+  The service may have been started by another session, with `start(persistent: true)`. Only the main client can attach to it.
+  """
+  @spec running_service(t(), String.t()) :: Dagger.Service.t()
+  def running_service(%__MODULE__{} = client, name) do
+    query_builder =
+      client.query_builder |> QB.select("runningService") |> QB.put_arg("name", name)
+
+    %Dagger.Service{
+      query_builder: query_builder,
+      client: client.client
+    }
+  end
+
   @doc """
   Creates a new secret.
   """
@@ -26,7 +26,9 @@ defmodule Dagger.Container do
           {:experimental_privileged_nesting, boolean() | nil},
           {:insecure_root_capabilities, boolean() | nil},
           {:expand, boolean() | nil},
-          {:no_init, boolean() | nil}
+          {:no_init, boolean() | nil},
+          {:network_mode, Dagger.NetworkMode.t() | nil},
+          {:egress_allowlist, [String.t()]}
         ]) :: Dagger.Service.t()
   def as_service(%__MODULE__{} = container, optional_args \\ []) do
     query_builder =
@@ -41,6 +43,8 @@ defmodule Dagger.Container do
       |> QB.maybe_put_arg("insecureRootCapabilities", optional_args[:insecure_root_capabilities])
       |> QB.maybe_put_arg("expand", optional_args[:expand])
       |> QB.maybe_put_arg("noInit", optional_args[:no_init])
+      |> QB.maybe_put_arg("networkMode", optional_args[:network_mode])
+      |> QB.maybe_put_arg("egressAllowlist", optional_args[:egress_allowlist])
 
     %Dagger.Service{
       query_builder: query_builder,
@@ -89,6 +93,22 @@ defmodule Dagger.Container do
     Client.execute(container.client, query_builder)
   end
 
+  @doc """
+  Compare this container's image configuration, layers and root filesystem with another container, typically a newer version of it.
+
+  Layers that haven't been exported yet are compressed to compute their digest and size.
+  """
+  @spec compare(t(), Dagger.Container.t()) :: Dagger.ContainerComparison.t()
+  def compare(%__MODULE__{} = container, other) do
+    query_builder =
+      container.query_builder |> QB.select("compare") |> QB.put_arg("other", Dagger.ID.id!(other))
+
+    %Dagger.ContainerComparison{
+      query_builder: query_builder,
+      client: container.client
+    }
+  end
+
   @doc """
   Return the container's default arguments.
   """
@@ -241,7 +261,12 @@ defmodule Dagger.Container do
           {:platform_variants, [Dagger.ContainerID.t()]},
           {:forced_compression, Dagger.ImageLayerCompression.t() | nil},
           {:media_types, Dagger.ImageMediaTypes.t() | nil},
-          {:expand, boolean() | nil}
+          {:expand, boolean() | nil},
+          {:signing_key, Dagger.SecretID.t() | nil},
+          {:signing_key_password, Dagger.SecretID.t() | nil},
+          {:provenance, boolean() | nil},
+          {:sbom, Dagger.SBOMFormat.t() | nil},
+          {:squash, Dagger.ImageSquashMode.t() | nil}
         ]) :: {:ok, String.t()} | {:error, term()}
   def export(%__MODULE__{} = container, path, optional_args \\ []) do
     query_builder =
@@ -258,6 +283,11 @@ defmodule Dagger.Container do
       |> QB.maybe_put_arg("forcedCompression", optional_args[:forced_compression])
       |> QB.maybe_put_arg("mediaTypes", optional_args[:media_types])
       |> QB.maybe_put_arg("expand", optional_args[:expand])
+      |> QB.maybe_put_arg("signingKey", optional_args[:signing_key])
+      |> QB.maybe_put_arg("signingKeyPassword", optional_args[:signing_key_password])
+      |> QB.maybe_put_arg("provenance", optional_args[:provenance])
+      |> QB.maybe_put_arg("sbom", optional_args[:sbom])
+      |> QB.maybe_put_arg("squash", optional_args[:squash])
 
     Client.execute(container.client, query_builder)
   end
@@ -450,7 +480,12 @@ defmodule Dagger.Container do
   @spec publish(t(), String.t(), [
           {:platform_variants, [Dagger.ContainerID.t()]},
           {:forced_compression, Dagger.ImageLayerCompression.t() | nil},
-          {:media_types, Dagger.ImageMediaTypes.t() | nil}
+          {:media_types, Dagger.ImageMediaTypes.t() | nil},
+          {:signing_key, Dagger.SecretID.t() | nil},
+          {:signing_key_password, Dagger.SecretID.t() | nil},
+          {:provenance, boolean() | nil},
+          {:sbom, Dagger.SBOMFormat.t() | nil},
+          {:squash, Dagger.ImageSquashMode.t() | nil}
         ]) :: {:ok, String.t()} | {:error, term()}
   def publish(%__MODULE__{} = container, address, optional_args \\ []) do
     query_builder =
@@ -466,6 +501,11 @@ defmodule Dagger.Container do
       )
       |> QB.maybe_put_arg("forcedCompression", optional_args[:forced_compression])
       |> QB.maybe_put_arg("mediaTypes", optional_args[:media_types])
+      |> QB.maybe_put_arg("signingKey", optional_args[:signing_key])
+      |> QB.maybe_put_arg("signingKeyPassword", optional_args[:signing_key_password])
+      |> QB.maybe_put_arg("provenance", optional_args[:provenance])
+      |> QB.maybe_put_arg("sbom", optional_args[:sbom])
+      |> QB.maybe_put_arg("squash", optional_args[:squash])
 
     Client.execute(container.client, query_builder)
   end
@@ -484,6 +524,24 @@ defmodule Dagger.Container do
     }
   end
 
+  @doc """
+  Return a software bill of materials (SBOM) of the packages in the container's root filesystem.
+
+  OS packages installed with apk, dpkg or rpm are detected, as well as the dependencies in Go, npm, Python and Cargo lockfiles. Mounts are not scanned.
+  """
+  @spec sbom(t(), [{:format, Dagger.SBOMFormat.t() | nil}]) :: Dagger.File.t()
+  def sbom(%__MODULE__{} = container, optional_args \\ []) do
+    query_builder =
+      container.query_builder
+      |> QB.select("sbom")
+      |> QB.maybe_put_arg("format", optional_args[:format])
+
+    %Dagger.File{
+      query_builder: query_builder,
+      client: container.client
+    }
+  end
+
   @doc """
   The buffered standard error stream of the last executed command
 
@@ -570,7 +628,9 @@ defmodule Dagger.Container do
           {:experimental_privileged_nesting, boolean() | nil},
           {:insecure_root_capabilities, boolean() | nil},
           {:expand, boolean() | nil},
-          {:no_init, boolean() | nil}
+          {:no_init, boolean() | nil},
+          {:network_mode, Dagger.NetworkMode.t() | nil},
+          {:egress_allowlist, [String.t()]}
         ]) :: :ok | {:error, term()}
   def up(%__MODULE__{} = container, optional_args \\ []) do
     query_builder =
@@ -587,6 +647,8 @@ defmodule Dagger.Container do
       |> QB.maybe_put_arg("insecureRootCapabilities", optional_args[:insecure_root_capabilities])
       |> QB.maybe_put_arg("expand", optional_args[:expand])
       |> QB.maybe_put_arg("noInit", optional_args[:no_init])
+      |> QB.maybe_put_arg("networkMode", optional_args[:network_mode])
+      |> QB.maybe_put_arg("egressAllowlist", optional_args[:egress_allowlist])
 
     case Client.execute(container.client, query_builder) do
       {:ok, _} -> :ok
@@ -622,6 +684,22 @@ defmodule Dagger.Container do
     }
   end
 
+  @doc """
+  Pauses the pipeline when this container is evaluated, opening an interactive terminal for it.
+
+  The pipeline continues when the terminal exits. Breakpoints only pause when the CLI runs with --interactive or --break-on, so they can be left in code, and they don't affect the cache of the pipeline.
+  """
+  @spec with_breakpoint(t()) :: Dagger.Container.t()
+  def with_breakpoint(%__MODULE__{} = container) do
+    query_builder =
+      container.query_builder |> QB.select("withBreakpoint")
+
+    %Dagger.Container{
+      query_builder: query_builder,
+      client: container.client
+    }
+  end
+
   @doc """
   Configures default arguments for future commands. Like CMD in Dockerfile.
   """
@@ -768,7 +846,9 @@ defmodule Dagger.Container do
           {:experimental_privileged_nesting, boolean() | nil},
           {:insecure_root_capabilities, boolean() | nil},
           {:expand, boolean() | nil},
-          {:no_init, boolean() | nil}
+          {:no_init, boolean() | nil},
+          {:network_mode, Dagger.NetworkMode.t() | nil},
+          {:egress_allowlist, [String.t()]}
         ]) :: Dagger.Container.t()
   def with_exec(%__MODULE__{} = container, args, optional_args \\ []) do
     query_builder =
@@ -788,6 +868,8 @@ defmodule Dagger.Container do
       |> QB.maybe_put_arg("insecureRootCapabilities", optional_args[:insecure_root_capabilities])
       |> QB.maybe_put_arg("expand", optional_args[:expand])
       |> QB.maybe_put_arg("noInit", optional_args[:no_init])
+      |> QB.maybe_put_arg("networkMode", optional_args[:network_mode])
+      |> QB.maybe_put_arg("egressAllowlist", optional_args[:egress_allowlist])
 
     %Dagger.Container{
       query_builder: query_builder,
@@ -892,6 +974,22 @@ defmodule Dagger.Container do
     }
   end
 
+  @doc """
+  Retrieves this container with a layer boundary at the current state of its root filesystem.
+
+  When the container is published or exported, the changes between consecutive boundaries are grouped into a single layer, on top of the layers of the image the container was created from. Changes after the last boundary make a layer of their own.
+  """
+  @spec with_layer_boundary(t()) :: Dagger.Container.t()
+  def with_layer_boundary(%__MODULE__{} = container) do
+    query_builder =
+      container.query_builder |> QB.select("withLayerBoundary")
+
+    %Dagger.Container{
+      query_builder: query_builder,
+      client: container.client
+    }
+  end
+
   @doc """
   Retrieves this container plus a cache volume mounted at the given path.
   """
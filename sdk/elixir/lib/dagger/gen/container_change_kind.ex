@@ -0,0 +1,43 @@
+# This file generated by `dagger_codegen`. Please DO NOT EDIT.
+defmodule Dagger.ContainerChangeKind do
+  @moduledoc """
+  How an entry differs between two containers
+  """
+
+  use Dagger.Core.Base, kind: :enum, name: "ContainerChangeKind"
+
+  @type t() :: :ADDED | :REMOVED | :MODIFIED | :UNCHANGED
+
+  @doc """
+  The entry only exists in the newer container.
+  """
+  @spec added() :: :ADDED
+  def added(), do: :ADDED
+
+  @doc """
+  The entry only exists in the older container.
+  """
+  @spec removed() :: :REMOVED
+  def removed(), do: :REMOVED
+
+  @doc """
+  The entry exists in both containers, with different values.
+  """
+  @spec modified() :: :MODIFIED
+  def modified(), do: :MODIFIED
+
+  @doc """
+  The entry is the same in both containers.
+  """
+  @spec unchanged() :: :UNCHANGED
+  def unchanged(), do: :UNCHANGED
+
+  @doc false
+  @spec from_string(String.t()) :: t()
+  def from_string(string)
+
+  def from_string("ADDED"), do: :ADDED
+  def from_string("REMOVED"), do: :REMOVED
+  def from_string("MODIFIED"), do: :MODIFIED
+  def from_string("UNCHANGED"), do: :UNCHANGED
+end
@@ -0,0 +1,138 @@
+# This file generated by `dagger_codegen`. Please DO NOT EDIT.
+defmodule Dagger.ContainerComparison do
+  @moduledoc """
+  A comparison between the image configuration, layers and root filesystem of two containers.
+  """
+
+  use Dagger.Core.Base, kind: :object, name: "ContainerComparison"
+
+  alias Dagger.Core.Client
+  alias Dagger.Core.QueryBuilder, as: QB
+
+  @derive Dagger.ID
+
+  defstruct [:query_builder, :client]
+
+  @type t() :: %__MODULE__{}
+
+  @doc """
+  The newer container.
+  """
+  @spec after_(t()) :: Dagger.Container.t()
+  def after_(%__MODULE__{} = container_comparison) do
+    query_builder =
+      container_comparison.query_builder |> QB.select("after")
+
+    %Dagger.Container{
+      query_builder: query_builder,
+      client: container_comparison.client
+    }
+  end
+
+  @doc """
+  The older container to compare against.
+  """
+  @spec before(t()) :: Dagger.Container.t()
+  def before(%__MODULE__{} = container_comparison) do
+    query_builder =
+      container_comparison.query_builder |> QB.select("before")
+
+    %Dagger.Container{
+      query_builder: query_builder,
+      client: container_comparison.client
+    }
+  end
+
+  @doc """
+  The changes between the root filesystems of the containers.
+  """
+  @spec changes(t()) :: Dagger.Changeset.t()
+  def changes(%__MODULE__{} = container_comparison) do
+    query_builder =
+      container_comparison.query_builder |> QB.select("changes")
+
+    %Dagger.Changeset{
+      query_builder: query_builder,
+      client: container_comparison.client
+    }
+  end
+
+  @doc """
+  Changes to the image configuration, e.g. environment variables, entrypoint, user, labels and exposed ports.
+  """
+  @spec config_changes(t()) :: {:ok, [Dagger.ContainerConfigChange.t()]} | {:error, term()}
+  def config_changes(%__MODULE__{} = container_comparison) do
+    query_builder =
+      container_comparison.query_builder |> QB.select("configChanges") |> QB.select("id")
+
+    with {:ok, items} <- Client.execute(container_comparison.client, query_builder) do
+      {:ok,
+       for %{"id" => id} <- items do
+         %Dagger.ContainerConfigChange{
+           query_builder:
+             QB.query()
+             |> QB.select("loadContainerConfigChangeFromID")
+             |> QB.put_arg("id", id),
+           client: container_comparison.client
+         }
+       end}
+    end
+  end
+
+  @doc """
+  A unique identifier for this ContainerComparison.
+  """
+  @spec id(t()) :: {:ok, Dagger.ContainerComparisonID.t()} | {:error, term()}
+  def id(%__MODULE__{} = container_comparison) do
+    query_builder =
+      container_comparison.query_builder |> QB.select("id")
+
+    Client.execute(container_comparison.client, query_builder)
+  end
+
+  @doc """
+  Comparison of the layers of the root filesystems, by position from the base layer.
+  """
+  @spec layers(t()) :: {:ok, [Dagger.ContainerLayerComparison.t()]} | {:error, term()}
+  def layers(%__MODULE__{} = container_comparison) do
+    query_builder =
+      container_comparison.query_builder |> QB.select("layers") |> QB.select("id")
+
+    with {:ok, items} <- Client.execute(container_comparison.client, query_builder) do
+      {:ok,
+       for %{"id" => id} <- items do
+         %Dagger.ContainerLayerComparison{
+           query_builder:
+             QB.query()
+             |> QB.select("loadContainerLayerComparisonFromID")
+             |> QB.put_arg("id", id),
+           client: container_comparison.client
+         }
+       end}
+    end
+  end
+
+  @doc """
+  A human-readable summary of the comparison.
+  """
+  @spec summary(t()) :: {:ok, String.t()} | {:error, term()}
+  def summary(%__MODULE__{} = container_comparison) do
+    query_builder =
+      container_comparison.query_builder |> QB.select("summary")
+
+    Client.execute(container_comparison.client, query_builder)
+  end
+end
+
+defimpl Jason.Encoder, for: Dagger.ContainerComparison do
+  def encode(container_comparison, opts) do
+    {:ok, id} = Dagger.ContainerComparison.id(container_comparison)
+    Jason.Encode.string(id, opts)
+  end
+end
+
+defimpl Nestru.Decoder, for: Dagger.ContainerComparison do
+  def decode_fields_hint(_struct, _context, id) do
+    {:ok, Dagger.Client.load_container_comparison_from_id(Dagger.Global.dag(), id)}
+  end
+end
@@ -0,0 +1,10 @@
+# This file generated by `dagger_codegen`. Please DO NOT EDIT.
+defmodule Dagger.ContainerComparisonID do
+  @moduledoc """
+  The `ContainerComparisonID` scalar type represents an identifier for an object of type ContainerComparison.
+  """
+
+  use Dagger.Core.Base, kind: :scalar, name: "ContainerComparisonID"
+
+  @type t() :: String.t()
+end
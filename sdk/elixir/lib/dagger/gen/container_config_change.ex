@@ -0,0 +1,99 @@
+# This file generated by `dagger_codegen`. Please DO NOT EDIT.
+defmodule Dagger.ContainerConfigChange do
+  @moduledoc """
+  A change to the image configuration between two containers.
+  """
+
+  use Dagger.Core.Base, kind: :object, name: "ContainerConfigChange"
+
+  alias Dagger.Core.Client
+  alias Dagger.Core.QueryBuilder, as: QB
+
+  @derive Dagger.ID
+
+  defstruct [:query_builder, :client]
+
+  @type t() :: %__MODULE__{}
+
+  @doc """
+  The value in the newer container. Lists are encoded as JSON arrays.
+  """
+  @spec after_(t()) :: {:ok, String.t()} | {:error, term()}
+  def after_(%__MODULE__{} = container_config_change) do
+    query_builder =
+      container_config_change.query_builder |> QB.select("after")
+
+    Client.execute(container_config_change.client, query_builder)
+  end
+
+  @doc """
+  The value in the older container. Lists are encoded as JSON arrays.
+  """
+  @spec before(t()) :: {:ok, String.t()} | {:error, term()}
+  def before(%__MODULE__{} = container_config_change) do
+    query_builder =
+      container_config_change.query_builder |> QB.select("before")
+
+    Client.execute(container_config_change.client, query_builder)
+  end
+
+  @doc """
+  The changed setting, named after the API that sets it, e.g. "envVariable", "entrypoint" or "label".
+  """
+  @spec field(t()) :: {:ok, String.t()} | {:error, term()}
+  def field(%__MODULE__{} = container_config_change) do
+    query_builder =
+      container_config_change.query_builder |> QB.select("field")
+
+    Client.execute(container_config_change.client, query_builder)
+  end
+
+  @doc """
+  A unique identifier for this ContainerConfigChange.
+  """
+  @spec id(t()) :: {:ok, Dagger.ContainerConfigChangeID.t()} | {:error, term()}
+  def id(%__MODULE__{} = container_config_change) do
+    query_builder =
+      container_config_change.query_builder |> QB.select("id")
+
+    Client.execute(container_config_change.client, query_builder)
+  end
+
+  @doc """
+  The changed entry of settings that have several, i.e. the name of an environment variable or label, or an exposed port such as "8080/tcp".
+  """
+  @spec key(t()) :: {:ok, String.t()} | {:error, term()}
+  def key(%__MODULE__{} = container_config_change) do
+    query_builder =
+      container_config_change.query_builder |> QB.select("key")
+
+    Client.execute(container_config_change.client, query_builder)
+  end
+
+  @doc """
+  How the setting changed.
+  """
+  @spec kind(t()) :: {:ok, Dagger.ContainerChangeKind.t()} | {:error, term()}
+  def kind(%__MODULE__{} = container_config_change) do
+    query_builder =
+      container_config_change.query_builder |> QB.select("kind")
+
+    case Client.execute(container_config_change.client, query_builder) do
+      {:ok, enum} -> {:ok, Dagger.ContainerChangeKind.from_string(enum)}
+      error -> error
+    end
+  end
+end
+
+defimpl Jason.Encoder, for: Dagger.ContainerConfigChange do
+  def encode(container_config_change, opts) do
+    {:ok, id} = Dagger.ContainerConfigChange.id(container_config_change)
+    Jason.Encode.string(id, opts)
+  end
+end
+
+defimpl Nestru.Decoder, for: Dagger.ContainerConfigChange do
+  def decode_fields_hint(_struct, _context, id) do
+    {:ok, Dagger.Client.load_container_config_change_from_id(Dagger.Global.dag(), id)}
+  end
+end
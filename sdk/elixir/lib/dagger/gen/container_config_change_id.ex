@@ -0,0 +1,10 @@
+# This file generated by `dagger_codegen`. Please DO NOT EDIT.
+defmodule Dagger.ContainerConfigChangeID do
+  @moduledoc """
+  The `ContainerConfigChangeID` scalar type represents an identifier for an object of type ContainerConfigChange.
+  """
+
+  use Dagger.Core.Base, kind: :scalar, name: "ContainerConfigChangeID"
+
+  @type t() :: String.t()
+end
@@ -0,0 +1,110 @@
+# This file generated by `dagger_codegen`. Please DO NOT EDIT.
+defmodule Dagger.ContainerLayerComparison do
+  @moduledoc """
+  A comparison of the layers of two containers at the same position.
+  """
+
+  use Dagger.Core.Base, kind: :object, name: "ContainerLayerComparison"
+
+  alias Dagger.Core.Client
+  alias Dagger.Core.QueryBuilder, as: QB
+
+  @derive Dagger.ID
+
+  defstruct [:query_builder, :client]
+
+  @type t() :: %__MODULE__{}
+
+  @doc """
+  The digest of the layer in the newer container, if it has one at this position.
+  """
+  @spec after_digest(t()) :: {:ok, String.t()} | {:error, term()}
+  def after_digest(%__MODULE__{} = container_layer_comparison) do
+    query_builder =
+      container_layer_comparison.query_builder |> QB.select("afterDigest")
+
+    Client.execute(container_layer_comparison.client, query_builder)
+  end
+
+  @doc """
+  The compressed size of the layer in the newer container, in bytes.
+  """
+  @spec after_size(t()) :: {:ok, integer()} | {:error, term()}
+  def after_size(%__MODULE__{} = container_layer_comparison) do
+    query_builder =
+      container_layer_comparison.query_builder |> QB.select("afterSize")
+
+    Client.execute(container_layer_comparison.client, query_builder)
+  end
+
+  @doc """
+  The digest of the layer in the older container, if it has one at this position.
+  """
+  @spec before_digest(t()) :: {:ok, String.t()} | {:error, term()}
+  def before_digest(%__MODULE__{} = container_layer_comparison) do
+    query_builder =
+      container_layer_comparison.query_builder |> QB.select("beforeDigest")
+
+    Client.execute(container_layer_comparison.client, query_builder)
+  end
+
+  @doc """
+  The compressed size of the layer in the older container, in bytes.
+  """
+  @spec before_size(t()) :: {:ok, integer()} | {:error, term()}
+  def before_size(%__MODULE__{} = container_layer_comparison) do
+    query_builder =
+      container_layer_comparison.query_builder |> QB.select("beforeSize")
+
+    Client.execute(container_layer_comparison.client, query_builder)
+  end
+
+  @doc """
+  A unique identifier for this ContainerLayerComparison.
+  """
+  @spec id(t()) :: {:ok, Dagger.ContainerLayerComparisonID.t()} | {:error, term()}
+  def id(%__MODULE__{} = container_layer_comparison) do
+    query_builder =
+      container_layer_comparison.query_builder |> QB.select("id")
+
+    Client.execute(container_layer_comparison.client, query_builder)
+  end
+
+  @doc """
+  The position of the layer, starting from the base layer at 0.
+  """
+  @spec index(t()) :: {:ok, integer()} | {:error, term()}
+  def index(%__MODULE__{} = container_layer_comparison) do
+    query_builder =
+      container_layer_comparison.query_builder |> QB.select("index")
+
+    Client.execute(container_layer_comparison.client, query_builder)
+  end
+
+  @doc """
+  Whether the layer differs between the containers.
+  """
+  @spec kind(t()) :: {:ok, Dagger.ContainerChangeKind.t()} | {:error, term()}
+  def kind(%__MODULE__{} = container_layer_comparison) do
+    query_builder =
+      container_layer_comparison.query_builder |> QB.select("kind")
+
+    case Client.execute(container_layer_comparison.client, query_builder) do
+      {:ok, enum} -> {:ok, Dagger.ContainerChangeKind.from_string(enum)}
+      error -> error
+    end
+  end
+end
+
+defimpl Jason.Encoder, for: Dagger.ContainerLayerComparison do
+  def encode(container_layer_comparison, opts) do
+    {:ok, id} = Dagger.ContainerLayerComparison.id(container_layer_comparison)
+    Jason.Encode.string(id, opts)
+  end
+end
+
+defimpl Nestru.Decoder, for: Dagger.ContainerLayerComparison do
+  def decode_fields_hint(_struct, _context, id) do
+    {:ok, Dagger.Client.load_container_layer_comparison_from_id(Dagger.Global.dag(), id)}
+  end
+end
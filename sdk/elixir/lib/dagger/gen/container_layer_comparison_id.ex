@@ -0,0 +1,10 @@
+# This file generated by `dagger_codegen`. Please DO NOT EDIT.
+defmodule Dagger.ContainerLayerComparisonID do
+  @moduledoc """
+  The `ContainerLayerComparisonID` scalar type represents an identifier for an object of type ContainerLayerComparison.
+  """
+
+  use Dagger.Core.Base, kind: :scalar, name: "ContainerLayerComparisonID"
+
+  @type t() :: String.t()
+end
@@ -296,6 +296,24 @@ defmodule Dagger.Directory do
     Client.execute(directory.client, query_builder)
   end
 
+  @doc """
+  Return a software bill of materials (SBOM) of the packages in the directory.
+
+  OS packages installed with apk, dpkg or rpm are detected, as well as the dependencies in Go, npm, Python and Cargo lockfiles.
+  """
+  @spec sbom(t(), [{:format, Dagger.SBOMFormat.t() | nil}]) :: Dagger.File.t()
+  def sbom(%__MODULE__{} = directory, optional_args \\ []) do
+    query_builder =
+      directory.query_builder
+      |> QB.select("sbom")
+      |> QB.maybe_put_arg("format", optional_args[:format])
+
+    %Dagger.File{
+      query_builder: query_builder,
+      client: directory.client
+    }
+  end
+
   @doc """
   Searches for content matching the given regular expression or literal string.
 
@@ -61,6 +61,50 @@ defmodule Dagger.Engine do
 
     Client.execute(engine.client, query_builder)
   end
+
+  @doc """
+  The list of persistent services running in the engine, shared across sessions
+  """
+  @spec services(t()) :: {:ok, [Dagger.EngineService.t()]} | {:error, term()}
+  def services(%__MODULE__{} = engine) do
+    query_builder =
+      engine.query_builder |> QB.select("services") |> QB.select("id")
+
+    with {:ok, items} <- Client.execute(engine.client, query_builder) do
+      {:ok,
+       for %{"id" => id} <- items do
+         %Dagger.EngineService{
+           query_builder:
+             QB.query()
+             |> QB.select("loadEngineServiceFromID")
+             |> QB.put_arg("id", id),
+           client: engine.client
+         }
+       end}
+    end
+  end
+
+  @doc """
+  The list of active sessions, across all clients connected to the engine
+  """
+  @spec sessions(t()) :: {:ok, [Dagger.EngineSession.t()]} | {:error, term()}
+  def sessions(%__MODULE__{} = engine) do
+    query_builder =
+      engine.query_builder |> QB.select("sessions") |> QB.select("id")
+
+    with {:ok, items} <- Client.execute(engine.client, query_builder) do
+      {:ok,
+       for %{"id" => id} <- items do
+         %Dagger.EngineSession{
+           query_builder:
+             QB.query()
+             |> QB.select("loadEngineSessionFromID")
+             |> QB.put_arg("id", id),
+           client: engine.client
+         }
+       end}
+    end
+  end
 end
 
 defimpl Jason.Encoder, for: Dagger.Engine do
@@ -64,6 +64,20 @@ defmodule Dagger.EngineCache do
     Client.execute(engine_cache.client, query_builder)
   end
 
+  @doc """
+  Pin a cache entry, so that it's never pruned
+  """
+  @spec pin(t(), String.t()) :: :ok | {:error, term()}
+  def pin(%__MODULE__{} = engine_cache, record_id) do
+    query_builder =
+      engine_cache.query_builder |> QB.select("pin") |> QB.put_arg("recordId", record_id)
+
+    case Client.execute(engine_cache.client, query_builder) do
+      {:ok, _} -> :ok
+      error -> error
+    end
+  end
+
   @doc """
   Prune the cache of releaseable entries
   """
@@ -101,6 +115,22 @@ defmodule Dagger.EngineCache do
 
     Client.execute(engine_cache.client, query_builder)
   end
+
+  @doc """
+  Unpin a cache entry, so that it can be pruned again
+
+  The entries of a pinned cache volume are pinned again when the volume is used; unpin the volume itself with CacheVolume.unpin instead.
+  """
+  @spec unpin(t(), String.t()) :: :ok | {:error, term()}
+  def unpin(%__MODULE__{} = engine_cache, record_id) do
+    query_builder =
+      engine_cache.query_builder |> QB.select("unpin") |> QB.put_arg("recordId", record_id)
+
+    case Client.execute(engine_cache.client, query_builder) do
+      {:ok, _} -> :ok
+      error -> error
+    end
+  end
 end
 
 defimpl Jason.Encoder, for: Dagger.EngineCache do
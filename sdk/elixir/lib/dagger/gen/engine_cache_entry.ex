@@ -80,6 +80,28 @@ defmodule Dagger.EngineCacheEntry do
 
     Client.execute(engine_cache_entry.client, query_builder)
   end
+
+  @doc """
+  Whether the cache entry is pinned, exempting it from pruning.
+  """
+  @spec pinned(t()) :: {:ok, boolean()} | {:error, term()}
+  def pinned(%__MODULE__{} = engine_cache_entry) do
+    query_builder =
+      engine_cache_entry.query_builder |> QB.select("pinned")
+
+    Client.execute(engine_cache_entry.client, query_builder)
+  end
+
+  @doc """
+  The ID of the underlying cache record, which stays the same across sessions and engine restarts.
+  """
+  @spec record_id(t()) :: {:ok, String.t()} | {:error, term()}
+  def record_id(%__MODULE__{} = engine_cache_entry) do
+    query_builder =
+      engine_cache_entry.query_builder |> QB.select("recordId")
+
+    Client.execute(engine_cache_entry.client, query_builder)
+  end
 end
 
 defimpl Jason.Encoder, for: Dagger.EngineCacheEntry do
@@ -0,0 +1,118 @@
+# This file generated by `dagger_codegen`. Please DO NOT EDIT.
+defmodule Dagger.EngineService do
+  @moduledoc """
+  A persistent service running in the Dagger engine, shared across sessions
+  """
+
+  use Dagger.Core.Base, kind: :object, name: "EngineService"
+
+  alias Dagger.Core.Client
+  alias Dagger.Core.QueryBuilder, as: QB
+
+  @derive Dagger.ID
+
+  defstruct [:query_builder, :client]
+
+  @type t() :: %__MODULE__{}
+
+  @doc """
+  The number of bindings currently using the service, across sessions.
+  """
+  @spec bindings(t()) :: {:ok, integer()} | {:error, term()}
+  def bindings(%__MODULE__{} = engine_service) do
+    query_builder =
+      engine_service.query_builder |> QB.select("bindings")
+
+    Client.execute(engine_service.client, query_builder)
+  end
+
+  @doc """
+  The hostname to reach the service from any session.
+  """
+  @spec hostname(t()) :: {:ok, String.t()} | {:error, term()}
+  def hostname(%__MODULE__{} = engine_service) do
+    query_builder =
+      engine_service.query_builder |> QB.select("hostname")
+
+    Client.execute(engine_service.client, query_builder)
+  end
+
+  @doc """
+  A unique identifier for this EngineService.
+  """
+  @spec id(t()) :: {:ok, Dagger.EngineServiceID.t()} | {:error, term()}
+  def id(%__MODULE__{} = engine_service) do
+    query_builder =
+      engine_service.query_builder |> QB.select("id")
+
+    Client.execute(engine_service.client, query_builder)
+  end
+
+  @doc """
+  The time the service became unused, in Unix nanoseconds, or 0 if it's in use.
+  """
+  @spec idle_since_unix_nano(t()) :: {:ok, integer()} | {:error, term()}
+  def idle_since_unix_nano(%__MODULE__{} = engine_service) do
+    query_builder =
+      engine_service.query_builder |> QB.select("idleSinceUnixNano")
+
+    Client.execute(engine_service.client, query_builder)
+  end
+
+  @doc """
+  How long the service keeps running while unused (e.g., "30m"), or empty if it runs until stopped.
+  """
+  @spec idle_timeout(t()) :: {:ok, String.t()} | {:error, term()}
+  def idle_timeout(%__MODULE__{} = engine_service) do
+    query_builder =
+      engine_service.query_builder |> QB.select("idleTimeout")
+
+    Client.execute(engine_service.client, query_builder)
+  end
+
+  @doc """
+  The name the service was started with.
+  """
+  @spec name(t()) :: {:ok, String.t()} | {:error, term()}
+  def name(%__MODULE__{} = engine_service) do
+    query_builder =
+      engine_service.query_builder |> QB.select("name")
+
+    Client.execute(engine_service.client, query_builder)
+  end
+
+  @doc """
+  The ports exposed by the service.
+  """
+  @spec ports(t()) :: {:ok, [integer()]} | {:error, term()}
+  def ports(%__MODULE__{} = engine_service) do
+    query_builder =
+      engine_service.query_builder |> QB.select("ports")
+
+    Client.execute(engine_service.client, query_builder)
+  end
+
+  @doc """
+  The time the service started, in Unix nanoseconds.
+  """
+  @spec started_unix_nano(t()) :: {:ok, integer()} | {:error, term()}
+  def started_unix_nano(%__MODULE__{} = engine_service) do
+    query_builder =
+      engine_service.query_builder |> QB.select("startedUnixNano")
+
+    Client.execute(engine_service.client, query_builder)
+  end
+end
+
+defimpl Jason.Encoder, for: Dagger.EngineService do
+  def encode(engine_service, opts) do
+    {:ok, id} = Dagger.EngineService.id(engine_service)
+    Jason.Encode.string(id, opts)
+  end
+end
+
+defimpl Nestru.Decoder, for: Dagger.EngineService do
+  def decode_fields_hint(_struct, _context, id) do
+    {:ok, Dagger.Client.load_engine_service_from_id(Dagger.Global.dag(), id)}
+  end
+end
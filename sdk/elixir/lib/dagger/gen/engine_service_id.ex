@@ -0,0 +1,10 @@
+# This file generated by `dagger_codegen`. Please DO NOT EDIT.
+defmodule Dagger.EngineServiceID do
+  @moduledoc """
+  The `EngineServiceID` scalar type represents an identifier for an object of type EngineService.
+  """
+
+  use Dagger.Core.Base, kind: :scalar, name: "EngineServiceID"
+
+  @type t() :: String.t()
+end
@@ -0,0 +1,140 @@
+# This file generated by `dagger_codegen`. Please DO NOT EDIT.
+defmodule Dagger.EngineSession do
+  @moduledoc """
+  A session of a client connected to the Dagger engine
+  """
+
+  use Dagger.Core.Base, kind: :object, name: "EngineSession"
+
+  alias Dagger.Core.Client
+  alias Dagger.Core.QueryBuilder, as: QB
+
+  @derive Dagger.ID
+
+  defstruct [:query_builder, :client]
+
+  @type t() :: %__MODULE__{}
+
+  @doc """
+  The number of cacheable calls in the session that were cached.
+  """
+  @spec cache_hits(t()) :: {:ok, integer()} | {:error, term()}
+  def cache_hits(%__MODULE__{} = engine_session) do
+    query_builder =
+      engine_session.query_builder |> QB.select("cacheHits")
+
+    Client.execute(engine_session.client, query_builder)
+  end
+
+  @doc """
+  The number of cacheable calls in the session that were not cached.
+  """
+  @spec cache_misses(t()) :: {:ok, integer()} | {:error, term()}
+  def cache_misses(%__MODULE__{} = engine_session) do
+    query_builder =
+      engine_session.query_builder |> QB.select("cacheMisses")
+
+    Client.execute(engine_session.client, query_builder)
+  end
+
+  @doc """
+  The hostname of the main client.
+  """
+  @spec client_hostname(t()) :: {:ok, String.t()} | {:error, term()}
+  def client_hostname(%__MODULE__{} = engine_session) do
+    query_builder =
+      engine_session.query_builder |> QB.select("clientHostname")
+
+    Client.execute(engine_session.client, query_builder)
+  end
+
+  @doc """
+  The ID of the main client that started the session.
+  """
+  @spec client_id(t()) :: {:ok, String.t()} | {:error, term()}
+  def client_id(%__MODULE__{} = engine_session) do
+    query_builder =
+      engine_session.query_builder |> QB.select("clientId")
+
+    Client.execute(engine_session.client, query_builder)
+  end
+
+  @doc """
+  The version of the main client.
+  """
+  @spec client_version(t()) :: {:ok, String.t()} | {:error, term()}
+  def client_version(%__MODULE__{} = engine_session) do
+    query_builder =
+      engine_session.query_builder |> QB.select("clientVersion")
+
+    Client.execute(engine_session.client, query_builder)
+  end
+
+  @doc """
+  The command run by the main client, if known.
+  """
+  @spec command(t()) :: {:ok, String.t()} | {:error, term()}
+  def command(%__MODULE__{} = engine_session) do
+    query_builder =
+      engine_session.query_builder |> QB.select("command")
+
+    Client.execute(engine_session.client, query_builder)
+  end
+
+  @doc """
+  The ID of the session.
+  """
+  @spec id(t()) :: {:ok, String.t()} | {:error, term()}
+  def id(%__MODULE__{} = engine_session) do
+    query_builder =
+      engine_session.query_builder |> QB.select("id")
+
+    Client.execute(engine_session.client, query_builder)
+  end
+
+  @doc """
+  The names of the modules with clients connected to the session.
+  """
+  @spec modules(t()) :: {:ok, [String.t()]} | {:error, term()}
+  def modules(%__MODULE__{} = engine_session) do
+    query_builder =
+      engine_session.query_builder |> QB.select("modules")
+
+    Client.execute(engine_session.client, query_builder)
+  end
+
+  @doc """
+  The number of containers currently running in the session.
+  """
+  @spec running_execs(t()) :: {:ok, integer()} | {:error, term()}
+  def running_execs(%__MODULE__{} = engine_session) do
+    query_builder =
+      engine_session.query_builder |> QB.select("runningExecs")
+
+    Client.execute(engine_session.client, query_builder)
+  end
+
+  @doc """
+  The time the session started, in Unix nanoseconds.
+  """
+  @spec started_unix_nano(t()) :: {:ok, integer()} | {:error, term()}
+  def started_unix_nano(%__MODULE__{} = engine_session) do
+    query_builder =
+      engine_session.query_builder |> QB.select("startedUnixNano")
+
+    Client.execute(engine_session.client, query_builder)
+  end
+end
+
+defimpl Jason.Encoder, for: Dagger.EngineSession do
+  def encode(engine_session, opts) do
+    {:ok, id} = Dagger.EngineSession.id(engine_session)
+    Jason.Encode.string(id, opts)
+  end
+end
+
+defimpl Nestru.Decoder, for: Dagger.EngineSession do
+  def decode_fields_hint(_struct, _context, id) do
+    {:ok, Dagger.Client.load_engine_session_from_id(Dagger.Global.dag(), id)}
+  end
+end
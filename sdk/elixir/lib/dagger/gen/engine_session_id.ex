@@ -0,0 +1,10 @@
+# This file generated by `dagger_codegen`. Please DO NOT EDIT.
+defmodule Dagger.EngineSessionID do
+  @moduledoc """
+  The `EngineSessionID` scalar type represents an identifier for an object of type EngineSession.
+  """
+
+  use Dagger.Core.Base, kind: :scalar, name: "EngineSessionID"
+
+  @type t() :: String.t()
+end
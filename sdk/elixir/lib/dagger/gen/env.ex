@@ -310,6 +310,86 @@ defmodule Dagger.Env do
     }
   end
 
+  @doc """
+  Create or update a binding of type ContainerComparison in the environment
+  """
+  @spec with_container_comparison_input(
+          t(),
+          String.t(),
+          Dagger.ContainerComparison.t(),
+          String.t()
+        ) :: Dagger.Env.t()
+  def with_container_comparison_input(%__MODULE__{} = env, name, value, description) do
+    query_builder =
+      env.query_builder
+      |> QB.select("withContainerComparisonInput")
+      |> QB.put_arg("name", name)
+      |> QB.put_arg("value", Dagger.ID.id!(value))
+      |> QB.put_arg("description", description)
+
+    %Dagger.Env{
+      query_builder: query_builder,
+      client: env.client
+    }
+  end
+
+  @doc """
+  Declare a desired ContainerComparison output to be assigned in the environment
+  """
+  @spec with_container_comparison_output(t(), String.t(), String.t()) :: Dagger.Env.t()
+  def with_container_comparison_output(%__MODULE__{} = env, name, description) do
+    query_builder =
+      env.query_builder
+      |> QB.select("withContainerComparisonOutput")
+      |> QB.put_arg("name", name)
+      |> QB.put_arg("description", description)
+
+    %Dagger.Env{
+      query_builder: query_builder,
+      client: env.client
+    }
+  end
+
+  @doc """
+  Create or update a binding of type ContainerConfigChange in the environment
+  """
+  @spec with_container_config_change_input(
+          t(),
+          String.t(),
+          Dagger.ContainerConfigChange.t(),
+          String.t()
+        ) :: Dagger.Env.t()
+  def with_container_config_change_input(%__MODULE__{} = env, name, value, description) do
+    query_builder =
+      env.query_builder
+      |> QB.select("withContainerConfigChangeInput")
+      |> QB.put_arg("name", name)
+      |> QB.put_arg("value", Dagger.ID.id!(value))
+      |> QB.put_arg("description", description)
+
+    %Dagger.Env{
+      query_builder: query_builder,
+      client: env.client
+    }
+  end
+
+  @doc """
+  Declare a desired ContainerConfigChange output to be assigned in the environment
+  """
+  @spec with_container_config_change_output(t(), String.t(), String.t()) :: Dagger.Env.t()
+  def with_container_config_change_output(%__MODULE__{} = env, name, description) do
+    query_builder =
+      env.query_builder
+      |> QB.select("withContainerConfigChangeOutput")
+      |> QB.put_arg("name", name)
+      |> QB.put_arg("description", description)
+
+    %Dagger.Env{
+      query_builder: query_builder,
+      client: env.client
+    }
+  end
+
   @doc """
   Create or update a binding of type Container in the environment
   """
@@ -328,6 +408,46 @@ defmodule Dagger.Env do
     }
   end
 
+  @doc """
+  Create or update a binding of type ContainerLayerComparison in the environment
+  """
+  @spec with_container_layer_comparison_input(
+          t(),
+          String.t(),
+          Dagger.ContainerLayerComparison.t(),
+          String.t()
+        ) :: Dagger.Env.t()
+  def with_container_layer_comparison_input(%__MODULE__{} = env, name, value, description) do
+    query_builder =
+      env.query_builder
+      |> QB.select("withContainerLayerComparisonInput")
+      |> QB.put_arg("name", name)
+      |> QB.put_arg("value", Dagger.ID.id!(value))
+      |> QB.put_arg("description", description)
+
+    %Dagger.Env{
+      query_builder: query_builder,
+      client: env.client
+    }
+  end
+
+  @doc """
+  Declare a desired ContainerLayerComparison output to be assigned in the environment
+  """
+  @spec with_container_layer_comparison_output(t(), String.t(), String.t()) :: Dagger.Env.t()
+  def with_container_layer_comparison_output(%__MODULE__{} = env, name, description) do
+    query_builder =
+      env.query_builder
+      |> QB.select("withContainerLayerComparisonOutput")
+      |> QB.put_arg("name", name)
+      |> QB.put_arg("description", description)
+
+    %Dagger.Env{
+      query_builder: query_builder,
+      client: env.client
+    }
+  end
+
   @doc """
   Declare a desired Container output to be assigned in the environment
   """
@@ -396,6 +516,78 @@ defmodule Dagger.Env do
     }
   end
 
+  @doc """
+  Create or update a binding of type EngineService in the environment
+  """
+  @spec with_engine_service_input(t(), String.t(), Dagger.EngineService.t(), String.t()) ::
+          Dagger.Env.t()
+  def with_engine_service_input(%__MODULE__{} = env, name, value, description) do
+    query_builder =
+      env.query_builder
+      |> QB.select("withEngineServiceInput")
+      |> QB.put_arg("name", name)
+      |> QB.put_arg("value", Dagger.ID.id!(value))
+      |> QB.put_arg("description", description)
+
+    %Dagger.Env{
+      query_builder: query_builder,
+      client: env.client
+    }
+  end
+
+  @doc """
+  Declare a desired EngineService output to be assigned in the environment
+  """
+  @spec with_engine_service_output(t(), String.t(), String.t()) :: Dagger.Env.t()
+  def with_engine_service_output(%__MODULE__{} = env, name, description) do
+    query_builder =
+      env.query_builder
+      |> QB.select("withEngineServiceOutput")
+      |> QB.put_arg("name", name)
+      |> QB.put_arg("description", description)
+
+    %Dagger.Env{
+      query_builder: query_builder,
+      client: env.client
+    }
+  end
+
+  @doc """
+  Create or update a binding of type EngineSession in the environment
+  """
+  @spec with_engine_session_input(t(), String.t(), Dagger.EngineSession.t(), String.t()) ::
+          Dagger.Env.t()
+  def with_engine_session_input(%__MODULE__{} = env, name, value, description) do
+    query_builder =
+      env.query_builder
+      |> QB.select("withEngineSessionInput")
+      |> QB.put_arg("name", name)
+      |> QB.put_arg("value", Dagger.ID.id!(value))
+      |> QB.put_arg("description", description)
+
+    %Dagger.Env{
+      query_builder: query_builder,
+      client: env.client
+    }
+  end
+
+  @doc """
+  Declare a desired EngineSession output to be assigned in the environment
+  """
+  @spec with_engine_session_output(t(), String.t(), String.t()) :: Dagger.Env.t()
+  def with_engine_session_output(%__MODULE__{} = env, name, description) do
+    query_builder =
+      env.query_builder
+      |> QB.select("withEngineSessionOutput")
+      |> QB.put_arg("name", name)
+      |> QB.put_arg("description", description)
+
+    %Dagger.Env{
+      query_builder: query_builder,
+      client: env.client
+    }
+  end
+
   @doc """
   Create or update a binding of type EnvFile in the environment
   """
@@ -501,6 +693,46 @@ defmodule Dagger.Env do
     }
   end
 
+  @doc """
+  Create or update a binding of type FunctionArgConstraints in the environment
+  """
+  @spec with_function_arg_constraints_input(
+          t(),
+          String.t(),
+          Dagger.FunctionArgConstraints.t(),
+          String.t()
+        ) :: Dagger.Env.t()
+  def with_function_arg_constraints_input(%__MODULE__{} = env, name, value, description) do
+    query_builder =
+      env.query_builder
+      |> QB.select("withFunctionArgConstraintsInput")
+      |> QB.put_arg("name", name)
+      |> QB.put_arg("value", Dagger.ID.id!(value))
+      |> QB.put_arg("description", description)
+
+    %Dagger.Env{
+      query_builder: query_builder,
+      client: env.client
+    }
+  end
+
+  @doc """
+  Declare a desired FunctionArgConstraints output to be assigned in the environment
+  """
+  @spec with_function_arg_constraints_output(t(), String.t(), String.t()) :: Dagger.Env.t()
+  def with_function_arg_constraints_output(%__MODULE__{} = env, name, description) do
+    query_builder =
+      env.query_builder
+      |> QB.select("withFunctionArgConstraintsOutput")
+      |> QB.put_arg("name", name)
+      |> QB.put_arg("description", description)
+
+    %Dagger.Env{
+      query_builder: query_builder,
+      client: env.client
+    }
+  end
+
   @doc """
   Create or update a binding of type GitRef in the environment
   """
@@ -623,6 +855,42 @@ defmodule Dagger.Env do
     }
   end
 
+  @doc """
+  Create or update a binding of type ModuleCapabilities in the environment
+  """
+  @spec with_module_capabilities_input(t(), String.t(), Dagger.ModuleCapabilities.t(), String.t()) ::
+          Dagger.Env.t()
+  def with_module_capabilities_input(%__MODULE__{} = env, name, value, description) do
+    query_builder =
+      env.query_builder
+      |> QB.select("withModuleCapabilitiesInput")
+      |> QB.put_arg("name", name)
+      |> QB.put_arg("value", Dagger.ID.id!(value))
+      |> QB.put_arg("description", description)
+
+    %Dagger.Env{
+      query_builder: query_builder,
+      client: env.client
+    }
+  end
+
+  @doc """
+  Declare a desired ModuleCapabilities output to be assigned in the environment
+  """
+  @spec with_module_capabilities_output(t(), String.t(), String.t()) :: Dagger.Env.t()
+  def with_module_capabilities_output(%__MODULE__{} = env, name, description) do
+    query_builder =
+      env.query_builder
+      |> QB.select("withModuleCapabilitiesOutput")
+      |> QB.put_arg("name", name)
+      |> QB.put_arg("description", description)
+
+    %Dagger.Env{
+      query_builder: query_builder,
+      client: env.client
+    }
+  end
+
   @doc """
   Create or update a binding of type ModuleConfigClient in the environment
   """
@@ -734,6 +1002,52 @@ defmodule Dagger.Env do
     }
   end
 
+  @doc """
+  Checks the values saved to an output by running a command against them
+
+  When the command fails, its error is returned to the model, which is asked to fix the value until the command succeeds or the attempts run out. Directory, File and Container values are mounted at the given path, and String values are passed on stdin.
+  """
+  @spec with_output_exec_check(t(), String.t(), Dagger.Container.t(), [String.t()], [
+          {:path, String.t() | nil},
+          {:max_attempts, integer() | nil}
+        ]) :: Dagger.Env.t()
+  def with_output_exec_check(%__MODULE__{} = env, name, container, args, optional_args \\ []) do
+    query_builder =
+      env.query_builder
+      |> QB.select("withOutputExecCheck")
+      |> QB.put_arg("name", name)
+      |> QB.put_arg("container", Dagger.ID.id!(container))
+      |> QB.put_arg("args", args)
+      |> QB.maybe_put_arg("path", optional_args[:path])
+      |> QB.maybe_put_arg("maxAttempts", optional_args[:max_attempts])
+
+    %Dagger.Env{
+      query_builder: query_builder,
+      client: env.client
+    }
+  end
+
+  @doc """
+  Checks the values saved to an output by calling a function against them
+
+  The function must belong to a module installed in the environment, and take an argument of the output's type. When it fails, its error is returned to the model, which is asked to fix the value until the function succeeds or the attempts run out.
+  """
+  @spec with_output_function_check(t(), String.t(), String.t(), [{:max_attempts, integer() | nil}]) ::
+          Dagger.Env.t()
+  def with_output_function_check(%__MODULE__{} = env, name, function, optional_args \\ []) do
+    query_builder =
+      env.query_builder
+      |> QB.select("withOutputFunctionCheck")
+      |> QB.put_arg("name", name)
+      |> QB.put_arg("function", function)
+      |> QB.maybe_put_arg("maxAttempts", optional_args[:max_attempts])
+
+    %Dagger.Env{
+      query_builder: query_builder,
+      client: env.client
+    }
+  end
+
   @doc """
   Create or update a binding of type SearchResult in the environment
   """
@@ -946,6 +1260,42 @@ defmodule Dagger.Env do
     }
   end
 
+  @doc """
+  Create or update a binding of type UnionTypeDef in the environment
+  """
+  @spec with_union_type_def_input(t(), String.t(), Dagger.UnionTypeDef.t(), String.t()) ::
+          Dagger.Env.t()
+  def with_union_type_def_input(%__MODULE__{} = env, name, value, description) do
+    query_builder =
+      env.query_builder
+      |> QB.select("withUnionTypeDefInput")
+      |> QB.put_arg("name", name)
+      |> QB.put_arg("value", Dagger.ID.id!(value))
+      |> QB.put_arg("description", description)
+
+    %Dagger.Env{
+      query_builder: query_builder,
+      client: env.client
+    }
+  end
+
+  @doc """
+  Declare a desired UnionTypeDef output to be assigned in the environment
+  """
+  @spec with_union_type_def_output(t(), String.t(), String.t()) :: Dagger.Env.t()
+  def with_union_type_def_output(%__MODULE__{} = env, name, description) do
+    query_builder =
+      env.query_builder
+      |> QB.select("withUnionTypeDefOutput")
+      |> QB.put_arg("name", name)
+      |> QB.put_arg("description", description)
+
+    %Dagger.Env{
+      query_builder: query_builder,
+      client: env.client
+    }
+  end
+
   @doc """
   Returns a new environment with the provided workspace
   """
@@ -120,7 +120,14 @@ defmodule Dagger.Function do
           {:default_path, String.t() | nil},
           {:ignore, [String.t()]},
           {:source_map, Dagger.SourceMapID.t() | nil},
-          {:deprecated, String.t() | nil}
+          {:deprecated, String.t() | nil},
+          {:min, Dagger.JSON.t() | nil},
+          {:max, Dagger.JSON.t() | nil},
+          {:pattern, String.t() | nil},
+          {:min_length, integer() | nil},
+          {:max_length, integer() | nil},
+          {:non_empty, boolean() | nil},
+          {:required_paths, [String.t()]}
         ]) :: Dagger.Function.t()
   def with_arg(%__MODULE__{} = function, name, type_def, optional_args \\ []) do
     query_builder =
@@ -134,6 +141,13 @@ defmodule Dagger.Function do
       |> QB.maybe_put_arg("ignore", optional_args[:ignore])
       |> QB.maybe_put_arg("sourceMap", optional_args[:source_map])
       |> QB.maybe_put_arg("deprecated", optional_args[:deprecated])
+      |> QB.maybe_put_arg("min", optional_args[:min])
+      |> QB.maybe_put_arg("max", optional_args[:max])
+      |> QB.maybe_put_arg("pattern", optional_args[:pattern])
+      |> QB.maybe_put_arg("minLength", optional_args[:min_length])
+      |> QB.maybe_put_arg("maxLength", optional_args[:max_length])
+      |> QB.maybe_put_arg("nonEmpty", optional_args[:non_empty])
+      |> QB.maybe_put_arg("requiredPaths", optional_args[:required_paths])
 
     %Dagger.Function{
       query_builder: query_builder,
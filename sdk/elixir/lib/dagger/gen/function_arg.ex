@@ -17,6 +17,20 @@ defmodule Dagger.FunctionArg do
 
   @type t() :: %__MODULE__{}
 
+  @doc """
+  Constraints the value of the argument must satisfy, if any.
+  """
+  @spec constraints(t()) :: Dagger.FunctionArgConstraints.t() | nil
+  def constraints(%__MODULE__{} = function_arg) do
+    query_builder =
+      function_arg.query_builder |> QB.select("constraints")
+
+    %Dagger.FunctionArgConstraints{
+      query_builder: query_builder,
+      client: function_arg.client
+    }
+  end
+
   @doc """
   Only applies to arguments of type File or Directory. If the argument is not set, load it from the given path in the context directory
   """
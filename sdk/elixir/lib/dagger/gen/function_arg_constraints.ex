@@ -0,0 +1,120 @@
+# This file generated by `dagger_codegen`. Please DO NOT EDIT.
+defmodule Dagger.FunctionArgConstraints do
+  @moduledoc """
+  Constraints on the value of a function argument.
+
+  They are checked by the engine before the function is called.
+  """
+
+  use Dagger.Core.Base, kind: :object, name: "FunctionArgConstraints"
+
+  alias Dagger.Core.Client
+  alias Dagger.Core.QueryBuilder, as: QB
+
+  @derive Dagger.ID
+
+  defstruct [:query_builder, :client]
+
+  @type t() :: %__MODULE__{}
+
+  @doc """
+  A unique identifier for this FunctionArgConstraints.
+  """
+  @spec id(t()) :: {:ok, Dagger.FunctionArgConstraintsID.t()} | {:error, term()}
+  def id(%__MODULE__{} = function_arg_constraints) do
+    query_builder =
+      function_arg_constraints.query_builder |> QB.select("id")
+
+    Client.execute(function_arg_constraints.client, query_builder)
+  end
+
+  @doc """
+  Only applies to Integer and Float arguments. The maximum value, inclusive, as a JSON number.
+  """
+  @spec max(t()) :: {:ok, Dagger.JSON.t()} | {:error, term()}
+  def max(%__MODULE__{} = function_arg_constraints) do
+    query_builder =
+      function_arg_constraints.query_builder |> QB.select("max")
+
+    Client.execute(function_arg_constraints.client, query_builder)
+  end
+
+  @doc """
+  Only applies to String and list arguments. The maximum length of the value, or 0 for no maximum.
+  """
+  @spec max_length(t()) :: {:ok, integer()} | {:error, term()}
+  def max_length(%__MODULE__{} = function_arg_constraints) do
+    query_builder =
+      function_arg_constraints.query_builder |> QB.select("maxLength")
+
+    Client.execute(function_arg_constraints.client, query_builder)
+  end
+
+  @doc """
+  Only applies to Integer and Float arguments. The minimum value, inclusive, as a JSON number.
+  """
+  @spec min(t()) :: {:ok, Dagger.JSON.t()} | {:error, term()}
+  def min(%__MODULE__{} = function_arg_constraints) do
+    query_builder =
+      function_arg_constraints.query_builder |> QB.select("min")
+
+    Client.execute(function_arg_constraints.client, query_builder)
+  end
+
+  @doc """
+  Only applies to String and list arguments. The minimum length of the value.
+  """
+  @spec min_length(t()) :: {:ok, integer()} | {:error, term()}
+  def min_length(%__MODULE__{} = function_arg_constraints) do
+    query_builder =
+      function_arg_constraints.query_builder |> QB.select("minLength")
+
+    Client.execute(function_arg_constraints.client, query_builder)
+  end
+
+  @doc """
+  Only applies to list arguments. Whether the list must contain at least one element.
+  """
+  @spec non_empty(t()) :: {:ok, boolean()} | {:error, term()}
+  def non_empty(%__MODULE__{} = function_arg_constraints) do
+    query_builder =
+      function_arg_constraints.query_builder |> QB.select("nonEmpty")
+
+    Client.execute(function_arg_constraints.client, query_builder)
+  end
+
+  @doc """
+  Only applies to String arguments. A regular expression (RE2 syntax) that the whole value must match.
+  """
+  @spec pattern(t()) :: {:ok, String.t()} | {:error, term()}
+  def pattern(%__MODULE__{} = function_arg_constraints) do
+    query_builder =
+      function_arg_constraints.query_builder |> QB.select("pattern")
+
+    Client.execute(function_arg_constraints.client, query_builder)
+  end
+
+  @doc """
+  Only applies to arguments of type Directory. Paths that must exist in the directory.
+  """
+  @spec required_paths(t()) :: {:ok, [String.t()]} | {:error, term()}
+  def required_paths(%__MODULE__{} = function_arg_constraints) do
+    query_builder =
+      function_arg_constraints.query_builder |> QB.select("requiredPaths")
+
+    Client.execute(function_arg_constraints.client, query_builder)
+  end
+end
+
+defimpl Jason.Encoder, for: Dagger.FunctionArgConstraints do
+  def encode(function_arg_constraints, opts) do
+    {:ok, id} = Dagger.FunctionArgConstraints.id(function_arg_constraints)
+    Jason.Encode.string(id, opts)
+  end
+end
+
+defimpl Nestru.Decoder, for: Dagger.FunctionArgConstraints do
+  def decode_fields_hint(_struct, _context, id) do
+    {:ok, Dagger.Client.load_function_arg_constraints_from_id(Dagger.Global.dag(), id)}
+  end
+end
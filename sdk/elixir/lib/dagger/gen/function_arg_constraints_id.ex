@@ -0,0 +1,10 @@
+# This file generated by `dagger_codegen`. Please DO NOT EDIT.
+defmodule Dagger.FunctionArgConstraintsID do
+  @moduledoc """
+  The `FunctionArgConstraintsID` scalar type represents an identifier for an object of type FunctionArgConstraints.
+  """
+
+  use Dagger.Core.Base, kind: :scalar, name: "FunctionArgConstraintsID"
+
+  @type t() :: String.t()
+end
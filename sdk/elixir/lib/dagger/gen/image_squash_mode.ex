@@ -0,0 +1,29 @@
+# This file generated by `dagger_codegen`. Please DO NOT EDIT.
+defmodule Dagger.ImageSquashMode do
+  @moduledoc """
+  How to squash the layers of an image
+  """
+
+  use Dagger.Core.Base, kind: :enum, name: "ImageSquashMode"
+
+  @type t() :: :ALL | :FROM_BASE
+
+  @doc """
+  Squash the whole root filesystem into a single layer.
+  """
+  @spec all() :: :ALL
+  def all(), do: :ALL
+
+  @doc """
+  Keep the layers of the image the container was created from, and squash everything on top of them into a single layer.
+  """
+  @spec from_base() :: :FROM_BASE
+  def from_base(), do: :FROM_BASE
+
+  @doc false
+  @spec from_string(String.t()) :: t()
+  def from_string(string)
+
+  def from_string("ALL"), do: :ALL
+  def from_string("FROM_BASE"), do: :FROM_BASE
+end
@@ -43,6 +43,17 @@ defmodule Dagger.LLM do
     }
   end
 
+  @doc """
+  return the calls recorded instead of being called in a dry run
+  """
+  @spec dry_run_calls(t()) :: {:ok, [String.t()]} | {:error, term()}
+  def dry_run_calls(%__MODULE__{} = llm) do
+    query_builder =
+      llm.query_builder |> QB.select("dryRunCalls")
+
+    Client.execute(llm.client, query_builder)
+  end
+
   @doc """
   return the LLM's current environment
   """
@@ -57,6 +68,49 @@ defmodule Dagger.LLM do
     }
   end
 
+  @doc """
+  Run several branches of the LLM in parallel, each with its own message history and a copy of its environment
+
+  Each branch submits the queued prompt, followed by one of the variations in turn, if any. Branches that fail are still returned, and fail when they are used. The token usage of all the branches is available with fanOutTokenUsage.
+  """
+  @spec fan_out(t(), [{:n, integer() | nil}, {:variations, [String.t()]}]) ::
+          {:ok, [Dagger.LLM.t()]} | {:error, term()}
+  def fan_out(%__MODULE__{} = llm, optional_args \\ []) do
+    query_builder =
+      llm.query_builder
+      |> QB.select("fanOut")
+      |> QB.maybe_put_arg("n", optional_args[:n])
+      |> QB.maybe_put_arg("variations", optional_args[:variations])
+      |> QB.select("id")
+
+    with {:ok, items} <- Client.execute(llm.client, query_builder) do
+      {:ok,
+       for %{"id" => id} <- items do
+         %Dagger.LLM{
+           query_builder:
+             QB.query()
+             |> QB.select("loadLLMFromID")
+             |> QB.put_arg("id", id),
+           client: llm.client
+         }
+       end}
+    end
+  end
+
+  @doc """
+  returns the token usage of the current state, including the other branches of the fan outs it came from
+  """
+  @spec fan_out_token_usage(t()) :: Dagger.LLMTokenUsage.t()
+  def fan_out_token_usage(%__MODULE__{} = llm) do
+    query_builder =
+      llm.query_builder |> QB.select("fanOutTokenUsage")
+
+    %Dagger.LLMTokenUsage{
+      query_builder: query_builder,
+      client: llm.client
+    }
+  end
+
   @doc """
   Indicates whether there are any queued prompts or tool results to send to the model
   """
@@ -230,6 +284,20 @@ defmodule Dagger.LLM do
     }
   end
 
+  @doc """
+  Return a new LLM that records the calls it makes with side effects outside of the engine, such as exports, publishing or host access, instead of calling them
+  """
+  @spec with_dry_run(t()) :: Dagger.LLM.t()
+  def with_dry_run(%__MODULE__{} = llm) do
+    query_builder =
+      llm.query_builder |> QB.select("withDryRun")
+
+    %Dagger.LLM{
+      query_builder: query_builder,
+      client: llm.client
+    }
+  end
+
   @doc """
   allow the LLM to interact with an environment via MCP
   """
@@ -331,6 +399,25 @@ defmodule Dagger.LLM do
     }
   end
 
+  @doc """
+  Return a new LLM that applies a policy to the functions matching a pattern
+
+  A pattern matches a function name, like withExec, or a type and function name, like Container.withExec, and may use * as a wildcard. Tools of MCP servers are matched by server and tool name. When several rules match a function, the last one applies, and functions that match no rule are allowed.
+  """
+  @spec with_tool_policy(t(), String.t(), Dagger.LLMToolPolicy.t()) :: Dagger.LLM.t()
+  def with_tool_policy(%__MODULE__{} = llm, pattern, policy) do
+    query_builder =
+      llm.query_builder
+      |> QB.select("withToolPolicy")
+      |> QB.put_arg("pattern", pattern)
+      |> QB.put_arg("policy", policy)
+
+    %Dagger.LLM{
+      query_builder: query_builder,
+      client: llm.client
+    }
+  end
+
   @doc """
   Disable the default system prompt
   """
@@ -0,0 +1,36 @@
+# This file generated by `dagger_codegen`. Please DO NOT EDIT.
+defmodule Dagger.LLMToolPolicy do
+  @moduledoc """
+  Whether an LLM may call a tool
+  """
+
+  use Dagger.Core.Base, kind: :enum, name: "LLMToolPolicy"
+
+  @type t() :: :ALLOW | :DENY | :ASK
+
+  @doc """
+  Let the LLM call the tool
+  """
+  @spec allow() :: :ALLOW
+  def allow(), do: :ALLOW
+
+  @doc """
+  Refuse to call the tool
+  """
+  @spec deny() :: :DENY
+  def deny(), do: :DENY
+
+  @doc """
+  Ask the user to approve each call of the tool, and refuse if they can't be asked, e.g. in CI
+  """
+  @spec ask() :: :ASK
+  def ask(), do: :ASK
+
+  @doc false
+  @spec from_string(String.t()) :: t()
+  def from_string(string)
+
+  def from_string("ALLOW"), do: :ALLOW
+  def from_string("DENY"), do: :DENY
+  def from_string("ASK"), do: :ASK
+end
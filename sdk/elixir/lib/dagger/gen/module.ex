@@ -286,6 +286,28 @@ defmodule Dagger.Module do
     end
   end
 
+  @doc """
+  Unions served by this module.
+  """
+  @spec unions(t()) :: {:ok, [Dagger.TypeDef.t()]} | {:error, term()}
+  def unions(%__MODULE__{} = module) do
+    query_builder =
+      module.query_builder |> QB.select("unions") |> QB.select("id")
+
+    with {:ok, items} <- Client.execute(module.client, query_builder) do
+      {:ok,
+       for %{"id" => id} <- items do
+         %Dagger.TypeDef{
+           query_builder:
+             QB.query()
+             |> QB.select("loadTypeDefFromID")
+             |> QB.put_arg("id", id),
+           client: module.client
+         }
+       end}
+    end
+  end
+
   @doc """
   User-defined default values, loaded from local .env files.
   """
@@ -361,6 +383,20 @@ defmodule Dagger.Module do
       client: module.client
     }
   end
+
+  @doc """
+  This module plus the given Union type
+  """
+  @spec with_union(t(), Dagger.TypeDef.t()) :: Dagger.Module.t()
+  def with_union(%__MODULE__{} = module, union) do
+    query_builder =
+      module.query_builder |> QB.select("withUnion") |> QB.put_arg("union", Dagger.ID.id!(union))
+
+    %Dagger.Module{
+      query_builder: query_builder,
+      client: module.client
+    }
+  end
 end
 
 defimpl Jason.Encoder, for: Dagger.Module do
@@ -0,0 +1,107 @@
+# This file generated by `dagger_codegen`. Please DO NOT EDIT.
+defmodule Dagger.ModuleCapabilities do
+  @moduledoc """
+  The capabilities a module requires.
+  """
+
+  use Dagger.Core.Base, kind: :object, name: "ModuleCapabilities"
+
+  alias Dagger.Core.Client
+  alias Dagger.Core.QueryBuilder, as: QB
+
+  @derive Dagger.ID
+
+  defstruct [:query_builder, :client]
+
+  @type t() :: %__MODULE__{}
+
+  @doc """
+  Whether the module may run commands with access to the Dagger API.
+  """
+  @spec exec(t()) :: {:ok, boolean()} | {:error, term()}
+  def exec(%__MODULE__{} = module_capabilities) do
+    query_builder =
+      module_capabilities.query_builder |> QB.select("exec")
+
+    Client.execute(module_capabilities.client, query_builder)
+  end
+
+  @doc """
+  Paths the module may read from the host through defaultPath arguments.
+  """
+  @spec host_read(t()) :: {:ok, [String.t()]} | {:error, term()}
+  def host_read(%__MODULE__{} = module_capabilities) do
+    query_builder =
+      module_capabilities.query_builder |> QB.select("hostRead")
+
+    Client.execute(module_capabilities.client, query_builder)
+  end
+
+  @doc """
+  Whether the module may use host unix sockets and tunnels.
+  """
+  @spec host_sockets(t()) :: {:ok, boolean()} | {:error, term()}
+  def host_sockets(%__MODULE__{} = module_capabilities) do
+    query_builder =
+      module_capabilities.query_builder |> QB.select("hostSockets")
+
+    Client.execute(module_capabilities.client, query_builder)
+  end
+
+  @doc """
+  A unique identifier for this ModuleCapabilities.
+  """
+  @spec id(t()) :: {:ok, Dagger.ModuleCapabilitiesID.t()} | {:error, term()}
+  def id(%__MODULE__{} = module_capabilities) do
+    query_builder =
+      module_capabilities.query_builder |> QB.select("id")
+
+    Client.execute(module_capabilities.client, query_builder)
+  end
+
+  @doc """
+  Whether the module may use LLMs.
+  """
+  @spec llm(t()) :: {:ok, boolean()} | {:error, term()}
+  def llm(%__MODULE__{} = module_capabilities) do
+    query_builder =
+      module_capabilities.query_builder |> QB.select("llm")
+
+    Client.execute(module_capabilities.client, query_builder)
+  end
+
+  @doc """
+  Whether the module may run commands and services with full network access, and download files with http. Image pulls and git fetches are done by the engine and aren't restricted.
+  """
+  @spec network(t()) :: {:ok, boolean()} | {:error, term()}
+  def network(%__MODULE__{} = module_capabilities) do
+    query_builder =
+      module_capabilities.query_builder |> QB.select("network")
+
+    Client.execute(module_capabilities.client, query_builder)
+  end
+
+  @doc """
+  Whether the module may load secrets from the host's secret providers.
+  """
+  @spec secrets(t()) :: {:ok, boolean()} | {:error, term()}
+  def secrets(%__MODULE__{} = module_capabilities) do
+    query_builder =
+      module_capabilities.query_builder |> QB.select("secrets")
+
+    Client.execute(module_capabilities.client, query_builder)
+  end
+end
+
+defimpl Jason.Encoder, for: Dagger.ModuleCapabilities do
+  def encode(module_capabilities, opts) do
+    {:ok, id} = Dagger.ModuleCapabilities.id(module_capabilities)
+    Jason.Encode.string(id, opts)
+  end
+end
+
+defimpl Nestru.Decoder, for: Dagger.ModuleCapabilities do
+  def decode_fields_hint(_struct, _context, id) do
+    {:ok, Dagger.Client.load_module_capabilities_from_id(Dagger.Global.dag(), id)}
+  end
+end
@@ -0,0 +1,10 @@
+# This file generated by `dagger_codegen`. Please DO NOT EDIT.
+defmodule Dagger.ModuleCapabilitiesID do
+  @moduledoc """
+  The `ModuleCapabilitiesID` scalar type represents an identifier for an object of type ModuleCapabilities.
+  """
+
+  use Dagger.Core.Base, kind: :scalar, name: "ModuleCapabilitiesID"
+
+  @type t() :: String.t()
+end
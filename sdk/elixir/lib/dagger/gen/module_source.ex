@@ -54,6 +54,20 @@ defmodule Dagger.ModuleSource do
     }
   end
 
+  @doc """
+  The capabilities the module requires, as declared in its dagger.json. Null if the module declares none, in which case it requires all capabilities.
+  """
+  @spec capabilities(t()) :: Dagger.ModuleCapabilities.t() | nil
+  def capabilities(%__MODULE__{} = module_source) do
+    query_builder =
+      module_source.query_builder |> QB.select("capabilities")
+
+    %Dagger.ModuleCapabilities{
+      query_builder: query_builder,
+      client: module_source.client
+    }
+  end
+
   @doc """
   The ref to clone the root of the git repo from. Only valid for git sources.
   """
@@ -195,6 +209,20 @@ defmodule Dagger.ModuleSource do
     }
   end
 
+  @doc """
+  The capabilities granted to the module when it was installed as a dependency. Null if none were recorded, in which case it is only restricted by the capabilities it declares.
+  """
+  @spec granted_capabilities(t()) :: Dagger.ModuleCapabilities.t() | nil
+  def granted_capabilities(%__MODULE__{} = module_source) do
+    query_builder =
+      module_source.query_builder |> QB.select("grantedCapabilities")
+
+    %Dagger.ModuleCapabilities{
+      query_builder: query_builder,
+      client: module_source.client
+    }
+  end
+
   @doc """
   The URL to access the web view of the repository (e.g., GitHub, GitLab, Bitbucket).
   """
@@ -511,6 +539,34 @@ defmodule Dagger.ModuleSource do
     }
   end
 
+  @doc """
+  Record the capabilities granted to the module as a dependency. Recorded in the parent module's dagger.json when installed.
+  """
+  @spec with_granted_capabilities(t(), [
+          {:host_read, [String.t()]},
+          {:host_sockets, boolean() | nil},
+          {:network, boolean() | nil},
+          {:llm, boolean() | nil},
+          {:exec, boolean() | nil},
+          {:secrets, boolean() | nil}
+        ]) :: Dagger.ModuleSource.t()
+  def with_granted_capabilities(%__MODULE__{} = module_source, optional_args \\ []) do
+    query_builder =
+      module_source.query_builder
+      |> QB.select("withGrantedCapabilities")
+      |> QB.maybe_put_arg("hostRead", optional_args[:host_read])
+      |> QB.maybe_put_arg("hostSockets", optional_args[:host_sockets])
+      |> QB.maybe_put_arg("network", optional_args[:network])
+      |> QB.maybe_put_arg("llm", optional_args[:llm])
+      |> QB.maybe_put_arg("exec", optional_args[:exec])
+      |> QB.maybe_put_arg("secrets", optional_args[:secrets])
+
+    %Dagger.ModuleSource{
+      query_builder: query_builder,
+      client: module_source.client
+    }
+  end
+
   @doc """
   Update the module source with additional include patterns for files+directories from its context that are required for building it
   """
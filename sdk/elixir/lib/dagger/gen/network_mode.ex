@@ -0,0 +1,36 @@
+# This file generated by `dagger_codegen`. Please DO NOT EDIT.
+defmodule Dagger.NetworkMode do
+  @moduledoc """
+  Network access granted to an execution
+  """
+
+  use Dagger.Core.Base, kind: :enum, name: "NetworkMode"
+
+  @type t() :: :FULL | :SERVICES_ONLY | :NONE
+
+  @doc """
+  Unrestricted network access
+  """
+  @spec full() :: :FULL
+  def full(), do: :FULL
+
+  @doc """
+  Network access to services only, e.g. those bound with "withServiceBinding"
+  """
+  @spec services_only() :: :SERVICES_ONLY
+  def services_only(), do: :SERVICES_ONLY
+
+  @doc """
+  No network access, apart from loopback
+  """
+  @spec none() :: :NONE
+  def none(), do: :NONE
+
+  @doc false
+  @spec from_string(String.t()) :: t()
+  def from_string(string)
+
+  def from_string("FULL"), do: :FULL
+  def from_string("SERVICES_ONLY"), do: :SERVICES_ONLY
+  def from_string("NONE"), do: :NONE
+end
@@ -0,0 +1,29 @@
+# This file generated by `dagger_codegen`. Please DO NOT EDIT.
+defmodule Dagger.SBOMFormat do
+  @moduledoc """
+  Format of a software bill of materials
+  """
+
+  use Dagger.Core.Base, kind: :enum, name: "SBOMFormat"
+
+  @type t() :: :SPDX_JSON | :CYCLONEDX_JSON
+
+  @doc """
+  SPDX 2.3 JSON
+  """
+  @spec spdx_json() :: :SPDX_JSON
+  def spdx_json(), do: :SPDX_JSON
+
+  @doc """
+  CycloneDX 1.5 JSON
+  """
+  @spec cyclonedx_json() :: :CYCLONEDX_JSON
+  def cyclonedx_json(), do: :CYCLONEDX_JSON
+
+  @doc false
+  @spec from_string(String.t()) :: t()
+  def from_string(string)
+
+  def from_string("SPDX_JSON"), do: :SPDX_JSON
+  def from_string("CYCLONEDX_JSON"), do: :CYCLONEDX_JSON
+end
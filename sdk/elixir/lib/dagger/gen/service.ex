@@ -56,6 +56,17 @@ defmodule Dagger.Service do
     Client.execute(service.client, query_builder)
   end
 
+  @doc """
+  The name of the service, if set with withName.
+  """
+  @spec name(t()) :: {:ok, String.t()} | {:error, term()}
+  def name(%__MODULE__{} = service) do
+    query_builder =
+      service.query_builder |> QB.select("name")
+
+    Client.execute(service.client, query_builder)
+  end
+
   @doc """
   Retrieves the list of ports provided by the service.
   """
@@ -83,10 +94,14 @@ defmodule Dagger.Service do
 
   Services bound to a Container do not need to be manually started.
   """
-  @spec start(t()) :: {:ok, Dagger.Service.t()} | {:error, term()}
-  def start(%__MODULE__{} = service) do
+  @spec start(t(), [{:persistent, boolean() | nil}, {:idle_timeout, String.t() | nil}]) ::
+          {:ok, Dagger.Service.t()} | {:error, term()}
+  def start(%__MODULE__{} = service, optional_args \\ []) do
     query_builder =
-      service.query_builder |> QB.select("start")
+      service.query_builder
+      |> QB.select("start")
+      |> QB.maybe_put_arg("persistent", optional_args[:persistent])
+      |> QB.maybe_put_arg("idleTimeout", optional_args[:idle_timeout])
 
     with {:ok, id} <- Client.execute(service.client, query_builder) do
       {:ok,
@@ -102,6 +117,8 @@ defmodule Dagger.Service do
 
   @doc """
   Stop the service.
+
+  A persistent service can only be stopped by the main client.
   """
   @spec stop(t(), [{:kill, boolean() | nil}]) :: {:ok, Dagger.Service.t()} | {:error, term()}
   def stop(%__MODULE__{} = service, optional_args \\ []) do
@@ -184,6 +201,20 @@ defmodule Dagger.Service do
       client: service.client
     }
   end
+
+  @doc """
+  Configures a name for the service, under which it can be started as a persistent service shared across sessions.
+  """
+  @spec with_name(t(), String.t()) :: Dagger.Service.t()
+  def with_name(%__MODULE__{} = service, name) do
+    query_builder =
+      service.query_builder |> QB.select("withName") |> QB.put_arg("name", name)
+
+    %Dagger.Service{
+      query_builder: query_builder,
+      client: service.client
+    }
+  end
 end
 
 defimpl Jason.Encoder, for: Dagger.Service do
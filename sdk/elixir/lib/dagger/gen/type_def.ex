@@ -99,6 +99,20 @@ defmodule Dagger.TypeDef do
     }
   end
 
+  @doc """
+  If kind is UNION, the union-specific type definition. If kind is not UNION, this will be null.
+  """
+  @spec as_union(t()) :: Dagger.UnionTypeDef.t() | nil
+  def as_union(%__MODULE__{} = type_def) do
+    query_builder =
+      type_def.query_builder |> QB.select("asUnion")
+
+    %Dagger.UnionTypeDef{
+      query_builder: query_builder,
+      client: type_def.client
+    }
+  end
+
   @doc """
   A unique identifier for this TypeDef.
   """
@@ -371,6 +385,45 @@ defmodule Dagger.TypeDef do
       client: type_def.client
     }
   end
+
+  @doc """
+  Returns a TypeDef of kind Union with the provided name.
+
+  Note that a union's members may be omitted if the intent is only to refer to a union.
+  """
+  @spec with_union(t(), String.t(), [
+          {:description, String.t() | nil},
+          {:source_map, Dagger.SourceMapID.t() | nil}
+        ]) :: Dagger.TypeDef.t()
+  def with_union(%__MODULE__{} = type_def, name, optional_args \\ []) do
+    query_builder =
+      type_def.query_builder
+      |> QB.select("withUnion")
+      |> QB.put_arg("name", name)
+      |> QB.maybe_put_arg("description", optional_args[:description])
+      |> QB.maybe_put_arg("sourceMap", optional_args[:source_map])
+
+    %Dagger.TypeDef{
+      query_builder: query_builder,
+      client: type_def.client
+    }
+  end
+
+  @doc """
+  Adds a member type to a Union TypeDef, failing if the type is not a union or the member is not an object.
+  """
+  @spec with_union_member(t(), Dagger.TypeDef.t()) :: Dagger.TypeDef.t()
+  def with_union_member(%__MODULE__{} = type_def, member) do
+    query_builder =
+      type_def.query_builder
+      |> QB.select("withUnionMember")
+      |> QB.put_arg("member", Dagger.ID.id!(member))
+
+    %Dagger.TypeDef{
+      query_builder: query_builder,
+      client: type_def.client
+    }
+  end
 end
 
 defimpl Jason.Encoder, for: Dagger.TypeDef do
@@ -18,6 +18,7 @@ defmodule Dagger.TypeDefKind do
           | :INPUT_KIND
           | :VOID_KIND
           | :ENUM_KIND
+          | :UNION_KIND
           | :STRING
           | :INTEGER
           | :FLOAT
@@ -106,6 +107,14 @@ defmodule Dagger.TypeDefKind do
   @spec enum_kind() :: :ENUM_KIND
   def enum_kind(), do: :ENUM_KIND
 
+  @doc """
+  Always paired with a UnionTypeDef.
+
+  A named type whose values have exactly one of a set of object types.
+  """
+  @spec union_kind() :: :UNION_KIND
+  def union_kind(), do: :UNION_KIND
+
   @doc """
   A string value.
   """
@@ -197,6 +206,7 @@ defmodule Dagger.TypeDefKind do
   def from_string("INPUT_KIND"), do: :INPUT_KIND
   def from_string("VOID_KIND"), do: :VOID_KIND
   def from_string("ENUM_KIND"), do: :ENUM_KIND
+  def from_string("UNION_KIND"), do: :UNION_KIND
   def from_string("STRING"), do: :STRING
   def from_string("INTEGER"), do: :INTEGER
   def from_string("FLOAT"), do: :FLOAT
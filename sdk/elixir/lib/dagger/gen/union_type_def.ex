@@ -0,0 +1,112 @@
+# This file generated by `dagger_codegen`. Please DO NOT EDIT.
+defmodule Dagger.UnionTypeDef do
+  @moduledoc """
+  A definition of a custom union defined in a Module.
+
+  A value of a union has exactly one of the union's member types.
+  """
+
+  use Dagger.Core.Base, kind: :object, name: "UnionTypeDef"
+
+  alias Dagger.Core.Client
+  alias Dagger.Core.QueryBuilder, as: QB
+
+  @derive Dagger.ID
+
+  defstruct [:query_builder, :client]
+
+  @type t() :: %__MODULE__{}
+
+  @doc """
+  The doc string for the union, if any.
+  """
+  @spec description(t()) :: {:ok, String.t()} | {:error, term()}
+  def description(%__MODULE__{} = union_type_def) do
+    query_builder =
+      union_type_def.query_builder |> QB.select("description")
+
+    Client.execute(union_type_def.client, query_builder)
+  end
+
+  @doc """
+  A unique identifier for this UnionTypeDef.
+  """
+  @spec id(t()) :: {:ok, Dagger.UnionTypeDefID.t()} | {:error, term()}
+  def id(%__MODULE__{} = union_type_def) do
+    query_builder =
+      union_type_def.query_builder |> QB.select("id")
+
+    Client.execute(union_type_def.client, query_builder)
+  end
+
+  @doc """
+  The types a value of this union can have. They are always objects.
+  """
+  @spec members(t()) :: {:ok, [Dagger.TypeDef.t()]} | {:error, term()}
+  def members(%__MODULE__{} = union_type_def) do
+    query_builder =
+      union_type_def.query_builder |> QB.select("members") |> QB.select("id")
+
+    with {:ok, items} <- Client.execute(union_type_def.client, query_builder) do
+      {:ok,
+       for %{"id" => id} <- items do
+         %Dagger.TypeDef{
+           query_builder:
+             QB.query()
+             |> QB.select("loadTypeDefFromID")
+             |> QB.put_arg("id", id),
+           client: union_type_def.client
+         }
+       end}
+    end
+  end
+
+  @doc """
+  The name of the union.
+  """
+  @spec name(t()) :: {:ok, String.t()} | {:error, term()}
+  def name(%__MODULE__{} = union_type_def) do
+    query_builder =
+      union_type_def.query_builder |> QB.select("name")
+
+    Client.execute(union_type_def.client, query_builder)
+  end
+
+  @doc """
+  The location of this union declaration.
+  """
+  @spec source_map(t()) :: Dagger.SourceMap.t() | nil
+  def source_map(%__MODULE__{} = union_type_def) do
+    query_builder =
+      union_type_def.query_builder |> QB.select("sourceMap")
+
+    %Dagger.SourceMap{
+      query_builder: query_builder,
+      client: union_type_def.client
+    }
+  end
+
+  @doc """
+  If this UnionTypeDef is associated with a Module, the name of the module. Unset otherwise.
+  """
+  @spec source_module_name(t()) :: {:ok, String.t()} | {:error, term()}
+  def source_module_name(%__MODULE__{} = union_type_def) do
+    query_builder =
+      union_type_def.query_builder |> QB.select("sourceModuleName")
+
+    Client.execute(union_type_def.client, query_builder)
+  end
+end
+
+defimpl Jason.Encoder, for: Dagger.UnionTypeDef do
+  def encode(union_type_def, opts) do
+    {:ok, id} = Dagger.UnionTypeDef.id(union_type_def)
+    Jason.Encode.string(id, opts)
+  end
+end
+
+defimpl Nestru.Decoder, for: Dagger.UnionTypeDef do
+  def decode_fields_hint(_struct, _context, id) do
+    {:ok, Dagger.Client.load_union_type_def_from_id(Dagger.Global.dag(), id)}
+  end
+end
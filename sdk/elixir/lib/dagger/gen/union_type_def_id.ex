@@ -0,0 +1,10 @@
+# This file generated by `dagger_codegen`. Please DO NOT EDIT.
+defmodule Dagger.UnionTypeDefID do
+  @moduledoc """
+  The `UnionTypeDefID` scalar type represents an identifier for an object of type UnionTypeDef.
+  """
+
+  use Dagger.Core.Base, kind: :scalar, name: "UnionTypeDefID"
+
+  @type t() :: String.t()
+end
@@ -234,12 +234,30 @@ func LoadCloudFromID(id dagger.CloudID) *dagger.Cloud {
 	return client.LoadCloudFromID(id)
 }
 
+// Load a ContainerComparison from its ID.
+func LoadContainerComparisonFromID(id dagger.ContainerComparisonID) *dagger.ContainerComparison {
+	client := initClient()
+	return client.LoadContainerComparisonFromID(id)
+}
+
+// Load a ContainerConfigChange from its ID.
+func LoadContainerConfigChangeFromID(id dagger.ContainerConfigChangeID) *dagger.ContainerConfigChange {
+	client := initClient()
+	return client.LoadContainerConfigChangeFromID(id)
+}
+
 // Load a Container from its ID.
 func LoadContainerFromID(id dagger.ContainerID) *dagger.Container {
 	client := initClient()
 	return client.LoadContainerFromID(id)
 }
 
+// Load a ContainerLayerComparison from its ID.
+func LoadContainerLayerComparisonFromID(id dagger.ContainerLayerComparisonID) *dagger.ContainerLayerComparison {
+	client := initClient()
+	return client.LoadContainerLayerComparisonFromID(id)
+}
+
 // Load a CurrentModule from its ID.
 func LoadCurrentModuleFromID(id dagger.CurrentModuleID) *dagger.CurrentModule {
 	client := initClient()
@@ -276,6 +294,18 @@ func LoadEngineFromID(id dagger.EngineID) *dagger.Engine {
 	return client.LoadEngineFromID(id)
 }
 
+// Load a EngineService from its ID.
+func LoadEngineServiceFromID(id dagger.EngineServiceID) *dagger.EngineService {
+	client := initClient()
+	return client.LoadEngineServiceFromID(id)
+}
+
+// Load a EngineSession from its ID.
+func LoadEngineSessionFromID(id dagger.EngineSessionID) *dagger.EngineSession {
+	client := initClient()
+	return client.LoadEngineSessionFromID(id)
+}
+
 // Load a EnumTypeDef from its ID.
 func LoadEnumTypeDefFromID(id dagger.EnumTypeDefID) *dagger.EnumTypeDef {
 	client := initClient()
@@ -330,6 +360,12 @@ func LoadFileFromID(id dagger.FileID) *dagger.File {
 	return client.LoadFileFromID(id)
 }
 
+// Load a FunctionArgConstraints from its ID.
+func LoadFunctionArgConstraintsFromID(id dagger.FunctionArgConstraintsID) *dagger.FunctionArgConstraints {
+	client := initClient()
+	return client.LoadFunctionArgConstraintsFromID(id)
+}
+
 // Load a FunctionArg from its ID.
 func LoadFunctionArgFromID(id dagger.FunctionArgID) *dagger.FunctionArg {
 	client := initClient()
@@ -420,6 +456,12 @@ func LoadListTypeDefFromID(id dagger.ListTypeDefID) *dagger.ListTypeDef {
 	return client.LoadListTypeDefFromID(id)
 }
 
+// Load a ModuleCapabilities from its ID.
+func LoadModuleCapabilitiesFromID(id dagger.ModuleCapabilitiesID) *dagger.ModuleCapabilities {
+	client := initClient()
+	return client.LoadModuleCapabilitiesFromID(id)
+}
+
 // Load a ModuleConfigClient from its ID.
 func LoadModuleConfigClientFromID(id dagger.ModuleConfigClientID) *dagger.ModuleConfigClient {
 	client := initClient()
@@ -516,6 +558,12 @@ func LoadTypeDefFromID(id dagger.TypeDefID) *dagger.TypeDef {
 	return client.LoadTypeDefFromID(id)
 }
 
+// Load a UnionTypeDef from its ID.
+func LoadUnionTypeDefFromID(id dagger.UnionTypeDefID) *dagger.UnionTypeDef {
+	client := initClient()
+	return client.LoadUnionTypeDefFromID(id)
+}
+
 // Create a new module.
 func Module() *dagger.Module {
 	client := initClient()
@@ -528,6 +576,14 @@ func ModuleSource(refString string, opts ...dagger.ModuleSourceOpts) *dagger.Mod
 	return client.ModuleSource(refString, opts...)
 }
 
+// Attach to a persistent service running in the engine under the given name.
+//
+// The service may have been started by another session, with `start(persistent: true)`. Only the main client can attach to it.
+func RunningService(name string) *dagger.Service {
+	client := initClient()
+	return client.RunningService(name)
+}
+
 // Creates a new secret.
 func Secret(uri string, opts ...dagger.SecretOpts) *dagger.Secret {
 	client := initClient()
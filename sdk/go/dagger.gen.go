@@ -151,9 +151,18 @@ type CheckID string
 // The `CloudID` scalar type represents an identifier for an object of type Cloud.
 type CloudID string
 
+// The `ContainerComparisonID` scalar type represents an identifier for an object of type ContainerComparison.
+type ContainerComparisonID string
+
+// The `ContainerConfigChangeID` scalar type represents an identifier for an object of type ContainerConfigChange.
+type ContainerConfigChangeID string
+
 // The `ContainerID` scalar type represents an identifier for an object of type Container.
 type ContainerID string
 
+// The `ContainerLayerComparisonID` scalar type represents an identifier for an object of type ContainerLayerComparison.
+type ContainerLayerComparisonID string
+
 // The `CurrentModuleID` scalar type represents an identifier for an object of type CurrentModule.
 type CurrentModuleID string
 
@@ -172,6 +181,12 @@ type EngineCacheID string
 // The `EngineID` scalar type represents an identifier for an object of type Engine.
 type EngineID string
 
+// The `EngineServiceID` scalar type represents an identifier for an object of type EngineService.
+type EngineServiceID string
+
+// The `EngineSessionID` scalar type represents an identifier for an object of type EngineSession.
+type EngineSessionID string
+
 // The `EnumTypeDefID` scalar type represents an identifier for an object of type EnumTypeDef.
 type EnumTypeDefID string
 
@@ -199,6 +214,9 @@ type FieldTypeDefID string
 // The `FileID` scalar type represents an identifier for an object of type File.
 type FileID string
 
+// The `FunctionArgConstraintsID` scalar type represents an identifier for an object of type FunctionArgConstraints.
+type FunctionArgConstraintsID string
+
 // The `FunctionArgID` scalar type represents an identifier for an object of type FunctionArg.
 type FunctionArgID string
 
@@ -247,6 +265,9 @@ type LabelID string
 // The `ListTypeDefID` scalar type represents an identifier for an object of type ListTypeDef.
 type ListTypeDefID string
 
+// The `ModuleCapabilitiesID` scalar type represents an identifier for an object of type ModuleCapabilities.
+type ModuleCapabilitiesID string
+
 // The `ModuleConfigClientID` scalar type represents an identifier for an object of type ModuleConfigClient.
 type ModuleConfigClientID string
 
@@ -300,6 +321,9 @@ type TerminalID string
 // The `TypeDefID` scalar type represents an identifier for an object of type TypeDef.
 type TypeDefID string
 
+// The `UnionTypeDefID` scalar type represents an identifier for an object of type UnionTypeDef.
+type UnionTypeDefID string
+
 // The absence of a value.
 //
 // A Null Void is used as a placeholder for resolvers that do not return anything.
@@ -612,6 +636,33 @@ func (r *Binding) AsContainer() *Container {
 	}
 }
 
+// Retrieve the binding value, as type ContainerComparison
+func (r *Binding) AsContainerComparison() *ContainerComparison {
+	q := r.query.Select("asContainerComparison")
+
+	return &ContainerComparison{
+		query: q,
+	}
+}
+
+// Retrieve the binding value, as type ContainerConfigChange
+func (r *Binding) AsContainerConfigChange() *ContainerConfigChange {
+	q := r.query.Select("asContainerConfigChange")
+
+	return &ContainerConfigChange{
+		query: q,
+	}
+}
+
+// Retrieve the binding value, as type ContainerLayerComparison
+func (r *Binding) AsContainerLayerComparison() *ContainerLayerComparison {
+	q := r.query.Select("asContainerLayerComparison")
+
+	return &ContainerLayerComparison{
+		query: q,
+	}
+}
+
 // Retrieve the binding value, as type Directory
 func (r *Binding) AsDirectory() *Directory {
 	q := r.query.Select("asDirectory")
@@ -621,6 +672,24 @@ func (r *Binding) AsDirectory() *Directory {
 	}
 }
 
+// Retrieve the binding value, as type EngineService
+func (r *Binding) AsEngineService() *EngineService {
+	q := r.query.Select("asEngineService")
+
+	return &EngineService{
+		query: q,
+	}
+}
+
+// Retrieve the binding value, as type EngineSession
+func (r *Binding) AsEngineSession() *EngineSession {
+	q := r.query.Select("asEngineSession")
+
+	return &EngineSession{
+		query: q,
+	}
+}
+
 // Retrieve the binding value, as type Env
 func (r *Binding) AsEnv() *Env {
 	q := r.query.Select("asEnv")
@@ -648,6 +717,15 @@ func (r *Binding) AsFile() *File {
 	}
 }
 
+// Retrieve the binding value, as type FunctionArgConstraints
+func (r *Binding) AsFunctionArgConstraints() *FunctionArgConstraints {
+	q := r.query.Select("asFunctionArgConstraints")
+
+	return &FunctionArgConstraints{
+		query: q,
+	}
+}
+
 // Retrieve the binding value, as type GitRef
 func (r *Binding) AsGitRef() *GitRef {
 	q := r.query.Select("asGitRef")
@@ -684,6 +762,15 @@ func (r *Binding) AsModule() *Module {
 	}
 }
 
+// Retrieve the binding value, as type ModuleCapabilities
+func (r *Binding) AsModuleCapabilities() *ModuleCapabilities {
+	q := r.query.Select("asModuleCapabilities")
+
+	return &ModuleCapabilities{
+		query: q,
+	}
+}
+
 // Retrieve the binding value, as type ModuleConfigClient
 func (r *Binding) AsModuleConfigClient() *ModuleConfigClient {
 	q := r.query.Select("asModuleConfigClient")
@@ -769,6 +856,15 @@ func (r *Binding) AsString(ctx context.Context) (string, error) {
 	return response, q.Execute(ctx)
 }
 
+// Retrieve the binding value, as type UnionTypeDef
+func (r *Binding) AsUnionTypeDef() *UnionTypeDef {
+	q := r.query.Select("asUnionTypeDef")
+
+	return &UnionTypeDef{
+		query: q,
+	}
+}
+
 // Returns the digest of the binding value
 func (r *Binding) Digest(ctx context.Context) (string, error) {
 	if r.digest != nil {
@@ -865,7 +961,9 @@ func (r *Binding) TypeName(ctx context.Context) (string, error) {
 type CacheVolume struct {
 	query *querybuilder.Selection
 
-	id *CacheVolumeID
+	id    *CacheVolumeID
+	pin   *Void
+	unpin *Void
 }
 
 func (r *CacheVolume) WithGraphQLQuery(q *querybuilder.Selection) *CacheVolume {
@@ -914,6 +1012,28 @@ func (r *CacheVolume) MarshalJSON() ([]byte, error) {
 	return json.Marshal(id)
 }
 
+// Pin the contents of this cache volume, so that they're never pruned by garbage collection.
+//
+// Contents written to the volume later are pinned too, until it's unpinned. Only the main client can pin a cache volume.
+func (r *CacheVolume) Pin(ctx context.Context) error {
+	if r.pin != nil {
+		return nil
+	}
+	q := r.query.Select("pin")
+
+	return q.Execute(ctx)
+}
+
+// Unpin the contents of this cache volume, so that they can be pruned by garbage collection again.
+func (r *CacheVolume) Unpin(ctx context.Context) error {
+	if r.unpin != nil {
+		return nil
+	}
+	q := r.query.Select("unpin")
+
+	return q.Execute(ctx)
+}
+
 // A comparison between two directories representing changes that can be applied.
 type Changeset struct {
 	query *querybuilder.Selection
@@ -923,6 +1043,14 @@ type Changeset struct {
 	isEmpty *bool
 	sync    *ChangesetID
 }
+type WithChangesetFunc func(r *Changeset) *Changeset
+
+// With calls the provided function with current Changeset.
+//
+// This is useful for reusability and readability by not breaking the calling chain.
+func (r *Changeset) With(f WithChangesetFunc) *Changeset {
+	return f(r)
+}
 
 func (r *Changeset) WithGraphQLQuery(q *querybuilder.Selection) *Changeset {
 	return &Changeset{
@@ -1076,6 +1204,19 @@ func (r *Changeset) Sync(ctx context.Context) (*Changeset, error) {
 	}, nil
 }
 
+// Add changes to this changeset, if they don't conflict with it.
+//
+// Both changesets must compare against the same directory. The changes conflict if a file is changed by both, or changed by one and removed by the other.
+func (r *Changeset) WithChangeset(changes *Changeset) *Changeset {
+	assertNotNil("changes", changes)
+	q := r.query.Select("withChangeset")
+	q = q.Arg("changes", changes)
+
+	return &Changeset{
+		query: q,
+	}
+}
+
 type Check struct {
 	query *querybuilder.Selection
 
@@ -1458,6 +1599,16 @@ type ContainerAsServiceOpts struct {
 	//
 	// This should only be used if the user requires that their exec process be the pid 1 process in the container. Otherwise it may result in unexpected behavior.
 	NoInit bool
+	// Restrict the network access of the service.
+	//
+	// Blocked connection attempts are reported as events on the service's span.
+	//
+	// Default: FULL
+	NetworkMode NetworkMode
+	// Hostnames and CIDRs the service may connect to, on top of what its network mode allows (e.g., ["proxy.golang.org", "10.0.0.0/8"]).
+	//
+	// Hostnames are resolved when the service starts. Requires a networkMode of NONE or SERVICES_ONLY.
+	EgressAllowlist []string
 }
 
 // Turn the container into a Service.
@@ -1490,6 +1641,14 @@ func (r *Container) AsService(opts ...ContainerAsServiceOpts) *Service {
 		if !querybuilder.IsZeroValue(opts[i].NoInit) {
 			q = q.Arg("noInit", opts[i].NoInit)
 		}
+		// `networkMode` optional argument
+		if !querybuilder.IsZeroValue(opts[i].NetworkMode) {
+			q = q.Arg("networkMode", opts[i].NetworkMode)
+		}
+		// `egressAllowlist` optional argument
+		if !querybuilder.IsZeroValue(opts[i].EgressAllowlist) {
+			q = q.Arg("egressAllowlist", opts[i].EgressAllowlist)
+		}
 	}
 
 	return &Service{
@@ -1553,6 +1712,19 @@ func (r *Container) CombinedOutput(ctx context.Context) (string, error) {
 	return response, q.Execute(ctx)
 }
 
+// Compare this container's image configuration, layers and root filesystem with another container, typically a newer version of it.
+//
+// Layers that haven't been exported yet are compressed to compute their digest and size.
+func (r *Container) Compare(other *Container) *ContainerComparison {
+	assertNotNil("other", other)
+	q := r.query.Select("compare")
+	q = q.Arg("other", other)
+
+	return &ContainerComparison{
+		query: q,
+	}
+}
+
 // Return the container's default arguments.
 func (r *Container) DefaultArgs(ctx context.Context) ([]string, error) {
 	q := r.query.Select("defaultArgs")
@@ -1736,6 +1908,20 @@ type ContainerExportOpts struct {
 	MediaTypes ImageMediaTypes
 	// Replace "${VAR}" or "$VAR" in the value of path according to the current environment variables defined in the container (e.g. "/$VAR/foo").
 	Expand bool
+	// Private key to sign the image with, in PEM format.
+	//
+	// Both keys generated by "cosign generate-key-pair" and unencrypted PKCS#8, EC and RSA keys are supported. The signature, and the provenance attestation if requested, are attached to the image as OCI referrers in the sigstore bundle format.
+	SigningKey *Secret
+	// Password of the signing key, if it's encrypted.
+	SigningKeyPassword *Secret
+	// Attach a SLSA provenance attestation describing the calls that built the image.
+	Provenance bool
+	// Attach a software bill of materials (SBOM) attestation in the given format, as returned by "sbom".
+	Sbom SBOMFormat
+	// Squash the layers of the exported image.
+	//
+	// By default, the changes between each layer boundary set with "withLayerBoundary" are grouped into a single layer, and each operation makes its own layer if there are no boundaries.
+	Squash ImageSquashMode
 }
 
 // Writes the container as an OCI tarball to the destination file path on the host.
@@ -1763,6 +1949,26 @@ func (r *Container) Export(ctx context.Context, path string, opts ...ContainerEx
 		if !querybuilder.IsZeroValue(opts[i].Expand) {
 			q = q.Arg("expand", opts[i].Expand)
 		}
+		// `signingKey` optional argument
+		if !querybuilder.IsZeroValue(opts[i].SigningKey) {
+			q = q.Arg("signingKey", opts[i].SigningKey)
+		}
+		// `signingKeyPassword` optional argument
+		if !querybuilder.IsZeroValue(opts[i].SigningKeyPassword) {
+			q = q.Arg("signingKeyPassword", opts[i].SigningKeyPassword)
+		}
+		// `provenance` optional argument
+		if !querybuilder.IsZeroValue(opts[i].Provenance) {
+			q = q.Arg("provenance", opts[i].Provenance)
+		}
+		// `sbom` optional argument
+		if !querybuilder.IsZeroValue(opts[i].Sbom) {
+			q = q.Arg("sbom", opts[i].Sbom)
+		}
+		// `squash` optional argument
+		if !querybuilder.IsZeroValue(opts[i].Squash) {
+			q = q.Arg("squash", opts[i].Squash)
+		}
 	}
 	q = q.Arg("path", path)
 
@@ -2046,6 +2252,20 @@ type ContainerPublishOpts struct {
 	//
 	// Default: OCIMediaTypes
 	MediaTypes ImageMediaTypes
+	// Private key to sign the image with, in PEM format.
+	//
+	// Both keys generated by "cosign generate-key-pair" and unencrypted PKCS#8, EC and RSA keys are supported. The signature, and the provenance attestation if requested, are attached to the image as OCI referrers in the sigstore bundle format.
+	SigningKey *Secret
+	// Password of the signing key, if it's encrypted.
+	SigningKeyPassword *Secret
+	// Attach a SLSA provenance attestation describing the calls that built the image.
+	Provenance bool
+	// Attach a software bill of materials (SBOM) attestation in the given format, as returned by "sbom".
+	Sbom SBOMFormat
+	// Squash the layers of the published image.
+	//
+	// By default, the changes between each layer boundary set with "withLayerBoundary" are grouped into a single layer, and each operation makes its own layer if there are no boundaries.
+	Squash ImageSquashMode
 }
 
 // Package the container state as an OCI image, and publish it to a registry
@@ -2069,6 +2289,26 @@ func (r *Container) Publish(ctx context.Context, address string, opts ...Contain
 		if !querybuilder.IsZeroValue(opts[i].MediaTypes) {
 			q = q.Arg("mediaTypes", opts[i].MediaTypes)
 		}
+		// `signingKey` optional argument
+		if !querybuilder.IsZeroValue(opts[i].SigningKey) {
+			q = q.Arg("signingKey", opts[i].SigningKey)
+		}
+		// `signingKeyPassword` optional argument
+		if !querybuilder.IsZeroValue(opts[i].SigningKeyPassword) {
+			q = q.Arg("signingKeyPassword", opts[i].SigningKeyPassword)
+		}
+		// `provenance` optional argument
+		if !querybuilder.IsZeroValue(opts[i].Provenance) {
+			q = q.Arg("provenance", opts[i].Provenance)
+		}
+		// `sbom` optional argument
+		if !querybuilder.IsZeroValue(opts[i].Sbom) {
+			q = q.Arg("sbom", opts[i].Sbom)
+		}
+		// `squash` optional argument
+		if !querybuilder.IsZeroValue(opts[i].Squash) {
+			q = q.Arg("squash", opts[i].Squash)
+		}
 	}
 	q = q.Arg("address", address)
 
@@ -2087,6 +2327,31 @@ func (r *Container) Rootfs() *Directory {
 	}
 }
 
+// ContainerSbomOpts contains options for Container.Sbom
+type ContainerSbomOpts struct {
+	// Format of the SBOM.
+	//
+	// Default: SPDX_JSON
+	Format SBOMFormat
+}
+
+// Return a software bill of materials (SBOM) of the packages in the container's root filesystem.
+//
+// OS packages installed with apk, dpkg or rpm are detected, as well as the dependencies in Go, npm, Python and Cargo lockfiles. Mounts are not scanned.
+func (r *Container) Sbom(opts ...ContainerSbomOpts) *File {
+	q := r.query.Select("sbom")
+	for i := len(opts) - 1; i >= 0; i-- {
+		// `format` optional argument
+		if !querybuilder.IsZeroValue(opts[i].Format) {
+			q = q.Arg("format", opts[i].Format)
+		}
+	}
+
+	return &File{
+		query: q,
+	}
+}
+
 // ContainerStatOpts contains options for Container.Stat
 type ContainerStatOpts struct {
 	// If specified, do not follow symlinks.
@@ -2211,6 +2476,16 @@ type ContainerUpOpts struct {
 	//
 	// This should only be used if the user requires that their exec process be the pid 1 process in the container. Otherwise it may result in unexpected behavior.
 	NoInit bool
+	// Restrict the network access of the service.
+	//
+	// Blocked connection attempts are reported as events on the service's span.
+	//
+	// Default: FULL
+	NetworkMode NetworkMode
+	// Hostnames and CIDRs the service may connect to, on top of what its network mode allows (e.g., ["proxy.golang.org", "10.0.0.0/8"]).
+	//
+	// Hostnames are resolved when the service starts. Requires a networkMode of NONE or SERVICES_ONLY.
+	EgressAllowlist []string
 }
 
 // Starts a Service and creates a tunnel that forwards traffic from the caller's network to that service.
@@ -2254,6 +2529,14 @@ func (r *Container) Up(ctx context.Context, opts ...ContainerUpOpts) error {
 		if !querybuilder.IsZeroValue(opts[i].NoInit) {
 			q = q.Arg("noInit", opts[i].NoInit)
 		}
+		// `networkMode` optional argument
+		if !querybuilder.IsZeroValue(opts[i].NetworkMode) {
+			q = q.Arg("networkMode", opts[i].NetworkMode)
+		}
+		// `egressAllowlist` optional argument
+		if !querybuilder.IsZeroValue(opts[i].EgressAllowlist) {
+			q = q.Arg("egressAllowlist", opts[i].EgressAllowlist)
+		}
 	}
 
 	return q.Execute(ctx)
@@ -2283,6 +2566,17 @@ func (r *Container) WithAnnotation(name string, value string) *Container {
 	}
 }
 
+// Pauses the pipeline when this container is evaluated, opening an interactive terminal for it.
+//
+// The pipeline continues when the terminal exits. Breakpoints only pause when the CLI runs with --interactive or --break-on, so they can be left in code, and they don't affect the cache of the pipeline.
+func (r *Container) WithBreakpoint() *Container {
+	q := r.query.Select("withBreakpoint")
+
+	return &Container{
+		query: q,
+	}
+}
+
 // Configures default arguments for future commands. Like CMD in Dockerfile.
 func (r *Container) WithDefaultArgs(args []string) *Container {
 	q := r.query.Select("withDefaultArgs")
@@ -2467,6 +2761,16 @@ type ContainerWithExecOpts struct {
 	//
 	// Only use this if you specifically need the command to be pid 1 in the container. Otherwise it may result in unexpected behavior. If you're not sure, you don't need this.
 	NoInit bool
+	// Restrict the network access of the command.
+	//
+	// Blocked connection attempts are reported as events on the command's span.
+	//
+	// Default: FULL
+	NetworkMode NetworkMode
+	// Hostnames and CIDRs the command may connect to, on top of what its network mode allows (e.g., ["proxy.golang.org", "10.0.0.0/8"]).
+	//
+	// Hostnames are resolved when the command starts. Requires a networkMode of NONE or SERVICES_ONLY.
+	EgressAllowlist []string
 }
 
 // Execute a command in the container, and return a new snapshot of the container state after execution.
@@ -2513,6 +2817,14 @@ func (r *Container) WithExec(args []string, opts ...ContainerWithExecOpts) *Cont
 		if !querybuilder.IsZeroValue(opts[i].NoInit) {
 			q = q.Arg("noInit", opts[i].NoInit)
 		}
+		// `networkMode` optional argument
+		if !querybuilder.IsZeroValue(opts[i].NetworkMode) {
+			q = q.Arg("networkMode", opts[i].NetworkMode)
+		}
+		// `egressAllowlist` optional argument
+		if !querybuilder.IsZeroValue(opts[i].EgressAllowlist) {
+			q = q.Arg("egressAllowlist", opts[i].EgressAllowlist)
+		}
 	}
 	q = q.Arg("args", args)
 
@@ -2653,6 +2965,17 @@ func (r *Container) WithLabel(name string, value string) *Container {
 	}
 }
 
+// Retrieves this container with a layer boundary at the current state of its root filesystem.
+//
+// When the container is published or exported, the changes between consecutive boundaries are grouped into a single layer, on top of the layers of the image the container was created from. Changes after the last boundary make a layer of their own.
+func (r *Container) WithLayerBoundary() *Container {
+	q := r.query.Select("withLayerBoundary")
+
+	return &Container{
+		query: q,
+	}
+}
+
 // ContainerWithMountedCacheOpts contains options for Container.WithMountedCache
 type ContainerWithMountedCacheOpts struct {
 	// Identifier of the directory to use as the cache volume's root.
@@ -3276,42 +3599,69 @@ func (r *Container) Workdir(ctx context.Context) (string, error) {
 	return response, q.Execute(ctx)
 }
 
-// Reflective module API provided to functions at runtime.
-type CurrentModule struct {
+// A comparison between the image configuration, layers and root filesystem of two containers.
+type ContainerComparison struct {
 	query *querybuilder.Selection
 
-	id   *CurrentModuleID
-	name *string
+	id      *ContainerComparisonID
+	summary *string
 }
 
-func (r *CurrentModule) WithGraphQLQuery(q *querybuilder.Selection) *CurrentModule {
-	return &CurrentModule{
+func (r *ContainerComparison) WithGraphQLQuery(q *querybuilder.Selection) *ContainerComparison {
+	return &ContainerComparison{
 		query: q,
 	}
 }
 
-// The dependencies of the module.
-func (r *CurrentModule) Dependencies(ctx context.Context) ([]Module, error) {
-	q := r.query.Select("dependencies")
+// The newer container.
+func (r *ContainerComparison) After() *Container {
+	q := r.query.Select("after")
+
+	return &Container{
+		query: q,
+	}
+}
+
+// The older container to compare against.
+func (r *ContainerComparison) Before() *Container {
+	q := r.query.Select("before")
+
+	return &Container{
+		query: q,
+	}
+}
+
+// The changes between the root filesystems of the containers.
+func (r *ContainerComparison) Changes() *Changeset {
+	q := r.query.Select("changes")
+
+	return &Changeset{
+		query: q,
+	}
+}
+
+// Changes to the image configuration, e.g. environment variables, entrypoint, user, labels and exposed ports.
+func (r *ContainerComparison) ConfigChanges(ctx context.Context) ([]ContainerConfigChange, error) {
+	q := r.query.Select("configChanges")
 
 	q = q.Select("id")
 
-	type dependencies struct {
-		Id ModuleID
+	type configChanges struct {
+		Id ContainerConfigChangeID
 	}
 
-	convert := func(fields []dependencies) []Module {
-		out := []Module{}
+	convert := func(fields []configChanges) []ContainerConfigChange {
+		out := []ContainerConfigChange{}
 
 		for i := range fields {
-			val := Module{id: &fields[i].Id}
-			val.query = q.Root().Select("loadModuleFromID").Arg("id", fields[i].Id)
+			val := ContainerConfigChange{id: &fields[i].Id}
+			val.query = q.Root().Select("loadContainerConfigChangeFromID").Arg("id", fields[i].Id)
 			out = append(out, val)
 		}
 
 		return out
 	}
-	var response []dependencies
+	var response []configChanges
 
 	q = q.Bind(&response)
 
@@ -3323,40 +3673,31 @@ func (r *CurrentModule) Dependencies(ctx context.Context) ([]Module, error) {
 	return convert(response), nil
 }
 
-// The generated files and directories made on top of the module source's context directory.
-func (r *CurrentModule) GeneratedContextDirectory() *Directory {
-	q := r.query.Select("generatedContextDirectory")
-
-	return &Directory{
-		query: q,
-	}
-}
-
-// A unique identifier for this CurrentModule.
-func (r *CurrentModule) ID(ctx context.Context) (CurrentModuleID, error) {
+// A unique identifier for this ContainerComparison.
+func (r *ContainerComparison) ID(ctx context.Context) (ContainerComparisonID, error) {
 	if r.id != nil {
 		return *r.id, nil
 	}
 	q := r.query.Select("id")
 
-	var response CurrentModuleID
+	var response ContainerComparisonID
 
 	q = q.Bind(&response)
 	return response, q.Execute(ctx)
 }
 
 // XXX_GraphQLType is an internal function. It returns the native GraphQL type name
-func (r *CurrentModule) XXX_GraphQLType() string {
-	return "CurrentModule"
+func (r *ContainerComparison) XXX_GraphQLType() string {
+	return "ContainerComparison"
 }
 
 // XXX_GraphQLIDType is an internal function. It returns the native GraphQL type name for the ID of this object
-func (r *CurrentModule) XXX_GraphQLIDType() string {
-	return "CurrentModuleID"
+func (r *ContainerComparison) XXX_GraphQLIDType() string {
+	return "ContainerComparisonID"
 }
 
 // XXX_GraphQLID is an internal function. It returns the underlying type ID
-func (r *CurrentModule) XXX_GraphQLID(ctx context.Context) (string, error) {
+func (r *ContainerComparison) XXX_GraphQLID(ctx context.Context) (string, error) {
 	id, err := r.ID(ctx)
 	if err != nil {
 		return "", err
@@ -3364,7 +3705,7 @@ func (r *CurrentModule) XXX_GraphQLID(ctx context.Context) (string, error) {
 	return string(id), nil
 }
 
-func (r *CurrentModule) MarshalJSON() ([]byte, error) {
+func (r *ContainerComparison) MarshalJSON() ([]byte, error) {
 	id, err := r.ID(marshalCtx)
 	if err != nil {
 		return nil, err
@@ -3372,12 +3713,45 @@ func (r *CurrentModule) MarshalJSON() ([]byte, error) {
 	return json.Marshal(id)
 }
 
-// The name of the module being executed in
-func (r *CurrentModule) Name(ctx context.Context) (string, error) {
-	if r.name != nil {
-		return *r.name, nil
+// Comparison of the layers of the root filesystems, by position from the base layer.
+func (r *ContainerComparison) Layers(ctx context.Context) ([]ContainerLayerComparison, error) {
+	q := r.query.Select("layers")
+
+	q = q.Select("id")
+
+	type layers struct {
+		Id ContainerLayerComparisonID
 	}
-	q := r.query.Select("name")
+
+	convert := func(fields []layers) []ContainerLayerComparison {
+		out := []ContainerLayerComparison{}
+
+		for i := range fields {
+			val := ContainerLayerComparison{id: &fields[i].Id}
+			val.query = q.Root().Select("loadContainerLayerComparisonFromID").Arg("id", fields[i].Id)
+			out = append(out, val)
+		}
+
+		return out
+	}
+	var response []layers
+
+	q = q.Bind(&response)
+
+	err := q.Execute(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return convert(response), nil
+}
+
+// A human-readable summary of the comparison.
+func (r *ContainerComparison) Summary(ctx context.Context) (string, error) {
+	if r.summary != nil {
+		return *r.summary, nil
+	}
+	q := r.query.Select("summary")
 
 	var response string
 
@@ -3385,35 +3759,404 @@ func (r *CurrentModule) Name(ctx context.Context) (string, error) {
 	return response, q.Execute(ctx)
 }
 
-// The directory containing the module's source code loaded into the engine (plus any generated code that may have been created).
-func (r *CurrentModule) Source() *Directory {
-	q := r.query.Select("source")
+// A change to the image configuration between two containers.
+type ContainerConfigChange struct {
+	query *querybuilder.Selection
 
-	return &Directory{
+	after  *string
+	before *string
+	field  *string
+	id     *ContainerConfigChangeID
+	key    *string
+	kind   *ContainerChangeKind
+}
+
+func (r *ContainerConfigChange) WithGraphQLQuery(q *querybuilder.Selection) *ContainerConfigChange {
+	return &ContainerConfigChange{
 		query: q,
 	}
 }
 
-// CurrentModuleWorkdirOpts contains options for CurrentModule.Workdir
-type CurrentModuleWorkdirOpts struct {
-	// Exclude artifacts that match the given pattern (e.g., ["node_modules/", ".git*"]).
-	Exclude []string
-	// Include only artifacts that match the given pattern (e.g., ["app/", "package.*"]).
-	Include []string
-	// Apply .gitignore filter rules inside the directory
-	Gitignore bool
+// The value in the newer container. Lists are encoded as JSON arrays.
+func (r *ContainerConfigChange) After(ctx context.Context) (string, error) {
+	if r.after != nil {
+		return *r.after, nil
+	}
+	q := r.query.Select("after")
+
+	var response string
+
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
 }
 
-// Load a directory from the module's scratch working directory, including any changes that may have been made to it during module function execution.
-func (r *CurrentModule) Workdir(path string, opts ...CurrentModuleWorkdirOpts) *Directory {
-	q := r.query.Select("workdir")
-	for i := len(opts) - 1; i >= 0; i-- {
-		// `exclude` optional argument
-		if !querybuilder.IsZeroValue(opts[i].Exclude) {
-			q = q.Arg("exclude", opts[i].Exclude)
-		}
-		// `include` optional argument
-		if !querybuilder.IsZeroValue(opts[i].Include) {
+// The value in the older container. Lists are encoded as JSON arrays.
+func (r *ContainerConfigChange) Before(ctx context.Context) (string, error) {
+	if r.before != nil {
+		return *r.before, nil
+	}
+	q := r.query.Select("before")
+
+	var response string
+
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
+}
+
+// The changed setting, named after the API that sets it, e.g. "envVariable", "entrypoint" or "label".
+func (r *ContainerConfigChange) Field(ctx context.Context) (string, error) {
+	if r.field != nil {
+		return *r.field, nil
+	}
+	q := r.query.Select("field")
+
+	var response string
+
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
+}
+
+// A unique identifier for this ContainerConfigChange.
+func (r *ContainerConfigChange) ID(ctx context.Context) (ContainerConfigChangeID, error) {
+	if r.id != nil {
+		return *r.id, nil
+	}
+	q := r.query.Select("id")
+
+	var response ContainerConfigChangeID
+
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
+}
+
+// XXX_GraphQLType is an internal function. It returns the native GraphQL type name
+func (r *ContainerConfigChange) XXX_GraphQLType() string {
+	return "ContainerConfigChange"
+}
+
+// XXX_GraphQLIDType is an internal function. It returns the native GraphQL type name for the ID of this object
+func (r *ContainerConfigChange) XXX_GraphQLIDType() string {
+	return "ContainerConfigChangeID"
+}
+
+// XXX_GraphQLID is an internal function. It returns the underlying type ID
+func (r *ContainerConfigChange) XXX_GraphQLID(ctx context.Context) (string, error) {
+	id, err := r.ID(ctx)
+	if err != nil {
+		return "", err
+	}
+	return string(id), nil
+}
+
+func (r *ContainerConfigChange) MarshalJSON() ([]byte, error) {
+	id, err := r.ID(marshalCtx)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(id)
+}
+
+// The changed entry of settings that have several, i.e. the name of an environment variable or label, or an exposed port such as "8080/tcp".
+func (r *ContainerConfigChange) Key(ctx context.Context) (string, error) {
+	if r.key != nil {
+		return *r.key, nil
+	}
+	q := r.query.Select("key")
+
+	var response string
+
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
+}
+
+// How the setting changed.
+func (r *ContainerConfigChange) Kind(ctx context.Context) (ContainerChangeKind, error) {
+	if r.kind != nil {
+		return *r.kind, nil
+	}
+	q := r.query.Select("kind")
+
+	var response ContainerChangeKind
+
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
+}
+
+// A comparison of the layers of two containers at the same position.
+type ContainerLayerComparison struct {
+	query *querybuilder.Selection
+
+	afterDigest  *string
+	afterSize    *int
+	beforeDigest *string
+	beforeSize   *int
+	id           *ContainerLayerComparisonID
+	index        *int
+	kind         *ContainerChangeKind
+}
+
+func (r *ContainerLayerComparison) WithGraphQLQuery(q *querybuilder.Selection) *ContainerLayerComparison {
+	return &ContainerLayerComparison{
+		query: q,
+	}
+}
+
+// The digest of the layer in the newer container, if it has one at this position.
+func (r *ContainerLayerComparison) AfterDigest(ctx context.Context) (string, error) {
+	if r.afterDigest != nil {
+		return *r.afterDigest, nil
+	}
+	q := r.query.Select("afterDigest")
+
+	var response string
+
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
+}
+
+// The compressed size of the layer in the newer container, in bytes.
+func (r *ContainerLayerComparison) AfterSize(ctx context.Context) (int, error) {
+	if r.afterSize != nil {
+		return *r.afterSize, nil
+	}
+	q := r.query.Select("afterSize")
+
+	var response int
+
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
+}
+
+// The digest of the layer in the older container, if it has one at this position.
+func (r *ContainerLayerComparison) BeforeDigest(ctx context.Context) (string, error) {
+	if r.beforeDigest != nil {
+		return *r.beforeDigest, nil
+	}
+	q := r.query.Select("beforeDigest")
+
+	var response string
+
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
+}
+
+// The compressed size of the layer in the older container, in bytes.
+func (r *ContainerLayerComparison) BeforeSize(ctx context.Context) (int, error) {
+	if r.beforeSize != nil {
+		return *r.beforeSize, nil
+	}
+	q := r.query.Select("beforeSize")
+
+	var response int
+
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
+}
+
+// A unique identifier for this ContainerLayerComparison.
+func (r *ContainerLayerComparison) ID(ctx context.Context) (ContainerLayerComparisonID, error) {
+	if r.id != nil {
+		return *r.id, nil
+	}
+	q := r.query.Select("id")
+
+	var response ContainerLayerComparisonID
+
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
+}
+
+// XXX_GraphQLType is an internal function. It returns the native GraphQL type name
+func (r *ContainerLayerComparison) XXX_GraphQLType() string {
+	return "ContainerLayerComparison"
+}
+
+// XXX_GraphQLIDType is an internal function. It returns the native GraphQL type name for the ID of this object
+func (r *ContainerLayerComparison) XXX_GraphQLIDType() string {
+	return "ContainerLayerComparisonID"
+}
+
+// XXX_GraphQLID is an internal function. It returns the underlying type ID
+func (r *ContainerLayerComparison) XXX_GraphQLID(ctx context.Context) (string, error) {
+	id, err := r.ID(ctx)
+	if err != nil {
+		return "", err
+	}
+	return string(id), nil
+}
+
+func (r *ContainerLayerComparison) MarshalJSON() ([]byte, error) {
+	id, err := r.ID(marshalCtx)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(id)
+}
+
+// The position of the layer, starting from the base layer at 0.
+func (r *ContainerLayerComparison) Index(ctx context.Context) (int, error) {
+	if r.index != nil {
+		return *r.index, nil
+	}
+	q := r.query.Select("index")
+
+	var response int
+
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
+}
+
+// Whether the layer differs between the containers.
+func (r *ContainerLayerComparison) Kind(ctx context.Context) (ContainerChangeKind, error) {
+	if r.kind != nil {
+		return *r.kind, nil
+	}
+	q := r.query.Select("kind")
+
+	var response ContainerChangeKind
+
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
+}
+
+// Reflective module API provided to functions at runtime.
+type CurrentModule struct {
+	query *querybuilder.Selection
+
+	id   *CurrentModuleID
+	name *string
+}
+
+func (r *CurrentModule) WithGraphQLQuery(q *querybuilder.Selection) *CurrentModule {
+	return &CurrentModule{
+		query: q,
+	}
+}
+
+// The dependencies of the module.
+func (r *CurrentModule) Dependencies(ctx context.Context) ([]Module, error) {
+	q := r.query.Select("dependencies")
+
+	q = q.Select("id")
+
+	type dependencies struct {
+		Id ModuleID
+	}
+
+	convert := func(fields []dependencies) []Module {
+		out := []Module{}
+
+		for i := range fields {
+			val := Module{id: &fields[i].Id}
+			val.query = q.Root().Select("loadModuleFromID").Arg("id", fields[i].Id)
+			out = append(out, val)
+		}
+
+		return out
+	}
+	var response []dependencies
+
+	q = q.Bind(&response)
+
+	err := q.Execute(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return convert(response), nil
+}
+
+// The generated files and directories made on top of the module source's context directory.
+func (r *CurrentModule) GeneratedContextDirectory() *Directory {
+	q := r.query.Select("generatedContextDirectory")
+
+	return &Directory{
+		query: q,
+	}
+}
+
+// A unique identifier for this CurrentModule.
+func (r *CurrentModule) ID(ctx context.Context) (CurrentModuleID, error) {
+	if r.id != nil {
+		return *r.id, nil
+	}
+	q := r.query.Select("id")
+
+	var response CurrentModuleID
+
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
+}
+
+// XXX_GraphQLType is an internal function. It returns the native GraphQL type name
+func (r *CurrentModule) XXX_GraphQLType() string {
+	return "CurrentModule"
+}
+
+// XXX_GraphQLIDType is an internal function. It returns the native GraphQL type name for the ID of this object
+func (r *CurrentModule) XXX_GraphQLIDType() string {
+	return "CurrentModuleID"
+}
+
+// XXX_GraphQLID is an internal function. It returns the underlying type ID
+func (r *CurrentModule) XXX_GraphQLID(ctx context.Context) (string, error) {
+	id, err := r.ID(ctx)
+	if err != nil {
+		return "", err
+	}
+	return string(id), nil
+}
+
+func (r *CurrentModule) MarshalJSON() ([]byte, error) {
+	id, err := r.ID(marshalCtx)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(id)
+}
+
+// The name of the module being executed in
+func (r *CurrentModule) Name(ctx context.Context) (string, error) {
+	if r.name != nil {
+		return *r.name, nil
+	}
+	q := r.query.Select("name")
+
+	var response string
+
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
+}
+
+// The directory containing the module's source code loaded into the engine (plus any generated code that may have been created).
+func (r *CurrentModule) Source() *Directory {
+	q := r.query.Select("source")
+
+	return &Directory{
+		query: q,
+	}
+}
+
+// CurrentModuleWorkdirOpts contains options for CurrentModule.Workdir
+type CurrentModuleWorkdirOpts struct {
+	// Exclude artifacts that match the given pattern (e.g., ["node_modules/", ".git*"]).
+	Exclude []string
+	// Include only artifacts that match the given pattern (e.g., ["app/", "package.*"]).
+	Include []string
+	// Apply .gitignore filter rules inside the directory
+	Gitignore bool
+}
+
+// Load a directory from the module's scratch working directory, including any changes that may have been made to it during module function execution.
+func (r *CurrentModule) Workdir(path string, opts ...CurrentModuleWorkdirOpts) *Directory {
+	q := r.query.Select("workdir")
+	for i := len(opts) - 1; i >= 0; i-- {
+		// `exclude` optional argument
+		if !querybuilder.IsZeroValue(opts[i].Exclude) {
+			q = q.Arg("exclude", opts[i].Exclude)
+		}
+		// `include` optional argument
+		if !querybuilder.IsZeroValue(opts[i].Include) {
 			q = q.Arg("include", opts[i].Include)
 		}
 		// `gitignore` optional argument
@@ -3841,6 +4584,31 @@ func (r *Directory) Name(ctx context.Context) (string, error) {
 	return response, q.Execute(ctx)
 }
 
+// DirectorySbomOpts contains options for Directory.Sbom
+type DirectorySbomOpts struct {
+	// Format of the SBOM.
+	//
+	// Default: SPDX_JSON
+	Format SBOMFormat
+}
+
+// Return a software bill of materials (SBOM) of the packages in the directory.
+//
+// OS packages installed with apk, dpkg or rpm are detected, as well as the dependencies in Go, npm, Python and Cargo lockfiles.
+func (r *Directory) Sbom(opts ...DirectorySbomOpts) *File {
+	q := r.query.Select("sbom")
+	for i := len(opts) - 1; i >= 0; i-- {
+		// `format` optional argument
+		if !querybuilder.IsZeroValue(opts[i].Format) {
+			q = q.Arg("format", opts[i].Format)
+		}
+	}
+
+	return &File{
+		query: q,
+	}
+}
+
 // DirectorySearchOpts contains options for Directory.Search
 type DirectorySearchOpts struct {
 	// Directory or file paths to search
@@ -4339,17 +5107,83 @@ func (r *Engine) LocalCache() *EngineCache {
 	}
 }
 
-// The name of the engine instance.
-func (r *Engine) Name(ctx context.Context) (string, error) {
-	if r.name != nil {
-		return *r.name, nil
+// The name of the engine instance.
+func (r *Engine) Name(ctx context.Context) (string, error) {
+	if r.name != nil {
+		return *r.name, nil
+	}
+	q := r.query.Select("name")
+
+	var response string
+
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
+}
+
+// The list of persistent services running in the engine, shared across sessions
+func (r *Engine) Services(ctx context.Context) ([]EngineService, error) {
+	q := r.query.Select("services")
+
+	q = q.Select("id")
+
+	type services struct {
+		Id EngineServiceID
+	}
+
+	convert := func(fields []services) []EngineService {
+		out := []EngineService{}
+
+		for i := range fields {
+			val := EngineService{id: &fields[i].Id}
+			val.query = q.Root().Select("loadEngineServiceFromID").Arg("id", fields[i].Id)
+			out = append(out, val)
+		}
+
+		return out
+	}
+	var response []services
+
+	q = q.Bind(&response)
+
+	err := q.Execute(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return convert(response), nil
+}
+
+// The list of active sessions, across all clients connected to the engine
+func (r *Engine) Sessions(ctx context.Context) ([]EngineSession, error) {
+	q := r.query.Select("sessions")
+
+	q = q.Select("id")
+
+	type sessions struct {
+		Id string
 	}
-	q := r.query.Select("name")
 
-	var response string
+	convert := func(fields []sessions) []EngineSession {
+		out := []EngineSession{}
+
+		for i := range fields {
+			val := EngineSession{id: &fields[i].Id}
+			val.query = q.Root().Select("loadEngineSessionFromID").Arg("id", fields[i].Id)
+			out = append(out, val)
+		}
+
+		return out
+	}
+	var response []sessions
 
 	q = q.Bind(&response)
-	return response, q.Execute(ctx)
+
+	err := q.Execute(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return convert(response), nil
 }
 
 // A cache storage for the Dagger engine
@@ -4359,9 +5193,11 @@ type EngineCache struct {
 	id            *EngineCacheID
 	maxUsedSpace  *int
 	minFreeSpace  *int
+	pin           *Void
 	prune         *Void
 	reservedSpace *int
 	targetSpace   *int
+	unpin         *Void
 }
 
 func (r *EngineCache) WithGraphQLQuery(q *querybuilder.Selection) *EngineCache {
@@ -4456,6 +5292,17 @@ func (r *EngineCache) MinFreeSpace(ctx context.Context) (int, error) {
 	return response, q.Execute(ctx)
 }
 
+// Pin a cache entry, so that it's never pruned
+func (r *EngineCache) Pin(ctx context.Context, recordId string) error {
+	if r.pin != nil {
+		return nil
+	}
+	q := r.query.Select("pin")
+	q = q.Arg("recordId", recordId)
+
+	return q.Execute(ctx)
+}
+
 // EngineCachePruneOpts contains options for EngineCache.Prune
 type EngineCachePruneOpts struct {
 	// Use the engine-wide default pruning policy if true, otherwise prune the whole cache of any releasable entries.
@@ -4504,6 +5351,19 @@ func (r *EngineCache) TargetSpace(ctx context.Context) (int, error) {
 	return response, q.Execute(ctx)
 }
 
+// Unpin a cache entry, so that it can be pruned again
+//
+// The entries of a pinned cache volume are pinned again when the volume is used; unpin the volume itself with CacheVolume.unpin instead.
+func (r *EngineCache) Unpin(ctx context.Context, recordId string) error {
+	if r.unpin != nil {
+		return nil
+	}
+	q := r.query.Select("unpin")
+	q = q.Arg("recordId", recordId)
+
+	return q.Execute(ctx)
+}
+
 // An individual cache entry in a cache entry set
 type EngineCacheEntry struct {
 	query *querybuilder.Selection
@@ -4514,91 +5374,331 @@ type EngineCacheEntry struct {
 	diskSpaceBytes            *int
 	id                        *EngineCacheEntryID
 	mostRecentUseTimeUnixNano *int
+	pinned                    *bool
+	recordId                  *string
+}
+
+func (r *EngineCacheEntry) WithGraphQLQuery(q *querybuilder.Selection) *EngineCacheEntry {
+	return &EngineCacheEntry{
+		query: q,
+	}
+}
+
+// Whether the cache entry is actively being used.
+func (r *EngineCacheEntry) ActivelyUsed(ctx context.Context) (bool, error) {
+	if r.activelyUsed != nil {
+		return *r.activelyUsed, nil
+	}
+	q := r.query.Select("activelyUsed")
+
+	var response bool
+
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
+}
+
+// The time the cache entry was created, in Unix nanoseconds.
+func (r *EngineCacheEntry) CreatedTimeUnixNano(ctx context.Context) (int, error) {
+	if r.createdTimeUnixNano != nil {
+		return *r.createdTimeUnixNano, nil
+	}
+	q := r.query.Select("createdTimeUnixNano")
+
+	var response int
+
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
+}
+
+// The description of the cache entry.
+func (r *EngineCacheEntry) Description(ctx context.Context) (string, error) {
+	if r.description != nil {
+		return *r.description, nil
+	}
+	q := r.query.Select("description")
+
+	var response string
+
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
+}
+
+// The disk space used by the cache entry.
+func (r *EngineCacheEntry) DiskSpaceBytes(ctx context.Context) (int, error) {
+	if r.diskSpaceBytes != nil {
+		return *r.diskSpaceBytes, nil
+	}
+	q := r.query.Select("diskSpaceBytes")
+
+	var response int
+
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
+}
+
+// A unique identifier for this EngineCacheEntry.
+func (r *EngineCacheEntry) ID(ctx context.Context) (EngineCacheEntryID, error) {
+	if r.id != nil {
+		return *r.id, nil
+	}
+	q := r.query.Select("id")
+
+	var response EngineCacheEntryID
+
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
+}
+
+// XXX_GraphQLType is an internal function. It returns the native GraphQL type name
+func (r *EngineCacheEntry) XXX_GraphQLType() string {
+	return "EngineCacheEntry"
+}
+
+// XXX_GraphQLIDType is an internal function. It returns the native GraphQL type name for the ID of this object
+func (r *EngineCacheEntry) XXX_GraphQLIDType() string {
+	return "EngineCacheEntryID"
+}
+
+// XXX_GraphQLID is an internal function. It returns the underlying type ID
+func (r *EngineCacheEntry) XXX_GraphQLID(ctx context.Context) (string, error) {
+	id, err := r.ID(ctx)
+	if err != nil {
+		return "", err
+	}
+	return string(id), nil
+}
+
+func (r *EngineCacheEntry) MarshalJSON() ([]byte, error) {
+	id, err := r.ID(marshalCtx)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(id)
+}
+
+// The most recent time the cache entry was used, in Unix nanoseconds.
+func (r *EngineCacheEntry) MostRecentUseTimeUnixNano(ctx context.Context) (int, error) {
+	if r.mostRecentUseTimeUnixNano != nil {
+		return *r.mostRecentUseTimeUnixNano, nil
+	}
+	q := r.query.Select("mostRecentUseTimeUnixNano")
+
+	var response int
+
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
+}
+
+// Whether the cache entry is pinned, exempting it from pruning.
+func (r *EngineCacheEntry) Pinned(ctx context.Context) (bool, error) {
+	if r.pinned != nil {
+		return *r.pinned, nil
+	}
+	q := r.query.Select("pinned")
+
+	var response bool
+
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
+}
+
+// The ID of the underlying cache record, which stays the same across sessions and engine restarts.
+func (r *EngineCacheEntry) RecordID(ctx context.Context) (string, error) {
+	if r.recordId != nil {
+		return *r.recordId, nil
+	}
+	q := r.query.Select("recordId")
+
+	var response string
+
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
+}
+
+// A set of cache entries returned by a query to a cache
+type EngineCacheEntrySet struct {
+	query *querybuilder.Selection
+
+	diskSpaceBytes *int
+	entryCount     *int
+	id             *EngineCacheEntrySetID
+}
+
+func (r *EngineCacheEntrySet) WithGraphQLQuery(q *querybuilder.Selection) *EngineCacheEntrySet {
+	return &EngineCacheEntrySet{
+		query: q,
+	}
+}
+
+// The total disk space used by the cache entries in this set.
+func (r *EngineCacheEntrySet) DiskSpaceBytes(ctx context.Context) (int, error) {
+	if r.diskSpaceBytes != nil {
+		return *r.diskSpaceBytes, nil
+	}
+	q := r.query.Select("diskSpaceBytes")
+
+	var response int
+
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
+}
+
+// The list of individual cache entries in the set
+func (r *EngineCacheEntrySet) Entries(ctx context.Context) ([]EngineCacheEntry, error) {
+	q := r.query.Select("entries")
+
+	q = q.Select("id")
+
+	type entries struct {
+		Id EngineCacheEntryID
+	}
+
+	convert := func(fields []entries) []EngineCacheEntry {
+		out := []EngineCacheEntry{}
+
+		for i := range fields {
+			val := EngineCacheEntry{id: &fields[i].Id}
+			val.query = q.Root().Select("loadEngineCacheEntryFromID").Arg("id", fields[i].Id)
+			out = append(out, val)
+		}
+
+		return out
+	}
+	var response []entries
+
+	q = q.Bind(&response)
+
+	err := q.Execute(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return convert(response), nil
+}
+
+// The number of cache entries in this set.
+func (r *EngineCacheEntrySet) EntryCount(ctx context.Context) (int, error) {
+	if r.entryCount != nil {
+		return *r.entryCount, nil
+	}
+	q := r.query.Select("entryCount")
+
+	var response int
+
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
+}
+
+// A unique identifier for this EngineCacheEntrySet.
+func (r *EngineCacheEntrySet) ID(ctx context.Context) (EngineCacheEntrySetID, error) {
+	if r.id != nil {
+		return *r.id, nil
+	}
+	q := r.query.Select("id")
+
+	var response EngineCacheEntrySetID
+
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
+}
+
+// XXX_GraphQLType is an internal function. It returns the native GraphQL type name
+func (r *EngineCacheEntrySet) XXX_GraphQLType() string {
+	return "EngineCacheEntrySet"
+}
+
+// XXX_GraphQLIDType is an internal function. It returns the native GraphQL type name for the ID of this object
+func (r *EngineCacheEntrySet) XXX_GraphQLIDType() string {
+	return "EngineCacheEntrySetID"
 }
 
-func (r *EngineCacheEntry) WithGraphQLQuery(q *querybuilder.Selection) *EngineCacheEntry {
-	return &EngineCacheEntry{
-		query: q,
+// XXX_GraphQLID is an internal function. It returns the underlying type ID
+func (r *EngineCacheEntrySet) XXX_GraphQLID(ctx context.Context) (string, error) {
+	id, err := r.ID(ctx)
+	if err != nil {
+		return "", err
 	}
+	return string(id), nil
 }
 
-// Whether the cache entry is actively being used.
-func (r *EngineCacheEntry) ActivelyUsed(ctx context.Context) (bool, error) {
-	if r.activelyUsed != nil {
-		return *r.activelyUsed, nil
+func (r *EngineCacheEntrySet) MarshalJSON() ([]byte, error) {
+	id, err := r.ID(marshalCtx)
+	if err != nil {
+		return nil, err
 	}
-	q := r.query.Select("activelyUsed")
+	return json.Marshal(id)
+}
 
-	var response bool
+// A persistent service running in the Dagger engine, shared across sessions
+type EngineService struct {
+	query *querybuilder.Selection
 
-	q = q.Bind(&response)
-	return response, q.Execute(ctx)
+	bindings          *int
+	hostname          *string
+	id                *EngineServiceID
+	idleSinceUnixNano *int
+	idleTimeout       *string
+	name              *string
+	startedUnixNano   *int
 }
 
-// The time the cache entry was created, in Unix nanoseconds.
-func (r *EngineCacheEntry) CreatedTimeUnixNano(ctx context.Context) (int, error) {
-	if r.createdTimeUnixNano != nil {
-		return *r.createdTimeUnixNano, nil
+func (r *EngineService) WithGraphQLQuery(q *querybuilder.Selection) *EngineService {
+	return &EngineService{
+		query: q,
 	}
-	q := r.query.Select("createdTimeUnixNano")
-
-	var response int
-
-	q = q.Bind(&response)
-	return response, q.Execute(ctx)
 }
 
-// The description of the cache entry.
-func (r *EngineCacheEntry) Description(ctx context.Context) (string, error) {
-	if r.description != nil {
-		return *r.description, nil
+// The number of bindings currently using the service, across sessions.
+func (r *EngineService) Bindings(ctx context.Context) (int, error) {
+	if r.bindings != nil {
+		return *r.bindings, nil
 	}
-	q := r.query.Select("description")
+	q := r.query.Select("bindings")
 
-	var response string
+	var response int
 
 	q = q.Bind(&response)
 	return response, q.Execute(ctx)
 }
 
-// The disk space used by the cache entry.
-func (r *EngineCacheEntry) DiskSpaceBytes(ctx context.Context) (int, error) {
-	if r.diskSpaceBytes != nil {
-		return *r.diskSpaceBytes, nil
+// The hostname to reach the service from any session.
+func (r *EngineService) Hostname(ctx context.Context) (string, error) {
+	if r.hostname != nil {
+		return *r.hostname, nil
 	}
-	q := r.query.Select("diskSpaceBytes")
+	q := r.query.Select("hostname")
 
-	var response int
+	var response string
 
 	q = q.Bind(&response)
 	return response, q.Execute(ctx)
 }
 
-// A unique identifier for this EngineCacheEntry.
-func (r *EngineCacheEntry) ID(ctx context.Context) (EngineCacheEntryID, error) {
+// A unique identifier for this EngineService.
+func (r *EngineService) ID(ctx context.Context) (EngineServiceID, error) {
 	if r.id != nil {
 		return *r.id, nil
 	}
 	q := r.query.Select("id")
 
-	var response EngineCacheEntryID
+	var response EngineServiceID
 
 	q = q.Bind(&response)
 	return response, q.Execute(ctx)
 }
 
 // XXX_GraphQLType is an internal function. It returns the native GraphQL type name
-func (r *EngineCacheEntry) XXX_GraphQLType() string {
-	return "EngineCacheEntry"
+func (r *EngineService) XXX_GraphQLType() string {
+	return "EngineService"
 }
 
 // XXX_GraphQLIDType is an internal function. It returns the native GraphQL type name for the ID of this object
-func (r *EngineCacheEntry) XXX_GraphQLIDType() string {
-	return "EngineCacheEntryID"
+func (r *EngineService) XXX_GraphQLIDType() string {
+	return "EngineServiceID"
 }
 
 // XXX_GraphQLID is an internal function. It returns the underlying type ID
-func (r *EngineCacheEntry) XXX_GraphQLID(ctx context.Context) (string, error) {
+func (r *EngineService) XXX_GraphQLID(ctx context.Context) (string, error) {
 	id, err := r.ID(ctx)
 	if err != nil {
 		return "", err
@@ -4606,7 +5706,7 @@ func (r *EngineCacheEntry) XXX_GraphQLID(ctx context.Context) (string, error) {
 	return string(id), nil
 }
 
-func (r *EngineCacheEntry) MarshalJSON() ([]byte, error) {
+func (r *EngineService) MarshalJSON() ([]byte, error) {
 	id, err := r.ID(marshalCtx)
 	if err != nil {
 		return nil, err
@@ -4614,12 +5714,12 @@ func (r *EngineCacheEntry) MarshalJSON() ([]byte, error) {
 	return json.Marshal(id)
 }
 
-// The most recent time the cache entry was used, in Unix nanoseconds.
-func (r *EngineCacheEntry) MostRecentUseTimeUnixNano(ctx context.Context) (int, error) {
-	if r.mostRecentUseTimeUnixNano != nil {
-		return *r.mostRecentUseTimeUnixNano, nil
+// The time the service became unused, in Unix nanoseconds, or 0 if it's in use.
+func (r *EngineService) IdleSinceUnixNano(ctx context.Context) (int, error) {
+	if r.idleSinceUnixNano != nil {
+		return *r.idleSinceUnixNano, nil
 	}
-	q := r.query.Select("mostRecentUseTimeUnixNano")
+	q := r.query.Select("idleSinceUnixNano")
 
 	var response int
 
@@ -4627,27 +5727,82 @@ func (r *EngineCacheEntry) MostRecentUseTimeUnixNano(ctx context.Context) (int,
 	return response, q.Execute(ctx)
 }
 
-// A set of cache entries returned by a query to a cache
-type EngineCacheEntrySet struct {
+// How long the service keeps running while unused (e.g., "30m"), or empty if it runs until stopped.
+func (r *EngineService) IdleTimeout(ctx context.Context) (string, error) {
+	if r.idleTimeout != nil {
+		return *r.idleTimeout, nil
+	}
+	q := r.query.Select("idleTimeout")
+
+	var response string
+
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
+}
+
+// The name the service was started with.
+func (r *EngineService) Name(ctx context.Context) (string, error) {
+	if r.name != nil {
+		return *r.name, nil
+	}
+	q := r.query.Select("name")
+
+	var response string
+
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
+}
+
+// The ports exposed by the service.
+func (r *EngineService) Ports(ctx context.Context) ([]int, error) {
+	q := r.query.Select("ports")
+
+	var response []int
+
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
+}
+
+// The time the service started, in Unix nanoseconds.
+func (r *EngineService) StartedUnixNano(ctx context.Context) (int, error) {
+	if r.startedUnixNano != nil {
+		return *r.startedUnixNano, nil
+	}
+	q := r.query.Select("startedUnixNano")
+
+	var response int
+
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
+}
+
+// A session of a client connected to the Dagger engine
+type EngineSession struct {
 	query *querybuilder.Selection
 
-	diskSpaceBytes *int
-	entryCount     *int
-	id             *EngineCacheEntrySetID
+	cacheHits       *int
+	cacheMisses     *int
+	clientHostname  *string
+	clientId        *string
+	clientVersion   *string
+	command         *string
+	id              *string
+	runningExecs    *int
+	startedUnixNano *int
 }
 
-func (r *EngineCacheEntrySet) WithGraphQLQuery(q *querybuilder.Selection) *EngineCacheEntrySet {
-	return &EngineCacheEntrySet{
+func (r *EngineSession) WithGraphQLQuery(q *querybuilder.Selection) *EngineSession {
+	return &EngineSession{
 		query: q,
 	}
 }
 
-// The total disk space used by the cache entries in this set.
-func (r *EngineCacheEntrySet) DiskSpaceBytes(ctx context.Context) (int, error) {
-	if r.diskSpaceBytes != nil {
-		return *r.diskSpaceBytes, nil
+// The number of cacheable calls in the session that were cached.
+func (r *EngineSession) CacheHits(ctx context.Context) (int, error) {
+	if r.cacheHits != nil {
+		return *r.cacheHits, nil
 	}
-	q := r.query.Select("diskSpaceBytes")
+	q := r.query.Select("cacheHits")
 
 	var response int
 
@@ -4655,77 +5810,96 @@ func (r *EngineCacheEntrySet) DiskSpaceBytes(ctx context.Context) (int, error) {
 	return response, q.Execute(ctx)
 }
 
-// The list of individual cache entries in the set
-func (r *EngineCacheEntrySet) Entries(ctx context.Context) ([]EngineCacheEntry, error) {
-	q := r.query.Select("entries")
+// The number of cacheable calls in the session that were not cached.
+func (r *EngineSession) CacheMisses(ctx context.Context) (int, error) {
+	if r.cacheMisses != nil {
+		return *r.cacheMisses, nil
+	}
+	q := r.query.Select("cacheMisses")
 
-	q = q.Select("id")
+	var response int
 
-	type entries struct {
-		Id EngineCacheEntryID
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
+}
+
+// The hostname of the main client.
+func (r *EngineSession) ClientHostname(ctx context.Context) (string, error) {
+	if r.clientHostname != nil {
+		return *r.clientHostname, nil
 	}
+	q := r.query.Select("clientHostname")
 
-	convert := func(fields []entries) []EngineCacheEntry {
-		out := []EngineCacheEntry{}
+	var response string
 
-		for i := range fields {
-			val := EngineCacheEntry{id: &fields[i].Id}
-			val.query = q.Root().Select("loadEngineCacheEntryFromID").Arg("id", fields[i].Id)
-			out = append(out, val)
-		}
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
+}
 
-		return out
+// The ID of the main client that started the session.
+func (r *EngineSession) ClientID(ctx context.Context) (string, error) {
+	if r.clientId != nil {
+		return *r.clientId, nil
 	}
-	var response []entries
+	q := r.query.Select("clientId")
+
+	var response string
 
 	q = q.Bind(&response)
+	return response, q.Execute(ctx)
+}
 
-	err := q.Execute(ctx)
-	if err != nil {
-		return nil, err
+// The version of the main client.
+func (r *EngineSession) ClientVersion(ctx context.Context) (string, error) {
+	if r.clientVersion != nil {
+		return *r.clientVersion, nil
 	}
+	q := r.query.Select("clientVersion")
 
-	return convert(response), nil
+	var response string
+
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
 }
 
-// The number of cache entries in this set.
-func (r *EngineCacheEntrySet) EntryCount(ctx context.Context) (int, error) {
-	if r.entryCount != nil {
-		return *r.entryCount, nil
+// The command run by the main client, if known.
+func (r *EngineSession) Command(ctx context.Context) (string, error) {
+	if r.command != nil {
+		return *r.command, nil
 	}
-	q := r.query.Select("entryCount")
+	q := r.query.Select("command")
 
-	var response int
+	var response string
 
 	q = q.Bind(&response)
 	return response, q.Execute(ctx)
 }
 
-// A unique identifier for this EngineCacheEntrySet.
-func (r *EngineCacheEntrySet) ID(ctx context.Context) (EngineCacheEntrySetID, error) {
+// The ID of the session.
+func (r *EngineSession) ID(ctx context.Context) (string, error) {
 	if r.id != nil {
 		return *r.id, nil
 	}
 	q := r.query.Select("id")
 
-	var response EngineCacheEntrySetID
+	var response string
 
 	q = q.Bind(&response)
 	return response, q.Execute(ctx)
 }
 
 // XXX_GraphQLType is an internal function. It returns the native GraphQL type name
-func (r *EngineCacheEntrySet) XXX_GraphQLType() string {
-	return "EngineCacheEntrySet"
+func (r *EngineSession) XXX_GraphQLType() string {
+	return "EngineSession"
 }
 
 // XXX_GraphQLIDType is an internal function. It returns the native GraphQL type name for the ID of this object
-func (r *EngineCacheEntrySet) XXX_GraphQLIDType() string {
-	return "EngineCacheEntrySetID"
+func (r *EngineSession) XXX_GraphQLIDType() string {
+	return "string"
 }
 
 // XXX_GraphQLID is an internal function. It returns the underlying type ID
-func (r *EngineCacheEntrySet) XXX_GraphQLID(ctx context.Context) (string, error) {
+func (r *EngineSession) XXX_GraphQLID(ctx context.Context) (string, error) {
 	id, err := r.ID(ctx)
 	if err != nil {
 		return "", err
@@ -4733,7 +5907,7 @@ func (r *EngineCacheEntrySet) XXX_GraphQLID(ctx context.Context) (string, error)
 	return string(id), nil
 }
 
-func (r *EngineCacheEntrySet) MarshalJSON() ([]byte, error) {
+func (r *EngineSession) MarshalJSON() ([]byte, error) {
 	id, err := r.ID(marshalCtx)
 	if err != nil {
 		return nil, err
@@ -4741,6 +5915,42 @@ func (r *EngineCacheEntrySet) MarshalJSON() ([]byte, error) {
 	return json.Marshal(id)
 }
 
+// The names of the modules with clients connected to the session.
+func (r *EngineSession) Modules(ctx context.Context) ([]string, error) {
+	q := r.query.Select("modules")
+
+	var response []string
+
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
+}
+
+// The number of containers currently running in the session.
+func (r *EngineSession) RunningExecs(ctx context.Context) (int, error) {
+	if r.runningExecs != nil {
+		return *r.runningExecs, nil
+	}
+	q := r.query.Select("runningExecs")
+
+	var response int
+
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
+}
+
+// The time the session started, in Unix nanoseconds.
+func (r *EngineSession) StartedUnixNano(ctx context.Context) (int, error) {
+	if r.startedUnixNano != nil {
+		return *r.startedUnixNano, nil
+	}
+	q := r.query.Select("startedUnixNano")
+
+	var response int
+
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
+}
+
 // A definition of a custom enum defined in a Module.
 type EnumTypeDef struct {
 	query *querybuilder.Selection
@@ -5274,7 +6484,79 @@ func (r *Env) WithCheckGroupOutput(name string, description string) *Env {
 // Create or update a binding of type Check in the environment
 func (r *Env) WithCheckInput(name string, value *Check, description string) *Env {
 	assertNotNil("value", value)
-	q := r.query.Select("withCheckInput")
+	q := r.query.Select("withCheckInput")
+	q = q.Arg("name", name)
+	q = q.Arg("value", value)
+	q = q.Arg("description", description)
+
+	return &Env{
+		query: q,
+	}
+}
+
+// Declare a desired Check output to be assigned in the environment
+func (r *Env) WithCheckOutput(name string, description string) *Env {
+	q := r.query.Select("withCheckOutput")
+	q = q.Arg("name", name)
+	q = q.Arg("description", description)
+
+	return &Env{
+		query: q,
+	}
+}
+
+// Create or update a binding of type Cloud in the environment
+func (r *Env) WithCloudInput(name string, value *Cloud, description string) *Env {
+	assertNotNil("value", value)
+	q := r.query.Select("withCloudInput")
+	q = q.Arg("name", name)
+	q = q.Arg("value", value)
+	q = q.Arg("description", description)
+
+	return &Env{
+		query: q,
+	}
+}
+
+// Declare a desired Cloud output to be assigned in the environment
+func (r *Env) WithCloudOutput(name string, description string) *Env {
+	q := r.query.Select("withCloudOutput")
+	q = q.Arg("name", name)
+	q = q.Arg("description", description)
+
+	return &Env{
+		query: q,
+	}
+}
+
+// Create or update a binding of type ContainerComparison in the environment
+func (r *Env) WithContainerComparisonInput(name string, value *ContainerComparison, description string) *Env {
+	assertNotNil("value", value)
+	q := r.query.Select("withContainerComparisonInput")
+	q = q.Arg("name", name)
+	q = q.Arg("value", value)
+	q = q.Arg("description", description)
+
+	return &Env{
+		query: q,
+	}
+}
+
+// Declare a desired ContainerComparison output to be assigned in the environment
+func (r *Env) WithContainerComparisonOutput(name string, description string) *Env {
+	q := r.query.Select("withContainerComparisonOutput")
+	q = q.Arg("name", name)
+	q = q.Arg("description", description)
+
+	return &Env{
+		query: q,
+	}
+}
+
+// Create or update a binding of type ContainerConfigChange in the environment
+func (r *Env) WithContainerConfigChangeInput(name string, value *ContainerConfigChange, description string) *Env {
+	assertNotNil("value", value)
+	q := r.query.Select("withContainerConfigChangeInput")
 	q = q.Arg("name", name)
 	q = q.Arg("value", value)
 	q = q.Arg("description", description)
@@ -5284,9 +6566,9 @@ func (r *Env) WithCheckInput(name string, value *Check, description string) *Env
 	}
 }
 
-// Declare a desired Check output to be assigned in the environment
-func (r *Env) WithCheckOutput(name string, description string) *Env {
-	q := r.query.Select("withCheckOutput")
+// Declare a desired ContainerConfigChange output to be assigned in the environment
+func (r *Env) WithContainerConfigChangeOutput(name string, description string) *Env {
+	q := r.query.Select("withContainerConfigChangeOutput")
 	q = q.Arg("name", name)
 	q = q.Arg("description", description)
 
@@ -5295,10 +6577,10 @@ func (r *Env) WithCheckOutput(name string, description string) *Env {
 	}
 }
 
-// Create or update a binding of type Cloud in the environment
-func (r *Env) WithCloudInput(name string, value *Cloud, description string) *Env {
+// Create or update a binding of type Container in the environment
+func (r *Env) WithContainerInput(name string, value *Container, description string) *Env {
 	assertNotNil("value", value)
-	q := r.query.Select("withCloudInput")
+	q := r.query.Select("withContainerInput")
 	q = q.Arg("name", name)
 	q = q.Arg("value", value)
 	q = q.Arg("description", description)
@@ -5308,10 +6590,12 @@ func (r *Env) WithCloudInput(name string, value *Cloud, description string) *Env
 	}
 }
 
-// Declare a desired Cloud output to be assigned in the environment
-func (r *Env) WithCloudOutput(name string, description string) *Env {
-	q := r.query.Select("withCloudOutput")
+// Create or update a binding of type ContainerLayerComparison in the environment
+func (r *Env) WithContainerLayerComparisonInput(name string, value *ContainerLayerComparison, description string) *Env {
+	assertNotNil("value", value)
+	q := r.query.Select("withContainerLayerComparisonInput")
 	q = q.Arg("name", name)
+	q = q.Arg("value", value)
 	q = q.Arg("description", description)
 
 	return &Env{
@@ -5319,12 +6603,10 @@ func (r *Env) WithCloudOutput(name string, description string) *Env {
 	}
 }
 
-// Create or update a binding of type Container in the environment
-func (r *Env) WithContainerInput(name string, value *Container, description string) *Env {
-	assertNotNil("value", value)
-	q := r.query.Select("withContainerInput")
+// Declare a desired ContainerLayerComparison output to be assigned in the environment
+func (r *Env) WithContainerLayerComparisonOutput(name string, description string) *Env {
+	q := r.query.Select("withContainerLayerComparisonOutput")
 	q = q.Arg("name", name)
-	q = q.Arg("value", value)
 	q = q.Arg("description", description)
 
 	return &Env{
@@ -5378,6 +6660,54 @@ func (r *Env) WithDirectoryOutput(name string, description string) *Env {
 	}
 }
 
+// Create or update a binding of type EngineService in the environment
+func (r *Env) WithEngineServiceInput(name string, value *EngineService, description string) *Env {
+	assertNotNil("value", value)
+	q := r.query.Select("withEngineServiceInput")
+	q = q.Arg("name", name)
+	q = q.Arg("value", value)
+	q = q.Arg("description", description)
+
+	return &Env{
+		query: q,
+	}
+}
+
+// Declare a desired EngineService output to be assigned in the environment
+func (r *Env) WithEngineServiceOutput(name string, description string) *Env {
+	q := r.query.Select("withEngineServiceOutput")
+	q = q.Arg("name", name)
+	q = q.Arg("description", description)
+
+	return &Env{
+		query: q,
+	}
+}
+
+// Create or update a binding of type EngineSession in the environment
+func (r *Env) WithEngineSessionInput(name string, value *EngineSession, description string) *Env {
+	assertNotNil("value", value)
+	q := r.query.Select("withEngineSessionInput")
+	q = q.Arg("name", name)
+	q = q.Arg("value", value)
+	q = q.Arg("description", description)
+
+	return &Env{
+		query: q,
+	}
+}
+
+// Declare a desired EngineSession output to be assigned in the environment
+func (r *Env) WithEngineSessionOutput(name string, description string) *Env {
+	q := r.query.Select("withEngineSessionOutput")
+	q = q.Arg("name", name)
+	q = q.Arg("description", description)
+
+	return &Env{
+		query: q,
+	}
+}
+
 // Create or update a binding of type EnvFile in the environment
 func (r *Env) WithEnvFileInput(name string, value *EnvFile, description string) *Env {
 	assertNotNil("value", value)
@@ -5450,6 +6780,30 @@ func (r *Env) WithFileOutput(name string, description string) *Env {
 	}
 }
 
+// Create or update a binding of type FunctionArgConstraints in the environment
+func (r *Env) WithFunctionArgConstraintsInput(name string, value *FunctionArgConstraints, description string) *Env {
+	assertNotNil("value", value)
+	q := r.query.Select("withFunctionArgConstraintsInput")
+	q = q.Arg("name", name)
+	q = q.Arg("value", value)
+	q = q.Arg("description", description)
+
+	return &Env{
+		query: q,
+	}
+}
+
+// Declare a desired FunctionArgConstraints output to be assigned in the environment
+func (r *Env) WithFunctionArgConstraintsOutput(name string, description string) *Env {
+	q := r.query.Select("withFunctionArgConstraintsOutput")
+	q = q.Arg("name", name)
+	q = q.Arg("description", description)
+
+	return &Env{
+		query: q,
+	}
+}
+
 // Create or update a binding of type GitRef in the environment
 func (r *Env) WithGitRefInput(name string, value *GitRef, description string) *Env {
 	assertNotNil("value", value)
@@ -5535,6 +6889,30 @@ func (r *Env) WithModule(module *Module) *Env {
 	}
 }
 
+// Create or update a binding of type ModuleCapabilities in the environment
+func (r *Env) WithModuleCapabilitiesInput(name string, value *ModuleCapabilities, description string) *Env {
+	assertNotNil("value", value)
+	q := r.query.Select("withModuleCapabilitiesInput")
+	q = q.Arg("name", name)
+	q = q.Arg("value", value)
+	q = q.Arg("description", description)
+
+	return &Env{
+		query: q,
+	}
+}
+
+// Declare a desired ModuleCapabilities output to be assigned in the environment
+func (r *Env) WithModuleCapabilitiesOutput(name string, description string) *Env {
+	q := r.query.Select("withModuleCapabilitiesOutput")
+	q = q.Arg("name", name)
+	q = q.Arg("description", description)
+
+	return &Env{
+		query: q,
+	}
+}
+
 // Create or update a binding of type ModuleConfigClient in the environment
 func (r *Env) WithModuleConfigClientInput(name string, value *ModuleConfigClient, description string) *Env {
 	assertNotNil("value", value)
@@ -5607,6 +6985,70 @@ func (r *Env) WithModuleSourceOutput(name string, description string) *Env {
 	}
 }
 
+// EnvWithOutputExecCheckOpts contains options for Env.WithOutputExecCheck
+type EnvWithOutputExecCheckOpts struct {
+	// The path to mount the value at
+	//
+	// Default: "/output"
+	Path string
+	// The number of values to check before giving up
+	//
+	// Default: 3
+	MaxAttempts int
+}
+
+// Checks the values saved to an output by running a command against them
+//
+// When the command fails, its error is returned to the model, which is asked to fix the value until the command succeeds or the attempts run out. Directory, File and Container values are mounted at the given path, and String values are passed on stdin.
+func (r *Env) WithOutputExecCheck(name string, container *Container, args []string, opts ...EnvWithOutputExecCheckOpts) *Env {
+	assertNotNil("container", container)
+	q := r.query.Select("withOutputExecCheck")
+	for i := len(opts) - 1; i >= 0; i-- {
+		// `path` optional argument
+		if !querybuilder.IsZeroValue(opts[i].Path) {
+			q = q.Arg("path", opts[i].Path)
+		}
+		// `maxAttempts` optional argument
+		if !querybuilder.IsZeroValue(opts[i].MaxAttempts) {
+			q = q.Arg("maxAttempts", opts[i].MaxAttempts)
+		}
+	}
+	q = q.Arg("name", name)
+	q = q.Arg("container", container)
+	q = q.Arg("args", args)
+
+	return &Env{
+		query: q,
+	}
+}
+
+// EnvWithOutputFunctionCheckOpts contains options for Env.WithOutputFunctionCheck
+type EnvWithOutputFunctionCheckOpts struct {
+	// The number of values to check before giving up
+	//
+	// Default: 3
+	MaxAttempts int
+}
+
+// Checks the values saved to an output by calling a function against them
+//
+// The function must belong to a module installed in the environment, and take an argument of the output's type. When it fails, its error is returned to the model, which is asked to fix the value until the function succeeds or the attempts run out.
+func (r *Env) WithOutputFunctionCheck(name string, function string, opts ...EnvWithOutputFunctionCheckOpts) *Env {
+	q := r.query.Select("withOutputFunctionCheck")
+	for i := len(opts) - 1; i >= 0; i-- {
+		// `maxAttempts` optional argument
+		if !querybuilder.IsZeroValue(opts[i].MaxAttempts) {
+			q = q.Arg("maxAttempts", opts[i].MaxAttempts)
+		}
+	}
+	q = q.Arg("name", name)
+	q = q.Arg("function", function)
+
+	return &Env{
+		query: q,
+	}
+}
+
 // Create or update a binding of type SearchResult in the environment
 func (r *Env) WithSearchResultInput(name string, value *SearchResult, description string) *Env {
 	assertNotNil("value", value)
@@ -5774,6 +7216,30 @@ func (r *Env) WithStringOutput(name string, description string) *Env {
 	}
 }
 
+// Create or update a binding of type UnionTypeDef in the environment
+func (r *Env) WithUnionTypeDefInput(name string, value *UnionTypeDef, description string) *Env {
+	assertNotNil("value", value)
+	q := r.query.Select("withUnionTypeDefInput")
+	q = q.Arg("name", name)
+	q = q.Arg("value", value)
+	q = q.Arg("description", description)
+
+	return &Env{
+		query: q,
+	}
+}
+
+// Declare a desired UnionTypeDef output to be assigned in the environment
+func (r *Env) WithUnionTypeDefOutput(name string, description string) *Env {
+	q := r.query.Select("withUnionTypeDefOutput")
+	q = q.Arg("name", name)
+	q = q.Arg("description", description)
+
+	return &Env{
+		query: q,
+	}
+}
+
 // Returns a new environment with the provided workspace
 func (r *Env) WithWorkspace(workspace *Directory) *Env {
 	assertNotNil("workspace", workspace)
@@ -6951,6 +8417,20 @@ type FunctionWithArgOpts struct {
 	SourceMap *SourceMap
 	// If deprecated, the reason or migration path.
 	Deprecated string
+	// If the argument is an Integer or Float, the minimum value, inclusive, as a JSON number.
+	Min JSON
+	// If the argument is an Integer or Float, the maximum value, inclusive, as a JSON number.
+	Max JSON
+	// If the argument is a String, a regular expression (RE2 syntax) that the whole value must match.
+	Pattern string
+	// If the argument is a String or a list, the minimum length of the value.
+	MinLength int
+	// If the argument is a String or a list, the maximum length of the value.
+	MaxLength int
+	// If the argument is a list, require it to contain at least one element.
+	NonEmpty bool
+	// If the argument is a Directory, paths that must exist in it.
+	RequiredPaths []string
 }
 
 // Returns the function with the provided argument
@@ -6982,6 +8462,34 @@ func (r *Function) WithArg(name string, typeDef *TypeDef, opts ...FunctionWithAr
 		if !querybuilder.IsZeroValue(opts[i].Deprecated) {
 			q = q.Arg("deprecated", opts[i].Deprecated)
 		}
+		// `min` optional argument
+		if !querybuilder.IsZeroValue(opts[i].Min) {
+			q = q.Arg("min", opts[i].Min)
+		}
+		// `max` optional argument
+		if !querybuilder.IsZeroValue(opts[i].Max) {
+			q = q.Arg("max", opts[i].Max)
+		}
+		// `pattern` optional argument
+		if !querybuilder.IsZeroValue(opts[i].Pattern) {
+			q = q.Arg("pattern", opts[i].Pattern)
+		}
+		// `minLength` optional argument
+		if !querybuilder.IsZeroValue(opts[i].MinLength) {
+			q = q.Arg("minLength", opts[i].MinLength)
+		}
+		// `maxLength` optional argument
+		if !querybuilder.IsZeroValue(opts[i].MaxLength) {
+			q = q.Arg("maxLength", opts[i].MaxLength)
+		}
+		// `nonEmpty` optional argument
+		if !querybuilder.IsZeroValue(opts[i].NonEmpty) {
+			q = q.Arg("nonEmpty", opts[i].NonEmpty)
+		}
+		// `requiredPaths` optional argument
+		if !querybuilder.IsZeroValue(opts[i].RequiredPaths) {
+			q = q.Arg("requiredPaths", opts[i].RequiredPaths)
+		}
 	}
 	q = q.Arg("name", name)
 	q = q.Arg("typeDef", typeDef)
@@ -7084,6 +8592,15 @@ func (r *FunctionArg) WithGraphQLQuery(q *querybuilder.Selection) *FunctionArg {
 	}
 }
 
+// Constraints the value of the argument must satisfy, if any.
+func (r *FunctionArg) Constraints() *FunctionArgConstraints {
+	q := r.query.Select("constraints")
+
+	return &FunctionArgConstraints{
+		query: q,
+	}
+}
+
 // Only applies to arguments of type File or Directory. If the argument is not set, load it from the given path in the context directory
 func (r *FunctionArg) DefaultPath(ctx context.Context) (string, error) {
 	if r.defaultPath != nil {
@@ -7115,52 +8632,154 @@ func (r *FunctionArg) Deprecated(ctx context.Context) (string, error) {
 	if r.deprecated != nil {
 		return *r.deprecated, nil
 	}
-	q := r.query.Select("deprecated")
+	q := r.query.Select("deprecated")
+
+	var response string
+
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
+}
+
+// A doc string for the argument, if any.
+func (r *FunctionArg) Description(ctx context.Context) (string, error) {
+	if r.description != nil {
+		return *r.description, nil
+	}
+	q := r.query.Select("description")
+
+	var response string
+
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
+}
+
+// A unique identifier for this FunctionArg.
+func (r *FunctionArg) ID(ctx context.Context) (FunctionArgID, error) {
+	if r.id != nil {
+		return *r.id, nil
+	}
+	q := r.query.Select("id")
+
+	var response FunctionArgID
+
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
+}
+
+// XXX_GraphQLType is an internal function. It returns the native GraphQL type name
+func (r *FunctionArg) XXX_GraphQLType() string {
+	return "FunctionArg"
+}
+
+// XXX_GraphQLIDType is an internal function. It returns the native GraphQL type name for the ID of this object
+func (r *FunctionArg) XXX_GraphQLIDType() string {
+	return "FunctionArgID"
+}
+
+// XXX_GraphQLID is an internal function. It returns the underlying type ID
+func (r *FunctionArg) XXX_GraphQLID(ctx context.Context) (string, error) {
+	id, err := r.ID(ctx)
+	if err != nil {
+		return "", err
+	}
+	return string(id), nil
+}
+
+func (r *FunctionArg) MarshalJSON() ([]byte, error) {
+	id, err := r.ID(marshalCtx)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(id)
+}
+
+// Only applies to arguments of type Directory. The ignore patterns are applied to the input directory, and matching entries are filtered out, in a cache-efficient manner.
+func (r *FunctionArg) Ignore(ctx context.Context) ([]string, error) {
+	q := r.query.Select("ignore")
+
+	var response []string
+
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
+}
+
+// The name of the argument in lowerCamelCase format.
+func (r *FunctionArg) Name(ctx context.Context) (string, error) {
+	if r.name != nil {
+		return *r.name, nil
+	}
+	q := r.query.Select("name")
+
+	var response string
+
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
+}
+
+// The location of this arg declaration.
+func (r *FunctionArg) SourceMap() *SourceMap {
+	q := r.query.Select("sourceMap")
+
+	return &SourceMap{
+		query: q,
+	}
+}
+
+// The type of the argument.
+func (r *FunctionArg) TypeDef() *TypeDef {
+	q := r.query.Select("typeDef")
+
+	return &TypeDef{
+		query: q,
+	}
+}
 
-	var response string
+// Constraints on the value of a function argument.
+//
+// They are checked by the engine before the function is called.
+type FunctionArgConstraints struct {
+	query *querybuilder.Selection
 
-	q = q.Bind(&response)
-	return response, q.Execute(ctx)
+	id        *FunctionArgConstraintsID
+	max       *JSON
+	maxLength *int
+	min       *JSON
+	minLength *int
+	nonEmpty  *bool
+	pattern   *string
 }
 
-// A doc string for the argument, if any.
-func (r *FunctionArg) Description(ctx context.Context) (string, error) {
-	if r.description != nil {
-		return *r.description, nil
+func (r *FunctionArgConstraints) WithGraphQLQuery(q *querybuilder.Selection) *FunctionArgConstraints {
+	return &FunctionArgConstraints{
+		query: q,
 	}
-	q := r.query.Select("description")
-
-	var response string
-
-	q = q.Bind(&response)
-	return response, q.Execute(ctx)
 }
 
-// A unique identifier for this FunctionArg.
-func (r *FunctionArg) ID(ctx context.Context) (FunctionArgID, error) {
+// A unique identifier for this FunctionArgConstraints.
+func (r *FunctionArgConstraints) ID(ctx context.Context) (FunctionArgConstraintsID, error) {
 	if r.id != nil {
 		return *r.id, nil
 	}
 	q := r.query.Select("id")
 
-	var response FunctionArgID
+	var response FunctionArgConstraintsID
 
 	q = q.Bind(&response)
 	return response, q.Execute(ctx)
 }
 
 // XXX_GraphQLType is an internal function. It returns the native GraphQL type name
-func (r *FunctionArg) XXX_GraphQLType() string {
-	return "FunctionArg"
+func (r *FunctionArgConstraints) XXX_GraphQLType() string {
+	return "FunctionArgConstraints"
 }
 
 // XXX_GraphQLIDType is an internal function. It returns the native GraphQL type name for the ID of this object
-func (r *FunctionArg) XXX_GraphQLIDType() string {
-	return "FunctionArgID"
+func (r *FunctionArgConstraints) XXX_GraphQLIDType() string {
+	return "FunctionArgConstraintsID"
 }
 
 // XXX_GraphQLID is an internal function. It returns the underlying type ID
-func (r *FunctionArg) XXX_GraphQLID(ctx context.Context) (string, error) {
+func (r *FunctionArgConstraints) XXX_GraphQLID(ctx context.Context) (string, error) {
 	id, err := r.ID(ctx)
 	if err != nil {
 		return "", err
@@ -7168,7 +8787,7 @@ func (r *FunctionArg) XXX_GraphQLID(ctx context.Context) (string, error) {
 	return string(id), nil
 }
 
-func (r *FunctionArg) MarshalJSON() ([]byte, error) {
+func (r *FunctionArgConstraints) MarshalJSON() ([]byte, error) {
 	id, err := r.ID(marshalCtx)
 	if err != nil {
 		return nil, err
@@ -7176,45 +8795,92 @@ func (r *FunctionArg) MarshalJSON() ([]byte, error) {
 	return json.Marshal(id)
 }
 
-// Only applies to arguments of type Directory. The ignore patterns are applied to the input directory, and matching entries are filtered out, in a cache-efficient manner.
-func (r *FunctionArg) Ignore(ctx context.Context) ([]string, error) {
-	q := r.query.Select("ignore")
+// Only applies to Integer and Float arguments. The maximum value, inclusive, as a JSON number.
+func (r *FunctionArgConstraints) Max(ctx context.Context) (JSON, error) {
+	if r.max != nil {
+		return *r.max, nil
+	}
+	q := r.query.Select("max")
 
-	var response []string
+	var response JSON
 
 	q = q.Bind(&response)
 	return response, q.Execute(ctx)
 }
 
-// The name of the argument in lowerCamelCase format.
-func (r *FunctionArg) Name(ctx context.Context) (string, error) {
-	if r.name != nil {
-		return *r.name, nil
+// Only applies to String and list arguments. The maximum length of the value, or 0 for no maximum.
+func (r *FunctionArgConstraints) MaxLength(ctx context.Context) (int, error) {
+	if r.maxLength != nil {
+		return *r.maxLength, nil
 	}
-	q := r.query.Select("name")
+	q := r.query.Select("maxLength")
 
-	var response string
+	var response int
 
 	q = q.Bind(&response)
 	return response, q.Execute(ctx)
 }
 
-// The location of this arg declaration.
-func (r *FunctionArg) SourceMap() *SourceMap {
-	q := r.query.Select("sourceMap")
+// Only applies to Integer and Float arguments. The minimum value, inclusive, as a JSON number.
+func (r *FunctionArgConstraints) Min(ctx context.Context) (JSON, error) {
+	if r.min != nil {
+		return *r.min, nil
+	}
+	q := r.query.Select("min")
 
-	return &SourceMap{
-		query: q,
+	var response JSON
+
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
+}
+
+// Only applies to String and list arguments. The minimum length of the value.
+func (r *FunctionArgConstraints) MinLength(ctx context.Context) (int, error) {
+	if r.minLength != nil {
+		return *r.minLength, nil
 	}
+	q := r.query.Select("minLength")
+
+	var response int
+
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
 }
 
-// The type of the argument.
-func (r *FunctionArg) TypeDef() *TypeDef {
-	q := r.query.Select("typeDef")
+// Only applies to list arguments. Whether the list must contain at least one element.
+func (r *FunctionArgConstraints) NonEmpty(ctx context.Context) (bool, error) {
+	if r.nonEmpty != nil {
+		return *r.nonEmpty, nil
+	}
+	q := r.query.Select("nonEmpty")
 
-	return &TypeDef{
-		query: q,
+	var response bool
+
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
+}
+
+// Only applies to String arguments. A regular expression (RE2 syntax) that the whole value must match.
+func (r *FunctionArgConstraints) Pattern(ctx context.Context) (string, error) {
+	if r.pattern != nil {
+		return *r.pattern, nil
 	}
+	q := r.query.Select("pattern")
+
+	var response string
+
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
+}
+
+// Only applies to arguments of type Directory. Paths that must exist in the directory.
+func (r *FunctionArgConstraints) RequiredPaths(ctx context.Context) ([]string, error) {
+	q := r.query.Select("requiredPaths")
+
+	var response []string
+
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
 }
 
 // An active function call.
@@ -8619,6 +10285,16 @@ func (r *LLM) BindResult(name string) *Binding {
 	}
 }
 
+// return the calls recorded instead of being called in a dry run
+func (r *LLM) DryRunCalls(ctx context.Context) ([]string, error) {
+	q := r.query.Select("dryRunCalls")
+
+	var response []string
+
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
+}
+
 // return the LLM's current environment
 func (r *LLM) Env() *Env {
 	q := r.query.Select("env")
@@ -8628,6 +10304,68 @@ func (r *LLM) Env() *Env {
 	}
 }
 
+// LLMFanOutOpts contains options for LLM.FanOut
+type LLMFanOutOpts struct {
+	// The number of branches to run. Defaults to the number of variations.
+	N int
+	// Prompts to append to the branches, one per branch in turn
+	Variations []string
+}
+
+// Run several branches of the LLM in parallel, each with its own message history and a copy of its environment
+//
+// Each branch submits the queued prompt, followed by one of the variations in turn, if any. Branches that fail are still returned, and fail when they are used. The token usage of all the branches is available with fanOutTokenUsage.
+func (r *LLM) FanOut(ctx context.Context, opts ...LLMFanOutOpts) ([]LLM, error) {
+	q := r.query.Select("fanOut")
+	for i := len(opts) - 1; i >= 0; i-- {
+		// `n` optional argument
+		if !querybuilder.IsZeroValue(opts[i].N) {
+			q = q.Arg("n", opts[i].N)
+		}
+		// `variations` optional argument
+		if !querybuilder.IsZeroValue(opts[i].Variations) {
+			q = q.Arg("variations", opts[i].Variations)
+		}
+	}
+
+	q = q.Select("id")
+
+	type fanOut struct {
+		Id LLMID
+	}
+
+	convert := func(fields []fanOut) []LLM {
+		out := []LLM{}
+
+		for i := range fields {
+			val := LLM{id: &fields[i].Id}
+			val.query = q.Root().Select("loadLLMFromID").Arg("id", fields[i].Id)
+			out = append(out, val)
+		}
+
+		return out
+	}
+	var response []fanOut
+
+	q = q.Bind(&response)
+
+	err := q.Execute(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return convert(response), nil
+}
+
+// returns the token usage of the current state, including the other branches of the fan outs it came from
+func (r *LLM) FanOutTokenUsage() *LLMTokenUsage {
+	q := r.query.Select("fanOutTokenUsage")
+
+	return &LLMTokenUsage{
+		query: q,
+	}
+}
+
 // Indicates whether there are any queued prompts or tool results to send to the model
 func (r *LLM) HasPrompt(ctx context.Context) (bool, error) {
 	if r.hasPrompt != nil {
@@ -8811,6 +10549,15 @@ func (r *LLM) WithBlockedFunction(typeName string, function string) *LLM {
 	}
 }
 
+// Return a new LLM that records the calls it makes with side effects outside of the engine, such as exports, publishing or host access, instead of calling them
+func (r *LLM) WithDryRun() *LLM {
+	q := r.query.Select("withDryRun")
+
+	return &LLM{
+		query: q,
+	}
+}
+
 // allow the LLM to interact with an environment via MCP
 func (r *LLM) WithEnv(env *Env) *LLM {
 	assertNotNil("env", env)
@@ -8884,6 +10631,19 @@ func (r *LLM) WithSystemPrompt(prompt string) *LLM {
 	}
 }
 
+// Return a new LLM that applies a policy to the functions matching a pattern
+//
+// A pattern matches a function name, like withExec, or a type and function name, like Container.withExec, and may use * as a wildcard. Tools of MCP servers are matched by server and tool name. When several rules match a function, the last one applies, and functions that match no rule are allowed.
+func (r *LLM) WithToolPolicy(pattern string, policy LLMToolPolicy) *LLM {
+	q := r.query.Select("withToolPolicy")
+	q = q.Arg("pattern", pattern)
+	q = q.Arg("policy", policy)
+
+	return &LLM{
+		query: q,
+	}
+}
+
 // Disable the default system prompt
 func (r *LLM) WithoutDefaultSystemPrompt() *LLM {
 	q := r.query.Select("withoutDefaultSystemPrompt")
@@ -9515,6 +11275,39 @@ func (r *Module) Sync(ctx context.Context) (*Module, error) {
 	}, nil
 }
 
+// Unions served by this module.
+func (r *Module) Unions(ctx context.Context) ([]TypeDef, error) {
+	q := r.query.Select("unions")
+
+	q = q.Select("id")
+
+	type unions struct {
+		Id TypeDefID
+	}
+
+	convert := func(fields []unions) []TypeDef {
+		out := []TypeDef{}
+
+		for i := range fields {
+			val := TypeDef{id: &fields[i].Id}
+			val.query = q.Root().Select("loadTypeDefFromID").Arg("id", fields[i].Id)
+			out = append(out, val)
+		}
+
+		return out
+	}
+	var response []unions
+
+	q = q.Bind(&response)
+
+	err := q.Execute(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return convert(response), nil
+}
+
 // User-defined default values, loaded from local .env files.
 func (r *Module) UserDefaults() *EnvFile {
 	q := r.query.Select("userDefaults")
@@ -9567,6 +11360,150 @@ func (r *Module) WithObject(object *TypeDef) *Module {
 	}
 }
 
+// This module plus the given Union type
+func (r *Module) WithUnion(union *TypeDef) *Module {
+	assertNotNil("union", union)
+	q := r.query.Select("withUnion")
+	q = q.Arg("union", union)
+
+	return &Module{
+		query: q,
+	}
+}
+
+// The capabilities a module requires.
+type ModuleCapabilities struct {
+	query *querybuilder.Selection
+
+	exec        *bool
+	hostSockets *bool
+	id          *ModuleCapabilitiesID
+	llm         *bool
+	network     *bool
+	secrets     *bool
+}
+
+func (r *ModuleCapabilities) WithGraphQLQuery(q *querybuilder.Selection) *ModuleCapabilities {
+	return &ModuleCapabilities{
+		query: q,
+	}
+}
+
+// Whether the module may run commands with access to the Dagger API.
+func (r *ModuleCapabilities) Exec(ctx context.Context) (bool, error) {
+	if r.exec != nil {
+		return *r.exec, nil
+	}
+	q := r.query.Select("exec")
+
+	var response bool
+
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
+}
+
+// Paths the module may read from the host through defaultPath arguments.
+func (r *ModuleCapabilities) HostRead(ctx context.Context) ([]string, error) {
+	q := r.query.Select("hostRead")
+
+	var response []string
+
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
+}
+
+// Whether the module may use host unix sockets and tunnels.
+func (r *ModuleCapabilities) HostSockets(ctx context.Context) (bool, error) {
+	if r.hostSockets != nil {
+		return *r.hostSockets, nil
+	}
+	q := r.query.Select("hostSockets")
+
+	var response bool
+
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
+}
+
+// A unique identifier for this ModuleCapabilities.
+func (r *ModuleCapabilities) ID(ctx context.Context) (ModuleCapabilitiesID, error) {
+	if r.id != nil {
+		return *r.id, nil
+	}
+	q := r.query.Select("id")
+
+	var response ModuleCapabilitiesID
+
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
+}
+
+// XXX_GraphQLType is an internal function. It returns the native GraphQL type name
+func (r *ModuleCapabilities) XXX_GraphQLType() string {
+	return "ModuleCapabilities"
+}
+
+// XXX_GraphQLIDType is an internal function. It returns the native GraphQL type name for the ID of this object
+func (r *ModuleCapabilities) XXX_GraphQLIDType() string {
+	return "ModuleCapabilitiesID"
+}
+
+// XXX_GraphQLID is an internal function. It returns the underlying type ID
+func (r *ModuleCapabilities) XXX_GraphQLID(ctx context.Context) (string, error) {
+	id, err := r.ID(ctx)
+	if err != nil {
+		return "", err
+	}
+	return string(id), nil
+}
+
+func (r *ModuleCapabilities) MarshalJSON() ([]byte, error) {
+	id, err := r.ID(marshalCtx)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(id)
+}
+
+// Whether the module may use LLMs.
+func (r *ModuleCapabilities) LLM(ctx context.Context) (bool, error) {
+	if r.llm != nil {
+		return *r.llm, nil
+	}
+	q := r.query.Select("llm")
+
+	var response bool
+
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
+}
+
+// Whether the module may run commands and services with full network access, and download files with http. Image pulls and git fetches are done by the engine and aren't restricted.
+func (r *ModuleCapabilities) Network(ctx context.Context) (bool, error) {
+	if r.network != nil {
+		return *r.network, nil
+	}
+	q := r.query.Select("network")
+
+	var response bool
+
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
+}
+
+// Whether the module may load secrets from the host's secret providers.
+func (r *ModuleCapabilities) Secrets(ctx context.Context) (bool, error) {
+	if r.secrets != nil {
+		return *r.secrets, nil
+	}
+	q := r.query.Select("secrets")
+
+	var response bool
+
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
+}
+
 // The client generated for the module.
 type ModuleConfigClient struct {
 	query *querybuilder.Selection
@@ -9719,6 +11656,15 @@ func (r *ModuleSource) Blueprint() *ModuleSource {
 	}
 }
 
+// The capabilities the module requires, as declared in its dagger.json. Null if the module declares none, in which case it requires all capabilities.
+func (r *ModuleSource) Capabilities() *ModuleCapabilities {
+	q := r.query.Select("capabilities")
+
+	return &ModuleCapabilities{
+		query: q,
+	}
+}
+
 // The ref to clone the root of the git repo from. Only valid for git sources.
 func (r *ModuleSource) CloneRef(ctx context.Context) (string, error) {
 	if r.cloneRef != nil {
@@ -9878,6 +11824,15 @@ func (r *ModuleSource) GeneratedContextDirectory() *Directory {
 	}
 }
 
+// The capabilities granted to the module when it was installed as a dependency. Null if none were recorded, in which case it is only restricted by the capabilities it declares.
+func (r *ModuleSource) GrantedCapabilities() *ModuleCapabilities {
+	q := r.query.Select("grantedCapabilities")
+
+	return &ModuleCapabilities{
+		query: q,
+	}
+}
+
 // The URL to access the web view of the repository (e.g., GitHub, GitLab, Bitbucket).
 func (r *ModuleSource) HTMLRepoURL(ctx context.Context) (string, error) {
 	if r.htmlRepoURL != nil {
@@ -10203,6 +12158,57 @@ func (r *ModuleSource) WithExperimentalFeatures(features []ModuleSourceExperimen
 	}
 }
 
+// ModuleSourceWithGrantedCapabilitiesOpts contains options for ModuleSource.WithGrantedCapabilities
+type ModuleSourceWithGrantedCapabilitiesOpts struct {
+	// Paths the module may read from the host through defaultPath arguments.
+	HostRead []string
+	// Whether the module may use host unix sockets and tunnels.
+	HostSockets bool
+	// Whether the module may run commands and services with full network access, and download files with http. Image pulls and git fetches are done by the engine and aren't restricted.
+	Network bool
+	// Whether the module may use LLMs.
+	LLM bool
+	// Whether the module may run commands with access to the Dagger API.
+	Exec bool
+	// Whether the module may load secrets from the host's secret providers.
+	Secrets bool
+}
+
+// Record the capabilities granted to the module as a dependency. Recorded in the parent module's dagger.json when installed.
+func (r *ModuleSource) WithGrantedCapabilities(opts ...ModuleSourceWithGrantedCapabilitiesOpts) *ModuleSource {
+	q := r.query.Select("withGrantedCapabilities")
+	for i := len(opts) - 1; i >= 0; i-- {
+		// `hostRead` optional argument
+		if !querybuilder.IsZeroValue(opts[i].HostRead) {
+			q = q.Arg("hostRead", opts[i].HostRead)
+		}
+		// `hostSockets` optional argument
+		if !querybuilder.IsZeroValue(opts[i].HostSockets) {
+			q = q.Arg("hostSockets", opts[i].HostSockets)
+		}
+		// `network` optional argument
+		if !querybuilder.IsZeroValue(opts[i].Network) {
+			q = q.Arg("network", opts[i].Network)
+		}
+		// `llm` optional argument
+		if !querybuilder.IsZeroValue(opts[i].LLM) {
+			q = q.Arg("llm", opts[i].LLM)
+		}
+		// `exec` optional argument
+		if !querybuilder.IsZeroValue(opts[i].Exec) {
+			q = q.Arg("exec", opts[i].Exec)
+		}
+		// `secrets` optional argument
+		if !querybuilder.IsZeroValue(opts[i].Secrets) {
+			q = q.Arg("secrets", opts[i].Secrets)
+		}
+	}
+
+	return &ModuleSource{
+		query: q,
+	}
+}
+
 // Update the module source with additional include patterns for files+directories from its context that are required for building it
 func (r *ModuleSource) WithIncludes(patterns []string) *ModuleSource {
 	q := r.query.Select("withIncludes")
@@ -11125,6 +13131,26 @@ func (r *Client) LoadCloudFromID(id CloudID) *Cloud {
 	}
 }
 
+// Load a ContainerComparison from its ID.
+func (r *Client) LoadContainerComparisonFromID(id ContainerComparisonID) *ContainerComparison {
+	q := r.query.Select("loadContainerComparisonFromID")
+	q = q.Arg("id", id)
+
+	return &ContainerComparison{
+		query: q,
+	}
+}
+
+// Load a ContainerConfigChange from its ID.
+func (r *Client) LoadContainerConfigChangeFromID(id ContainerConfigChangeID) *ContainerConfigChange {
+	q := r.query.Select("loadContainerConfigChangeFromID")
+	q = q.Arg("id", id)
+
+	return &ContainerConfigChange{
+		query: q,
+	}
+}
+
 // Load a Container from its ID.
 func (r *Client) LoadContainerFromID(id ContainerID) *Container {
 	q := r.query.Select("loadContainerFromID")
@@ -11135,6 +13161,16 @@ func (r *Client) LoadContainerFromID(id ContainerID) *Container {
 	}
 }
 
+// Load a ContainerLayerComparison from its ID.
+func (r *Client) LoadContainerLayerComparisonFromID(id ContainerLayerComparisonID) *ContainerLayerComparison {
+	q := r.query.Select("loadContainerLayerComparisonFromID")
+	q = q.Arg("id", id)
+
+	return &ContainerLayerComparison{
+		query: q,
+	}
+}
+
 // Load a CurrentModule from its ID.
 func (r *Client) LoadCurrentModuleFromID(id CurrentModuleID) *CurrentModule {
 	q := r.query.Select("loadCurrentModuleFromID")
@@ -11195,6 +13231,26 @@ func (r *Client) LoadEngineFromID(id EngineID) *Engine {
 	}
 }
 
+// Load a EngineService from its ID.
+func (r *Client) LoadEngineServiceFromID(id EngineServiceID) *EngineService {
+	q := r.query.Select("loadEngineServiceFromID")
+	q = q.Arg("id", id)
+
+	return &EngineService{
+		query: q,
+	}
+}
+
+// Load a EngineSession from its ID.
+func (r *Client) LoadEngineSessionFromID(id EngineSessionID) *EngineSession {
+	q := r.query.Select("loadEngineSessionFromID")
+	q = q.Arg("id", id)
+
+	return &EngineSession{
+		query: q,
+	}
+}
+
 // Load a EnumTypeDef from its ID.
 func (r *Client) LoadEnumTypeDefFromID(id EnumTypeDefID) *EnumTypeDef {
 	q := r.query.Select("loadEnumTypeDefFromID")
@@ -11285,6 +13341,16 @@ func (r *Client) LoadFileFromID(id FileID) *File {
 	}
 }
 
+// Load a FunctionArgConstraints from its ID.
+func (r *Client) LoadFunctionArgConstraintsFromID(id FunctionArgConstraintsID) *FunctionArgConstraints {
+	q := r.query.Select("loadFunctionArgConstraintsFromID")
+	q = q.Arg("id", id)
+
+	return &FunctionArgConstraints{
+		query: q,
+	}
+}
+
 // Load a FunctionArg from its ID.
 func (r *Client) LoadFunctionArgFromID(id FunctionArgID) *FunctionArg {
 	q := r.query.Select("loadFunctionArgFromID")
@@ -11435,6 +13501,16 @@ func (r *Client) LoadListTypeDefFromID(id ListTypeDefID) *ListTypeDef {
 	}
 }
 
+// Load a ModuleCapabilities from its ID.
+func (r *Client) LoadModuleCapabilitiesFromID(id ModuleCapabilitiesID) *ModuleCapabilities {
+	q := r.query.Select("loadModuleCapabilitiesFromID")
+	q = q.Arg("id", id)
+
+	return &ModuleCapabilities{
+		query: q,
+	}
+}
+
 // Load a ModuleConfigClient from its ID.
 func (r *Client) LoadModuleConfigClientFromID(id ModuleConfigClientID) *ModuleConfigClient {
 	q := r.query.Select("loadModuleConfigClientFromID")
@@ -11595,6 +13671,16 @@ func (r *Client) LoadTypeDefFromID(id TypeDefID) *TypeDef {
 	}
 }
 
+// Load a UnionTypeDef from its ID.
+func (r *Client) LoadUnionTypeDefFromID(id UnionTypeDefID) *UnionTypeDef {
+	q := r.query.Select("loadUnionTypeDefFromID")
+	q = q.Arg("id", id)
+
+	return &UnionTypeDef{
+		query: q,
+	}
+}
+
 // Create a new module.
 func (r *Client) Module() *Module {
 	q := r.query.Select("module")
@@ -11644,6 +13730,18 @@ func (r *Client) ModuleSource(refString string, opts ...ModuleSourceOpts) *Modul
 	}
 }
 
+// Attach to a persistent service running in the engine under the given name.
+//
+// The service may have been started by another session, with `start(persistent: true)`. Only the main client can attach to it.
+func (r *Client) RunningService(name string) *Service {
+	q := r.query.Select("runningService")
+	q = q.Arg("name", name)
+
+	return &Service{
+		query: q,
+	}
+}
+
 // SecretOpts contains options for Client.Secret
 type SecretOpts struct {
 	// If set, the given string will be used as the cache key for this secret. This means that any secrets with the same cache key will be considered equivalent in terms of cache lookups, even if they have different URIs or plaintext values.
@@ -12227,6 +14325,7 @@ type Service struct {
 	endpoint *string
 	hostname *string
 	id       *ServiceID
+	name     *string
 	start    *ServiceID
 	stop     *ServiceID
 	sync     *ServiceID
@@ -12335,6 +14434,19 @@ func (r *Service) MarshalJSON() ([]byte, error) {
 	return json.Marshal(id)
 }
 
+// The name of the service, if set with withName.
+func (r *Service) Name(ctx context.Context) (string, error) {
+	if r.name != nil {
+		return *r.name, nil
+	}
+	q := r.query.Select("name")
+
+	var response string
+
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
+}
+
 // Retrieves the list of ports provided by the service.
 func (r *Service) Ports(ctx context.Context) ([]Port, error) {
 	q := r.query.Select("ports")
@@ -12368,11 +14480,33 @@ func (r *Service) Ports(ctx context.Context) ([]Port, error) {
 	return convert(response), nil
 }
 
+// ServiceStartOpts contains options for Service.Start
+type ServiceStartOpts struct {
+	// Keep the service running after the session that started it ends.
+	//
+	// The service must have a name, set with withName. Other sessions can attach to it with runningService, or by starting or binding the same service.
+	Persistent bool
+	// Stop a persistent service once nothing has used it for this long, as a duration string (e.g., "30m", "1h").
+	//
+	// If unset, the service runs until it's stopped.
+	IdleTimeout string
+}
+
 // Start the service and wait for its health checks to succeed.
 //
 // Services bound to a Container do not need to be manually started.
-func (r *Service) Start(ctx context.Context) (*Service, error) {
+func (r *Service) Start(ctx context.Context, opts ...ServiceStartOpts) (*Service, error) {
 	q := r.query.Select("start")
+	for i := len(opts) - 1; i >= 0; i-- {
+		// `persistent` optional argument
+		if !querybuilder.IsZeroValue(opts[i].Persistent) {
+			q = q.Arg("persistent", opts[i].Persistent)
+		}
+		// `idleTimeout` optional argument
+		if !querybuilder.IsZeroValue(opts[i].IdleTimeout) {
+			q = q.Arg("idleTimeout", opts[i].IdleTimeout)
+		}
+	}
 
 	var id ServiceID
 	if err := q.Bind(&id).Execute(ctx); err != nil {
@@ -12390,6 +14524,8 @@ type ServiceStopOpts struct {
 }
 
 // Stop the service.
+//
+// A persistent service can only be stopped by the main client.
 func (r *Service) Stop(ctx context.Context, opts ...ServiceStopOpts) (*Service, error) {
 	q := r.query.Select("stop")
 	for i := len(opts) - 1; i >= 0; i-- {
@@ -12480,6 +14616,16 @@ func (r *Service) WithHostname(hostname string) *Service {
 	}
 }
 
+// Configures a name for the service, under which it can be started as a persistent service shared across sessions.
+func (r *Service) WithName(name string) *Service {
+	q := r.query.Select("withName")
+	q = q.Arg("name", name)
+
+	return &Service{
+		query: q,
+	}
+}
+
 // A Unix or TCP/IP socket that can be mounted into a container.
 type Socket struct {
 	query *querybuilder.Selection
@@ -12911,6 +15057,15 @@ func (r *TypeDef) AsScalar() *ScalarTypeDef {
 	}
 }
 
+// If kind is UNION, the union-specific type definition. If kind is not UNION, this will be null.
+func (r *TypeDef) AsUnion() *UnionTypeDef {
+	q := r.query.Select("asUnion")
+
+	return &UnionTypeDef{
+		query: q,
+	}
+}
+
 // A unique identifier for this TypeDef.
 func (r *TypeDef) ID(ctx context.Context) (TypeDefID, error) {
 	if r.id != nil {
@@ -13216,45 +15371,225 @@ func (r *TypeDef) WithObject(name string, opts ...TypeDefWithObjectOpts) *TypeDe
 		if !querybuilder.IsZeroValue(opts[i].Deprecated) {
 			q = q.Arg("deprecated", opts[i].Deprecated)
 		}
-	}
-	q = q.Arg("name", name)
+	}
+	q = q.Arg("name", name)
+
+	return &TypeDef{
+		query: q,
+	}
+}
+
+// Sets whether this type can be set to null.
+func (r *TypeDef) WithOptional(optional bool) *TypeDef {
+	q := r.query.Select("withOptional")
+	q = q.Arg("optional", optional)
+
+	return &TypeDef{
+		query: q,
+	}
+}
+
+// TypeDefWithScalarOpts contains options for TypeDef.WithScalar
+type TypeDefWithScalarOpts struct {
+	Description string
+}
+
+// Returns a TypeDef of kind Scalar with the provided name.
+func (r *TypeDef) WithScalar(name string, opts ...TypeDefWithScalarOpts) *TypeDef {
+	q := r.query.Select("withScalar")
+	for i := len(opts) - 1; i >= 0; i-- {
+		// `description` optional argument
+		if !querybuilder.IsZeroValue(opts[i].Description) {
+			q = q.Arg("description", opts[i].Description)
+		}
+	}
+	q = q.Arg("name", name)
+
+	return &TypeDef{
+		query: q,
+	}
+}
+
+// TypeDefWithUnionOpts contains options for TypeDef.WithUnion
+type TypeDefWithUnionOpts struct {
+	// A doc string for the union, if any
+	Description string
+	// The source map for the union definition.
+	SourceMap *SourceMap
+}
+
+// Returns a TypeDef of kind Union with the provided name.
+//
+// Note that a union's members may be omitted if the intent is only to refer to a union.
+func (r *TypeDef) WithUnion(name string, opts ...TypeDefWithUnionOpts) *TypeDef {
+	q := r.query.Select("withUnion")
+	for i := len(opts) - 1; i >= 0; i-- {
+		// `description` optional argument
+		if !querybuilder.IsZeroValue(opts[i].Description) {
+			q = q.Arg("description", opts[i].Description)
+		}
+		// `sourceMap` optional argument
+		if !querybuilder.IsZeroValue(opts[i].SourceMap) {
+			q = q.Arg("sourceMap", opts[i].SourceMap)
+		}
+	}
+	q = q.Arg("name", name)
+
+	return &TypeDef{
+		query: q,
+	}
+}
+
+// Adds a member type to a Union TypeDef, failing if the type is not a union or the member is not an object.
+func (r *TypeDef) WithUnionMember(member *TypeDef) *TypeDef {
+	assertNotNil("member", member)
+	q := r.query.Select("withUnionMember")
+	q = q.Arg("member", member)
+
+	return &TypeDef{
+		query: q,
+	}
+}
+
+// A definition of a custom union defined in a Module.
+//
+// A value of a union has exactly one of the union's member types.
+type UnionTypeDef struct {
+	query *querybuilder.Selection
+
+	description      *string
+	id               *UnionTypeDefID
+	name             *string
+	sourceModuleName *string
+}
+
+func (r *UnionTypeDef) WithGraphQLQuery(q *querybuilder.Selection) *UnionTypeDef {
+	return &UnionTypeDef{
+		query: q,
+	}
+}
+
+// The doc string for the union, if any.
+func (r *UnionTypeDef) Description(ctx context.Context) (string, error) {
+	if r.description != nil {
+		return *r.description, nil
+	}
+	q := r.query.Select("description")
+
+	var response string
+
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
+}
+
+// A unique identifier for this UnionTypeDef.
+func (r *UnionTypeDef) ID(ctx context.Context) (UnionTypeDefID, error) {
+	if r.id != nil {
+		return *r.id, nil
+	}
+	q := r.query.Select("id")
+
+	var response UnionTypeDefID
+
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
+}
+
+// XXX_GraphQLType is an internal function. It returns the native GraphQL type name
+func (r *UnionTypeDef) XXX_GraphQLType() string {
+	return "UnionTypeDef"
+}
+
+// XXX_GraphQLIDType is an internal function. It returns the native GraphQL type name for the ID of this object
+func (r *UnionTypeDef) XXX_GraphQLIDType() string {
+	return "UnionTypeDefID"
+}
+
+// XXX_GraphQLID is an internal function. It returns the underlying type ID
+func (r *UnionTypeDef) XXX_GraphQLID(ctx context.Context) (string, error) {
+	id, err := r.ID(ctx)
+	if err != nil {
+		return "", err
+	}
+	return string(id), nil
+}
+
+func (r *UnionTypeDef) MarshalJSON() ([]byte, error) {
+	id, err := r.ID(marshalCtx)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(id)
+}
+
+// The types a value of this union can have. They are always objects.
+func (r *UnionTypeDef) Members(ctx context.Context) ([]TypeDef, error) {
+	q := r.query.Select("members")
+
+	q = q.Select("id")
+
+	type members struct {
+		Id TypeDefID
+	}
+
+	convert := func(fields []members) []TypeDef {
+		out := []TypeDef{}
+
+		for i := range fields {
+			val := TypeDef{id: &fields[i].Id}
+			val.query = q.Root().Select("loadTypeDefFromID").Arg("id", fields[i].Id)
+			out = append(out, val)
+		}
 
-	return &TypeDef{
-		query: q,
+		return out
 	}
-}
+	var response []members
 
-// Sets whether this type can be set to null.
-func (r *TypeDef) WithOptional(optional bool) *TypeDef {
-	q := r.query.Select("withOptional")
-	q = q.Arg("optional", optional)
+	q = q.Bind(&response)
 
-	return &TypeDef{
-		query: q,
+	err := q.Execute(ctx)
+	if err != nil {
+		return nil, err
 	}
-}
 
-// TypeDefWithScalarOpts contains options for TypeDef.WithScalar
-type TypeDefWithScalarOpts struct {
-	Description string
+	return convert(response), nil
 }
 
-// Returns a TypeDef of kind Scalar with the provided name.
-func (r *TypeDef) WithScalar(name string, opts ...TypeDefWithScalarOpts) *TypeDef {
-	q := r.query.Select("withScalar")
-	for i := len(opts) - 1; i >= 0; i-- {
-		// `description` optional argument
-		if !querybuilder.IsZeroValue(opts[i].Description) {
-			q = q.Arg("description", opts[i].Description)
-		}
+// The name of the union.
+func (r *UnionTypeDef) Name(ctx context.Context) (string, error) {
+	if r.name != nil {
+		return *r.name, nil
 	}
-	q = q.Arg("name", name)
+	q := r.query.Select("name")
 
-	return &TypeDef{
+	var response string
+
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
+}
+
+// The location of this union declaration.
+func (r *UnionTypeDef) SourceMap() *SourceMap {
+	q := r.query.Select("sourceMap")
+
+	return &SourceMap{
 		query: q,
 	}
 }
 
+// If this UnionTypeDef is associated with a Module, the name of the module. Unset otherwise.
+func (r *UnionTypeDef) SourceModuleName(ctx context.Context) (string, error) {
+	if r.sourceModuleName != nil {
+		return *r.sourceModuleName, nil
+	}
+	q := r.query.Select("sourceModuleName")
+
+	var response string
+
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
+}
+
 // Sharing mode of the cache volume.
 type CacheSharingMode string
 
@@ -13319,6 +15654,77 @@ const (
 	CacheSharingModeLocked CacheSharingMode = "LOCKED"
 )
 
+// How an entry differs between two containers
+type ContainerChangeKind string
+
+func (ContainerChangeKind) IsEnum() {}
+
+func (v ContainerChangeKind) Name() string {
+	switch v {
+	case ContainerChangeKindAdded:
+		return "ADDED"
+	case ContainerChangeKindRemoved:
+		return "REMOVED"
+	case ContainerChangeKindModified:
+		return "MODIFIED"
+	case ContainerChangeKindUnchanged:
+		return "UNCHANGED"
+	default:
+		return ""
+	}
+}
+
+func (v ContainerChangeKind) Value() string {
+	return string(v)
+}
+
+func (v *ContainerChangeKind) MarshalJSON() ([]byte, error) {
+	if *v == "" {
+		return []byte(`""`), nil
+	}
+	name := v.Name()
+	if name == "" {
+		return nil, fmt.Errorf("invalid enum value %q", *v)
+	}
+	return json.Marshal(name)
+}
+
+func (v *ContainerChangeKind) UnmarshalJSON(dt []byte) error {
+	var s string
+	if err := json.Unmarshal(dt, &s); err != nil {
+		return err
+	}
+	switch s {
+	case "":
+		*v = ""
+	case "ADDED":
+		*v = ContainerChangeKindAdded
+	case "MODIFIED":
+		*v = ContainerChangeKindModified
+	case "REMOVED":
+		*v = ContainerChangeKindRemoved
+	case "UNCHANGED":
+		*v = ContainerChangeKindUnchanged
+	default:
+		return fmt.Errorf("invalid enum value %q", s)
+	}
+	return nil
+}
+
+const (
+	// The entry only exists in the newer container.
+	ContainerChangeKindAdded ContainerChangeKind = "ADDED"
+
+	// The entry only exists in the older container.
+	ContainerChangeKindRemoved ContainerChangeKind = "REMOVED"
+
+	// The entry exists in both containers, with different values.
+	ContainerChangeKindModified ContainerChangeKind = "MODIFIED"
+
+	// The entry is the same in both containers.
+	ContainerChangeKindUnchanged ContainerChangeKind = "UNCHANGED"
+)
+
 // File type.
 type ExistsType string
 
@@ -13658,6 +16064,127 @@ const (
 	ImageMediaTypesDocker           ImageMediaTypes = ImageMediaTypesDockerMediaTypes
 )
 
+// How to squash the layers of an image
+type ImageSquashMode string
+
+func (ImageSquashMode) IsEnum() {}
+
+func (v ImageSquashMode) Name() string {
+	switch v {
+	case ImageSquashModeAll:
+		return "ALL"
+	case ImageSquashModeFromBase:
+		return "FROM_BASE"
+	default:
+		return ""
+	}
+}
+
+func (v ImageSquashMode) Value() string {
+	return string(v)
+}
+
+func (v *ImageSquashMode) MarshalJSON() ([]byte, error) {
+	if *v == "" {
+		return []byte(`""`), nil
+	}
+	name := v.Name()
+	if name == "" {
+		return nil, fmt.Errorf("invalid enum value %q", *v)
+	}
+	return json.Marshal(name)
+}
+
+func (v *ImageSquashMode) UnmarshalJSON(dt []byte) error {
+	var s string
+	if err := json.Unmarshal(dt, &s); err != nil {
+		return err
+	}
+	switch s {
+	case "":
+		*v = ""
+	case "ALL":
+		*v = ImageSquashModeAll
+	case "FROM_BASE":
+		*v = ImageSquashModeFromBase
+	default:
+		return fmt.Errorf("invalid enum value %q", s)
+	}
+	return nil
+}
+
+const (
+	// Squash the whole root filesystem into a single layer.
+	ImageSquashModeAll ImageSquashMode = "ALL"
+
+	// Keep the layers of the image the container was created from, and squash everything on top of them into a single layer.
+	ImageSquashModeFromBase ImageSquashMode = "FROM_BASE"
+)
+
+// Whether an LLM may call a tool
+type LLMToolPolicy string
+
+func (LLMToolPolicy) IsEnum() {}
+
+func (v LLMToolPolicy) Name() string {
+	switch v {
+	case LLMToolPolicyAllow:
+		return "ALLOW"
+	case LLMToolPolicyDeny:
+		return "DENY"
+	case LLMToolPolicyAsk:
+		return "ASK"
+	default:
+		return ""
+	}
+}
+
+func (v LLMToolPolicy) Value() string {
+	return string(v)
+}
+
+func (v *LLMToolPolicy) MarshalJSON() ([]byte, error) {
+	if *v == "" {
+		return []byte(`""`), nil
+	}
+	name := v.Name()
+	if name == "" {
+		return nil, fmt.Errorf("invalid enum value %q", *v)
+	}
+	return json.Marshal(name)
+}
+
+func (v *LLMToolPolicy) UnmarshalJSON(dt []byte) error {
+	var s string
+	if err := json.Unmarshal(dt, &s); err != nil {
+		return err
+	}
+	switch s {
+	case "":
+		*v = ""
+	case "ALLOW":
+		*v = LLMToolPolicyAllow
+	case "ASK":
+		*v = LLMToolPolicyAsk
+	case "DENY":
+		*v = LLMToolPolicyDeny
+	default:
+		return fmt.Errorf("invalid enum value %q", s)
+	}
+	return nil
+}
+
+const (
+	// Let the LLM call the tool
+	LLMToolPolicyAllow LLMToolPolicy = "ALLOW"
+
+	// Refuse to call the tool
+	LLMToolPolicyDeny LLMToolPolicy = "DENY"
+
+	// Ask the user to approve each call of the tool, and refuse if they can't be asked, e.g. in CI
+	LLMToolPolicyAsk LLMToolPolicy = "ASK"
+)
+
 // Experimental features of a module
 type ModuleSourceExperimentalFeature string
 
@@ -13778,6 +16305,70 @@ const (
 	ModuleSourceKindDir       ModuleSourceKind = ModuleSourceKindDirSource
 )
 
+// Network access granted to an execution
+type NetworkMode string
+
+func (NetworkMode) IsEnum() {}
+
+func (v NetworkMode) Name() string {
+	switch v {
+	case NetworkModeFull:
+		return "FULL"
+	case NetworkModeServicesOnly:
+		return "SERVICES_ONLY"
+	case NetworkModeNone:
+		return "NONE"
+	default:
+		return ""
+	}
+}
+
+func (v NetworkMode) Value() string {
+	return string(v)
+}
+
+func (v *NetworkMode) MarshalJSON() ([]byte, error) {
+	if *v == "" {
+		return []byte(`""`), nil
+	}
+	name := v.Name()
+	if name == "" {
+		return nil, fmt.Errorf("invalid enum value %q", *v)
+	}
+	return json.Marshal(name)
+}
+
+func (v *NetworkMode) UnmarshalJSON(dt []byte) error {
+	var s string
+	if err := json.Unmarshal(dt, &s); err != nil {
+		return err
+	}
+	switch s {
+	case "":
+		*v = ""
+	case "FULL":
+		*v = NetworkModeFull
+	case "NONE":
+		*v = NetworkModeNone
+	case "SERVICES_ONLY":
+		*v = NetworkModeServicesOnly
+	default:
+		return fmt.Errorf("invalid enum value %q", s)
+	}
+	return nil
+}
+
+const (
+	// Unrestricted network access
+	NetworkModeFull NetworkMode = "FULL"
+
+	// Network access to services only, e.g. those bound with "withServiceBinding"
+	NetworkModeServicesOnly NetworkMode = "SERVICES_ONLY"
+
+	// No network access, apart from loopback
+	NetworkModeNone NetworkMode = "NONE"
+)
+
 // Transport layer network protocol associated to a port.
 type NetworkProtocol string
 
@@ -13897,6 +16488,63 @@ const (
 	ReturnTypeAny ReturnType = "ANY"
 )
 
+// Format of a software bill of materials
+type SBOMFormat string
+
+func (SBOMFormat) IsEnum() {}
+
+func (v SBOMFormat) Name() string {
+	switch v {
+	case SBOMFormatSpdxJson:
+		return "SPDX_JSON"
+	case SBOMFormatCyclonedxJson:
+		return "CYCLONEDX_JSON"
+	default:
+		return ""
+	}
+}
+
+func (v SBOMFormat) Value() string {
+	return string(v)
+}
+
+func (v *SBOMFormat) MarshalJSON() ([]byte, error) {
+	if *v == "" {
+		return []byte(`""`), nil
+	}
+	name := v.Name()
+	if name == "" {
+		return nil, fmt.Errorf("invalid enum value %q", *v)
+	}
+	return json.Marshal(name)
+}
+
+func (v *SBOMFormat) UnmarshalJSON(dt []byte) error {
+	var s string
+	if err := json.Unmarshal(dt, &s); err != nil {
+		return err
+	}
+	switch s {
+	case "":
+		*v = ""
+	case "CYCLONEDX_JSON":
+		*v = SBOMFormatCyclonedxJson
+	case "SPDX_JSON":
+		*v = SBOMFormatSpdxJson
+	default:
+		return fmt.Errorf("invalid enum value %q", s)
+	}
+	return nil
+}
+
+const (
+	// SPDX 2.3 JSON
+	SBOMFormatSpdxJson SBOMFormat = "SPDX_JSON"
+
+	// CycloneDX 1.5 JSON
+	SBOMFormatCyclonedxJson SBOMFormat = "CYCLONEDX_JSON"
+)
+
 // Distinguishes the different kinds of TypeDefs.
 type TypeDefKind string
 
@@ -13926,6 +16574,8 @@ func (v TypeDefKind) Name() string {
 		return "VOID_KIND"
 	case TypeDefKindEnumKind:
 		return "ENUM_KIND"
+	case TypeDefKindUnionKind:
+		return "UNION_KIND"
 	default:
 		return ""
 	}
@@ -13994,6 +16644,8 @@ func (v *TypeDefKind) UnmarshalJSON(dt []byte) error {
 		*v = TypeDefKindString
 	case "STRING_KIND":
 		*v = TypeDefKindStringKind
+	case "UNION_KIND":
+		*v = TypeDefKindUnionKind
 	case "VOID":
 		*v = TypeDefKindVoid
 	case "VOID_KIND":
@@ -14079,4 +16731,9 @@ const (
 	//
 	// Always paired with an EnumTypeDef.
 	TypeDefKindEnum TypeDefKind = TypeDefKindEnumKind
+
+	// Always paired with a UnionTypeDef.
+	//
+	// A named type whose values have exactly one of a set of object types.
+	TypeDefKindUnionKind TypeDefKind = "UNION_KIND"
 )
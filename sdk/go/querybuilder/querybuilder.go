@@ -23,6 +23,10 @@ type Selection struct {
 	bind     any
 	multiple bool
 
+	// typeCondition makes the selection an inline fragment on the named
+	// type, e.g. to select a member of a union, rather than a field
+	typeCondition string
+
 	prev *Selection
 
 	client graphql.Client
@@ -57,6 +61,16 @@ func (s *Selection) Select(name string) *Selection {
 	return s.SelectWithAlias("", name)
 }
 
+// InlineFragment selects the fields of the following selections only if the
+// value has the given type, e.g. to select a member of a union.
+func (s *Selection) InlineFragment(typeName string) *Selection {
+	return &Selection{
+		typeCondition: typeName,
+		prev:          s,
+		client:        s.client,
+	}
+}
+
 func (s *Selection) SelectMultiple(name ...string) *Selection {
 	sel := s.SelectWithAlias("", strings.Join(name, " "))
 	sel.multiple = true
@@ -112,6 +126,12 @@ func (s *Selection) Build(ctx context.Context) (string, error) {
 
 		b.WriteRune('{')
 
+		if sel.typeCondition != "" {
+			b.WriteString("... on ")
+			b.WriteString(sel.typeCondition)
+			continue
+		}
+
 		if sel.alias != "" {
 			b.WriteString(sel.alias)
 			b.WriteRune(':')
@@ -146,7 +166,8 @@ func (s *Selection) unpack(data any) error {
 			k = i.alias
 		}
 
-		if !i.multiple {
+		// fragments select fields of the same value
+		if !i.multiple && i.typeCondition == "" {
 			if f, ok := data.(map[string]any); ok {
 				data = f[k]
 			}
@@ -150,6 +150,30 @@ func TestUnpackList(t *testing.T) {
 	require.EqualValues(t, []string{"one", "two", "three"}, contents)
 }
 
+func TestInlineFragment(t *testing.T) {
+	var id string
+	root := Query().
+		Select("artifact").Arg("kind", "file").
+		InlineFragment("File").
+		Select("id").Bind(&id)
+
+	q, err := root.Build(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, `query{artifact(kind:"file"){... on File{id}}}`, q)
+
+	var response any
+	err = json.Unmarshal([]byte(`
+		{
+			"artifact": {
+				"id": "file-id"
+			}
+		}
+	`), &response)
+	require.NoError(t, err)
+	require.NoError(t, root.unpack(response))
+	require.Equal(t, "file-id", id)
+}
+
 func TestSiblings(t *testing.T) {
 	q, err := Query().
 		Select("foo").
@@ -32,6 +32,14 @@ const (
 	// recognizable value to the user.
 	DagOutputAttr = "dagger.io/dag.output"
 
+	// The DAG digest of the most similar call seen before, set when a call
+	// misses the cache.
+	DagCacheMissPreviousAttr = "dagger.io/dag.cache.miss.previous"
+
+	// Explanations of how a call that missed the cache differs from the call
+	// in DagCacheMissPreviousAttr, e.g. which argument or content changed.
+	DagCacheMissReasonsAttr = "dagger.io/dag.cache.miss.reasons"
+
 	// Indicates that this span is "internal" and can be hidden by default.
 	//
 	// Internal spans may typically be revealed with a toggle.
@@ -137,6 +145,12 @@ const (
 	// The name of an MCP server providing the tool.
 	LLMToolServerAttr = "dagger.io/llm.tool.server"
 
+	// The name of an LLM output whose value is being checked.
+	LLMOutputCheckAttr = "dagger.io/llm.output.check"
+	// The number of the check attempt, and the maximum number of attempts.
+	LLMOutputCheckAttemptAttr     = "dagger.io/llm.output.check.attempt"
+	LLMOutputCheckMaxAttemptsAttr = "dagger.io/llm.output.check.max_attempts"
+
 	// The list of LLM tool arguments to show to the user.
 	LLMToolArgNamesAttr  = "dagger.io/llm.tool.args.names"
 	LLMToolArgValuesAttr = "dagger.io/llm.tool.args.values"
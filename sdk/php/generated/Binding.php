@@ -73,6 +73,33 @@ class Binding extends Client\AbstractObject implements Client\IdAble
         return new \Dagger\Container($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
     }
 
+    /**
+     * Retrieve the binding value, as type ContainerComparison
+     */
+    public function asContainerComparison(): ContainerComparison
+    {
+        $innerQueryBuilder = new \Dagger\Client\QueryBuilder('asContainerComparison');
+        return new \Dagger\ContainerComparison($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
+    }
+
+    /**
+     * Retrieve the binding value, as type ContainerConfigChange
+     */
+    public function asContainerConfigChange(): ContainerConfigChange
+    {
+        $innerQueryBuilder = new \Dagger\Client\QueryBuilder('asContainerConfigChange');
+        return new \Dagger\ContainerConfigChange($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
+    }
+
+    /**
+     * Retrieve the binding value, as type ContainerLayerComparison
+     */
+    public function asContainerLayerComparison(): ContainerLayerComparison
+    {
+        $innerQueryBuilder = new \Dagger\Client\QueryBuilder('asContainerLayerComparison');
+        return new \Dagger\ContainerLayerComparison($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
+    }
+
     /**
      * Retrieve the binding value, as type Directory
      */
@@ -82,6 +109,24 @@ class Binding extends Client\AbstractObject implements Client\IdAble
         return new \Dagger\Directory($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
     }
 
+    /**
+     * Retrieve the binding value, as type EngineService
+     */
+    public function asEngineService(): EngineService
+    {
+        $innerQueryBuilder = new \Dagger\Client\QueryBuilder('asEngineService');
+        return new \Dagger\EngineService($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
+    }
+
+    /**
+     * Retrieve the binding value, as type EngineSession
+     */
+    public function asEngineSession(): EngineSession
+    {
+        $innerQueryBuilder = new \Dagger\Client\QueryBuilder('asEngineSession');
+        return new \Dagger\EngineSession($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
+    }
+
     /**
      * Retrieve the binding value, as type Env
      */
@@ -109,6 +154,15 @@ class Binding extends Client\AbstractObject implements Client\IdAble
         return new \Dagger\File($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
     }
 
+    /**
+     * Retrieve the binding value, as type FunctionArgConstraints
+     */
+    public function asFunctionArgConstraints(): FunctionArgConstraints
+    {
+        $innerQueryBuilder = new \Dagger\Client\QueryBuilder('asFunctionArgConstraints');
+        return new \Dagger\FunctionArgConstraints($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
+    }
+
     /**
      * Retrieve the binding value, as type GitRef
      */
@@ -145,6 +199,15 @@ class Binding extends Client\AbstractObject implements Client\IdAble
         return new \Dagger\Module($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
     }
 
+    /**
+     * Retrieve the binding value, as type ModuleCapabilities
+     */
+    public function asModuleCapabilities(): ModuleCapabilities
+    {
+        $innerQueryBuilder = new \Dagger\Client\QueryBuilder('asModuleCapabilities');
+        return new \Dagger\ModuleCapabilities($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
+    }
+
     /**
      * Retrieve the binding value, as type ModuleConfigClient
      */
@@ -226,6 +289,15 @@ class Binding extends Client\AbstractObject implements Client\IdAble
         return (string)$this->queryLeaf($leafQueryBuilder, 'asString');
     }
 
+    /**
+     * Retrieve the binding value, as type UnionTypeDef
+     */
+    public function asUnionTypeDef(): UnionTypeDef
+    {
+        $innerQueryBuilder = new \Dagger\Client\QueryBuilder('asUnionTypeDef');
+        return new \Dagger\UnionTypeDef($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
+    }
+
     /**
      * Returns the digest of the binding value
      */
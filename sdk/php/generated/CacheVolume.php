@@ -21,4 +21,24 @@ class CacheVolume extends Client\AbstractObject implements Client\IdAble
         $leafQueryBuilder = new \Dagger\Client\QueryBuilder('id');
         return new \Dagger\CacheVolumeId((string)$this->queryLeaf($leafQueryBuilder, 'id'));
     }
+
+    /**
+     * Pin the contents of this cache volume, so that they're never pruned by garbage collection.
+     *
+     * Contents written to the volume later are pinned too, until it's unpinned. Only the main client can pin a cache volume.
+     */
+    public function pin(): void
+    {
+        $leafQueryBuilder = new \Dagger\Client\QueryBuilder('pin');
+        $this->queryLeaf($leafQueryBuilder, 'pin');
+    }
+
+    /**
+     * Unpin the contents of this cache volume, so that they can be pruned by garbage collection again.
+     */
+    public function unpin(): void
+    {
+        $leafQueryBuilder = new \Dagger\Client\QueryBuilder('unpin');
+        $this->queryLeaf($leafQueryBuilder, 'unpin');
+    }
 }
@@ -112,4 +112,16 @@ class Changeset extends Client\AbstractObject implements Client\IdAble
         $leafQueryBuilder = new \Dagger\Client\QueryBuilder('sync');
         return new \Dagger\ChangesetId((string)$this->queryLeaf($leafQueryBuilder, 'sync'));
     }
+
+    /**
+     * Add changes to this changeset, if they don't conflict with it.
+     *
+     * Both changesets must compare against the same directory. The changes conflict if a file is changed by both, or changed by one and removed by the other.
+     */
+    public function withChangeset(ChangesetId|Changeset $changes): Changeset
+    {
+        $innerQueryBuilder = new \Dagger\Client\QueryBuilder('withChangeset');
+        $innerQueryBuilder->setArgument('changes', $changes);
+        return new \Dagger\Changeset($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
+    }
 }
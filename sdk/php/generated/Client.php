@@ -366,6 +366,27 @@ class Client extends Client\AbstractClient
         return new \Dagger\Cloud($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
     }
 
+    /**
+     * Load a ContainerComparison from its ID.
+     */
+    public function loadContainerComparisonFromID(ContainerComparisonId|ContainerComparison $id): ContainerComparison
+    {
+        $innerQueryBuilder = new \Dagger\Client\QueryBuilder('loadContainerComparisonFromID');
+        $innerQueryBuilder->setArgument('id', $id);
+        return new \Dagger\ContainerComparison($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
+    }
+
+    /**
+     * Load a ContainerConfigChange from its ID.
+     */
+    public function loadContainerConfigChangeFromID(
+        ContainerConfigChangeId|ContainerConfigChange $id,
+    ): ContainerConfigChange {
+        $innerQueryBuilder = new \Dagger\Client\QueryBuilder('loadContainerConfigChangeFromID');
+        $innerQueryBuilder->setArgument('id', $id);
+        return new \Dagger\ContainerConfigChange($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
+    }
+
     /**
      * Load a Container from its ID.
      */
@@ -376,6 +397,17 @@ class Client extends Client\AbstractClient
         return new \Dagger\Container($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
     }
 
+    /**
+     * Load a ContainerLayerComparison from its ID.
+     */
+    public function loadContainerLayerComparisonFromID(
+        ContainerLayerComparisonId|ContainerLayerComparison $id,
+    ): ContainerLayerComparison {
+        $innerQueryBuilder = new \Dagger\Client\QueryBuilder('loadContainerLayerComparisonFromID');
+        $innerQueryBuilder->setArgument('id', $id);
+        return new \Dagger\ContainerLayerComparison($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
+    }
+
     /**
      * Load a CurrentModule from its ID.
      */
@@ -436,6 +468,26 @@ class Client extends Client\AbstractClient
         return new \Dagger\Engine($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
     }
 
+    /**
+     * Load a EngineService from its ID.
+     */
+    public function loadEngineServiceFromID(EngineServiceId|EngineService $id): EngineService
+    {
+        $innerQueryBuilder = new \Dagger\Client\QueryBuilder('loadEngineServiceFromID');
+        $innerQueryBuilder->setArgument('id', $id);
+        return new \Dagger\EngineService($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
+    }
+
+    /**
+     * Load a EngineSession from its ID.
+     */
+    public function loadEngineSessionFromID(EngineSessionId|EngineSession $id): EngineSession
+    {
+        $innerQueryBuilder = new \Dagger\Client\QueryBuilder('loadEngineSessionFromID');
+        $innerQueryBuilder->setArgument('id', $id);
+        return new \Dagger\EngineSession($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
+    }
+
     /**
      * Load a EnumTypeDef from its ID.
      */
@@ -526,6 +578,17 @@ class Client extends Client\AbstractClient
         return new \Dagger\File($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
     }
 
+    /**
+     * Load a FunctionArgConstraints from its ID.
+     */
+    public function loadFunctionArgConstraintsFromID(
+        FunctionArgConstraintsId|FunctionArgConstraints $id,
+    ): FunctionArgConstraints {
+        $innerQueryBuilder = new \Dagger\Client\QueryBuilder('loadFunctionArgConstraintsFromID');
+        $innerQueryBuilder->setArgument('id', $id);
+        return new \Dagger\FunctionArgConstraints($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
+    }
+
     /**
      * Load a FunctionArg from its ID.
      */
@@ -677,6 +740,16 @@ class Client extends Client\AbstractClient
         return new \Dagger\ListTypeDef($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
     }
 
+    /**
+     * Load a ModuleCapabilities from its ID.
+     */
+    public function loadModuleCapabilitiesFromID(ModuleCapabilitiesId|ModuleCapabilities $id): ModuleCapabilities
+    {
+        $innerQueryBuilder = new \Dagger\Client\QueryBuilder('loadModuleCapabilitiesFromID');
+        $innerQueryBuilder->setArgument('id', $id);
+        return new \Dagger\ModuleCapabilities($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
+    }
+
     /**
      * Load a ModuleConfigClient from its ID.
      */
@@ -837,6 +910,16 @@ class Client extends Client\AbstractClient
         return new \Dagger\TypeDef($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
     }
 
+    /**
+     * Load a UnionTypeDef from its ID.
+     */
+    public function loadUnionTypeDefFromID(UnionTypeDefId|UnionTypeDef $id): UnionTypeDef
+    {
+        $innerQueryBuilder = new \Dagger\Client\QueryBuilder('loadUnionTypeDefFromID');
+        $innerQueryBuilder->setArgument('id', $id);
+        return new \Dagger\UnionTypeDef($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
+    }
+
     /**
      * Create a new module.
      */
@@ -873,6 +956,18 @@ class Client extends Client\AbstractClient
         return new \Dagger\ModuleSource($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
     }
 
+    /**
+     * Attach to a persistent service running in the engine under the given name.
+     *
+     * The service may have been started by another session, with `start(persistent: true)`. Only the main client can attach to it.
+     */
+    public function runningService(string $name): Service
+    {
+        $innerQueryBuilder = new \Dagger\Client\QueryBuilder('runningService');
+        $innerQueryBuilder->setArgument('name', $name);
+        return new \Dagger\Service($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
+    }
+
     /**
      * Creates a new secret.
      */
@@ -25,6 +25,8 @@ class Container extends Client\AbstractObject implements Client\IdAble
         ?bool $insecureRootCapabilities = false,
         ?bool $expand = false,
         ?bool $noInit = false,
+        ?NetworkMode $networkMode = null,
+        ?array $egressAllowlist = null,
     ): Service {
         $innerQueryBuilder = new \Dagger\Client\QueryBuilder('asService');
         if (null !== $args) {
@@ -45,6 +47,12 @@ class Container extends Client\AbstractObject implements Client\IdAble
         if (null !== $noInit) {
         $innerQueryBuilder->setArgument('noInit', $noInit);
         }
+        if (null !== $networkMode) {
+        $innerQueryBuilder->setArgument('networkMode', $networkMode);
+        }
+        if (null !== $egressAllowlist) {
+        $innerQueryBuilder->setArgument('egressAllowlist', $egressAllowlist);
+        }
         return new \Dagger\Service($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
     }
 
@@ -80,6 +88,18 @@ class Container extends Client\AbstractObject implements Client\IdAble
         return (string)$this->queryLeaf($leafQueryBuilder, 'combinedOutput');
     }
 
+    /**
+     * Compare this container's image configuration, layers and root filesystem with another container, typically a newer version of it.
+     *
+     * Layers that haven't been exported yet are compressed to compute their digest and size.
+     */
+    public function compare(ContainerId|Container $other): ContainerComparison
+    {
+        $innerQueryBuilder = new \Dagger\Client\QueryBuilder('compare');
+        $innerQueryBuilder->setArgument('other', $other);
+        return new \Dagger\ContainerComparison($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
+    }
+
     /**
      * Return the container's default arguments.
      */
@@ -197,6 +217,11 @@ class Container extends Client\AbstractObject implements Client\IdAble
         ?ImageLayerCompression $forcedCompression = null,
         ?ImageMediaTypes $mediaTypes = null,
         ?bool $expand = false,
+        SecretId|Secret|null $signingKey = null,
+        SecretId|Secret|null $signingKeyPassword = null,
+        ?bool $provenance = false,
+        ?SBOMFormat $sbom = null,
+        ?ImageSquashMode $squash = null,
     ): string {
         $leafQueryBuilder = new \Dagger\Client\QueryBuilder('export');
         $leafQueryBuilder->setArgument('path', $path);
@@ -212,6 +237,21 @@ class Container extends Client\AbstractObject implements Client\IdAble
         if (null !== $expand) {
         $leafQueryBuilder->setArgument('expand', $expand);
         }
+        if (null !== $signingKey) {
+        $leafQueryBuilder->setArgument('signingKey', $signingKey);
+        }
+        if (null !== $signingKeyPassword) {
+        $leafQueryBuilder->setArgument('signingKeyPassword', $signingKeyPassword);
+        }
+        if (null !== $provenance) {
+        $leafQueryBuilder->setArgument('provenance', $provenance);
+        }
+        if (null !== $sbom) {
+        $leafQueryBuilder->setArgument('sbom', $sbom);
+        }
+        if (null !== $squash) {
+        $leafQueryBuilder->setArgument('squash', $squash);
+        }
         return (string)$this->queryLeaf($leafQueryBuilder, 'export');
     }
 
@@ -352,6 +392,11 @@ class Container extends Client\AbstractObject implements Client\IdAble
         ?array $platformVariants = null,
         ?ImageLayerCompression $forcedCompression = null,
         ?ImageMediaTypes $mediaTypes = null,
+        SecretId|Secret|null $signingKey = null,
+        SecretId|Secret|null $signingKeyPassword = null,
+        ?bool $provenance = false,
+        ?SBOMFormat $sbom = null,
+        ?ImageSquashMode $squash = null,
     ): string {
         $leafQueryBuilder = new \Dagger\Client\QueryBuilder('publish');
         $leafQueryBuilder->setArgument('address', $address);
@@ -364,6 +409,21 @@ class Container extends Client\AbstractObject implements Client\IdAble
         if (null !== $mediaTypes) {
         $leafQueryBuilder->setArgument('mediaTypes', $mediaTypes);
         }
+        if (null !== $signingKey) {
+        $leafQueryBuilder->setArgument('signingKey', $signingKey);
+        }
+        if (null !== $signingKeyPassword) {
+        $leafQueryBuilder->setArgument('signingKeyPassword', $signingKeyPassword);
+        }
+        if (null !== $provenance) {
+        $leafQueryBuilder->setArgument('provenance', $provenance);
+        }
+        if (null !== $sbom) {
+        $leafQueryBuilder->setArgument('sbom', $sbom);
+        }
+        if (null !== $squash) {
+        $leafQueryBuilder->setArgument('squash', $squash);
+        }
         return (string)$this->queryLeaf($leafQueryBuilder, 'publish');
     }
 
@@ -376,6 +436,20 @@ class Container extends Client\AbstractObject implements Client\IdAble
         return new \Dagger\Directory($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
     }
 
+    /**
+     * Return a software bill of materials (SBOM) of the packages in the container's root filesystem.
+     *
+     * OS packages installed with apk, dpkg or rpm are detected, as well as the dependencies in Go, npm, Python and Cargo lockfiles. Mounts are not scanned.
+     */
+    public function sbom(?SBOMFormat $format = null): File
+    {
+        $innerQueryBuilder = new \Dagger\Client\QueryBuilder('sbom');
+        if (null !== $format) {
+        $innerQueryBuilder->setArgument('format', $format);
+        }
+        return new \Dagger\File($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
+    }
+
     /**
      * Return file status
      */
@@ -457,6 +531,8 @@ class Container extends Client\AbstractObject implements Client\IdAble
         ?bool $insecureRootCapabilities = false,
         ?bool $expand = false,
         ?bool $noInit = false,
+        ?NetworkMode $networkMode = null,
+        ?array $egressAllowlist = null,
     ): void {
         $leafQueryBuilder = new \Dagger\Client\QueryBuilder('up');
         if (null !== $random) {
@@ -483,6 +559,12 @@ class Container extends Client\AbstractObject implements Client\IdAble
         if (null !== $noInit) {
         $leafQueryBuilder->setArgument('noInit', $noInit);
         }
+        if (null !== $networkMode) {
+        $leafQueryBuilder->setArgument('networkMode', $networkMode);
+        }
+        if (null !== $egressAllowlist) {
+        $leafQueryBuilder->setArgument('egressAllowlist', $egressAllowlist);
+        }
         $this->queryLeaf($leafQueryBuilder, 'up');
     }
 
@@ -506,6 +588,17 @@ class Container extends Client\AbstractObject implements Client\IdAble
         return new \Dagger\Container($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
     }
 
+    /**
+     * Pauses the pipeline when this container is evaluated, opening an interactive terminal for it.
+     *
+     * The pipeline continues when the terminal exits. Breakpoints only pause when the CLI runs with --interactive or --break-on, so they can be left in code, and they don't affect the cache of the pipeline.
+     */
+    public function withBreakpoint(): Container
+    {
+        $innerQueryBuilder = new \Dagger\Client\QueryBuilder('withBreakpoint');
+        return new \Dagger\Container($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
+    }
+
     /**
      * Configures default arguments for future commands. Like CMD in Dockerfile.
      */
@@ -630,6 +723,8 @@ class Container extends Client\AbstractObject implements Client\IdAble
         ?bool $insecureRootCapabilities = false,
         ?bool $expand = false,
         ?bool $noInit = false,
+        ?NetworkMode $networkMode = null,
+        ?array $egressAllowlist = null,
     ): Container {
         $innerQueryBuilder = new \Dagger\Client\QueryBuilder('withExec');
         $innerQueryBuilder->setArgument('args', $args);
@@ -663,6 +758,12 @@ class Container extends Client\AbstractObject implements Client\IdAble
         if (null !== $noInit) {
         $innerQueryBuilder->setArgument('noInit', $noInit);
         }
+        if (null !== $networkMode) {
+        $innerQueryBuilder->setArgument('networkMode', $networkMode);
+        }
+        if (null !== $egressAllowlist) {
+        $innerQueryBuilder->setArgument('egressAllowlist', $egressAllowlist);
+        }
         return new \Dagger\Container($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
     }
 
@@ -756,6 +857,17 @@ class Container extends Client\AbstractObject implements Client\IdAble
         return new \Dagger\Container($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
     }
 
+    /**
+     * Retrieves this container with a layer boundary at the current state of its root filesystem.
+     *
+     * When the container is published or exported, the changes between consecutive boundaries are grouped into a single layer, on top of the layers of the image the container was created from. Changes after the last boundary make a layer of their own.
+     */
+    public function withLayerBoundary(): Container
+    {
+        $innerQueryBuilder = new \Dagger\Client\QueryBuilder('withLayerBoundary');
+        return new \Dagger\Container($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
+    }
+
     /**
      * Retrieves this container plus a cache volume mounted at the given path.
      */
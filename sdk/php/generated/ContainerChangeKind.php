@@ -0,0 +1,27 @@
+<?php
+
+/**
+ * This class has been generated by dagger-php-sdk. DO NOT EDIT.
+ */
+
+declare(strict_types=1);
+
+namespace Dagger;
+
+/**
+ * How an entry differs between two containers
+ */
+enum ContainerChangeKind: string
+{
+    /** The entry only exists in the newer container. */
+    case ADDED = 'ADDED';
+
+    /** The entry only exists in the older container. */
+    case REMOVED = 'REMOVED';
+
+    /** The entry exists in both containers, with different values. */
+    case MODIFIED = 'MODIFIED';
+
+    /** The entry is the same in both containers. */
+    case UNCHANGED = 'UNCHANGED';
+}
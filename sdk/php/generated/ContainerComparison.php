@@ -0,0 +1,78 @@
+<?php
+
+/**
+ * This class has been generated by dagger-php-sdk. DO NOT EDIT.
+ */
+
+declare(strict_types=1);
+
+namespace Dagger;
+
+/**
+ * A comparison between the image configuration, layers and root filesystem of two containers.
+ */
+class ContainerComparison extends Client\AbstractObject implements Client\IdAble
+{
+    /**
+     * The newer container.
+     */
+    public function after(): Container
+    {
+        $innerQueryBuilder = new \Dagger\Client\QueryBuilder('after');
+        return new \Dagger\Container($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
+    }
+
+    /**
+     * The older container to compare against.
+     */
+    public function before(): Container
+    {
+        $innerQueryBuilder = new \Dagger\Client\QueryBuilder('before');
+        return new \Dagger\Container($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
+    }
+
+    /**
+     * The changes between the root filesystems of the containers.
+     */
+    public function changes(): Changeset
+    {
+        $innerQueryBuilder = new \Dagger\Client\QueryBuilder('changes');
+        return new \Dagger\Changeset($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
+    }
+
+    /**
+     * Changes to the image configuration, e.g. environment variables, entrypoint, user, labels and exposed ports.
+     */
+    public function configChanges(): array
+    {
+        $leafQueryBuilder = new \Dagger\Client\QueryBuilder('configChanges');
+        return (array)$this->queryLeaf($leafQueryBuilder, 'configChanges');
+    }
+
+    /**
+     * A unique identifier for this ContainerComparison.
+     */
+    public function id(): ContainerComparisonId
+    {
+        $leafQueryBuilder = new \Dagger\Client\QueryBuilder('id');
+        return new \Dagger\ContainerComparisonId((string)$this->queryLeaf($leafQueryBuilder, 'id'));
+    }
+
+    /**
+     * Comparison of the layers of the root filesystems, by position from the base layer.
+     */
+    public function layers(): array
+    {
+        $leafQueryBuilder = new \Dagger\Client\QueryBuilder('layers');
+        return (array)$this->queryLeaf($leafQueryBuilder, 'layers');
+    }
+
+    /**
+     * A human-readable summary of the comparison.
+     */
+    public function summary(): string
+    {
+        $leafQueryBuilder = new \Dagger\Client\QueryBuilder('summary');
+        return (string)$this->queryLeaf($leafQueryBuilder, 'summary');
+    }
+}
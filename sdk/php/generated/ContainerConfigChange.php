@@ -0,0 +1,69 @@
+<?php
+
+/**
+ * This class has been generated by dagger-php-sdk. DO NOT EDIT.
+ */
+
+declare(strict_types=1);
+
+namespace Dagger;
+
+/**
+ * A change to the image configuration between two containers.
+ */
+class ContainerConfigChange extends Client\AbstractObject implements Client\IdAble
+{
+    /**
+     * The value in the newer container. Lists are encoded as JSON arrays.
+     */
+    public function after(): string
+    {
+        $leafQueryBuilder = new \Dagger\Client\QueryBuilder('after');
+        return (string)$this->queryLeaf($leafQueryBuilder, 'after');
+    }
+
+    /**
+     * The value in the older container. Lists are encoded as JSON arrays.
+     */
+    public function before(): string
+    {
+        $leafQueryBuilder = new \Dagger\Client\QueryBuilder('before');
+        return (string)$this->queryLeaf($leafQueryBuilder, 'before');
+    }
+
+    /**
+     * The changed setting, named after the API that sets it, e.g. "envVariable", "entrypoint" or "label".
+     */
+    public function field(): string
+    {
+        $leafQueryBuilder = new \Dagger\Client\QueryBuilder('field');
+        return (string)$this->queryLeaf($leafQueryBuilder, 'field');
+    }
+
+    /**
+     * A unique identifier for this ContainerConfigChange.
+     */
+    public function id(): ContainerConfigChangeId
+    {
+        $leafQueryBuilder = new \Dagger\Client\QueryBuilder('id');
+        return new \Dagger\ContainerConfigChangeId((string)$this->queryLeaf($leafQueryBuilder, 'id'));
+    }
+
+    /**
+     * The changed entry of settings that have several, i.e. the name of an environment variable or label, or an exposed port such as "8080/tcp".
+     */
+    public function key(): string
+    {
+        $leafQueryBuilder = new \Dagger\Client\QueryBuilder('key');
+        return (string)$this->queryLeaf($leafQueryBuilder, 'key');
+    }
+
+    /**
+     * How the setting changed.
+     */
+    public function kind(): ContainerChangeKind
+    {
+        $leafQueryBuilder = new \Dagger\Client\QueryBuilder('kind');
+        return \Dagger\ContainerChangeKind::from((string)$this->queryLeaf($leafQueryBuilder, 'kind'));
+    }
+}
@@ -0,0 +1,78 @@
+<?php
+
+/**
+ * This class has been generated by dagger-php-sdk. DO NOT EDIT.
+ */
+
+declare(strict_types=1);
+
+namespace Dagger;
+
+/**
+ * A comparison of the layers of two containers at the same position.
+ */
+class ContainerLayerComparison extends Client\AbstractObject implements Client\IdAble
+{
+    /**
+     * The digest of the layer in the newer container, if it has one at this position.
+     */
+    public function afterDigest(): string
+    {
+        $leafQueryBuilder = new \Dagger\Client\QueryBuilder('afterDigest');
+        return (string)$this->queryLeaf($leafQueryBuilder, 'afterDigest');
+    }
+
+    /**
+     * The compressed size of the layer in the newer container, in bytes.
+     */
+    public function afterSize(): int
+    {
+        $leafQueryBuilder = new \Dagger\Client\QueryBuilder('afterSize');
+        return (int)$this->queryLeaf($leafQueryBuilder, 'afterSize');
+    }
+
+    /**
+     * The digest of the layer in the older container, if it has one at this position.
+     */
+    public function beforeDigest(): string
+    {
+        $leafQueryBuilder = new \Dagger\Client\QueryBuilder('beforeDigest');
+        return (string)$this->queryLeaf($leafQueryBuilder, 'beforeDigest');
+    }
+
+    /**
+     * The compressed size of the layer in the older container, in bytes.
+     */
+    public function beforeSize(): int
+    {
+        $leafQueryBuilder = new \Dagger\Client\QueryBuilder('beforeSize');
+        return (int)$this->queryLeaf($leafQueryBuilder, 'beforeSize');
+    }
+
+    /**
+     * A unique identifier for this ContainerLayerComparison.
+     */
+    public function id(): ContainerLayerComparisonId
+    {
+        $leafQueryBuilder = new \Dagger\Client\QueryBuilder('id');
+        return new \Dagger\ContainerLayerComparisonId((string)$this->queryLeaf($leafQueryBuilder, 'id'));
+    }
+
+    /**
+     * The position of the layer, starting from the base layer at 0.
+     */
+    public function index(): int
+    {
+        $leafQueryBuilder = new \Dagger\Client\QueryBuilder('index');
+        return (int)$this->queryLeaf($leafQueryBuilder, 'index');
+    }
+
+    /**
+     * Whether the layer differs between the containers.
+     */
+    public function kind(): ContainerChangeKind
+    {
+        $leafQueryBuilder = new \Dagger\Client\QueryBuilder('kind');
+        return \Dagger\ContainerChangeKind::from((string)$this->queryLeaf($leafQueryBuilder, 'kind'));
+    }
+}
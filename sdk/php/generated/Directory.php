@@ -239,6 +239,20 @@ class Directory extends Client\AbstractObject implements Client\IdAble
         return (string)$this->queryLeaf($leafQueryBuilder, 'name');
     }
 
+    /**
+     * Return a software bill of materials (SBOM) of the packages in the directory.
+     *
+     * OS packages installed with apk, dpkg or rpm are detected, as well as the dependencies in Go, npm, Python and Cargo lockfiles.
+     */
+    public function sbom(?SBOMFormat $format = null): File
+    {
+        $innerQueryBuilder = new \Dagger\Client\QueryBuilder('sbom');
+        if (null !== $format) {
+        $innerQueryBuilder->setArgument('format', $format);
+        }
+        return new \Dagger\File($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
+    }
+
     /**
      * Searches for content matching the given regular expression or literal string.
      *
@@ -48,4 +48,22 @@ class Engine extends Client\AbstractObject implements Client\IdAble
         $leafQueryBuilder = new \Dagger\Client\QueryBuilder('name');
         return (string)$this->queryLeaf($leafQueryBuilder, 'name');
     }
+
+    /**
+     * The list of persistent services running in the engine, shared across sessions
+     */
+    public function services(): array
+    {
+        $leafQueryBuilder = new \Dagger\Client\QueryBuilder('services');
+        return (array)$this->queryLeaf($leafQueryBuilder, 'services');
+    }
+
+    /**
+     * The list of active sessions, across all clients connected to the engine
+     */
+    public function sessions(): array
+    {
+        $leafQueryBuilder = new \Dagger\Client\QueryBuilder('sessions');
+        return (array)$this->queryLeaf($leafQueryBuilder, 'sessions');
+    }
 }
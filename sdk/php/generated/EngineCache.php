@@ -52,6 +52,16 @@ class EngineCache extends Client\AbstractObject implements Client\IdAble
         return (int)$this->queryLeaf($leafQueryBuilder, 'minFreeSpace');
     }
 
+    /**
+     * Pin a cache entry, so that it's never pruned
+     */
+    public function pin(string $recordId): void
+    {
+        $leafQueryBuilder = new \Dagger\Client\QueryBuilder('pin');
+        $leafQueryBuilder->setArgument('recordId', $recordId);
+        $this->queryLeaf($leafQueryBuilder, 'pin');
+    }
+
     /**
      * Prune the cache of releaseable entries
      */
@@ -81,4 +91,16 @@ class EngineCache extends Client\AbstractObject implements Client\IdAble
         $leafQueryBuilder = new \Dagger\Client\QueryBuilder('targetSpace');
         return (int)$this->queryLeaf($leafQueryBuilder, 'targetSpace');
     }
+
+    /**
+     * Unpin a cache entry, so that it can be pruned again
+     *
+     * The entries of a pinned cache volume are pinned again when the volume is used; unpin the volume itself with CacheVolume.unpin instead.
+     */
+    public function unpin(string $recordId): void
+    {
+        $leafQueryBuilder = new \Dagger\Client\QueryBuilder('unpin');
+        $leafQueryBuilder->setArgument('recordId', $recordId);
+        $this->queryLeaf($leafQueryBuilder, 'unpin');
+    }
 }
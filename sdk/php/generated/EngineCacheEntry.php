@@ -66,4 +66,22 @@ class EngineCacheEntry extends Client\AbstractObject implements Client\IdAble
         $leafQueryBuilder = new \Dagger\Client\QueryBuilder('mostRecentUseTimeUnixNano');
         return (int)$this->queryLeaf($leafQueryBuilder, 'mostRecentUseTimeUnixNano');
     }
+
+    /**
+     * Whether the cache entry is pinned, exempting it from pruning.
+     */
+    public function pinned(): bool
+    {
+        $leafQueryBuilder = new \Dagger\Client\QueryBuilder('pinned');
+        return (bool)$this->queryLeaf($leafQueryBuilder, 'pinned');
+    }
+
+    /**
+     * The ID of the underlying cache record, which stays the same across sessions and engine restarts.
+     */
+    public function recordId(): string
+    {
+        $leafQueryBuilder = new \Dagger\Client\QueryBuilder('recordId');
+        return (string)$this->queryLeaf($leafQueryBuilder, 'recordId');
+    }
 }
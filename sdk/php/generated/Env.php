@@ -195,6 +195,58 @@ class Env extends Client\AbstractObject implements Client\IdAble
         return new \Dagger\Env($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
     }
 
+    /**
+     * Create or update a binding of type ContainerComparison in the environment
+     */
+    public function withContainerComparisonInput(
+        string $name,
+        ContainerComparisonId|ContainerComparison $value,
+        string $description,
+    ): Env {
+        $innerQueryBuilder = new \Dagger\Client\QueryBuilder('withContainerComparisonInput');
+        $innerQueryBuilder->setArgument('name', $name);
+        $innerQueryBuilder->setArgument('value', $value);
+        $innerQueryBuilder->setArgument('description', $description);
+        return new \Dagger\Env($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
+    }
+
+    /**
+     * Declare a desired ContainerComparison output to be assigned in the environment
+     */
+    public function withContainerComparisonOutput(string $name, string $description): Env
+    {
+        $innerQueryBuilder = new \Dagger\Client\QueryBuilder('withContainerComparisonOutput');
+        $innerQueryBuilder->setArgument('name', $name);
+        $innerQueryBuilder->setArgument('description', $description);
+        return new \Dagger\Env($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
+    }
+
+    /**
+     * Create or update a binding of type ContainerConfigChange in the environment
+     */
+    public function withContainerConfigChangeInput(
+        string $name,
+        ContainerConfigChangeId|ContainerConfigChange $value,
+        string $description,
+    ): Env {
+        $innerQueryBuilder = new \Dagger\Client\QueryBuilder('withContainerConfigChangeInput');
+        $innerQueryBuilder->setArgument('name', $name);
+        $innerQueryBuilder->setArgument('value', $value);
+        $innerQueryBuilder->setArgument('description', $description);
+        return new \Dagger\Env($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
+    }
+
+    /**
+     * Declare a desired ContainerConfigChange output to be assigned in the environment
+     */
+    public function withContainerConfigChangeOutput(string $name, string $description): Env
+    {
+        $innerQueryBuilder = new \Dagger\Client\QueryBuilder('withContainerConfigChangeOutput');
+        $innerQueryBuilder->setArgument('name', $name);
+        $innerQueryBuilder->setArgument('description', $description);
+        return new \Dagger\Env($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
+    }
+
     /**
      * Create or update a binding of type Container in the environment
      */
@@ -207,6 +259,32 @@ class Env extends Client\AbstractObject implements Client\IdAble
         return new \Dagger\Env($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
     }
 
+    /**
+     * Create or update a binding of type ContainerLayerComparison in the environment
+     */
+    public function withContainerLayerComparisonInput(
+        string $name,
+        ContainerLayerComparisonId|ContainerLayerComparison $value,
+        string $description,
+    ): Env {
+        $innerQueryBuilder = new \Dagger\Client\QueryBuilder('withContainerLayerComparisonInput');
+        $innerQueryBuilder->setArgument('name', $name);
+        $innerQueryBuilder->setArgument('value', $value);
+        $innerQueryBuilder->setArgument('description', $description);
+        return new \Dagger\Env($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
+    }
+
+    /**
+     * Declare a desired ContainerLayerComparison output to be assigned in the environment
+     */
+    public function withContainerLayerComparisonOutput(string $name, string $description): Env
+    {
+        $innerQueryBuilder = new \Dagger\Client\QueryBuilder('withContainerLayerComparisonOutput');
+        $innerQueryBuilder->setArgument('name', $name);
+        $innerQueryBuilder->setArgument('description', $description);
+        return new \Dagger\Env($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
+    }
+
     /**
      * Declare a desired Container output to be assigned in the environment
      */
@@ -252,6 +330,58 @@ class Env extends Client\AbstractObject implements Client\IdAble
         return new \Dagger\Env($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
     }
 
+    /**
+     * Create or update a binding of type EngineService in the environment
+     */
+    public function withEngineServiceInput(
+        string $name,
+        EngineServiceId|EngineService $value,
+        string $description,
+    ): Env {
+        $innerQueryBuilder = new \Dagger\Client\QueryBuilder('withEngineServiceInput');
+        $innerQueryBuilder->setArgument('name', $name);
+        $innerQueryBuilder->setArgument('value', $value);
+        $innerQueryBuilder->setArgument('description', $description);
+        return new \Dagger\Env($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
+    }
+
+    /**
+     * Declare a desired EngineService output to be assigned in the environment
+     */
+    public function withEngineServiceOutput(string $name, string $description): Env
+    {
+        $innerQueryBuilder = new \Dagger\Client\QueryBuilder('withEngineServiceOutput');
+        $innerQueryBuilder->setArgument('name', $name);
+        $innerQueryBuilder->setArgument('description', $description);
+        return new \Dagger\Env($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
+    }
+
+    /**
+     * Create or update a binding of type EngineSession in the environment
+     */
+    public function withEngineSessionInput(
+        string $name,
+        EngineSessionId|EngineSession $value,
+        string $description,
+    ): Env {
+        $innerQueryBuilder = new \Dagger\Client\QueryBuilder('withEngineSessionInput');
+        $innerQueryBuilder->setArgument('name', $name);
+        $innerQueryBuilder->setArgument('value', $value);
+        $innerQueryBuilder->setArgument('description', $description);
+        return new \Dagger\Env($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
+    }
+
+    /**
+     * Declare a desired EngineSession output to be assigned in the environment
+     */
+    public function withEngineSessionOutput(string $name, string $description): Env
+    {
+        $innerQueryBuilder = new \Dagger\Client\QueryBuilder('withEngineSessionOutput');
+        $innerQueryBuilder->setArgument('name', $name);
+        $innerQueryBuilder->setArgument('description', $description);
+        return new \Dagger\Env($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
+    }
+
     /**
      * Create or update a binding of type EnvFile in the environment
      */
@@ -321,6 +451,32 @@ class Env extends Client\AbstractObject implements Client\IdAble
         return new \Dagger\Env($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
     }
 
+    /**
+     * Create or update a binding of type FunctionArgConstraints in the environment
+     */
+    public function withFunctionArgConstraintsInput(
+        string $name,
+        FunctionArgConstraintsId|FunctionArgConstraints $value,
+        string $description,
+    ): Env {
+        $innerQueryBuilder = new \Dagger\Client\QueryBuilder('withFunctionArgConstraintsInput');
+        $innerQueryBuilder->setArgument('name', $name);
+        $innerQueryBuilder->setArgument('value', $value);
+        $innerQueryBuilder->setArgument('description', $description);
+        return new \Dagger\Env($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
+    }
+
+    /**
+     * Declare a desired FunctionArgConstraints output to be assigned in the environment
+     */
+    public function withFunctionArgConstraintsOutput(string $name, string $description): Env
+    {
+        $innerQueryBuilder = new \Dagger\Client\QueryBuilder('withFunctionArgConstraintsOutput');
+        $innerQueryBuilder->setArgument('name', $name);
+        $innerQueryBuilder->setArgument('description', $description);
+        return new \Dagger\Env($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
+    }
+
     /**
      * Create or update a binding of type GitRef in the environment
      */
@@ -405,6 +561,32 @@ class Env extends Client\AbstractObject implements Client\IdAble
         return new \Dagger\Env($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
     }
 
+    /**
+     * Create or update a binding of type ModuleCapabilities in the environment
+     */
+    public function withModuleCapabilitiesInput(
+        string $name,
+        ModuleCapabilitiesId|ModuleCapabilities $value,
+        string $description,
+    ): Env {
+        $innerQueryBuilder = new \Dagger\Client\QueryBuilder('withModuleCapabilitiesInput');
+        $innerQueryBuilder->setArgument('name', $name);
+        $innerQueryBuilder->setArgument('value', $value);
+        $innerQueryBuilder->setArgument('description', $description);
+        return new \Dagger\Env($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
+    }
+
+    /**
+     * Declare a desired ModuleCapabilities output to be assigned in the environment
+     */
+    public function withModuleCapabilitiesOutput(string $name, string $description): Env
+    {
+        $innerQueryBuilder = new \Dagger\Client\QueryBuilder('withModuleCapabilitiesOutput');
+        $innerQueryBuilder->setArgument('name', $name);
+        $innerQueryBuilder->setArgument('description', $description);
+        return new \Dagger\Env($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
+    }
+
     /**
      * Create or update a binding of type ModuleConfigClient in the environment
      */
@@ -477,6 +659,47 @@ class Env extends Client\AbstractObject implements Client\IdAble
         return new \Dagger\Env($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
     }
 
+    /**
+     * Checks the values saved to an output by running a command against them
+     *
+     * When the command fails, its error is returned to the model, which is asked to fix the value until the command succeeds or the attempts run out. Directory, File and Container values are mounted at the given path, and String values are passed on stdin.
+     */
+    public function withOutputExecCheck(
+        string $name,
+        ContainerId|Container $container,
+        array $args,
+        ?string $path = '/output',
+        ?int $maxAttempts = 3,
+    ): Env {
+        $innerQueryBuilder = new \Dagger\Client\QueryBuilder('withOutputExecCheck');
+        $innerQueryBuilder->setArgument('name', $name);
+        $innerQueryBuilder->setArgument('container', $container);
+        $innerQueryBuilder->setArgument('args', $args);
+        if (null !== $path) {
+        $innerQueryBuilder->setArgument('path', $path);
+        }
+        if (null !== $maxAttempts) {
+        $innerQueryBuilder->setArgument('maxAttempts', $maxAttempts);
+        }
+        return new \Dagger\Env($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
+    }
+
+    /**
+     * Checks the values saved to an output by calling a function against them
+     *
+     * The function must belong to a module installed in the environment, and take an argument of the output's type. When it fails, its error is returned to the model, which is asked to fix the value until the function succeeds or the attempts run out.
+     */
+    public function withOutputFunctionCheck(string $name, string $function, ?int $maxAttempts = 3): Env
+    {
+        $innerQueryBuilder = new \Dagger\Client\QueryBuilder('withOutputFunctionCheck');
+        $innerQueryBuilder->setArgument('name', $name);
+        $innerQueryBuilder->setArgument('function', $function);
+        if (null !== $maxAttempts) {
+        $innerQueryBuilder->setArgument('maxAttempts', $maxAttempts);
+        }
+        return new \Dagger\Env($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
+    }
+
     /**
      * Create or update a binding of type SearchResult in the environment
      */
@@ -641,6 +864,29 @@ class Env extends Client\AbstractObject implements Client\IdAble
         return new \Dagger\Env($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
     }
 
+    /**
+     * Create or update a binding of type UnionTypeDef in the environment
+     */
+    public function withUnionTypeDefInput(string $name, UnionTypeDefId|UnionTypeDef $value, string $description): Env
+    {
+        $innerQueryBuilder = new \Dagger\Client\QueryBuilder('withUnionTypeDefInput');
+        $innerQueryBuilder->setArgument('name', $name);
+        $innerQueryBuilder->setArgument('value', $value);
+        $innerQueryBuilder->setArgument('description', $description);
+        return new \Dagger\Env($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
+    }
+
+    /**
+     * Declare a desired UnionTypeDef output to be assigned in the environment
+     */
+    public function withUnionTypeDefOutput(string $name, string $description): Env
+    {
+        $innerQueryBuilder = new \Dagger\Client\QueryBuilder('withUnionTypeDefOutput');
+        $innerQueryBuilder->setArgument('name', $name);
+        $innerQueryBuilder->setArgument('description', $description);
+        return new \Dagger\Env($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
+    }
+
     /**
      * Returns a new environment with the provided workspace
      */
@@ -15,6 +15,15 @@ namespace Dagger;
  */
 class FunctionArg extends Client\AbstractObject implements Client\IdAble
 {
+    /**
+     * Constraints the value of the argument must satisfy, if any.
+     */
+    public function constraints(): FunctionArgConstraints
+    {
+        $innerQueryBuilder = new \Dagger\Client\QueryBuilder('constraints');
+        return new \Dagger\FunctionArgConstraints($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
+    }
+
     /**
      * Only applies to arguments of type File or Directory. If the argument is not set, load it from the given path in the context directory
      */
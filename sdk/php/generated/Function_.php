@@ -90,6 +90,13 @@ class Function_ extends Client\AbstractObject implements Client\IdAble
         ?array $ignore = null,
         SourceMapId|SourceMap|null $sourceMap = null,
         ?string $deprecated = null,
+        ?Json $min = null,
+        ?Json $max = null,
+        ?string $pattern = '',
+        ?int $minLength = 0,
+        ?int $maxLength = 0,
+        ?bool $nonEmpty = false,
+        ?array $requiredPaths = null,
     ): Function_ {
         $innerQueryBuilder = new \Dagger\Client\QueryBuilder('withArg');
         $innerQueryBuilder->setArgument('name', $name);
@@ -112,6 +119,27 @@ class Function_ extends Client\AbstractObject implements Client\IdAble
         if (null !== $deprecated) {
         $innerQueryBuilder->setArgument('deprecated', $deprecated);
         }
+        if (null !== $min) {
+        $innerQueryBuilder->setArgument('min', $min);
+        }
+        if (null !== $max) {
+        $innerQueryBuilder->setArgument('max', $max);
+        }
+        if (null !== $pattern) {
+        $innerQueryBuilder->setArgument('pattern', $pattern);
+        }
+        if (null !== $minLength) {
+        $innerQueryBuilder->setArgument('minLength', $minLength);
+        }
+        if (null !== $maxLength) {
+        $innerQueryBuilder->setArgument('maxLength', $maxLength);
+        }
+        if (null !== $nonEmpty) {
+        $innerQueryBuilder->setArgument('nonEmpty', $nonEmpty);
+        }
+        if (null !== $requiredPaths) {
+        $innerQueryBuilder->setArgument('requiredPaths', $requiredPaths);
+        }
         return new \Dagger\Function_($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
     }
 
@@ -30,6 +30,15 @@ class LLM extends Client\AbstractObject implements Client\IdAble
         return new \Dagger\Binding($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
     }
 
+    /**
+     * return the calls recorded instead of being called in a dry run
+     */
+    public function dryRunCalls(): array
+    {
+        $leafQueryBuilder = new \Dagger\Client\QueryBuilder('dryRunCalls');
+        return (array)$this->queryLeaf($leafQueryBuilder, 'dryRunCalls');
+    }
+
     /**
      * return the LLM's current environment
      */
@@ -39,6 +48,32 @@ class LLM extends Client\AbstractObject implements Client\IdAble
         return new \Dagger\Env($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
     }
 
+    /**
+     * Run several branches of the LLM in parallel, each with its own message history and a copy of its environment
+     *
+     * Each branch submits the queued prompt, followed by one of the variations in turn, if any. Branches that fail are still returned, and fail when they are used. The token usage of all the branches is available with fanOutTokenUsage.
+     */
+    public function fanOut(?int $n = 0, ?array $variations = null): array
+    {
+        $leafQueryBuilder = new \Dagger\Client\QueryBuilder('fanOut');
+        if (null !== $n) {
+        $leafQueryBuilder->setArgument('n', $n);
+        }
+        if (null !== $variations) {
+        $leafQueryBuilder->setArgument('variations', $variations);
+        }
+        return (array)$this->queryLeaf($leafQueryBuilder, 'fanOut');
+    }
+
+    /**
+     * returns the token usage of the current state, including the other branches of the fan outs it came from
+     */
+    public function fanOutTokenUsage(): LLMTokenUsage
+    {
+        $innerQueryBuilder = new \Dagger\Client\QueryBuilder('fanOutTokenUsage');
+        return new \Dagger\LLMTokenUsage($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
+    }
+
     /**
      * Indicates whether there are any queued prompts or tool results to send to the model
      */
@@ -158,6 +193,15 @@ class LLM extends Client\AbstractObject implements Client\IdAble
         return new \Dagger\LLM($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
     }
 
+    /**
+     * Return a new LLM that records the calls it makes with side effects outside of the engine, such as exports, publishing or host access, instead of calling them
+     */
+    public function withDryRun(): LLM
+    {
+        $innerQueryBuilder = new \Dagger\Client\QueryBuilder('withDryRun');
+        return new \Dagger\LLM($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
+    }
+
     /**
      * allow the LLM to interact with an environment via MCP
      */
@@ -228,6 +272,19 @@ class LLM extends Client\AbstractObject implements Client\IdAble
         return new \Dagger\LLM($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
     }
 
+    /**
+     * Return a new LLM that applies a policy to the functions matching a pattern
+     *
+     * A pattern matches a function name, like withExec, or a type and function name, like Container.withExec, and may use * as a wildcard. Tools of MCP servers are matched by server and tool name. When several rules match a function, the last one applies, and functions that match no rule are allowed.
+     */
+    public function withToolPolicy(string $pattern, LLMToolPolicy $policy): LLM
+    {
+        $innerQueryBuilder = new \Dagger\Client\QueryBuilder('withToolPolicy');
+        $innerQueryBuilder->setArgument('pattern', $pattern);
+        $innerQueryBuilder->setArgument('policy', $policy);
+        return new \Dagger\LLM($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
+    }
+
     /**
      * Disable the default system prompt
      */
@@ -170,6 +170,15 @@ class Module extends Client\AbstractObject implements Client\IdAble
         return new \Dagger\ModuleId((string)$this->queryLeaf($leafQueryBuilder, 'sync'));
     }
 
+    /**
+     * Unions served by this module.
+     */
+    public function unions(): array
+    {
+        $leafQueryBuilder = new \Dagger\Client\QueryBuilder('unions');
+        return (array)$this->queryLeaf($leafQueryBuilder, 'unions');
+    }
+
     /**
      * User-defined default values, loaded from local .env files.
      */
@@ -218,4 +227,14 @@ class Module extends Client\AbstractObject implements Client\IdAble
         $innerQueryBuilder->setArgument('object', $object);
         return new \Dagger\Module($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
     }
+
+    /**
+     * This module plus the given Union type
+     */
+    public function withUnion(TypeDefId|TypeDef $union): Module
+    {
+        $innerQueryBuilder = new \Dagger\Client\QueryBuilder('withUnion');
+        $innerQueryBuilder->setArgument('union', $union);
+        return new \Dagger\Module($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
+    }
 }
@@ -40,6 +40,15 @@ class ModuleSource extends Client\AbstractObject implements Client\IdAble
         return new \Dagger\ModuleSource($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
     }
 
+    /**
+     * The capabilities the module requires, as declared in its dagger.json. Null if the module declares none, in which case it requires all capabilities.
+     */
+    public function capabilities(): ModuleCapabilities
+    {
+        $innerQueryBuilder = new \Dagger\Client\QueryBuilder('capabilities');
+        return new \Dagger\ModuleCapabilities($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
+    }
+
     /**
      * The ref to clone the root of the git repo from. Only valid for git sources.
      */
@@ -131,6 +140,15 @@ class ModuleSource extends Client\AbstractObject implements Client\IdAble
         return new \Dagger\Directory($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
     }
 
+    /**
+     * The capabilities granted to the module when it was installed as a dependency. Null if none were recorded, in which case it is only restricted by the capabilities it declares.
+     */
+    public function grantedCapabilities(): ModuleCapabilities
+    {
+        $innerQueryBuilder = new \Dagger\Client\QueryBuilder('grantedCapabilities');
+        return new \Dagger\ModuleCapabilities($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
+    }
+
     /**
      * The URL to access the web view of the repository (e.g., GitHub, GitLab, Bitbucket).
      */
@@ -348,6 +366,39 @@ class ModuleSource extends Client\AbstractObject implements Client\IdAble
         return new \Dagger\ModuleSource($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
     }
 
+    /**
+     * Record the capabilities granted to the module as a dependency. Recorded in the parent module's dagger.json when installed.
+     */
+    public function withGrantedCapabilities(
+        ?array $hostRead = null,
+        ?bool $hostSockets = false,
+        ?bool $network = false,
+        ?bool $llm = false,
+        ?bool $exec = false,
+        ?bool $secrets = false,
+    ): ModuleSource {
+        $innerQueryBuilder = new \Dagger\Client\QueryBuilder('withGrantedCapabilities');
+        if (null !== $hostRead) {
+        $innerQueryBuilder->setArgument('hostRead', $hostRead);
+        }
+        if (null !== $hostSockets) {
+        $innerQueryBuilder->setArgument('hostSockets', $hostSockets);
+        }
+        if (null !== $network) {
+        $innerQueryBuilder->setArgument('network', $network);
+        }
+        if (null !== $llm) {
+        $innerQueryBuilder->setArgument('llm', $llm);
+        }
+        if (null !== $exec) {
+        $innerQueryBuilder->setArgument('exec', $exec);
+        }
+        if (null !== $secrets) {
+        $innerQueryBuilder->setArgument('secrets', $secrets);
+        }
+        return new \Dagger\ModuleSource($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
+    }
+
     /**
      * Update the module source with additional include patterns for files+directories from its context that are required for building it
      */
@@ -50,6 +50,15 @@ class Service extends Client\AbstractObject implements Client\IdAble
         return new \Dagger\ServiceId((string)$this->queryLeaf($leafQueryBuilder, 'id'));
     }
 
+    /**
+     * The name of the service, if set with withName.
+     */
+    public function name(): string
+    {
+        $leafQueryBuilder = new \Dagger\Client\QueryBuilder('name');
+        return (string)$this->queryLeaf($leafQueryBuilder, 'name');
+    }
+
     /**
      * Retrieves the list of ports provided by the service.
      */
@@ -64,14 +73,22 @@ class Service extends Client\AbstractObject implements Client\IdAble
      *
      * Services bound to a Container do not need to be manually started.
      */
-    public function start(): ServiceId
+    public function start(?bool $persistent = false, ?string $idleTimeout = null): ServiceId
     {
         $leafQueryBuilder = new \Dagger\Client\QueryBuilder('start');
+        if (null !== $persistent) {
+        $leafQueryBuilder->setArgument('persistent', $persistent);
+        }
+        if (null !== $idleTimeout) {
+        $leafQueryBuilder->setArgument('idleTimeout', $idleTimeout);
+        }
         return new \Dagger\ServiceId((string)$this->queryLeaf($leafQueryBuilder, 'start'));
     }
 
     /**
      * Stop the service.
+     *
+     * A persistent service can only be stopped by the main client.
      */
     public function stop(?bool $kill = false): ServiceId
     {
@@ -124,4 +141,14 @@ class Service extends Client\AbstractObject implements Client\IdAble
         $innerQueryBuilder->setArgument('hostname', $hostname);
         return new \Dagger\Service($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
     }
+
+    /**
+     * Configures a name for the service, under which it can be started as a persistent service shared across sessions.
+     */
+    public function withName(string $name): Service
+    {
+        $innerQueryBuilder = new \Dagger\Client\QueryBuilder('withName');
+        $innerQueryBuilder->setArgument('name', $name);
+        return new \Dagger\Service($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
+    }
 }
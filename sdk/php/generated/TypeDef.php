@@ -67,6 +67,15 @@ class TypeDef extends Client\AbstractObject implements Client\IdAble
         return new \Dagger\ScalarTypeDef($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
     }
 
+    /**
+     * If kind is UNION, the union-specific type definition. If kind is not UNION, this will be null.
+     */
+    public function asUnion(): UnionTypeDef
+    {
+        $innerQueryBuilder = new \Dagger\Client\QueryBuilder('asUnion');
+        return new \Dagger\UnionTypeDef($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
+    }
+
     /**
      * A unique identifier for this TypeDef.
      */
@@ -296,4 +305,35 @@ class TypeDef extends Client\AbstractObject implements Client\IdAble
         }
         return new \Dagger\TypeDef($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
     }
+
+    /**
+     * Returns a TypeDef of kind Union with the provided name.
+     *
+     * Note that a union's members may be omitted if the intent is only to refer to a union.
+     */
+    public function withUnion(
+        string $name,
+        ?string $description = '',
+        SourceMapId|SourceMap|null $sourceMap = null,
+    ): TypeDef {
+        $innerQueryBuilder = new \Dagger\Client\QueryBuilder('withUnion');
+        $innerQueryBuilder->setArgument('name', $name);
+        if (null !== $description) {
+        $innerQueryBuilder->setArgument('description', $description);
+        }
+        if (null !== $sourceMap) {
+        $innerQueryBuilder->setArgument('sourceMap', $sourceMap);
+        }
+        return new \Dagger\TypeDef($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
+    }
+
+    /**
+     * Adds a member type to a Union TypeDef, failing if the type is not a union or the member is not an object.
+     */
+    public function withUnionMember(TypeDefId|TypeDef $member): TypeDef
+    {
+        $innerQueryBuilder = new \Dagger\Client\QueryBuilder('withUnionMember');
+        $innerQueryBuilder->setArgument('member', $member);
+        return new \Dagger\TypeDef($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
+    }
 }
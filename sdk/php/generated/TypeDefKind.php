@@ -66,6 +66,13 @@ enum TypeDefKind: string
      */
     case ENUM_KIND = 'ENUM_KIND';
 
+    /**
+     * Always paired with a UnionTypeDef.
+     *
+     * A named type whose values have exactly one of a set of object types.
+     */
+    case UNION_KIND = 'UNION_KIND';
+
     /** A string value. */
     case STRING = 'STRING';
 
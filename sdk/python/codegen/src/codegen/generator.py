@@ -44,6 +44,7 @@ from graphql import (
     GraphQLScalarType,
     GraphQLSchema,
     GraphQLType,
+    GraphQLUnionType,
     GraphQLWrappingType,
     Undefined,
     get_named_type,
@@ -185,19 +186,34 @@ class Handler(ABC, Generic[_H]):
 @joiner
 def generate(schema: GraphQLSchema) -> Iterator[str]:
     """Code generation main function."""
+    has_unions = any(is_union_type(t) for t in schema.type_map.values())
+    base_types = ["Enum", "Input", "Root", "Scalar", "Type"]
+    if has_unions:
+        base_types.append("Union")
+
+    # Input fields are renamed with `dataclasses.field` if the Python name
+    # differs from the GraphQL name.
+    has_renamed_fields = any(
+        format_name(name) != name
+        for t in schema.type_map.values()
+        if is_input_object_type(t)
+        for name in t.fields
+    )
+    dataclass_imports = "dataclass, field" if has_renamed_fields else "dataclass"
+
     yield textwrap.dedent(
-        """\
+        f"""\
         # Code generated by dagger. DO NOT EDIT.
 
         import warnings  # noqa: F401
         from collections.abc import Callable
-        from dataclasses import dataclass
+        from dataclasses import {dataclass_imports}
 
         from typing_extensions import Self
 
         from dagger.client._core import Arg
         from dagger.client._guards import typecheck
-        from dagger.client.base import Enum, Input, Root, Scalar, Type
+        from dagger.client.base import {", ".join(base_types)}
         """,
     )
 
@@ -212,6 +228,7 @@ def generate(schema: GraphQLSchema) -> Iterator[str]:
         Enum(ctx),
         Input(ctx),
         Object(ctx),
+        Union(ctx),
     )
 
     # Split into two iterators to update ctx.remaining.
@@ -274,6 +291,12 @@ def is_list_of_objects_type(
     return is_list_type(t) and is_object_type(get_named_type(t))
 
 
+def is_list_of_unions_type(
+    t: GraphQLType,
+) -> TypeGuard[GraphQLList[GraphQLUnionType]]:
+    return is_list_type(t) and is_union_type(get_named_type(t))
+
+
 def is_wrapping_type(t: GraphQLType) -> TypeGuard[GraphQLWrappingType]:
     return isinstance(t, GraphQLWrappingType)
 
@@ -290,6 +313,10 @@ def is_object_type(t: GraphQLType) -> TypeGuard[GraphQLObjectType]:
     return isinstance(t, GraphQLObjectType)
 
 
+def is_union_type(t: GraphQLType) -> TypeGuard[GraphQLUnionType]:
+    return isinstance(t, GraphQLUnionType)
+
+
 def is_output_leaf_type(t: GraphQLOutputType) -> TypeGuard[GraphQLLeafType]:
     return is_leaf_type(get_named_type(t))
 
@@ -486,7 +513,7 @@ class _InputField:
     def __str__(self) -> Iterator[str]:
         """Output for an InputObject field."""
         yield ""
-        yield self.ctx.render_types(self.as_param())
+        yield self.ctx.render_types(self.as_field())
         doc_parts: list[str] = []
         if self.description:
             doc_parts.append(self.description)
@@ -499,6 +526,17 @@ class _InputField:
         if doc_parts:
             yield doc("\n\n".join(doc_parts))
 
+    def as_field(self) -> str:
+        """As a field of an input object dataclass."""
+        out = self.as_param()
+        if self.name == self.graphql_name:
+            return out
+        # Keep the GraphQL name to send the field with.
+        out, _, default = out.partition(" = ")
+        params = [f"default={default}"] if default else []
+        params.append(f"metadata={{{quote('name')}: {quote(self.graphql_name)}}}")
+        return f"{out} = field({', '.join(params)})"
+
     def as_param(self) -> str:
         """As a parameter in a function signature."""
         type_ = "Self" if self.is_self else self.type
@@ -562,7 +600,8 @@ class _ObjectField:
 
         self.is_leaf = is_output_leaf_type(field.type)
         self.is_list = is_list_of_objects_type(field.type)
-        self.is_exec = self.is_leaf or self.is_list
+        self.is_union_list = is_list_of_unions_type(field.type)
+        self.is_exec = self.is_leaf or self.is_list or self.is_union_list
         self.is_void = self.is_leaf and self.named_type.name == "Void"
         self.type = format_output_type(field.type).replace("Query", "Client")
 
@@ -657,6 +696,13 @@ class _ObjectField:
             yield f"return {self.type}(_ctx)"
         elif self.is_list:
             yield f"return await _ctx.execute_object_list({self.named_type.name})"
+        elif self.is_union_list:
+            members = cast(GraphQLUnionType, self.named_type).types
+            names = ", ".join(quote(t.name) for t in members)
+            yield (
+                f"return await _ctx.execute_union_list({self.named_type.name}, "
+                f"[{names}])"
+            )
         elif self.is_void:
             yield "await _ctx.execute()"
         else:
@@ -869,3 +915,35 @@ class Object(ObjectHandler[GraphQLObjectType]):
                     return cb(self)
                 '''  # noqa: E501
             )
+
+
+@dataclass
+class Union(Handler[GraphQLUnionType]):
+    predicate: ClassVar[Predicate] = staticmethod(is_union_type)
+
+    def render_head(self, t: GraphQLUnionType) -> str:
+        return f"@typecheck\n{super().render_head(t)}"
+
+    @joiner
+    def render_body(self, t: GraphQLUnionType) -> Iterator[str]:
+        if body := super().render_body(t):
+            yield body
+
+        for member in t.types:
+            name = member.name
+            sig = f"async def as_{format_name(name)}(self) -> {name} | None:"
+            yield ""
+            yield self.ctx.render_types(sig)
+            yield indent(
+                "\n".join(
+                    (
+                        doc(
+                            f"Return the value as a {name}, "
+                            "or None if it has a different type."
+                        ),
+                        f"if await self.typename() != {quote(name)}:",
+                        indent("return None"),
+                        f"return {name}(self._as({quote(name)}))",
+                    )
+                )
+            )
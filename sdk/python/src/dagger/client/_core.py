@@ -19,7 +19,16 @@ import graphql
 import httpx
 from beartype.door import TypeHint
 from cattrs.preconf.json import make_converter as make_json_converter
-from gql.dsl import DSLField, DSLQuery, DSLSchema, DSLSelectable, DSLType, dsl_gql
+from gql.dsl import (
+    DSLField,
+    DSLInlineFragment,
+    DSLMetaField,
+    DSLQuery,
+    DSLSchema,
+    DSLSelectable,
+    DSLType,
+    dsl_gql,
+)
 from gql.transport.exceptions import (
     TransportClosed,
     TransportConnectionFailed,
@@ -36,7 +45,7 @@ from dagger import (
 )
 from dagger._exceptions import _query_error_from_transport
 from dagger.client._session import BaseConnection, SharedConnection
-from dagger.client.base import Scalar, Type
+from dagger.client.base import Input, Scalar, Type, Union
 
 from ._guards import (
     IDType,
@@ -48,6 +57,9 @@ logger = logging.getLogger(__name__)
 
 T = TypeVar("T")
 Obj_T = TypeVar("Obj_T", bound=Type)
+Union_T = TypeVar("Union_T", bound=Union)
+
+TYPENAME_FIELD = "__typename"
 
 
 class Arg(typing.NamedTuple):
@@ -62,13 +74,30 @@ class Field:
     name: str
     args: dict[str, Any]
     children: dict[str, "Field"] = dataclasses.field(default_factory=dict)
-
-    def to_dsl(self, schema: DSLSchema) -> DSLField:
-        type_: DSLType = getattr(schema, self.type_name)
-        field_ = getattr(type_, self.name)(**self.args)
+    fragment: bool = False
+    """Select the children only if the value is of type `type_name`."""
+
+    def to_dsl(self, schema: DSLSchema) -> DSLSelectable:
+        field_: DSLField | DSLMetaField | DSLInlineFragment
+        if self.fragment:
+            field_ = DSLInlineFragment().on(getattr(schema, self.type_name))
+        elif self.name == TYPENAME_FIELD:
+            field_ = DSLMetaField(TYPENAME_FIELD)
+        else:
+            type_: DSLType = getattr(schema, self.type_name)
+            field_ = getattr(type_, self.name)(**self.args)
         if self.children:
             field_ = field_.select(
-                **{name: child.to_dsl(schema) for name, child in self.children.items()}
+                *(
+                    child.to_dsl(schema)
+                    for child in self.children.values()
+                    if child.fragment
+                ),
+                **{
+                    name: child.to_dsl(schema)
+                    for name, child in self.children.items()
+                    if not child.fragment
+                },
             )
         return field_
 
@@ -121,6 +150,13 @@ class Context:
         selections.append(field_)
         return dataclasses.replace(self, selections=selections)
 
+    def select_fragment(self, type_name: str) -> "Context":
+        """Select the fields that follow only if the value is of the given type."""
+        field_ = Field(type_name, type_name, {}, fragment=True)
+        selections = self.selections.copy()
+        selections.append(field_)
+        return dataclasses.replace(self, selections=selections)
+
     def root_select(
         self,
         field_name: str,
@@ -223,6 +259,36 @@ class Context:
 
         return [element_type(ctx.select_id(element_type.__name__, v.id)) for v in ids]
 
+    async def execute_union_list(
+        self,
+        element_type: type[Union_T],
+        members: typing.Sequence[str],
+    ) -> list[Union_T]:
+        selections = self.selections.copy()
+        parent = selections.pop()
+        # Each member is selected by ID under its own alias, since the IDs
+        # of different members have different types.
+        children = {"typename": Field(parent.type_name, TYPENAME_FIELD, {})}
+        for member in members:
+            children[member] = Field(
+                member,
+                member,
+                {},
+                children={member: Field(member, "id", {})},
+                fragment=True,
+            )
+        selections.append(dataclasses.replace(parent, children=children))
+        ctx = dataclasses.replace(self, selections=selections)
+        values = await ctx.execute(list[dict[str, str]])
+
+        return [
+            element_type(
+                ctx.select_id(v["typename"], v[v["typename"]]),
+                v["typename"],
+            )
+            for v in values
+        ]
+
     async def execute_sync(
         self,
         obj: Obj_T,
@@ -247,7 +313,9 @@ class Context:
         for f in self.selections:
             if not isinstance(value, dict):
                 break
-            value = value[f.name]
+            # fragments select fields of the same value
+            if not f.fragment:
+                value = value[f.name]
 
         if value is None and not type_hint.is_bearable(value):
             msg = (
@@ -275,6 +343,10 @@ class Context:
             sel = self.selections[pos]
             sel.args[k][idx] = await v.id()
 
+        async def _resolve_field_id(pos: int, k: str, field: str, v: IDType):
+            sel = self.selections[pos]
+            sel.args[k][field] = await v.id()
+
         # resolve all ids concurrently
         with exceptiongroup.catch(
             {(graphql.GraphQLError, DaggerError): self.handle_group_err}
@@ -291,6 +363,14 @@ class Context:
                                     tg.start_soon(_resolve_seq_id, i, seq_i, k, seq_v)
                         elif is_id_type(v):
                             tg.start_soon(_resolve_id, i, k, v)
+                        # check if it's an input object with Type object fields,
+                        # like the member set in a union input
+                        elif isinstance(v, dict):
+                            for field, field_v in v.items():
+                                if is_id_type(field_v):
+                                    tg.start_soon(
+                                        _resolve_field_id, i, k, field, field_v
+                                    )
 
 
 def make_converter(ctx: Context):
@@ -319,6 +399,24 @@ def make_converter(ctx: Context):
         _struct,
     )
 
+    # Input objects are sent with the GraphQL names of their fields,
+    # which are set in the field's metadata when it differs.
+
+    def _is_input(cls: type) -> bool:
+        return isinstance(cls, type) and issubclass(cls, Input)
+
+    def _unstruct_input(obj: Input) -> dict[str, Any]:
+        return {
+            f.metadata.get("name", f.name): conv.unstructure(getattr(obj, f.name))
+            for f in dataclasses.fields(obj)  # type: ignore[arg-type]
+            if getattr(obj, f.name) != f.default
+        }
+
+    conv.register_unstructure_hook_func(
+        _is_input,
+        _unstruct_input,
+    )
+
     configure_converter_enum(conv)
 
     return conv
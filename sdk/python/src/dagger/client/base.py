@@ -79,6 +79,25 @@ class Interface(Type):
         return await self._select("id", []).execute(str)
 
 
+class Union(Type):
+    """Union type, a value of one of its member types."""
+
+    __slots__ = (*Type.__slots__, "_typename")
+
+    def __init__(self, ctx: Context, typename: str | None = None):
+        super().__init__(ctx)
+        self._typename = typename
+
+    async def typename(self) -> str:
+        """The name of the member type of the value."""
+        if self._typename is None:
+            self._typename = await self._select("__typename", []).execute(str)
+        return self._typename
+
+    def _as(self, type_name: str) -> Context:
+        return self._ctx.select_fragment(type_name)
+
+
 class Root(Type):
     """Top level query object type (a.k.a. Query)."""
 
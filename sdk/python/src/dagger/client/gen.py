@@ -46,11 +46,26 @@ class CloudID(Scalar):
     type Cloud."""
 
 
+class ContainerComparisonID(Scalar):
+    """The `ContainerComparisonID` scalar type represents an identifier
+    for an object of type ContainerComparison."""
+
+
+class ContainerConfigChangeID(Scalar):
+    """The `ContainerConfigChangeID` scalar type represents an identifier
+    for an object of type ContainerConfigChange."""
+
+
 class ContainerID(Scalar):
     """The `ContainerID` scalar type represents an identifier for an
     object of type Container."""
 
 
+class ContainerLayerComparisonID(Scalar):
+    """The `ContainerLayerComparisonID` scalar type represents an
+    identifier for an object of type ContainerLayerComparison."""
+
+
 class CurrentModuleID(Scalar):
     """The `CurrentModuleID` scalar type represents an identifier for an
     object of type CurrentModule."""
@@ -81,6 +96,16 @@ class EngineID(Scalar):
     of type Engine."""
 
 
+class EngineServiceID(Scalar):
+    """The `EngineServiceID` scalar type represents an identifier for an
+    object of type EngineService."""
+
+
+class EngineSessionID(Scalar):
+    """The `EngineSessionID` scalar type represents an identifier for an
+    object of type EngineSession."""
+
+
 class EnumTypeDefID(Scalar):
     """The `EnumTypeDefID` scalar type represents an identifier for an
     object of type EnumTypeDef."""
@@ -126,6 +151,11 @@ class FileID(Scalar):
     type File."""
 
 
+class FunctionArgConstraintsID(Scalar):
+    """The `FunctionArgConstraintsID` scalar type represents an identifier
+    for an object of type FunctionArgConstraints."""
+
+
 class FunctionArgID(Scalar):
     """The `FunctionArgID` scalar type represents an identifier for an
     object of type FunctionArg."""
@@ -205,6 +235,11 @@ class ListTypeDefID(Scalar):
     object of type ListTypeDef."""
 
 
+class ModuleCapabilitiesID(Scalar):
+    """The `ModuleCapabilitiesID` scalar type represents an identifier for
+    an object of type ModuleCapabilities."""
+
+
 class ModuleConfigClientID(Scalar):
     """The `ModuleConfigClientID` scalar type represents an identifier for
     an object of type ModuleConfigClient."""
@@ -291,6 +326,11 @@ class TypeDefID(Scalar):
     of type TypeDef."""
 
 
+class UnionTypeDefID(Scalar):
+    """The `UnionTypeDefID` scalar type represents an identifier for an
+    object of type UnionTypeDef."""
+
+
 class Void(Scalar):
     """The absence of a value.  A Null Void is used as a placeholder for
     resolvers that do not return anything."""
@@ -309,6 +349,22 @@ class CacheSharingMode(Enum):
     """Shares the cache volume amongst many build pipelines"""
 
 
+class ContainerChangeKind(Enum):
+    """How an entry differs between two containers"""
+
+    ADDED = "ADDED"
+    """The entry only exists in the newer container."""
+
+    MODIFIED = "MODIFIED"
+    """The entry exists in both containers, with different values."""
+
+    REMOVED = "REMOVED"
+    """The entry only exists in the older container."""
+
+    UNCHANGED = "UNCHANGED"
+    """The entry is the same in both containers."""
+
+
 class ExistsType(Enum):
     """File type."""
 
@@ -380,6 +436,29 @@ class ImageMediaTypes(Enum):
     OCI = "OCIMediaTypes"
 
 
+class ImageSquashMode(Enum):
+    """How to squash the layers of an image"""
+
+    ALL = "ALL"
+    """Squash the whole root filesystem into a single layer."""
+
+    FROM_BASE = "FROM_BASE"
+    """Keep the layers of the image the container was created from, and squash everything on top of them into a single layer."""
+
+
+class LLMToolPolicy(Enum):
+    """Whether an LLM may call a tool"""
+
+    ALLOW = "ALLOW"
+    """Let the LLM call the tool"""
+
+    ASK = "ASK"
+    """Ask the user to approve each call of the tool, and refuse if they can't be asked, e.g. in CI"""
+
+    DENY = "DENY"
+    """Refuse to call the tool"""
+
+
 class ModuleSourceExperimentalFeature(Enum):
     """Experimental features of a module"""
 
@@ -400,6 +479,19 @@ class ModuleSourceKind(Enum):
     LOCAL = "LOCAL_SOURCE"
 
 
+class NetworkMode(Enum):
+    """Network access granted to an execution"""
+
+    FULL = "FULL"
+    """Unrestricted network access"""
+
+    NONE = "NONE"
+    """No network access, apart from loopback"""
+
+    SERVICES_ONLY = "SERVICES_ONLY"
+    """Network access to services only, e.g. those bound with "withServiceBinding" """
+
+
 class NetworkProtocol(Enum):
     """Transport layer network protocol associated to a port."""
 
@@ -421,6 +513,16 @@ class ReturnType(Enum):
     """A successful execution (exit code 0)"""
 
 
+class SBOMFormat(Enum):
+    """Format of a software bill of materials"""
+
+    CYCLONEDX_JSON = "CYCLONEDX_JSON"
+    """CycloneDX 1.5 JSON"""
+
+    SPDX_JSON = "SPDX_JSON"
+    """SPDX 2.3 JSON"""
+
+
 class TypeDefKind(Enum):
     """Distinguishes the different kinds of TypeDefs."""
 
@@ -498,6 +600,12 @@ class TypeDefKind(Enum):
     STRING = "STRING_KIND"
     """A string value."""
 
+    UNION_KIND = "UNION_KIND"
+    """Always paired with a UnionTypeDef.
+
+    A named type whose values have exactly one of a set of object types.
+    """
+
     VOID_KIND = "VOID_KIND"
     """A special kind used to signify that no value is returned.
 
@@ -717,12 +825,42 @@ class Binding(Type):
         _ctx = self._select("asContainer", _args)
         return Container(_ctx)
 
+    def as_container_comparison(self) -> "ContainerComparison":
+        """Retrieve the binding value, as type ContainerComparison"""
+        _args: list[Arg] = []
+        _ctx = self._select("asContainerComparison", _args)
+        return ContainerComparison(_ctx)
+
+    def as_container_config_change(self) -> "ContainerConfigChange":
+        """Retrieve the binding value, as type ContainerConfigChange"""
+        _args: list[Arg] = []
+        _ctx = self._select("asContainerConfigChange", _args)
+        return ContainerConfigChange(_ctx)
+
+    def as_container_layer_comparison(self) -> "ContainerLayerComparison":
+        """Retrieve the binding value, as type ContainerLayerComparison"""
+        _args: list[Arg] = []
+        _ctx = self._select("asContainerLayerComparison", _args)
+        return ContainerLayerComparison(_ctx)
+
     def as_directory(self) -> "Directory":
         """Retrieve the binding value, as type Directory"""
         _args: list[Arg] = []
         _ctx = self._select("asDirectory", _args)
         return Directory(_ctx)
 
+    def as_engine_service(self) -> "EngineService":
+        """Retrieve the binding value, as type EngineService"""
+        _args: list[Arg] = []
+        _ctx = self._select("asEngineService", _args)
+        return EngineService(_ctx)
+
+    def as_engine_session(self) -> "EngineSession":
+        """Retrieve the binding value, as type EngineSession"""
+        _args: list[Arg] = []
+        _ctx = self._select("asEngineSession", _args)
+        return EngineSession(_ctx)
+
     def as_env(self) -> "Env":
         """Retrieve the binding value, as type Env"""
         _args: list[Arg] = []
@@ -741,6 +879,12 @@ class Binding(Type):
         _ctx = self._select("asFile", _args)
         return File(_ctx)
 
+    def as_function_arg_constraints(self) -> "FunctionArgConstraints":
+        """Retrieve the binding value, as type FunctionArgConstraints"""
+        _args: list[Arg] = []
+        _ctx = self._select("asFunctionArgConstraints", _args)
+        return FunctionArgConstraints(_ctx)
+
     def as_git_ref(self) -> "GitRef":
         """Retrieve the binding value, as type GitRef"""
         _args: list[Arg] = []
@@ -765,6 +909,12 @@ class Binding(Type):
         _ctx = self._select("asModule", _args)
         return Module(_ctx)
 
+    def as_module_capabilities(self) -> "ModuleCapabilities":
+        """Retrieve the binding value, as type ModuleCapabilities"""
+        _args: list[Arg] = []
+        _ctx = self._select("asModuleCapabilities", _args)
+        return ModuleCapabilities(_ctx)
+
     def as_module_config_client(self) -> "ModuleConfigClient":
         """Retrieve the binding value, as type ModuleConfigClient"""
         _args: list[Arg] = []
@@ -834,6 +984,12 @@ class Binding(Type):
         _ctx = self._select("asString", _args)
         return await _ctx.execute(str | None)
 
+    def as_union_type_def(self) -> "UnionTypeDef":
+        """Retrieve the binding value, as type UnionTypeDef"""
+        _args: list[Arg] = []
+        _ctx = self._select("asUnionTypeDef", _args)
+        return UnionTypeDef(_ctx)
+
     async def digest(self) -> str:
         """Returns the digest of the binding value
 
@@ -969,6 +1125,51 @@ class CacheVolume(Type):
         _ctx = self._select("id", _args)
         return await _ctx.execute(CacheVolumeID)
 
+    async def pin(self) -> Void | None:
+        """Pin the contents of this cache volume, so that they're never pruned by
+        garbage collection.
+
+        Contents written to the volume later are pinned too, until it's
+        unpinned. Only the main client can pin a cache volume.
+
+        Returns
+        -------
+        Void | None
+            The absence of a value.  A Null Void is used as a placeholder for
+            resolvers that do not return anything.
+
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
+        """
+        _args: list[Arg] = []
+        _ctx = self._select("pin", _args)
+        await _ctx.execute()
+
+    async def unpin(self) -> Void | None:
+        """Unpin the contents of this cache volume, so that they can be pruned by
+        garbage collection again.
+
+        Returns
+        -------
+        Void | None
+            The absence of a value.  A Null Void is used as a placeholder for
+            resolvers that do not return anything.
+
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
+        """
+        _args: list[Arg] = []
+        _ctx = self._select("unpin", _args)
+        await _ctx.execute()
+
 
 @typecheck
 class Changeset(Type):
@@ -1151,6 +1352,31 @@ class Changeset(Type):
     def __await__(self):
         return self.sync().__await__()
 
+    def with_changeset(self, changes: Self) -> Self:
+        """Add changes to this changeset, if they don't conflict with it.
+
+        Both changesets must compare against the same directory. The changes
+        conflict if a file is changed by both, or changed by one and removed
+        by the other.
+
+        Parameters
+        ----------
+        changes:
+            The changes to add.
+        """
+        _args = [
+            Arg("changes", changes),
+        ]
+        _ctx = self._select("withChangeset", _args)
+        return Changeset(_ctx)
+
+    def with_(self, cb: Callable[["Changeset"], "Changeset"]) -> "Changeset":
+        """Call the provided callable with current Changeset.
+
+        This is useful for reusability and readability by not breaking the calling chain.
+        """
+        return cb(self)
+
 
 @typecheck
 class Check(Type):
@@ -1429,6 +1655,8 @@ class Container(Type):
         insecure_root_capabilities: bool | None = False,
         expand: bool | None = False,
         no_init: bool | None = False,
+        network_mode: NetworkMode | None = NetworkMode.FULL,
+        egress_allowlist: list[str] | None = None,
     ) -> "Service":
         """Turn the container into a Service.
 
@@ -1459,6 +1687,15 @@ class Container(Type):
             This should only be used if the user requires that their exec
             process be the pid 1 process in the container. Otherwise it may
             result in unexpected behavior.
+        network_mode:
+            Restrict the network access of the service.
+            Blocked connection attempts are reported as events on the
+            service's span.
+        egress_allowlist:
+            Hostnames and CIDRs the service may connect to, on top of what its
+            network mode allows (e.g., ["proxy.golang.org", "10.0.0.0/8"]).
+            Hostnames are resolved when the service starts. Requires a
+            networkMode of NONE or SERVICES_ONLY.
         """
         _args = [
             Arg("args", [] if args is None else args, []),
@@ -1469,6 +1706,12 @@ class Container(Type):
             Arg("insecureRootCapabilities", insecure_root_capabilities, False),
             Arg("expand", expand, False),
             Arg("noInit", no_init, False),
+            Arg("networkMode", network_mode, NetworkMode.FULL),
+            Arg(
+                "egressAllowlist",
+                [] if egress_allowlist is None else egress_allowlist,
+                [],
+            ),
         ]
         _ctx = self._select("asService", _args)
         return Service(_ctx)
@@ -1538,6 +1781,24 @@ class Container(Type):
         _ctx = self._select("combinedOutput", _args)
         return await _ctx.execute(str)
 
+    def compare(self, other: Self) -> "ContainerComparison":
+        """Compare this container's image configuration, layers and root
+        filesystem with another container, typically a newer version of it.
+
+        Layers that haven't been exported yet are compressed to compute their
+        digest and size.
+
+        Parameters
+        ----------
+        other:
+            The newer container to compare against.
+        """
+        _args = [
+            Arg("other", other),
+        ]
+        _ctx = self._select("compare", _args)
+        return ContainerComparison(_ctx)
+
     async def default_args(self) -> list[str]:
         """Return the container's default arguments.
 
@@ -1741,6 +2002,11 @@ class Container(Type):
         forced_compression: ImageLayerCompression | None = None,
         media_types: ImageMediaTypes | None = ImageMediaTypes.OCIMediaTypes,
         expand: bool | None = False,
+        signing_key: "Secret | None" = None,
+        signing_key_password: "Secret | None" = None,
+        provenance: bool | None = False,
+        sbom: SBOMFormat | None = None,
+        squash: ImageSquashMode | None = None,
     ) -> str:
         """Writes the container as an OCI tarball to the destination file path on
         the host.
@@ -1772,6 +2038,25 @@ class Container(Type):
             Replace "${VAR}" or "$VAR" in the value of path according to the
             current environment variables defined in the container (e.g.
             "/$VAR/foo").
+        signing_key:
+            Private key to sign the image with, in PEM format.
+            Both keys generated by "cosign generate-key-pair" and unencrypted
+            PKCS#8, EC and RSA keys are supported. The signature, and the
+            provenance attestation if requested, are attached to the image as
+            OCI referrers in the sigstore bundle format.
+        signing_key_password:
+            Password of the signing key, if it's encrypted.
+        provenance:
+            Attach a SLSA provenance attestation describing the calls that
+            built the image.
+        sbom:
+            Attach a software bill of materials (SBOM) attestation in the
+            given format, as returned by "sbom".
+        squash:
+            Squash the layers of the exported image.
+            By default, the changes between each layer boundary set with
+            "withLayerBoundary" are grouped into a single layer, and each
+            operation makes its own layer if there are no boundaries.
 
         Returns
         -------
@@ -1797,6 +2082,11 @@ class Container(Type):
             Arg("forcedCompression", forced_compression, None),
             Arg("mediaTypes", media_types, ImageMediaTypes.OCIMediaTypes),
             Arg("expand", expand, False),
+            Arg("signingKey", signing_key, None),
+            Arg("signingKeyPassword", signing_key_password, None),
+            Arg("provenance", provenance, False),
+            Arg("sbom", sbom, None),
+            Arg("squash", squash, None),
         ]
         _ctx = self._select("export", _args)
         return await _ctx.execute(str)
@@ -2063,6 +2353,11 @@ class Container(Type):
         platform_variants: "list[Container] | None" = None,
         forced_compression: ImageLayerCompression | None = None,
         media_types: ImageMediaTypes | None = ImageMediaTypes.OCIMediaTypes,
+        signing_key: "Secret | None" = None,
+        signing_key_password: "Secret | None" = None,
+        provenance: bool | None = False,
+        sbom: SBOMFormat | None = None,
+        squash: ImageSquashMode | None = None,
     ) -> str:
         """Package the container state as an OCI image, and publish it to a
         registry
@@ -2092,6 +2387,25 @@ class Container(Type):
             Defaults to "OCI", which is compatible with most recent
             registries, but "Docker" may be needed for older registries
             without OCI support.
+        signing_key:
+            Private key to sign the image with, in PEM format.
+            Both keys generated by "cosign generate-key-pair" and unencrypted
+            PKCS#8, EC and RSA keys are supported. The signature, and the
+            provenance attestation if requested, are attached to the image as
+            OCI referrers in the sigstore bundle format.
+        signing_key_password:
+            Password of the signing key, if it's encrypted.
+        provenance:
+            Attach a SLSA provenance attestation describing the calls that
+            built the image.
+        sbom:
+            Attach a software bill of materials (SBOM) attestation in the
+            given format, as returned by "sbom".
+        squash:
+            Squash the layers of the published image.
+            By default, the changes between each layer boundary set with
+            "withLayerBoundary" are grouped into a single layer, and each
+            operation makes its own layer if there are no boundaries.
 
         Returns
         -------
@@ -2116,6 +2430,11 @@ class Container(Type):
             ),
             Arg("forcedCompression", forced_compression, None),
             Arg("mediaTypes", media_types, ImageMediaTypes.OCIMediaTypes),
+            Arg("signingKey", signing_key, None),
+            Arg("signingKeyPassword", signing_key_password, None),
+            Arg("provenance", provenance, False),
+            Arg("sbom", sbom, None),
+            Arg("squash", squash, None),
         ]
         _ctx = self._select("publish", _args)
         return await _ctx.execute(str)
@@ -2129,6 +2448,29 @@ class Container(Type):
         _ctx = self._select("rootfs", _args)
         return Directory(_ctx)
 
+    def sbom(
+        self,
+        *,
+        format: SBOMFormat | None = SBOMFormat.SPDX_JSON,
+    ) -> "File":
+        """Return a software bill of materials (SBOM) of the packages in the
+        container's root filesystem.
+
+        OS packages installed with apk, dpkg or rpm are detected, as well as
+        the dependencies in Go, npm, Python and Cargo lockfiles. Mounts are
+        not scanned.
+
+        Parameters
+        ----------
+        format:
+            Format of the SBOM.
+        """
+        _args = [
+            Arg("format", format, SBOMFormat.SPDX_JSON),
+        ]
+        _ctx = self._select("sbom", _args)
+        return File(_ctx)
+
     def stat(
         self,
         path: str,
@@ -2261,6 +2603,8 @@ class Container(Type):
         insecure_root_capabilities: bool | None = False,
         expand: bool | None = False,
         no_init: bool | None = False,
+        network_mode: NetworkMode | None = NetworkMode.FULL,
+        egress_allowlist: list[str] | None = None,
     ) -> Void | None:
         """Starts a Service and creates a tunnel that forwards traffic from the
         caller's network to that service.
@@ -2298,6 +2642,15 @@ class Container(Type):
             This should only be used if the user requires that their exec
             process be the pid 1 process in the container. Otherwise it may
             result in unexpected behavior.
+        network_mode:
+            Restrict the network access of the service.
+            Blocked connection attempts are reported as events on the
+            service's span.
+        egress_allowlist:
+            Hostnames and CIDRs the service may connect to, on top of what its
+            network mode allows (e.g., ["proxy.golang.org", "10.0.0.0/8"]).
+            Hostnames are resolved when the service starts. Requires a
+            networkMode of NONE or SERVICES_ONLY.
 
         Returns
         -------
@@ -2323,6 +2676,12 @@ class Container(Type):
             Arg("insecureRootCapabilities", insecure_root_capabilities, False),
             Arg("expand", expand, False),
             Arg("noInit", no_init, False),
+            Arg("networkMode", network_mode, NetworkMode.FULL),
+            Arg(
+                "egressAllowlist",
+                [] if egress_allowlist is None else egress_allowlist,
+                [],
+            ),
         ]
         _ctx = self._select("up", _args)
         await _ctx.execute()
@@ -2365,6 +2724,18 @@ class Container(Type):
         _ctx = self._select("withAnnotation", _args)
         return Container(_ctx)
 
+    def with_breakpoint(self) -> Self:
+        """Pauses the pipeline when this container is evaluated, opening an
+        interactive terminal for it.
+
+        The pipeline continues when the terminal exits. Breakpoints only pause
+        when the CLI runs with --interactive or --break-on, so they can be
+        left in code, and they don't affect the cache of the pipeline.
+        """
+        _args: list[Arg] = []
+        _ctx = self._select("withBreakpoint", _args)
+        return Container(_ctx)
+
     def with_default_args(self, args: list[str]) -> Self:
         """Configures default arguments for future commands. Like CMD in
         Dockerfile.
@@ -2559,6 +2930,8 @@ class Container(Type):
         insecure_root_capabilities: bool | None = False,
         expand: bool | None = False,
         no_init: bool | None = False,
+        network_mode: NetworkMode | None = NetworkMode.FULL,
+        egress_allowlist: list[str] | None = None,
     ) -> Self:
         """Execute a command in the container, and return a new snapshot of the
         container state after execution.
@@ -2606,6 +2979,15 @@ class Container(Type):
             Only use this if you specifically need the command to be pid 1 in
             the container. Otherwise it may result in unexpected behavior. If
             you're not sure, you don't need this.
+        network_mode:
+            Restrict the network access of the command.
+            Blocked connection attempts are reported as events on the
+            command's span.
+        egress_allowlist:
+            Hostnames and CIDRs the command may connect to, on top of what its
+            network mode allows (e.g., ["proxy.golang.org", "10.0.0.0/8"]).
+            Hostnames are resolved when the command starts. Requires a
+            networkMode of NONE or SERVICES_ONLY.
         """
         _args = [
             Arg("args", args),
@@ -2621,6 +3003,12 @@ class Container(Type):
             Arg("insecureRootCapabilities", insecure_root_capabilities, False),
             Arg("expand", expand, False),
             Arg("noInit", no_init, False),
+            Arg("networkMode", network_mode, NetworkMode.FULL),
+            Arg(
+                "egressAllowlist",
+                [] if egress_allowlist is None else egress_allowlist,
+                [],
+            ),
         ]
         _ctx = self._select("withExec", _args)
         return Container(_ctx)
@@ -2759,6 +3147,19 @@ class Container(Type):
         _ctx = self._select("withLabel", _args)
         return Container(_ctx)
 
+    def with_layer_boundary(self) -> Self:
+        """Retrieves this container with a layer boundary at the current state of
+        its root filesystem.
+
+        When the container is published or exported, the changes between
+        consecutive boundaries are grouped into a single layer, on top of the
+        layers of the image the container was created from. Changes after the
+        last boundary make a layer of their own.
+        """
+        _args: list[Arg] = []
+        _ctx = self._select("withLayerBoundary", _args)
+        return Container(_ctx)
+
     def with_mounted_cache(
         self,
         path: str,
@@ -3471,25 +3872,38 @@ class Container(Type):
 
 
 @typecheck
-class CurrentModule(Type):
-    """Reflective module API provided to functions at runtime."""
+class ContainerComparison(Type):
+    """A comparison between the image configuration, layers and root
+    filesystem of two containers."""
 
-    async def dependencies(self) -> list["Module"]:
-        """The dependencies of the module."""
+    def after(self) -> Container:
+        """The newer container."""
         _args: list[Arg] = []
-        _ctx = self._select("dependencies", _args)
-        return await _ctx.execute_object_list(Module)
+        _ctx = self._select("after", _args)
+        return Container(_ctx)
 
-    def generated_context_directory(self) -> "Directory":
-        """The generated files and directories made on top of the module source's
-        context directory.
+    def before(self) -> Container:
+        """The older container to compare against."""
+        _args: list[Arg] = []
+        _ctx = self._select("before", _args)
+        return Container(_ctx)
+
+    def changes(self) -> Changeset:
+        """The changes between the root filesystems of the containers."""
+        _args: list[Arg] = []
+        _ctx = self._select("changes", _args)
+        return Changeset(_ctx)
+
+    async def config_changes(self) -> list["ContainerConfigChange"]:
+        """Changes to the image configuration, e.g. environment variables,
+        entrypoint, user, labels and exposed ports.
         """
         _args: list[Arg] = []
-        _ctx = self._select("generatedContextDirectory", _args)
-        return Directory(_ctx)
+        _ctx = self._select("configChanges", _args)
+        return await _ctx.execute_object_list(ContainerConfigChange)
 
-    async def id(self) -> CurrentModuleID:
-        """A unique identifier for this CurrentModule.
+    async def id(self) -> ContainerComparisonID:
+        """A unique identifier for this ContainerComparison.
 
         Note
         ----
@@ -3497,9 +3911,9 @@ class CurrentModule(Type):
 
         Returns
         -------
-        CurrentModuleID
-            The `CurrentModuleID` scalar type represents an identifier for an
-            object of type CurrentModule.
+        ContainerComparisonID
+            The `ContainerComparisonID` scalar type represents an identifier
+            for an object of type ContainerComparison.
 
         Raises
         ------
@@ -3510,10 +3924,18 @@ class CurrentModule(Type):
         """
         _args: list[Arg] = []
         _ctx = self._select("id", _args)
-        return await _ctx.execute(CurrentModuleID)
+        return await _ctx.execute(ContainerComparisonID)
 
-    async def name(self) -> str:
-        """The name of the module being executed in
+    async def layers(self) -> list["ContainerLayerComparison"]:
+        """Comparison of the layers of the root filesystems, by position from the
+        base layer.
+        """
+        _args: list[Arg] = []
+        _ctx = self._select("layers", _args)
+        return await _ctx.execute_object_list(ContainerLayerComparison)
+
+    async def summary(self) -> str:
+        """A human-readable summary of the comparison.
 
         Returns
         -------
@@ -3530,179 +3952,59 @@ class CurrentModule(Type):
             If the API returns an error.
         """
         _args: list[Arg] = []
-        _ctx = self._select("name", _args)
+        _ctx = self._select("summary", _args)
         return await _ctx.execute(str)
 
-    def source(self) -> "Directory":
-        """The directory containing the module's source code loaded into the
-        engine (plus any generated code that may have been created).
-        """
-        _args: list[Arg] = []
-        _ctx = self._select("source", _args)
-        return Directory(_ctx)
 
-    def workdir(
-        self,
-        path: str,
-        *,
-        exclude: list[str] | None = None,
-        include: list[str] | None = None,
-        gitignore: bool | None = False,
-    ) -> "Directory":
-        """Load a directory from the module's scratch working directory,
-        including any changes that may have been made to it during module
-        function execution.
+@typecheck
+class ContainerConfigChange(Type):
+    """A change to the image configuration between two containers."""
 
-        Parameters
-        ----------
-        path:
-            Location of the directory to access (e.g., ".").
-        exclude:
-            Exclude artifacts that match the given pattern (e.g.,
-            ["node_modules/", ".git*"]).
-        include:
-            Include only artifacts that match the given pattern (e.g.,
-            ["app/", "package.*"]).
-        gitignore:
-            Apply .gitignore filter rules inside the directory
-        """
-        _args = [
-            Arg("path", path),
-            Arg("exclude", [] if exclude is None else exclude, []),
-            Arg("include", [] if include is None else include, []),
-            Arg("gitignore", gitignore, False),
-        ]
-        _ctx = self._select("workdir", _args)
-        return Directory(_ctx)
+    async def after(self) -> str:
+        """The value in the newer container. Lists are encoded as JSON arrays.
 
-    def workdir_file(self, path: str) -> "File":
-        """Load a file from the module's scratch working directory, including any
-        changes that may have been made to it during module function
-        execution.Load a file from the module's scratch working directory,
-        including any changes that may have been made to it during module
-        function execution.
+        Returns
+        -------
+        str
+            The `String` scalar type represents textual data, represented as
+            UTF-8 character sequences. The String type is most often used by
+            GraphQL to represent free-form human-readable text.
 
-        Parameters
-        ----------
-        path:
-            Location of the file to retrieve (e.g., "README.md").
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
         """
-        _args = [
-            Arg("path", path),
-        ]
-        _ctx = self._select("workdirFile", _args)
-        return File(_ctx)
+        _args: list[Arg] = []
+        _ctx = self._select("after", _args)
+        return await _ctx.execute(str)
 
+    async def before(self) -> str:
+        """The value in the older container. Lists are encoded as JSON arrays.
 
-@typecheck
-class Directory(Type):
-    """A directory."""
-
-    def as_git(self) -> "GitRepository":
-        """Converts this directory to a local git repository"""
-        _args: list[Arg] = []
-        _ctx = self._select("asGit", _args)
-        return GitRepository(_ctx)
-
-    def as_module(
-        self,
-        *,
-        source_root_path: str | None = ".",
-    ) -> "Module":
-        """Load the directory as a Dagger module source
-
-        Parameters
-        ----------
-        source_root_path:
-            An optional subpath of the directory which contains the module's
-            configuration file.
-            If not set, the module source code is loaded from the root of the
-            directory.
-        """
-        _args = [
-            Arg("sourceRootPath", source_root_path, "."),
-        ]
-        _ctx = self._select("asModule", _args)
-        return Module(_ctx)
-
-    def as_module_source(
-        self,
-        *,
-        source_root_path: str | None = ".",
-    ) -> "ModuleSource":
-        """Load the directory as a Dagger module source
-
-        Parameters
-        ----------
-        source_root_path:
-            An optional subpath of the directory which contains the module's
-            configuration file.
-            If not set, the module source code is loaded from the root of the
-            directory.
-        """
-        _args = [
-            Arg("sourceRootPath", source_root_path, "."),
-        ]
-        _ctx = self._select("asModuleSource", _args)
-        return ModuleSource(_ctx)
-
-    def changes(self, from_: Self) -> Changeset:
-        """Return the difference between this directory and another directory,
-        typically an older snapshot.
-
-        The difference is encoded as a changeset, which also tracks removed
-        files, and can be applied to other directories.
-
-        Parameters
-        ----------
-        from_:
-            The base directory snapshot to compare against
-        """
-        _args = [
-            Arg("from", from_),
-        ]
-        _ctx = self._select("changes", _args)
-        return Changeset(_ctx)
-
-    def chown(self, path: str, owner: str) -> Self:
-        """Change the owner of the directory contents recursively.
-
-        Parameters
-        ----------
-        path:
-            Path of the directory to change ownership of (e.g., "/").
-        owner:
-            A user:group to set for the mounted directory and its contents.
-            The user and group must be an ID (1000:1000), not a name
-            (foo:bar).
-            If the group is omitted, it defaults to the same as the user.
-        """
-        _args = [
-            Arg("path", path),
-            Arg("owner", owner),
-        ]
-        _ctx = self._select("chown", _args)
-        return Directory(_ctx)
-
-    def diff(self, other: Self) -> Self:
-        """Return the difference between this directory and an another directory.
-        The difference is encoded as a directory.
+        Returns
+        -------
+        str
+            The `String` scalar type represents textual data, represented as
+            UTF-8 character sequences. The String type is most often used by
+            GraphQL to represent free-form human-readable text.
 
-        Parameters
-        ----------
-        other:
-            The directory to compare against
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
         """
-        _args = [
-            Arg("other", other),
-        ]
-        _ctx = self._select("diff", _args)
-        return Directory(_ctx)
+        _args: list[Arg] = []
+        _ctx = self._select("before", _args)
+        return await _ctx.execute(str)
 
-    async def digest(self) -> str:
-        """Return the directory's digest. The format of the digest is not
-        guaranteed to be stable between releases of Dagger. It is guaranteed
-        to be stable between invocations of the same Dagger engine.
+    async def field(self) -> str:
+        """The changed setting, named after the API that sets it, e.g.
+        "envVariable", "entrypoint" or "label".
 
         Returns
         -------
@@ -3719,80 +4021,40 @@ class Directory(Type):
             If the API returns an error.
         """
         _args: list[Arg] = []
-        _ctx = self._select("digest", _args)
+        _ctx = self._select("field", _args)
         return await _ctx.execute(str)
 
-    def directory(self, path: str) -> Self:
-        """Retrieves a directory at the given path.
+    async def id(self) -> ContainerConfigChangeID:
+        """A unique identifier for this ContainerConfigChange.
 
-        Parameters
-        ----------
-        path:
-            Location of the directory to retrieve. Example: "/src"
-        """
-        _args = [
-            Arg("path", path),
-        ]
-        _ctx = self._select("directory", _args)
-        return Directory(_ctx)
+        Note
+        ----
+        This is lazily evaluated, no operation is actually run.
 
-    def docker_build(
-        self,
-        *,
-        dockerfile: str | None = "Dockerfile",
-        platform: Platform | None = None,
-        build_args: list[BuildArg] | None = None,
-        target: str | None = "",
-        secrets: "list[Secret] | None" = None,
-        no_init: bool | None = False,
-    ) -> Container:
-        """Use Dockerfile compatibility to build a container from this directory.
-        Only use this function for Dockerfile compatibility. Otherwise use the
-        native Container type directly, it is feature-complete and supports
-        all Dockerfile features.
+        Returns
+        -------
+        ContainerConfigChangeID
+            The `ContainerConfigChangeID` scalar type represents an identifier
+            for an object of type ContainerConfigChange.
 
-        Parameters
-        ----------
-        dockerfile:
-            Path to the Dockerfile to use (e.g., "frontend.Dockerfile").
-        platform:
-            The platform to build.
-        build_args:
-            Build arguments to use in the build.
-        target:
-            Target build stage to build.
-        secrets:
-            Secrets to pass to the build.
-            They will be mounted at /run/secrets/[secret-name].
-        no_init:
-            If set, skip the automatic init process injected into containers
-            created by RUN statements.
-            This should only be used if the user requires that their exec
-            processes be the pid 1 process in the container. Otherwise it may
-            result in unexpected behavior.
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
         """
-        _args = [
-            Arg("dockerfile", dockerfile, "Dockerfile"),
-            Arg("platform", platform, None),
-            Arg("buildArgs", [] if build_args is None else build_args, []),
-            Arg("target", target, ""),
-            Arg("secrets", [] if secrets is None else secrets, []),
-            Arg("noInit", no_init, False),
-        ]
-        _ctx = self._select("dockerBuild", _args)
-        return Container(_ctx)
-
-    async def entries(self, *, path: str | None = None) -> list[str]:
-        """Returns a list of files and directories at the given path.
+        _args: list[Arg] = []
+        _ctx = self._select("id", _args)
+        return await _ctx.execute(ContainerConfigChangeID)
 
-        Parameters
-        ----------
-        path:
-            Location of the directory to look at (e.g., "/src").
+    async def key(self) -> str:
+        """The changed entry of settings that have several, i.e. the name of an
+        environment variable or label, or an exposed port such as "8080/tcp".
 
         Returns
         -------
-        list[str]
+        str
             The `String` scalar type represents textual data, represented as
             UTF-8 character sequences. The String type is most often used by
             GraphQL to represent free-form human-readable text.
@@ -3804,35 +4066,17 @@ class Directory(Type):
         QueryError
             If the API returns an error.
         """
-        _args = [
-            Arg("path", path, None),
-        ]
-        _ctx = self._select("entries", _args)
-        return await _ctx.execute(list[str])
-
-    async def exists(
-        self,
-        path: str,
-        *,
-        expected_type: ExistsType | None = None,
-        do_not_follow_symlinks: bool | None = False,
-    ) -> bool:
-        """check if a file or directory exists
+        _args: list[Arg] = []
+        _ctx = self._select("key", _args)
+        return await _ctx.execute(str)
 
-        Parameters
-        ----------
-        path:
-            Path to check (e.g., "/file.txt").
-        expected_type:
-            If specified, also validate the type of file (e.g. "REGULAR_TYPE",
-            "DIRECTORY_TYPE", or "SYMLINK_TYPE").
-        do_not_follow_symlinks:
-            If specified, do not follow symlinks.
+    async def kind(self) -> ContainerChangeKind:
+        """How the setting changed.
 
         Returns
         -------
-        bool
-            The `Boolean` scalar type represents `true` or `false`.
+        ContainerChangeKind
+            How an entry differs between two containers
 
         Raises
         ------
@@ -3841,34 +4085,19 @@ class Directory(Type):
         QueryError
             If the API returns an error.
         """
-        _args = [
-            Arg("path", path),
-            Arg("expectedType", expected_type, None),
-            Arg("doNotFollowSymlinks", do_not_follow_symlinks, False),
-        ]
-        _ctx = self._select("exists", _args)
-        return await _ctx.execute(bool)
+        _args: list[Arg] = []
+        _ctx = self._select("kind", _args)
+        return await _ctx.execute(ContainerChangeKind)
 
-    async def export(
-        self,
-        path: str,
-        *,
-        wipe: bool | None = False,
-    ) -> str:
-        """Writes the contents of the directory to a path on the host.
 
-        Parameters
-        ----------
-        path:
-            Location of the copied directory (e.g., "logs/").
-        wipe:
-            If true, then the host directory will be wiped clean before
-            exporting so that it exactly matches the directory being exported;
-            this means it will delete any files on the host that aren't in the
-            exported dir. If false (the default), the contents of the
-            directory will be merged with any existing contents of the host
-            directory, leaving any existing files on the host that aren't in
-            the exported directory alone.
+@typecheck
+class ContainerLayerComparison(Type):
+    """A comparison of the layers of two containers at the same
+    position."""
+
+    async def after_digest(self) -> str:
+        """The digest of the layer in the newer container, if it has one at this
+        position.
 
         Returns
         -------
@@ -3884,69 +4113,38 @@ class Directory(Type):
         QueryError
             If the API returns an error.
         """
-        _args = [
-            Arg("path", path),
-            Arg("wipe", wipe, False),
-        ]
-        _ctx = self._select("export", _args)
+        _args: list[Arg] = []
+        _ctx = self._select("afterDigest", _args)
         return await _ctx.execute(str)
 
-    def file(self, path: str) -> "File":
-        """Retrieve a file at the given path.
-
-        Parameters
-        ----------
-        path:
-            Location of the file to retrieve (e.g., "README.md").
-        """
-        _args = [
-            Arg("path", path),
-        ]
-        _ctx = self._select("file", _args)
-        return File(_ctx)
+    async def after_size(self) -> int:
+        """The compressed size of the layer in the newer container, in bytes.
 
-    def filter(
-        self,
-        *,
-        exclude: list[str] | None = None,
-        include: list[str] | None = None,
-        gitignore: bool | None = False,
-    ) -> Self:
-        """Return a snapshot with some paths included or excluded
+        Returns
+        -------
+        int
+            The `Int` scalar type represents non-fractional signed whole
+            numeric values. Int can represent values between -(2^31) and 2^31
+            - 1.
 
-        Parameters
-        ----------
-        exclude:
-            If set, paths matching one of these glob patterns is excluded from
-            the new snapshot. Example: ["node_modules/", ".git*", ".env"]
-        include:
-            If set, only paths matching one of these glob patterns is included
-            in the new snapshot. Example: (e.g., ["app/", "package.*"]).
-        gitignore:
-            If set, apply .gitignore rules when filtering the directory.
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
         """
-        _args = [
-            Arg("exclude", [] if exclude is None else exclude, []),
-            Arg("include", [] if include is None else include, []),
-            Arg("gitignore", gitignore, False),
-        ]
-        _ctx = self._select("filter", _args)
-        return Directory(_ctx)
-
-    async def find_up(self, name: str, start: str) -> str | None:
-        """Search up the directory tree for a file or directory, and return its
-        path. If no match, return null
+        _args: list[Arg] = []
+        _ctx = self._select("afterSize", _args)
+        return await _ctx.execute(int)
 
-        Parameters
-        ----------
-        name:
-            The name of the file or directory to search for
-        start:
-            The path to start the search from
+    async def before_digest(self) -> str:
+        """The digest of the layer in the older container, if it has one at this
+        position.
 
         Returns
         -------
-        str | None
+        str
             The `String` scalar type represents textual data, represented as
             UTF-8 character sequences. The String type is most often used by
             GraphQL to represent free-form human-readable text.
@@ -3958,27 +4156,19 @@ class Directory(Type):
         QueryError
             If the API returns an error.
         """
-        _args = [
-            Arg("name", name),
-            Arg("start", start),
-        ]
-        _ctx = self._select("findUp", _args)
-        return await _ctx.execute(str | None)
-
-    async def glob(self, pattern: str) -> list[str]:
-        """Returns a list of files and directories that matche the given pattern.
+        _args: list[Arg] = []
+        _ctx = self._select("beforeDigest", _args)
+        return await _ctx.execute(str)
 
-        Parameters
-        ----------
-        pattern:
-            Pattern to match (e.g., "*.md").
+    async def before_size(self) -> int:
+        """The compressed size of the layer in the older container, in bytes.
 
         Returns
         -------
-        list[str]
-            The `String` scalar type represents textual data, represented as
-            UTF-8 character sequences. The String type is most often used by
-            GraphQL to represent free-form human-readable text.
+        int
+            The `Int` scalar type represents non-fractional signed whole
+            numeric values. Int can represent values between -(2^31) and 2^31
+            - 1.
 
         Raises
         ------
@@ -3987,14 +4177,12 @@ class Directory(Type):
         QueryError
             If the API returns an error.
         """
-        _args = [
-            Arg("pattern", pattern),
-        ]
-        _ctx = self._select("glob", _args)
-        return await _ctx.execute(list[str])
+        _args: list[Arg] = []
+        _ctx = self._select("beforeSize", _args)
+        return await _ctx.execute(int)
 
-    async def id(self) -> DirectoryID:
-        """A unique identifier for this Directory.
+    async def id(self) -> ContainerLayerComparisonID:
+        """A unique identifier for this ContainerLayerComparison.
 
         Note
         ----
@@ -4002,9 +4190,9 @@ class Directory(Type):
 
         Returns
         -------
-        DirectoryID
-            The `DirectoryID` scalar type represents an identifier for an
-            object of type Directory.
+        ContainerLayerComparisonID
+            The `ContainerLayerComparisonID` scalar type represents an
+            identifier for an object of type ContainerLayerComparison.
 
         Raises
         ------
@@ -4015,17 +4203,17 @@ class Directory(Type):
         """
         _args: list[Arg] = []
         _ctx = self._select("id", _args)
-        return await _ctx.execute(DirectoryID)
+        return await _ctx.execute(ContainerLayerComparisonID)
 
-    async def name(self) -> str:
-        """Returns the name of the directory.
+    async def index(self) -> int:
+        """The position of the layer, starting from the base layer at 0.
 
         Returns
         -------
-        str
-            The `String` scalar type represents textual data, represented as
-            UTF-8 character sequences. The String type is most often used by
-            GraphQL to represent free-form human-readable text.
+        int
+            The `Int` scalar type represents non-fractional signed whole
+            numeric values. Int can represent values between -(2^31) and 2^31
+            - 1.
 
         Raises
         ------
@@ -4035,96 +4223,59 @@ class Directory(Type):
             If the API returns an error.
         """
         _args: list[Arg] = []
-        _ctx = self._select("name", _args)
-        return await _ctx.execute(str)
+        _ctx = self._select("index", _args)
+        return await _ctx.execute(int)
 
-    async def search(
-        self,
-        pattern: str,
-        *,
-        paths: list[str] | None = None,
-        globs: list[str] | None = None,
-        literal: bool | None = False,
-        multiline: bool | None = False,
-        dotall: bool | None = False,
-        insensitive: bool | None = False,
-        skip_ignored: bool | None = False,
-        skip_hidden: bool | None = False,
-        files_only: bool | None = False,
-        limit: int | None = None,
-    ) -> list["SearchResult"]:
-        """Searches for content matching the given regular expression or literal
-        string.
+    async def kind(self) -> ContainerChangeKind:
+        """Whether the layer differs between the containers.
 
-        Uses Rust regex syntax; escape literal ., [, ], {, }, | with
-        backslashes.
+        Returns
+        -------
+        ContainerChangeKind
+            How an entry differs between two containers
 
-        Parameters
-        ----------
-        pattern:
-            The text to match.
-        paths:
-            Directory or file paths to search
-        globs:
-            Glob patterns to match (e.g., "*.md")
-        literal:
-            Interpret the pattern as a literal string instead of a regular
-            expression.
-        multiline:
-            Enable searching across multiple lines.
-        dotall:
-            Allow the . pattern to match newlines in multiline mode.
-        insensitive:
-            Enable case-insensitive matching.
-        skip_ignored:
-            Honor .gitignore, .ignore, and .rgignore files.
-        skip_hidden:
-            Skip hidden files (files starting with .).
-        files_only:
-            Only return matching files, not lines and content
-        limit:
-            Limit the number of results to return
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
         """
-        _args = [
-            Arg("pattern", pattern),
-            Arg("paths", [] if paths is None else paths, []),
-            Arg("globs", [] if globs is None else globs, []),
-            Arg("literal", literal, False),
-            Arg("multiline", multiline, False),
-            Arg("dotall", dotall, False),
-            Arg("insensitive", insensitive, False),
-            Arg("skipIgnored", skip_ignored, False),
-            Arg("skipHidden", skip_hidden, False),
-            Arg("filesOnly", files_only, False),
-            Arg("limit", limit, None),
-        ]
-        _ctx = self._select("search", _args)
-        return await _ctx.execute_object_list(SearchResult)
+        _args: list[Arg] = []
+        _ctx = self._select("kind", _args)
+        return await _ctx.execute(ContainerChangeKind)
 
-    def stat(
-        self,
-        path: str,
-        *,
-        do_not_follow_symlinks: bool | None = False,
-    ) -> "Stat":
-        """Return file status
 
-        Parameters
-        ----------
-        path:
-            Path to stat (e.g., "/file.txt").
-        do_not_follow_symlinks:
-            If specified, do not follow symlinks.
+@typecheck
+class CurrentModule(Type):
+    """Reflective module API provided to functions at runtime."""
+
+    async def dependencies(self) -> list["Module"]:
+        """The dependencies of the module."""
+        _args: list[Arg] = []
+        _ctx = self._select("dependencies", _args)
+        return await _ctx.execute_object_list(Module)
+
+    def generated_context_directory(self) -> "Directory":
+        """The generated files and directories made on top of the module source's
+        context directory.
         """
-        _args = [
-            Arg("path", path),
-            Arg("doNotFollowSymlinks", do_not_follow_symlinks, False),
-        ]
-        _ctx = self._select("stat", _args)
-        return Stat(_ctx)
+        _args: list[Arg] = []
+        _ctx = self._select("generatedContextDirectory", _args)
+        return Directory(_ctx)
 
-    async def sync(self) -> Self:
-        """Force evaluation in the engine.
+    async def id(self) -> CurrentModuleID:
+        """A unique identifier for this CurrentModule.
+
+        Note
+        ----
+        This is lazily evaluated, no operation is actually run.
+
+        Returns
+        -------
+        CurrentModuleID
+            The `CurrentModuleID` scalar type represents an identifier for an
+            object of type CurrentModule.
 
         Raises
         ------
@@ -4134,81 +4285,54 @@ class Directory(Type):
             If the API returns an error.
         """
         _args: list[Arg] = []
-        return await self._ctx.execute_sync(self, "sync", _args)
-
-    def __await__(self):
-        return self.sync().__await__()
+        _ctx = self._select("id", _args)
+        return await _ctx.execute(CurrentModuleID)
 
-    def terminal(
-        self,
-        *,
-        container: Container | None = None,
-        cmd: list[str] | None = None,
-        experimental_privileged_nesting: bool | None = False,
-        insecure_root_capabilities: bool | None = False,
-    ) -> Self:
-        """Opens an interactive terminal in new container with this directory
-        mounted inside.
+    async def name(self) -> str:
+        """The name of the module being executed in
 
-        Parameters
-        ----------
-        container:
-            If set, override the default container used for the terminal.
-        cmd:
-            If set, override the container's default terminal command and
-            invoke these command arguments instead.
-        experimental_privileged_nesting:
-            Provides Dagger access to the executed command.
-        insecure_root_capabilities:
-            Execute the command with all root capabilities. This is similar to
-            running a command with "sudo" or executing "docker run" with the "
-            --privileged" flag. Containerization does not provide any security
-            guarantees when using this option. It should only be used when
-            absolutely necessary and only with trusted commands.
-        """
-        _args = [
-            Arg("container", container, None),
-            Arg("cmd", [] if cmd is None else cmd, []),
-            Arg(
-                "experimentalPrivilegedNesting", experimental_privileged_nesting, False
-            ),
-            Arg("insecureRootCapabilities", insecure_root_capabilities, False),
-        ]
-        _ctx = self._select("terminal", _args)
-        return Directory(_ctx)
+        Returns
+        -------
+        str
+            The `String` scalar type represents textual data, represented as
+            UTF-8 character sequences. The String type is most often used by
+            GraphQL to represent free-form human-readable text.
 
-    def with_changes(self, changes: Changeset) -> Self:
-        """Return a directory with changes from another directory applied to it.
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
+        """
+        _args: list[Arg] = []
+        _ctx = self._select("name", _args)
+        return await _ctx.execute(str)
 
-        Parameters
-        ----------
-        changes:
-            Changes to apply to the directory
+    def source(self) -> "Directory":
+        """The directory containing the module's source code loaded into the
+        engine (plus any generated code that may have been created).
         """
-        _args = [
-            Arg("changes", changes),
-        ]
-        _ctx = self._select("withChanges", _args)
+        _args: list[Arg] = []
+        _ctx = self._select("source", _args)
         return Directory(_ctx)
 
-    def with_directory(
+    def workdir(
         self,
         path: str,
-        source: Self,
         *,
         exclude: list[str] | None = None,
         include: list[str] | None = None,
         gitignore: bool | None = False,
-        owner: str | None = "",
-    ) -> Self:
-        """Return a snapshot with a directory added
+    ) -> "Directory":
+        """Load a directory from the module's scratch working directory,
+        including any changes that may have been made to it during module
+        function execution.
 
         Parameters
         ----------
         path:
-            Location of the written directory (e.g., "/src/").
-        source:
-            Identifier of the directory to copy.
+            Location of the directory to access (e.g., ".").
         exclude:
             Exclude artifacts that match the given pattern (e.g.,
             ["node_modules/", ".git*"]).
@@ -4217,285 +4341,1463 @@ class Directory(Type):
             ["app/", "package.*"]).
         gitignore:
             Apply .gitignore filter rules inside the directory
-        owner:
-            A user:group to set for the copied directory and its contents.
-            The user and group must be an ID (1000:1000), not a name
-            (foo:bar).
-            If the group is omitted, it defaults to the same as the user.
         """
         _args = [
             Arg("path", path),
-            Arg("source", source),
             Arg("exclude", [] if exclude is None else exclude, []),
             Arg("include", [] if include is None else include, []),
             Arg("gitignore", gitignore, False),
-            Arg("owner", owner, ""),
         ]
-        _ctx = self._select("withDirectory", _args)
+        _ctx = self._select("workdir", _args)
         return Directory(_ctx)
 
-    def with_error(self, err: str) -> Self:
-        """Raise an error.
+    def workdir_file(self, path: str) -> "File":
+        """Load a file from the module's scratch working directory, including any
+        changes that may have been made to it during module function
+        execution.Load a file from the module's scratch working directory,
+        including any changes that may have been made to it during module
+        function execution.
 
         Parameters
         ----------
-        err:
-            Message of the error to raise. If empty, the error will be
-            ignored.
+        path:
+            Location of the file to retrieve (e.g., "README.md").
         """
         _args = [
-            Arg("err", err),
+            Arg("path", path),
         ]
-        _ctx = self._select("withError", _args)
-        return Directory(_ctx)
+        _ctx = self._select("workdirFile", _args)
+        return File(_ctx)
 
-    def with_file(
+
+@typecheck
+class Directory(Type):
+    """A directory."""
+
+    def as_git(self) -> "GitRepository":
+        """Converts this directory to a local git repository"""
+        _args: list[Arg] = []
+        _ctx = self._select("asGit", _args)
+        return GitRepository(_ctx)
+
+    def as_module(
         self,
-        path: str,
-        source: "File",
         *,
-        permissions: int | None = None,
-        owner: str | None = "",
-    ) -> Self:
-        """Retrieves this directory plus the contents of the given file copied to
-        the given path.
+        source_root_path: str | None = ".",
+    ) -> "Module":
+        """Load the directory as a Dagger module source
 
         Parameters
         ----------
-        path:
-            Location of the copied file (e.g., "/file.txt").
-        source:
-            Identifier of the file to copy.
-        permissions:
-            Permission given to the copied file (e.g., 0600).
-        owner:
-            A user:group to set for the copied directory and its contents.
-            The user and group must be an ID (1000:1000), not a name
-            (foo:bar).
-            If the group is omitted, it defaults to the same as the user.
+        source_root_path:
+            An optional subpath of the directory which contains the module's
+            configuration file.
+            If not set, the module source code is loaded from the root of the
+            directory.
         """
         _args = [
-            Arg("path", path),
-            Arg("source", source),
-            Arg("permissions", permissions, None),
-            Arg("owner", owner, ""),
+            Arg("sourceRootPath", source_root_path, "."),
         ]
-        _ctx = self._select("withFile", _args)
-        return Directory(_ctx)
+        _ctx = self._select("asModule", _args)
+        return Module(_ctx)
 
-    def with_files(
+    def as_module_source(
         self,
-        path: str,
-        sources: list["File"],
         *,
-        permissions: int | None = None,
-    ) -> Self:
-        """Retrieves this directory plus the contents of the given files copied
-        to the given path.
+        source_root_path: str | None = ".",
+    ) -> "ModuleSource":
+        """Load the directory as a Dagger module source
 
         Parameters
         ----------
-        path:
-            Location where copied files should be placed (e.g., "/src").
-        sources:
-            Identifiers of the files to copy.
-        permissions:
-            Permission given to the copied files (e.g., 0600).
+        source_root_path:
+            An optional subpath of the directory which contains the module's
+            configuration file.
+            If not set, the module source code is loaded from the root of the
+            directory.
         """
         _args = [
-            Arg("path", path),
-            Arg("sources", sources),
-            Arg("permissions", permissions, None),
+            Arg("sourceRootPath", source_root_path, "."),
         ]
-        _ctx = self._select("withFiles", _args)
-        return Directory(_ctx)
+        _ctx = self._select("asModuleSource", _args)
+        return ModuleSource(_ctx)
 
-    def with_new_directory(
-        self,
-        path: str,
-        *,
-        permissions: int | None = 420,
-    ) -> Self:
-        """Retrieves this directory plus a new directory created at the given
-        path.
+    def changes(self, from_: Self) -> Changeset:
+        """Return the difference between this directory and another directory,
+        typically an older snapshot.
+
+        The difference is encoded as a changeset, which also tracks removed
+        files, and can be applied to other directories.
 
         Parameters
         ----------
-        path:
-            Location of the directory created (e.g., "/logs").
-        permissions:
-            Permission granted to the created directory (e.g., 0777).
+        from_:
+            The base directory snapshot to compare against
         """
         _args = [
-            Arg("path", path),
-            Arg("permissions", permissions, 420),
+            Arg("from", from_),
         ]
-        _ctx = self._select("withNewDirectory", _args)
-        return Directory(_ctx)
+        _ctx = self._select("changes", _args)
+        return Changeset(_ctx)
 
-    def with_new_file(
-        self,
-        path: str,
-        contents: str,
-        *,
-        permissions: int | None = 420,
-    ) -> Self:
-        """Return a snapshot with a new file added
+    def chown(self, path: str, owner: str) -> Self:
+        """Change the owner of the directory contents recursively.
 
         Parameters
         ----------
         path:
-            Path of the new file. Example: "foo/bar.txt"
-        contents:
-            Contents of the new file. Example: "Hello world!"
-        permissions:
-            Permissions of the new file. Example: 0600
+            Path of the directory to change ownership of (e.g., "/").
+        owner:
+            A user:group to set for the mounted directory and its contents.
+            The user and group must be an ID (1000:1000), not a name
+            (foo:bar).
+            If the group is omitted, it defaults to the same as the user.
         """
         _args = [
             Arg("path", path),
-            Arg("contents", contents),
-            Arg("permissions", permissions, 420),
+            Arg("owner", owner),
         ]
-        _ctx = self._select("withNewFile", _args)
+        _ctx = self._select("chown", _args)
         return Directory(_ctx)
 
-    def with_patch(self, patch: str) -> Self:
-        """Retrieves this directory with the given Git-compatible patch applied.
-
-        .. caution::
-            Experimental: This API is highly experimental and may be removed
-            or replaced entirely.
+    def diff(self, other: Self) -> Self:
+        """Return the difference between this directory and an another directory.
+        The difference is encoded as a directory.
 
         Parameters
         ----------
-        patch:
-            Patch to apply (e.g., "diff --git a/file.txt b/file.txt\nindex
-            1234567..abcdef8 100644\n--- a/file.txt\n+++ b/file.txt\n@@ -1,1
-            +1,1 @@\n-Hello\n+World\n").
+        other:
+            The directory to compare against
         """
         _args = [
-            Arg("patch", patch),
+            Arg("other", other),
         ]
-        _ctx = self._select("withPatch", _args)
+        _ctx = self._select("diff", _args)
         return Directory(_ctx)
 
-    def with_patch_file(self, patch: "File") -> Self:
-        """Retrieves this directory with the given Git-compatible patch file
-        applied.
+    async def digest(self) -> str:
+        """Return the directory's digest. The format of the digest is not
+        guaranteed to be stable between releases of Dagger. It is guaranteed
+        to be stable between invocations of the same Dagger engine.
 
-        .. caution::
-            Experimental: This API is highly experimental and may be removed
-            or replaced entirely.
+        Returns
+        -------
+        str
+            The `String` scalar type represents textual data, represented as
+            UTF-8 character sequences. The String type is most often used by
+            GraphQL to represent free-form human-readable text.
+
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
+        """
+        _args: list[Arg] = []
+        _ctx = self._select("digest", _args)
+        return await _ctx.execute(str)
+
+    def directory(self, path: str) -> Self:
+        """Retrieves a directory at the given path.
 
         Parameters
         ----------
-        patch:
-            File containing the patch to apply
+        path:
+            Location of the directory to retrieve. Example: "/src"
         """
         _args = [
-            Arg("patch", patch),
+            Arg("path", path),
         ]
-        _ctx = self._select("withPatchFile", _args)
+        _ctx = self._select("directory", _args)
         return Directory(_ctx)
 
-    def with_symlink(self, target: str, link_name: str) -> Self:
-        """Return a snapshot with a symlink
+    def docker_build(
+        self,
+        *,
+        dockerfile: str | None = "Dockerfile",
+        platform: Platform | None = None,
+        build_args: list[BuildArg] | None = None,
+        target: str | None = "",
+        secrets: "list[Secret] | None" = None,
+        no_init: bool | None = False,
+    ) -> Container:
+        """Use Dockerfile compatibility to build a container from this directory.
+        Only use this function for Dockerfile compatibility. Otherwise use the
+        native Container type directly, it is feature-complete and supports
+        all Dockerfile features.
 
         Parameters
         ----------
+        dockerfile:
+            Path to the Dockerfile to use (e.g., "frontend.Dockerfile").
+        platform:
+            The platform to build.
+        build_args:
+            Build arguments to use in the build.
         target:
-            Location of the file or directory to link to (e.g.,
-            "/existing/file").
-        link_name:
-            Location where the symbolic link will be created (e.g., "/new-
-            file-link").
+            Target build stage to build.
+        secrets:
+            Secrets to pass to the build.
+            They will be mounted at /run/secrets/[secret-name].
+        no_init:
+            If set, skip the automatic init process injected into containers
+            created by RUN statements.
+            This should only be used if the user requires that their exec
+            processes be the pid 1 process in the container. Otherwise it may
+            result in unexpected behavior.
+        """
+        _args = [
+            Arg("dockerfile", dockerfile, "Dockerfile"),
+            Arg("platform", platform, None),
+            Arg("buildArgs", [] if build_args is None else build_args, []),
+            Arg("target", target, ""),
+            Arg("secrets", [] if secrets is None else secrets, []),
+            Arg("noInit", no_init, False),
+        ]
+        _ctx = self._select("dockerBuild", _args)
+        return Container(_ctx)
+
+    async def entries(self, *, path: str | None = None) -> list[str]:
+        """Returns a list of files and directories at the given path.
+
+        Parameters
+        ----------
+        path:
+            Location of the directory to look at (e.g., "/src").
+
+        Returns
+        -------
+        list[str]
+            The `String` scalar type represents textual data, represented as
+            UTF-8 character sequences. The String type is most often used by
+            GraphQL to represent free-form human-readable text.
+
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
+        """
+        _args = [
+            Arg("path", path, None),
+        ]
+        _ctx = self._select("entries", _args)
+        return await _ctx.execute(list[str])
+
+    async def exists(
+        self,
+        path: str,
+        *,
+        expected_type: ExistsType | None = None,
+        do_not_follow_symlinks: bool | None = False,
+    ) -> bool:
+        """check if a file or directory exists
+
+        Parameters
+        ----------
+        path:
+            Path to check (e.g., "/file.txt").
+        expected_type:
+            If specified, also validate the type of file (e.g. "REGULAR_TYPE",
+            "DIRECTORY_TYPE", or "SYMLINK_TYPE").
+        do_not_follow_symlinks:
+            If specified, do not follow symlinks.
+
+        Returns
+        -------
+        bool
+            The `Boolean` scalar type represents `true` or `false`.
+
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
+        """
+        _args = [
+            Arg("path", path),
+            Arg("expectedType", expected_type, None),
+            Arg("doNotFollowSymlinks", do_not_follow_symlinks, False),
+        ]
+        _ctx = self._select("exists", _args)
+        return await _ctx.execute(bool)
+
+    async def export(
+        self,
+        path: str,
+        *,
+        wipe: bool | None = False,
+    ) -> str:
+        """Writes the contents of the directory to a path on the host.
+
+        Parameters
+        ----------
+        path:
+            Location of the copied directory (e.g., "logs/").
+        wipe:
+            If true, then the host directory will be wiped clean before
+            exporting so that it exactly matches the directory being exported;
+            this means it will delete any files on the host that aren't in the
+            exported dir. If false (the default), the contents of the
+            directory will be merged with any existing contents of the host
+            directory, leaving any existing files on the host that aren't in
+            the exported directory alone.
+
+        Returns
+        -------
+        str
+            The `String` scalar type represents textual data, represented as
+            UTF-8 character sequences. The String type is most often used by
+            GraphQL to represent free-form human-readable text.
+
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
+        """
+        _args = [
+            Arg("path", path),
+            Arg("wipe", wipe, False),
+        ]
+        _ctx = self._select("export", _args)
+        return await _ctx.execute(str)
+
+    def file(self, path: str) -> "File":
+        """Retrieve a file at the given path.
+
+        Parameters
+        ----------
+        path:
+            Location of the file to retrieve (e.g., "README.md").
+        """
+        _args = [
+            Arg("path", path),
+        ]
+        _ctx = self._select("file", _args)
+        return File(_ctx)
+
+    def filter(
+        self,
+        *,
+        exclude: list[str] | None = None,
+        include: list[str] | None = None,
+        gitignore: bool | None = False,
+    ) -> Self:
+        """Return a snapshot with some paths included or excluded
+
+        Parameters
+        ----------
+        exclude:
+            If set, paths matching one of these glob patterns is excluded from
+            the new snapshot. Example: ["node_modules/", ".git*", ".env"]
+        include:
+            If set, only paths matching one of these glob patterns is included
+            in the new snapshot. Example: (e.g., ["app/", "package.*"]).
+        gitignore:
+            If set, apply .gitignore rules when filtering the directory.
+        """
+        _args = [
+            Arg("exclude", [] if exclude is None else exclude, []),
+            Arg("include", [] if include is None else include, []),
+            Arg("gitignore", gitignore, False),
+        ]
+        _ctx = self._select("filter", _args)
+        return Directory(_ctx)
+
+    async def find_up(self, name: str, start: str) -> str | None:
+        """Search up the directory tree for a file or directory, and return its
+        path. If no match, return null
+
+        Parameters
+        ----------
+        name:
+            The name of the file or directory to search for
+        start:
+            The path to start the search from
+
+        Returns
+        -------
+        str | None
+            The `String` scalar type represents textual data, represented as
+            UTF-8 character sequences. The String type is most often used by
+            GraphQL to represent free-form human-readable text.
+
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
+        """
+        _args = [
+            Arg("name", name),
+            Arg("start", start),
+        ]
+        _ctx = self._select("findUp", _args)
+        return await _ctx.execute(str | None)
+
+    async def glob(self, pattern: str) -> list[str]:
+        """Returns a list of files and directories that matche the given pattern.
+
+        Parameters
+        ----------
+        pattern:
+            Pattern to match (e.g., "*.md").
+
+        Returns
+        -------
+        list[str]
+            The `String` scalar type represents textual data, represented as
+            UTF-8 character sequences. The String type is most often used by
+            GraphQL to represent free-form human-readable text.
+
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
+        """
+        _args = [
+            Arg("pattern", pattern),
+        ]
+        _ctx = self._select("glob", _args)
+        return await _ctx.execute(list[str])
+
+    async def id(self) -> DirectoryID:
+        """A unique identifier for this Directory.
+
+        Note
+        ----
+        This is lazily evaluated, no operation is actually run.
+
+        Returns
+        -------
+        DirectoryID
+            The `DirectoryID` scalar type represents an identifier for an
+            object of type Directory.
+
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
+        """
+        _args: list[Arg] = []
+        _ctx = self._select("id", _args)
+        return await _ctx.execute(DirectoryID)
+
+    async def name(self) -> str:
+        """Returns the name of the directory.
+
+        Returns
+        -------
+        str
+            The `String` scalar type represents textual data, represented as
+            UTF-8 character sequences. The String type is most often used by
+            GraphQL to represent free-form human-readable text.
+
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
+        """
+        _args: list[Arg] = []
+        _ctx = self._select("name", _args)
+        return await _ctx.execute(str)
+
+    def sbom(
+        self,
+        *,
+        format: SBOMFormat | None = SBOMFormat.SPDX_JSON,
+    ) -> "File":
+        """Return a software bill of materials (SBOM) of the packages in the
+        directory.
+
+        OS packages installed with apk, dpkg or rpm are detected, as well as
+        the dependencies in Go, npm, Python and Cargo lockfiles.
+
+        Parameters
+        ----------
+        format:
+            Format of the SBOM.
+        """
+        _args = [
+            Arg("format", format, SBOMFormat.SPDX_JSON),
+        ]
+        _ctx = self._select("sbom", _args)
+        return File(_ctx)
+
+    async def search(
+        self,
+        pattern: str,
+        *,
+        paths: list[str] | None = None,
+        globs: list[str] | None = None,
+        literal: bool | None = False,
+        multiline: bool | None = False,
+        dotall: bool | None = False,
+        insensitive: bool | None = False,
+        skip_ignored: bool | None = False,
+        skip_hidden: bool | None = False,
+        files_only: bool | None = False,
+        limit: int | None = None,
+    ) -> list["SearchResult"]:
+        """Searches for content matching the given regular expression or literal
+        string.
+
+        Uses Rust regex syntax; escape literal ., [, ], {, }, | with
+        backslashes.
+
+        Parameters
+        ----------
+        pattern:
+            The text to match.
+        paths:
+            Directory or file paths to search
+        globs:
+            Glob patterns to match (e.g., "*.md")
+        literal:
+            Interpret the pattern as a literal string instead of a regular
+            expression.
+        multiline:
+            Enable searching across multiple lines.
+        dotall:
+            Allow the . pattern to match newlines in multiline mode.
+        insensitive:
+            Enable case-insensitive matching.
+        skip_ignored:
+            Honor .gitignore, .ignore, and .rgignore files.
+        skip_hidden:
+            Skip hidden files (files starting with .).
+        files_only:
+            Only return matching files, not lines and content
+        limit:
+            Limit the number of results to return
+        """
+        _args = [
+            Arg("pattern", pattern),
+            Arg("paths", [] if paths is None else paths, []),
+            Arg("globs", [] if globs is None else globs, []),
+            Arg("literal", literal, False),
+            Arg("multiline", multiline, False),
+            Arg("dotall", dotall, False),
+            Arg("insensitive", insensitive, False),
+            Arg("skipIgnored", skip_ignored, False),
+            Arg("skipHidden", skip_hidden, False),
+            Arg("filesOnly", files_only, False),
+            Arg("limit", limit, None),
+        ]
+        _ctx = self._select("search", _args)
+        return await _ctx.execute_object_list(SearchResult)
+
+    def stat(
+        self,
+        path: str,
+        *,
+        do_not_follow_symlinks: bool | None = False,
+    ) -> "Stat":
+        """Return file status
+
+        Parameters
+        ----------
+        path:
+            Path to stat (e.g., "/file.txt").
+        do_not_follow_symlinks:
+            If specified, do not follow symlinks.
+        """
+        _args = [
+            Arg("path", path),
+            Arg("doNotFollowSymlinks", do_not_follow_symlinks, False),
+        ]
+        _ctx = self._select("stat", _args)
+        return Stat(_ctx)
+
+    async def sync(self) -> Self:
+        """Force evaluation in the engine.
+
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
+        """
+        _args: list[Arg] = []
+        return await self._ctx.execute_sync(self, "sync", _args)
+
+    def __await__(self):
+        return self.sync().__await__()
+
+    def terminal(
+        self,
+        *,
+        container: Container | None = None,
+        cmd: list[str] | None = None,
+        experimental_privileged_nesting: bool | None = False,
+        insecure_root_capabilities: bool | None = False,
+    ) -> Self:
+        """Opens an interactive terminal in new container with this directory
+        mounted inside.
+
+        Parameters
+        ----------
+        container:
+            If set, override the default container used for the terminal.
+        cmd:
+            If set, override the container's default terminal command and
+            invoke these command arguments instead.
+        experimental_privileged_nesting:
+            Provides Dagger access to the executed command.
+        insecure_root_capabilities:
+            Execute the command with all root capabilities. This is similar to
+            running a command with "sudo" or executing "docker run" with the "
+            --privileged" flag. Containerization does not provide any security
+            guarantees when using this option. It should only be used when
+            absolutely necessary and only with trusted commands.
+        """
+        _args = [
+            Arg("container", container, None),
+            Arg("cmd", [] if cmd is None else cmd, []),
+            Arg(
+                "experimentalPrivilegedNesting", experimental_privileged_nesting, False
+            ),
+            Arg("insecureRootCapabilities", insecure_root_capabilities, False),
+        ]
+        _ctx = self._select("terminal", _args)
+        return Directory(_ctx)
+
+    def with_changes(self, changes: Changeset) -> Self:
+        """Return a directory with changes from another directory applied to it.
+
+        Parameters
+        ----------
+        changes:
+            Changes to apply to the directory
+        """
+        _args = [
+            Arg("changes", changes),
+        ]
+        _ctx = self._select("withChanges", _args)
+        return Directory(_ctx)
+
+    def with_directory(
+        self,
+        path: str,
+        source: Self,
+        *,
+        exclude: list[str] | None = None,
+        include: list[str] | None = None,
+        gitignore: bool | None = False,
+        owner: str | None = "",
+    ) -> Self:
+        """Return a snapshot with a directory added
+
+        Parameters
+        ----------
+        path:
+            Location of the written directory (e.g., "/src/").
+        source:
+            Identifier of the directory to copy.
+        exclude:
+            Exclude artifacts that match the given pattern (e.g.,
+            ["node_modules/", ".git*"]).
+        include:
+            Include only artifacts that match the given pattern (e.g.,
+            ["app/", "package.*"]).
+        gitignore:
+            Apply .gitignore filter rules inside the directory
+        owner:
+            A user:group to set for the copied directory and its contents.
+            The user and group must be an ID (1000:1000), not a name
+            (foo:bar).
+            If the group is omitted, it defaults to the same as the user.
+        """
+        _args = [
+            Arg("path", path),
+            Arg("source", source),
+            Arg("exclude", [] if exclude is None else exclude, []),
+            Arg("include", [] if include is None else include, []),
+            Arg("gitignore", gitignore, False),
+            Arg("owner", owner, ""),
+        ]
+        _ctx = self._select("withDirectory", _args)
+        return Directory(_ctx)
+
+    def with_error(self, err: str) -> Self:
+        """Raise an error.
+
+        Parameters
+        ----------
+        err:
+            Message of the error to raise. If empty, the error will be
+            ignored.
+        """
+        _args = [
+            Arg("err", err),
+        ]
+        _ctx = self._select("withError", _args)
+        return Directory(_ctx)
+
+    def with_file(
+        self,
+        path: str,
+        source: "File",
+        *,
+        permissions: int | None = None,
+        owner: str | None = "",
+    ) -> Self:
+        """Retrieves this directory plus the contents of the given file copied to
+        the given path.
+
+        Parameters
+        ----------
+        path:
+            Location of the copied file (e.g., "/file.txt").
+        source:
+            Identifier of the file to copy.
+        permissions:
+            Permission given to the copied file (e.g., 0600).
+        owner:
+            A user:group to set for the copied directory and its contents.
+            The user and group must be an ID (1000:1000), not a name
+            (foo:bar).
+            If the group is omitted, it defaults to the same as the user.
+        """
+        _args = [
+            Arg("path", path),
+            Arg("source", source),
+            Arg("permissions", permissions, None),
+            Arg("owner", owner, ""),
+        ]
+        _ctx = self._select("withFile", _args)
+        return Directory(_ctx)
+
+    def with_files(
+        self,
+        path: str,
+        sources: list["File"],
+        *,
+        permissions: int | None = None,
+    ) -> Self:
+        """Retrieves this directory plus the contents of the given files copied
+        to the given path.
+
+        Parameters
+        ----------
+        path:
+            Location where copied files should be placed (e.g., "/src").
+        sources:
+            Identifiers of the files to copy.
+        permissions:
+            Permission given to the copied files (e.g., 0600).
+        """
+        _args = [
+            Arg("path", path),
+            Arg("sources", sources),
+            Arg("permissions", permissions, None),
+        ]
+        _ctx = self._select("withFiles", _args)
+        return Directory(_ctx)
+
+    def with_new_directory(
+        self,
+        path: str,
+        *,
+        permissions: int | None = 420,
+    ) -> Self:
+        """Retrieves this directory plus a new directory created at the given
+        path.
+
+        Parameters
+        ----------
+        path:
+            Location of the directory created (e.g., "/logs").
+        permissions:
+            Permission granted to the created directory (e.g., 0777).
+        """
+        _args = [
+            Arg("path", path),
+            Arg("permissions", permissions, 420),
+        ]
+        _ctx = self._select("withNewDirectory", _args)
+        return Directory(_ctx)
+
+    def with_new_file(
+        self,
+        path: str,
+        contents: str,
+        *,
+        permissions: int | None = 420,
+    ) -> Self:
+        """Return a snapshot with a new file added
+
+        Parameters
+        ----------
+        path:
+            Path of the new file. Example: "foo/bar.txt"
+        contents:
+            Contents of the new file. Example: "Hello world!"
+        permissions:
+            Permissions of the new file. Example: 0600
+        """
+        _args = [
+            Arg("path", path),
+            Arg("contents", contents),
+            Arg("permissions", permissions, 420),
+        ]
+        _ctx = self._select("withNewFile", _args)
+        return Directory(_ctx)
+
+    def with_patch(self, patch: str) -> Self:
+        """Retrieves this directory with the given Git-compatible patch applied.
+
+        .. caution::
+            Experimental: This API is highly experimental and may be removed
+            or replaced entirely.
+
+        Parameters
+        ----------
+        patch:
+            Patch to apply (e.g., "diff --git a/file.txt b/file.txt\nindex
+            1234567..abcdef8 100644\n--- a/file.txt\n+++ b/file.txt\n@@ -1,1
+            +1,1 @@\n-Hello\n+World\n").
+        """
+        _args = [
+            Arg("patch", patch),
+        ]
+        _ctx = self._select("withPatch", _args)
+        return Directory(_ctx)
+
+    def with_patch_file(self, patch: "File") -> Self:
+        """Retrieves this directory with the given Git-compatible patch file
+        applied.
+
+        .. caution::
+            Experimental: This API is highly experimental and may be removed
+            or replaced entirely.
+
+        Parameters
+        ----------
+        patch:
+            File containing the patch to apply
+        """
+        _args = [
+            Arg("patch", patch),
+        ]
+        _ctx = self._select("withPatchFile", _args)
+        return Directory(_ctx)
+
+    def with_symlink(self, target: str, link_name: str) -> Self:
+        """Return a snapshot with a symlink
+
+        Parameters
+        ----------
+        target:
+            Location of the file or directory to link to (e.g.,
+            "/existing/file").
+        link_name:
+            Location where the symbolic link will be created (e.g., "/new-
+            file-link").
+        """
+        _args = [
+            Arg("target", target),
+            Arg("linkName", link_name),
+        ]
+        _ctx = self._select("withSymlink", _args)
+        return Directory(_ctx)
+
+    def with_timestamps(self, timestamp: int) -> Self:
+        """Retrieves this directory with all file/dir timestamps set to the given
+        time.
+
+        Parameters
+        ----------
+        timestamp:
+            Timestamp to set dir/files in.
+            Formatted in seconds following Unix epoch (e.g., 1672531199).
+        """
+        _args = [
+            Arg("timestamp", timestamp),
+        ]
+        _ctx = self._select("withTimestamps", _args)
+        return Directory(_ctx)
+
+    def without_directory(self, path: str) -> Self:
+        """Return a snapshot with a subdirectory removed
+
+        Parameters
+        ----------
+        path:
+            Path of the subdirectory to remove. Example: ".github/workflows"
+        """
+        _args = [
+            Arg("path", path),
+        ]
+        _ctx = self._select("withoutDirectory", _args)
+        return Directory(_ctx)
+
+    def without_file(self, path: str) -> Self:
+        """Return a snapshot with a file removed
+
+        Parameters
+        ----------
+        path:
+            Path of the file to remove (e.g., "/file.txt").
+        """
+        _args = [
+            Arg("path", path),
+        ]
+        _ctx = self._select("withoutFile", _args)
+        return Directory(_ctx)
+
+    def without_files(self, paths: list[str]) -> Self:
+        """Return a snapshot with files removed
+
+        Parameters
+        ----------
+        paths:
+            Paths of the files to remove (e.g., ["/file.txt"]).
+        """
+        _args = [
+            Arg("paths", paths),
+        ]
+        _ctx = self._select("withoutFiles", _args)
+        return Directory(_ctx)
+
+    def with_(self, cb: Callable[["Directory"], "Directory"]) -> "Directory":
+        """Call the provided callable with current Directory.
+
+        This is useful for reusability and readability by not breaking the calling chain.
+        """
+        return cb(self)
+
+
+@typecheck
+class Engine(Type):
+    """The Dagger engine configuration and state"""
+
+    async def clients(self) -> list[str]:
+        """The list of connected client IDs
+
+        Returns
+        -------
+        list[str]
+            The `String` scalar type represents textual data, represented as
+            UTF-8 character sequences. The String type is most often used by
+            GraphQL to represent free-form human-readable text.
+
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
+        """
+        _args: list[Arg] = []
+        _ctx = self._select("clients", _args)
+        return await _ctx.execute(list[str])
+
+    async def id(self) -> EngineID:
+        """A unique identifier for this Engine.
+
+        Note
+        ----
+        This is lazily evaluated, no operation is actually run.
+
+        Returns
+        -------
+        EngineID
+            The `EngineID` scalar type represents an identifier for an object
+            of type Engine.
+
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
+        """
+        _args: list[Arg] = []
+        _ctx = self._select("id", _args)
+        return await _ctx.execute(EngineID)
+
+    def local_cache(self) -> "EngineCache":
+        """The local (on-disk) cache for the Dagger engine"""
+        _args: list[Arg] = []
+        _ctx = self._select("localCache", _args)
+        return EngineCache(_ctx)
+
+    async def name(self) -> str:
+        """The name of the engine instance.
+
+        Returns
+        -------
+        str
+            The `String` scalar type represents textual data, represented as
+            UTF-8 character sequences. The String type is most often used by
+            GraphQL to represent free-form human-readable text.
+
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
+        """
+        _args: list[Arg] = []
+        _ctx = self._select("name", _args)
+        return await _ctx.execute(str)
+
+    async def services(self) -> list["EngineService"]:
+        """The list of persistent services running in the engine, shared across
+        sessions
+        """
+        _args: list[Arg] = []
+        _ctx = self._select("services", _args)
+        return await _ctx.execute_object_list(EngineService)
+
+    async def sessions(self) -> list["EngineSession"]:
+        """The list of active sessions, across all clients connected to the
+        engine
+        """
+        _args: list[Arg] = []
+        _ctx = self._select("sessions", _args)
+        return await _ctx.execute_object_list(EngineSession)
+
+
+@typecheck
+class EngineCache(Type):
+    """A cache storage for the Dagger engine"""
+
+    def entry_set(self, *, key: str | None = "") -> "EngineCacheEntrySet":
+        """The current set of entries in the cache"""
+        _args = [
+            Arg("key", key, ""),
+        ]
+        _ctx = self._select("entrySet", _args)
+        return EngineCacheEntrySet(_ctx)
+
+    async def id(self) -> EngineCacheID:
+        """A unique identifier for this EngineCache.
+
+        Note
+        ----
+        This is lazily evaluated, no operation is actually run.
+
+        Returns
+        -------
+        EngineCacheID
+            The `EngineCacheID` scalar type represents an identifier for an
+            object of type EngineCache.
+
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
+        """
+        _args: list[Arg] = []
+        _ctx = self._select("id", _args)
+        return await _ctx.execute(EngineCacheID)
+
+    async def max_used_space(self) -> int:
+        """The maximum bytes to keep in the cache without pruning.
+
+        Returns
+        -------
+        int
+            The `Int` scalar type represents non-fractional signed whole
+            numeric values. Int can represent values between -(2^31) and 2^31
+            - 1.
+
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
+        """
+        _args: list[Arg] = []
+        _ctx = self._select("maxUsedSpace", _args)
+        return await _ctx.execute(int)
+
+    async def min_free_space(self) -> int:
+        """The target amount of free disk space the garbage collector will
+        attempt to leave.
+
+        Returns
+        -------
+        int
+            The `Int` scalar type represents non-fractional signed whole
+            numeric values. Int can represent values between -(2^31) and 2^31
+            - 1.
+
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
+        """
+        _args: list[Arg] = []
+        _ctx = self._select("minFreeSpace", _args)
+        return await _ctx.execute(int)
+
+    async def pin(self, record_id: str) -> Void | None:
+        """Pin a cache entry, so that it's never pruned
+
+        Parameters
+        ----------
+        record_id:
+            The record ID of the cache entry to pin.
+
+        Returns
+        -------
+        Void | None
+            The absence of a value.  A Null Void is used as a placeholder for
+            resolvers that do not return anything.
+
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
+        """
+        _args = [
+            Arg("recordId", record_id),
+        ]
+        _ctx = self._select("pin", _args)
+        await _ctx.execute()
+
+    async def prune(
+        self,
+        *,
+        use_default_policy: bool | None = False,
+    ) -> Void | None:
+        """Prune the cache of releaseable entries
+
+        Parameters
+        ----------
+        use_default_policy:
+            Use the engine-wide default pruning policy if true, otherwise
+            prune the whole cache of any releasable entries.
+
+        Returns
+        -------
+        Void | None
+            The absence of a value.  A Null Void is used as a placeholder for
+            resolvers that do not return anything.
+
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
+        """
+        _args = [
+            Arg("useDefaultPolicy", use_default_policy, False),
+        ]
+        _ctx = self._select("prune", _args)
+        await _ctx.execute()
+
+    async def reserved_space(self) -> int:
+        """The minimum amount of disk space this policy is guaranteed to retain.
+
+        Returns
+        -------
+        int
+            The `Int` scalar type represents non-fractional signed whole
+            numeric values. Int can represent values between -(2^31) and 2^31
+            - 1.
+
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
+        """
+        _args: list[Arg] = []
+        _ctx = self._select("reservedSpace", _args)
+        return await _ctx.execute(int)
+
+    async def target_space(self) -> int:
+        """The target number of bytes to keep when pruning.
+
+        Returns
+        -------
+        int
+            The `Int` scalar type represents non-fractional signed whole
+            numeric values. Int can represent values between -(2^31) and 2^31
+            - 1.
+
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
+        """
+        _args: list[Arg] = []
+        _ctx = self._select("targetSpace", _args)
+        return await _ctx.execute(int)
+
+    async def unpin(self, record_id: str) -> Void | None:
+        """Unpin a cache entry, so that it can be pruned again
+
+        The entries of a pinned cache volume are pinned again when the volume
+        is used; unpin the volume itself with CacheVolume.unpin instead.
+
+        Parameters
+        ----------
+        record_id:
+            The record ID of the cache entry to unpin.
+
+        Returns
+        -------
+        Void | None
+            The absence of a value.  A Null Void is used as a placeholder for
+            resolvers that do not return anything.
+
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
+        """
+        _args = [
+            Arg("recordId", record_id),
+        ]
+        _ctx = self._select("unpin", _args)
+        await _ctx.execute()
+
+
+@typecheck
+class EngineCacheEntry(Type):
+    """An individual cache entry in a cache entry set"""
+
+    async def actively_used(self) -> bool:
+        """Whether the cache entry is actively being used.
+
+        Returns
+        -------
+        bool
+            The `Boolean` scalar type represents `true` or `false`.
+
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
+        """
+        _args: list[Arg] = []
+        _ctx = self._select("activelyUsed", _args)
+        return await _ctx.execute(bool)
+
+    async def created_time_unix_nano(self) -> int:
+        """The time the cache entry was created, in Unix nanoseconds.
+
+        Returns
+        -------
+        int
+            The `Int` scalar type represents non-fractional signed whole
+            numeric values. Int can represent values between -(2^31) and 2^31
+            - 1.
+
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
+        """
+        _args: list[Arg] = []
+        _ctx = self._select("createdTimeUnixNano", _args)
+        return await _ctx.execute(int)
+
+    async def description(self) -> str:
+        """The description of the cache entry.
+
+        Returns
+        -------
+        str
+            The `String` scalar type represents textual data, represented as
+            UTF-8 character sequences. The String type is most often used by
+            GraphQL to represent free-form human-readable text.
+
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
+        """
+        _args: list[Arg] = []
+        _ctx = self._select("description", _args)
+        return await _ctx.execute(str)
+
+    async def disk_space_bytes(self) -> int:
+        """The disk space used by the cache entry.
+
+        Returns
+        -------
+        int
+            The `Int` scalar type represents non-fractional signed whole
+            numeric values. Int can represent values between -(2^31) and 2^31
+            - 1.
+
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
+        """
+        _args: list[Arg] = []
+        _ctx = self._select("diskSpaceBytes", _args)
+        return await _ctx.execute(int)
+
+    async def id(self) -> EngineCacheEntryID:
+        """A unique identifier for this EngineCacheEntry.
+
+        Note
+        ----
+        This is lazily evaluated, no operation is actually run.
+
+        Returns
+        -------
+        EngineCacheEntryID
+            The `EngineCacheEntryID` scalar type represents an identifier for
+            an object of type EngineCacheEntry.
+
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
         """
-        _args = [
-            Arg("target", target),
-            Arg("linkName", link_name),
-        ]
-        _ctx = self._select("withSymlink", _args)
-        return Directory(_ctx)
+        _args: list[Arg] = []
+        _ctx = self._select("id", _args)
+        return await _ctx.execute(EngineCacheEntryID)
 
-    def with_timestamps(self, timestamp: int) -> Self:
-        """Retrieves this directory with all file/dir timestamps set to the given
-        time.
+    async def most_recent_use_time_unix_nano(self) -> int:
+        """The most recent time the cache entry was used, in Unix nanoseconds.
 
-        Parameters
-        ----------
-        timestamp:
-            Timestamp to set dir/files in.
-            Formatted in seconds following Unix epoch (e.g., 1672531199).
+        Returns
+        -------
+        int
+            The `Int` scalar type represents non-fractional signed whole
+            numeric values. Int can represent values between -(2^31) and 2^31
+            - 1.
+
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
         """
-        _args = [
-            Arg("timestamp", timestamp),
-        ]
-        _ctx = self._select("withTimestamps", _args)
-        return Directory(_ctx)
+        _args: list[Arg] = []
+        _ctx = self._select("mostRecentUseTimeUnixNano", _args)
+        return await _ctx.execute(int)
 
-    def without_directory(self, path: str) -> Self:
-        """Return a snapshot with a subdirectory removed
+    async def pinned(self) -> bool:
+        """Whether the cache entry is pinned, exempting it from pruning.
 
-        Parameters
-        ----------
-        path:
-            Path of the subdirectory to remove. Example: ".github/workflows"
+        Returns
+        -------
+        bool
+            The `Boolean` scalar type represents `true` or `false`.
+
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
         """
-        _args = [
-            Arg("path", path),
-        ]
-        _ctx = self._select("withoutDirectory", _args)
-        return Directory(_ctx)
+        _args: list[Arg] = []
+        _ctx = self._select("pinned", _args)
+        return await _ctx.execute(bool)
 
-    def without_file(self, path: str) -> Self:
-        """Return a snapshot with a file removed
+    async def record_id(self) -> str:
+        """The ID of the underlying cache record, which stays the same across
+        sessions and engine restarts.
 
-        Parameters
-        ----------
-        path:
-            Path of the file to remove (e.g., "/file.txt").
+        Returns
+        -------
+        str
+            The `String` scalar type represents textual data, represented as
+            UTF-8 character sequences. The String type is most often used by
+            GraphQL to represent free-form human-readable text.
+
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
         """
-        _args = [
-            Arg("path", path),
-        ]
-        _ctx = self._select("withoutFile", _args)
-        return Directory(_ctx)
+        _args: list[Arg] = []
+        _ctx = self._select("recordId", _args)
+        return await _ctx.execute(str)
 
-    def without_files(self, paths: list[str]) -> Self:
-        """Return a snapshot with files removed
 
-        Parameters
-        ----------
-        paths:
-            Paths of the files to remove (e.g., ["/file.txt"]).
-        """
-        _args = [
-            Arg("paths", paths),
-        ]
-        _ctx = self._select("withoutFiles", _args)
-        return Directory(_ctx)
+@typecheck
+class EngineCacheEntrySet(Type):
+    """A set of cache entries returned by a query to a cache"""
 
-    def with_(self, cb: Callable[["Directory"], "Directory"]) -> "Directory":
-        """Call the provided callable with current Directory.
+    async def disk_space_bytes(self) -> int:
+        """The total disk space used by the cache entries in this set.
 
-        This is useful for reusability and readability by not breaking the calling chain.
-        """
-        return cb(self)
+        Returns
+        -------
+        int
+            The `Int` scalar type represents non-fractional signed whole
+            numeric values. Int can represent values between -(2^31) and 2^31
+            - 1.
 
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
+        """
+        _args: list[Arg] = []
+        _ctx = self._select("diskSpaceBytes", _args)
+        return await _ctx.execute(int)
 
-@typecheck
-class Engine(Type):
-    """The Dagger engine configuration and state"""
+    async def entries(self) -> list[EngineCacheEntry]:
+        """The list of individual cache entries in the set"""
+        _args: list[Arg] = []
+        _ctx = self._select("entries", _args)
+        return await _ctx.execute_object_list(EngineCacheEntry)
 
-    async def clients(self) -> list[str]:
-        """The list of connected client IDs
+    async def entry_count(self) -> int:
+        """The number of cache entries in this set.
 
         Returns
         -------
-        list[str]
-            The `String` scalar type represents textual data, represented as
-            UTF-8 character sequences. The String type is most often used by
-            GraphQL to represent free-form human-readable text.
+        int
+            The `Int` scalar type represents non-fractional signed whole
+            numeric values. Int can represent values between -(2^31) and 2^31
+            - 1.
 
         Raises
         ------
@@ -4505,11 +5807,11 @@ class Engine(Type):
             If the API returns an error.
         """
         _args: list[Arg] = []
-        _ctx = self._select("clients", _args)
-        return await _ctx.execute(list[str])
+        _ctx = self._select("entryCount", _args)
+        return await _ctx.execute(int)
 
-    async def id(self) -> EngineID:
-        """A unique identifier for this Engine.
+    async def id(self) -> EngineCacheEntrySetID:
+        """A unique identifier for this EngineCacheEntrySet.
 
         Note
         ----
@@ -4517,9 +5819,9 @@ class Engine(Type):
 
         Returns
         -------
-        EngineID
-            The `EngineID` scalar type represents an identifier for an object
-            of type Engine.
+        EngineCacheEntrySetID
+            The `EngineCacheEntrySetID` scalar type represents an identifier
+            for an object of type EngineCacheEntrySet.
 
         Raises
         ------
@@ -4530,16 +5832,37 @@ class Engine(Type):
         """
         _args: list[Arg] = []
         _ctx = self._select("id", _args)
-        return await _ctx.execute(EngineID)
+        return await _ctx.execute(EngineCacheEntrySetID)
 
-    def local_cache(self) -> "EngineCache":
-        """The local (on-disk) cache for the Dagger engine"""
+
+@typecheck
+class EngineService(Type):
+    """A persistent service running in the Dagger engine, shared across
+    sessions"""
+
+    async def bindings(self) -> int:
+        """The number of bindings currently using the service, across sessions.
+
+        Returns
+        -------
+        int
+            The `Int` scalar type represents non-fractional signed whole
+            numeric values. Int can represent values between -(2^31) and 2^31
+            - 1.
+
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
+        """
         _args: list[Arg] = []
-        _ctx = self._select("localCache", _args)
-        return EngineCache(_ctx)
+        _ctx = self._select("bindings", _args)
+        return await _ctx.execute(int)
 
-    async def name(self) -> str:
-        """The name of the engine instance.
+    async def hostname(self) -> str:
+        """The hostname to reach the service from any session.
 
         Returns
         -------
@@ -4556,24 +5879,11 @@ class Engine(Type):
             If the API returns an error.
         """
         _args: list[Arg] = []
-        _ctx = self._select("name", _args)
+        _ctx = self._select("hostname", _args)
         return await _ctx.execute(str)
 
-
-@typecheck
-class EngineCache(Type):
-    """A cache storage for the Dagger engine"""
-
-    def entry_set(self, *, key: str | None = "") -> "EngineCacheEntrySet":
-        """The current set of entries in the cache"""
-        _args = [
-            Arg("key", key, ""),
-        ]
-        _ctx = self._select("entrySet", _args)
-        return EngineCacheEntrySet(_ctx)
-
-    async def id(self) -> EngineCacheID:
-        """A unique identifier for this EngineCache.
+    async def id(self) -> EngineServiceID:
+        """A unique identifier for this EngineService.
 
         Note
         ----
@@ -4581,9 +5891,9 @@ class EngineCache(Type):
 
         Returns
         -------
-        EngineCacheID
-            The `EngineCacheID` scalar type represents an identifier for an
-            object of type EngineCache.
+        EngineServiceID
+            The `EngineServiceID` scalar type represents an identifier for an
+            object of type EngineService.
 
         Raises
         ------
@@ -4594,10 +5904,11 @@ class EngineCache(Type):
         """
         _args: list[Arg] = []
         _ctx = self._select("id", _args)
-        return await _ctx.execute(EngineCacheID)
+        return await _ctx.execute(EngineServiceID)
 
-    async def max_used_space(self) -> int:
-        """The maximum bytes to keep in the cache without pruning.
+    async def idle_since_unix_nano(self) -> int:
+        """The time the service became unused, in Unix nanoseconds, or 0 if it's
+        in use.
 
         Returns
         -------
@@ -4614,19 +5925,19 @@ class EngineCache(Type):
             If the API returns an error.
         """
         _args: list[Arg] = []
-        _ctx = self._select("maxUsedSpace", _args)
+        _ctx = self._select("idleSinceUnixNano", _args)
         return await _ctx.execute(int)
 
-    async def min_free_space(self) -> int:
-        """The target amount of free disk space the garbage collector will
-        attempt to leave.
+    async def idle_timeout(self) -> str:
+        """How long the service keeps running while unused (e.g., "30m"), or
+        empty if it runs until stopped.
 
         Returns
         -------
-        int
-            The `Int` scalar type represents non-fractional signed whole
-            numeric values. Int can represent values between -(2^31) and 2^31
-            - 1.
+        str
+            The `String` scalar type represents textual data, represented as
+            UTF-8 character sequences. The String type is most often used by
+            GraphQL to represent free-form human-readable text.
 
         Raises
         ------
@@ -4636,27 +5947,18 @@ class EngineCache(Type):
             If the API returns an error.
         """
         _args: list[Arg] = []
-        _ctx = self._select("minFreeSpace", _args)
-        return await _ctx.execute(int)
-
-    async def prune(
-        self,
-        *,
-        use_default_policy: bool | None = False,
-    ) -> Void | None:
-        """Prune the cache of releaseable entries
+        _ctx = self._select("idleTimeout", _args)
+        return await _ctx.execute(str)
 
-        Parameters
-        ----------
-        use_default_policy:
-            Use the engine-wide default pruning policy if true, otherwise
-            prune the whole cache of any releasable entries.
+    async def name(self) -> str:
+        """The name the service was started with.
 
         Returns
         -------
-        Void | None
-            The absence of a value.  A Null Void is used as a placeholder for
-            resolvers that do not return anything.
+        str
+            The `String` scalar type represents textual data, represented as
+            UTF-8 character sequences. The String type is most often used by
+            GraphQL to represent free-form human-readable text.
 
         Raises
         ------
@@ -4665,18 +5967,16 @@ class EngineCache(Type):
         QueryError
             If the API returns an error.
         """
-        _args = [
-            Arg("useDefaultPolicy", use_default_policy, False),
-        ]
-        _ctx = self._select("prune", _args)
-        await _ctx.execute()
+        _args: list[Arg] = []
+        _ctx = self._select("name", _args)
+        return await _ctx.execute(str)
 
-    async def reserved_space(self) -> int:
-        """The minimum amount of disk space this policy is guaranteed to retain.
+    async def ports(self) -> list[int]:
+        """The ports exposed by the service.
 
         Returns
         -------
-        int
+        list[int]
             The `Int` scalar type represents non-fractional signed whole
             numeric values. Int can represent values between -(2^31) and 2^31
             - 1.
@@ -4689,11 +5989,11 @@ class EngineCache(Type):
             If the API returns an error.
         """
         _args: list[Arg] = []
-        _ctx = self._select("reservedSpace", _args)
-        return await _ctx.execute(int)
+        _ctx = self._select("ports", _args)
+        return await _ctx.execute(list[int])
 
-    async def target_space(self) -> int:
-        """The target number of bytes to keep when pruning.
+    async def started_unix_nano(self) -> int:
+        """The time the service started, in Unix nanoseconds.
 
         Returns
         -------
@@ -4710,21 +6010,23 @@ class EngineCache(Type):
             If the API returns an error.
         """
         _args: list[Arg] = []
-        _ctx = self._select("targetSpace", _args)
+        _ctx = self._select("startedUnixNano", _args)
         return await _ctx.execute(int)
 
 
 @typecheck
-class EngineCacheEntry(Type):
-    """An individual cache entry in a cache entry set"""
+class EngineSession(Type):
+    """A session of a client connected to the Dagger engine"""
 
-    async def actively_used(self) -> bool:
-        """Whether the cache entry is actively being used.
+    async def cache_hits(self) -> int:
+        """The number of cacheable calls in the session that were cached.
 
         Returns
         -------
-        bool
-            The `Boolean` scalar type represents `true` or `false`.
+        int
+            The `Int` scalar type represents non-fractional signed whole
+            numeric values. Int can represent values between -(2^31) and 2^31
+            - 1.
 
         Raises
         ------
@@ -4734,11 +6036,11 @@ class EngineCacheEntry(Type):
             If the API returns an error.
         """
         _args: list[Arg] = []
-        _ctx = self._select("activelyUsed", _args)
-        return await _ctx.execute(bool)
+        _ctx = self._select("cacheHits", _args)
+        return await _ctx.execute(int)
 
-    async def created_time_unix_nano(self) -> int:
-        """The time the cache entry was created, in Unix nanoseconds.
+    async def cache_misses(self) -> int:
+        """The number of cacheable calls in the session that were not cached.
 
         Returns
         -------
@@ -4755,11 +6057,11 @@ class EngineCacheEntry(Type):
             If the API returns an error.
         """
         _args: list[Arg] = []
-        _ctx = self._select("createdTimeUnixNano", _args)
+        _ctx = self._select("cacheMisses", _args)
         return await _ctx.execute(int)
 
-    async def description(self) -> str:
-        """The description of the cache entry.
+    async def client_hostname(self) -> str:
+        """The hostname of the main client.
 
         Returns
         -------
@@ -4776,18 +6078,18 @@ class EngineCacheEntry(Type):
             If the API returns an error.
         """
         _args: list[Arg] = []
-        _ctx = self._select("description", _args)
+        _ctx = self._select("clientHostname", _args)
         return await _ctx.execute(str)
 
-    async def disk_space_bytes(self) -> int:
-        """The disk space used by the cache entry.
+    async def client_id(self) -> str:
+        """The ID of the main client that started the session.
 
         Returns
         -------
-        int
-            The `Int` scalar type represents non-fractional signed whole
-            numeric values. Int can represent values between -(2^31) and 2^31
-            - 1.
+        str
+            The `String` scalar type represents textual data, represented as
+            UTF-8 character sequences. The String type is most often used by
+            GraphQL to represent free-form human-readable text.
 
         Raises
         ------
@@ -4797,21 +6099,18 @@ class EngineCacheEntry(Type):
             If the API returns an error.
         """
         _args: list[Arg] = []
-        _ctx = self._select("diskSpaceBytes", _args)
-        return await _ctx.execute(int)
-
-    async def id(self) -> EngineCacheEntryID:
-        """A unique identifier for this EngineCacheEntry.
+        _ctx = self._select("clientId", _args)
+        return await _ctx.execute(str)
 
-        Note
-        ----
-        This is lazily evaluated, no operation is actually run.
+    async def client_version(self) -> str:
+        """The version of the main client.
 
         Returns
         -------
-        EngineCacheEntryID
-            The `EngineCacheEntryID` scalar type represents an identifier for
-            an object of type EngineCacheEntry.
+        str
+            The `String` scalar type represents textual data, represented as
+            UTF-8 character sequences. The String type is most often used by
+            GraphQL to represent free-form human-readable text.
 
         Raises
         ------
@@ -4821,18 +6120,18 @@ class EngineCacheEntry(Type):
             If the API returns an error.
         """
         _args: list[Arg] = []
-        _ctx = self._select("id", _args)
-        return await _ctx.execute(EngineCacheEntryID)
+        _ctx = self._select("clientVersion", _args)
+        return await _ctx.execute(str)
 
-    async def most_recent_use_time_unix_nano(self) -> int:
-        """The most recent time the cache entry was used, in Unix nanoseconds.
+    async def command(self) -> str:
+        """The command run by the main client, if known.
 
         Returns
         -------
-        int
-            The `Int` scalar type represents non-fractional signed whole
-            numeric values. Int can represent values between -(2^31) and 2^31
-            - 1.
+        str
+            The `String` scalar type represents textual data, represented as
+            UTF-8 character sequences. The String type is most often used by
+            GraphQL to represent free-form human-readable text.
 
         Raises
         ------
@@ -4842,23 +6141,22 @@ class EngineCacheEntry(Type):
             If the API returns an error.
         """
         _args: list[Arg] = []
-        _ctx = self._select("mostRecentUseTimeUnixNano", _args)
-        return await _ctx.execute(int)
-
+        _ctx = self._select("command", _args)
+        return await _ctx.execute(str)
 
-@typecheck
-class EngineCacheEntrySet(Type):
-    """A set of cache entries returned by a query to a cache"""
+    async def id(self) -> str:
+        """The ID of the session.
 
-    async def disk_space_bytes(self) -> int:
-        """The total disk space used by the cache entries in this set.
+        Note
+        ----
+        This is lazily evaluated, no operation is actually run.
 
         Returns
         -------
-        int
-            The `Int` scalar type represents non-fractional signed whole
-            numeric values. Int can represent values between -(2^31) and 2^31
-            - 1.
+        str
+            The `String` scalar type represents textual data, represented as
+            UTF-8 character sequences. The String type is most often used by
+            GraphQL to represent free-form human-readable text.
 
         Raises
         ------
@@ -4868,17 +6166,32 @@ class EngineCacheEntrySet(Type):
             If the API returns an error.
         """
         _args: list[Arg] = []
-        _ctx = self._select("diskSpaceBytes", _args)
-        return await _ctx.execute(int)
+        _ctx = self._select("id", _args)
+        return await _ctx.execute(str)
 
-    async def entries(self) -> list[EngineCacheEntry]:
-        """The list of individual cache entries in the set"""
+    async def modules(self) -> list[str]:
+        """The names of the modules with clients connected to the session.
+
+        Returns
+        -------
+        list[str]
+            The `String` scalar type represents textual data, represented as
+            UTF-8 character sequences. The String type is most often used by
+            GraphQL to represent free-form human-readable text.
+
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
+        """
         _args: list[Arg] = []
-        _ctx = self._select("entries", _args)
-        return await _ctx.execute_object_list(EngineCacheEntry)
+        _ctx = self._select("modules", _args)
+        return await _ctx.execute(list[str])
 
-    async def entry_count(self) -> int:
-        """The number of cache entries in this set.
+    async def running_execs(self) -> int:
+        """The number of containers currently running in the session.
 
         Returns
         -------
@@ -4895,21 +6208,18 @@ class EngineCacheEntrySet(Type):
             If the API returns an error.
         """
         _args: list[Arg] = []
-        _ctx = self._select("entryCount", _args)
+        _ctx = self._select("runningExecs", _args)
         return await _ctx.execute(int)
 
-    async def id(self) -> EngineCacheEntrySetID:
-        """A unique identifier for this EngineCacheEntrySet.
-
-        Note
-        ----
-        This is lazily evaluated, no operation is actually run.
+    async def started_unix_nano(self) -> int:
+        """The time the session started, in Unix nanoseconds.
 
         Returns
         -------
-        EngineCacheEntrySetID
-            The `EngineCacheEntrySetID` scalar type represents an identifier
-            for an object of type EngineCacheEntrySet.
+        int
+            The `Int` scalar type represents non-fractional signed whole
+            numeric values. Int can represent values between -(2^31) and 2^31
+            - 1.
 
         Raises
         ------
@@ -4919,8 +6229,8 @@ class EngineCacheEntrySet(Type):
             If the API returns an error.
         """
         _args: list[Arg] = []
-        _ctx = self._select("id", _args)
-        return await _ctx.execute(EngineCacheEntrySetID)
+        _ctx = self._select("startedUnixNano", _args)
+        return await _ctx.execute(int)
 
 
 @typecheck
@@ -5227,7 +6537,133 @@ class Env(Type):
         name:
             The name of the binding
         value:
-            The Address value to assign to the binding
+            The Address value to assign to the binding
+        description:
+            The purpose of the input
+        """
+        _args = [
+            Arg("name", name),
+            Arg("value", value),
+            Arg("description", description),
+        ]
+        _ctx = self._select("withAddressInput", _args)
+        return Env(_ctx)
+
+    def with_address_output(self, name: str, description: str) -> Self:
+        """Declare a desired Address output to be assigned in the environment
+
+        Parameters
+        ----------
+        name:
+            The name of the binding
+        description:
+            A description of the desired value of the binding
+        """
+        _args = [
+            Arg("name", name),
+            Arg("description", description),
+        ]
+        _ctx = self._select("withAddressOutput", _args)
+        return Env(_ctx)
+
+    def with_cache_volume_input(
+        self,
+        name: str,
+        value: CacheVolume,
+        description: str,
+    ) -> Self:
+        """Create or update a binding of type CacheVolume in the environment
+
+        Parameters
+        ----------
+        name:
+            The name of the binding
+        value:
+            The CacheVolume value to assign to the binding
+        description:
+            The purpose of the input
+        """
+        _args = [
+            Arg("name", name),
+            Arg("value", value),
+            Arg("description", description),
+        ]
+        _ctx = self._select("withCacheVolumeInput", _args)
+        return Env(_ctx)
+
+    def with_cache_volume_output(self, name: str, description: str) -> Self:
+        """Declare a desired CacheVolume output to be assigned in the environment
+
+        Parameters
+        ----------
+        name:
+            The name of the binding
+        description:
+            A description of the desired value of the binding
+        """
+        _args = [
+            Arg("name", name),
+            Arg("description", description),
+        ]
+        _ctx = self._select("withCacheVolumeOutput", _args)
+        return Env(_ctx)
+
+    def with_changeset_input(
+        self,
+        name: str,
+        value: Changeset,
+        description: str,
+    ) -> Self:
+        """Create or update a binding of type Changeset in the environment
+
+        Parameters
+        ----------
+        name:
+            The name of the binding
+        value:
+            The Changeset value to assign to the binding
+        description:
+            The purpose of the input
+        """
+        _args = [
+            Arg("name", name),
+            Arg("value", value),
+            Arg("description", description),
+        ]
+        _ctx = self._select("withChangesetInput", _args)
+        return Env(_ctx)
+
+    def with_changeset_output(self, name: str, description: str) -> Self:
+        """Declare a desired Changeset output to be assigned in the environment
+
+        Parameters
+        ----------
+        name:
+            The name of the binding
+        description:
+            A description of the desired value of the binding
+        """
+        _args = [
+            Arg("name", name),
+            Arg("description", description),
+        ]
+        _ctx = self._select("withChangesetOutput", _args)
+        return Env(_ctx)
+
+    def with_check_group_input(
+        self,
+        name: str,
+        value: CheckGroup,
+        description: str,
+    ) -> Self:
+        """Create or update a binding of type CheckGroup in the environment
+
+        Parameters
+        ----------
+        name:
+            The name of the binding
+        value:
+            The CheckGroup value to assign to the binding
         description:
             The purpose of the input
         """
@@ -5236,11 +6672,11 @@ class Env(Type):
             Arg("value", value),
             Arg("description", description),
         ]
-        _ctx = self._select("withAddressInput", _args)
+        _ctx = self._select("withCheckGroupInput", _args)
         return Env(_ctx)
 
-    def with_address_output(self, name: str, description: str) -> Self:
-        """Declare a desired Address output to be assigned in the environment
+    def with_check_group_output(self, name: str, description: str) -> Self:
+        """Declare a desired CheckGroup output to be assigned in the environment
 
         Parameters
         ----------
@@ -5253,23 +6689,23 @@ class Env(Type):
             Arg("name", name),
             Arg("description", description),
         ]
-        _ctx = self._select("withAddressOutput", _args)
+        _ctx = self._select("withCheckGroupOutput", _args)
         return Env(_ctx)
 
-    def with_cache_volume_input(
+    def with_check_input(
         self,
         name: str,
-        value: CacheVolume,
+        value: Check,
         description: str,
     ) -> Self:
-        """Create or update a binding of type CacheVolume in the environment
+        """Create or update a binding of type Check in the environment
 
         Parameters
         ----------
         name:
             The name of the binding
         value:
-            The CacheVolume value to assign to the binding
+            The Check value to assign to the binding
         description:
             The purpose of the input
         """
@@ -5278,11 +6714,11 @@ class Env(Type):
             Arg("value", value),
             Arg("description", description),
         ]
-        _ctx = self._select("withCacheVolumeInput", _args)
+        _ctx = self._select("withCheckInput", _args)
         return Env(_ctx)
 
-    def with_cache_volume_output(self, name: str, description: str) -> Self:
-        """Declare a desired CacheVolume output to be assigned in the environment
+    def with_check_output(self, name: str, description: str) -> Self:
+        """Declare a desired Check output to be assigned in the environment
 
         Parameters
         ----------
@@ -5295,23 +6731,23 @@ class Env(Type):
             Arg("name", name),
             Arg("description", description),
         ]
-        _ctx = self._select("withCacheVolumeOutput", _args)
+        _ctx = self._select("withCheckOutput", _args)
         return Env(_ctx)
 
-    def with_changeset_input(
+    def with_cloud_input(
         self,
         name: str,
-        value: Changeset,
+        value: Cloud,
         description: str,
     ) -> Self:
-        """Create or update a binding of type Changeset in the environment
+        """Create or update a binding of type Cloud in the environment
 
         Parameters
         ----------
         name:
             The name of the binding
         value:
-            The Changeset value to assign to the binding
+            The Cloud value to assign to the binding
         description:
             The purpose of the input
         """
@@ -5320,11 +6756,11 @@ class Env(Type):
             Arg("value", value),
             Arg("description", description),
         ]
-        _ctx = self._select("withChangesetInput", _args)
+        _ctx = self._select("withCloudInput", _args)
         return Env(_ctx)
 
-    def with_changeset_output(self, name: str, description: str) -> Self:
-        """Declare a desired Changeset output to be assigned in the environment
+    def with_cloud_output(self, name: str, description: str) -> Self:
+        """Declare a desired Cloud output to be assigned in the environment
 
         Parameters
         ----------
@@ -5337,23 +6773,24 @@ class Env(Type):
             Arg("name", name),
             Arg("description", description),
         ]
-        _ctx = self._select("withChangesetOutput", _args)
+        _ctx = self._select("withCloudOutput", _args)
         return Env(_ctx)
 
-    def with_check_group_input(
+    def with_container_comparison_input(
         self,
         name: str,
-        value: CheckGroup,
+        value: ContainerComparison,
         description: str,
     ) -> Self:
-        """Create or update a binding of type CheckGroup in the environment
+        """Create or update a binding of type ContainerComparison in the
+        environment
 
         Parameters
         ----------
         name:
             The name of the binding
         value:
-            The CheckGroup value to assign to the binding
+            The ContainerComparison value to assign to the binding
         description:
             The purpose of the input
         """
@@ -5362,11 +6799,12 @@ class Env(Type):
             Arg("value", value),
             Arg("description", description),
         ]
-        _ctx = self._select("withCheckGroupInput", _args)
+        _ctx = self._select("withContainerComparisonInput", _args)
         return Env(_ctx)
 
-    def with_check_group_output(self, name: str, description: str) -> Self:
-        """Declare a desired CheckGroup output to be assigned in the environment
+    def with_container_comparison_output(self, name: str, description: str) -> Self:
+        """Declare a desired ContainerComparison output to be assigned in the
+        environment
 
         Parameters
         ----------
@@ -5379,23 +6817,24 @@ class Env(Type):
             Arg("name", name),
             Arg("description", description),
         ]
-        _ctx = self._select("withCheckGroupOutput", _args)
+        _ctx = self._select("withContainerComparisonOutput", _args)
         return Env(_ctx)
 
-    def with_check_input(
+    def with_container_config_change_input(
         self,
         name: str,
-        value: Check,
+        value: ContainerConfigChange,
         description: str,
     ) -> Self:
-        """Create or update a binding of type Check in the environment
+        """Create or update a binding of type ContainerConfigChange in the
+        environment
 
         Parameters
         ----------
         name:
             The name of the binding
         value:
-            The Check value to assign to the binding
+            The ContainerConfigChange value to assign to the binding
         description:
             The purpose of the input
         """
@@ -5404,11 +6843,12 @@ class Env(Type):
             Arg("value", value),
             Arg("description", description),
         ]
-        _ctx = self._select("withCheckInput", _args)
+        _ctx = self._select("withContainerConfigChangeInput", _args)
         return Env(_ctx)
 
-    def with_check_output(self, name: str, description: str) -> Self:
-        """Declare a desired Check output to be assigned in the environment
+    def with_container_config_change_output(self, name: str, description: str) -> Self:
+        """Declare a desired ContainerConfigChange output to be assigned in the
+        environment
 
         Parameters
         ----------
@@ -5421,23 +6861,23 @@ class Env(Type):
             Arg("name", name),
             Arg("description", description),
         ]
-        _ctx = self._select("withCheckOutput", _args)
+        _ctx = self._select("withContainerConfigChangeOutput", _args)
         return Env(_ctx)
 
-    def with_cloud_input(
+    def with_container_input(
         self,
         name: str,
-        value: Cloud,
+        value: Container,
         description: str,
     ) -> Self:
-        """Create or update a binding of type Cloud in the environment
+        """Create or update a binding of type Container in the environment
 
         Parameters
         ----------
         name:
             The name of the binding
         value:
-            The Cloud value to assign to the binding
+            The Container value to assign to the binding
         description:
             The purpose of the input
         """
@@ -5446,49 +6886,53 @@ class Env(Type):
             Arg("value", value),
             Arg("description", description),
         ]
-        _ctx = self._select("withCloudInput", _args)
+        _ctx = self._select("withContainerInput", _args)
         return Env(_ctx)
 
-    def with_cloud_output(self, name: str, description: str) -> Self:
-        """Declare a desired Cloud output to be assigned in the environment
+    def with_container_layer_comparison_input(
+        self,
+        name: str,
+        value: ContainerLayerComparison,
+        description: str,
+    ) -> Self:
+        """Create or update a binding of type ContainerLayerComparison in the
+        environment
 
         Parameters
         ----------
         name:
             The name of the binding
+        value:
+            The ContainerLayerComparison value to assign to the binding
         description:
-            A description of the desired value of the binding
+            The purpose of the input
         """
         _args = [
             Arg("name", name),
+            Arg("value", value),
             Arg("description", description),
         ]
-        _ctx = self._select("withCloudOutput", _args)
+        _ctx = self._select("withContainerLayerComparisonInput", _args)
         return Env(_ctx)
 
-    def with_container_input(
-        self,
-        name: str,
-        value: Container,
-        description: str,
+    def with_container_layer_comparison_output(
+        self, name: str, description: str
     ) -> Self:
-        """Create or update a binding of type Container in the environment
+        """Declare a desired ContainerLayerComparison output to be assigned in
+        the environment
 
         Parameters
         ----------
         name:
             The name of the binding
-        value:
-            The Container value to assign to the binding
         description:
-            The purpose of the input
+            A description of the desired value of the binding
         """
         _args = [
             Arg("name", name),
-            Arg("value", value),
             Arg("description", description),
         ]
-        _ctx = self._select("withContainerInput", _args)
+        _ctx = self._select("withContainerLayerComparisonOutput", _args)
         return Env(_ctx)
 
     def with_container_output(self, name: str, description: str) -> Self:
@@ -5561,6 +7005,92 @@ class Env(Type):
         _ctx = self._select("withDirectoryOutput", _args)
         return Env(_ctx)
 
+    def with_engine_service_input(
+        self,
+        name: str,
+        value: EngineService,
+        description: str,
+    ) -> Self:
+        """Create or update a binding of type EngineService in the environment
+
+        Parameters
+        ----------
+        name:
+            The name of the binding
+        value:
+            The EngineService value to assign to the binding
+        description:
+            The purpose of the input
+        """
+        _args = [
+            Arg("name", name),
+            Arg("value", value),
+            Arg("description", description),
+        ]
+        _ctx = self._select("withEngineServiceInput", _args)
+        return Env(_ctx)
+
+    def with_engine_service_output(self, name: str, description: str) -> Self:
+        """Declare a desired EngineService output to be assigned in the
+        environment
+
+        Parameters
+        ----------
+        name:
+            The name of the binding
+        description:
+            A description of the desired value of the binding
+        """
+        _args = [
+            Arg("name", name),
+            Arg("description", description),
+        ]
+        _ctx = self._select("withEngineServiceOutput", _args)
+        return Env(_ctx)
+
+    def with_engine_session_input(
+        self,
+        name: str,
+        value: EngineSession,
+        description: str,
+    ) -> Self:
+        """Create or update a binding of type EngineSession in the environment
+
+        Parameters
+        ----------
+        name:
+            The name of the binding
+        value:
+            The EngineSession value to assign to the binding
+        description:
+            The purpose of the input
+        """
+        _args = [
+            Arg("name", name),
+            Arg("value", value),
+            Arg("description", description),
+        ]
+        _ctx = self._select("withEngineSessionInput", _args)
+        return Env(_ctx)
+
+    def with_engine_session_output(self, name: str, description: str) -> Self:
+        """Declare a desired EngineSession output to be assigned in the
+        environment
+
+        Parameters
+        ----------
+        name:
+            The name of the binding
+        description:
+            A description of the desired value of the binding
+        """
+        _args = [
+            Arg("name", name),
+            Arg("description", description),
+        ]
+        _ctx = self._select("withEngineSessionOutput", _args)
+        return Env(_ctx)
+
     def with_env_file_input(
         self,
         name: str,
@@ -5687,6 +7217,50 @@ class Env(Type):
         _ctx = self._select("withFileOutput", _args)
         return Env(_ctx)
 
+    def with_function_arg_constraints_input(
+        self,
+        name: str,
+        value: "FunctionArgConstraints",
+        description: str,
+    ) -> Self:
+        """Create or update a binding of type FunctionArgConstraints in the
+        environment
+
+        Parameters
+        ----------
+        name:
+            The name of the binding
+        value:
+            The FunctionArgConstraints value to assign to the binding
+        description:
+            The purpose of the input
+        """
+        _args = [
+            Arg("name", name),
+            Arg("value", value),
+            Arg("description", description),
+        ]
+        _ctx = self._select("withFunctionArgConstraintsInput", _args)
+        return Env(_ctx)
+
+    def with_function_arg_constraints_output(self, name: str, description: str) -> Self:
+        """Declare a desired FunctionArgConstraints output to be assigned in the
+        environment
+
+        Parameters
+        ----------
+        name:
+            The name of the binding
+        description:
+            A description of the desired value of the binding
+        """
+        _args = [
+            Arg("name", name),
+            Arg("description", description),
+        ]
+        _ctx = self._select("withFunctionArgConstraintsOutput", _args)
+        return Env(_ctx)
+
     def with_git_ref_input(
         self,
         name: str,
@@ -5785,7 +7359,63 @@ class Env(Type):
         name:
             The name of the binding
         value:
-            The JSONValue value to assign to the binding
+            The JSONValue value to assign to the binding
+        description:
+            The purpose of the input
+        """
+        _args = [
+            Arg("name", name),
+            Arg("value", value),
+            Arg("description", description),
+        ]
+        _ctx = self._select("withJSONValueInput", _args)
+        return Env(_ctx)
+
+    def with_json_value_output(self, name: str, description: str) -> Self:
+        """Declare a desired JSONValue output to be assigned in the environment
+
+        Parameters
+        ----------
+        name:
+            The name of the binding
+        description:
+            A description of the desired value of the binding
+        """
+        _args = [
+            Arg("name", name),
+            Arg("description", description),
+        ]
+        _ctx = self._select("withJSONValueOutput", _args)
+        return Env(_ctx)
+
+    def with_module(self, module: "Module") -> Self:
+        """Installs a module into the environment, exposing its functions to the
+        model
+
+        Contextual path arguments will be populated using the environment's
+        workspace.
+        """
+        _args = [
+            Arg("module", module),
+        ]
+        _ctx = self._select("withModule", _args)
+        return Env(_ctx)
+
+    def with_module_capabilities_input(
+        self,
+        name: str,
+        value: "ModuleCapabilities",
+        description: str,
+    ) -> Self:
+        """Create or update a binding of type ModuleCapabilities in the
+        environment
+
+        Parameters
+        ----------
+        name:
+            The name of the binding
+        value:
+            The ModuleCapabilities value to assign to the binding
         description:
             The purpose of the input
         """
@@ -5794,11 +7424,12 @@ class Env(Type):
             Arg("value", value),
             Arg("description", description),
         ]
-        _ctx = self._select("withJSONValueInput", _args)
+        _ctx = self._select("withModuleCapabilitiesInput", _args)
         return Env(_ctx)
 
-    def with_json_value_output(self, name: str, description: str) -> Self:
-        """Declare a desired JSONValue output to be assigned in the environment
+    def with_module_capabilities_output(self, name: str, description: str) -> Self:
+        """Declare a desired ModuleCapabilities output to be assigned in the
+        environment
 
         Parameters
         ----------
@@ -5811,20 +7442,7 @@ class Env(Type):
             Arg("name", name),
             Arg("description", description),
         ]
-        _ctx = self._select("withJSONValueOutput", _args)
-        return Env(_ctx)
-
-    def with_module(self, module: "Module") -> Self:
-        """Installs a module into the environment, exposing its functions to the
-        model
-
-        Contextual path arguments will be populated using the environment's
-        workspace.
-        """
-        _args = [
-            Arg("module", module),
-        ]
-        _ctx = self._select("withModule", _args)
+        _ctx = self._select("withModuleCapabilitiesOutput", _args)
         return Env(_ctx)
 
     def with_module_config_client_input(
@@ -5956,6 +7574,77 @@ class Env(Type):
         _ctx = self._select("withModuleSourceOutput", _args)
         return Env(_ctx)
 
+    def with_output_exec_check(
+        self,
+        name: str,
+        container: Container,
+        args: list[str],
+        *,
+        path: str | None = "/output",
+        max_attempts: int | None = 3,
+    ) -> Self:
+        """Checks the values saved to an output by running a command against them
+
+        When the command fails, its error is returned to the model, which is
+        asked to fix the value until the command succeeds or the attempts run
+        out. Directory, File and Container values are mounted at the given
+        path, and String values are passed on stdin.
+
+        Parameters
+        ----------
+        name:
+            The name of the output
+        container:
+            The container to run the command in
+        args:
+            The command to run
+        path:
+            The path to mount the value at
+        max_attempts:
+            The number of values to check before giving up
+        """
+        _args = [
+            Arg("name", name),
+            Arg("container", container),
+            Arg("args", args),
+            Arg("path", path, "/output"),
+            Arg("maxAttempts", max_attempts, 3),
+        ]
+        _ctx = self._select("withOutputExecCheck", _args)
+        return Env(_ctx)
+
+    def with_output_function_check(
+        self,
+        name: str,
+        function: str,
+        *,
+        max_attempts: int | None = 3,
+    ) -> Self:
+        """Checks the values saved to an output by calling a function against
+        them
+
+        The function must belong to a module installed in the environment, and
+        take an argument of the output's type. When it fails, its error is
+        returned to the model, which is asked to fix the value until the
+        function succeeds or the attempts run out.
+
+        Parameters
+        ----------
+        name:
+            The name of the output
+        function:
+            The name of the function to call
+        max_attempts:
+            The number of values to check before giving up
+        """
+        _args = [
+            Arg("name", name),
+            Arg("function", function),
+            Arg("maxAttempts", max_attempts, 3),
+        ]
+        _ctx = self._select("withOutputFunctionCheck", _args)
+        return Env(_ctx)
+
     def with_search_result_input(
         self,
         name: str,
@@ -6252,6 +7941,49 @@ class Env(Type):
         _ctx = self._select("withStringOutput", _args)
         return Env(_ctx)
 
+    def with_union_type_def_input(
+        self,
+        name: str,
+        value: "UnionTypeDef",
+        description: str,
+    ) -> Self:
+        """Create or update a binding of type UnionTypeDef in the environment
+
+        Parameters
+        ----------
+        name:
+            The name of the binding
+        value:
+            The UnionTypeDef value to assign to the binding
+        description:
+            The purpose of the input
+        """
+        _args = [
+            Arg("name", name),
+            Arg("value", value),
+            Arg("description", description),
+        ]
+        _ctx = self._select("withUnionTypeDefInput", _args)
+        return Env(_ctx)
+
+    def with_union_type_def_output(self, name: str, description: str) -> Self:
+        """Declare a desired UnionTypeDef output to be assigned in the
+        environment
+
+        Parameters
+        ----------
+        name:
+            The name of the binding
+        description:
+            A description of the desired value of the binding
+        """
+        _args = [
+            Arg("name", name),
+            Arg("description", description),
+        ]
+        _ctx = self._select("withUnionTypeDefOutput", _args)
+        return Env(_ctx)
+
     def with_workspace(self, workspace: Directory) -> Self:
         """Returns a new environment with the provided workspace
 
@@ -7277,6 +9009,13 @@ class Function(Type):
         ignore: list[str] | None = None,
         source_map: "SourceMap | None" = None,
         deprecated: str | None = None,
+        min: JSON | None = None,
+        max: JSON | None = None,
+        pattern: str | None = "",
+        min_length: int | None = 0,
+        max_length: int | None = 0,
+        non_empty: bool | None = False,
+        required_paths: list[str] | None = None,
     ) -> Self:
         """Returns the function with the provided argument
 
@@ -7300,6 +9039,26 @@ class Function(Type):
             The source map for the argument definition.
         deprecated:
             If deprecated, the reason or migration path.
+        min:
+            If the argument is an Integer or Float, the minimum value,
+            inclusive, as a JSON number.
+        max:
+            If the argument is an Integer or Float, the maximum value,
+            inclusive, as a JSON number.
+        pattern:
+            If the argument is a String, a regular expression (RE2 syntax)
+            that the whole value must match.
+        min_length:
+            If the argument is a String or a list, the minimum length of the
+            value.
+        max_length:
+            If the argument is a String or a list, the maximum length of the
+            value.
+        non_empty:
+            If the argument is a list, require it to contain at least one
+            element.
+        required_paths:
+            If the argument is a Directory, paths that must exist in it.
         """
         _args = [
             Arg("name", name),
@@ -7310,6 +9069,13 @@ class Function(Type):
             Arg("ignore", [] if ignore is None else ignore, []),
             Arg("sourceMap", source_map, None),
             Arg("deprecated", deprecated, None),
+            Arg("min", min, None),
+            Arg("max", max, None),
+            Arg("pattern", pattern, ""),
+            Arg("minLength", min_length, 0),
+            Arg("maxLength", max_length, 0),
+            Arg("nonEmpty", non_empty, False),
+            Arg("requiredPaths", [] if required_paths is None else required_paths, []),
         ]
         _ctx = self._select("withArg", _args)
         return Function(_ctx)
@@ -7399,6 +9165,12 @@ class FunctionArg(Type):
     argument at function definition time, not an argument passed at
     function call time."""
 
+    def constraints(self) -> "FunctionArgConstraints":
+        """Constraints the value of the argument must satisfy, if any."""
+        _args: list[Arg] = []
+        _ctx = self._select("constraints", _args)
+        return FunctionArgConstraints(_ctx)
+
     async def default_path(self) -> str:
         """Only applies to arguments of type File or Directory. If the argument
         is not set, load it from the given path in the context directory
@@ -7418,12 +9190,184 @@ class FunctionArg(Type):
             If the API returns an error.
         """
         _args: list[Arg] = []
-        _ctx = self._select("defaultPath", _args)
-        return await _ctx.execute(str)
+        _ctx = self._select("defaultPath", _args)
+        return await _ctx.execute(str)
+
+    async def default_value(self) -> JSON:
+        """A default value to use for this argument when not explicitly set by
+        the caller, if any.
+
+        Returns
+        -------
+        JSON
+            An arbitrary JSON-encoded value.
+
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
+        """
+        _args: list[Arg] = []
+        _ctx = self._select("defaultValue", _args)
+        return await _ctx.execute(JSON)
+
+    async def deprecated(self) -> str | None:
+        """The reason this function is deprecated, if any.
+
+        Returns
+        -------
+        str | None
+            The `String` scalar type represents textual data, represented as
+            UTF-8 character sequences. The String type is most often used by
+            GraphQL to represent free-form human-readable text.
+
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
+        """
+        _args: list[Arg] = []
+        _ctx = self._select("deprecated", _args)
+        return await _ctx.execute(str | None)
+
+    async def description(self) -> str:
+        """A doc string for the argument, if any.
+
+        Returns
+        -------
+        str
+            The `String` scalar type represents textual data, represented as
+            UTF-8 character sequences. The String type is most often used by
+            GraphQL to represent free-form human-readable text.
+
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
+        """
+        _args: list[Arg] = []
+        _ctx = self._select("description", _args)
+        return await _ctx.execute(str)
+
+    async def id(self) -> FunctionArgID:
+        """A unique identifier for this FunctionArg.
+
+        Note
+        ----
+        This is lazily evaluated, no operation is actually run.
+
+        Returns
+        -------
+        FunctionArgID
+            The `FunctionArgID` scalar type represents an identifier for an
+            object of type FunctionArg.
+
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
+        """
+        _args: list[Arg] = []
+        _ctx = self._select("id", _args)
+        return await _ctx.execute(FunctionArgID)
+
+    async def ignore(self) -> list[str]:
+        """Only applies to arguments of type Directory. The ignore patterns are
+        applied to the input directory, and matching entries are filtered out,
+        in a cache-efficient manner.
+
+        Returns
+        -------
+        list[str]
+            The `String` scalar type represents textual data, represented as
+            UTF-8 character sequences. The String type is most often used by
+            GraphQL to represent free-form human-readable text.
+
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
+        """
+        _args: list[Arg] = []
+        _ctx = self._select("ignore", _args)
+        return await _ctx.execute(list[str])
+
+    async def name(self) -> str:
+        """The name of the argument in lowerCamelCase format.
+
+        Returns
+        -------
+        str
+            The `String` scalar type represents textual data, represented as
+            UTF-8 character sequences. The String type is most often used by
+            GraphQL to represent free-form human-readable text.
+
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
+        """
+        _args: list[Arg] = []
+        _ctx = self._select("name", _args)
+        return await _ctx.execute(str)
+
+    def source_map(self) -> "SourceMap":
+        """The location of this arg declaration."""
+        _args: list[Arg] = []
+        _ctx = self._select("sourceMap", _args)
+        return SourceMap(_ctx)
+
+    def type_def(self) -> "TypeDef":
+        """The type of the argument."""
+        _args: list[Arg] = []
+        _ctx = self._select("typeDef", _args)
+        return TypeDef(_ctx)
+
+
+@typecheck
+class FunctionArgConstraints(Type):
+    """Constraints on the value of a function argument.  They are checked
+    by the engine before the function is called."""
+
+    async def id(self) -> FunctionArgConstraintsID:
+        """A unique identifier for this FunctionArgConstraints.
+
+        Note
+        ----
+        This is lazily evaluated, no operation is actually run.
+
+        Returns
+        -------
+        FunctionArgConstraintsID
+            The `FunctionArgConstraintsID` scalar type represents an
+            identifier for an object of type FunctionArgConstraints.
+
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
+        """
+        _args: list[Arg] = []
+        _ctx = self._select("id", _args)
+        return await _ctx.execute(FunctionArgConstraintsID)
 
-    async def default_value(self) -> JSON:
-        """A default value to use for this argument when not explicitly set by
-        the caller, if any.
+    async def max(self) -> JSON:
+        """Only applies to Integer and Float arguments. The maximum value,
+        inclusive, as a JSON number.
 
         Returns
         -------
@@ -7438,18 +9382,19 @@ class FunctionArg(Type):
             If the API returns an error.
         """
         _args: list[Arg] = []
-        _ctx = self._select("defaultValue", _args)
+        _ctx = self._select("max", _args)
         return await _ctx.execute(JSON)
 
-    async def deprecated(self) -> str | None:
-        """The reason this function is deprecated, if any.
+    async def max_length(self) -> int:
+        """Only applies to String and list arguments. The maximum length of the
+        value, or 0 for no maximum.
 
         Returns
         -------
-        str | None
-            The `String` scalar type represents textual data, represented as
-            UTF-8 character sequences. The String type is most often used by
-            GraphQL to represent free-form human-readable text.
+        int
+            The `Int` scalar type represents non-fractional signed whole
+            numeric values. Int can represent values between -(2^31) and 2^31
+            - 1.
 
         Raises
         ------
@@ -7459,18 +9404,17 @@ class FunctionArg(Type):
             If the API returns an error.
         """
         _args: list[Arg] = []
-        _ctx = self._select("deprecated", _args)
-        return await _ctx.execute(str | None)
+        _ctx = self._select("maxLength", _args)
+        return await _ctx.execute(int)
 
-    async def description(self) -> str:
-        """A doc string for the argument, if any.
+    async def min(self) -> JSON:
+        """Only applies to Integer and Float arguments. The minimum value,
+        inclusive, as a JSON number.
 
         Returns
         -------
-        str
-            The `String` scalar type represents textual data, represented as
-            UTF-8 character sequences. The String type is most often used by
-            GraphQL to represent free-form human-readable text.
+        JSON
+            An arbitrary JSON-encoded value.
 
         Raises
         ------
@@ -7480,21 +9424,19 @@ class FunctionArg(Type):
             If the API returns an error.
         """
         _args: list[Arg] = []
-        _ctx = self._select("description", _args)
-        return await _ctx.execute(str)
-
-    async def id(self) -> FunctionArgID:
-        """A unique identifier for this FunctionArg.
+        _ctx = self._select("min", _args)
+        return await _ctx.execute(JSON)
 
-        Note
-        ----
-        This is lazily evaluated, no operation is actually run.
+    async def min_length(self) -> int:
+        """Only applies to String and list arguments. The minimum length of the
+        value.
 
         Returns
         -------
-        FunctionArgID
-            The `FunctionArgID` scalar type represents an identifier for an
-            object of type FunctionArg.
+        int
+            The `Int` scalar type represents non-fractional signed whole
+            numeric values. Int can represent values between -(2^31) and 2^31
+            - 1.
 
         Raises
         ------
@@ -7504,20 +9446,17 @@ class FunctionArg(Type):
             If the API returns an error.
         """
         _args: list[Arg] = []
-        _ctx = self._select("id", _args)
-        return await _ctx.execute(FunctionArgID)
+        _ctx = self._select("minLength", _args)
+        return await _ctx.execute(int)
 
-    async def ignore(self) -> list[str]:
-        """Only applies to arguments of type Directory. The ignore patterns are
-        applied to the input directory, and matching entries are filtered out,
-        in a cache-efficient manner.
+    async def non_empty(self) -> bool:
+        """Only applies to list arguments. Whether the list must contain at least
+        one element.
 
         Returns
         -------
-        list[str]
-            The `String` scalar type represents textual data, represented as
-            UTF-8 character sequences. The String type is most often used by
-            GraphQL to represent free-form human-readable text.
+        bool
+            The `Boolean` scalar type represents `true` or `false`.
 
         Raises
         ------
@@ -7527,11 +9466,12 @@ class FunctionArg(Type):
             If the API returns an error.
         """
         _args: list[Arg] = []
-        _ctx = self._select("ignore", _args)
-        return await _ctx.execute(list[str])
+        _ctx = self._select("nonEmpty", _args)
+        return await _ctx.execute(bool)
 
-    async def name(self) -> str:
-        """The name of the argument in lowerCamelCase format.
+    async def pattern(self) -> str:
+        """Only applies to String arguments. A regular expression (RE2 syntax)
+        that the whole value must match.
 
         Returns
         -------
@@ -7548,20 +9488,30 @@ class FunctionArg(Type):
             If the API returns an error.
         """
         _args: list[Arg] = []
-        _ctx = self._select("name", _args)
+        _ctx = self._select("pattern", _args)
         return await _ctx.execute(str)
 
-    def source_map(self) -> "SourceMap":
-        """The location of this arg declaration."""
-        _args: list[Arg] = []
-        _ctx = self._select("sourceMap", _args)
-        return SourceMap(_ctx)
+    async def required_paths(self) -> list[str]:
+        """Only applies to arguments of type Directory. Paths that must exist in
+        the directory.
 
-    def type_def(self) -> "TypeDef":
-        """The type of the argument."""
+        Returns
+        -------
+        list[str]
+            The `String` scalar type represents textual data, represented as
+            UTF-8 character sequences. The String type is most often used by
+            GraphQL to represent free-form human-readable text.
+
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
+        """
         _args: list[Arg] = []
-        _ctx = self._select("typeDef", _args)
-        return TypeDef(_ctx)
+        _ctx = self._select("requiredPaths", _args)
+        return await _ctx.execute(list[str])
 
 
 @typecheck
@@ -8831,12 +10781,70 @@ class LLM(Type):
         _ctx = self._select("bindResult", _args)
         return Binding(_ctx)
 
+    async def dry_run_calls(self) -> list[str]:
+        """return the calls recorded instead of being called in a dry run
+
+        Returns
+        -------
+        list[str]
+            The `String` scalar type represents textual data, represented as
+            UTF-8 character sequences. The String type is most often used by
+            GraphQL to represent free-form human-readable text.
+
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
+        """
+        _args: list[Arg] = []
+        _ctx = self._select("dryRunCalls", _args)
+        return await _ctx.execute(list[str])
+
     def env(self) -> Env:
         """return the LLM's current environment"""
         _args: list[Arg] = []
         _ctx = self._select("env", _args)
         return Env(_ctx)
 
+    async def fan_out(
+        self,
+        *,
+        n: int | None = 0,
+        variations: list[str] | None = None,
+    ) -> list["LLM"]:
+        """Run several branches of the LLM in parallel, each with its own message
+        history and a copy of its environment
+
+        Each branch submits the queued prompt, followed by one of the
+        variations in turn, if any. Branches that fail are still returned, and
+        fail when they are used. The token usage of all the branches is
+        available with fanOutTokenUsage.
+
+        Parameters
+        ----------
+        n:
+            The number of branches to run. Defaults to the number of
+            variations.
+        variations:
+            Prompts to append to the branches, one per branch in turn
+        """
+        _args = [
+            Arg("n", n, 0),
+            Arg("variations", [] if variations is None else variations, []),
+        ]
+        _ctx = self._select("fanOut", _args)
+        return await _ctx.execute_object_list(LLM)
+
+    def fan_out_token_usage(self) -> "LLMTokenUsage":
+        """returns the token usage of the current state, including the other
+        branches of the fan outs it came from
+        """
+        _args: list[Arg] = []
+        _ctx = self._select("fanOutTokenUsage", _args)
+        return LLMTokenUsage(_ctx)
+
     async def has_prompt(self) -> bool:
         """Indicates whether there are any queued prompts or tool results to send
         to the model
@@ -9068,6 +11076,15 @@ class LLM(Type):
         _ctx = self._select("withBlockedFunction", _args)
         return LLM(_ctx)
 
+    def with_dry_run(self) -> Self:
+        """Return a new LLM that records the calls it makes with side effects
+        outside of the engine, such as exports, publishing or host access,
+        instead of calling them
+        """
+        _args: list[Arg] = []
+        _ctx = self._select("withDryRun", _args)
+        return LLM(_ctx)
+
     def with_env(self, env: Env) -> Self:
         """allow the LLM to interact with an environment via MCP"""
         _args = [
@@ -9157,6 +11174,34 @@ class LLM(Type):
         _ctx = self._select("withSystemPrompt", _args)
         return LLM(_ctx)
 
+    def with_tool_policy(
+        self,
+        pattern: str,
+        policy: LLMToolPolicy,
+    ) -> Self:
+        """Return a new LLM that applies a policy to the functions matching a
+        pattern
+
+        A pattern matches a function name, like withExec, or a type and
+        function name, like Container.withExec, and may use * as a wildcard.
+        Tools of MCP servers are matched by server and tool name. When several
+        rules match a function, the last one applies, and functions that match
+        no rule are allowed.
+
+        Parameters
+        ----------
+        pattern:
+            The functions to apply the policy to
+        policy:
+            Whether the LLM may call the functions
+        """
+        _args = [
+            Arg("pattern", pattern),
+            Arg("policy", policy),
+        ]
+        _ctx = self._select("withToolPolicy", _args)
+        return LLM(_ctx)
+
     def without_default_system_prompt(self) -> Self:
         """Disable the default system prompt"""
         _args: list[Arg] = []
@@ -9638,6 +11683,12 @@ class Module(Type):
     def __await__(self):
         return self.sync().__await__()
 
+    async def unions(self) -> list["TypeDef"]:
+        """Unions served by this module."""
+        _args: list[Arg] = []
+        _ctx = self._select("unions", _args)
+        return await _ctx.execute_object_list(TypeDef)
+
     def user_defaults(self) -> EnvFile:
         """User-defined default values, loaded from local .env files."""
         _args: list[Arg] = []
@@ -9674,20 +11725,175 @@ class Module(Type):
         _ctx = self._select("withInterface", _args)
         return Module(_ctx)
 
-    def with_object(self, object: "TypeDef") -> Self:
-        """This module plus the given Object type and associated functions."""
-        _args = [
-            Arg("object", object),
-        ]
-        _ctx = self._select("withObject", _args)
-        return Module(_ctx)
+    def with_object(self, object: "TypeDef") -> Self:
+        """This module plus the given Object type and associated functions."""
+        _args = [
+            Arg("object", object),
+        ]
+        _ctx = self._select("withObject", _args)
+        return Module(_ctx)
+
+    def with_union(self, union: "TypeDef") -> Self:
+        """This module plus the given Union type"""
+        _args = [
+            Arg("union", union),
+        ]
+        _ctx = self._select("withUnion", _args)
+        return Module(_ctx)
+
+    def with_(self, cb: Callable[["Module"], "Module"]) -> "Module":
+        """Call the provided callable with current Module.
+
+        This is useful for reusability and readability by not breaking the calling chain.
+        """
+        return cb(self)
+
+
+@typecheck
+class ModuleCapabilities(Type):
+    """The capabilities a module requires."""
+
+    async def exec(self) -> bool:
+        """Whether the module may run commands with access to the Dagger API.
+
+        Returns
+        -------
+        bool
+            The `Boolean` scalar type represents `true` or `false`.
+
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
+        """
+        _args: list[Arg] = []
+        _ctx = self._select("exec", _args)
+        return await _ctx.execute(bool)
+
+    async def host_read(self) -> list[str]:
+        """Paths the module may read from the host through defaultPath arguments.
+
+        Returns
+        -------
+        list[str]
+            The `String` scalar type represents textual data, represented as
+            UTF-8 character sequences. The String type is most often used by
+            GraphQL to represent free-form human-readable text.
+
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
+        """
+        _args: list[Arg] = []
+        _ctx = self._select("hostRead", _args)
+        return await _ctx.execute(list[str])
+
+    async def host_sockets(self) -> bool:
+        """Whether the module may use host unix sockets and tunnels.
+
+        Returns
+        -------
+        bool
+            The `Boolean` scalar type represents `true` or `false`.
+
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
+        """
+        _args: list[Arg] = []
+        _ctx = self._select("hostSockets", _args)
+        return await _ctx.execute(bool)
+
+    async def id(self) -> ModuleCapabilitiesID:
+        """A unique identifier for this ModuleCapabilities.
+
+        Note
+        ----
+        This is lazily evaluated, no operation is actually run.
+
+        Returns
+        -------
+        ModuleCapabilitiesID
+            The `ModuleCapabilitiesID` scalar type represents an identifier
+            for an object of type ModuleCapabilities.
+
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
+        """
+        _args: list[Arg] = []
+        _ctx = self._select("id", _args)
+        return await _ctx.execute(ModuleCapabilitiesID)
+
+    async def llm(self) -> bool:
+        """Whether the module may use LLMs.
+
+        Returns
+        -------
+        bool
+            The `Boolean` scalar type represents `true` or `false`.
+
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
+        """
+        _args: list[Arg] = []
+        _ctx = self._select("llm", _args)
+        return await _ctx.execute(bool)
+
+    async def network(self) -> bool:
+        """Whether the module may run commands and services with full network
+        access, and download files with http. Image pulls and git fetches are
+        done by the engine and aren't restricted.
+
+        Returns
+        -------
+        bool
+            The `Boolean` scalar type represents `true` or `false`.
+
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
+        """
+        _args: list[Arg] = []
+        _ctx = self._select("network", _args)
+        return await _ctx.execute(bool)
 
-    def with_(self, cb: Callable[["Module"], "Module"]) -> "Module":
-        """Call the provided callable with current Module.
+    async def secrets(self) -> bool:
+        """Whether the module may load secrets from the host's secret providers.
 
-        This is useful for reusability and readability by not breaking the calling chain.
+        Returns
+        -------
+        bool
+            The `Boolean` scalar type represents `true` or `false`.
+
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
         """
-        return cb(self)
+        _args: list[Arg] = []
+        _ctx = self._select("secrets", _args)
+        return await _ctx.execute(bool)
 
 
 @typecheck
@@ -9801,6 +12007,15 @@ class ModuleSource(Type):
         _ctx = self._select("blueprint", _args)
         return ModuleSource(_ctx)
 
+    def capabilities(self) -> ModuleCapabilities:
+        """The capabilities the module requires, as declared in its dagger.json.
+        Null if the module declares none, in which case it requires all
+        capabilities.
+        """
+        _args: list[Arg] = []
+        _ctx = self._select("capabilities", _args)
+        return ModuleCapabilities(_ctx)
+
     async def clone_ref(self) -> str:
         """The ref to clone the root of the git repo from. Only valid for git
         sources.
@@ -9950,6 +12165,15 @@ class ModuleSource(Type):
         _ctx = self._select("generatedContextDirectory", _args)
         return Directory(_ctx)
 
+    def granted_capabilities(self) -> ModuleCapabilities:
+        """The capabilities granted to the module when it was installed as a
+        dependency. Null if none were recorded, in which case it is only
+        restricted by the capabilities it declares.
+        """
+        _args: list[Arg] = []
+        _ctx = self._select("grantedCapabilities", _args)
+        return ModuleCapabilities(_ctx)
+
     async def html_repo_url(self) -> str:
         """The URL to access the web view of the repository (e.g., GitHub,
         GitLab, Bitbucket).
@@ -10358,6 +12582,49 @@ class ModuleSource(Type):
         _ctx = self._select("withExperimentalFeatures", _args)
         return ModuleSource(_ctx)
 
+    def with_granted_capabilities(
+        self,
+        *,
+        host_read: list[str] | None = None,
+        host_sockets: bool | None = False,
+        network: bool | None = False,
+        llm: bool | None = False,
+        exec: bool | None = False,
+        secrets: bool | None = False,
+    ) -> Self:
+        """Record the capabilities granted to the module as a dependency.
+        Recorded in the parent module's dagger.json when installed.
+
+        Parameters
+        ----------
+        host_read:
+            Paths the module may read from the host through defaultPath
+            arguments.
+        host_sockets:
+            Whether the module may use host unix sockets and tunnels.
+        network:
+            Whether the module may run commands and services with full network
+            access, and download files with http. Image pulls and git fetches
+            are done by the engine and aren't restricted.
+        llm:
+            Whether the module may use LLMs.
+        exec:
+            Whether the module may run commands with access to the Dagger API.
+        secrets:
+            Whether the module may load secrets from the host's secret
+            providers.
+        """
+        _args = [
+            Arg("hostRead", [] if host_read is None else host_read, []),
+            Arg("hostSockets", host_sockets, False),
+            Arg("network", network, False),
+            Arg("llm", llm, False),
+            Arg("exec", exec, False),
+            Arg("secrets", secrets, False),
+        ]
+        _ctx = self._select("withGrantedCapabilities", _args)
+        return ModuleSource(_ctx)
+
     def with_includes(self, patterns: list[str]) -> Self:
         """Update the module source with additional include patterns for
         files+directories from its context that are required for building it
@@ -11223,6 +13490,26 @@ class Client(Root):
         _ctx = self._select("loadCloudFromID", _args)
         return Cloud(_ctx)
 
+    def load_container_comparison_from_id(
+        self, id: ContainerComparisonID
+    ) -> ContainerComparison:
+        """Load a ContainerComparison from its ID."""
+        _args = [
+            Arg("id", id),
+        ]
+        _ctx = self._select("loadContainerComparisonFromID", _args)
+        return ContainerComparison(_ctx)
+
+    def load_container_config_change_from_id(
+        self, id: ContainerConfigChangeID
+    ) -> ContainerConfigChange:
+        """Load a ContainerConfigChange from its ID."""
+        _args = [
+            Arg("id", id),
+        ]
+        _ctx = self._select("loadContainerConfigChangeFromID", _args)
+        return ContainerConfigChange(_ctx)
+
     def load_container_from_id(self, id: ContainerID) -> Container:
         """Load a Container from its ID."""
         _args = [
@@ -11231,6 +13518,16 @@ class Client(Root):
         _ctx = self._select("loadContainerFromID", _args)
         return Container(_ctx)
 
+    def load_container_layer_comparison_from_id(
+        self, id: ContainerLayerComparisonID
+    ) -> ContainerLayerComparison:
+        """Load a ContainerLayerComparison from its ID."""
+        _args = [
+            Arg("id", id),
+        ]
+        _ctx = self._select("loadContainerLayerComparisonFromID", _args)
+        return ContainerLayerComparison(_ctx)
+
     def load_current_module_from_id(self, id: CurrentModuleID) -> CurrentModule:
         """Load a CurrentModule from its ID."""
         _args = [
@@ -11283,6 +13580,22 @@ class Client(Root):
         _ctx = self._select("loadEngineFromID", _args)
         return Engine(_ctx)
 
+    def load_engine_service_from_id(self, id: EngineServiceID) -> EngineService:
+        """Load a EngineService from its ID."""
+        _args = [
+            Arg("id", id),
+        ]
+        _ctx = self._select("loadEngineServiceFromID", _args)
+        return EngineService(_ctx)
+
+    def load_engine_session_from_id(self, id: EngineSessionID) -> EngineSession:
+        """Load a EngineSession from its ID."""
+        _args = [
+            Arg("id", id),
+        ]
+        _ctx = self._select("loadEngineSessionFromID", _args)
+        return EngineSession(_ctx)
+
     def load_enum_type_def_from_id(self, id: EnumTypeDefID) -> EnumTypeDef:
         """Load a EnumTypeDef from its ID."""
         _args = [
@@ -11357,6 +13670,16 @@ class Client(Root):
         _ctx = self._select("loadFileFromID", _args)
         return File(_ctx)
 
+    def load_function_arg_constraints_from_id(
+        self, id: FunctionArgConstraintsID
+    ) -> FunctionArgConstraints:
+        """Load a FunctionArgConstraints from its ID."""
+        _args = [
+            Arg("id", id),
+        ]
+        _ctx = self._select("loadFunctionArgConstraintsFromID", _args)
+        return FunctionArgConstraints(_ctx)
+
     def load_function_arg_from_id(self, id: FunctionArgID) -> FunctionArg:
         """Load a FunctionArg from its ID."""
         _args = [
@@ -11481,6 +13804,16 @@ class Client(Root):
         _ctx = self._select("loadListTypeDefFromID", _args)
         return ListTypeDef(_ctx)
 
+    def load_module_capabilities_from_id(
+        self, id: ModuleCapabilitiesID
+    ) -> ModuleCapabilities:
+        """Load a ModuleCapabilities from its ID."""
+        _args = [
+            Arg("id", id),
+        ]
+        _ctx = self._select("loadModuleCapabilitiesFromID", _args)
+        return ModuleCapabilities(_ctx)
+
     def load_module_config_client_from_id(
         self, id: ModuleConfigClientID
     ) -> ModuleConfigClient:
@@ -11611,6 +13944,14 @@ class Client(Root):
         _ctx = self._select("loadTypeDefFromID", _args)
         return TypeDef(_ctx)
 
+    def load_union_type_def_from_id(self, id: UnionTypeDefID) -> "UnionTypeDef":
+        """Load a UnionTypeDef from its ID."""
+        _args = [
+            Arg("id", id),
+        ]
+        _ctx = self._select("loadUnionTypeDefFromID", _args)
+        return UnionTypeDef(_ctx)
+
     def module(self) -> Module:
         """Create a new module."""
         _args: list[Arg] = []
@@ -11655,6 +13996,24 @@ class Client(Root):
         _ctx = self._select("moduleSource", _args)
         return ModuleSource(_ctx)
 
+    def running_service(self, name: str) -> "Service":
+        """Attach to a persistent service running in the engine under the given
+        name.
+
+        The service may have been started by another session, with
+        `start(persistent: true)`. Only the main client can attach to it.
+
+        Parameters
+        ----------
+        name:
+            The name the service was started with.
+        """
+        _args = [
+            Arg("name", name),
+        ]
+        _ctx = self._select("runningService", _args)
+        return Service(_ctx)
+
     def secret(
         self,
         uri: str,
@@ -12314,17 +14673,55 @@ class Service(Type):
         _ctx = self._select("id", _args)
         return await _ctx.execute(ServiceID)
 
+    async def name(self) -> str:
+        """The name of the service, if set with withName.
+
+        Returns
+        -------
+        str
+            The `String` scalar type represents textual data, represented as
+            UTF-8 character sequences. The String type is most often used by
+            GraphQL to represent free-form human-readable text.
+
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
+        """
+        _args: list[Arg] = []
+        _ctx = self._select("name", _args)
+        return await _ctx.execute(str)
+
     async def ports(self) -> list[Port]:
         """Retrieves the list of ports provided by the service."""
         _args: list[Arg] = []
         _ctx = self._select("ports", _args)
         return await _ctx.execute_object_list(Port)
 
-    async def start(self) -> Self:
+    async def start(
+        self,
+        *,
+        persistent: bool | None = False,
+        idle_timeout: str | None = None,
+    ) -> Self:
         """Start the service and wait for its health checks to succeed.
 
         Services bound to a Container do not need to be manually started.
 
+        Parameters
+        ----------
+        persistent:
+            Keep the service running after the session that started it ends.
+            The service must have a name, set with withName. Other sessions
+            can attach to it with runningService, or by starting or binding
+            the same service.
+        idle_timeout:
+            Stop a persistent service once nothing has used it for this long,
+            as a duration string (e.g., "30m", "1h").
+            If unset, the service runs until it's stopped.
+
         Raises
         ------
         ExecuteTimeoutError
@@ -12332,12 +14729,17 @@ class Service(Type):
         QueryError
             If the API returns an error.
         """
-        _args: list[Arg] = []
+        _args = [
+            Arg("persistent", persistent, False),
+            Arg("idleTimeout", idle_timeout, None),
+        ]
         return await self._ctx.execute_sync(self, "start", _args)
 
     async def stop(self, *, kill: bool | None = False) -> Self:
         """Stop the service.
 
+        A persistent service can only be stopped by the main client.
+
         Parameters
         ----------
         kill:
@@ -12431,6 +14833,21 @@ class Service(Type):
         _ctx = self._select("withHostname", _args)
         return Service(_ctx)
 
+    def with_name(self, name: str) -> Self:
+        """Configures a name for the service, under which it can be started as a
+        persistent service shared across sessions.
+
+        Parameters
+        ----------
+        name:
+            The name to use.
+        """
+        _args = [
+            Arg("name", name),
+        ]
+        _ctx = self._select("withName", _args)
+        return Service(_ctx)
+
     def with_(self, cb: Callable[["Service"], "Service"]) -> "Service":
         """Call the provided callable with current Service.
 
@@ -12813,6 +15230,14 @@ class TypeDef(Type):
         _ctx = self._select("asScalar", _args)
         return ScalarTypeDef(_ctx)
 
+    def as_union(self) -> "UnionTypeDef":
+        """If kind is UNION, the union-specific type definition. If kind is not
+        UNION, this will be null.
+        """
+        _args: list[Arg] = []
+        _ctx = self._select("asUnion", _args)
+        return UnionTypeDef(_ctx)
+
     async def id(self) -> TypeDefID:
         """A unique identifier for this TypeDef.
 
@@ -13113,6 +15538,50 @@ class TypeDef(Type):
         _ctx = self._select("withScalar", _args)
         return TypeDef(_ctx)
 
+    def with_union(
+        self,
+        name: str,
+        *,
+        description: str | None = "",
+        source_map: SourceMap | None = None,
+    ) -> Self:
+        """Returns a TypeDef of kind Union with the provided name.
+
+        Note that a union's members may be omitted if the intent is only to
+        refer to a union.
+
+        Parameters
+        ----------
+        name:
+            The name of the union
+        description:
+            A doc string for the union, if any
+        source_map:
+            The source map for the union definition.
+        """
+        _args = [
+            Arg("name", name),
+            Arg("description", description, ""),
+            Arg("sourceMap", source_map, None),
+        ]
+        _ctx = self._select("withUnion", _args)
+        return TypeDef(_ctx)
+
+    def with_union_member(self, member: Self) -> Self:
+        """Adds a member type to a Union TypeDef, failing if the type is not a
+        union or the member is not an object.
+
+        Parameters
+        ----------
+        member:
+            The object type to add to the union
+        """
+        _args = [
+            Arg("member", member),
+        ]
+        _ctx = self._select("withUnionMember", _args)
+        return TypeDef(_ctx)
+
     def with_(self, cb: Callable[["TypeDef"], "TypeDef"]) -> "TypeDef":
         """Call the provided callable with current TypeDef.
 
@@ -13121,6 +15590,112 @@ class TypeDef(Type):
         return cb(self)
 
 
+@typecheck
+class UnionTypeDef(Type):
+    """A definition of a custom union defined in a Module.  A value of a
+    union has exactly one of the union's member types."""
+
+    async def description(self) -> str:
+        """The doc string for the union, if any.
+
+        Returns
+        -------
+        str
+            The `String` scalar type represents textual data, represented as
+            UTF-8 character sequences. The String type is most often used by
+            GraphQL to represent free-form human-readable text.
+
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
+        """
+        _args: list[Arg] = []
+        _ctx = self._select("description", _args)
+        return await _ctx.execute(str)
+
+    async def id(self) -> UnionTypeDefID:
+        """A unique identifier for this UnionTypeDef.
+
+        Note
+        ----
+        This is lazily evaluated, no operation is actually run.
+
+        Returns
+        -------
+        UnionTypeDefID
+            The `UnionTypeDefID` scalar type represents an identifier for an
+            object of type UnionTypeDef.
+
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
+        """
+        _args: list[Arg] = []
+        _ctx = self._select("id", _args)
+        return await _ctx.execute(UnionTypeDefID)
+
+    async def members(self) -> list[TypeDef]:
+        """The types a value of this union can have. They are always objects."""
+        _args: list[Arg] = []
+        _ctx = self._select("members", _args)
+        return await _ctx.execute_object_list(TypeDef)
+
+    async def name(self) -> str:
+        """The name of the union.
+
+        Returns
+        -------
+        str
+            The `String` scalar type represents textual data, represented as
+            UTF-8 character sequences. The String type is most often used by
+            GraphQL to represent free-form human-readable text.
+
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
+        """
+        _args: list[Arg] = []
+        _ctx = self._select("name", _args)
+        return await _ctx.execute(str)
+
+    def source_map(self) -> SourceMap:
+        """The location of this union declaration."""
+        _args: list[Arg] = []
+        _ctx = self._select("sourceMap", _args)
+        return SourceMap(_ctx)
+
+    async def source_module_name(self) -> str:
+        """If this UnionTypeDef is associated with a Module, the name of the
+        module. Unset otherwise.
+
+        Returns
+        -------
+        str
+            The `String` scalar type represents textual data, represented as
+            UTF-8 character sequences. The String type is most often used by
+            GraphQL to represent free-form human-readable text.
+
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
+        """
+        _args: list[Arg] = []
+        _ctx = self._select("sourceModuleName", _args)
+        return await _ctx.execute(str)
+
+
 dag = Client()
 """The global client instance."""
 
@@ -13146,7 +15721,14 @@ __all__ = [
     "Cloud",
     "CloudID",
     "Container",
+    "ContainerChangeKind",
+    "ContainerComparison",
+    "ContainerComparisonID",
+    "ContainerConfigChange",
+    "ContainerConfigChangeID",
     "ContainerID",
+    "ContainerLayerComparison",
+    "ContainerLayerComparisonID",
     "CurrentModule",
     "CurrentModuleID",
     "Directory",
@@ -13159,6 +15741,10 @@ __all__ = [
     "EngineCacheEntrySetID",
     "EngineCacheID",
     "EngineID",
+    "EngineService",
+    "EngineServiceID",
+    "EngineSession",
+    "EngineSessionID",
     "EnumTypeDef",
     "EnumTypeDefID",
     "EnumValueTypeDef",
@@ -13181,6 +15767,8 @@ __all__ = [
     "FileType",
     "Function",
     "FunctionArg",
+    "FunctionArgConstraints",
+    "FunctionArgConstraintsID",
     "FunctionArgID",
     "FunctionCachePolicy",
     "FunctionCall",
@@ -13198,6 +15786,7 @@ __all__ = [
     "HostID",
     "ImageLayerCompression",
     "ImageMediaTypes",
+    "ImageSquashMode",
     "InputTypeDef",
     "InputTypeDefID",
     "InterfaceTypeDef",
@@ -13206,11 +15795,14 @@ __all__ = [
     "JSONValueID",
     "LLMTokenUsage",
     "LLMTokenUsageID",
+    "LLMToolPolicy",
     "Label",
     "LabelID",
     "ListTypeDef",
     "ListTypeDefID",
     "Module",
+    "ModuleCapabilities",
+    "ModuleCapabilitiesID",
     "ModuleConfigClient",
     "ModuleConfigClientID",
     "ModuleID",
@@ -13218,6 +15810,7 @@ __all__ = [
     "ModuleSourceExperimentalFeature",
     "ModuleSourceID",
     "ModuleSourceKind",
+    "NetworkMode",
     "NetworkProtocol",
     "ObjectTypeDef",
     "ObjectTypeDefID",
@@ -13227,6 +15820,7 @@ __all__ = [
     "PortForward",
     "PortID",
     "ReturnType",
+    "SBOMFormat",
     "SDKConfig",
     "SDKConfigID",
     "ScalarTypeDef",
@@ -13250,6 +15844,8 @@ __all__ = [
     "TypeDef",
     "TypeDefID",
     "TypeDefKind",
+    "UnionTypeDef",
+    "UnionTypeDefID",
     "Void",
     "dag",
 ]
@@ -1,5 +1,6 @@
 import dataclasses
 import inspect
+import json
 import logging
 
 from cattrs.preconf.json import JsonConverter
@@ -99,6 +100,80 @@ class Deprecated:
         return self.reason
 
 
+@dataclasses.dataclass(slots=True, frozen=True, kw_only=True)
+class Constraints:
+    """Constraints on the value of a function argument.
+
+    The engine checks them before calling the function, so it doesn't need to
+    validate the argument by hand.
+
+    Example usage::
+
+        @function
+        def release(
+            self,
+            version: Annotated[str, Constraints(pattern=r"v[0-9]+")],
+            platforms: Annotated[list[str], Constraints(non_empty=True)],
+            replicas: Annotated[int, Constraints(min=1, max=100)] = 1,
+        ): ...
+
+
+        @function
+        def build(
+            self, src: Annotated[dagger.Directory, Constraints(required_paths=["go.mod"])]
+        ): ...
+    """
+
+    min: int | float | None = None
+    """Only applies to numbers. The minimum value, inclusive."""
+
+    max: int | float | None = None
+    """Only applies to numbers. The maximum value, inclusive."""
+
+    pattern: str = ""
+    """Only applies to strings. A regular expression (RE2 syntax) that the
+    whole value must match."""
+
+    min_length: int = 0
+    """Only applies to strings and lists. The minimum length of the value."""
+
+    max_length: int = 0
+    """Only applies to strings and lists. The maximum length of the value."""
+
+    non_empty: bool = False
+    """Only applies to lists. Whether the list must contain at least one
+    element."""
+
+    required_paths: list[str] | None = None
+    """Only applies to :py:class:`dagger.Directory`. Paths that must exist in
+    the directory."""
+
+    # See Ignore about why this is needed with a list.
+    def __hash__(self) -> int:
+        return hash(
+            (
+                self.min,
+                self.max,
+                self.pattern,
+                self.min_length,
+                self.max_length,
+                self.non_empty,
+                tuple(self.required_paths or ()),
+            )
+        )
+
+    def _json_number(self, value: float | None) -> dagger.JSON | None:
+        return None if value is None else dagger.JSON(json.dumps(value))
+
+    @property
+    def min_json(self) -> dagger.JSON | None:
+        return self._json_number(self.min)
+
+    @property
+    def max_json(self) -> dagger.JSON | None:
+        return self._json_number(self.max)
+
+
 @dataclasses.dataclass(slots=True, kw_only=True)
 class Parameter:
     """Parameter from function signature in :py:class:`FunctionResolver`."""
@@ -116,6 +191,7 @@ class Parameter:
     default_path: ContextPath | None = None
     default_value: dagger.JSON | None = None
     deprecated: str | None = None
+    constraints: Constraints | None = None
 
     conv: dataclasses.InitVar[JsonConverter]
 
@@ -19,6 +19,7 @@ from typing_extensions import dataclass_transform, overload
 import dagger
 from dagger import dag
 from dagger.client._core import configure_converter_enum
+from dagger.mod._arguments import Constraints
 from dagger.mod._converter import make_converter, to_typedef
 from dagger.mod._exceptions import (
     BadUsageError,
@@ -218,6 +219,7 @@ class Module:
                     if param.is_nullable:
                         arg_def = arg_def.with_optional(True)
 
+                    constraints = param.constraints or Constraints()
                     func_def = func_def.with_arg(
                         param.name,
                         arg_def,
@@ -226,6 +228,13 @@ class Module:
                         default_path=param.default_path,
                         ignore=param.ignore,
                         deprecated=param.deprecated,
+                        min=constraints.min_json,
+                        max=constraints.max_json,
+                        pattern=constraints.pattern,
+                        min_length=constraints.min_length,
+                        max_length=constraints.max_length,
+                        non_empty=constraints.non_empty,
+                        required_paths=constraints.required_paths,
                     )
 
                 type_def = (
@@ -27,6 +27,7 @@ from dagger.mod._types import APIName, FieldDefinition, FunctionDefinition, Pyth
 from dagger.mod._utils import (
     get_alt_constructor,
     get_alt_name,
+    get_constraints,
     get_default_path,
     get_deprecated,
     get_doc,
@@ -158,6 +159,7 @@ class Function(Generic[P, R]):
             ignore=get_ignore(param.annotation),
             default_path=get_default_path(param.annotation),
             deprecated=get_deprecated(param.annotation),
+            constraints=get_constraints(param.annotation),
             conv=self.converter,
         )
 
@@ -21,7 +21,7 @@ from cattrs.cols import is_sequence
 from graphql.pyutils import snake_to_camel
 
 from dagger.client.base import Type
-from dagger.mod._arguments import DefaultPath, Deprecated, Ignore, Name
+from dagger.mod._arguments import Constraints, DefaultPath, Deprecated, Ignore, Name
 from dagger.mod._types import ContextPath
 
 asyncify = anyio.to_thread.run_sync
@@ -120,6 +120,11 @@ def get_default_path(obj: Any) -> ContextPath | None:
     return meta.from_context if meta else None
 
 
+def get_constraints(obj: Any) -> Constraints | None:
+    """Get the last Constraints() of an annotated type."""
+    return get_meta(obj, Constraints)
+
+
 def get_alt_name(annotation: type) -> str | None:
     """Get an alternative name in last Name() of an annotated type."""
     return annotated.name if (annotated := get_meta(annotation, Name)) else None
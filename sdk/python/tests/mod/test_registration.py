@@ -140,6 +140,27 @@ def test_function_argument_deprecated_metadata():
         _ = mod.get_object("Foo").functions["legacy"].parameters
 
 
+def test_function_argument_constraints():
+    mod = Module()
+
+    @mod.object_type
+    class Foo:
+        @mod.function
+        def release(
+            self,
+            version: Annotated[str, dagger.Constraints(pattern=r"v[0-9]+")],
+            replicas: Annotated[int, dagger.Constraints(min=1, max=100)] = 1,
+        ) -> str:
+            return version
+
+    params = mod.get_object("Foo").functions["release"].parameters
+    assert params["version"].constraints == dagger.Constraints(pattern=r"v[0-9]+")
+    replicas = params["replicas"].constraints
+    assert replicas is not None
+    assert replicas.min_json == "1"
+    assert replicas.max_json == "100"
+
+
 def test_field_deprecated_metadata():
     mod = Module()
 
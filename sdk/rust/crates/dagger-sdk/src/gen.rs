@@ -247,6 +247,76 @@ impl CloudId {
     }
 }
 #[derive(Serialize, Deserialize, PartialEq, Debug, Clone)]
+pub struct ContainerComparisonId(pub String);
+impl From<&str> for ContainerComparisonId {
+    fn from(value: &str) -> Self {
+        Self(value.to_string())
+    }
+}
+impl From<String> for ContainerComparisonId {
+    fn from(value: String) -> Self {
+        Self(value)
+    }
+}
+impl IntoID<ContainerComparisonId> for ContainerComparison {
+    fn into_id(
+        self,
+    ) -> std::pin::Pin<
+        Box<dyn core::future::Future<Output = Result<ContainerComparisonId, DaggerError>> + Send>,
+    > {
+        Box::pin(async move { self.id().await })
+    }
+}
+impl IntoID<ContainerComparisonId> for ContainerComparisonId {
+    fn into_id(
+        self,
+    ) -> std::pin::Pin<
+        Box<dyn core::future::Future<Output = Result<ContainerComparisonId, DaggerError>> + Send>,
+    > {
+        Box::pin(async move { Ok::<ContainerComparisonId, DaggerError>(self) })
+    }
+}
+impl ContainerComparisonId {
+    fn quote(&self) -> String {
+        format!("\"{}\"", self.0.clone())
+    }
+}
+#[derive(Serialize, Deserialize, PartialEq, Debug, Clone)]
+pub struct ContainerConfigChangeId(pub String);
+impl From<&str> for ContainerConfigChangeId {
+    fn from(value: &str) -> Self {
+        Self(value.to_string())
+    }
+}
+impl From<String> for ContainerConfigChangeId {
+    fn from(value: String) -> Self {
+        Self(value)
+    }
+}
+impl IntoID<ContainerConfigChangeId> for ContainerConfigChange {
+    fn into_id(
+        self,
+    ) -> std::pin::Pin<
+        Box<dyn core::future::Future<Output = Result<ContainerConfigChangeId, DaggerError>> + Send>,
+    > {
+        Box::pin(async move { self.id().await })
+    }
+}
+impl IntoID<ContainerConfigChangeId> for ContainerConfigChangeId {
+    fn into_id(
+        self,
+    ) -> std::pin::Pin<
+        Box<dyn core::future::Future<Output = Result<ContainerConfigChangeId, DaggerError>> + Send>,
+    > {
+        Box::pin(async move { Ok::<ContainerConfigChangeId, DaggerError>(self) })
+    }
+}
+impl ContainerConfigChangeId {
+    fn quote(&self) -> String {
+        format!("\"{}\"", self.0.clone())
+    }
+}
+#[derive(Serialize, Deserialize, PartialEq, Debug, Clone)]
 pub struct ContainerId(pub String);
 impl From<&str> for ContainerId {
     fn from(value: &str) -> Self {
@@ -282,6 +352,47 @@ impl ContainerId {
     }
 }
 #[derive(Serialize, Deserialize, PartialEq, Debug, Clone)]
+pub struct ContainerLayerComparisonId(pub String);
+impl From<&str> for ContainerLayerComparisonId {
+    fn from(value: &str) -> Self {
+        Self(value.to_string())
+    }
+}
+impl From<String> for ContainerLayerComparisonId {
+    fn from(value: String) -> Self {
+        Self(value)
+    }
+}
+impl IntoID<ContainerLayerComparisonId> for ContainerLayerComparison {
+    fn into_id(
+        self,
+    ) -> std::pin::Pin<
+        Box<
+            dyn core::future::Future<Output = Result<ContainerLayerComparisonId, DaggerError>>
+                + Send,
+        >,
+    > {
+        Box::pin(async move { self.id().await })
+    }
+}
+impl IntoID<ContainerLayerComparisonId> for ContainerLayerComparisonId {
+    fn into_id(
+        self,
+    ) -> std::pin::Pin<
+        Box<
+            dyn core::future::Future<Output = Result<ContainerLayerComparisonId, DaggerError>>
+                + Send,
+        >,
+    > {
+        Box::pin(async move { Ok::<ContainerLayerComparisonId, DaggerError>(self) })
+    }
+}
+impl ContainerLayerComparisonId {
+    fn quote(&self) -> String {
+        format!("\"{}\"", self.0.clone())
+    }
+}
+#[derive(Serialize, Deserialize, PartialEq, Debug, Clone)]
 pub struct CurrentModuleId(pub String);
 impl From<&str> for CurrentModuleId {
     fn from(value: &str) -> Self {
@@ -490,6 +601,76 @@ impl EngineId {
     }
 }
 #[derive(Serialize, Deserialize, PartialEq, Debug, Clone)]
+pub struct EngineServiceId(pub String);
+impl From<&str> for EngineServiceId {
+    fn from(value: &str) -> Self {
+        Self(value.to_string())
+    }
+}
+impl From<String> for EngineServiceId {
+    fn from(value: String) -> Self {
+        Self(value)
+    }
+}
+impl IntoID<EngineServiceId> for EngineService {
+    fn into_id(
+        self,
+    ) -> std::pin::Pin<
+        Box<dyn core::future::Future<Output = Result<EngineServiceId, DaggerError>> + Send>,
+    > {
+        Box::pin(async move { self.id().await })
+    }
+}
+impl IntoID<EngineServiceId> for EngineServiceId {
+    fn into_id(
+        self,
+    ) -> std::pin::Pin<
+        Box<dyn core::future::Future<Output = Result<EngineServiceId, DaggerError>> + Send>,
+    > {
+        Box::pin(async move { Ok::<EngineServiceId, DaggerError>(self) })
+    }
+}
+impl EngineServiceId {
+    fn quote(&self) -> String {
+        format!("\"{}\"", self.0.clone())
+    }
+}
+#[derive(Serialize, Deserialize, PartialEq, Debug, Clone)]
+pub struct EngineSessionId(pub String);
+impl From<&str> for EngineSessionId {
+    fn from(value: &str) -> Self {
+        Self(value.to_string())
+    }
+}
+impl From<String> for EngineSessionId {
+    fn from(value: String) -> Self {
+        Self(value)
+    }
+}
+impl IntoID<EngineSessionId> for EngineSession {
+    fn into_id(
+        self,
+    ) -> std::pin::Pin<
+        Box<dyn core::future::Future<Output = Result<EngineSessionId, DaggerError>> + Send>,
+    > {
+        Box::pin(async move { self.id().await })
+    }
+}
+impl IntoID<EngineSessionId> for EngineSessionId {
+    fn into_id(
+        self,
+    ) -> std::pin::Pin<
+        Box<dyn core::future::Future<Output = Result<EngineSessionId, DaggerError>> + Send>,
+    > {
+        Box::pin(async move { Ok::<EngineSessionId, DaggerError>(self) })
+    }
+}
+impl EngineSessionId {
+    fn quote(&self) -> String {
+        format!("\"{}\"", self.0.clone())
+    }
+}
+#[derive(Serialize, Deserialize, PartialEq, Debug, Clone)]
 pub struct EnumTypeDefId(pub String);
 impl From<&str> for EnumTypeDefId {
     fn from(value: &str) -> Self {
@@ -797,6 +978,45 @@ impl FileId {
     }
 }
 #[derive(Serialize, Deserialize, PartialEq, Debug, Clone)]
+pub struct FunctionArgConstraintsId(pub String);
+impl From<&str> for FunctionArgConstraintsId {
+    fn from(value: &str) -> Self {
+        Self(value.to_string())
+    }
+}
+impl From<String> for FunctionArgConstraintsId {
+    fn from(value: String) -> Self {
+        Self(value)
+    }
+}
+impl IntoID<FunctionArgConstraintsId> for FunctionArgConstraints {
+    fn into_id(
+        self,
+    ) -> std::pin::Pin<
+        Box<
+            dyn core::future::Future<Output = Result<FunctionArgConstraintsId, DaggerError>> + Send,
+        >,
+    > {
+        Box::pin(async move { self.id().await })
+    }
+}
+impl IntoID<FunctionArgConstraintsId> for FunctionArgConstraintsId {
+    fn into_id(
+        self,
+    ) -> std::pin::Pin<
+        Box<
+            dyn core::future::Future<Output = Result<FunctionArgConstraintsId, DaggerError>> + Send,
+        >,
+    > {
+        Box::pin(async move { Ok::<FunctionArgConstraintsId, DaggerError>(self) })
+    }
+}
+impl FunctionArgConstraintsId {
+    fn quote(&self) -> String {
+        format!("\"{}\"", self.0.clone())
+    }
+}
+#[derive(Serialize, Deserialize, PartialEq, Debug, Clone)]
 pub struct FunctionArgId(pub String);
 impl From<&str> for FunctionArgId {
     fn from(value: &str) -> Self {
@@ -1329,6 +1549,41 @@ impl ListTypeDefId {
     }
 }
 #[derive(Serialize, Deserialize, PartialEq, Debug, Clone)]
+pub struct ModuleCapabilitiesId(pub String);
+impl From<&str> for ModuleCapabilitiesId {
+    fn from(value: &str) -> Self {
+        Self(value.to_string())
+    }
+}
+impl From<String> for ModuleCapabilitiesId {
+    fn from(value: String) -> Self {
+        Self(value)
+    }
+}
+impl IntoID<ModuleCapabilitiesId> for ModuleCapabilities {
+    fn into_id(
+        self,
+    ) -> std::pin::Pin<
+        Box<dyn core::future::Future<Output = Result<ModuleCapabilitiesId, DaggerError>> + Send>,
+    > {
+        Box::pin(async move { self.id().await })
+    }
+}
+impl IntoID<ModuleCapabilitiesId> for ModuleCapabilitiesId {
+    fn into_id(
+        self,
+    ) -> std::pin::Pin<
+        Box<dyn core::future::Future<Output = Result<ModuleCapabilitiesId, DaggerError>> + Send>,
+    > {
+        Box::pin(async move { Ok::<ModuleCapabilitiesId, DaggerError>(self) })
+    }
+}
+impl ModuleCapabilitiesId {
+    fn quote(&self) -> String {
+        format!("\"{}\"", self.0.clone())
+    }
+}
+#[derive(Serialize, Deserialize, PartialEq, Debug, Clone)]
 pub struct ModuleConfigClientId(pub String);
 impl From<&str> for ModuleConfigClientId {
     fn from(value: &str) -> Self {
@@ -1890,6 +2145,41 @@ impl TypeDefId {
     }
 }
 #[derive(Serialize, Deserialize, PartialEq, Debug, Clone)]
+pub struct UnionTypeDefId(pub String);
+impl From<&str> for UnionTypeDefId {
+    fn from(value: &str) -> Self {
+        Self(value.to_string())
+    }
+}
+impl From<String> for UnionTypeDefId {
+    fn from(value: String) -> Self {
+        Self(value)
+    }
+}
+impl IntoID<UnionTypeDefId> for UnionTypeDef {
+    fn into_id(
+        self,
+    ) -> std::pin::Pin<
+        Box<dyn core::future::Future<Output = Result<UnionTypeDefId, DaggerError>> + Send>,
+    > {
+        Box::pin(async move { self.id().await })
+    }
+}
+impl IntoID<UnionTypeDefId> for UnionTypeDefId {
+    fn into_id(
+        self,
+    ) -> std::pin::Pin<
+        Box<dyn core::future::Future<Output = Result<UnionTypeDefId, DaggerError>> + Send>,
+    > {
+        Box::pin(async move { Ok::<UnionTypeDefId, DaggerError>(self) })
+    }
+}
+impl UnionTypeDefId {
+    fn quote(&self) -> String {
+        format!("\"{}\"", self.0.clone())
+    }
+}
+#[derive(Serialize, Deserialize, PartialEq, Debug, Clone)]
 pub struct Void(pub String);
 impl From<&str> for Void {
     fn from(value: &str) -> Self {
@@ -2162,6 +2452,33 @@ impl Binding {
             graphql_client: self.graphql_client.clone(),
         }
     }
+    /// Retrieve the binding value, as type ContainerComparison
+    pub fn as_container_comparison(&self) -> ContainerComparison {
+        let query = self.selection.select("asContainerComparison");
+        ContainerComparison {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
+    /// Retrieve the binding value, as type ContainerConfigChange
+    pub fn as_container_config_change(&self) -> ContainerConfigChange {
+        let query = self.selection.select("asContainerConfigChange");
+        ContainerConfigChange {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
+    /// Retrieve the binding value, as type ContainerLayerComparison
+    pub fn as_container_layer_comparison(&self) -> ContainerLayerComparison {
+        let query = self.selection.select("asContainerLayerComparison");
+        ContainerLayerComparison {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
     /// Retrieve the binding value, as type Directory
     pub fn as_directory(&self) -> Directory {
         let query = self.selection.select("asDirectory");
@@ -2171,6 +2488,24 @@ impl Binding {
             graphql_client: self.graphql_client.clone(),
         }
     }
+    /// Retrieve the binding value, as type EngineService
+    pub fn as_engine_service(&self) -> EngineService {
+        let query = self.selection.select("asEngineService");
+        EngineService {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
+    /// Retrieve the binding value, as type EngineSession
+    pub fn as_engine_session(&self) -> EngineSession {
+        let query = self.selection.select("asEngineSession");
+        EngineSession {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
     /// Retrieve the binding value, as type Env
     pub fn as_env(&self) -> Env {
         let query = self.selection.select("asEnv");
@@ -2198,6 +2533,15 @@ impl Binding {
             graphql_client: self.graphql_client.clone(),
         }
     }
+    /// Retrieve the binding value, as type FunctionArgConstraints
+    pub fn as_function_arg_constraints(&self) -> FunctionArgConstraints {
+        let query = self.selection.select("asFunctionArgConstraints");
+        FunctionArgConstraints {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
     /// Retrieve the binding value, as type GitRef
     pub fn as_git_ref(&self) -> GitRef {
         let query = self.selection.select("asGitRef");
@@ -2234,6 +2578,15 @@ impl Binding {
             graphql_client: self.graphql_client.clone(),
         }
     }
+    /// Retrieve the binding value, as type ModuleCapabilities
+    pub fn as_module_capabilities(&self) -> ModuleCapabilities {
+        let query = self.selection.select("asModuleCapabilities");
+        ModuleCapabilities {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
     /// Retrieve the binding value, as type ModuleConfigClient
     pub fn as_module_config_client(&self) -> ModuleConfigClient {
         let query = self.selection.select("asModuleConfigClient");
@@ -2311,6 +2664,15 @@ impl Binding {
         let query = self.selection.select("asString");
         query.execute(self.graphql_client.clone()).await
     }
+    /// Retrieve the binding value, as type UnionTypeDef
+    pub fn as_union_type_def(&self) -> UnionTypeDef {
+        let query = self.selection.select("asUnionTypeDef");
+        UnionTypeDef {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
     /// Returns the digest of the binding value
     pub async fn digest(&self) -> Result<String, DaggerError> {
         let query = self.selection.select("digest");
@@ -2349,6 +2711,17 @@ impl CacheVolume {
         let query = self.selection.select("id");
         query.execute(self.graphql_client.clone()).await
     }
+    /// Pin the contents of this cache volume, so that they're never pruned by garbage collection.
+    /// Contents written to the volume later are pinned too, until it's unpinned. Only the main client can pin a cache volume.
+    pub async fn pin(&self) -> Result<Void, DaggerError> {
+        let query = self.selection.select("pin");
+        query.execute(self.graphql_client.clone()).await
+    }
+    /// Unpin the contents of this cache volume, so that they can be pruned by garbage collection again.
+    pub async fn unpin(&self) -> Result<Void, DaggerError> {
+        let query = self.selection.select("unpin");
+        query.execute(self.graphql_client.clone()).await
+    }
 }
 #[derive(Clone)]
 pub struct Changeset {
@@ -2433,9 +2806,30 @@ impl Changeset {
         let query = self.selection.select("sync");
         query.execute(self.graphql_client.clone()).await
     }
-}
-#[derive(Clone)]
-pub struct Check {
+    /// Add changes to this changeset, if they don't conflict with it.
+    /// Both changesets must compare against the same directory. The changes conflict if a file is changed by both, or changed by one and removed by the other.
+    ///
+    /// # Arguments
+    ///
+    /// * `changes` - The changes to add.
+    pub fn with_changeset(&self, changes: impl IntoID<ChangesetId>) -> Changeset {
+        let mut query = self.selection.select("withChangeset");
+        query = query.arg_lazy(
+            "changes",
+            Box::new(move || {
+                let changes = changes.clone();
+                Box::pin(async move { changes.into_id().await.unwrap().quote() })
+            }),
+        );
+        Changeset {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
+}
+#[derive(Clone)]
+pub struct Check {
     pub proc: Option<Arc<DaggerSessionProc>>,
     pub selection: Selection,
     pub graphql_client: DynGraphQLClient,
@@ -2556,6 +2950,10 @@ pub struct ContainerAsServiceOpts<'a> {
     /// If empty, the container's default command is used.
     #[builder(setter(into, strip_option), default)]
     pub args: Option<Vec<&'a str>>,
+    /// Hostnames and CIDRs the service may connect to, on top of what its network mode allows (e.g., ["proxy.golang.org", "10.0.0.0/8"]).
+    /// Hostnames are resolved when the service starts. Requires a networkMode of NONE or SERVICES_ONLY.
+    #[builder(setter(into, strip_option), default)]
+    pub egress_allowlist: Option<Vec<&'a str>>,
     /// Replace "${VAR}" or "$VAR" in the args according to the current environment variables defined in the container (e.g. "/$VAR/foo").
     #[builder(setter(into, strip_option), default)]
     pub expand: Option<bool>,
@@ -2565,6 +2963,10 @@ pub struct ContainerAsServiceOpts<'a> {
     /// Execute the command with all root capabilities. This is similar to running a command with "sudo" or executing "docker run" with the "--privileged" flag. Containerization does not provide any security guarantees when using this option. It should only be used when absolutely necessary and only with trusted commands.
     #[builder(setter(into, strip_option), default)]
     pub insecure_root_capabilities: Option<bool>,
+    /// Restrict the network access of the service.
+    /// Blocked connection attempts are reported as events on the service's span.
+    #[builder(setter(into, strip_option), default)]
+    pub network_mode: Option<NetworkMode>,
     /// If set, skip the automatic init process injected into containers by default.
     /// This should only be used if the user requires that their exec process be the pid 1 process in the container. Otherwise it may result in unexpected behavior.
     #[builder(setter(into, strip_option), default)]
@@ -2620,6 +3022,23 @@ pub struct ContainerExportOpts {
     /// Used for multi-platform image.
     #[builder(setter(into, strip_option), default)]
     pub platform_variants: Option<Vec<ContainerId>>,
+    /// Attach a SLSA provenance attestation describing the calls that built the image.
+    #[builder(setter(into, strip_option), default)]
+    pub provenance: Option<bool>,
+    /// Attach a software bill of materials (SBOM) attestation in the given format, as returned by "sbom".
+    #[builder(setter(into, strip_option), default)]
+    pub sbom: Option<SbomFormat>,
+    /// Private key to sign the image with, in PEM format.
+    /// Both keys generated by "cosign generate-key-pair" and unencrypted PKCS#8, EC and RSA keys are supported. The signature, and the provenance attestation if requested, are attached to the image as OCI referrers in the sigstore bundle format.
+    #[builder(setter(into, strip_option), default)]
+    pub signing_key: Option<SecretId>,
+    /// Password of the signing key, if it's encrypted.
+    #[builder(setter(into, strip_option), default)]
+    pub signing_key_password: Option<SecretId>,
+    /// Squash the layers of the exported image.
+    /// By default, the changes between each layer boundary set with "withLayerBoundary" are grouped into a single layer, and each operation makes its own layer if there are no boundaries.
+    #[builder(setter(into, strip_option), default)]
+    pub squash: Option<ImageSquashMode>,
 }
 #[derive(Builder, Debug, PartialEq)]
 pub struct ContainerExportImageOpts {
@@ -2662,6 +3081,29 @@ pub struct ContainerPublishOpts {
     /// Used for multi-platform image.
     #[builder(setter(into, strip_option), default)]
     pub platform_variants: Option<Vec<ContainerId>>,
+    /// Attach a SLSA provenance attestation describing the calls that built the image.
+    #[builder(setter(into, strip_option), default)]
+    pub provenance: Option<bool>,
+    /// Attach a software bill of materials (SBOM) attestation in the given format, as returned by "sbom".
+    #[builder(setter(into, strip_option), default)]
+    pub sbom: Option<SbomFormat>,
+    /// Private key to sign the image with, in PEM format.
+    /// Both keys generated by "cosign generate-key-pair" and unencrypted PKCS#8, EC and RSA keys are supported. The signature, and the provenance attestation if requested, are attached to the image as OCI referrers in the sigstore bundle format.
+    #[builder(setter(into, strip_option), default)]
+    pub signing_key: Option<SecretId>,
+    /// Password of the signing key, if it's encrypted.
+    #[builder(setter(into, strip_option), default)]
+    pub signing_key_password: Option<SecretId>,
+    /// Squash the layers of the published image.
+    /// By default, the changes between each layer boundary set with "withLayerBoundary" are grouped into a single layer, and each operation makes its own layer if there are no boundaries.
+    #[builder(setter(into, strip_option), default)]
+    pub squash: Option<ImageSquashMode>,
+}
+#[derive(Builder, Debug, PartialEq)]
+pub struct ContainerSbomOpts {
+    /// Format of the SBOM.
+    #[builder(setter(into, strip_option), default)]
+    pub format: Option<SbomFormat>,
 }
 #[derive(Builder, Debug, PartialEq)]
 pub struct ContainerStatOpts {
@@ -2687,6 +3129,10 @@ pub struct ContainerUpOpts<'a> {
     /// If empty, the container's default command is used.
     #[builder(setter(into, strip_option), default)]
     pub args: Option<Vec<&'a str>>,
+    /// Hostnames and CIDRs the service may connect to, on top of what its network mode allows (e.g., ["proxy.golang.org", "10.0.0.0/8"]).
+    /// Hostnames are resolved when the service starts. Requires a networkMode of NONE or SERVICES_ONLY.
+    #[builder(setter(into, strip_option), default)]
+    pub egress_allowlist: Option<Vec<&'a str>>,
     /// Replace "${VAR}" or "$VAR" in the args according to the current environment variables defined in the container (e.g. "/$VAR/foo").
     #[builder(setter(into, strip_option), default)]
     pub expand: Option<bool>,
@@ -2696,6 +3142,10 @@ pub struct ContainerUpOpts<'a> {
     /// Execute the command with all root capabilities. This is similar to running a command with "sudo" or executing "docker run" with the "--privileged" flag. Containerization does not provide any security guarantees when using this option. It should only be used when absolutely necessary and only with trusted commands.
     #[builder(setter(into, strip_option), default)]
     pub insecure_root_capabilities: Option<bool>,
+    /// Restrict the network access of the service.
+    /// Blocked connection attempts are reported as events on the service's span.
+    #[builder(setter(into, strip_option), default)]
+    pub network_mode: Option<NetworkMode>,
     /// If set, skip the automatic init process injected into containers by default.
     /// This should only be used if the user requires that their exec process be the pid 1 process in the container. Otherwise it may result in unexpected behavior.
     #[builder(setter(into, strip_option), default)]
@@ -2754,6 +3204,10 @@ pub struct ContainerWithEnvVariableOpts {
 }
 #[derive(Builder, Debug, PartialEq)]
 pub struct ContainerWithExecOpts<'a> {
+    /// Hostnames and CIDRs the command may connect to, on top of what its network mode allows (e.g., ["proxy.golang.org", "10.0.0.0/8"]).
+    /// Hostnames are resolved when the command starts. Requires a networkMode of NONE or SERVICES_ONLY.
+    #[builder(setter(into, strip_option), default)]
+    pub egress_allowlist: Option<Vec<&'a str>>,
     /// Replace "${VAR}" or "$VAR" in the args according to the current environment variables defined in the container (e.g. "/$VAR/foo").
     #[builder(setter(into, strip_option), default)]
     pub expand: Option<bool>,
@@ -2767,6 +3221,10 @@ pub struct ContainerWithExecOpts<'a> {
     /// DANGER: this grants the command full access to the host system. Only use when 1) you trust the command being executed and 2) you specifically need this level of access.
     #[builder(setter(into, strip_option), default)]
     pub insecure_root_capabilities: Option<bool>,
+    /// Restrict the network access of the command.
+    /// Blocked connection attempts are reported as events on the command's span.
+    #[builder(setter(into, strip_option), default)]
+    pub network_mode: Option<NetworkMode>,
     /// Skip the automatic init process injected into containers by default.
     /// Only use this if you specifically need the command to be pid 1 in the container. Otherwise it may result in unexpected behavior. If you're not sure, you don't need this.
     #[builder(setter(into, strip_option), default)]
@@ -3014,6 +3472,12 @@ impl Container {
         if let Some(no_init) = opts.no_init {
             query = query.arg("noInit", no_init);
         }
+        if let Some(network_mode) = opts.network_mode {
+            query = query.arg("networkMode", network_mode);
+        }
+        if let Some(egress_allowlist) = opts.egress_allowlist {
+            query = query.arg("egressAllowlist", egress_allowlist);
+        }
         Service {
             proc: self.proc.clone(),
             selection: query,
@@ -3061,6 +3525,27 @@ impl Container {
         let query = self.selection.select("combinedOutput");
         query.execute(self.graphql_client.clone()).await
     }
+    /// Compare this container's image configuration, layers and root filesystem with another container, typically a newer version of it.
+    /// Layers that haven't been exported yet are compressed to compute their digest and size.
+    ///
+    /// # Arguments
+    ///
+    /// * `other` - The newer container to compare against.
+    pub fn compare(&self, other: impl IntoID<ContainerId>) -> ContainerComparison {
+        let mut query = self.selection.select("compare");
+        query = query.arg_lazy(
+            "other",
+            Box::new(move || {
+                let other = other.clone();
+                Box::pin(async move { other.into_id().await.unwrap().quote() })
+            }),
+        );
+        ContainerComparison {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
     /// Return the container's default arguments.
     pub async fn default_args(&self) -> Result<Vec<String>, DaggerError> {
         let query = self.selection.select("defaultArgs");
@@ -3242,6 +3727,21 @@ impl Container {
         if let Some(expand) = opts.expand {
             query = query.arg("expand", expand);
         }
+        if let Some(signing_key) = opts.signing_key {
+            query = query.arg("signingKey", signing_key);
+        }
+        if let Some(signing_key_password) = opts.signing_key_password {
+            query = query.arg("signingKeyPassword", signing_key_password);
+        }
+        if let Some(provenance) = opts.provenance {
+            query = query.arg("provenance", provenance);
+        }
+        if let Some(sbom) = opts.sbom {
+            query = query.arg("sbom", sbom);
+        }
+        if let Some(squash) = opts.squash {
+            query = query.arg("squash", squash);
+        }
         query.execute(self.graphql_client.clone()).await
     }
     /// Exports the container as an image to the host's container image store.
@@ -3465,6 +3965,21 @@ impl Container {
         if let Some(media_types) = opts.media_types {
             query = query.arg("mediaTypes", media_types);
         }
+        if let Some(signing_key) = opts.signing_key {
+            query = query.arg("signingKey", signing_key);
+        }
+        if let Some(signing_key_password) = opts.signing_key_password {
+            query = query.arg("signingKeyPassword", signing_key_password);
+        }
+        if let Some(provenance) = opts.provenance {
+            query = query.arg("provenance", provenance);
+        }
+        if let Some(sbom) = opts.sbom {
+            query = query.arg("sbom", sbom);
+        }
+        if let Some(squash) = opts.squash {
+            query = query.arg("squash", squash);
+        }
         query.execute(self.graphql_client.clone()).await
     }
     /// Return a snapshot of the container's root filesystem. The snapshot can be modified then written back using withRootfs. Use that method for filesystem modifications.
@@ -3476,6 +3991,37 @@ impl Container {
             graphql_client: self.graphql_client.clone(),
         }
     }
+    /// Return a software bill of materials (SBOM) of the packages in the container's root filesystem.
+    /// OS packages installed with apk, dpkg or rpm are detected, as well as the dependencies in Go, npm, Python and Cargo lockfiles. Mounts are not scanned.
+    ///
+    /// # Arguments
+    ///
+    /// * `opt` - optional argument, see inner type for documentation, use <func>_opts to use
+    pub fn sbom(&self) -> File {
+        let query = self.selection.select("sbom");
+        File {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
+    /// Return a software bill of materials (SBOM) of the packages in the container's root filesystem.
+    /// OS packages installed with apk, dpkg or rpm are detected, as well as the dependencies in Go, npm, Python and Cargo lockfiles. Mounts are not scanned.
+    ///
+    /// # Arguments
+    ///
+    /// * `opt` - optional argument, see inner type for documentation, use <func>_opts to use
+    pub fn sbom_opts(&self, opts: ContainerSbomOpts) -> File {
+        let mut query = self.selection.select("sbom");
+        if let Some(format) = opts.format {
+            query = query.arg("format", format);
+        }
+        File {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
     /// Return file status
     ///
     /// # Arguments
@@ -3610,6 +4156,12 @@ impl Container {
         if let Some(no_init) = opts.no_init {
             query = query.arg("noInit", no_init);
         }
+        if let Some(network_mode) = opts.network_mode {
+            query = query.arg("networkMode", network_mode);
+        }
+        if let Some(egress_allowlist) = opts.egress_allowlist {
+            query = query.arg("egressAllowlist", egress_allowlist);
+        }
         query.execute(self.graphql_client.clone()).await
     }
     /// Retrieves the user to be set for all commands.
@@ -3633,6 +4185,16 @@ impl Container {
             graphql_client: self.graphql_client.clone(),
         }
     }
+    /// Pauses the pipeline when this container is evaluated, opening an interactive terminal for it.
+    /// The pipeline continues when the terminal exits. Breakpoints only pause when the CLI runs with --interactive or --break-on, so they can be left in code, and they don't affect the cache of the pipeline.
+    pub fn with_breakpoint(&self) -> Container {
+        let query = self.selection.select("withBreakpoint");
+        Container {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
     /// Configures default arguments for future commands. Like CMD in Dockerfile.
     ///
     /// # Arguments
@@ -3967,6 +4529,12 @@ impl Container {
         if let Some(no_init) = opts.no_init {
             query = query.arg("noInit", no_init);
         }
+        if let Some(network_mode) = opts.network_mode {
+            query = query.arg("networkMode", network_mode);
+        }
+        if let Some(egress_allowlist) = opts.egress_allowlist {
+            query = query.arg("egressAllowlist", egress_allowlist);
+        }
         Container {
             proc: self.proc.clone(),
             selection: query,
@@ -4146,6 +4714,16 @@ impl Container {
             graphql_client: self.graphql_client.clone(),
         }
     }
+    /// Retrieves this container with a layer boundary at the current state of its root filesystem.
+    /// When the container is published or exported, the changes between consecutive boundaries are grouped into a single layer, on top of the layers of the image the container was created from. Changes after the last boundary make a layer of their own.
+    pub fn with_layer_boundary(&self) -> Container {
+        let query = self.selection.select("withLayerBoundary");
+        Container {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
     /// Retrieves this container plus a cache volume mounted at the given path.
     ///
     /// # Arguments
@@ -5114,6 +5692,150 @@ impl Container {
     }
 }
 #[derive(Clone)]
+pub struct ContainerComparison {
+    pub proc: Option<Arc<DaggerSessionProc>>,
+    pub selection: Selection,
+    pub graphql_client: DynGraphQLClient,
+}
+impl ContainerComparison {
+    /// The newer container.
+    pub fn after(&self) -> Container {
+        let query = self.selection.select("after");
+        Container {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
+    /// The older container to compare against.
+    pub fn before(&self) -> Container {
+        let query = self.selection.select("before");
+        Container {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
+    /// The changes between the root filesystems of the containers.
+    pub fn changes(&self) -> Changeset {
+        let query = self.selection.select("changes");
+        Changeset {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
+    /// Changes to the image configuration, e.g. environment variables, entrypoint, user, labels and exposed ports.
+    pub fn config_changes(&self) -> Vec<ContainerConfigChange> {
+        let query = self.selection.select("configChanges");
+        vec![ContainerConfigChange {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }]
+    }
+    /// A unique identifier for this ContainerComparison.
+    pub async fn id(&self) -> Result<ContainerComparisonId, DaggerError> {
+        let query = self.selection.select("id");
+        query.execute(self.graphql_client.clone()).await
+    }
+    /// Comparison of the layers of the root filesystems, by position from the base layer.
+    pub fn layers(&self) -> Vec<ContainerLayerComparison> {
+        let query = self.selection.select("layers");
+        vec![ContainerLayerComparison {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }]
+    }
+    /// A human-readable summary of the comparison.
+    pub async fn summary(&self) -> Result<String, DaggerError> {
+        let query = self.selection.select("summary");
+        query.execute(self.graphql_client.clone()).await
+    }
+}
+#[derive(Clone)]
+pub struct ContainerConfigChange {
+    pub proc: Option<Arc<DaggerSessionProc>>,
+    pub selection: Selection,
+    pub graphql_client: DynGraphQLClient,
+}
+impl ContainerConfigChange {
+    /// The value in the newer container. Lists are encoded as JSON arrays.
+    pub async fn after(&self) -> Result<String, DaggerError> {
+        let query = self.selection.select("after");
+        query.execute(self.graphql_client.clone()).await
+    }
+    /// The value in the older container. Lists are encoded as JSON arrays.
+    pub async fn before(&self) -> Result<String, DaggerError> {
+        let query = self.selection.select("before");
+        query.execute(self.graphql_client.clone()).await
+    }
+    /// The changed setting, named after the API that sets it, e.g. "envVariable", "entrypoint" or "label".
+    pub async fn field(&self) -> Result<String, DaggerError> {
+        let query = self.selection.select("field");
+        query.execute(self.graphql_client.clone()).await
+    }
+    /// A unique identifier for this ContainerConfigChange.
+    pub async fn id(&self) -> Result<ContainerConfigChangeId, DaggerError> {
+        let query = self.selection.select("id");
+        query.execute(self.graphql_client.clone()).await
+    }
+    /// The changed entry of settings that have several, i.e. the name of an environment variable or label, or an exposed port such as "8080/tcp".
+    pub async fn key(&self) -> Result<String, DaggerError> {
+        let query = self.selection.select("key");
+        query.execute(self.graphql_client.clone()).await
+    }
+    /// How the setting changed.
+    pub async fn kind(&self) -> Result<ContainerChangeKind, DaggerError> {
+        let query = self.selection.select("kind");
+        query.execute(self.graphql_client.clone()).await
+    }
+}
+#[derive(Clone)]
+pub struct ContainerLayerComparison {
+    pub proc: Option<Arc<DaggerSessionProc>>,
+    pub selection: Selection,
+    pub graphql_client: DynGraphQLClient,
+}
+impl ContainerLayerComparison {
+    /// The digest of the layer in the newer container, if it has one at this position.
+    pub async fn after_digest(&self) -> Result<String, DaggerError> {
+        let query = self.selection.select("afterDigest");
+        query.execute(self.graphql_client.clone()).await
+    }
+    /// The compressed size of the layer in the newer container, in bytes.
+    pub async fn after_size(&self) -> Result<isize, DaggerError> {
+        let query = self.selection.select("afterSize");
+        query.execute(self.graphql_client.clone()).await
+    }
+    /// The digest of the layer in the older container, if it has one at this position.
+    pub async fn before_digest(&self) -> Result<String, DaggerError> {
+        let query = self.selection.select("beforeDigest");
+        query.execute(self.graphql_client.clone()).await
+    }
+    /// The compressed size of the layer in the older container, in bytes.
+    pub async fn before_size(&self) -> Result<isize, DaggerError> {
+        let query = self.selection.select("beforeSize");
+        query.execute(self.graphql_client.clone()).await
+    }
+    /// A unique identifier for this ContainerLayerComparison.
+    pub async fn id(&self) -> Result<ContainerLayerComparisonId, DaggerError> {
+        let query = self.selection.select("id");
+        query.execute(self.graphql_client.clone()).await
+    }
+    /// The position of the layer, starting from the base layer at 0.
+    pub async fn index(&self) -> Result<isize, DaggerError> {
+        let query = self.selection.select("index");
+        query.execute(self.graphql_client.clone()).await
+    }
+    /// Whether the layer differs between the containers.
+    pub async fn kind(&self) -> Result<ContainerChangeKind, DaggerError> {
+        let query = self.selection.select("kind");
+        query.execute(self.graphql_client.clone()).await
+    }
+}
+#[derive(Clone)]
 pub struct CurrentModule {
     pub proc: Option<Arc<DaggerSessionProc>>,
     pub selection: Selection,
@@ -5304,6 +6026,12 @@ pub struct DirectoryFilterOpts<'a> {
     pub include: Option<Vec<&'a str>>,
 }
 #[derive(Builder, Debug, PartialEq)]
+pub struct DirectorySbomOpts {
+    /// Format of the SBOM.
+    #[builder(setter(into, strip_option), default)]
+    pub format: Option<SbomFormat>,
+}
+#[derive(Builder, Debug, PartialEq)]
 pub struct DirectorySearchOpts<'a> {
     /// Allow the . pattern to match newlines in multiline mode.
     #[builder(setter(into, strip_option), default)]
@@ -5765,6 +6493,37 @@ impl Directory {
         let query = self.selection.select("name");
         query.execute(self.graphql_client.clone()).await
     }
+    /// Return a software bill of materials (SBOM) of the packages in the directory.
+    /// OS packages installed with apk, dpkg or rpm are detected, as well as the dependencies in Go, npm, Python and Cargo lockfiles.
+    ///
+    /// # Arguments
+    ///
+    /// * `opt` - optional argument, see inner type for documentation, use <func>_opts to use
+    pub fn sbom(&self) -> File {
+        let query = self.selection.select("sbom");
+        File {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
+    /// Return a software bill of materials (SBOM) of the packages in the directory.
+    /// OS packages installed with apk, dpkg or rpm are detected, as well as the dependencies in Go, npm, Python and Cargo lockfiles.
+    ///
+    /// # Arguments
+    ///
+    /// * `opt` - optional argument, see inner type for documentation, use <func>_opts to use
+    pub fn sbom_opts(&self, opts: DirectorySbomOpts) -> File {
+        let mut query = self.selection.select("sbom");
+        if let Some(format) = opts.format {
+            query = query.arg("format", format);
+        }
+        File {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
     /// Searches for content matching the given regular expression or literal string.
     /// Uses Rust regex syntax; escape literal ., [, ], {, }, | with backslashes.
     ///
@@ -6336,6 +7095,24 @@ impl Engine {
         let query = self.selection.select("name");
         query.execute(self.graphql_client.clone()).await
     }
+    /// The list of persistent services running in the engine, shared across sessions
+    pub fn services(&self) -> Vec<EngineService> {
+        let query = self.selection.select("services");
+        vec![EngineService {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }]
+    }
+    /// The list of active sessions, across all clients connected to the engine
+    pub fn sessions(&self) -> Vec<EngineSession> {
+        let query = self.selection.select("sessions");
+        vec![EngineSession {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }]
+    }
 }
 #[derive(Clone)]
 pub struct EngineCache {
@@ -6399,6 +7176,16 @@ impl EngineCache {
         let query = self.selection.select("minFreeSpace");
         query.execute(self.graphql_client.clone()).await
     }
+    /// Pin a cache entry, so that it's never pruned
+    ///
+    /// # Arguments
+    ///
+    /// * `record_id` - The record ID of the cache entry to pin.
+    pub async fn pin(&self, record_id: impl Into<String>) -> Result<Void, DaggerError> {
+        let mut query = self.selection.select("pin");
+        query = query.arg("recordId", record_id.into());
+        query.execute(self.graphql_client.clone()).await
+    }
     /// Prune the cache of releaseable entries
     ///
     /// # Arguments
@@ -6430,6 +7217,17 @@ impl EngineCache {
         let query = self.selection.select("targetSpace");
         query.execute(self.graphql_client.clone()).await
     }
+    /// Unpin a cache entry, so that it can be pruned again
+    /// The entries of a pinned cache volume are pinned again when the volume is used; unpin the volume itself with CacheVolume.unpin instead.
+    ///
+    /// # Arguments
+    ///
+    /// * `record_id` - The record ID of the cache entry to unpin.
+    pub async fn unpin(&self, record_id: impl Into<String>) -> Result<Void, DaggerError> {
+        let mut query = self.selection.select("unpin");
+        query = query.arg("recordId", record_id.into());
+        query.execute(self.graphql_client.clone()).await
+    }
 }
 #[derive(Clone)]
 pub struct EngineCacheEntry {
@@ -6468,6 +7266,16 @@ impl EngineCacheEntry {
         let query = self.selection.select("mostRecentUseTimeUnixNano");
         query.execute(self.graphql_client.clone()).await
     }
+    /// Whether the cache entry is pinned, exempting it from pruning.
+    pub async fn pinned(&self) -> Result<bool, DaggerError> {
+        let query = self.selection.select("pinned");
+        query.execute(self.graphql_client.clone()).await
+    }
+    /// The ID of the underlying cache record, which stays the same across sessions and engine restarts.
+    pub async fn record_id(&self) -> Result<String, DaggerError> {
+        let query = self.selection.select("recordId");
+        query.execute(self.graphql_client.clone()).await
+    }
 }
 #[derive(Clone)]
 pub struct EngineCacheEntrySet {
@@ -6502,32 +7310,138 @@ impl EngineCacheEntrySet {
     }
 }
 #[derive(Clone)]
-pub struct EnumTypeDef {
+pub struct EngineService {
     pub proc: Option<Arc<DaggerSessionProc>>,
     pub selection: Selection,
     pub graphql_client: DynGraphQLClient,
 }
-impl EnumTypeDef {
-    /// A doc string for the enum, if any.
-    pub async fn description(&self) -> Result<String, DaggerError> {
-        let query = self.selection.select("description");
+impl EngineService {
+    /// The number of bindings currently using the service, across sessions.
+    pub async fn bindings(&self) -> Result<isize, DaggerError> {
+        let query = self.selection.select("bindings");
         query.execute(self.graphql_client.clone()).await
     }
-    /// A unique identifier for this EnumTypeDef.
-    pub async fn id(&self) -> Result<EnumTypeDefId, DaggerError> {
+    /// The hostname to reach the service from any session.
+    pub async fn hostname(&self) -> Result<String, DaggerError> {
+        let query = self.selection.select("hostname");
+        query.execute(self.graphql_client.clone()).await
+    }
+    /// A unique identifier for this EngineService.
+    pub async fn id(&self) -> Result<EngineServiceId, DaggerError> {
         let query = self.selection.select("id");
         query.execute(self.graphql_client.clone()).await
     }
-    /// The members of the enum.
-    pub fn members(&self) -> Vec<EnumValueTypeDef> {
-        let query = self.selection.select("members");
-        vec![EnumValueTypeDef {
-            proc: self.proc.clone(),
-            selection: query,
-            graphql_client: self.graphql_client.clone(),
-        }]
+    /// The time the service became unused, in Unix nanoseconds, or 0 if it's in use.
+    pub async fn idle_since_unix_nano(&self) -> Result<isize, DaggerError> {
+        let query = self.selection.select("idleSinceUnixNano");
+        query.execute(self.graphql_client.clone()).await
     }
-    /// The name of the enum.
+    /// How long the service keeps running while unused (e.g., "30m"), or empty if it runs until stopped.
+    pub async fn idle_timeout(&self) -> Result<String, DaggerError> {
+        let query = self.selection.select("idleTimeout");
+        query.execute(self.graphql_client.clone()).await
+    }
+    /// The name the service was started with.
+    pub async fn name(&self) -> Result<String, DaggerError> {
+        let query = self.selection.select("name");
+        query.execute(self.graphql_client.clone()).await
+    }
+    /// The ports exposed by the service.
+    pub async fn ports(&self) -> Result<Vec<isize>, DaggerError> {
+        let query = self.selection.select("ports");
+        query.execute(self.graphql_client.clone()).await
+    }
+    /// The time the service started, in Unix nanoseconds.
+    pub async fn started_unix_nano(&self) -> Result<isize, DaggerError> {
+        let query = self.selection.select("startedUnixNano");
+        query.execute(self.graphql_client.clone()).await
+    }
+}
+#[derive(Clone)]
+pub struct EngineSession {
+    pub proc: Option<Arc<DaggerSessionProc>>,
+    pub selection: Selection,
+    pub graphql_client: DynGraphQLClient,
+}
+impl EngineSession {
+    /// The number of cacheable calls in the session that were cached.
+    pub async fn cache_hits(&self) -> Result<isize, DaggerError> {
+        let query = self.selection.select("cacheHits");
+        query.execute(self.graphql_client.clone()).await
+    }
+    /// The number of cacheable calls in the session that were not cached.
+    pub async fn cache_misses(&self) -> Result<isize, DaggerError> {
+        let query = self.selection.select("cacheMisses");
+        query.execute(self.graphql_client.clone()).await
+    }
+    /// The hostname of the main client.
+    pub async fn client_hostname(&self) -> Result<String, DaggerError> {
+        let query = self.selection.select("clientHostname");
+        query.execute(self.graphql_client.clone()).await
+    }
+    /// The ID of the main client that started the session.
+    pub async fn client_id(&self) -> Result<String, DaggerError> {
+        let query = self.selection.select("clientId");
+        query.execute(self.graphql_client.clone()).await
+    }
+    /// The version of the main client.
+    pub async fn client_version(&self) -> Result<String, DaggerError> {
+        let query = self.selection.select("clientVersion");
+        query.execute(self.graphql_client.clone()).await
+    }
+    /// The command run by the main client, if known.
+    pub async fn command(&self) -> Result<String, DaggerError> {
+        let query = self.selection.select("command");
+        query.execute(self.graphql_client.clone()).await
+    }
+    /// The ID of the session.
+    pub async fn id(&self) -> Result<String, DaggerError> {
+        let query = self.selection.select("id");
+        query.execute(self.graphql_client.clone()).await
+    }
+    /// The names of the modules with clients connected to the session.
+    pub async fn modules(&self) -> Result<Vec<String>, DaggerError> {
+        let query = self.selection.select("modules");
+        query.execute(self.graphql_client.clone()).await
+    }
+    /// The number of containers currently running in the session.
+    pub async fn running_execs(&self) -> Result<isize, DaggerError> {
+        let query = self.selection.select("runningExecs");
+        query.execute(self.graphql_client.clone()).await
+    }
+    /// The time the session started, in Unix nanoseconds.
+    pub async fn started_unix_nano(&self) -> Result<isize, DaggerError> {
+        let query = self.selection.select("startedUnixNano");
+        query.execute(self.graphql_client.clone()).await
+    }
+}
+#[derive(Clone)]
+pub struct EnumTypeDef {
+    pub proc: Option<Arc<DaggerSessionProc>>,
+    pub selection: Selection,
+    pub graphql_client: DynGraphQLClient,
+}
+impl EnumTypeDef {
+    /// A doc string for the enum, if any.
+    pub async fn description(&self) -> Result<String, DaggerError> {
+        let query = self.selection.select("description");
+        query.execute(self.graphql_client.clone()).await
+    }
+    /// A unique identifier for this EnumTypeDef.
+    pub async fn id(&self) -> Result<EnumTypeDefId, DaggerError> {
+        let query = self.selection.select("id");
+        query.execute(self.graphql_client.clone()).await
+    }
+    /// The members of the enum.
+    pub fn members(&self) -> Vec<EnumValueTypeDef> {
+        let query = self.selection.select("members");
+        vec![EnumValueTypeDef {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }]
+    }
+    /// The name of the enum.
     pub async fn name(&self) -> Result<String, DaggerError> {
         let query = self.selection.select("name");
         query.execute(self.graphql_client.clone()).await
@@ -6603,6 +7517,21 @@ pub struct Env {
     pub selection: Selection,
     pub graphql_client: DynGraphQLClient,
 }
+#[derive(Builder, Debug, PartialEq)]
+pub struct EnvWithOutputExecCheckOpts<'a> {
+    /// The number of values to check before giving up
+    #[builder(setter(into, strip_option), default)]
+    pub max_attempts: Option<isize>,
+    /// The path to mount the value at
+    #[builder(setter(into, strip_option), default)]
+    pub path: Option<&'a str>,
+}
+#[derive(Builder, Debug, PartialEq)]
+pub struct EnvWithOutputFunctionCheckOpts {
+    /// The number of values to check before giving up
+    #[builder(setter(into, strip_option), default)]
+    pub max_attempts: Option<isize>,
+}
 impl Env {
     /// A unique identifier for this Env.
     pub async fn id(&self) -> Result<EnvId, DaggerError> {
@@ -6941,6 +7870,104 @@ impl Env {
             graphql_client: self.graphql_client.clone(),
         }
     }
+    /// Create or update a binding of type ContainerComparison in the environment
+    ///
+    /// # Arguments
+    ///
+    /// * `name` - The name of the binding
+    /// * `value` - The ContainerComparison value to assign to the binding
+    /// * `description` - The purpose of the input
+    pub fn with_container_comparison_input(
+        &self,
+        name: impl Into<String>,
+        value: impl IntoID<ContainerComparisonId>,
+        description: impl Into<String>,
+    ) -> Env {
+        let mut query = self.selection.select("withContainerComparisonInput");
+        query = query.arg("name", name.into());
+        query = query.arg_lazy(
+            "value",
+            Box::new(move || {
+                let value = value.clone();
+                Box::pin(async move { value.into_id().await.unwrap().quote() })
+            }),
+        );
+        query = query.arg("description", description.into());
+        Env {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
+    /// Declare a desired ContainerComparison output to be assigned in the environment
+    ///
+    /// # Arguments
+    ///
+    /// * `name` - The name of the binding
+    /// * `description` - A description of the desired value of the binding
+    pub fn with_container_comparison_output(
+        &self,
+        name: impl Into<String>,
+        description: impl Into<String>,
+    ) -> Env {
+        let mut query = self.selection.select("withContainerComparisonOutput");
+        query = query.arg("name", name.into());
+        query = query.arg("description", description.into());
+        Env {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
+    /// Create or update a binding of type ContainerConfigChange in the environment
+    ///
+    /// # Arguments
+    ///
+    /// * `name` - The name of the binding
+    /// * `value` - The ContainerConfigChange value to assign to the binding
+    /// * `description` - The purpose of the input
+    pub fn with_container_config_change_input(
+        &self,
+        name: impl Into<String>,
+        value: impl IntoID<ContainerConfigChangeId>,
+        description: impl Into<String>,
+    ) -> Env {
+        let mut query = self.selection.select("withContainerConfigChangeInput");
+        query = query.arg("name", name.into());
+        query = query.arg_lazy(
+            "value",
+            Box::new(move || {
+                let value = value.clone();
+                Box::pin(async move { value.into_id().await.unwrap().quote() })
+            }),
+        );
+        query = query.arg("description", description.into());
+        Env {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
+    /// Declare a desired ContainerConfigChange output to be assigned in the environment
+    ///
+    /// # Arguments
+    ///
+    /// * `name` - The name of the binding
+    /// * `description` - A description of the desired value of the binding
+    pub fn with_container_config_change_output(
+        &self,
+        name: impl Into<String>,
+        description: impl Into<String>,
+    ) -> Env {
+        let mut query = self.selection.select("withContainerConfigChangeOutput");
+        query = query.arg("name", name.into());
+        query = query.arg("description", description.into());
+        Env {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
     /// Create or update a binding of type Container in the environment
     ///
     /// # Arguments
@@ -6970,6 +7997,55 @@ impl Env {
             graphql_client: self.graphql_client.clone(),
         }
     }
+    /// Create or update a binding of type ContainerLayerComparison in the environment
+    ///
+    /// # Arguments
+    ///
+    /// * `name` - The name of the binding
+    /// * `value` - The ContainerLayerComparison value to assign to the binding
+    /// * `description` - The purpose of the input
+    pub fn with_container_layer_comparison_input(
+        &self,
+        name: impl Into<String>,
+        value: impl IntoID<ContainerLayerComparisonId>,
+        description: impl Into<String>,
+    ) -> Env {
+        let mut query = self.selection.select("withContainerLayerComparisonInput");
+        query = query.arg("name", name.into());
+        query = query.arg_lazy(
+            "value",
+            Box::new(move || {
+                let value = value.clone();
+                Box::pin(async move { value.into_id().await.unwrap().quote() })
+            }),
+        );
+        query = query.arg("description", description.into());
+        Env {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
+    /// Declare a desired ContainerLayerComparison output to be assigned in the environment
+    ///
+    /// # Arguments
+    ///
+    /// * `name` - The name of the binding
+    /// * `description` - A description of the desired value of the binding
+    pub fn with_container_layer_comparison_output(
+        &self,
+        name: impl Into<String>,
+        description: impl Into<String>,
+    ) -> Env {
+        let mut query = self.selection.select("withContainerLayerComparisonOutput");
+        query = query.arg("name", name.into());
+        query = query.arg("description", description.into());
+        Env {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
     /// Declare a desired Container output to be assigned in the environment
     ///
     /// # Arguments
@@ -7049,6 +8125,104 @@ impl Env {
             graphql_client: self.graphql_client.clone(),
         }
     }
+    /// Create or update a binding of type EngineService in the environment
+    ///
+    /// # Arguments
+    ///
+    /// * `name` - The name of the binding
+    /// * `value` - The EngineService value to assign to the binding
+    /// * `description` - The purpose of the input
+    pub fn with_engine_service_input(
+        &self,
+        name: impl Into<String>,
+        value: impl IntoID<EngineServiceId>,
+        description: impl Into<String>,
+    ) -> Env {
+        let mut query = self.selection.select("withEngineServiceInput");
+        query = query.arg("name", name.into());
+        query = query.arg_lazy(
+            "value",
+            Box::new(move || {
+                let value = value.clone();
+                Box::pin(async move { value.into_id().await.unwrap().quote() })
+            }),
+        );
+        query = query.arg("description", description.into());
+        Env {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
+    /// Declare a desired EngineService output to be assigned in the environment
+    ///
+    /// # Arguments
+    ///
+    /// * `name` - The name of the binding
+    /// * `description` - A description of the desired value of the binding
+    pub fn with_engine_service_output(
+        &self,
+        name: impl Into<String>,
+        description: impl Into<String>,
+    ) -> Env {
+        let mut query = self.selection.select("withEngineServiceOutput");
+        query = query.arg("name", name.into());
+        query = query.arg("description", description.into());
+        Env {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
+    /// Create or update a binding of type EngineSession in the environment
+    ///
+    /// # Arguments
+    ///
+    /// * `name` - The name of the binding
+    /// * `value` - The EngineSession value to assign to the binding
+    /// * `description` - The purpose of the input
+    pub fn with_engine_session_input(
+        &self,
+        name: impl Into<String>,
+        value: impl IntoID<EngineSessionId>,
+        description: impl Into<String>,
+    ) -> Env {
+        let mut query = self.selection.select("withEngineSessionInput");
+        query = query.arg("name", name.into());
+        query = query.arg_lazy(
+            "value",
+            Box::new(move || {
+                let value = value.clone();
+                Box::pin(async move { value.into_id().await.unwrap().quote() })
+            }),
+        );
+        query = query.arg("description", description.into());
+        Env {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
+    /// Declare a desired EngineSession output to be assigned in the environment
+    ///
+    /// # Arguments
+    ///
+    /// * `name` - The name of the binding
+    /// * `description` - A description of the desired value of the binding
+    pub fn with_engine_session_output(
+        &self,
+        name: impl Into<String>,
+        description: impl Into<String>,
+    ) -> Env {
+        let mut query = self.selection.select("withEngineSessionOutput");
+        query = query.arg("name", name.into());
+        query = query.arg("description", description.into());
+        Env {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
     /// Create or update a binding of type EnvFile in the environment
     ///
     /// # Arguments
@@ -7188,6 +8362,55 @@ impl Env {
             graphql_client: self.graphql_client.clone(),
         }
     }
+    /// Create or update a binding of type FunctionArgConstraints in the environment
+    ///
+    /// # Arguments
+    ///
+    /// * `name` - The name of the binding
+    /// * `value` - The FunctionArgConstraints value to assign to the binding
+    /// * `description` - The purpose of the input
+    pub fn with_function_arg_constraints_input(
+        &self,
+        name: impl Into<String>,
+        value: impl IntoID<FunctionArgConstraintsId>,
+        description: impl Into<String>,
+    ) -> Env {
+        let mut query = self.selection.select("withFunctionArgConstraintsInput");
+        query = query.arg("name", name.into());
+        query = query.arg_lazy(
+            "value",
+            Box::new(move || {
+                let value = value.clone();
+                Box::pin(async move { value.into_id().await.unwrap().quote() })
+            }),
+        );
+        query = query.arg("description", description.into());
+        Env {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
+    /// Declare a desired FunctionArgConstraints output to be assigned in the environment
+    ///
+    /// # Arguments
+    ///
+    /// * `name` - The name of the binding
+    /// * `description` - A description of the desired value of the binding
+    pub fn with_function_arg_constraints_output(
+        &self,
+        name: impl Into<String>,
+        description: impl Into<String>,
+    ) -> Env {
+        let mut query = self.selection.select("withFunctionArgConstraintsOutput");
+        query = query.arg("name", name.into());
+        query = query.arg("description", description.into());
+        Env {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
     /// Create or update a binding of type GitRef in the environment
     ///
     /// # Arguments
@@ -7352,6 +8575,55 @@ impl Env {
             graphql_client: self.graphql_client.clone(),
         }
     }
+    /// Create or update a binding of type ModuleCapabilities in the environment
+    ///
+    /// # Arguments
+    ///
+    /// * `name` - The name of the binding
+    /// * `value` - The ModuleCapabilities value to assign to the binding
+    /// * `description` - The purpose of the input
+    pub fn with_module_capabilities_input(
+        &self,
+        name: impl Into<String>,
+        value: impl IntoID<ModuleCapabilitiesId>,
+        description: impl Into<String>,
+    ) -> Env {
+        let mut query = self.selection.select("withModuleCapabilitiesInput");
+        query = query.arg("name", name.into());
+        query = query.arg_lazy(
+            "value",
+            Box::new(move || {
+                let value = value.clone();
+                Box::pin(async move { value.into_id().await.unwrap().quote() })
+            }),
+        );
+        query = query.arg("description", description.into());
+        Env {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
+    /// Declare a desired ModuleCapabilities output to be assigned in the environment
+    ///
+    /// # Arguments
+    ///
+    /// * `name` - The name of the binding
+    /// * `description` - A description of the desired value of the binding
+    pub fn with_module_capabilities_output(
+        &self,
+        name: impl Into<String>,
+        description: impl Into<String>,
+    ) -> Env {
+        let mut query = self.selection.select("withModuleCapabilitiesOutput");
+        query = query.arg("name", name.into());
+        query = query.arg("description", description.into());
+        Env {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
     /// Create or update a binding of type ModuleConfigClient in the environment
     ///
     /// # Arguments
@@ -7441,58 +8713,181 @@ impl Env {
         name: impl Into<String>,
         description: impl Into<String>,
     ) -> Env {
-        let mut query = self.selection.select("withModuleOutput");
+        let mut query = self.selection.select("withModuleOutput");
+        query = query.arg("name", name.into());
+        query = query.arg("description", description.into());
+        Env {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
+    /// Create or update a binding of type ModuleSource in the environment
+    ///
+    /// # Arguments
+    ///
+    /// * `name` - The name of the binding
+    /// * `value` - The ModuleSource value to assign to the binding
+    /// * `description` - The purpose of the input
+    pub fn with_module_source_input(
+        &self,
+        name: impl Into<String>,
+        value: impl IntoID<ModuleSourceId>,
+        description: impl Into<String>,
+    ) -> Env {
+        let mut query = self.selection.select("withModuleSourceInput");
+        query = query.arg("name", name.into());
+        query = query.arg_lazy(
+            "value",
+            Box::new(move || {
+                let value = value.clone();
+                Box::pin(async move { value.into_id().await.unwrap().quote() })
+            }),
+        );
+        query = query.arg("description", description.into());
+        Env {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
+    /// Declare a desired ModuleSource output to be assigned in the environment
+    ///
+    /// # Arguments
+    ///
+    /// * `name` - The name of the binding
+    /// * `description` - A description of the desired value of the binding
+    pub fn with_module_source_output(
+        &self,
+        name: impl Into<String>,
+        description: impl Into<String>,
+    ) -> Env {
+        let mut query = self.selection.select("withModuleSourceOutput");
+        query = query.arg("name", name.into());
+        query = query.arg("description", description.into());
+        Env {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
+    /// Checks the values saved to an output by running a command against them
+    /// When the command fails, its error is returned to the model, which is asked to fix the value until the command succeeds or the attempts run out. Directory, File and Container values are mounted at the given path, and String values are passed on stdin.
+    ///
+    /// # Arguments
+    ///
+    /// * `name` - The name of the output
+    /// * `container` - The container to run the command in
+    /// * `args` - The command to run
+    /// * `opt` - optional argument, see inner type for documentation, use <func>_opts to use
+    pub fn with_output_exec_check(
+        &self,
+        name: impl Into<String>,
+        container: impl IntoID<ContainerId>,
+        args: Vec<impl Into<String>>,
+    ) -> Env {
+        let mut query = self.selection.select("withOutputExecCheck");
+        query = query.arg("name", name.into());
+        query = query.arg_lazy(
+            "container",
+            Box::new(move || {
+                let container = container.clone();
+                Box::pin(async move { container.into_id().await.unwrap().quote() })
+            }),
+        );
+        query = query.arg(
+            "args",
+            args.into_iter().map(|i| i.into()).collect::<Vec<String>>(),
+        );
+        Env {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
+    /// Checks the values saved to an output by running a command against them
+    /// When the command fails, its error is returned to the model, which is asked to fix the value until the command succeeds or the attempts run out. Directory, File and Container values are mounted at the given path, and String values are passed on stdin.
+    ///
+    /// # Arguments
+    ///
+    /// * `name` - The name of the output
+    /// * `container` - The container to run the command in
+    /// * `args` - The command to run
+    /// * `opt` - optional argument, see inner type for documentation, use <func>_opts to use
+    pub fn with_output_exec_check_opts<'a>(
+        &self,
+        name: impl Into<String>,
+        container: impl IntoID<ContainerId>,
+        args: Vec<impl Into<String>>,
+        opts: EnvWithOutputExecCheckOpts<'a>,
+    ) -> Env {
+        let mut query = self.selection.select("withOutputExecCheck");
         query = query.arg("name", name.into());
-        query = query.arg("description", description.into());
+        query = query.arg_lazy(
+            "container",
+            Box::new(move || {
+                let container = container.clone();
+                Box::pin(async move { container.into_id().await.unwrap().quote() })
+            }),
+        );
+        query = query.arg(
+            "args",
+            args.into_iter().map(|i| i.into()).collect::<Vec<String>>(),
+        );
+        if let Some(path) = opts.path {
+            query = query.arg("path", path);
+        }
+        if let Some(max_attempts) = opts.max_attempts {
+            query = query.arg("maxAttempts", max_attempts);
+        }
         Env {
             proc: self.proc.clone(),
             selection: query,
             graphql_client: self.graphql_client.clone(),
         }
     }
-    /// Create or update a binding of type ModuleSource in the environment
+    /// Checks the values saved to an output by calling a function against them
+    /// The function must belong to a module installed in the environment, and take an argument of the output's type. When it fails, its error is returned to the model, which is asked to fix the value until the function succeeds or the attempts run out.
     ///
     /// # Arguments
     ///
-    /// * `name` - The name of the binding
-    /// * `value` - The ModuleSource value to assign to the binding
-    /// * `description` - The purpose of the input
-    pub fn with_module_source_input(
+    /// * `name` - The name of the output
+    /// * `function` - The name of the function to call
+    /// * `opt` - optional argument, see inner type for documentation, use <func>_opts to use
+    pub fn with_output_function_check(
         &self,
         name: impl Into<String>,
-        value: impl IntoID<ModuleSourceId>,
-        description: impl Into<String>,
+        function: impl Into<String>,
     ) -> Env {
-        let mut query = self.selection.select("withModuleSourceInput");
+        let mut query = self.selection.select("withOutputFunctionCheck");
         query = query.arg("name", name.into());
-        query = query.arg_lazy(
-            "value",
-            Box::new(move || {
-                let value = value.clone();
-                Box::pin(async move { value.into_id().await.unwrap().quote() })
-            }),
-        );
-        query = query.arg("description", description.into());
+        query = query.arg("function", function.into());
         Env {
             proc: self.proc.clone(),
             selection: query,
             graphql_client: self.graphql_client.clone(),
         }
     }
-    /// Declare a desired ModuleSource output to be assigned in the environment
+    /// Checks the values saved to an output by calling a function against them
+    /// The function must belong to a module installed in the environment, and take an argument of the output's type. When it fails, its error is returned to the model, which is asked to fix the value until the function succeeds or the attempts run out.
     ///
     /// # Arguments
     ///
-    /// * `name` - The name of the binding
-    /// * `description` - A description of the desired value of the binding
-    pub fn with_module_source_output(
+    /// * `name` - The name of the output
+    /// * `function` - The name of the function to call
+    /// * `opt` - optional argument, see inner type for documentation, use <func>_opts to use
+    pub fn with_output_function_check_opts(
         &self,
         name: impl Into<String>,
-        description: impl Into<String>,
+        function: impl Into<String>,
+        opts: EnvWithOutputFunctionCheckOpts,
     ) -> Env {
-        let mut query = self.selection.select("withModuleSourceOutput");
+        let mut query = self.selection.select("withOutputFunctionCheck");
         query = query.arg("name", name.into());
-        query = query.arg("description", description.into());
+        query = query.arg("function", function.into());
+        if let Some(max_attempts) = opts.max_attempts {
+            query = query.arg("maxAttempts", max_attempts);
+        }
         Env {
             proc: self.proc.clone(),
             selection: query,
@@ -7832,6 +9227,55 @@ impl Env {
             graphql_client: self.graphql_client.clone(),
         }
     }
+    /// Create or update a binding of type UnionTypeDef in the environment
+    ///
+    /// # Arguments
+    ///
+    /// * `name` - The name of the binding
+    /// * `value` - The UnionTypeDef value to assign to the binding
+    /// * `description` - The purpose of the input
+    pub fn with_union_type_def_input(
+        &self,
+        name: impl Into<String>,
+        value: impl IntoID<UnionTypeDefId>,
+        description: impl Into<String>,
+    ) -> Env {
+        let mut query = self.selection.select("withUnionTypeDefInput");
+        query = query.arg("name", name.into());
+        query = query.arg_lazy(
+            "value",
+            Box::new(move || {
+                let value = value.clone();
+                Box::pin(async move { value.into_id().await.unwrap().quote() })
+            }),
+        );
+        query = query.arg("description", description.into());
+        Env {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
+    /// Declare a desired UnionTypeDef output to be assigned in the environment
+    ///
+    /// # Arguments
+    ///
+    /// * `name` - The name of the binding
+    /// * `description` - A description of the desired value of the binding
+    pub fn with_union_type_def_output(
+        &self,
+        name: impl Into<String>,
+        description: impl Into<String>,
+    ) -> Env {
+        let mut query = self.selection.select("withUnionTypeDefOutput");
+        query = query.arg("name", name.into());
+        query = query.arg("description", description.into());
+        Env {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
     /// Returns a new environment with the provided workspace
     ///
     /// # Arguments
@@ -8557,6 +10001,27 @@ pub struct FunctionWithArgOpts<'a> {
     /// Patterns to ignore when loading the contextual argument value.
     #[builder(setter(into, strip_option), default)]
     pub ignore: Option<Vec<&'a str>>,
+    /// If the argument is an Integer or Float, the maximum value, inclusive, as a JSON number.
+    #[builder(setter(into, strip_option), default)]
+    pub max: Option<Json>,
+    /// If the argument is a String or a list, the maximum length of the value.
+    #[builder(setter(into, strip_option), default)]
+    pub max_length: Option<isize>,
+    /// If the argument is an Integer or Float, the minimum value, inclusive, as a JSON number.
+    #[builder(setter(into, strip_option), default)]
+    pub min: Option<Json>,
+    /// If the argument is a String or a list, the minimum length of the value.
+    #[builder(setter(into, strip_option), default)]
+    pub min_length: Option<isize>,
+    /// If the argument is a list, require it to contain at least one element.
+    #[builder(setter(into, strip_option), default)]
+    pub non_empty: Option<bool>,
+    /// If the argument is a String, a regular expression (RE2 syntax) that the whole value must match.
+    #[builder(setter(into, strip_option), default)]
+    pub pattern: Option<&'a str>,
+    /// If the argument is a Directory, paths that must exist in it.
+    #[builder(setter(into, strip_option), default)]
+    pub required_paths: Option<Vec<&'a str>>,
     /// The source map for the argument definition.
     #[builder(setter(into, strip_option), default)]
     pub source_map: Option<SourceMapId>,
@@ -8684,6 +10149,27 @@ impl Function {
         if let Some(deprecated) = opts.deprecated {
             query = query.arg("deprecated", deprecated);
         }
+        if let Some(min) = opts.min {
+            query = query.arg("min", min);
+        }
+        if let Some(max) = opts.max {
+            query = query.arg("max", max);
+        }
+        if let Some(pattern) = opts.pattern {
+            query = query.arg("pattern", pattern);
+        }
+        if let Some(min_length) = opts.min_length {
+            query = query.arg("minLength", min_length);
+        }
+        if let Some(max_length) = opts.max_length {
+            query = query.arg("maxLength", max_length);
+        }
+        if let Some(non_empty) = opts.non_empty {
+            query = query.arg("nonEmpty", non_empty);
+        }
+        if let Some(required_paths) = opts.required_paths {
+            query = query.arg("requiredPaths", required_paths);
+        }
         Function {
             proc: self.proc.clone(),
             selection: query,
@@ -8807,6 +10293,15 @@ pub struct FunctionArg {
     pub graphql_client: DynGraphQLClient,
 }
 impl FunctionArg {
+    /// Constraints the value of the argument must satisfy, if any.
+    pub fn constraints(&self) -> FunctionArgConstraints {
+        let query = self.selection.select("constraints");
+        FunctionArgConstraints {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
     /// Only applies to arguments of type File or Directory. If the argument is not set, load it from the given path in the context directory
     pub async fn default_path(&self) -> Result<String, DaggerError> {
         let query = self.selection.select("defaultPath");
@@ -8862,6 +10357,54 @@ impl FunctionArg {
     }
 }
 #[derive(Clone)]
+pub struct FunctionArgConstraints {
+    pub proc: Option<Arc<DaggerSessionProc>>,
+    pub selection: Selection,
+    pub graphql_client: DynGraphQLClient,
+}
+impl FunctionArgConstraints {
+    /// A unique identifier for this FunctionArgConstraints.
+    pub async fn id(&self) -> Result<FunctionArgConstraintsId, DaggerError> {
+        let query = self.selection.select("id");
+        query.execute(self.graphql_client.clone()).await
+    }
+    /// Only applies to Integer and Float arguments. The maximum value, inclusive, as a JSON number.
+    pub async fn max(&self) -> Result<Json, DaggerError> {
+        let query = self.selection.select("max");
+        query.execute(self.graphql_client.clone()).await
+    }
+    /// Only applies to String and list arguments. The maximum length of the value, or 0 for no maximum.
+    pub async fn max_length(&self) -> Result<isize, DaggerError> {
+        let query = self.selection.select("maxLength");
+        query.execute(self.graphql_client.clone()).await
+    }
+    /// Only applies to Integer and Float arguments. The minimum value, inclusive, as a JSON number.
+    pub async fn min(&self) -> Result<Json, DaggerError> {
+        let query = self.selection.select("min");
+        query.execute(self.graphql_client.clone()).await
+    }
+    /// Only applies to String and list arguments. The minimum length of the value.
+    pub async fn min_length(&self) -> Result<isize, DaggerError> {
+        let query = self.selection.select("minLength");
+        query.execute(self.graphql_client.clone()).await
+    }
+    /// Only applies to list arguments. Whether the list must contain at least one element.
+    pub async fn non_empty(&self) -> Result<bool, DaggerError> {
+        let query = self.selection.select("nonEmpty");
+        query.execute(self.graphql_client.clone()).await
+    }
+    /// Only applies to String arguments. A regular expression (RE2 syntax) that the whole value must match.
+    pub async fn pattern(&self) -> Result<String, DaggerError> {
+        let query = self.selection.select("pattern");
+        query.execute(self.graphql_client.clone()).await
+    }
+    /// Only applies to arguments of type Directory. Paths that must exist in the directory.
+    pub async fn required_paths(&self) -> Result<Vec<String>, DaggerError> {
+        let query = self.selection.select("requiredPaths");
+        query.execute(self.graphql_client.clone()).await
+    }
+}
+#[derive(Clone)]
 pub struct FunctionCall {
     pub proc: Option<Arc<DaggerSessionProc>>,
     pub selection: Selection,
@@ -9792,6 +11335,15 @@ pub struct Llm {
     pub selection: Selection,
     pub graphql_client: DynGraphQLClient,
 }
+#[derive(Builder, Debug, PartialEq)]
+pub struct LlmFanOutOpts<'a> {
+    /// The number of branches to run. Defaults to the number of variations.
+    #[builder(setter(into, strip_option), default)]
+    pub n: Option<isize>,
+    /// Prompts to append to the branches, one per branch in turn
+    #[builder(setter(into, strip_option), default)]
+    pub variations: Option<Vec<&'a str>>,
+}
 impl Llm {
     /// create a branch in the LLM's history
     pub fn attempt(&self, number: isize) -> Llm {
@@ -9813,6 +11365,11 @@ impl Llm {
             graphql_client: self.graphql_client.clone(),
         }
     }
+    /// return the calls recorded instead of being called in a dry run
+    pub async fn dry_run_calls(&self) -> Result<Vec<String>, DaggerError> {
+        let query = self.selection.select("dryRunCalls");
+        query.execute(self.graphql_client.clone()).await
+    }
     /// return the LLM's current environment
     pub fn env(&self) -> Env {
         let query = self.selection.select("env");
@@ -9822,6 +11379,49 @@ impl Llm {
             graphql_client: self.graphql_client.clone(),
         }
     }
+    /// Run several branches of the LLM in parallel, each with its own message history and a copy of its environment
+    /// Each branch submits the queued prompt, followed by one of the variations in turn, if any. Branches that fail are still returned, and fail when they are used. The token usage of all the branches is available with fanOutTokenUsage.
+    ///
+    /// # Arguments
+    ///
+    /// * `opt` - optional argument, see inner type for documentation, use <func>_opts to use
+    pub fn fan_out(&self) -> Vec<Llm> {
+        let query = self.selection.select("fanOut");
+        vec![Llm {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }]
+    }
+    /// Run several branches of the LLM in parallel, each with its own message history and a copy of its environment
+    /// Each branch submits the queued prompt, followed by one of the variations in turn, if any. Branches that fail are still returned, and fail when they are used. The token usage of all the branches is available with fanOutTokenUsage.
+    ///
+    /// # Arguments
+    ///
+    /// * `opt` - optional argument, see inner type for documentation, use <func>_opts to use
+    pub fn fan_out_opts<'a>(&self, opts: LlmFanOutOpts<'a>) -> Vec<Llm> {
+        let mut query = self.selection.select("fanOut");
+        if let Some(n) = opts.n {
+            query = query.arg("n", n);
+        }
+        if let Some(variations) = opts.variations {
+            query = query.arg("variations", variations);
+        }
+        vec![Llm {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }]
+    }
+    /// returns the token usage of the current state, including the other branches of the fan outs it came from
+    pub fn fan_out_token_usage(&self) -> LlmTokenUsage {
+        let query = self.selection.select("fanOutTokenUsage");
+        LlmTokenUsage {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
     /// Indicates whether there are any queued prompts or tool results to send to the model
     pub async fn has_prompt(&self) -> Result<bool, DaggerError> {
         let query = self.selection.select("hasPrompt");
@@ -9912,6 +11512,15 @@ impl Llm {
             graphql_client: self.graphql_client.clone(),
         }
     }
+    /// Return a new LLM that records the calls it makes with side effects outside of the engine, such as exports, publishing or host access, instead of calling them
+    pub fn with_dry_run(&self) -> Llm {
+        let query = self.selection.select("withDryRun");
+        Llm {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
     /// allow the LLM to interact with an environment via MCP
     pub fn with_env(&self, env: impl IntoID<EnvId>) -> Llm {
         let mut query = self.selection.select("withEnv");
@@ -10021,6 +11630,23 @@ impl Llm {
             graphql_client: self.graphql_client.clone(),
         }
     }
+    /// Return a new LLM that applies a policy to the functions matching a pattern
+    /// A pattern matches a function name, like withExec, or a type and function name, like Container.withExec, and may use * as a wildcard. Tools of MCP servers are matched by server and tool name. When several rules match a function, the last one applies, and functions that match no rule are allowed.
+    ///
+    /// # Arguments
+    ///
+    /// * `pattern` - The functions to apply the policy to
+    /// * `policy` - Whether the LLM may call the functions
+    pub fn with_tool_policy(&self, pattern: impl Into<String>, policy: LlmToolPolicy) -> Llm {
+        let mut query = self.selection.select("withToolPolicy");
+        query = query.arg("pattern", pattern.into());
+        query = query.arg("policy", policy);
+        Llm {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
     /// Disable the default system prompt
     pub fn without_default_system_prompt(&self) -> Llm {
         let query = self.selection.select("withoutDefaultSystemPrompt");
@@ -10315,6 +11941,15 @@ impl Module {
         let query = self.selection.select("sync");
         query.execute(self.graphql_client.clone()).await
     }
+    /// Unions served by this module.
+    pub fn unions(&self) -> Vec<TypeDef> {
+        let query = self.selection.select("unions");
+        vec![TypeDef {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }]
+    }
     /// User-defined default values, loaded from local .env files.
     pub fn user_defaults(&self) -> EnvFile {
         let query = self.selection.select("userDefaults");
@@ -10386,6 +12021,65 @@ impl Module {
             graphql_client: self.graphql_client.clone(),
         }
     }
+    /// This module plus the given Union type
+    pub fn with_union(&self, union: impl IntoID<TypeDefId>) -> Module {
+        let mut query = self.selection.select("withUnion");
+        query = query.arg_lazy(
+            "union",
+            Box::new(move || {
+                let union = union.clone();
+                Box::pin(async move { union.into_id().await.unwrap().quote() })
+            }),
+        );
+        Module {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
+}
+#[derive(Clone)]
+pub struct ModuleCapabilities {
+    pub proc: Option<Arc<DaggerSessionProc>>,
+    pub selection: Selection,
+    pub graphql_client: DynGraphQLClient,
+}
+impl ModuleCapabilities {
+    /// Whether the module may run commands with access to the Dagger API.
+    pub async fn exec(&self) -> Result<bool, DaggerError> {
+        let query = self.selection.select("exec");
+        query.execute(self.graphql_client.clone()).await
+    }
+    /// Paths the module may read from the host through defaultPath arguments.
+    pub async fn host_read(&self) -> Result<Vec<String>, DaggerError> {
+        let query = self.selection.select("hostRead");
+        query.execute(self.graphql_client.clone()).await
+    }
+    /// Whether the module may use host unix sockets and tunnels.
+    pub async fn host_sockets(&self) -> Result<bool, DaggerError> {
+        let query = self.selection.select("hostSockets");
+        query.execute(self.graphql_client.clone()).await
+    }
+    /// A unique identifier for this ModuleCapabilities.
+    pub async fn id(&self) -> Result<ModuleCapabilitiesId, DaggerError> {
+        let query = self.selection.select("id");
+        query.execute(self.graphql_client.clone()).await
+    }
+    /// Whether the module may use LLMs.
+    pub async fn llm(&self) -> Result<bool, DaggerError> {
+        let query = self.selection.select("llm");
+        query.execute(self.graphql_client.clone()).await
+    }
+    /// Whether the module may run commands and services with full network access, and download files with http. Image pulls and git fetches are done by the engine and aren't restricted.
+    pub async fn network(&self) -> Result<bool, DaggerError> {
+        let query = self.selection.select("network");
+        query.execute(self.graphql_client.clone()).await
+    }
+    /// Whether the module may load secrets from the host's secret providers.
+    pub async fn secrets(&self) -> Result<bool, DaggerError> {
+        let query = self.selection.select("secrets");
+        query.execute(self.graphql_client.clone()).await
+    }
 }
 #[derive(Clone)]
 pub struct ModuleConfigClient {
@@ -10416,6 +12110,27 @@ pub struct ModuleSource {
     pub selection: Selection,
     pub graphql_client: DynGraphQLClient,
 }
+#[derive(Builder, Debug, PartialEq)]
+pub struct ModuleSourceWithGrantedCapabilitiesOpts<'a> {
+    /// Whether the module may run commands with access to the Dagger API.
+    #[builder(setter(into, strip_option), default)]
+    pub exec: Option<bool>,
+    /// Paths the module may read from the host through defaultPath arguments.
+    #[builder(setter(into, strip_option), default)]
+    pub host_read: Option<Vec<&'a str>>,
+    /// Whether the module may use host unix sockets and tunnels.
+    #[builder(setter(into, strip_option), default)]
+    pub host_sockets: Option<bool>,
+    /// Whether the module may use LLMs.
+    #[builder(setter(into, strip_option), default)]
+    pub llm: Option<bool>,
+    /// Whether the module may run commands and services with full network access, and download files with http. Image pulls and git fetches are done by the engine and aren't restricted.
+    #[builder(setter(into, strip_option), default)]
+    pub network: Option<bool>,
+    /// Whether the module may load secrets from the host's secret providers.
+    #[builder(setter(into, strip_option), default)]
+    pub secrets: Option<bool>,
+}
 impl ModuleSource {
     /// Load the source as a module. If this is a local source, the parent directory must have been provided during module source creation
     pub fn as_module(&self) -> Module {
@@ -10440,6 +12155,15 @@ impl ModuleSource {
             graphql_client: self.graphql_client.clone(),
         }
     }
+    /// The capabilities the module requires, as declared in its dagger.json. Null if the module declares none, in which case it requires all capabilities.
+    pub fn capabilities(&self) -> ModuleCapabilities {
+        let query = self.selection.select("capabilities");
+        ModuleCapabilities {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
     /// The ref to clone the root of the git repo from. Only valid for git sources.
     pub async fn clone_ref(&self) -> Result<String, DaggerError> {
         let query = self.selection.select("cloneRef");
@@ -10515,6 +12239,15 @@ impl ModuleSource {
             graphql_client: self.graphql_client.clone(),
         }
     }
+    /// The capabilities granted to the module when it was installed as a dependency. Null if none were recorded, in which case it is only restricted by the capabilities it declares.
+    pub fn granted_capabilities(&self) -> ModuleCapabilities {
+        let query = self.selection.select("grantedCapabilities");
+        ModuleCapabilities {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
     /// The URL to access the web view of the repository (e.g., GitHub, GitLab, Bitbucket).
     pub async fn html_repo_url(&self) -> Result<String, DaggerError> {
         let query = self.selection.select("htmlRepoURL");
@@ -10700,8 +12433,55 @@ impl ModuleSource {
         &self,
         features: Vec<ModuleSourceExperimentalFeature>,
     ) -> ModuleSource {
-        let mut query = self.selection.select("withExperimentalFeatures");
-        query = query.arg("features", features);
+        let mut query = self.selection.select("withExperimentalFeatures");
+        query = query.arg("features", features);
+        ModuleSource {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
+    /// Record the capabilities granted to the module as a dependency. Recorded in the parent module's dagger.json when installed.
+    ///
+    /// # Arguments
+    ///
+    /// * `opt` - optional argument, see inner type for documentation, use <func>_opts to use
+    pub fn with_granted_capabilities(&self) -> ModuleSource {
+        let query = self.selection.select("withGrantedCapabilities");
+        ModuleSource {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
+    /// Record the capabilities granted to the module as a dependency. Recorded in the parent module's dagger.json when installed.
+    ///
+    /// # Arguments
+    ///
+    /// * `opt` - optional argument, see inner type for documentation, use <func>_opts to use
+    pub fn with_granted_capabilities_opts<'a>(
+        &self,
+        opts: ModuleSourceWithGrantedCapabilitiesOpts<'a>,
+    ) -> ModuleSource {
+        let mut query = self.selection.select("withGrantedCapabilities");
+        if let Some(host_read) = opts.host_read {
+            query = query.arg("hostRead", host_read);
+        }
+        if let Some(host_sockets) = opts.host_sockets {
+            query = query.arg("hostSockets", host_sockets);
+        }
+        if let Some(network) = opts.network {
+            query = query.arg("network", network);
+        }
+        if let Some(llm) = opts.llm {
+            query = query.arg("llm", llm);
+        }
+        if let Some(exec) = opts.exec {
+            query = query.arg("exec", exec);
+        }
+        if let Some(secrets) = opts.secrets {
+            query = query.arg("secrets", secrets);
+        }
         ModuleSource {
             proc: self.proc.clone(),
             selection: query,
@@ -11689,6 +13469,44 @@ impl Query {
             graphql_client: self.graphql_client.clone(),
         }
     }
+    /// Load a ContainerComparison from its ID.
+    pub fn load_container_comparison_from_id(
+        &self,
+        id: impl IntoID<ContainerComparisonId>,
+    ) -> ContainerComparison {
+        let mut query = self.selection.select("loadContainerComparisonFromID");
+        query = query.arg_lazy(
+            "id",
+            Box::new(move || {
+                let id = id.clone();
+                Box::pin(async move { id.into_id().await.unwrap().quote() })
+            }),
+        );
+        ContainerComparison {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
+    /// Load a ContainerConfigChange from its ID.
+    pub fn load_container_config_change_from_id(
+        &self,
+        id: impl IntoID<ContainerConfigChangeId>,
+    ) -> ContainerConfigChange {
+        let mut query = self.selection.select("loadContainerConfigChangeFromID");
+        query = query.arg_lazy(
+            "id",
+            Box::new(move || {
+                let id = id.clone();
+                Box::pin(async move { id.into_id().await.unwrap().quote() })
+            }),
+        );
+        ContainerConfigChange {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
     /// Load a Container from its ID.
     pub fn load_container_from_id(&self, id: impl IntoID<ContainerId>) -> Container {
         let mut query = self.selection.select("loadContainerFromID");
@@ -11705,6 +13523,25 @@ impl Query {
             graphql_client: self.graphql_client.clone(),
         }
     }
+    /// Load a ContainerLayerComparison from its ID.
+    pub fn load_container_layer_comparison_from_id(
+        &self,
+        id: impl IntoID<ContainerLayerComparisonId>,
+    ) -> ContainerLayerComparison {
+        let mut query = self.selection.select("loadContainerLayerComparisonFromID");
+        query = query.arg_lazy(
+            "id",
+            Box::new(move || {
+                let id = id.clone();
+                Box::pin(async move { id.into_id().await.unwrap().quote() })
+            }),
+        );
+        ContainerLayerComparison {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
     /// Load a CurrentModule from its ID.
     pub fn load_current_module_from_id(&self, id: impl IntoID<CurrentModuleId>) -> CurrentModule {
         let mut query = self.selection.select("loadCurrentModuleFromID");
@@ -11807,6 +13644,38 @@ impl Query {
             graphql_client: self.graphql_client.clone(),
         }
     }
+    /// Load a EngineService from its ID.
+    pub fn load_engine_service_from_id(&self, id: impl IntoID<EngineServiceId>) -> EngineService {
+        let mut query = self.selection.select("loadEngineServiceFromID");
+        query = query.arg_lazy(
+            "id",
+            Box::new(move || {
+                let id = id.clone();
+                Box::pin(async move { id.into_id().await.unwrap().quote() })
+            }),
+        );
+        EngineService {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
+    /// Load a EngineSession from its ID.
+    pub fn load_engine_session_from_id(&self, id: impl IntoID<EngineSessionId>) -> EngineSession {
+        let mut query = self.selection.select("loadEngineSessionFromID");
+        query = query.arg_lazy(
+            "id",
+            Box::new(move || {
+                let id = id.clone();
+                Box::pin(async move { id.into_id().await.unwrap().quote() })
+            }),
+        );
+        EngineSession {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
     /// Load a EnumTypeDef from its ID.
     pub fn load_enum_type_def_from_id(&self, id: impl IntoID<EnumTypeDefId>) -> EnumTypeDef {
         let mut query = self.selection.select("loadEnumTypeDefFromID");
@@ -11954,6 +13823,25 @@ impl Query {
             graphql_client: self.graphql_client.clone(),
         }
     }
+    /// Load a FunctionArgConstraints from its ID.
+    pub fn load_function_arg_constraints_from_id(
+        &self,
+        id: impl IntoID<FunctionArgConstraintsId>,
+    ) -> FunctionArgConstraints {
+        let mut query = self.selection.select("loadFunctionArgConstraintsFromID");
+        query = query.arg_lazy(
+            "id",
+            Box::new(move || {
+                let id = id.clone();
+                Box::pin(async move { id.into_id().await.unwrap().quote() })
+            }),
+        );
+        FunctionArgConstraints {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
     /// Load a FunctionArg from its ID.
     pub fn load_function_arg_from_id(&self, id: impl IntoID<FunctionArgId>) -> FunctionArg {
         let mut query = self.selection.select("loadFunctionArgFromID");
@@ -12200,6 +14088,25 @@ impl Query {
             graphql_client: self.graphql_client.clone(),
         }
     }
+    /// Load a ModuleCapabilities from its ID.
+    pub fn load_module_capabilities_from_id(
+        &self,
+        id: impl IntoID<ModuleCapabilitiesId>,
+    ) -> ModuleCapabilities {
+        let mut query = self.selection.select("loadModuleCapabilitiesFromID");
+        query = query.arg_lazy(
+            "id",
+            Box::new(move || {
+                let id = id.clone();
+                Box::pin(async move { id.into_id().await.unwrap().quote() })
+            }),
+        );
+        ModuleCapabilities {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
     /// Load a ModuleConfigClient from its ID.
     pub fn load_module_config_client_from_id(
         &self,
@@ -12462,6 +14369,22 @@ impl Query {
             graphql_client: self.graphql_client.clone(),
         }
     }
+    /// Load a UnionTypeDef from its ID.
+    pub fn load_union_type_def_from_id(&self, id: impl IntoID<UnionTypeDefId>) -> UnionTypeDef {
+        let mut query = self.selection.select("loadUnionTypeDefFromID");
+        query = query.arg_lazy(
+            "id",
+            Box::new(move || {
+                let id = id.clone();
+                Box::pin(async move { id.into_id().await.unwrap().quote() })
+            }),
+        );
+        UnionTypeDef {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
     /// Create a new module.
     pub fn module(&self) -> Module {
         let query = self.selection.select("module");
@@ -12517,6 +14440,21 @@ impl Query {
             graphql_client: self.graphql_client.clone(),
         }
     }
+    /// Attach to a persistent service running in the engine under the given name.
+    /// The service may have been started by another session, with `start(persistent: true)`. Only the main client can attach to it.
+    ///
+    /// # Arguments
+    ///
+    /// * `name` - The name the service was started with.
+    pub fn running_service(&self, name: impl Into<String>) -> Service {
+        let mut query = self.selection.select("runningService");
+        query = query.arg("name", name.into());
+        Service {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
     /// Creates a new secret.
     ///
     /// # Arguments
@@ -12765,6 +14703,17 @@ pub struct ServiceEndpointOpts<'a> {
     pub scheme: Option<&'a str>,
 }
 #[derive(Builder, Debug, PartialEq)]
+pub struct ServiceStartOpts<'a> {
+    /// Stop a persistent service once nothing has used it for this long, as a duration string (e.g., "30m", "1h").
+    /// If unset, the service runs until it's stopped.
+    #[builder(setter(into, strip_option), default)]
+    pub idle_timeout: Option<&'a str>,
+    /// Keep the service running after the session that started it ends.
+    /// The service must have a name, set with withName. Other sessions can attach to it with runningService, or by starting or binding the same service.
+    #[builder(setter(into, strip_option), default)]
+    pub persistent: Option<bool>,
+}
+#[derive(Builder, Debug, PartialEq)]
 pub struct ServiceStopOpts {
     /// Immediately kill the service without waiting for a graceful exit
     #[builder(setter(into, strip_option), default)]
@@ -12827,6 +14776,11 @@ impl Service {
         let query = self.selection.select("id");
         query.execute(self.graphql_client.clone()).await
     }
+    /// The name of the service, if set with withName.
+    pub async fn name(&self) -> Result<String, DaggerError> {
+        let query = self.selection.select("name");
+        query.execute(self.graphql_client.clone()).await
+    }
     /// Retrieves the list of ports provided by the service.
     pub fn ports(&self) -> Vec<Port> {
         let query = self.selection.select("ports");
@@ -12838,11 +14792,35 @@ impl Service {
     }
     /// Start the service and wait for its health checks to succeed.
     /// Services bound to a Container do not need to be manually started.
+    ///
+    /// # Arguments
+    ///
+    /// * `opt` - optional argument, see inner type for documentation, use <func>_opts to use
     pub async fn start(&self) -> Result<ServiceId, DaggerError> {
         let query = self.selection.select("start");
         query.execute(self.graphql_client.clone()).await
     }
+    /// Start the service and wait for its health checks to succeed.
+    /// Services bound to a Container do not need to be manually started.
+    ///
+    /// # Arguments
+    ///
+    /// * `opt` - optional argument, see inner type for documentation, use <func>_opts to use
+    pub async fn start_opts<'a>(
+        &self,
+        opts: ServiceStartOpts<'a>,
+    ) -> Result<ServiceId, DaggerError> {
+        let mut query = self.selection.select("start");
+        if let Some(persistent) = opts.persistent {
+            query = query.arg("persistent", persistent);
+        }
+        if let Some(idle_timeout) = opts.idle_timeout {
+            query = query.arg("idleTimeout", idle_timeout);
+        }
+        query.execute(self.graphql_client.clone()).await
+    }
     /// Stop the service.
+    /// A persistent service can only be stopped by the main client.
     ///
     /// # Arguments
     ///
@@ -12852,6 +14830,7 @@ impl Service {
         query.execute(self.graphql_client.clone()).await
     }
     /// Stop the service.
+    /// A persistent service can only be stopped by the main client.
     ///
     /// # Arguments
     ///
@@ -12933,6 +14912,20 @@ impl Service {
             graphql_client: self.graphql_client.clone(),
         }
     }
+    /// Configures a name for the service, under which it can be started as a persistent service shared across sessions.
+    ///
+    /// # Arguments
+    ///
+    /// * `name` - The name to use.
+    pub fn with_name(&self, name: impl Into<String>) -> Service {
+        let mut query = self.selection.select("withName");
+        query = query.arg("name", name.into());
+        Service {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
 }
 #[derive(Clone)]
 pub struct Socket {
@@ -13112,6 +15105,15 @@ pub struct TypeDefWithScalarOpts<'a> {
     #[builder(setter(into, strip_option), default)]
     pub description: Option<&'a str>,
 }
+#[derive(Builder, Debug, PartialEq)]
+pub struct TypeDefWithUnionOpts<'a> {
+    /// A doc string for the union, if any
+    #[builder(setter(into, strip_option), default)]
+    pub description: Option<&'a str>,
+    /// The source map for the union definition.
+    #[builder(setter(into, strip_option), default)]
+    pub source_map: Option<SourceMapId>,
+}
 impl TypeDef {
     /// If kind is ENUM, the enum-specific type definition. If kind is not ENUM, this will be null.
     pub fn as_enum(&self) -> EnumTypeDef {
@@ -13167,6 +15169,15 @@ impl TypeDef {
             graphql_client: self.graphql_client.clone(),
         }
     }
+    /// If kind is UNION, the union-specific type definition. If kind is not UNION, this will be null.
+    pub fn as_union(&self) -> UnionTypeDef {
+        let query = self.selection.select("asUnion");
+        UnionTypeDef {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
     /// A unique identifier for this TypeDef.
     pub async fn id(&self) -> Result<TypeDefId, DaggerError> {
         let query = self.selection.select("id");
@@ -13557,6 +15568,114 @@ impl TypeDef {
             graphql_client: self.graphql_client.clone(),
         }
     }
+    /// Returns a TypeDef of kind Union with the provided name.
+    /// Note that a union's members may be omitted if the intent is only to refer to a union.
+    ///
+    /// # Arguments
+    ///
+    /// * `name` - The name of the union
+    /// * `opt` - optional argument, see inner type for documentation, use <func>_opts to use
+    pub fn with_union(&self, name: impl Into<String>) -> TypeDef {
+        let mut query = self.selection.select("withUnion");
+        query = query.arg("name", name.into());
+        TypeDef {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
+    /// Returns a TypeDef of kind Union with the provided name.
+    /// Note that a union's members may be omitted if the intent is only to refer to a union.
+    ///
+    /// # Arguments
+    ///
+    /// * `name` - The name of the union
+    /// * `opt` - optional argument, see inner type for documentation, use <func>_opts to use
+    pub fn with_union_opts<'a>(
+        &self,
+        name: impl Into<String>,
+        opts: TypeDefWithUnionOpts<'a>,
+    ) -> TypeDef {
+        let mut query = self.selection.select("withUnion");
+        query = query.arg("name", name.into());
+        if let Some(description) = opts.description {
+            query = query.arg("description", description);
+        }
+        if let Some(source_map) = opts.source_map {
+            query = query.arg("sourceMap", source_map);
+        }
+        TypeDef {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
+    /// Adds a member type to a Union TypeDef, failing if the type is not a union or the member is not an object.
+    ///
+    /// # Arguments
+    ///
+    /// * `member` - The object type to add to the union
+    pub fn with_union_member(&self, member: impl IntoID<TypeDefId>) -> TypeDef {
+        let mut query = self.selection.select("withUnionMember");
+        query = query.arg_lazy(
+            "member",
+            Box::new(move || {
+                let member = member.clone();
+                Box::pin(async move { member.into_id().await.unwrap().quote() })
+            }),
+        );
+        TypeDef {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
+}
+#[derive(Clone)]
+pub struct UnionTypeDef {
+    pub proc: Option<Arc<DaggerSessionProc>>,
+    pub selection: Selection,
+    pub graphql_client: DynGraphQLClient,
+}
+impl UnionTypeDef {
+    /// The doc string for the union, if any.
+    pub async fn description(&self) -> Result<String, DaggerError> {
+        let query = self.selection.select("description");
+        query.execute(self.graphql_client.clone()).await
+    }
+    /// A unique identifier for this UnionTypeDef.
+    pub async fn id(&self) -> Result<UnionTypeDefId, DaggerError> {
+        let query = self.selection.select("id");
+        query.execute(self.graphql_client.clone()).await
+    }
+    /// The types a value of this union can have. They are always objects.
+    pub fn members(&self) -> Vec<TypeDef> {
+        let query = self.selection.select("members");
+        vec![TypeDef {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }]
+    }
+    /// The name of the union.
+    pub async fn name(&self) -> Result<String, DaggerError> {
+        let query = self.selection.select("name");
+        query.execute(self.graphql_client.clone()).await
+    }
+    /// The location of this union declaration.
+    pub fn source_map(&self) -> SourceMap {
+        let query = self.selection.select("sourceMap");
+        SourceMap {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
+    /// If this UnionTypeDef is associated with a Module, the name of the module. Unset otherwise.
+    pub async fn source_module_name(&self) -> Result<String, DaggerError> {
+        let query = self.selection.select("sourceModuleName");
+        query.execute(self.graphql_client.clone()).await
+    }
 }
 #[derive(Serialize, Deserialize, Clone, PartialEq, Debug)]
 pub enum CacheSharingMode {
@@ -13568,6 +15687,17 @@ pub enum CacheSharingMode {
     Shared,
 }
 #[derive(Serialize, Deserialize, Clone, PartialEq, Debug)]
+pub enum ContainerChangeKind {
+    #[serde(rename = "ADDED")]
+    Added,
+    #[serde(rename = "MODIFIED")]
+    Modified,
+    #[serde(rename = "REMOVED")]
+    Removed,
+    #[serde(rename = "UNCHANGED")]
+    Unchanged,
+}
+#[derive(Serialize, Deserialize, Clone, PartialEq, Debug)]
 pub enum ExistsType {
     #[serde(rename = "DIRECTORY_TYPE")]
     DirectoryType,
@@ -13627,6 +15757,22 @@ pub enum ImageMediaTypes {
     OciMediaTypes,
 }
 #[derive(Serialize, Deserialize, Clone, PartialEq, Debug)]
+pub enum ImageSquashMode {
+    #[serde(rename = "ALL")]
+    All,
+    #[serde(rename = "FROM_BASE")]
+    FromBase,
+}
+#[derive(Serialize, Deserialize, Clone, PartialEq, Debug)]
+pub enum LLMToolPolicy {
+    #[serde(rename = "ALLOW")]
+    Allow,
+    #[serde(rename = "ASK")]
+    Ask,
+    #[serde(rename = "DENY")]
+    Deny,
+}
+#[derive(Serialize, Deserialize, Clone, PartialEq, Debug)]
 pub enum ModuleSourceExperimentalFeature {
     #[serde(rename = "SELF_CALLS")]
     SelfCalls,
@@ -13647,6 +15793,15 @@ pub enum ModuleSourceKind {
     LocalSource,
 }
 #[derive(Serialize, Deserialize, Clone, PartialEq, Debug)]
+pub enum NetworkMode {
+    #[serde(rename = "FULL")]
+    Full,
+    #[serde(rename = "NONE")]
+    None,
+    #[serde(rename = "SERVICES_ONLY")]
+    ServicesOnly,
+}
+#[derive(Serialize, Deserialize, Clone, PartialEq, Debug)]
 pub enum NetworkProtocol {
     #[serde(rename = "TCP")]
     Tcp,
@@ -13663,6 +15818,13 @@ pub enum ReturnType {
     Success,
 }
 #[derive(Serialize, Deserialize, Clone, PartialEq, Debug)]
+pub enum SBOMFormat {
+    #[serde(rename = "CYCLONEDX_JSON")]
+    CyclonedxJson,
+    #[serde(rename = "SPDX_JSON")]
+    SpdxJson,
+}
+#[derive(Serialize, Deserialize, Clone, PartialEq, Debug)]
 pub enum TypeDefKind {
     #[serde(rename = "BOOLEAN")]
     Boolean,
@@ -13704,6 +15866,8 @@ pub enum TypeDefKind {
     String,
     #[serde(rename = "STRING_KIND")]
     StringKind,
+    #[serde(rename = "UNION_KIND")]
+    UnionKind,
     #[serde(rename = "VOID")]
     Void,
     #[serde(rename = "VOID_KIND")]
@@ -165,6 +165,20 @@ export type ContainerAsServiceOpts = {
    * This should only be used if the user requires that their exec process be the pid 1 process in the container. Otherwise it may result in unexpected behavior.
    */
   noInit?: boolean
+
+  /**
+   * Restrict the network access of the service.
+   *
+   * Blocked connection attempts are reported as events on the service's span.
+   */
+  networkMode?: NetworkMode
+
+  /**
+   * Hostnames and CIDRs the service may connect to, on top of what its network mode allows (e.g., ["proxy.golang.org", "10.0.0.0/8"]).
+   *
+   * Hostnames are resolved when the service starts. Requires a networkMode of NONE or SERVICES_ONLY.
+   */
+  egressAllowlist?: string[]
 }
 
 export type ContainerAsTarballOpts = {
@@ -235,6 +249,35 @@ export type ContainerExportOpts = {
    * Replace "${VAR}" or "$VAR" in the value of path according to the current environment variables defined in the container (e.g. "/$VAR/foo").
    */
   expand?: boolean
+
+  /**
+   * Private key to sign the image with, in PEM format.
+   *
+   * Both keys generated by "cosign generate-key-pair" and unencrypted PKCS#8, EC and RSA keys are supported. The signature, and the provenance attestation if requested, are attached to the image as OCI referrers in the sigstore bundle format.
+   */
+  signingKey?: Secret
+
+  /**
+   * Password of the signing key, if it's encrypted.
+   */
+  signingKeyPassword?: Secret
+
+  /**
+   * Attach a SLSA provenance attestation describing the calls that built the image.
+   */
+  provenance?: boolean
+
+  /**
+   * Attach a software bill of materials (SBOM) attestation in the given format, as returned by "sbom".
+   */
+  sbom?: SBOMFormat
+
+  /**
+   * Squash the layers of the exported image.
+   *
+   * By default, the changes between each layer boundary set with "withLayerBoundary" are grouped into a single layer, and each operation makes its own layer if there are no boundaries.
+   */
+  squash?: ImageSquashMode
 }
 
 export type ContainerExportImageOpts = {
@@ -295,6 +338,42 @@ export type ContainerPublishOpts = {
    * Defaults to "OCI", which is compatible with most recent registries, but "Docker" may be needed for older registries without OCI support.
    */
   mediaTypes?: ImageMediaTypes
+
+  /**
+   * Private key to sign the image with, in PEM format.
+   *
+   * Both keys generated by "cosign generate-key-pair" and unencrypted PKCS#8, EC and RSA keys are supported. The signature, and the provenance attestation if requested, are attached to the image as OCI referrers in the sigstore bundle format.
+   */
+  signingKey?: Secret
+
+  /**
+   * Password of the signing key, if it's encrypted.
+   */
+  signingKeyPassword?: Secret
+
+  /**
+   * Attach a SLSA provenance attestation describing the calls that built the image.
+   */
+  provenance?: boolean
+
+  /**
+   * Attach a software bill of materials (SBOM) attestation in the given format, as returned by "sbom".
+   */
+  sbom?: SBOMFormat
+
+  /**
+   * Squash the layers of the published image.
+   *
+   * By default, the changes between each layer boundary set with "withLayerBoundary" are grouped into a single layer, and each operation makes its own layer if there are no boundaries.
+   */
+  squash?: ImageSquashMode
+}
+
+export type ContainerSbomOpts = {
+  /**
+   * Format of the SBOM.
+   */
+  format?: SBOMFormat
 }
 
 export type ContainerStatOpts = {
@@ -367,6 +446,20 @@ export type ContainerUpOpts = {
    * This should only be used if the user requires that their exec process be the pid 1 process in the container. Otherwise it may result in unexpected behavior.
    */
   noInit?: boolean
+
+  /**
+   * Restrict the network access of the service.
+   *
+   * Blocked connection attempts are reported as events on the service's span.
+   */
+  networkMode?: NetworkMode
+
+  /**
+   * Hostnames and CIDRs the service may connect to, on top of what its network mode allows (e.g., ["proxy.golang.org", "10.0.0.0/8"]).
+   *
+   * Hostnames are resolved when the service starts. Requires a networkMode of NONE or SERVICES_ONLY.
+   */
+  egressAllowlist?: string[]
 }
 
 export type ContainerWithDefaultTerminalCmdOpts = {
@@ -480,6 +573,20 @@ export type ContainerWithExecOpts = {
    * Only use this if you specifically need the command to be pid 1 in the container. Otherwise it may result in unexpected behavior. If you're not sure, you don't need this.
    */
   noInit?: boolean
+
+  /**
+   * Restrict the network access of the command.
+   *
+   * Blocked connection attempts are reported as events on the command's span.
+   */
+  networkMode?: NetworkMode
+
+  /**
+   * Hostnames and CIDRs the command may connect to, on top of what its network mode allows (e.g., ["proxy.golang.org", "10.0.0.0/8"]).
+   *
+   * Hostnames are resolved when the command starts. Requires a networkMode of NONE or SERVICES_ONLY.
+   */
+  egressAllowlist?: string[]
 }
 
 export type ContainerWithExposedPortOpts = {
@@ -736,11 +843,92 @@ export type ContainerWithoutUnixSocketOpts = {
   expand?: boolean
 }
 
+/**
+ * How an entry differs between two containers
+ */
+export enum ContainerChangeKind {
+  /**
+   * The entry only exists in the newer container.
+   */
+  Added = "ADDED",
+
+  /**
+   * The entry exists in both containers, with different values.
+   */
+  Modified = "MODIFIED",
+
+  /**
+   * The entry only exists in the older container.
+   */
+  Removed = "REMOVED",
+
+  /**
+   * The entry is the same in both containers.
+   */
+  Unchanged = "UNCHANGED",
+}
+
+/**
+ * Utility function to convert a ContainerChangeKind value to its name so
+ * it can be uses as argument to call a exposed function.
+ */
+function ContainerChangeKindValueToName(value: ContainerChangeKind): string {
+  switch (value) {
+    case ContainerChangeKind.Added:
+      return "ADDED"
+    case ContainerChangeKind.Modified:
+      return "MODIFIED"
+    case ContainerChangeKind.Removed:
+      return "REMOVED"
+    case ContainerChangeKind.Unchanged:
+      return "UNCHANGED"
+    default:
+      return value
+  }
+}
+
+/**
+ * Utility function to convert a ContainerChangeKind name to its value so
+ * it can be properly used inside the module runtime.
+ */
+function ContainerChangeKindNameToValue(name: string): ContainerChangeKind {
+  switch (name) {
+    case "ADDED":
+      return ContainerChangeKind.Added
+    case "MODIFIED":
+      return ContainerChangeKind.Modified
+    case "REMOVED":
+      return ContainerChangeKind.Removed
+    case "UNCHANGED":
+      return ContainerChangeKind.Unchanged
+    default:
+      return name as ContainerChangeKind
+  }
+}
+/**
+ * The `ContainerComparisonID` scalar type represents an identifier for an object of type ContainerComparison.
+ */
+export type ContainerComparisonID = string & { __ContainerComparisonID: never }
+
+/**
+ * The `ContainerConfigChangeID` scalar type represents an identifier for an object of type ContainerConfigChange.
+ */
+export type ContainerConfigChangeID = string & {
+  __ContainerConfigChangeID: never
+}
+
 /**
  * The `ContainerID` scalar type represents an identifier for an object of type Container.
  */
 export type ContainerID = string & { __ContainerID: never }
 
+/**
+ * The `ContainerLayerComparisonID` scalar type represents an identifier for an object of type ContainerLayerComparison.
+ */
+export type ContainerLayerComparisonID = string & {
+  __ContainerLayerComparisonID: never
+}
+
 export type CurrentModuleWorkdirOpts = {
   /**
    * Exclude artifacts that match the given pattern (e.g., ["node_modules/", ".git*"]).
@@ -860,6 +1048,13 @@ export type DirectoryFilterOpts = {
   gitignore?: boolean
 }
 
+export type DirectorySbomOpts = {
+  /**
+   * Format of the SBOM.
+   */
+  format?: SBOMFormat
+}
+
 export type DirectorySearchOpts = {
   /**
    * Directory or file paths to search
@@ -1045,6 +1240,16 @@ export type EngineCacheID = string & { __EngineCacheID: never }
  */
 export type EngineID = string & { __EngineID: never }
 
+/**
+ * The `EngineServiceID` scalar type represents an identifier for an object of type EngineService.
+ */
+export type EngineServiceID = string & { __EngineServiceID: never }
+
+/**
+ * The `EngineSessionID` scalar type represents an identifier for an object of type EngineSession.
+ */
+export type EngineSessionID = string & { __EngineSessionID: never }
+
 /**
  * The `EnumTypeDefID` scalar type represents an identifier for an object of type EnumTypeDef.
  */
@@ -1055,6 +1260,25 @@ export type EnumTypeDefID = string & { __EnumTypeDefID: never }
  */
 export type EnumValueTypeDefID = string & { __EnumValueTypeDefID: never }
 
+export type EnvWithOutputExecCheckOpts = {
+  /**
+   * The path to mount the value at
+   */
+  path?: string
+
+  /**
+   * The number of values to check before giving up
+   */
+  maxAttempts?: number
+}
+
+export type EnvWithOutputFunctionCheckOpts = {
+  /**
+   * The number of values to check before giving up
+   */
+  maxAttempts?: number
+}
+
 export type EnvFileGetOpts = {
   /**
    * Return the value exactly as written to the file. No quote removal or variable expansion
@@ -1355,6 +1579,41 @@ export type FunctionWithArgOpts = {
    * If deprecated, the reason or migration path.
    */
   deprecated?: string
+
+  /**
+   * If the argument is an Integer or Float, the minimum value, inclusive, as a JSON number.
+   */
+  min?: JSON
+
+  /**
+   * If the argument is an Integer or Float, the maximum value, inclusive, as a JSON number.
+   */
+  max?: JSON
+
+  /**
+   * If the argument is a String, a regular expression (RE2 syntax) that the whole value must match.
+   */
+  pattern?: string
+
+  /**
+   * If the argument is a String or a list, the minimum length of the value.
+   */
+  minLength?: number
+
+  /**
+   * If the argument is a String or a list, the maximum length of the value.
+   */
+  maxLength?: number
+
+  /**
+   * If the argument is a list, require it to contain at least one element.
+   */
+  nonEmpty?: boolean
+
+  /**
+   * If the argument is a Directory, paths that must exist in it.
+   */
+  requiredPaths?: string[]
 }
 
 export type FunctionWithCachePolicyOpts = {
@@ -1371,6 +1630,13 @@ export type FunctionWithDeprecatedOpts = {
   reason?: string
 }
 
+/**
+ * The `FunctionArgConstraintsID` scalar type represents an identifier for an object of type FunctionArgConstraints.
+ */
+export type FunctionArgConstraintsID = string & {
+  __FunctionArgConstraintsID: never
+}
+
 /**
  * The `FunctionArgID` scalar type represents an identifier for an object of type FunctionArg.
  */
@@ -1630,6 +1896,50 @@ function ImageMediaTypesNameToValue(name: string): ImageMediaTypes {
       return name as ImageMediaTypes
   }
 }
+/**
+ * How to squash the layers of an image
+ */
+export enum ImageSquashMode {
+  /**
+   * Squash the whole root filesystem into a single layer.
+   */
+  All = "ALL",
+
+  /**
+   * Keep the layers of the image the container was created from, and squash everything on top of them into a single layer.
+   */
+  FromBase = "FROM_BASE",
+}
+
+/**
+ * Utility function to convert a ImageSquashMode value to its name so
+ * it can be uses as argument to call a exposed function.
+ */
+function ImageSquashModeValueToName(value: ImageSquashMode): string {
+  switch (value) {
+    case ImageSquashMode.All:
+      return "ALL"
+    case ImageSquashMode.FromBase:
+      return "FROM_BASE"
+    default:
+      return value
+  }
+}
+
+/**
+ * Utility function to convert a ImageSquashMode name to its value so
+ * it can be properly used inside the module runtime.
+ */
+function ImageSquashModeNameToValue(name: string): ImageSquashMode {
+  switch (name) {
+    case "ALL":
+      return ImageSquashMode.All
+    case "FROM_BASE":
+      return ImageSquashMode.FromBase
+    default:
+      return name as ImageSquashMode
+  }
+}
 /**
  * The `InputTypeDefID` scalar type represents an identifier for an object of type InputTypeDef.
  */
@@ -1662,6 +1972,18 @@ export type JSONValueContentsOpts = {
  */
 export type JSONValueID = string & { __JSONValueID: never }
 
+export type LLMFanOutOpts = {
+  /**
+   * The number of branches to run. Defaults to the number of variations.
+   */
+  n?: number
+
+  /**
+   * Prompts to append to the branches, one per branch in turn
+   */
+  variations?: string[]
+}
+
 /**
  * The `LLMID` scalar type represents an identifier for an object of type LLM.
  */
@@ -1672,6 +1994,59 @@ export type LLMID = string & { __LLMID: never }
  */
 export type LLMTokenUsageID = string & { __LLMTokenUsageID: never }
 
+/**
+ * Whether an LLM may call a tool
+ */
+export enum LLMToolPolicy {
+  /**
+   * Let the LLM call the tool
+   */
+  Allow = "ALLOW",
+
+  /**
+   * Ask the user to approve each call of the tool, and refuse if they can't be asked, e.g. in CI
+   */
+  Ask = "ASK",
+
+  /**
+   * Refuse to call the tool
+   */
+  Deny = "DENY",
+}
+
+/**
+ * Utility function to convert a LLMToolPolicy value to its name so
+ * it can be uses as argument to call a exposed function.
+ */
+function LlmtoolPolicyValueToName(value: LLMToolPolicy): string {
+  switch (value) {
+    case LLMToolPolicy.Allow:
+      return "ALLOW"
+    case LLMToolPolicy.Ask:
+      return "ASK"
+    case LLMToolPolicy.Deny:
+      return "DENY"
+    default:
+      return value
+  }
+}
+
+/**
+ * Utility function to convert a LLMToolPolicy name to its value so
+ * it can be properly used inside the module runtime.
+ */
+function LlmtoolPolicyNameToValue(name: string): LLMToolPolicy {
+  switch (name) {
+    case "ALLOW":
+      return LLMToolPolicy.Allow
+    case "ASK":
+      return LLMToolPolicy.Ask
+    case "DENY":
+      return LLMToolPolicy.Deny
+    default:
+      return name as LLMToolPolicy
+  }
+}
 /**
  * The `LabelID` scalar type represents an identifier for an object of type Label.
  */
@@ -1696,6 +2071,11 @@ export type ModuleServeOpts = {
   includeDependencies?: boolean
 }
 
+/**
+ * The `ModuleCapabilitiesID` scalar type represents an identifier for an object of type ModuleCapabilities.
+ */
+export type ModuleCapabilitiesID = string & { __ModuleCapabilitiesID: never }
+
 /**
  * The `ModuleConfigClientID` scalar type represents an identifier for an object of type ModuleConfigClient.
  */
@@ -1706,6 +2086,38 @@ export type ModuleConfigClientID = string & { __ModuleConfigClientID: never }
  */
 export type ModuleID = string & { __ModuleID: never }
 
+export type ModuleSourceWithGrantedCapabilitiesOpts = {
+  /**
+   * Paths the module may read from the host through defaultPath arguments.
+   */
+  hostRead?: string[]
+
+  /**
+   * Whether the module may use host unix sockets and tunnels.
+   */
+  hostSockets?: boolean
+
+  /**
+   * Whether the module may run commands and services with full network access, and download files with http. Image pulls and git fetches are done by the engine and aren't restricted.
+   */
+  network?: boolean
+
+  /**
+   * Whether the module may use LLMs.
+   */
+  llm?: boolean
+
+  /**
+   * Whether the module may run commands with access to the Dagger API.
+   */
+  exec?: boolean
+
+  /**
+   * Whether the module may load secrets from the host's secret providers.
+   */
+  secrets?: boolean
+}
+
 /**
  * Experimental features of a module
  */
@@ -1796,37 +2208,90 @@ function ModuleSourceKindNameToValue(name: string): ModuleSourceKind {
   }
 }
 /**
- * Transport layer network protocol associated to a port.
+ * Network access granted to an execution
  */
-export enum NetworkProtocol {
-  Tcp = "TCP",
-  Udp = "UDP",
+export enum NetworkMode {
+  /**
+   * Unrestricted network access
+   */
+  Full = "FULL",
+
+  /**
+   * No network access, apart from loopback
+   */
+  None = "NONE",
+
+  /**
+   * Network access to services only, e.g. those bound with "withServiceBinding"
+   */
+  ServicesOnly = "SERVICES_ONLY",
 }
 
 /**
- * Utility function to convert a NetworkProtocol value to its name so
+ * Utility function to convert a NetworkMode value to its name so
  * it can be uses as argument to call a exposed function.
  */
-function NetworkProtocolValueToName(value: NetworkProtocol): string {
+function NetworkModeValueToName(value: NetworkMode): string {
   switch (value) {
-    case NetworkProtocol.Tcp:
-      return "TCP"
-    case NetworkProtocol.Udp:
-      return "UDP"
+    case NetworkMode.Full:
+      return "FULL"
+    case NetworkMode.None:
+      return "NONE"
+    case NetworkMode.ServicesOnly:
+      return "SERVICES_ONLY"
     default:
       return value
   }
 }
 
 /**
- * Utility function to convert a NetworkProtocol name to its value so
+ * Utility function to convert a NetworkMode name to its value so
  * it can be properly used inside the module runtime.
  */
-function NetworkProtocolNameToValue(name: string): NetworkProtocol {
+function NetworkModeNameToValue(name: string): NetworkMode {
   switch (name) {
-    case "TCP":
-      return NetworkProtocol.Tcp
-    case "UDP":
+    case "FULL":
+      return NetworkMode.Full
+    case "NONE":
+      return NetworkMode.None
+    case "SERVICES_ONLY":
+      return NetworkMode.ServicesOnly
+    default:
+      return name as NetworkMode
+  }
+}
+/**
+ * Transport layer network protocol associated to a port.
+ */
+export enum NetworkProtocol {
+  Tcp = "TCP",
+  Udp = "UDP",
+}
+
+/**
+ * Utility function to convert a NetworkProtocol value to its name so
+ * it can be uses as argument to call a exposed function.
+ */
+function NetworkProtocolValueToName(value: NetworkProtocol): string {
+  switch (value) {
+    case NetworkProtocol.Tcp:
+      return "TCP"
+    case NetworkProtocol.Udp:
+      return "UDP"
+    default:
+      return value
+  }
+}
+
+/**
+ * Utility function to convert a NetworkProtocol name to its value so
+ * it can be properly used inside the module runtime.
+ */
+function NetworkProtocolNameToValue(name: string): NetworkProtocol {
+  switch (name) {
+    case "TCP":
+      return NetworkProtocol.Tcp
+    case "UDP":
       return NetworkProtocol.Udp
     default:
       return name as NetworkProtocol
@@ -2072,6 +2537,50 @@ function ReturnTypeNameToValue(name: string): ReturnType {
       return name as ReturnType
   }
 }
+/**
+ * Format of a software bill of materials
+ */
+export enum SBOMFormat {
+  /**
+   * CycloneDX 1.5 JSON
+   */
+  CyclonedxJson = "CYCLONEDX_JSON",
+
+  /**
+   * SPDX 2.3 JSON
+   */
+  SpdxJson = "SPDX_JSON",
+}
+
+/**
+ * Utility function to convert a SBOMFormat value to its name so
+ * it can be uses as argument to call a exposed function.
+ */
+function SbomformatValueToName(value: SBOMFormat): string {
+  switch (value) {
+    case SBOMFormat.CyclonedxJson:
+      return "CYCLONEDX_JSON"
+    case SBOMFormat.SpdxJson:
+      return "SPDX_JSON"
+    default:
+      return value
+  }
+}
+
+/**
+ * Utility function to convert a SBOMFormat name to its value so
+ * it can be properly used inside the module runtime.
+ */
+function SbomformatNameToValue(name: string): SBOMFormat {
+  switch (name) {
+    case "CYCLONEDX_JSON":
+      return SBOMFormat.CyclonedxJson
+    case "SPDX_JSON":
+      return SBOMFormat.SpdxJson
+    default:
+      return name as SBOMFormat
+  }
+}
 /**
  * The `SDKConfigID` scalar type represents an identifier for an object of type SDKConfig.
  */
@@ -2109,6 +2618,22 @@ export type ServiceEndpointOpts = {
   scheme?: string
 }
 
+export type ServiceStartOpts = {
+  /**
+   * Keep the service running after the session that started it ends.
+   *
+   * The service must have a name, set with withName. Other sessions can attach to it with runningService, or by starting or binding the same service.
+   */
+  persistent?: boolean
+
+  /**
+   * Stop a persistent service once nothing has used it for this long, as a duration string (e.g., "30m", "1h").
+   *
+   * If unset, the service runs until it's stopped.
+   */
+  idleTimeout?: string
+}
+
 export type ServiceStopOpts = {
   /**
    * Immediately kill the service without waiting for a graceful exit
@@ -2242,6 +2767,18 @@ export type TypeDefWithScalarOpts = {
   description?: string
 }
 
+export type TypeDefWithUnionOpts = {
+  /**
+   * A doc string for the union, if any
+   */
+  description?: string
+
+  /**
+   * The source map for the union definition.
+   */
+  sourceMap?: SourceMap
+}
+
 /**
  * The `TypeDefID` scalar type represents an identifier for an object of type TypeDef.
  */
@@ -2367,6 +2904,13 @@ export enum TypeDefKind {
    */
   StringKind = TypeDefKind.String,
 
+  /**
+   * Always paired with a UnionTypeDef.
+   *
+   * A named type whose values have exactly one of a set of object types.
+   */
+  UnionKind = "UNION_KIND",
+
   /**
    * A special kind used to signify that no value is returned.
    *
@@ -2408,6 +2952,8 @@ function TypeDefKindValueToName(value: TypeDefKind): string {
       return "SCALAR"
     case TypeDefKind.String:
       return "STRING"
+    case TypeDefKind.UnionKind:
+      return "UNION_KIND"
     case TypeDefKind.Void:
       return "VOID"
     default:
@@ -2441,12 +2987,19 @@ function TypeDefKindNameToValue(name: string): TypeDefKind {
       return TypeDefKind.Scalar
     case "STRING":
       return TypeDefKind.String
+    case "UNION_KIND":
+      return TypeDefKind.UnionKind
     case "VOID":
       return TypeDefKind.Void
     default:
       return name as TypeDefKind
   }
 }
+/**
+ * The `UnionTypeDefID` scalar type represents an identifier for an object of type UnionTypeDef.
+ */
+export type UnionTypeDefID = string & { __UnionTypeDefID: never }
+
 /**
  * The absence of a value.
  *
@@ -2687,6 +3240,30 @@ export class Binding extends BaseClient {
     return new Container(ctx)
   }
 
+  /**
+   * Retrieve the binding value, as type ContainerComparison
+   */
+  asContainerComparison = (): ContainerComparison => {
+    const ctx = this._ctx.select("asContainerComparison")
+    return new ContainerComparison(ctx)
+  }
+
+  /**
+   * Retrieve the binding value, as type ContainerConfigChange
+   */
+  asContainerConfigChange = (): ContainerConfigChange => {
+    const ctx = this._ctx.select("asContainerConfigChange")
+    return new ContainerConfigChange(ctx)
+  }
+
+  /**
+   * Retrieve the binding value, as type ContainerLayerComparison
+   */
+  asContainerLayerComparison = (): ContainerLayerComparison => {
+    const ctx = this._ctx.select("asContainerLayerComparison")
+    return new ContainerLayerComparison(ctx)
+  }
+
   /**
    * Retrieve the binding value, as type Directory
    */
@@ -2695,6 +3272,22 @@ export class Binding extends BaseClient {
     return new Directory(ctx)
   }
 
+  /**
+   * Retrieve the binding value, as type EngineService
+   */
+  asEngineService = (): EngineService => {
+    const ctx = this._ctx.select("asEngineService")
+    return new EngineService(ctx)
+  }
+
+  /**
+   * Retrieve the binding value, as type EngineSession
+   */
+  asEngineSession = (): EngineSession => {
+    const ctx = this._ctx.select("asEngineSession")
+    return new EngineSession(ctx)
+  }
+
   /**
    * Retrieve the binding value, as type Env
    */
@@ -2719,6 +3312,14 @@ export class Binding extends BaseClient {
     return new File(ctx)
   }
 
+  /**
+   * Retrieve the binding value, as type FunctionArgConstraints
+   */
+  asFunctionArgConstraints = (): FunctionArgConstraints => {
+    const ctx = this._ctx.select("asFunctionArgConstraints")
+    return new FunctionArgConstraints(ctx)
+  }
+
   /**
    * Retrieve the binding value, as type GitRef
    */
@@ -2751,6 +3352,14 @@ export class Binding extends BaseClient {
     return new Module_(ctx)
   }
 
+  /**
+   * Retrieve the binding value, as type ModuleCapabilities
+   */
+  asModuleCapabilities = (): ModuleCapabilities => {
+    const ctx = this._ctx.select("asModuleCapabilities")
+    return new ModuleCapabilities(ctx)
+  }
+
   /**
    * Retrieve the binding value, as type ModuleConfigClient
    */
@@ -2830,6 +3439,14 @@ export class Binding extends BaseClient {
     return response
   }
 
+  /**
+   * Retrieve the binding value, as type UnionTypeDef
+   */
+  asUnionTypeDef = (): UnionTypeDef => {
+    const ctx = this._ctx.select("asUnionTypeDef")
+    return new UnionTypeDef(ctx)
+  }
+
   /**
    * Returns the digest of the binding value
    */
@@ -2896,14 +3513,18 @@ export class Binding extends BaseClient {
  */
 export class CacheVolume extends BaseClient {
   private readonly _id?: CacheVolumeID = undefined
+  private readonly _pin?: Void = undefined
+  private readonly _unpin?: Void = undefined
 
   /**
    * Constructor is used for internal usage only, do not create object from it.
    */
-  constructor(ctx?: Context, _id?: CacheVolumeID) {
+  constructor(ctx?: Context, _id?: CacheVolumeID, _pin?: Void, _unpin?: Void) {
     super(ctx)
 
     this._id = _id
+    this._pin = _pin
+    this._unpin = _unpin
   }
 
   /**
@@ -2920,6 +3541,34 @@ export class CacheVolume extends BaseClient {
 
     return response
   }
+
+  /**
+   * Pin the contents of this cache volume, so that they're never pruned by garbage collection.
+   *
+   * Contents written to the volume later are pinned too, until it's unpinned. Only the main client can pin a cache volume.
+   */
+  pin = async (): Promise<void> => {
+    if (this._pin) {
+      return
+    }
+
+    const ctx = this._ctx.select("pin")
+
+    await ctx.execute()
+  }
+
+  /**
+   * Unpin the contents of this cache volume, so that they can be pruned by garbage collection again.
+   */
+  unpin = async (): Promise<void> => {
+    if (this._unpin) {
+      return
+    }
+
+    const ctx = this._ctx.select("unpin")
+
+    await ctx.execute()
+  }
 }
 
 /**
@@ -3070,6 +3719,26 @@ export class Changeset extends BaseClient {
 
     return new Client(ctx.copy()).loadChangesetFromID(response)
   }
+
+  /**
+   * Add changes to this changeset, if they don't conflict with it.
+   *
+   * Both changesets must compare against the same directory. The changes conflict if a file is changed by both, or changed by one and removed by the other.
+   * @param changes The changes to add.
+   */
+  withChangeset = (changes: Changeset): Changeset => {
+    const ctx = this._ctx.select("withChangeset", { changes })
+    return new Changeset(ctx)
+  }
+
+  /**
+   * Call the provided function with current Changeset.
+   *
+   * This is useful for reusability and readability by not breaking the calling chain.
+   */
+  with = (arg: (param: Changeset) => Changeset) => {
+    return arg(this)
+  }
 }
 
 export class Check extends BaseClient {
@@ -3432,9 +4101,19 @@ export class Container extends BaseClient {
    * @param opts.noInit If set, skip the automatic init process injected into containers by default.
    *
    * This should only be used if the user requires that their exec process be the pid 1 process in the container. Otherwise it may result in unexpected behavior.
+   * @param opts.networkMode Restrict the network access of the service.
+   *
+   * Blocked connection attempts are reported as events on the service's span.
+   * @param opts.egressAllowlist Hostnames and CIDRs the service may connect to, on top of what its network mode allows (e.g., ["proxy.golang.org", "10.0.0.0/8"]).
+   *
+   * Hostnames are resolved when the service starts. Requires a networkMode of NONE or SERVICES_ONLY.
    */
   asService = (opts?: ContainerAsServiceOpts): Service => {
-    const ctx = this._ctx.select("asService", { ...opts })
+    const metadata = {
+      networkMode: { is_enum: true, value_to_name: NetworkModeValueToName },
+    }
+
+    const ctx = this._ctx.select("asService", { ...opts, __metadata: metadata })
     return new Service(ctx)
   }
 
@@ -3480,6 +4159,17 @@ export class Container extends BaseClient {
     return response
   }
 
+  /**
+   * Compare this container's image configuration, layers and root filesystem with another container, typically a newer version of it.
+   *
+   * Layers that haven't been exported yet are compressed to compute their digest and size.
+   * @param other The newer container to compare against.
+   */
+  compare = (other: Container): ContainerComparison => {
+    const ctx = this._ctx.select("compare", { other })
+    return new ContainerComparison(ctx)
+  }
+
   /**
    * Return the container's default arguments.
    */
@@ -3635,6 +4325,15 @@ export class Container extends BaseClient {
    *
    * Defaults to OCI, which is largely compatible with most recent container runtimes, but Docker may be needed for older runtimes without OCI support.
    * @param opts.expand Replace "${VAR}" or "$VAR" in the value of path according to the current environment variables defined in the container (e.g. "/$VAR/foo").
+   * @param opts.signingKey Private key to sign the image with, in PEM format.
+   *
+   * Both keys generated by "cosign generate-key-pair" and unencrypted PKCS#8, EC and RSA keys are supported. The signature, and the provenance attestation if requested, are attached to the image as OCI referrers in the sigstore bundle format.
+   * @param opts.signingKeyPassword Password of the signing key, if it's encrypted.
+   * @param opts.provenance Attach a SLSA provenance attestation describing the calls that built the image.
+   * @param opts.sbom Attach a software bill of materials (SBOM) attestation in the given format, as returned by "sbom".
+   * @param opts.squash Squash the layers of the exported image.
+   *
+   * By default, the changes between each layer boundary set with "withLayerBoundary" are grouped into a single layer, and each operation makes its own layer if there are no boundaries.
    */
   export = async (
     path: string,
@@ -3650,6 +4349,8 @@ export class Container extends BaseClient {
         value_to_name: ImageLayerCompressionValueToName,
       },
       mediaTypes: { is_enum: true, value_to_name: ImageMediaTypesValueToName },
+      sbom: { is_enum: true, value_to_name: SBOMFormatValueToName },
+      squash: { is_enum: true, value_to_name: ImageSquashModeValueToName },
     }
 
     const ctx = this._ctx.select("export", {
@@ -3837,6 +4538,15 @@ export class Container extends BaseClient {
    * @param opts.mediaTypes Use the specified media types for the published image's layers.
    *
    * Defaults to "OCI", which is compatible with most recent registries, but "Docker" may be needed for older registries without OCI support.
+   * @param opts.signingKey Private key to sign the image with, in PEM format.
+   *
+   * Both keys generated by "cosign generate-key-pair" and unencrypted PKCS#8, EC and RSA keys are supported. The signature, and the provenance attestation if requested, are attached to the image as OCI referrers in the sigstore bundle format.
+   * @param opts.signingKeyPassword Password of the signing key, if it's encrypted.
+   * @param opts.provenance Attach a SLSA provenance attestation describing the calls that built the image.
+   * @param opts.sbom Attach a software bill of materials (SBOM) attestation in the given format, as returned by "sbom".
+   * @param opts.squash Squash the layers of the published image.
+   *
+   * By default, the changes between each layer boundary set with "withLayerBoundary" are grouped into a single layer, and each operation makes its own layer if there are no boundaries.
    */
   publish = async (
     address: string,
@@ -3852,6 +4562,8 @@ export class Container extends BaseClient {
         value_to_name: ImageLayerCompressionValueToName,
       },
       mediaTypes: { is_enum: true, value_to_name: ImageMediaTypesValueToName },
+      sbom: { is_enum: true, value_to_name: SBOMFormatValueToName },
+      squash: { is_enum: true, value_to_name: ImageSquashModeValueToName },
     }
 
     const ctx = this._ctx.select("publish", {
@@ -3873,6 +4585,21 @@ export class Container extends BaseClient {
     return new Directory(ctx)
   }
 
+  /**
+   * Return a software bill of materials (SBOM) of the packages in the container's root filesystem.
+   *
+   * OS packages installed with apk, dpkg or rpm are detected, as well as the dependencies in Go, npm, Python and Cargo lockfiles. Mounts are not scanned.
+   * @param opts.format Format of the SBOM.
+   */
+  sbom = (opts?: ContainerSbomOpts): File => {
+    const metadata = {
+      format: { is_enum: true, value_to_name: SBOMFormatValueToName },
+    }
+
+    const ctx = this._ctx.select("sbom", { ...opts, __metadata: metadata })
+    return new File(ctx)
+  }
+
   /**
    * Return file status
    * @param path Path to check (e.g., "/file.txt").
@@ -3959,13 +4686,23 @@ export class Container extends BaseClient {
    * @param opts.noInit If set, skip the automatic init process injected into containers by default.
    *
    * This should only be used if the user requires that their exec process be the pid 1 process in the container. Otherwise it may result in unexpected behavior.
+   * @param opts.networkMode Restrict the network access of the service.
+   *
+   * Blocked connection attempts are reported as events on the service's span.
+   * @param opts.egressAllowlist Hostnames and CIDRs the service may connect to, on top of what its network mode allows (e.g., ["proxy.golang.org", "10.0.0.0/8"]).
+   *
+   * Hostnames are resolved when the service starts. Requires a networkMode of NONE or SERVICES_ONLY.
    */
   up = async (opts?: ContainerUpOpts): Promise<void> => {
     if (this._up) {
       return
     }
 
-    const ctx = this._ctx.select("up", { ...opts })
+    const metadata = {
+      networkMode: { is_enum: true, value_to_name: NetworkModeValueToName },
+    }
+
+    const ctx = this._ctx.select("up", { ...opts, __metadata: metadata })
 
     await ctx.execute()
   }
@@ -3995,6 +4732,16 @@ export class Container extends BaseClient {
     return new Container(ctx)
   }
 
+  /**
+   * Pauses the pipeline when this container is evaluated, opening an interactive terminal for it.
+   *
+   * The pipeline continues when the terminal exits. Breakpoints only pause when the CLI runs with --interactive or --break-on, so they can be left in code, and they don't affect the cache of the pipeline.
+   */
+  withBreakpoint = (): Container => {
+    const ctx = this._ctx.select("withBreakpoint")
+    return new Container(ctx)
+  }
+
   /**
    * Configures default arguments for future commands. Like CMD in Dockerfile.
    * @param args Arguments to prepend to future executions (e.g., ["-v", "--no-cache"]).
@@ -4108,10 +4855,17 @@ export class Container extends BaseClient {
    * @param opts.noInit Skip the automatic init process injected into containers by default.
    *
    * Only use this if you specifically need the command to be pid 1 in the container. Otherwise it may result in unexpected behavior. If you're not sure, you don't need this.
+   * @param opts.networkMode Restrict the network access of the command.
+   *
+   * Blocked connection attempts are reported as events on the command's span.
+   * @param opts.egressAllowlist Hostnames and CIDRs the command may connect to, on top of what its network mode allows (e.g., ["proxy.golang.org", "10.0.0.0/8"]).
+   *
+   * Hostnames are resolved when the command starts. Requires a networkMode of NONE or SERVICES_ONLY.
    */
   withExec = (args: string[], opts?: ContainerWithExecOpts): Container => {
     const metadata = {
       expect: { is_enum: true, value_to_name: ReturnTypeValueToName },
+      networkMode: { is_enum: true, value_to_name: NetworkModeValueToName },
     }
 
     const ctx = this._ctx.select("withExec", {
@@ -4203,6 +4957,16 @@ export class Container extends BaseClient {
     return new Container(ctx)
   }
 
+  /**
+   * Retrieves this container with a layer boundary at the current state of its root filesystem.
+   *
+   * When the container is published or exported, the changes between consecutive boundaries are grouped into a single layer, on top of the layers of the image the container was created from. Changes after the last boundary make a layer of their own.
+   */
+  withLayerBoundary = (): Container => {
+    const ctx = this._ctx.select("withLayerBoundary")
+    return new Container(ctx)
+  }
+
   /**
    * Retrieves this container plus a cache volume mounted at the given path.
    * @param path Location of the cache directory (e.g., "/root/.npm").
@@ -4639,229 +5403,198 @@ export class Container extends BaseClient {
 }
 
 /**
- * Reflective module API provided to functions at runtime.
+ * A comparison between the image configuration, layers and root filesystem of two containers.
  */
-export class CurrentModule extends BaseClient {
-  private readonly _id?: CurrentModuleID = undefined
-  private readonly _name?: string = undefined
+export class ContainerComparison extends BaseClient {
+  private readonly _id?: ContainerComparisonID = undefined
+  private readonly _summary?: string = undefined
 
   /**
    * Constructor is used for internal usage only, do not create object from it.
    */
-  constructor(ctx?: Context, _id?: CurrentModuleID, _name?: string) {
+  constructor(ctx?: Context, _id?: ContainerComparisonID, _summary?: string) {
     super(ctx)
 
     this._id = _id
-    this._name = _name
+    this._summary = _summary
   }
 
   /**
-   * A unique identifier for this CurrentModule.
+   * A unique identifier for this ContainerComparison.
    */
-  id = async (): Promise<CurrentModuleID> => {
+  id = async (): Promise<ContainerComparisonID> => {
     if (this._id) {
       return this._id
     }
 
     const ctx = this._ctx.select("id")
 
-    const response: Awaited<CurrentModuleID> = await ctx.execute()
+    const response: Awaited<ContainerComparisonID> = await ctx.execute()
 
     return response
   }
 
   /**
-   * The dependencies of the module.
+   * The newer container.
    */
-  dependencies = async (): Promise<Module_[]> => {
-    type dependencies = {
-      id: ModuleID
-    }
-
-    const ctx = this._ctx.select("dependencies").select("id")
-
-    const response: Awaited<dependencies[]> = await ctx.execute()
+  after = (): Container => {
+    const ctx = this._ctx.select("after")
+    return new Container(ctx)
+  }
 
-    return response.map((r) => new Client(ctx.copy()).loadModuleFromID(r.id))
+  /**
+   * The older container to compare against.
+   */
+  before = (): Container => {
+    const ctx = this._ctx.select("before")
+    return new Container(ctx)
   }
 
   /**
-   * The generated files and directories made on top of the module source's context directory.
+   * The changes between the root filesystems of the containers.
    */
-  generatedContextDirectory = (): Directory => {
-    const ctx = this._ctx.select("generatedContextDirectory")
-    return new Directory(ctx)
+  changes = (): Changeset => {
+    const ctx = this._ctx.select("changes")
+    return new Changeset(ctx)
   }
 
   /**
-   * The name of the module being executed in
+   * Changes to the image configuration, e.g. environment variables, entrypoint, user, labels and exposed ports.
    */
-  name = async (): Promise<string> => {
-    if (this._name) {
-      return this._name
+  configChanges = async (): Promise<ContainerConfigChange[]> => {
+    type configChanges = {
+      id: ContainerConfigChangeID
     }
 
-    const ctx = this._ctx.select("name")
+    const ctx = this._ctx.select("configChanges").select("id")
 
-    const response: Awaited<string> = await ctx.execute()
+    const response: Awaited<configChanges[]> = await ctx.execute()
 
-    return response
+    return response.map((r) =>
+      new Client(ctx.copy()).loadContainerConfigChangeFromID(r.id),
+    )
   }
 
   /**
-   * The directory containing the module's source code loaded into the engine (plus any generated code that may have been created).
+   * Comparison of the layers of the root filesystems, by position from the base layer.
    */
-  source = (): Directory => {
-    const ctx = this._ctx.select("source")
-    return new Directory(ctx)
+  layers = async (): Promise<ContainerLayerComparison[]> => {
+    type layers = {
+      id: ContainerLayerComparisonID
+    }
+
+    const ctx = this._ctx.select("layers").select("id")
+
+    const response: Awaited<layers[]> = await ctx.execute()
+
+    return response.map((r) =>
+      new Client(ctx.copy()).loadContainerLayerComparisonFromID(r.id),
+    )
   }
 
   /**
-   * Load a directory from the module's scratch working directory, including any changes that may have been made to it during module function execution.
-   * @param path Location of the directory to access (e.g., ".").
-   * @param opts.exclude Exclude artifacts that match the given pattern (e.g., ["node_modules/", ".git*"]).
-   * @param opts.include Include only artifacts that match the given pattern (e.g., ["app/", "package.*"]).
-   * @param opts.gitignore Apply .gitignore filter rules inside the directory
+   * A human-readable summary of the comparison.
    */
-  workdir = (path: string, opts?: CurrentModuleWorkdirOpts): Directory => {
-    const ctx = this._ctx.select("workdir", { path, ...opts })
-    return new Directory(ctx)
-  }
+  summary = async (): Promise<string> => {
+    if (this._summary) {
+      return this._summary
+    }
 
-  /**
-   * Load a file from the module's scratch working directory, including any changes that may have been made to it during module function execution.Load a file from the module's scratch working directory, including any changes that may have been made to it during module function execution.
-   * @param path Location of the file to retrieve (e.g., "README.md").
-   */
-  workdirFile = (path: string): File => {
-    const ctx = this._ctx.select("workdirFile", { path })
-    return new File(ctx)
+    const ctx = this._ctx.select("summary")
+
+    const response: Awaited<string> = await ctx.execute()
+
+    return response
   }
 }
 
 /**
- * A directory.
+ * A change to the image configuration between two containers.
  */
-export class Directory extends BaseClient {
-  private readonly _id?: DirectoryID = undefined
-  private readonly _digest?: string = undefined
-  private readonly _exists?: boolean = undefined
-  private readonly _export?: string = undefined
-  private readonly _findUp?: string = undefined
-  private readonly _name?: string = undefined
-  private readonly _sync?: DirectoryID = undefined
+export class ContainerConfigChange extends BaseClient {
+  private readonly _id?: ContainerConfigChangeID = undefined
+  private readonly _after?: string = undefined
+  private readonly _before?: string = undefined
+  private readonly _field?: string = undefined
+  private readonly _key?: string = undefined
+  private readonly _kind?: ContainerChangeKind = undefined
 
   /**
    * Constructor is used for internal usage only, do not create object from it.
    */
   constructor(
     ctx?: Context,
-    _id?: DirectoryID,
-    _digest?: string,
-    _exists?: boolean,
-    _export?: string,
-    _findUp?: string,
-    _name?: string,
-    _sync?: DirectoryID,
+    _id?: ContainerConfigChangeID,
+    _after?: string,
+    _before?: string,
+    _field?: string,
+    _key?: string,
+    _kind?: ContainerChangeKind,
   ) {
     super(ctx)
 
     this._id = _id
-    this._digest = _digest
-    this._exists = _exists
-    this._export = _export
-    this._findUp = _findUp
-    this._name = _name
-    this._sync = _sync
+    this._after = _after
+    this._before = _before
+    this._field = _field
+    this._key = _key
+    this._kind = _kind
   }
 
   /**
-   * A unique identifier for this Directory.
+   * A unique identifier for this ContainerConfigChange.
    */
-  id = async (): Promise<DirectoryID> => {
+  id = async (): Promise<ContainerConfigChangeID> => {
     if (this._id) {
       return this._id
     }
 
     const ctx = this._ctx.select("id")
 
-    const response: Awaited<DirectoryID> = await ctx.execute()
+    const response: Awaited<ContainerConfigChangeID> = await ctx.execute()
 
     return response
   }
 
   /**
-   * Converts this directory to a local git repository
+   * The value in the newer container. Lists are encoded as JSON arrays.
    */
-  asGit = (): GitRepository => {
-    const ctx = this._ctx.select("asGit")
-    return new GitRepository(ctx)
-  }
+  after = async (): Promise<string> => {
+    if (this._after) {
+      return this._after
+    }
 
-  /**
-   * Load the directory as a Dagger module source
-   * @param opts.sourceRootPath An optional subpath of the directory which contains the module's configuration file.
-   *
-   * If not set, the module source code is loaded from the root of the directory.
-   */
-  asModule = (opts?: DirectoryAsModuleOpts): Module_ => {
-    const ctx = this._ctx.select("asModule", { ...opts })
-    return new Module_(ctx)
-  }
+    const ctx = this._ctx.select("after")
 
-  /**
-   * Load the directory as a Dagger module source
-   * @param opts.sourceRootPath An optional subpath of the directory which contains the module's configuration file.
-   *
-   * If not set, the module source code is loaded from the root of the directory.
-   */
-  asModuleSource = (opts?: DirectoryAsModuleSourceOpts): ModuleSource => {
-    const ctx = this._ctx.select("asModuleSource", { ...opts })
-    return new ModuleSource(ctx)
-  }
+    const response: Awaited<string> = await ctx.execute()
 
-  /**
-   * Return the difference between this directory and another directory, typically an older snapshot.
-   *
-   * The difference is encoded as a changeset, which also tracks removed files, and can be applied to other directories.
-   * @param from The base directory snapshot to compare against
-   */
-  changes = (from: Directory): Changeset => {
-    const ctx = this._ctx.select("changes", { from })
-    return new Changeset(ctx)
+    return response
   }
 
   /**
-   * Change the owner of the directory contents recursively.
-   * @param path Path of the directory to change ownership of (e.g., "/").
-   * @param owner A user:group to set for the mounted directory and its contents.
-   *
-   * The user and group must be an ID (1000:1000), not a name (foo:bar).
-   *
-   * If the group is omitted, it defaults to the same as the user.
+   * The value in the older container. Lists are encoded as JSON arrays.
    */
-  chown = (path: string, owner: string): Directory => {
-    const ctx = this._ctx.select("chown", { path, owner })
-    return new Directory(ctx)
-  }
+  before = async (): Promise<string> => {
+    if (this._before) {
+      return this._before
+    }
 
-  /**
-   * Return the difference between this directory and an another directory. The difference is encoded as a directory.
-   * @param other The directory to compare against
-   */
-  diff = (other: Directory): Directory => {
-    const ctx = this._ctx.select("diff", { other })
-    return new Directory(ctx)
+    const ctx = this._ctx.select("before")
+
+    const response: Awaited<string> = await ctx.execute()
+
+    return response
   }
 
   /**
-   * Return the directory's digest. The format of the digest is not guaranteed to be stable between releases of Dagger. It is guaranteed to be stable between invocations of the same Dagger engine.
+   * The changed setting, named after the API that sets it, e.g. "envVariable", "entrypoint" or "label".
    */
-  digest = async (): Promise<string> => {
-    if (this._digest) {
-      return this._digest
+  field = async (): Promise<string> => {
+    if (this._field) {
+      return this._field
     }
 
-    const ctx = this._ctx.select("digest")
+    const ctx = this._ctx.select("field")
 
     const response: Awaited<string> = await ctx.execute()
 
@@ -4869,87 +5602,96 @@ export class Directory extends BaseClient {
   }
 
   /**
-   * Retrieves a directory at the given path.
-   * @param path Location of the directory to retrieve. Example: "/src"
+   * The changed entry of settings that have several, i.e. the name of an environment variable or label, or an exposed port such as "8080/tcp".
    */
-  directory = (path: string): Directory => {
-    const ctx = this._ctx.select("directory", { path })
-    return new Directory(ctx)
+  key = async (): Promise<string> => {
+    if (this._key) {
+      return this._key
+    }
+
+    const ctx = this._ctx.select("key")
+
+    const response: Awaited<string> = await ctx.execute()
+
+    return response
   }
 
   /**
-   * Use Dockerfile compatibility to build a container from this directory. Only use this function for Dockerfile compatibility. Otherwise use the native Container type directly, it is feature-complete and supports all Dockerfile features.
-   * @param opts.dockerfile Path to the Dockerfile to use (e.g., "frontend.Dockerfile").
-   * @param opts.platform The platform to build.
-   * @param opts.buildArgs Build arguments to use in the build.
-   * @param opts.target Target build stage to build.
-   * @param opts.secrets Secrets to pass to the build.
-   *
-   * They will be mounted at /run/secrets/[secret-name].
-   * @param opts.noInit If set, skip the automatic init process injected into containers created by RUN statements.
-   *
-   * This should only be used if the user requires that their exec processes be the pid 1 process in the container. Otherwise it may result in unexpected behavior.
+   * How the setting changed.
    */
-  dockerBuild = (opts?: DirectoryDockerBuildOpts): Container => {
-    const ctx = this._ctx.select("dockerBuild", { ...opts })
-    return new Container(ctx)
+  kind = async (): Promise<ContainerChangeKind> => {
+    if (this._kind) {
+      return this._kind
+    }
+
+    const ctx = this._ctx.select("kind")
+
+    const response: Awaited<ContainerChangeKind> = await ctx.execute()
+
+    return ContainerChangeKindNameToValue(response)
   }
+}
+
+/**
+ * A comparison of the layers of two containers at the same position.
+ */
+export class ContainerLayerComparison extends BaseClient {
+  private readonly _id?: ContainerLayerComparisonID = undefined
+  private readonly _afterDigest?: string = undefined
+  private readonly _afterSize?: number = undefined
+  private readonly _beforeDigest?: string = undefined
+  private readonly _beforeSize?: number = undefined
+  private readonly _index?: number = undefined
+  private readonly _kind?: ContainerChangeKind = undefined
 
   /**
-   * Returns a list of files and directories at the given path.
-   * @param opts.path Location of the directory to look at (e.g., "/src").
+   * Constructor is used for internal usage only, do not create object from it.
    */
-  entries = async (opts?: DirectoryEntriesOpts): Promise<string[]> => {
-    const ctx = this._ctx.select("entries", { ...opts })
-
-    const response: Awaited<string[]> = await ctx.execute()
+  constructor(
+    ctx?: Context,
+    _id?: ContainerLayerComparisonID,
+    _afterDigest?: string,
+    _afterSize?: number,
+    _beforeDigest?: string,
+    _beforeSize?: number,
+    _index?: number,
+    _kind?: ContainerChangeKind,
+  ) {
+    super(ctx)
 
-    return response
+    this._id = _id
+    this._afterDigest = _afterDigest
+    this._afterSize = _afterSize
+    this._beforeDigest = _beforeDigest
+    this._beforeSize = _beforeSize
+    this._index = _index
+    this._kind = _kind
   }
 
   /**
-   * check if a file or directory exists
-   * @param path Path to check (e.g., "/file.txt").
-   * @param opts.expectedType If specified, also validate the type of file (e.g. "REGULAR_TYPE", "DIRECTORY_TYPE", or "SYMLINK_TYPE").
-   * @param opts.doNotFollowSymlinks If specified, do not follow symlinks.
+   * A unique identifier for this ContainerLayerComparison.
    */
-  exists = async (
-    path: string,
-    opts?: DirectoryExistsOpts,
-  ): Promise<boolean> => {
-    if (this._exists) {
-      return this._exists
-    }
-
-    const metadata = {
-      expectedType: { is_enum: true, value_to_name: ExistsTypeValueToName },
+  id = async (): Promise<ContainerLayerComparisonID> => {
+    if (this._id) {
+      return this._id
     }
 
-    const ctx = this._ctx.select("exists", {
-      path,
-      ...opts,
-      __metadata: metadata,
-    })
+    const ctx = this._ctx.select("id")
 
-    const response: Awaited<boolean> = await ctx.execute()
+    const response: Awaited<ContainerLayerComparisonID> = await ctx.execute()
 
     return response
   }
 
   /**
-   * Writes the contents of the directory to a path on the host.
-   * @param path Location of the copied directory (e.g., "logs/").
-   * @param opts.wipe If true, then the host directory will be wiped clean before exporting so that it exactly matches the directory being exported; this means it will delete any files on the host that aren't in the exported dir. If false (the default), the contents of the directory will be merged with any existing contents of the host directory, leaving any existing files on the host that aren't in the exported directory alone.
+   * The digest of the layer in the newer container, if it has one at this position.
    */
-  export = async (
-    path: string,
-    opts?: DirectoryExportOpts,
-  ): Promise<string> => {
-    if (this._export) {
-      return this._export
+  afterDigest = async (): Promise<string> => {
+    if (this._afterDigest) {
+      return this._afterDigest
     }
 
-    const ctx = this._ctx.select("export", { path, ...opts })
+    const ctx = this._ctx.select("afterDigest")
 
     const response: Awaited<string> = await ctx.execute()
 
@@ -4957,36 +5699,29 @@ export class Directory extends BaseClient {
   }
 
   /**
-   * Retrieve a file at the given path.
-   * @param path Location of the file to retrieve (e.g., "README.md").
+   * The compressed size of the layer in the newer container, in bytes.
    */
-  file = (path: string): File => {
-    const ctx = this._ctx.select("file", { path })
-    return new File(ctx)
-  }
+  afterSize = async (): Promise<number> => {
+    if (this._afterSize) {
+      return this._afterSize
+    }
 
-  /**
-   * Return a snapshot with some paths included or excluded
-   * @param opts.exclude If set, paths matching one of these glob patterns is excluded from the new snapshot. Example: ["node_modules/", ".git*", ".env"]
-   * @param opts.include If set, only paths matching one of these glob patterns is included in the new snapshot. Example: (e.g., ["app/", "package.*"]).
-   * @param opts.gitignore If set, apply .gitignore rules when filtering the directory.
-   */
-  filter = (opts?: DirectoryFilterOpts): Directory => {
-    const ctx = this._ctx.select("filter", { ...opts })
-    return new Directory(ctx)
+    const ctx = this._ctx.select("afterSize")
+
+    const response: Awaited<number> = await ctx.execute()
+
+    return response
   }
 
   /**
-   * Search up the directory tree for a file or directory, and return its path. If no match, return null
-   * @param name The name of the file or directory to search for
-   * @param start The path to start the search from
+   * The digest of the layer in the older container, if it has one at this position.
    */
-  findUp = async (name: string, start: string): Promise<string> => {
-    if (this._findUp) {
-      return this._findUp
+  beforeDigest = async (): Promise<string> => {
+    if (this._beforeDigest) {
+      return this._beforeDigest
     }
 
-    const ctx = this._ctx.select("findUp", { name, start })
+    const ctx = this._ctx.select("beforeDigest")
 
     const response: Awaited<string> = await ctx.execute()
 
@@ -4994,423 +5729,1297 @@ export class Directory extends BaseClient {
   }
 
   /**
-   * Returns a list of files and directories that matche the given pattern.
-   * @param pattern Pattern to match (e.g., "*.md").
+   * The compressed size of the layer in the older container, in bytes.
    */
-  glob = async (pattern: string): Promise<string[]> => {
-    const ctx = this._ctx.select("glob", { pattern })
+  beforeSize = async (): Promise<number> => {
+    if (this._beforeSize) {
+      return this._beforeSize
+    }
 
-    const response: Awaited<string[]> = await ctx.execute()
+    const ctx = this._ctx.select("beforeSize")
+
+    const response: Awaited<number> = await ctx.execute()
 
     return response
   }
 
   /**
-   * Returns the name of the directory.
+   * The position of the layer, starting from the base layer at 0.
    */
-  name = async (): Promise<string> => {
-    if (this._name) {
-      return this._name
+  index = async (): Promise<number> => {
+    if (this._index) {
+      return this._index
     }
 
-    const ctx = this._ctx.select("name")
+    const ctx = this._ctx.select("index")
 
-    const response: Awaited<string> = await ctx.execute()
+    const response: Awaited<number> = await ctx.execute()
 
     return response
   }
 
   /**
-   * Searches for content matching the given regular expression or literal string.
-   *
-   * Uses Rust regex syntax; escape literal ., [, ], {, }, | with backslashes.
-   * @param opts.paths Directory or file paths to search
-   * @param opts.globs Glob patterns to match (e.g., "*.md")
-   * @param opts.pattern The text to match.
-   * @param opts.literal Interpret the pattern as a literal string instead of a regular expression.
-   * @param opts.multiline Enable searching across multiple lines.
-   * @param opts.dotall Allow the . pattern to match newlines in multiline mode.
-   * @param opts.insensitive Enable case-insensitive matching.
-   * @param opts.skipIgnored Honor .gitignore, .ignore, and .rgignore files.
-   * @param opts.skipHidden Skip hidden files (files starting with .).
-   * @param opts.filesOnly Only return matching files, not lines and content
-   * @param opts.limit Limit the number of results to return
+   * Whether the layer differs between the containers.
    */
-  search = async (opts?: DirectorySearchOpts): Promise<SearchResult[]> => {
-    type search = {
-      id: SearchResultID
+  kind = async (): Promise<ContainerChangeKind> => {
+    if (this._kind) {
+      return this._kind
     }
 
-    const ctx = this._ctx.select("search", { ...opts }).select("id")
+    const ctx = this._ctx.select("kind")
 
-    const response: Awaited<search[]> = await ctx.execute()
+    const response: Awaited<ContainerChangeKind> = await ctx.execute()
 
-    return response.map((r) =>
-      new Client(ctx.copy()).loadSearchResultFromID(r.id),
-    )
+    return ContainerChangeKindNameToValue(response)
   }
+}
 
-  /**
-   * Return file status
-   * @param path Path to stat (e.g., "/file.txt").
-   * @param opts.doNotFollowSymlinks If specified, do not follow symlinks.
-   */
-  stat = (path: string, opts?: DirectoryStatOpts): Stat => {
-    const ctx = this._ctx.select("stat", { path, ...opts })
-    return new Stat(ctx)
-  }
+/**
+ * Reflective module API provided to functions at runtime.
+ */
+export class CurrentModule extends BaseClient {
+  private readonly _id?: CurrentModuleID = undefined
+  private readonly _name?: string = undefined
 
   /**
-   * Force evaluation in the engine.
+   * Constructor is used for internal usage only, do not create object from it.
    */
-  sync = async (): Promise<Directory> => {
-    const ctx = this._ctx.select("sync")
-
-    const response: Awaited<DirectoryID> = await ctx.execute()
+  constructor(ctx?: Context, _id?: CurrentModuleID, _name?: string) {
+    super(ctx)
 
-    return new Client(ctx.copy()).loadDirectoryFromID(response)
+    this._id = _id
+    this._name = _name
   }
 
   /**
-   * Opens an interactive terminal in new container with this directory mounted inside.
-   * @param opts.container If set, override the default container used for the terminal.
-   * @param opts.cmd If set, override the container's default terminal command and invoke these command arguments instead.
-   * @param opts.experimentalPrivilegedNesting Provides Dagger access to the executed command.
-   * @param opts.insecureRootCapabilities Execute the command with all root capabilities. This is similar to running a command with "sudo" or executing "docker run" with the "--privileged" flag. Containerization does not provide any security guarantees when using this option. It should only be used when absolutely necessary and only with trusted commands.
+   * A unique identifier for this CurrentModule.
    */
-  terminal = (opts?: DirectoryTerminalOpts): Directory => {
-    const ctx = this._ctx.select("terminal", { ...opts })
-    return new Directory(ctx)
+  id = async (): Promise<CurrentModuleID> => {
+    if (this._id) {
+      return this._id
+    }
+
+    const ctx = this._ctx.select("id")
+
+    const response: Awaited<CurrentModuleID> = await ctx.execute()
+
+    return response
   }
 
   /**
-   * Return a directory with changes from another directory applied to it.
-   * @param changes Changes to apply to the directory
+   * The dependencies of the module.
    */
-  withChanges = (changes: Changeset): Directory => {
-    const ctx = this._ctx.select("withChanges", { changes })
-    return new Directory(ctx)
+  dependencies = async (): Promise<Module_[]> => {
+    type dependencies = {
+      id: ModuleID
+    }
+
+    const ctx = this._ctx.select("dependencies").select("id")
+
+    const response: Awaited<dependencies[]> = await ctx.execute()
+
+    return response.map((r) => new Client(ctx.copy()).loadModuleFromID(r.id))
   }
 
   /**
-   * Return a snapshot with a directory added
-   * @param path Location of the written directory (e.g., "/src/").
-   * @param source Identifier of the directory to copy.
-   * @param opts.exclude Exclude artifacts that match the given pattern (e.g., ["node_modules/", ".git*"]).
-   * @param opts.include Include only artifacts that match the given pattern (e.g., ["app/", "package.*"]).
-   * @param opts.gitignore Apply .gitignore filter rules inside the directory
-   * @param opts.owner A user:group to set for the copied directory and its contents.
-   *
-   * The user and group must be an ID (1000:1000), not a name (foo:bar).
-   *
-   * If the group is omitted, it defaults to the same as the user.
+   * The generated files and directories made on top of the module source's context directory.
    */
-  withDirectory = (
-    path: string,
-    source: Directory,
-    opts?: DirectoryWithDirectoryOpts,
-  ): Directory => {
-    const ctx = this._ctx.select("withDirectory", { path, source, ...opts })
+  generatedContextDirectory = (): Directory => {
+    const ctx = this._ctx.select("generatedContextDirectory")
     return new Directory(ctx)
   }
 
   /**
-   * Raise an error.
-   * @param err Message of the error to raise. If empty, the error will be ignored.
+   * The name of the module being executed in
    */
-  withError = (err: string): Directory => {
-    const ctx = this._ctx.select("withError", { err })
-    return new Directory(ctx)
+  name = async (): Promise<string> => {
+    if (this._name) {
+      return this._name
+    }
+
+    const ctx = this._ctx.select("name")
+
+    const response: Awaited<string> = await ctx.execute()
+
+    return response
   }
 
   /**
-   * Retrieves this directory plus the contents of the given file copied to the given path.
-   * @param path Location of the copied file (e.g., "/file.txt").
-   * @param source Identifier of the file to copy.
-   * @param opts.permissions Permission given to the copied file (e.g., 0600).
-   * @param opts.owner A user:group to set for the copied directory and its contents.
-   *
-   * The user and group must be an ID (1000:1000), not a name (foo:bar).
-   *
-   * If the group is omitted, it defaults to the same as the user.
+   * The directory containing the module's source code loaded into the engine (plus any generated code that may have been created).
    */
-  withFile = (
-    path: string,
-    source: File,
-    opts?: DirectoryWithFileOpts,
-  ): Directory => {
-    const ctx = this._ctx.select("withFile", { path, source, ...opts })
+  source = (): Directory => {
+    const ctx = this._ctx.select("source")
     return new Directory(ctx)
   }
 
   /**
-   * Retrieves this directory plus the contents of the given files copied to the given path.
-   * @param path Location where copied files should be placed (e.g., "/src").
-   * @param sources Identifiers of the files to copy.
-   * @param opts.permissions Permission given to the copied files (e.g., 0600).
+   * Load a directory from the module's scratch working directory, including any changes that may have been made to it during module function execution.
+   * @param path Location of the directory to access (e.g., ".").
+   * @param opts.exclude Exclude artifacts that match the given pattern (e.g., ["node_modules/", ".git*"]).
+   * @param opts.include Include only artifacts that match the given pattern (e.g., ["app/", "package.*"]).
+   * @param opts.gitignore Apply .gitignore filter rules inside the directory
    */
-  withFiles = (
-    path: string,
-    sources: File[],
-    opts?: DirectoryWithFilesOpts,
-  ): Directory => {
-    const ctx = this._ctx.select("withFiles", { path, sources, ...opts })
+  workdir = (path: string, opts?: CurrentModuleWorkdirOpts): Directory => {
+    const ctx = this._ctx.select("workdir", { path, ...opts })
     return new Directory(ctx)
   }
 
   /**
-   * Retrieves this directory plus a new directory created at the given path.
-   * @param path Location of the directory created (e.g., "/logs").
-   * @param opts.permissions Permission granted to the created directory (e.g., 0777).
+   * Load a file from the module's scratch working directory, including any changes that may have been made to it during module function execution.Load a file from the module's scratch working directory, including any changes that may have been made to it during module function execution.
+   * @param path Location of the file to retrieve (e.g., "README.md").
    */
-  withNewDirectory = (
-    path: string,
-    opts?: DirectoryWithNewDirectoryOpts,
-  ): Directory => {
-    const ctx = this._ctx.select("withNewDirectory", { path, ...opts })
-    return new Directory(ctx)
+  workdirFile = (path: string): File => {
+    const ctx = this._ctx.select("workdirFile", { path })
+    return new File(ctx)
   }
+}
+
+/**
+ * A directory.
+ */
+export class Directory extends BaseClient {
+  private readonly _id?: DirectoryID = undefined
+  private readonly _digest?: string = undefined
+  private readonly _exists?: boolean = undefined
+  private readonly _export?: string = undefined
+  private readonly _findUp?: string = undefined
+  private readonly _name?: string = undefined
+  private readonly _sync?: DirectoryID = undefined
 
   /**
-   * Return a snapshot with a new file added
-   * @param path Path of the new file. Example: "foo/bar.txt"
-   * @param contents Contents of the new file. Example: "Hello world!"
-   * @param opts.permissions Permissions of the new file. Example: 0600
+   * Constructor is used for internal usage only, do not create object from it.
    */
-  withNewFile = (
-    path: string,
-    contents: string,
-    opts?: DirectoryWithNewFileOpts,
-  ): Directory => {
-    const ctx = this._ctx.select("withNewFile", { path, contents, ...opts })
-    return new Directory(ctx)
+  constructor(
+    ctx?: Context,
+    _id?: DirectoryID,
+    _digest?: string,
+    _exists?: boolean,
+    _export?: string,
+    _findUp?: string,
+    _name?: string,
+    _sync?: DirectoryID,
+  ) {
+    super(ctx)
+
+    this._id = _id
+    this._digest = _digest
+    this._exists = _exists
+    this._export = _export
+    this._findUp = _findUp
+    this._name = _name
+    this._sync = _sync
   }
 
   /**
-   * Retrieves this directory with the given Git-compatible patch applied.
-   * @param patch Patch to apply (e.g., "diff --git a/file.txt b/file.txt\nindex 1234567..abcdef8 100644\n--- a/file.txt\n+++ b/file.txt\n@@ -1,1 +1,1 @@\n-Hello\n+World\n").
-   * @experimental
+   * A unique identifier for this Directory.
    */
-  withPatch = (patch: string): Directory => {
-    const ctx = this._ctx.select("withPatch", { patch })
-    return new Directory(ctx)
+  id = async (): Promise<DirectoryID> => {
+    if (this._id) {
+      return this._id
+    }
+
+    const ctx = this._ctx.select("id")
+
+    const response: Awaited<DirectoryID> = await ctx.execute()
+
+    return response
   }
 
   /**
-   * Retrieves this directory with the given Git-compatible patch file applied.
-   * @param patch File containing the patch to apply
-   * @experimental
+   * Converts this directory to a local git repository
    */
-  withPatchFile = (patch: File): Directory => {
-    const ctx = this._ctx.select("withPatchFile", { patch })
-    return new Directory(ctx)
+  asGit = (): GitRepository => {
+    const ctx = this._ctx.select("asGit")
+    return new GitRepository(ctx)
   }
 
   /**
-   * Return a snapshot with a symlink
-   * @param target Location of the file or directory to link to (e.g., "/existing/file").
-   * @param linkName Location where the symbolic link will be created (e.g., "/new-file-link").
+   * Load the directory as a Dagger module source
+   * @param opts.sourceRootPath An optional subpath of the directory which contains the module's configuration file.
+   *
+   * If not set, the module source code is loaded from the root of the directory.
    */
-  withSymlink = (target: string, linkName: string): Directory => {
-    const ctx = this._ctx.select("withSymlink", { target, linkName })
-    return new Directory(ctx)
+  asModule = (opts?: DirectoryAsModuleOpts): Module_ => {
+    const ctx = this._ctx.select("asModule", { ...opts })
+    return new Module_(ctx)
   }
 
   /**
-   * Retrieves this directory with all file/dir timestamps set to the given time.
-   * @param timestamp Timestamp to set dir/files in.
+   * Load the directory as a Dagger module source
+   * @param opts.sourceRootPath An optional subpath of the directory which contains the module's configuration file.
    *
-   * Formatted in seconds following Unix epoch (e.g., 1672531199).
+   * If not set, the module source code is loaded from the root of the directory.
    */
-  withTimestamps = (timestamp: number): Directory => {
-    const ctx = this._ctx.select("withTimestamps", { timestamp })
-    return new Directory(ctx)
+  asModuleSource = (opts?: DirectoryAsModuleSourceOpts): ModuleSource => {
+    const ctx = this._ctx.select("asModuleSource", { ...opts })
+    return new ModuleSource(ctx)
   }
 
   /**
-   * Return a snapshot with a subdirectory removed
-   * @param path Path of the subdirectory to remove. Example: ".github/workflows"
+   * Return the difference between this directory and another directory, typically an older snapshot.
+   *
+   * The difference is encoded as a changeset, which also tracks removed files, and can be applied to other directories.
+   * @param from The base directory snapshot to compare against
    */
-  withoutDirectory = (path: string): Directory => {
-    const ctx = this._ctx.select("withoutDirectory", { path })
-    return new Directory(ctx)
+  changes = (from: Directory): Changeset => {
+    const ctx = this._ctx.select("changes", { from })
+    return new Changeset(ctx)
   }
 
   /**
-   * Return a snapshot with a file removed
-   * @param path Path of the file to remove (e.g., "/file.txt").
-   */
-  withoutFile = (path: string): Directory => {
-    const ctx = this._ctx.select("withoutFile", { path })
+   * Change the owner of the directory contents recursively.
+   * @param path Path of the directory to change ownership of (e.g., "/").
+   * @param owner A user:group to set for the mounted directory and its contents.
+   *
+   * The user and group must be an ID (1000:1000), not a name (foo:bar).
+   *
+   * If the group is omitted, it defaults to the same as the user.
+   */
+  chown = (path: string, owner: string): Directory => {
+    const ctx = this._ctx.select("chown", { path, owner })
+    return new Directory(ctx)
+  }
+
+  /**
+   * Return the difference between this directory and an another directory. The difference is encoded as a directory.
+   * @param other The directory to compare against
+   */
+  diff = (other: Directory): Directory => {
+    const ctx = this._ctx.select("diff", { other })
+    return new Directory(ctx)
+  }
+
+  /**
+   * Return the directory's digest. The format of the digest is not guaranteed to be stable between releases of Dagger. It is guaranteed to be stable between invocations of the same Dagger engine.
+   */
+  digest = async (): Promise<string> => {
+    if (this._digest) {
+      return this._digest
+    }
+
+    const ctx = this._ctx.select("digest")
+
+    const response: Awaited<string> = await ctx.execute()
+
+    return response
+  }
+
+  /**
+   * Retrieves a directory at the given path.
+   * @param path Location of the directory to retrieve. Example: "/src"
+   */
+  directory = (path: string): Directory => {
+    const ctx = this._ctx.select("directory", { path })
+    return new Directory(ctx)
+  }
+
+  /**
+   * Use Dockerfile compatibility to build a container from this directory. Only use this function for Dockerfile compatibility. Otherwise use the native Container type directly, it is feature-complete and supports all Dockerfile features.
+   * @param opts.dockerfile Path to the Dockerfile to use (e.g., "frontend.Dockerfile").
+   * @param opts.platform The platform to build.
+   * @param opts.buildArgs Build arguments to use in the build.
+   * @param opts.target Target build stage to build.
+   * @param opts.secrets Secrets to pass to the build.
+   *
+   * They will be mounted at /run/secrets/[secret-name].
+   * @param opts.noInit If set, skip the automatic init process injected into containers created by RUN statements.
+   *
+   * This should only be used if the user requires that their exec processes be the pid 1 process in the container. Otherwise it may result in unexpected behavior.
+   */
+  dockerBuild = (opts?: DirectoryDockerBuildOpts): Container => {
+    const ctx = this._ctx.select("dockerBuild", { ...opts })
+    return new Container(ctx)
+  }
+
+  /**
+   * Returns a list of files and directories at the given path.
+   * @param opts.path Location of the directory to look at (e.g., "/src").
+   */
+  entries = async (opts?: DirectoryEntriesOpts): Promise<string[]> => {
+    const ctx = this._ctx.select("entries", { ...opts })
+
+    const response: Awaited<string[]> = await ctx.execute()
+
+    return response
+  }
+
+  /**
+   * check if a file or directory exists
+   * @param path Path to check (e.g., "/file.txt").
+   * @param opts.expectedType If specified, also validate the type of file (e.g. "REGULAR_TYPE", "DIRECTORY_TYPE", or "SYMLINK_TYPE").
+   * @param opts.doNotFollowSymlinks If specified, do not follow symlinks.
+   */
+  exists = async (
+    path: string,
+    opts?: DirectoryExistsOpts,
+  ): Promise<boolean> => {
+    if (this._exists) {
+      return this._exists
+    }
+
+    const metadata = {
+      expectedType: { is_enum: true, value_to_name: ExistsTypeValueToName },
+    }
+
+    const ctx = this._ctx.select("exists", {
+      path,
+      ...opts,
+      __metadata: metadata,
+    })
+
+    const response: Awaited<boolean> = await ctx.execute()
+
+    return response
+  }
+
+  /**
+   * Writes the contents of the directory to a path on the host.
+   * @param path Location of the copied directory (e.g., "logs/").
+   * @param opts.wipe If true, then the host directory will be wiped clean before exporting so that it exactly matches the directory being exported; this means it will delete any files on the host that aren't in the exported dir. If false (the default), the contents of the directory will be merged with any existing contents of the host directory, leaving any existing files on the host that aren't in the exported directory alone.
+   */
+  export = async (
+    path: string,
+    opts?: DirectoryExportOpts,
+  ): Promise<string> => {
+    if (this._export) {
+      return this._export
+    }
+
+    const ctx = this._ctx.select("export", { path, ...opts })
+
+    const response: Awaited<string> = await ctx.execute()
+
+    return response
+  }
+
+  /**
+   * Retrieve a file at the given path.
+   * @param path Location of the file to retrieve (e.g., "README.md").
+   */
+  file = (path: string): File => {
+    const ctx = this._ctx.select("file", { path })
+    return new File(ctx)
+  }
+
+  /**
+   * Return a snapshot with some paths included or excluded
+   * @param opts.exclude If set, paths matching one of these glob patterns is excluded from the new snapshot. Example: ["node_modules/", ".git*", ".env"]
+   * @param opts.include If set, only paths matching one of these glob patterns is included in the new snapshot. Example: (e.g., ["app/", "package.*"]).
+   * @param opts.gitignore If set, apply .gitignore rules when filtering the directory.
+   */
+  filter = (opts?: DirectoryFilterOpts): Directory => {
+    const ctx = this._ctx.select("filter", { ...opts })
     return new Directory(ctx)
   }
 
   /**
-   * Return a snapshot with files removed
-   * @param paths Paths of the files to remove (e.g., ["/file.txt"]).
+   * Search up the directory tree for a file or directory, and return its path. If no match, return null
+   * @param name The name of the file or directory to search for
+   * @param start The path to start the search from
+   */
+  findUp = async (name: string, start: string): Promise<string> => {
+    if (this._findUp) {
+      return this._findUp
+    }
+
+    const ctx = this._ctx.select("findUp", { name, start })
+
+    const response: Awaited<string> = await ctx.execute()
+
+    return response
+  }
+
+  /**
+   * Returns a list of files and directories that matche the given pattern.
+   * @param pattern Pattern to match (e.g., "*.md").
+   */
+  glob = async (pattern: string): Promise<string[]> => {
+    const ctx = this._ctx.select("glob", { pattern })
+
+    const response: Awaited<string[]> = await ctx.execute()
+
+    return response
+  }
+
+  /**
+   * Returns the name of the directory.
+   */
+  name = async (): Promise<string> => {
+    if (this._name) {
+      return this._name
+    }
+
+    const ctx = this._ctx.select("name")
+
+    const response: Awaited<string> = await ctx.execute()
+
+    return response
+  }
+
+  /**
+   * Return a software bill of materials (SBOM) of the packages in the directory.
+   *
+   * OS packages installed with apk, dpkg or rpm are detected, as well as the dependencies in Go, npm, Python and Cargo lockfiles.
+   * @param opts.format Format of the SBOM.
+   */
+  sbom = (opts?: DirectorySbomOpts): File => {
+    const metadata = {
+      format: { is_enum: true, value_to_name: SBOMFormatValueToName },
+    }
+
+    const ctx = this._ctx.select("sbom", { ...opts, __metadata: metadata })
+    return new File(ctx)
+  }
+
+  /**
+   * Searches for content matching the given regular expression or literal string.
+   *
+   * Uses Rust regex syntax; escape literal ., [, ], {, }, | with backslashes.
+   * @param opts.paths Directory or file paths to search
+   * @param opts.globs Glob patterns to match (e.g., "*.md")
+   * @param opts.pattern The text to match.
+   * @param opts.literal Interpret the pattern as a literal string instead of a regular expression.
+   * @param opts.multiline Enable searching across multiple lines.
+   * @param opts.dotall Allow the . pattern to match newlines in multiline mode.
+   * @param opts.insensitive Enable case-insensitive matching.
+   * @param opts.skipIgnored Honor .gitignore, .ignore, and .rgignore files.
+   * @param opts.skipHidden Skip hidden files (files starting with .).
+   * @param opts.filesOnly Only return matching files, not lines and content
+   * @param opts.limit Limit the number of results to return
+   */
+  search = async (opts?: DirectorySearchOpts): Promise<SearchResult[]> => {
+    type search = {
+      id: SearchResultID
+    }
+
+    const ctx = this._ctx.select("search", { ...opts }).select("id")
+
+    const response: Awaited<search[]> = await ctx.execute()
+
+    return response.map((r) =>
+      new Client(ctx.copy()).loadSearchResultFromID(r.id),
+    )
+  }
+
+  /**
+   * Return file status
+   * @param path Path to stat (e.g., "/file.txt").
+   * @param opts.doNotFollowSymlinks If specified, do not follow symlinks.
+   */
+  stat = (path: string, opts?: DirectoryStatOpts): Stat => {
+    const ctx = this._ctx.select("stat", { path, ...opts })
+    return new Stat(ctx)
+  }
+
+  /**
+   * Force evaluation in the engine.
+   */
+  sync = async (): Promise<Directory> => {
+    const ctx = this._ctx.select("sync")
+
+    const response: Awaited<DirectoryID> = await ctx.execute()
+
+    return new Client(ctx.copy()).loadDirectoryFromID(response)
+  }
+
+  /**
+   * Opens an interactive terminal in new container with this directory mounted inside.
+   * @param opts.container If set, override the default container used for the terminal.
+   * @param opts.cmd If set, override the container's default terminal command and invoke these command arguments instead.
+   * @param opts.experimentalPrivilegedNesting Provides Dagger access to the executed command.
+   * @param opts.insecureRootCapabilities Execute the command with all root capabilities. This is similar to running a command with "sudo" or executing "docker run" with the "--privileged" flag. Containerization does not provide any security guarantees when using this option. It should only be used when absolutely necessary and only with trusted commands.
+   */
+  terminal = (opts?: DirectoryTerminalOpts): Directory => {
+    const ctx = this._ctx.select("terminal", { ...opts })
+    return new Directory(ctx)
+  }
+
+  /**
+   * Return a directory with changes from another directory applied to it.
+   * @param changes Changes to apply to the directory
+   */
+  withChanges = (changes: Changeset): Directory => {
+    const ctx = this._ctx.select("withChanges", { changes })
+    return new Directory(ctx)
+  }
+
+  /**
+   * Return a snapshot with a directory added
+   * @param path Location of the written directory (e.g., "/src/").
+   * @param source Identifier of the directory to copy.
+   * @param opts.exclude Exclude artifacts that match the given pattern (e.g., ["node_modules/", ".git*"]).
+   * @param opts.include Include only artifacts that match the given pattern (e.g., ["app/", "package.*"]).
+   * @param opts.gitignore Apply .gitignore filter rules inside the directory
+   * @param opts.owner A user:group to set for the copied directory and its contents.
+   *
+   * The user and group must be an ID (1000:1000), not a name (foo:bar).
+   *
+   * If the group is omitted, it defaults to the same as the user.
+   */
+  withDirectory = (
+    path: string,
+    source: Directory,
+    opts?: DirectoryWithDirectoryOpts,
+  ): Directory => {
+    const ctx = this._ctx.select("withDirectory", { path, source, ...opts })
+    return new Directory(ctx)
+  }
+
+  /**
+   * Raise an error.
+   * @param err Message of the error to raise. If empty, the error will be ignored.
+   */
+  withError = (err: string): Directory => {
+    const ctx = this._ctx.select("withError", { err })
+    return new Directory(ctx)
+  }
+
+  /**
+   * Retrieves this directory plus the contents of the given file copied to the given path.
+   * @param path Location of the copied file (e.g., "/file.txt").
+   * @param source Identifier of the file to copy.
+   * @param opts.permissions Permission given to the copied file (e.g., 0600).
+   * @param opts.owner A user:group to set for the copied directory and its contents.
+   *
+   * The user and group must be an ID (1000:1000), not a name (foo:bar).
+   *
+   * If the group is omitted, it defaults to the same as the user.
+   */
+  withFile = (
+    path: string,
+    source: File,
+    opts?: DirectoryWithFileOpts,
+  ): Directory => {
+    const ctx = this._ctx.select("withFile", { path, source, ...opts })
+    return new Directory(ctx)
+  }
+
+  /**
+   * Retrieves this directory plus the contents of the given files copied to the given path.
+   * @param path Location where copied files should be placed (e.g., "/src").
+   * @param sources Identifiers of the files to copy.
+   * @param opts.permissions Permission given to the copied files (e.g., 0600).
+   */
+  withFiles = (
+    path: string,
+    sources: File[],
+    opts?: DirectoryWithFilesOpts,
+  ): Directory => {
+    const ctx = this._ctx.select("withFiles", { path, sources, ...opts })
+    return new Directory(ctx)
+  }
+
+  /**
+   * Retrieves this directory plus a new directory created at the given path.
+   * @param path Location of the directory created (e.g., "/logs").
+   * @param opts.permissions Permission granted to the created directory (e.g., 0777).
+   */
+  withNewDirectory = (
+    path: string,
+    opts?: DirectoryWithNewDirectoryOpts,
+  ): Directory => {
+    const ctx = this._ctx.select("withNewDirectory", { path, ...opts })
+    return new Directory(ctx)
+  }
+
+  /**
+   * Return a snapshot with a new file added
+   * @param path Path of the new file. Example: "foo/bar.txt"
+   * @param contents Contents of the new file. Example: "Hello world!"
+   * @param opts.permissions Permissions of the new file. Example: 0600
+   */
+  withNewFile = (
+    path: string,
+    contents: string,
+    opts?: DirectoryWithNewFileOpts,
+  ): Directory => {
+    const ctx = this._ctx.select("withNewFile", { path, contents, ...opts })
+    return new Directory(ctx)
+  }
+
+  /**
+   * Retrieves this directory with the given Git-compatible patch applied.
+   * @param patch Patch to apply (e.g., "diff --git a/file.txt b/file.txt\nindex 1234567..abcdef8 100644\n--- a/file.txt\n+++ b/file.txt\n@@ -1,1 +1,1 @@\n-Hello\n+World\n").
+   * @experimental
+   */
+  withPatch = (patch: string): Directory => {
+    const ctx = this._ctx.select("withPatch", { patch })
+    return new Directory(ctx)
+  }
+
+  /**
+   * Retrieves this directory with the given Git-compatible patch file applied.
+   * @param patch File containing the patch to apply
+   * @experimental
+   */
+  withPatchFile = (patch: File): Directory => {
+    const ctx = this._ctx.select("withPatchFile", { patch })
+    return new Directory(ctx)
+  }
+
+  /**
+   * Return a snapshot with a symlink
+   * @param target Location of the file or directory to link to (e.g., "/existing/file").
+   * @param linkName Location where the symbolic link will be created (e.g., "/new-file-link").
+   */
+  withSymlink = (target: string, linkName: string): Directory => {
+    const ctx = this._ctx.select("withSymlink", { target, linkName })
+    return new Directory(ctx)
+  }
+
+  /**
+   * Retrieves this directory with all file/dir timestamps set to the given time.
+   * @param timestamp Timestamp to set dir/files in.
+   *
+   * Formatted in seconds following Unix epoch (e.g., 1672531199).
+   */
+  withTimestamps = (timestamp: number): Directory => {
+    const ctx = this._ctx.select("withTimestamps", { timestamp })
+    return new Directory(ctx)
+  }
+
+  /**
+   * Return a snapshot with a subdirectory removed
+   * @param path Path of the subdirectory to remove. Example: ".github/workflows"
+   */
+  withoutDirectory = (path: string): Directory => {
+    const ctx = this._ctx.select("withoutDirectory", { path })
+    return new Directory(ctx)
+  }
+
+  /**
+   * Return a snapshot with a file removed
+   * @param path Path of the file to remove (e.g., "/file.txt").
+   */
+  withoutFile = (path: string): Directory => {
+    const ctx = this._ctx.select("withoutFile", { path })
+    return new Directory(ctx)
+  }
+
+  /**
+   * Return a snapshot with files removed
+   * @param paths Paths of the files to remove (e.g., ["/file.txt"]).
+   */
+  withoutFiles = (paths: string[]): Directory => {
+    const ctx = this._ctx.select("withoutFiles", { paths })
+    return new Directory(ctx)
+  }
+
+  /**
+   * Call the provided function with current Directory.
+   *
+   * This is useful for reusability and readability by not breaking the calling chain.
+   */
+  with = (arg: (param: Directory) => Directory) => {
+    return arg(this)
+  }
+}
+
+/**
+ * The Dagger engine configuration and state
+ */
+export class Engine extends BaseClient {
+  private readonly _id?: EngineID = undefined
+  private readonly _name?: string = undefined
+
+  /**
+   * Constructor is used for internal usage only, do not create object from it.
+   */
+  constructor(ctx?: Context, _id?: EngineID, _name?: string) {
+    super(ctx)
+
+    this._id = _id
+    this._name = _name
+  }
+
+  /**
+   * A unique identifier for this Engine.
+   */
+  id = async (): Promise<EngineID> => {
+    if (this._id) {
+      return this._id
+    }
+
+    const ctx = this._ctx.select("id")
+
+    const response: Awaited<EngineID> = await ctx.execute()
+
+    return response
+  }
+
+  /**
+   * The list of connected client IDs
+   */
+  clients = async (): Promise<string[]> => {
+    const ctx = this._ctx.select("clients")
+
+    const response: Awaited<string[]> = await ctx.execute()
+
+    return response
+  }
+
+  /**
+   * The local (on-disk) cache for the Dagger engine
+   */
+  localCache = (): EngineCache => {
+    const ctx = this._ctx.select("localCache")
+    return new EngineCache(ctx)
+  }
+
+  /**
+   * The name of the engine instance.
+   */
+  name = async (): Promise<string> => {
+    if (this._name) {
+      return this._name
+    }
+
+    const ctx = this._ctx.select("name")
+
+    const response: Awaited<string> = await ctx.execute()
+
+    return response
+  }
+
+  /**
+   * The list of persistent services running in the engine, shared across sessions
+   */
+  services = async (): Promise<EngineService[]> => {
+    type services = {
+      id: EngineServiceID
+    }
+
+    const ctx = this._ctx.select("services").select("id")
+
+    const response: Awaited<services[]> = await ctx.execute()
+
+    return response.map((r) =>
+      new Client(ctx.copy()).loadEngineServiceFromID(r.id),
+    )
+  }
+
+  /**
+   * The list of active sessions, across all clients connected to the engine
+   */
+  sessions = async (): Promise<EngineSession[]> => {
+    type sessions = {
+      id: string
+    }
+
+    const ctx = this._ctx.select("sessions").select("id")
+
+    const response: Awaited<sessions[]> = await ctx.execute()
+
+    return response.map((r) =>
+      new Client(ctx.copy()).loadEngineSessionFromID(r.id),
+    )
+  }
+}
+
+/**
+ * A cache storage for the Dagger engine
+ */
+export class EngineCache extends BaseClient {
+  private readonly _id?: EngineCacheID = undefined
+  private readonly _maxUsedSpace?: number = undefined
+  private readonly _minFreeSpace?: number = undefined
+  private readonly _pin?: Void = undefined
+  private readonly _prune?: Void = undefined
+  private readonly _reservedSpace?: number = undefined
+  private readonly _targetSpace?: number = undefined
+  private readonly _unpin?: Void = undefined
+
+  /**
+   * Constructor is used for internal usage only, do not create object from it.
+   */
+  constructor(
+    ctx?: Context,
+    _id?: EngineCacheID,
+    _maxUsedSpace?: number,
+    _minFreeSpace?: number,
+    _pin?: Void,
+    _prune?: Void,
+    _reservedSpace?: number,
+    _targetSpace?: number,
+    _unpin?: Void,
+  ) {
+    super(ctx)
+
+    this._id = _id
+    this._maxUsedSpace = _maxUsedSpace
+    this._minFreeSpace = _minFreeSpace
+    this._pin = _pin
+    this._prune = _prune
+    this._reservedSpace = _reservedSpace
+    this._targetSpace = _targetSpace
+    this._unpin = _unpin
+  }
+
+  /**
+   * A unique identifier for this EngineCache.
+   */
+  id = async (): Promise<EngineCacheID> => {
+    if (this._id) {
+      return this._id
+    }
+
+    const ctx = this._ctx.select("id")
+
+    const response: Awaited<EngineCacheID> = await ctx.execute()
+
+    return response
+  }
+
+  /**
+   * The current set of entries in the cache
+   */
+  entrySet = (opts?: EngineCacheEntrySetOpts): EngineCacheEntrySet => {
+    const ctx = this._ctx.select("entrySet", { ...opts })
+    return new EngineCacheEntrySet(ctx)
+  }
+
+  /**
+   * The maximum bytes to keep in the cache without pruning.
+   */
+  maxUsedSpace = async (): Promise<number> => {
+    if (this._maxUsedSpace) {
+      return this._maxUsedSpace
+    }
+
+    const ctx = this._ctx.select("maxUsedSpace")
+
+    const response: Awaited<number> = await ctx.execute()
+
+    return response
+  }
+
+  /**
+   * The target amount of free disk space the garbage collector will attempt to leave.
+   */
+  minFreeSpace = async (): Promise<number> => {
+    if (this._minFreeSpace) {
+      return this._minFreeSpace
+    }
+
+    const ctx = this._ctx.select("minFreeSpace")
+
+    const response: Awaited<number> = await ctx.execute()
+
+    return response
+  }
+
+  /**
+   * Pin a cache entry, so that it's never pruned
+   * @param recordId The record ID of the cache entry to pin.
+   */
+  pin = async (recordId: string): Promise<void> => {
+    if (this._pin) {
+      return
+    }
+
+    const ctx = this._ctx.select("pin", { recordId })
+
+    await ctx.execute()
+  }
+
+  /**
+   * Prune the cache of releaseable entries
+   * @param opts.useDefaultPolicy Use the engine-wide default pruning policy if true, otherwise prune the whole cache of any releasable entries.
+   */
+  prune = async (opts?: EngineCachePruneOpts): Promise<void> => {
+    if (this._prune) {
+      return
+    }
+
+    const ctx = this._ctx.select("prune", { ...opts })
+
+    await ctx.execute()
+  }
+
+  /**
+   * The minimum amount of disk space this policy is guaranteed to retain.
+   */
+  reservedSpace = async (): Promise<number> => {
+    if (this._reservedSpace) {
+      return this._reservedSpace
+    }
+
+    const ctx = this._ctx.select("reservedSpace")
+
+    const response: Awaited<number> = await ctx.execute()
+
+    return response
+  }
+
+  /**
+   * The target number of bytes to keep when pruning.
+   */
+  targetSpace = async (): Promise<number> => {
+    if (this._targetSpace) {
+      return this._targetSpace
+    }
+
+    const ctx = this._ctx.select("targetSpace")
+
+    const response: Awaited<number> = await ctx.execute()
+
+    return response
+  }
+
+  /**
+   * Unpin a cache entry, so that it can be pruned again
+   *
+   * The entries of a pinned cache volume are pinned again when the volume is used; unpin the volume itself with CacheVolume.unpin instead.
+   * @param recordId The record ID of the cache entry to unpin.
+   */
+  unpin = async (recordId: string): Promise<void> => {
+    if (this._unpin) {
+      return
+    }
+
+    const ctx = this._ctx.select("unpin", { recordId })
+
+    await ctx.execute()
+  }
+}
+
+/**
+ * An individual cache entry in a cache entry set
+ */
+export class EngineCacheEntry extends BaseClient {
+  private readonly _id?: EngineCacheEntryID = undefined
+  private readonly _activelyUsed?: boolean = undefined
+  private readonly _createdTimeUnixNano?: number = undefined
+  private readonly _description?: string = undefined
+  private readonly _diskSpaceBytes?: number = undefined
+  private readonly _mostRecentUseTimeUnixNano?: number = undefined
+  private readonly _pinned?: boolean = undefined
+  private readonly _recordId?: string = undefined
+
+  /**
+   * Constructor is used for internal usage only, do not create object from it.
+   */
+  constructor(
+    ctx?: Context,
+    _id?: EngineCacheEntryID,
+    _activelyUsed?: boolean,
+    _createdTimeUnixNano?: number,
+    _description?: string,
+    _diskSpaceBytes?: number,
+    _mostRecentUseTimeUnixNano?: number,
+    _pinned?: boolean,
+    _recordId?: string,
+  ) {
+    super(ctx)
+
+    this._id = _id
+    this._activelyUsed = _activelyUsed
+    this._createdTimeUnixNano = _createdTimeUnixNano
+    this._description = _description
+    this._diskSpaceBytes = _diskSpaceBytes
+    this._mostRecentUseTimeUnixNano = _mostRecentUseTimeUnixNano
+    this._pinned = _pinned
+    this._recordId = _recordId
+  }
+
+  /**
+   * A unique identifier for this EngineCacheEntry.
+   */
+  id = async (): Promise<EngineCacheEntryID> => {
+    if (this._id) {
+      return this._id
+    }
+
+    const ctx = this._ctx.select("id")
+
+    const response: Awaited<EngineCacheEntryID> = await ctx.execute()
+
+    return response
+  }
+
+  /**
+   * Whether the cache entry is actively being used.
+   */
+  activelyUsed = async (): Promise<boolean> => {
+    if (this._activelyUsed) {
+      return this._activelyUsed
+    }
+
+    const ctx = this._ctx.select("activelyUsed")
+
+    const response: Awaited<boolean> = await ctx.execute()
+
+    return response
+  }
+
+  /**
+   * The time the cache entry was created, in Unix nanoseconds.
+   */
+  createdTimeUnixNano = async (): Promise<number> => {
+    if (this._createdTimeUnixNano) {
+      return this._createdTimeUnixNano
+    }
+
+    const ctx = this._ctx.select("createdTimeUnixNano")
+
+    const response: Awaited<number> = await ctx.execute()
+
+    return response
+  }
+
+  /**
+   * The description of the cache entry.
+   */
+  description = async (): Promise<string> => {
+    if (this._description) {
+      return this._description
+    }
+
+    const ctx = this._ctx.select("description")
+
+    const response: Awaited<string> = await ctx.execute()
+
+    return response
+  }
+
+  /**
+   * The disk space used by the cache entry.
+   */
+  diskSpaceBytes = async (): Promise<number> => {
+    if (this._diskSpaceBytes) {
+      return this._diskSpaceBytes
+    }
+
+    const ctx = this._ctx.select("diskSpaceBytes")
+
+    const response: Awaited<number> = await ctx.execute()
+
+    return response
+  }
+
+  /**
+   * The most recent time the cache entry was used, in Unix nanoseconds.
+   */
+  mostRecentUseTimeUnixNano = async (): Promise<number> => {
+    if (this._mostRecentUseTimeUnixNano) {
+      return this._mostRecentUseTimeUnixNano
+    }
+
+    const ctx = this._ctx.select("mostRecentUseTimeUnixNano")
+
+    const response: Awaited<number> = await ctx.execute()
+
+    return response
+  }
+
+  /**
+   * Whether the cache entry is pinned, exempting it from pruning.
    */
-  withoutFiles = (paths: string[]): Directory => {
-    const ctx = this._ctx.select("withoutFiles", { paths })
-    return new Directory(ctx)
+  pinned = async (): Promise<boolean> => {
+    if (this._pinned) {
+      return this._pinned
+    }
+
+    const ctx = this._ctx.select("pinned")
+
+    const response: Awaited<boolean> = await ctx.execute()
+
+    return response
   }
 
   /**
-   * Call the provided function with current Directory.
-   *
-   * This is useful for reusability and readability by not breaking the calling chain.
+   * The ID of the underlying cache record, which stays the same across sessions and engine restarts.
    */
-  with = (arg: (param: Directory) => Directory) => {
-    return arg(this)
+  recordId = async (): Promise<string> => {
+    if (this._recordId) {
+      return this._recordId
+    }
+
+    const ctx = this._ctx.select("recordId")
+
+    const response: Awaited<string> = await ctx.execute()
+
+    return response
   }
 }
 
 /**
- * The Dagger engine configuration and state
+ * A set of cache entries returned by a query to a cache
  */
-export class Engine extends BaseClient {
-  private readonly _id?: EngineID = undefined
-  private readonly _name?: string = undefined
+export class EngineCacheEntrySet extends BaseClient {
+  private readonly _id?: EngineCacheEntrySetID = undefined
+  private readonly _diskSpaceBytes?: number = undefined
+  private readonly _entryCount?: number = undefined
 
   /**
    * Constructor is used for internal usage only, do not create object from it.
    */
-  constructor(ctx?: Context, _id?: EngineID, _name?: string) {
+  constructor(
+    ctx?: Context,
+    _id?: EngineCacheEntrySetID,
+    _diskSpaceBytes?: number,
+    _entryCount?: number,
+  ) {
     super(ctx)
 
     this._id = _id
-    this._name = _name
+    this._diskSpaceBytes = _diskSpaceBytes
+    this._entryCount = _entryCount
   }
 
   /**
-   * A unique identifier for this Engine.
+   * A unique identifier for this EngineCacheEntrySet.
    */
-  id = async (): Promise<EngineID> => {
+  id = async (): Promise<EngineCacheEntrySetID> => {
     if (this._id) {
       return this._id
     }
 
     const ctx = this._ctx.select("id")
 
-    const response: Awaited<EngineID> = await ctx.execute()
+    const response: Awaited<EngineCacheEntrySetID> = await ctx.execute()
 
     return response
   }
 
   /**
-   * The list of connected client IDs
+   * The total disk space used by the cache entries in this set.
    */
-  clients = async (): Promise<string[]> => {
-    const ctx = this._ctx.select("clients")
+  diskSpaceBytes = async (): Promise<number> => {
+    if (this._diskSpaceBytes) {
+      return this._diskSpaceBytes
+    }
 
-    const response: Awaited<string[]> = await ctx.execute()
+    const ctx = this._ctx.select("diskSpaceBytes")
+
+    const response: Awaited<number> = await ctx.execute()
 
     return response
   }
 
   /**
-   * The local (on-disk) cache for the Dagger engine
+   * The list of individual cache entries in the set
    */
-  localCache = (): EngineCache => {
-    const ctx = this._ctx.select("localCache")
-    return new EngineCache(ctx)
+  entries = async (): Promise<EngineCacheEntry[]> => {
+    type entries = {
+      id: EngineCacheEntryID
+    }
+
+    const ctx = this._ctx.select("entries").select("id")
+
+    const response: Awaited<entries[]> = await ctx.execute()
+
+    return response.map((r) =>
+      new Client(ctx.copy()).loadEngineCacheEntryFromID(r.id),
+    )
   }
 
   /**
-   * The name of the engine instance.
+   * The number of cache entries in this set.
    */
-  name = async (): Promise<string> => {
-    if (this._name) {
-      return this._name
+  entryCount = async (): Promise<number> => {
+    if (this._entryCount) {
+      return this._entryCount
     }
 
-    const ctx = this._ctx.select("name")
+    const ctx = this._ctx.select("entryCount")
 
-    const response: Awaited<string> = await ctx.execute()
+    const response: Awaited<number> = await ctx.execute()
 
     return response
   }
 }
 
 /**
- * A cache storage for the Dagger engine
+ * A persistent service running in the Dagger engine, shared across sessions
  */
-export class EngineCache extends BaseClient {
-  private readonly _id?: EngineCacheID = undefined
-  private readonly _maxUsedSpace?: number = undefined
-  private readonly _minFreeSpace?: number = undefined
-  private readonly _prune?: Void = undefined
-  private readonly _reservedSpace?: number = undefined
-  private readonly _targetSpace?: number = undefined
+export class EngineService extends BaseClient {
+  private readonly _id?: EngineServiceID = undefined
+  private readonly _bindings?: number = undefined
+  private readonly _hostname?: string = undefined
+  private readonly _idleSinceUnixNano?: number = undefined
+  private readonly _idleTimeout?: string = undefined
+  private readonly _name?: string = undefined
+  private readonly _startedUnixNano?: number = undefined
 
   /**
    * Constructor is used for internal usage only, do not create object from it.
    */
   constructor(
     ctx?: Context,
-    _id?: EngineCacheID,
-    _maxUsedSpace?: number,
-    _minFreeSpace?: number,
-    _prune?: Void,
-    _reservedSpace?: number,
-    _targetSpace?: number,
+    _id?: EngineServiceID,
+    _bindings?: number,
+    _hostname?: string,
+    _idleSinceUnixNano?: number,
+    _idleTimeout?: string,
+    _name?: string,
+    _startedUnixNano?: number,
   ) {
     super(ctx)
 
     this._id = _id
-    this._maxUsedSpace = _maxUsedSpace
-    this._minFreeSpace = _minFreeSpace
-    this._prune = _prune
-    this._reservedSpace = _reservedSpace
-    this._targetSpace = _targetSpace
+    this._bindings = _bindings
+    this._hostname = _hostname
+    this._idleSinceUnixNano = _idleSinceUnixNano
+    this._idleTimeout = _idleTimeout
+    this._name = _name
+    this._startedUnixNano = _startedUnixNano
   }
 
   /**
-   * A unique identifier for this EngineCache.
+   * A unique identifier for this EngineService.
    */
-  id = async (): Promise<EngineCacheID> => {
+  id = async (): Promise<EngineServiceID> => {
     if (this._id) {
       return this._id
     }
 
     const ctx = this._ctx.select("id")
 
-    const response: Awaited<EngineCacheID> = await ctx.execute()
+    const response: Awaited<EngineServiceID> = await ctx.execute()
 
     return response
   }
 
   /**
-   * The current set of entries in the cache
+   * The number of bindings currently using the service, across sessions.
    */
-  entrySet = (opts?: EngineCacheEntrySetOpts): EngineCacheEntrySet => {
-    const ctx = this._ctx.select("entrySet", { ...opts })
-    return new EngineCacheEntrySet(ctx)
+  bindings = async (): Promise<number> => {
+    if (this._bindings) {
+      return this._bindings
+    }
+
+    const ctx = this._ctx.select("bindings")
+
+    const response: Awaited<number> = await ctx.execute()
+
+    return response
   }
 
   /**
-   * The maximum bytes to keep in the cache without pruning.
+   * The hostname to reach the service from any session.
    */
-  maxUsedSpace = async (): Promise<number> => {
-    if (this._maxUsedSpace) {
-      return this._maxUsedSpace
+  hostname = async (): Promise<string> => {
+    if (this._hostname) {
+      return this._hostname
     }
 
-    const ctx = this._ctx.select("maxUsedSpace")
+    const ctx = this._ctx.select("hostname")
 
-    const response: Awaited<number> = await ctx.execute()
+    const response: Awaited<string> = await ctx.execute()
 
     return response
   }
 
   /**
-   * The target amount of free disk space the garbage collector will attempt to leave.
+   * The time the service became unused, in Unix nanoseconds, or 0 if it's in use.
    */
-  minFreeSpace = async (): Promise<number> => {
-    if (this._minFreeSpace) {
-      return this._minFreeSpace
+  idleSinceUnixNano = async (): Promise<number> => {
+    if (this._idleSinceUnixNano) {
+      return this._idleSinceUnixNano
     }
 
-    const ctx = this._ctx.select("minFreeSpace")
+    const ctx = this._ctx.select("idleSinceUnixNano")
 
     const response: Awaited<number> = await ctx.execute()
 
@@ -5418,43 +7027,55 @@ export class EngineCache extends BaseClient {
   }
 
   /**
-   * Prune the cache of releaseable entries
-   * @param opts.useDefaultPolicy Use the engine-wide default pruning policy if true, otherwise prune the whole cache of any releasable entries.
+   * How long the service keeps running while unused (e.g., "30m"), or empty if it runs until stopped.
    */
-  prune = async (opts?: EngineCachePruneOpts): Promise<void> => {
-    if (this._prune) {
-      return
+  idleTimeout = async (): Promise<string> => {
+    if (this._idleTimeout) {
+      return this._idleTimeout
     }
 
-    const ctx = this._ctx.select("prune", { ...opts })
+    const ctx = this._ctx.select("idleTimeout")
 
-    await ctx.execute()
+    const response: Awaited<string> = await ctx.execute()
+
+    return response
   }
 
   /**
-   * The minimum amount of disk space this policy is guaranteed to retain.
+   * The name the service was started with.
    */
-  reservedSpace = async (): Promise<number> => {
-    if (this._reservedSpace) {
-      return this._reservedSpace
+  name = async (): Promise<string> => {
+    if (this._name) {
+      return this._name
     }
 
-    const ctx = this._ctx.select("reservedSpace")
+    const ctx = this._ctx.select("name")
 
-    const response: Awaited<number> = await ctx.execute()
+    const response: Awaited<string> = await ctx.execute()
 
     return response
   }
 
   /**
-   * The target number of bytes to keep when pruning.
+   * The ports exposed by the service.
    */
-  targetSpace = async (): Promise<number> => {
-    if (this._targetSpace) {
-      return this._targetSpace
+  ports = async (): Promise<number[]> => {
+    const ctx = this._ctx.select("ports")
+
+    const response: Awaited<number[]> = await ctx.execute()
+
+    return response
+  }
+
+  /**
+   * The time the service started, in Unix nanoseconds.
+   */
+  startedUnixNano = async (): Promise<number> => {
+    if (this._startedUnixNano) {
+      return this._startedUnixNano
     }
 
-    const ctx = this._ctx.select("targetSpace")
+    const ctx = this._ctx.select("startedUnixNano")
 
     const response: Awaited<number> = await ctx.execute()
 
@@ -5463,77 +7084,71 @@ export class EngineCache extends BaseClient {
 }
 
 /**
- * An individual cache entry in a cache entry set
+ * A session of a client connected to the Dagger engine
  */
-export class EngineCacheEntry extends BaseClient {
-  private readonly _id?: EngineCacheEntryID = undefined
-  private readonly _activelyUsed?: boolean = undefined
-  private readonly _createdTimeUnixNano?: number = undefined
-  private readonly _description?: string = undefined
-  private readonly _diskSpaceBytes?: number = undefined
-  private readonly _mostRecentUseTimeUnixNano?: number = undefined
+export class EngineSession extends BaseClient {
+  private readonly _id?: string = undefined
+  private readonly _cacheHits?: number = undefined
+  private readonly _cacheMisses?: number = undefined
+  private readonly _clientHostname?: string = undefined
+  private readonly _clientId?: string = undefined
+  private readonly _clientVersion?: string = undefined
+  private readonly _command?: string = undefined
+  private readonly _runningExecs?: number = undefined
+  private readonly _startedUnixNano?: number = undefined
 
   /**
    * Constructor is used for internal usage only, do not create object from it.
    */
   constructor(
     ctx?: Context,
-    _id?: EngineCacheEntryID,
-    _activelyUsed?: boolean,
-    _createdTimeUnixNano?: number,
-    _description?: string,
-    _diskSpaceBytes?: number,
-    _mostRecentUseTimeUnixNano?: number,
+    _id?: string,
+    _cacheHits?: number,
+    _cacheMisses?: number,
+    _clientHostname?: string,
+    _clientId?: string,
+    _clientVersion?: string,
+    _command?: string,
+    _runningExecs?: number,
+    _startedUnixNano?: number,
   ) {
     super(ctx)
 
     this._id = _id
-    this._activelyUsed = _activelyUsed
-    this._createdTimeUnixNano = _createdTimeUnixNano
-    this._description = _description
-    this._diskSpaceBytes = _diskSpaceBytes
-    this._mostRecentUseTimeUnixNano = _mostRecentUseTimeUnixNano
+    this._cacheHits = _cacheHits
+    this._cacheMisses = _cacheMisses
+    this._clientHostname = _clientHostname
+    this._clientId = _clientId
+    this._clientVersion = _clientVersion
+    this._command = _command
+    this._runningExecs = _runningExecs
+    this._startedUnixNano = _startedUnixNano
   }
 
   /**
-   * A unique identifier for this EngineCacheEntry.
+   * The ID of the session.
    */
-  id = async (): Promise<EngineCacheEntryID> => {
+  id = async (): Promise<string> => {
     if (this._id) {
       return this._id
     }
 
     const ctx = this._ctx.select("id")
 
-    const response: Awaited<EngineCacheEntryID> = await ctx.execute()
-
-    return response
-  }
-
-  /**
-   * Whether the cache entry is actively being used.
-   */
-  activelyUsed = async (): Promise<boolean> => {
-    if (this._activelyUsed) {
-      return this._activelyUsed
-    }
-
-    const ctx = this._ctx.select("activelyUsed")
-
-    const response: Awaited<boolean> = await ctx.execute()
+    const response: Awaited<string> = await ctx.execute()
 
     return response
   }
 
   /**
-   * The time the cache entry was created, in Unix nanoseconds.
+   * The number of cacheable calls in the session that were cached.
    */
-  createdTimeUnixNano = async (): Promise<number> => {
-    if (this._createdTimeUnixNano) {
-      return this._createdTimeUnixNano
+  cacheHits = async (): Promise<number> => {
+    if (this._cacheHits) {
+      return this._cacheHits
     }
 
-    const ctx = this._ctx.select("createdTimeUnixNano")
+    const ctx = this._ctx.select("cacheHits")
 
     const response: Awaited<number> = await ctx.execute()
 
@@ -5541,29 +7156,14 @@ export class EngineCacheEntry extends BaseClient {
   }
 
   /**
-   * The description of the cache entry.
-   */
-  description = async (): Promise<string> => {
-    if (this._description) {
-      return this._description
-    }
-
-    const ctx = this._ctx.select("description")
-
-    const response: Awaited<string> = await ctx.execute()
-
-    return response
-  }
-
-  /**
-   * The disk space used by the cache entry.
+   * The number of cacheable calls in the session that were not cached.
    */
-  diskSpaceBytes = async (): Promise<number> => {
-    if (this._diskSpaceBytes) {
-      return this._diskSpaceBytes
+  cacheMisses = async (): Promise<number> => {
+    if (this._cacheMisses) {
+      return this._cacheMisses
     }
 
-    const ctx = this._ctx.select("diskSpaceBytes")
+    const ctx = this._ctx.select("cacheMisses")
 
     const response: Awaited<number> = await ctx.execute()
 
@@ -5571,101 +7171,100 @@ export class EngineCacheEntry extends BaseClient {
   }
 
   /**
-   * The most recent time the cache entry was used, in Unix nanoseconds.
+   * The hostname of the main client.
    */
-  mostRecentUseTimeUnixNano = async (): Promise<number> => {
-    if (this._mostRecentUseTimeUnixNano) {
-      return this._mostRecentUseTimeUnixNano
+  clientHostname = async (): Promise<string> => {
+    if (this._clientHostname) {
+      return this._clientHostname
     }
 
-    const ctx = this._ctx.select("mostRecentUseTimeUnixNano")
+    const ctx = this._ctx.select("clientHostname")
 
-    const response: Awaited<number> = await ctx.execute()
+    const response: Awaited<string> = await ctx.execute()
 
     return response
   }
-}
-
-/**
- * A set of cache entries returned by a query to a cache
- */
-export class EngineCacheEntrySet extends BaseClient {
-  private readonly _id?: EngineCacheEntrySetID = undefined
-  private readonly _diskSpaceBytes?: number = undefined
-  private readonly _entryCount?: number = undefined
 
   /**
-   * Constructor is used for internal usage only, do not create object from it.
+   * The ID of the main client that started the session.
    */
-  constructor(
-    ctx?: Context,
-    _id?: EngineCacheEntrySetID,
-    _diskSpaceBytes?: number,
-    _entryCount?: number,
-  ) {
-    super(ctx)
+  clientId = async (): Promise<string> => {
+    if (this._clientId) {
+      return this._clientId
+    }
 
-    this._id = _id
-    this._diskSpaceBytes = _diskSpaceBytes
-    this._entryCount = _entryCount
+    const ctx = this._ctx.select("clientId")
+
+    const response: Awaited<string> = await ctx.execute()
+
+    return response
   }
 
   /**
-   * A unique identifier for this EngineCacheEntrySet.
+   * The version of the main client.
    */
-  id = async (): Promise<EngineCacheEntrySetID> => {
-    if (this._id) {
-      return this._id
+  clientVersion = async (): Promise<string> => {
+    if (this._clientVersion) {
+      return this._clientVersion
     }
 
-    const ctx = this._ctx.select("id")
+    const ctx = this._ctx.select("clientVersion")
 
-    const response: Awaited<EngineCacheEntrySetID> = await ctx.execute()
+    const response: Awaited<string> = await ctx.execute()
 
     return response
   }
 
   /**
-   * The total disk space used by the cache entries in this set.
+   * The command run by the main client, if known.
    */
-  diskSpaceBytes = async (): Promise<number> => {
-    if (this._diskSpaceBytes) {
-      return this._diskSpaceBytes
+  command = async (): Promise<string> => {
+    if (this._command) {
+      return this._command
     }
 
-    const ctx = this._ctx.select("diskSpaceBytes")
+    const ctx = this._ctx.select("command")
 
-    const response: Awaited<number> = await ctx.execute()
+    const response: Awaited<string> = await ctx.execute()
 
     return response
   }
 
   /**
-   * The list of individual cache entries in the set
+   * The names of the modules with clients connected to the session.
    */
-  entries = async (): Promise<EngineCacheEntry[]> => {
-    type entries = {
-      id: EngineCacheEntryID
+  modules = async (): Promise<string[]> => {
+    const ctx = this._ctx.select("modules")
+
+    const response: Awaited<string[]> = await ctx.execute()
+
+    return response
+  }
+
+  /**
+   * The number of containers currently running in the session.
+   */
+  runningExecs = async (): Promise<number> => {
+    if (this._runningExecs) {
+      return this._runningExecs
     }
 
-    const ctx = this._ctx.select("entries").select("id")
+    const ctx = this._ctx.select("runningExecs")
 
-    const response: Awaited<entries[]> = await ctx.execute()
+    const response: Awaited<number> = await ctx.execute()
 
-    return response.map((r) =>
-      new Client(ctx.copy()).loadEngineCacheEntryFromID(r.id),
-    )
+    return response
   }
 
   /**
-   * The number of cache entries in this set.
+   * The time the session started, in Unix nanoseconds.
    */
-  entryCount = async (): Promise<number> => {
-    if (this._entryCount) {
-      return this._entryCount
+  startedUnixNano = async (): Promise<number> => {
+    if (this._startedUnixNano) {
+      return this._startedUnixNano
     }
 
-    const ctx = this._ctx.select("entryCount")
+    const ctx = this._ctx.select("startedUnixNano")
 
     const response: Awaited<number> = await ctx.execute()
 
@@ -6148,6 +7747,73 @@ export class Env extends BaseClient {
     return new Env(ctx)
   }
 
+  /**
+   * Create or update a binding of type ContainerComparison in the environment
+   * @param name The name of the binding
+   * @param value The ContainerComparison value to assign to the binding
+   * @param description The purpose of the input
+   */
+  withContainerComparisonInput = (
+    name: string,
+    value: ContainerComparison,
+    description: string,
+  ): Env => {
+    const ctx = this._ctx.select("withContainerComparisonInput", {
+      name,
+      value,
+      description,
+    })
+    return new Env(ctx)
+  }
+
+  /**
+   * Declare a desired ContainerComparison output to be assigned in the environment
+   * @param name The name of the binding
+   * @param description A description of the desired value of the binding
+   */
+  withContainerComparisonOutput = (name: string, description: string): Env => {
+    const ctx = this._ctx.select("withContainerComparisonOutput", {
+      name,
+      description,
+    })
+    return new Env(ctx)
+  }
+
+  /**
+   * Create or update a binding of type ContainerConfigChange in the environment
+   * @param name The name of the binding
+   * @param value The ContainerConfigChange value to assign to the binding
+   * @param description The purpose of the input
+   */
+  withContainerConfigChangeInput = (
+    name: string,
+    value: ContainerConfigChange,
+    description: string,
+  ): Env => {
+    const ctx = this._ctx.select("withContainerConfigChangeInput", {
+      name,
+      value,
+      description,
+    })
+    return new Env(ctx)
+  }
+
+  /**
+   * Declare a desired ContainerConfigChange output to be assigned in the environment
+   * @param name The name of the binding
+   * @param description A description of the desired value of the binding
+   */
+  withContainerConfigChangeOutput = (
+    name: string,
+    description: string,
+  ): Env => {
+    const ctx = this._ctx.select("withContainerConfigChangeOutput", {
+      name,
+      description,
+    })
+    return new Env(ctx)
+  }
+
   /**
    * Create or update a binding of type Container in the environment
    * @param name The name of the binding
@@ -6167,6 +7833,41 @@ export class Env extends BaseClient {
     return new Env(ctx)
   }
 
+  /**
+   * Create or update a binding of type ContainerLayerComparison in the environment
+   * @param name The name of the binding
+   * @param value The ContainerLayerComparison value to assign to the binding
+   * @param description The purpose of the input
+   */
+  withContainerLayerComparisonInput = (
+    name: string,
+    value: ContainerLayerComparison,
+    description: string,
+  ): Env => {
+    const ctx = this._ctx.select("withContainerLayerComparisonInput", {
+      name,
+      value,
+      description,
+    })
+    return new Env(ctx)
+  }
+
+  /**
+   * Declare a desired ContainerLayerComparison output to be assigned in the environment
+   * @param name The name of the binding
+   * @param description A description of the desired value of the binding
+   */
+  withContainerLayerComparisonOutput = (
+    name: string,
+    description: string,
+  ): Env => {
+    const ctx = this._ctx.select("withContainerLayerComparisonOutput", {
+      name,
+      description,
+    })
+    return new Env(ctx)
+  }
+
   /**
    * Declare a desired Container output to be assigned in the environment
    * @param name The name of the binding
@@ -6216,6 +7917,70 @@ export class Env extends BaseClient {
     return new Env(ctx)
   }
 
+  /**
+   * Create or update a binding of type EngineService in the environment
+   * @param name The name of the binding
+   * @param value The EngineService value to assign to the binding
+   * @param description The purpose of the input
+   */
+  withEngineServiceInput = (
+    name: string,
+    value: EngineService,
+    description: string,
+  ): Env => {
+    const ctx = this._ctx.select("withEngineServiceInput", {
+      name,
+      value,
+      description,
+    })
+    return new Env(ctx)
+  }
+
+  /**
+   * Declare a desired EngineService output to be assigned in the environment
+   * @param name The name of the binding
+   * @param description A description of the desired value of the binding
+   */
+  withEngineServiceOutput = (name: string, description: string): Env => {
+    const ctx = this._ctx.select("withEngineServiceOutput", {
+      name,
+      description,
+    })
+    return new Env(ctx)
+  }
+
+  /**
+   * Create or update a binding of type EngineSession in the environment
+   * @param name The name of the binding
+   * @param value The EngineSession value to assign to the binding
+   * @param description The purpose of the input
+   */
+  withEngineSessionInput = (
+    name: string,
+    value: EngineSession,
+    description: string,
+  ): Env => {
+    const ctx = this._ctx.select("withEngineSessionInput", {
+      name,
+      value,
+      description,
+    })
+    return new Env(ctx)
+  }
+
+  /**
+   * Declare a desired EngineSession output to be assigned in the environment
+   * @param name The name of the binding
+   * @param description A description of the desired value of the binding
+   */
+  withEngineSessionOutput = (name: string, description: string): Env => {
+    const ctx = this._ctx.select("withEngineSessionOutput", {
+      name,
+      description,
+    })
+    return new Env(ctx)
+  }
+
   /**
    * Create or update a binding of type EnvFile in the environment
    * @param name The name of the binding
@@ -6287,6 +8052,41 @@ export class Env extends BaseClient {
     return new Env(ctx)
   }
 
+  /**
+   * Create or update a binding of type FunctionArgConstraints in the environment
+   * @param name The name of the binding
+   * @param value The FunctionArgConstraints value to assign to the binding
+   * @param description The purpose of the input
+   */
+  withFunctionArgConstraintsInput = (
+    name: string,
+    value: FunctionArgConstraints,
+    description: string,
+  ): Env => {
+    const ctx = this._ctx.select("withFunctionArgConstraintsInput", {
+      name,
+      value,
+      description,
+    })
+    return new Env(ctx)
+  }
+
+  /**
+   * Declare a desired FunctionArgConstraints output to be assigned in the environment
+   * @param name The name of the binding
+   * @param description A description of the desired value of the binding
+   */
+  withFunctionArgConstraintsOutput = (
+    name: string,
+    description: string,
+  ): Env => {
+    const ctx = this._ctx.select("withFunctionArgConstraintsOutput", {
+      name,
+      description,
+    })
+    return new Env(ctx)
+  }
+
   /**
    * Create or update a binding of type GitRef in the environment
    * @param name The name of the binding
@@ -6385,6 +8185,38 @@ export class Env extends BaseClient {
     return new Env(ctx)
   }
 
+  /**
+   * Create or update a binding of type ModuleCapabilities in the environment
+   * @param name The name of the binding
+   * @param value The ModuleCapabilities value to assign to the binding
+   * @param description The purpose of the input
+   */
+  withModuleCapabilitiesInput = (
+    name: string,
+    value: ModuleCapabilities,
+    description: string,
+  ): Env => {
+    const ctx = this._ctx.select("withModuleCapabilitiesInput", {
+      name,
+      value,
+      description,
+    })
+    return new Env(ctx)
+  }
+
+  /**
+   * Declare a desired ModuleCapabilities output to be assigned in the environment
+   * @param name The name of the binding
+   * @param description A description of the desired value of the binding
+   */
+  withModuleCapabilitiesOutput = (name: string, description: string): Env => {
+    const ctx = this._ctx.select("withModuleCapabilitiesOutput", {
+      name,
+      description,
+    })
+    return new Env(ctx)
+  }
+
   /**
    * Create or update a binding of type ModuleConfigClient in the environment
    * @param name The name of the binding
@@ -6478,6 +8310,52 @@ export class Env extends BaseClient {
     return new Env(ctx)
   }
 
+  /**
+   * Checks the values saved to an output by running a command against them
+   *
+   * When the command fails, its error is returned to the model, which is asked to fix the value until the command succeeds or the attempts run out. Directory, File and Container values are mounted at the given path, and String values are passed on stdin.
+   * @param name The name of the output
+   * @param container The container to run the command in
+   * @param args The command to run
+   * @param opts.path The path to mount the value at
+   * @param opts.maxAttempts The number of values to check before giving up
+   */
+  withOutputExecCheck = (
+    name: string,
+    container: Container,
+    args: string[],
+    opts?: EnvWithOutputExecCheckOpts,
+  ): Env => {
+    const ctx = this._ctx.select("withOutputExecCheck", {
+      name,
+      container,
+      args,
+      ...opts,
+    })
+    return new Env(ctx)
+  }
+
+  /**
+   * Checks the values saved to an output by calling a function against them
+   *
+   * The function must belong to a module installed in the environment, and take an argument of the output's type. When it fails, its error is returned to the model, which is asked to fix the value until the function succeeds or the attempts run out.
+   * @param name The name of the output
+   * @param function The name of the function to call
+   * @param opts.maxAttempts The number of values to check before giving up
+   */
+  withOutputFunctionCheck = (
+    name: string,
+    function_: string,
+    opts?: EnvWithOutputFunctionCheckOpts,
+  ): Env => {
+    const ctx = this._ctx.select("withOutputFunctionCheck", {
+      name,
+      function: function_,
+      ...opts,
+    })
+    return new Env(ctx)
+  }
+
   /**
    * Create or update a binding of type SearchResult in the environment
    * @param name The name of the binding
@@ -6667,6 +8545,38 @@ export class Env extends BaseClient {
     return new Env(ctx)
   }
 
+  /**
+   * Create or update a binding of type UnionTypeDef in the environment
+   * @param name The name of the binding
+   * @param value The UnionTypeDef value to assign to the binding
+   * @param description The purpose of the input
+   */
+  withUnionTypeDefInput = (
+    name: string,
+    value: UnionTypeDef,
+    description: string,
+  ): Env => {
+    const ctx = this._ctx.select("withUnionTypeDefInput", {
+      name,
+      value,
+      description,
+    })
+    return new Env(ctx)
+  }
+
+  /**
+   * Declare a desired UnionTypeDef output to be assigned in the environment
+   * @param name The name of the binding
+   * @param description A description of the desired value of the binding
+   */
+  withUnionTypeDefOutput = (name: string, description: string): Env => {
+    const ctx = this._ctx.select("withUnionTypeDefOutput", {
+      name,
+      description,
+    })
+    return new Env(ctx)
+  }
+
   /**
    * Returns a new environment with the provided workspace
    * @param workspace The directory to set as the host filesystem
@@ -7559,6 +9469,13 @@ export class Function_ extends BaseClient {
    * @param opts.ignore Patterns to ignore when loading the contextual argument value.
    * @param opts.sourceMap The source map for the argument definition.
    * @param opts.deprecated If deprecated, the reason or migration path.
+   * @param opts.min If the argument is an Integer or Float, the minimum value, inclusive, as a JSON number.
+   * @param opts.max If the argument is an Integer or Float, the maximum value, inclusive, as a JSON number.
+   * @param opts.pattern If the argument is a String, a regular expression (RE2 syntax) that the whole value must match.
+   * @param opts.minLength If the argument is a String or a list, the minimum length of the value.
+   * @param opts.maxLength If the argument is a String or a list, the maximum length of the value.
+   * @param opts.nonEmpty If the argument is a list, require it to contain at least one element.
+   * @param opts.requiredPaths If the argument is a Directory, paths that must exist in it.
    */
   withArg = (
     name: string,
@@ -7685,6 +9602,14 @@ export class FunctionArg extends BaseClient {
     return response
   }
 
+  /**
+   * Constraints the value of the argument must satisfy, if any.
+   */
+  constraints = (): FunctionArgConstraints => {
+    const ctx = this._ctx.select("constraints")
+    return new FunctionArgConstraints(ctx)
+  }
+
   /**
    * Only applies to arguments of type File or Directory. If the argument is not set, load it from the given path in the context directory
    */
@@ -7788,6 +9713,161 @@ export class FunctionArg extends BaseClient {
   }
 }
 
+/**
+ * Constraints on the value of a function argument.
+ *
+ * They are checked by the engine before the function is called.
+ */
+export class FunctionArgConstraints extends BaseClient {
+  private readonly _id?: FunctionArgConstraintsID = undefined
+  private readonly _max?: JSON = undefined
+  private readonly _maxLength?: number = undefined
+  private readonly _min?: JSON = undefined
+  private readonly _minLength?: number = undefined
+  private readonly _nonEmpty?: boolean = undefined
+  private readonly _pattern?: string = undefined
+
+  /**
+   * Constructor is used for internal usage only, do not create object from it.
+   */
+  constructor(
+    ctx?: Context,
+    _id?: FunctionArgConstraintsID,
+    _max?: JSON,
+    _maxLength?: number,
+    _min?: JSON,
+    _minLength?: number,
+    _nonEmpty?: boolean,
+    _pattern?: string,
+  ) {
+    super(ctx)
+
+    this._id = _id
+    this._max = _max
+    this._maxLength = _maxLength
+    this._min = _min
+    this._minLength = _minLength
+    this._nonEmpty = _nonEmpty
+    this._pattern = _pattern
+  }
+
+  /**
+   * A unique identifier for this FunctionArgConstraints.
+   */
+  id = async (): Promise<FunctionArgConstraintsID> => {
+    if (this._id) {
+      return this._id
+    }
+
+    const ctx = this._ctx.select("id")
+
+    const response: Awaited<FunctionArgConstraintsID> = await ctx.execute()
+
+    return response
+  }
+
+  /**
+   * Only applies to Integer and Float arguments. The maximum value, inclusive, as a JSON number.
+   */
+  max = async (): Promise<JSON> => {
+    if (this._max) {
+      return this._max
+    }
+
+    const ctx = this._ctx.select("max")
+
+    const response: Awaited<JSON> = await ctx.execute()
+
+    return response
+  }
+
+  /**
+   * Only applies to String and list arguments. The maximum length of the value, or 0 for no maximum.
+   */
+  maxLength = async (): Promise<number> => {
+    if (this._maxLength) {
+      return this._maxLength
+    }
+
+    const ctx = this._ctx.select("maxLength")
+
+    const response: Awaited<number> = await ctx.execute()
+
+    return response
+  }
+
+  /**
+   * Only applies to Integer and Float arguments. The minimum value, inclusive, as a JSON number.
+   */
+  min = async (): Promise<JSON> => {
+    if (this._min) {
+      return this._min
+    }
+
+    const ctx = this._ctx.select("min")
+
+    const response: Awaited<JSON> = await ctx.execute()
+
+    return response
+  }
+
+  /**
+   * Only applies to String and list arguments. The minimum length of the value.
+   */
+  minLength = async (): Promise<number> => {
+    if (this._minLength) {
+      return this._minLength
+    }
+
+    const ctx = this._ctx.select("minLength")
+
+    const response: Awaited<number> = await ctx.execute()
+
+    return response
+  }
+
+  /**
+   * Only applies to list arguments. Whether the list must contain at least one element.
+   */
+  nonEmpty = async (): Promise<boolean> => {
+    if (this._nonEmpty) {
+      return this._nonEmpty
+    }
+
+    const ctx = this._ctx.select("nonEmpty")
+
+    const response: Awaited<boolean> = await ctx.execute()
+
+    return response
+  }
+
+  /**
+   * Only applies to String arguments. A regular expression (RE2 syntax) that the whole value must match.
+   */
+  pattern = async (): Promise<string> => {
+    if (this._pattern) {
+      return this._pattern
+    }
+
+    const ctx = this._ctx.select("pattern")
+
+    const response: Awaited<string> = await ctx.execute()
+
+    return response
+  }
+
+  /**
+   * Only applies to arguments of type Directory. Paths that must exist in the directory.
+   */
+  requiredPaths = async (): Promise<string[]> => {
+    const ctx = this._ctx.select("requiredPaths")
+
+    const response: Awaited<string[]> = await ctx.execute()
+
+    return response
+  }
+}
+
 /**
  * An active function call.
  */
@@ -8877,6 +10957,17 @@ export class LLM extends BaseClient {
     return new Binding(ctx)
   }
 
+  /**
+   * return the calls recorded instead of being called in a dry run
+   */
+  dryRunCalls = async (): Promise<string[]> => {
+    const ctx = this._ctx.select("dryRunCalls")
+
+    const response: Awaited<string[]> = await ctx.execute()
+
+    return response
+  }
+
   /**
    * return the LLM's current environment
    */
@@ -8885,6 +10976,33 @@ export class LLM extends BaseClient {
     return new Env(ctx)
   }
 
+  /**
+   * Run several branches of the LLM in parallel, each with its own message history and a copy of its environment
+   *
+   * Each branch submits the queued prompt, followed by one of the variations in turn, if any. Branches that fail are still returned, and fail when they are used. The token usage of all the branches is available with fanOutTokenUsage.
+   * @param opts.n The number of branches to run. Defaults to the number of variations.
+   * @param opts.variations Prompts to append to the branches, one per branch in turn
+   */
+  fanOut = async (opts?: LLMFanOutOpts): Promise<LLM[]> => {
+    type fanOut = {
+      id: LLMID
+    }
+
+    const ctx = this._ctx.select("fanOut", { ...opts }).select("id")
+
+    const response: Awaited<fanOut[]> = await ctx.execute()
+
+    return response.map((r) => new Client(ctx.copy()).loadLLMFromID(r.id))
+  }
+
+  /**
+   * returns the token usage of the current state, including the other branches of the fan outs it came from
+   */
+  fanOutTokenUsage = (): LLMTokenUsage => {
+    const ctx = this._ctx.select("fanOutTokenUsage")
+    return new LLMTokenUsage(ctx)
+  }
+
   /**
    * Indicates whether there are any queued prompts or tool results to send to the model
    */
@@ -9039,6 +11157,14 @@ export class LLM extends BaseClient {
     return new LLM(ctx)
   }
 
+  /**
+   * Return a new LLM that records the calls it makes with side effects outside of the engine, such as exports, publishing or host access, instead of calling them
+   */
+  withDryRun = (): LLM => {
+    const ctx = this._ctx.select("withDryRun")
+    return new LLM(ctx)
+  }
+
   /**
    * allow the LLM to interact with an environment via MCP
    */
@@ -9101,6 +11227,26 @@ export class LLM extends BaseClient {
     return new LLM(ctx)
   }
 
+  /**
+   * Return a new LLM that applies a policy to the functions matching a pattern
+   *
+   * A pattern matches a function name, like withExec, or a type and function name, like Container.withExec, and may use * as a wildcard. Tools of MCP servers are matched by server and tool name. When several rules match a function, the last one applies, and functions that match no rule are allowed.
+   * @param pattern The functions to apply the policy to
+   * @param policy Whether the LLM may call the functions
+   */
+  withToolPolicy = (pattern: string, policy: LLMToolPolicy): LLM => {
+    const metadata = {
+      policy: { is_enum: true, value_to_name: LLMToolPolicyValueToName },
+    }
+
+    const ctx = this._ctx.select("withToolPolicy", {
+      pattern,
+      policy,
+      __metadata: metadata,
+    })
+    return new LLM(ctx)
+  }
+
   /**
    * Disable the default system prompt
    */
@@ -9567,55 +11713,213 @@ export class Module_ extends BaseClient {
   }
 
   /**
-   * User-defined default values, loaded from local .env files.
+   * Unions served by this module.
+   */
+  unions = async (): Promise<TypeDef[]> => {
+    type unions = {
+      id: TypeDefID
+    }
+
+    const ctx = this._ctx.select("unions").select("id")
+
+    const response: Awaited<unions[]> = await ctx.execute()
+
+    return response.map((r) => new Client(ctx.copy()).loadTypeDefFromID(r.id))
+  }
+
+  /**
+   * User-defined default values, loaded from local .env files.
+   */
+  userDefaults = (): EnvFile => {
+    const ctx = this._ctx.select("userDefaults")
+    return new EnvFile(ctx)
+  }
+
+  /**
+   * Retrieves the module with the given description
+   * @param description The description to set
+   */
+  withDescription = (description: string): Module_ => {
+    const ctx = this._ctx.select("withDescription", { description })
+    return new Module_(ctx)
+  }
+
+  /**
+   * This module plus the given Enum type and associated values
+   */
+  withEnum = (enum_: TypeDef): Module_ => {
+    const ctx = this._ctx.select("withEnum", {
+      enum: enum_,
+    })
+    return new Module_(ctx)
+  }
+
+  /**
+   * This module plus the given Interface type and associated functions
+   */
+  withInterface = (iface: TypeDef): Module_ => {
+    const ctx = this._ctx.select("withInterface", { iface })
+    return new Module_(ctx)
+  }
+
+  /**
+   * This module plus the given Object type and associated functions.
+   */
+  withObject = (object: TypeDef): Module_ => {
+    const ctx = this._ctx.select("withObject", { object })
+    return new Module_(ctx)
+  }
+
+  /**
+   * This module plus the given Union type
+   */
+  withUnion = (union: TypeDef): Module_ => {
+    const ctx = this._ctx.select("withUnion", { union })
+    return new Module_(ctx)
+  }
+
+  /**
+   * Call the provided function with current Module.
+   *
+   * This is useful for reusability and readability by not breaking the calling chain.
+   */
+  with = (arg: (param: Module_) => Module_) => {
+    return arg(this)
+  }
+}
+
+/**
+ * The capabilities a module requires.
+ */
+export class ModuleCapabilities extends BaseClient {
+  private readonly _id?: ModuleCapabilitiesID = undefined
+  private readonly _exec?: boolean = undefined
+  private readonly _hostSockets?: boolean = undefined
+  private readonly _llm?: boolean = undefined
+  private readonly _network?: boolean = undefined
+  private readonly _secrets?: boolean = undefined
+
+  /**
+   * Constructor is used for internal usage only, do not create object from it.
+   */
+  constructor(
+    ctx?: Context,
+    _id?: ModuleCapabilitiesID,
+    _exec?: boolean,
+    _hostSockets?: boolean,
+    _llm?: boolean,
+    _network?: boolean,
+    _secrets?: boolean,
+  ) {
+    super(ctx)
+
+    this._id = _id
+    this._exec = _exec
+    this._hostSockets = _hostSockets
+    this._llm = _llm
+    this._network = _network
+    this._secrets = _secrets
+  }
+
+  /**
+   * A unique identifier for this ModuleCapabilities.
+   */
+  id = async (): Promise<ModuleCapabilitiesID> => {
+    if (this._id) {
+      return this._id
+    }
+
+    const ctx = this._ctx.select("id")
+
+    const response: Awaited<ModuleCapabilitiesID> = await ctx.execute()
+
+    return response
+  }
+
+  /**
+   * Whether the module may run commands with access to the Dagger API.
    */
-  userDefaults = (): EnvFile => {
-    const ctx = this._ctx.select("userDefaults")
-    return new EnvFile(ctx)
+  exec = async (): Promise<boolean> => {
+    if (this._exec) {
+      return this._exec
+    }
+
+    const ctx = this._ctx.select("exec")
+
+    const response: Awaited<boolean> = await ctx.execute()
+
+    return response
   }
 
   /**
-   * Retrieves the module with the given description
-   * @param description The description to set
+   * Paths the module may read from the host through defaultPath arguments.
    */
-  withDescription = (description: string): Module_ => {
-    const ctx = this._ctx.select("withDescription", { description })
-    return new Module_(ctx)
+  hostRead = async (): Promise<string[]> => {
+    const ctx = this._ctx.select("hostRead")
+
+    const response: Awaited<string[]> = await ctx.execute()
+
+    return response
   }
 
   /**
-   * This module plus the given Enum type and associated values
+   * Whether the module may use host unix sockets and tunnels.
    */
-  withEnum = (enum_: TypeDef): Module_ => {
-    const ctx = this._ctx.select("withEnum", {
-      enum: enum_,
-    })
-    return new Module_(ctx)
+  hostSockets = async (): Promise<boolean> => {
+    if (this._hostSockets) {
+      return this._hostSockets
+    }
+
+    const ctx = this._ctx.select("hostSockets")
+
+    const response: Awaited<boolean> = await ctx.execute()
+
+    return response
   }
 
   /**
-   * This module plus the given Interface type and associated functions
+   * Whether the module may use LLMs.
    */
-  withInterface = (iface: TypeDef): Module_ => {
-    const ctx = this._ctx.select("withInterface", { iface })
-    return new Module_(ctx)
+  llm = async (): Promise<boolean> => {
+    if (this._llm) {
+      return this._llm
+    }
+
+    const ctx = this._ctx.select("llm")
+
+    const response: Awaited<boolean> = await ctx.execute()
+
+    return response
   }
 
   /**
-   * This module plus the given Object type and associated functions.
+   * Whether the module may run commands and services with full network access, and download files with http. Image pulls and git fetches are done by the engine and aren't restricted.
    */
-  withObject = (object: TypeDef): Module_ => {
-    const ctx = this._ctx.select("withObject", { object })
-    return new Module_(ctx)
+  network = async (): Promise<boolean> => {
+    if (this._network) {
+      return this._network
+    }
+
+    const ctx = this._ctx.select("network")
+
+    const response: Awaited<boolean> = await ctx.execute()
+
+    return response
   }
 
   /**
-   * Call the provided function with current Module.
-   *
-   * This is useful for reusability and readability by not breaking the calling chain.
+   * Whether the module may load secrets from the host's secret providers.
    */
-  with = (arg: (param: Module_) => Module_) => {
-    return arg(this)
+  secrets = async (): Promise<boolean> => {
+    if (this._secrets) {
+      return this._secrets
+    }
+
+    const ctx = this._ctx.select("secrets")
+
+    const response: Awaited<boolean> = await ctx.execute()
+
+    return response
   }
 }
 
@@ -9810,6 +12114,14 @@ export class ModuleSource extends BaseClient {
     return new ModuleSource(ctx)
   }
 
+  /**
+   * The capabilities the module requires, as declared in its dagger.json. Null if the module declares none, in which case it requires all capabilities.
+   */
+  capabilities = (): ModuleCapabilities => {
+    const ctx = this._ctx.select("capabilities")
+    return new ModuleCapabilities(ctx)
+  }
+
   /**
    * The ref to clone the root of the git repo from. Only valid for git sources.
    */
@@ -9944,6 +12256,14 @@ export class ModuleSource extends BaseClient {
     return new Directory(ctx)
   }
 
+  /**
+   * The capabilities granted to the module when it was installed as a dependency. Null if none were recorded, in which case it is only restricted by the capabilities it declares.
+   */
+  grantedCapabilities = (): ModuleCapabilities => {
+    const ctx = this._ctx.select("grantedCapabilities")
+    return new ModuleCapabilities(ctx)
+  }
+
   /**
    * The URL to access the web view of the repository (e.g., GitHub, GitLab, Bitbucket).
    */
@@ -10228,6 +12548,22 @@ export class ModuleSource extends BaseClient {
     return new ModuleSource(ctx)
   }
 
+  /**
+   * Record the capabilities granted to the module as a dependency. Recorded in the parent module's dagger.json when installed.
+   * @param opts.hostRead Paths the module may read from the host through defaultPath arguments.
+   * @param opts.hostSockets Whether the module may use host unix sockets and tunnels.
+   * @param opts.network Whether the module may run commands and services with full network access, and download files with http. Image pulls and git fetches are done by the engine and aren't restricted.
+   * @param opts.llm Whether the module may use LLMs.
+   * @param opts.exec Whether the module may run commands with access to the Dagger API.
+   * @param opts.secrets Whether the module may load secrets from the host's secret providers.
+   */
+  withGrantedCapabilities = (
+    opts?: ModuleSourceWithGrantedCapabilitiesOpts,
+  ): ModuleSource => {
+    const ctx = this._ctx.select("withGrantedCapabilities", { ...opts })
+    return new ModuleSource(ctx)
+  }
+
   /**
    * Update the module source with additional include patterns for files+directories from its context that are required for building it
    * @param patterns The new additional include patterns.
@@ -10931,6 +13267,26 @@ export class Client extends BaseClient {
     return new Cloud(ctx)
   }
 
+  /**
+   * Load a ContainerComparison from its ID.
+   */
+  loadContainerComparisonFromID = (
+    id: ContainerComparisonID,
+  ): ContainerComparison => {
+    const ctx = this._ctx.select("loadContainerComparisonFromID", { id })
+    return new ContainerComparison(ctx)
+  }
+
+  /**
+   * Load a ContainerConfigChange from its ID.
+   */
+  loadContainerConfigChangeFromID = (
+    id: ContainerConfigChangeID,
+  ): ContainerConfigChange => {
+    const ctx = this._ctx.select("loadContainerConfigChangeFromID", { id })
+    return new ContainerConfigChange(ctx)
+  }
+
   /**
    * Load a Container from its ID.
    */
@@ -10939,6 +13295,16 @@ export class Client extends BaseClient {
     return new Container(ctx)
   }
 
+  /**
+   * Load a ContainerLayerComparison from its ID.
+   */
+  loadContainerLayerComparisonFromID = (
+    id: ContainerLayerComparisonID,
+  ): ContainerLayerComparison => {
+    const ctx = this._ctx.select("loadContainerLayerComparisonFromID", { id })
+    return new ContainerLayerComparison(ctx)
+  }
+
   /**
    * Load a CurrentModule from its ID.
    */
@@ -10989,6 +13355,22 @@ export class Client extends BaseClient {
     return new Engine(ctx)
   }
 
+  /**
+   * Load a EngineService from its ID.
+   */
+  loadEngineServiceFromID = (id: EngineServiceID): EngineService => {
+    const ctx = this._ctx.select("loadEngineServiceFromID", { id })
+    return new EngineService(ctx)
+  }
+
+  /**
+   * Load a EngineSession from its ID.
+   */
+  loadEngineSessionFromID = (id: EngineSessionID): EngineSession => {
+    const ctx = this._ctx.select("loadEngineSessionFromID", { id })
+    return new EngineSession(ctx)
+  }
+
   /**
    * Load a EnumTypeDef from its ID.
    */
@@ -11061,6 +13443,16 @@ export class Client extends BaseClient {
     return new File(ctx)
   }
 
+  /**
+   * Load a FunctionArgConstraints from its ID.
+   */
+  loadFunctionArgConstraintsFromID = (
+    id: FunctionArgConstraintsID,
+  ): FunctionArgConstraints => {
+    const ctx = this._ctx.select("loadFunctionArgConstraintsFromID", { id })
+    return new FunctionArgConstraints(ctx)
+  }
+
   /**
    * Load a FunctionArg from its ID.
    */
@@ -11183,6 +13575,16 @@ export class Client extends BaseClient {
     return new ListTypeDef(ctx)
   }
 
+  /**
+   * Load a ModuleCapabilities from its ID.
+   */
+  loadModuleCapabilitiesFromID = (
+    id: ModuleCapabilitiesID,
+  ): ModuleCapabilities => {
+    const ctx = this._ctx.select("loadModuleCapabilitiesFromID", { id })
+    return new ModuleCapabilities(ctx)
+  }
+
   /**
    * Load a ModuleConfigClient from its ID.
    */
@@ -11313,6 +13715,14 @@ export class Client extends BaseClient {
     return new TypeDef(ctx)
   }
 
+  /**
+   * Load a UnionTypeDef from its ID.
+   */
+  loadUnionTypeDefFromID = (id: UnionTypeDefID): UnionTypeDef => {
+    const ctx = this._ctx.select("loadUnionTypeDefFromID", { id })
+    return new UnionTypeDef(ctx)
+  }
+
   /**
    * Create a new module.
    */
@@ -11348,6 +13758,17 @@ export class Client extends BaseClient {
     return new ModuleSource(ctx)
   }
 
+  /**
+   * Attach to a persistent service running in the engine under the given name.
+   *
+   * The service may have been started by another session, with `start(persistent: true)`. Only the main client can attach to it.
+   * @param name The name the service was started with.
+   */
+  runningService = (name: string): Service => {
+    const ctx = this._ctx.select("runningService", { name })
+    return new Service(ctx)
+  }
+
   /**
    * Creates a new secret.
    * @param uri The URI of the secret store
@@ -11863,6 +14284,7 @@ export class Service extends BaseClient {
   private readonly _id?: ServiceID = undefined
   private readonly _endpoint?: string = undefined
   private readonly _hostname?: string = undefined
+  private readonly _name?: string = undefined
   private readonly _start?: ServiceID = undefined
   private readonly _stop?: ServiceID = undefined
   private readonly _sync?: ServiceID = undefined
@@ -11876,6 +14298,7 @@ export class Service extends BaseClient {
     _id?: ServiceID,
     _endpoint?: string,
     _hostname?: string,
+    _name?: string,
     _start?: ServiceID,
     _stop?: ServiceID,
     _sync?: ServiceID,
@@ -11886,6 +14309,7 @@ export class Service extends BaseClient {
     this._id = _id
     this._endpoint = _endpoint
     this._hostname = _hostname
+    this._name = _name
     this._start = _start
     this._stop = _stop
     this._sync = _sync
@@ -11943,6 +14367,21 @@ export class Service extends BaseClient {
     return response
   }
 
+  /**
+   * The name of the service, if set with withName.
+   */
+  name = async (): Promise<string> => {
+    if (this._name) {
+      return this._name
+    }
+
+    const ctx = this._ctx.select("name")
+
+    const response: Awaited<string> = await ctx.execute()
+
+    return response
+  }
+
   /**
    * Retrieves the list of ports provided by the service.
    */
@@ -11962,9 +14401,15 @@ export class Service extends BaseClient {
    * Start the service and wait for its health checks to succeed.
    *
    * Services bound to a Container do not need to be manually started.
+   * @param opts.persistent Keep the service running after the session that started it ends.
+   *
+   * The service must have a name, set with withName. Other sessions can attach to it with runningService, or by starting or binding the same service.
+   * @param opts.idleTimeout Stop a persistent service once nothing has used it for this long, as a duration string (e.g., "30m", "1h").
+   *
+   * If unset, the service runs until it's stopped.
    */
-  start = async (): Promise<Service> => {
-    const ctx = this._ctx.select("start")
+  start = async (opts?: ServiceStartOpts): Promise<Service> => {
+    const ctx = this._ctx.select("start", { ...opts })
 
     const response: Awaited<ServiceID> = await ctx.execute()
 
@@ -11973,6 +14418,8 @@ export class Service extends BaseClient {
 
   /**
    * Stop the service.
+   *
+   * A persistent service can only be stopped by the main client.
    * @param opts.kill Immediately kill the service without waiting for a graceful exit
    */
   stop = async (opts?: ServiceStopOpts): Promise<Service> => {
@@ -12024,6 +14471,15 @@ export class Service extends BaseClient {
     return new Service(ctx)
   }
 
+  /**
+   * Configures a name for the service, under which it can be started as a persistent service shared across sessions.
+   * @param name The name to use.
+   */
+  withName = (name: string): Service => {
+    const ctx = this._ctx.select("withName", { name })
+    return new Service(ctx)
+  }
+
   /**
    * Call the provided function with current Service.
    *
@@ -12428,6 +14884,14 @@ export class TypeDef extends BaseClient {
     return new ScalarTypeDef(ctx)
   }
 
+  /**
+   * If kind is UNION, the union-specific type definition. If kind is not UNION, this will be null.
+   */
+  asUnion = (): UnionTypeDef => {
+    const ctx = this._ctx.select("asUnion")
+    return new UnionTypeDef(ctx)
+  }
+
   /**
    * The kind of type this is (e.g. primitive, list, object).
    */
@@ -12591,6 +15055,28 @@ export class TypeDef extends BaseClient {
     return new TypeDef(ctx)
   }
 
+  /**
+   * Returns a TypeDef of kind Union with the provided name.
+   *
+   * Note that a union's members may be omitted if the intent is only to refer to a union.
+   * @param name The name of the union
+   * @param opts.description A doc string for the union, if any
+   * @param opts.sourceMap The source map for the union definition.
+   */
+  withUnion = (name: string, opts?: TypeDefWithUnionOpts): TypeDef => {
+    const ctx = this._ctx.select("withUnion", { name, ...opts })
+    return new TypeDef(ctx)
+  }
+
+  /**
+   * Adds a member type to a Union TypeDef, failing if the type is not a union or the member is not an object.
+   * @param member The object type to add to the union
+   */
+  withUnionMember = (member: TypeDef): TypeDef => {
+    const ctx = this._ctx.select("withUnionMember", { member })
+    return new TypeDef(ctx)
+  }
+
   /**
    * Call the provided function with current TypeDef.
    *
@@ -12601,4 +15087,117 @@ export class TypeDef extends BaseClient {
   }
 }
 
+/**
+ * A definition of a custom union defined in a Module.
+ *
+ * A value of a union has exactly one of the union's member types.
+ */
+export class UnionTypeDef extends BaseClient {
+  private readonly _id?: UnionTypeDefID = undefined
+  private readonly _description?: string = undefined
+  private readonly _name?: string = undefined
+  private readonly _sourceModuleName?: string = undefined
+
+  /**
+   * Constructor is used for internal usage only, do not create object from it.
+   */
+  constructor(
+    ctx?: Context,
+    _id?: UnionTypeDefID,
+    _description?: string,
+    _name?: string,
+    _sourceModuleName?: string,
+  ) {
+    super(ctx)
+
+    this._id = _id
+    this._description = _description
+    this._name = _name
+    this._sourceModuleName = _sourceModuleName
+  }
+
+  /**
+   * A unique identifier for this UnionTypeDef.
+   */
+  id = async (): Promise<UnionTypeDefID> => {
+    if (this._id) {
+      return this._id
+    }
+
+    const ctx = this._ctx.select("id")
+
+    const response: Awaited<UnionTypeDefID> = await ctx.execute()
+
+    return response
+  }
+
+  /**
+   * The doc string for the union, if any.
+   */
+  description = async (): Promise<string> => {
+    if (this._description) {
+      return this._description
+    }
+
+    const ctx = this._ctx.select("description")
+
+    const response: Awaited<string> = await ctx.execute()
+
+    return response
+  }
+
+  /**
+   * The types a value of this union can have. They are always objects.
+   */
+  members = async (): Promise<TypeDef[]> => {
+    type members = {
+      id: TypeDefID
+    }
+
+    const ctx = this._ctx.select("members").select("id")
+
+    const response: Awaited<members[]> = await ctx.execute()
+
+    return response.map((r) => new Client(ctx.copy()).loadTypeDefFromID(r.id))
+  }
+
+  /**
+   * The name of the union.
+   */
+  name = async (): Promise<string> => {
+    if (this._name) {
+      return this._name
+    }
+
+    const ctx = this._ctx.select("name")
+
+    const response: Awaited<string> = await ctx.execute()
+
+    return response
+  }
+
+  /**
+   * The location of this union declaration.
+   */
+  sourceMap = (): SourceMap => {
+    const ctx = this._ctx.select("sourceMap")
+    return new SourceMap(ctx)
+  }
+
+  /**
+   * If this UnionTypeDef is associated with a Module, the name of the module. Unset otherwise.
+   */
+  sourceModuleName = async (): Promise<string> => {
+    if (this._sourceModuleName) {
+      return this._sourceModuleName
+    }
+
+    const ctx = this._ctx.select("sourceModuleName")
+
+    const response: Awaited<string> = await ctx.execute()
+
+    return response
+  }
+}
+
 export const dag = new Client()
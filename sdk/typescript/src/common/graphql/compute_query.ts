@@ -131,6 +131,22 @@ async function computeNestedQuery(
 
           q.args[key] = tmp
         }
+
+        // Compute nested query for fields of an input object, such as
+        // the member set in a union input
+        if (
+          value instanceof Object &&
+          !Array.isArray(value) &&
+          !isQueryTree(value)
+        ) {
+          for (const [field, fieldValue] of Object.entries(value)) {
+            if (fieldValue instanceof Object && isQueryTree(fieldValue)) {
+              const getQueryTree = await computeQueryTree(fieldValue)
+
+              value[field] = await compute(getQueryTree, client)
+            }
+          }
+        }
       }),
     )
   }
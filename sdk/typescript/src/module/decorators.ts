@@ -54,6 +54,15 @@ export const enumType = registry.enumType
  * load it from the given path in the context directory.
  * @param opts.ignore Only applies to arguments of type Directory. The ignore patterns are applied to the input directory,
  * and matching entries are filtered out, in a cache-efficient manner..
+ * @param opts.min Only applies to number arguments. The minimum value, inclusive.
+ * @param opts.max Only applies to number arguments. The maximum value, inclusive.
+ * @param opts.pattern Only applies to string arguments. A regular expression (RE2 syntax) that the whole value must match.
+ * @param opts.minLength Only applies to string or array arguments. The minimum length of the value.
+ * @param opts.maxLength Only applies to string or array arguments. The maximum length of the value.
+ * @param opts.nonEmpty Only applies to array arguments. Whether the array must contain at least one element.
+ * @param opts.requiredPaths Only applies to arguments of type Directory. Paths that must exist in the directory.
+ *
+ * The engine checks these constraints before calling the function.
  *
  * Relative paths are relative to the current source files.
  * Absolute paths are rooted to the module context directory.
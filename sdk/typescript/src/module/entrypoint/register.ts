@@ -218,6 +218,35 @@ export class Register {
           opts.ignore = arg.ignore
         }
 
+        // Constraints are checked by the engine before calling the function.
+        if (arg.min !== undefined) {
+          opts.min = JSON.stringify(arg.min) as string & { __JSON: never }
+        }
+
+        if (arg.max !== undefined) {
+          opts.max = JSON.stringify(arg.max) as string & { __JSON: never }
+        }
+
+        if (arg.pattern) {
+          opts.pattern = arg.pattern
+        }
+
+        if (arg.minLength) {
+          opts.minLength = arg.minLength
+        }
+
+        if (arg.maxLength) {
+          opts.maxLength = arg.maxLength
+        }
+
+        if (arg.nonEmpty) {
+          opts.nonEmpty = arg.nonEmpty
+        }
+
+        if (arg.requiredPaths) {
+          opts.requiredPaths = arg.requiredPaths
+        }
+
         fct = fct.withArg(arg.name, typeDef, opts)
       })
 
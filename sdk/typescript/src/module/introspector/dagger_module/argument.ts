@@ -28,6 +28,13 @@ export class DaggerArgument extends Locatable {
   public defaultPath?: string
   public ignore?: string[]
   public defaultValue?: any
+  public min?: number
+  public max?: number
+  public pattern?: string
+  public minLength?: number
+  public maxLength?: number
+  public nonEmpty?: boolean
+  public requiredPaths?: string[]
 
   private symbol: ts.Symbol
 
@@ -67,6 +74,13 @@ export class DaggerArgument extends Locatable {
     if (decoratorArguments) {
       this.ignore = decoratorArguments.ignore
       this.defaultPath = decoratorArguments.defaultPath
+      this.min = decoratorArguments.min
+      this.max = decoratorArguments.max
+      this.pattern = decoratorArguments.pattern
+      this.minLength = decoratorArguments.minLength
+      this.maxLength = decoratorArguments.maxLength
+      this.nonEmpty = decoratorArguments.nonEmpty
+      this.requiredPaths = decoratorArguments.requiredPaths
     }
 
     this.type = this.getType()
@@ -156,6 +170,13 @@ export class DaggerArgument extends Locatable {
       defaultValue: this.defaultValue,
       defaultPath: this.defaultPath,
       ignore: this.ignore,
+      min: this.min,
+      max: this.max,
+      pattern: this.pattern,
+      minLength: this.minLength,
+      maxLength: this.maxLength,
+      nonEmpty: this.nonEmpty,
+      requiredPaths: this.requiredPaths,
     }
   }
 }
@@ -35,6 +35,10 @@ describe("scan by reference TypeScript", function () {
       name: "Should correctly handle context",
       directory: "context",
     },
+    {
+      name: "Should correctly handle argument constraints",
+      directory: "constraints",
+    },
     {
       name: "Should correctly scan scalar",
       directory: "scalar",
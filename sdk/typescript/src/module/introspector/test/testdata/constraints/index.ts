@@ -0,0 +1,25 @@
+import { Directory } from "../../../../../api/client.gen.js"
+import { func, object, argument } from "../../../../decorators.js"
+
+@object()
+export class Constraints {
+  @func()
+  release(
+    @argument({ pattern: "v[0-9]+" })
+    version: string,
+    @argument({ min: 1, max: 100 })
+    replicas: number,
+    @argument({ nonEmpty: true, maxLength: 3 })
+    platforms: string[],
+  ): string {
+    return `${version} ${replicas} ${platforms.join(",")}`
+  }
+
+  @func()
+  build(
+    @argument({ requiredPaths: ["go.mod"] })
+    src: Directory,
+  ): Directory {
+    return src
+  }
+}
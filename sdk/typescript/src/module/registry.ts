@@ -37,6 +37,55 @@ export type ArgumentOptions = {
    * This should only be used for Directory types.
    */
   ignore?: string[]
+
+  /**
+   * The minimum value of the argument, inclusive.
+   *
+   * This should only be used for number types.
+   */
+  min?: number
+
+  /**
+   * The maximum value of the argument, inclusive.
+   *
+   * This should only be used for number types.
+   */
+  max?: number
+
+  /**
+   * A regular expression (RE2 syntax) that the whole value must match.
+   *
+   * This should only be used for string types.
+   */
+  pattern?: string
+
+  /**
+   * The minimum length of the value.
+   *
+   * This should only be used for string or array types.
+   */
+  minLength?: number
+
+  /**
+   * The maximum length of the value.
+   *
+   * This should only be used for string or array types.
+   */
+  maxLength?: number
+
+  /**
+   * Whether the array must contain at least one element.
+   *
+   * This should only be used for array types.
+   */
+  nonEmpty?: boolean
+
+  /**
+   * Paths that must exist in the directory.
+   *
+   * This should only be used for Directory types.
+   */
+  requiredPaths?: string[]
 }
 
 export type FunctionOptions = {
@@ -0,0 +1,118 @@
+package fsxutil
+
+import (
+	"context"
+	gofs "io/fs"
+	"slices"
+	"time"
+
+	"github.com/dagger/dagger/internal/fsutil"
+	"github.com/pkg/errors"
+)
+
+// WatchOpt configures which files of a directory are watched for changes.
+type WatchOpt struct {
+	// IncludePatterns and ExcludePatterns filter the watched paths, the same
+	// way as fsutil.FilterOpt
+	IncludePatterns []string
+	ExcludePatterns []string
+
+	// GitIgnore skips paths ignored by .gitignore files
+	GitIgnore bool
+}
+
+// Snapshot is the state of the watched files in a directory at some point.
+type Snapshot map[string]fileState
+
+type fileState struct {
+	mode    gofs.FileMode
+	size    int64
+	modTime time.Time
+}
+
+// TakeSnapshot records the state of the watched files under root.
+func TakeSnapshot(ctx context.Context, root string, opt WatchOpt) (Snapshot, error) {
+	baseFS, err := fsutil.NewFS(root)
+	if err != nil {
+		return nil, err
+	}
+	filterFS, err := fsutil.NewFilterFS(baseFS, &fsutil.FilterOpt{
+		IncludePatterns: opt.IncludePatterns,
+		// the git dir changes on every git command, but never affects builds
+		ExcludePatterns: append(slices.Clone(opt.ExcludePatterns), ".git"),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if opt.GitIgnore {
+		filterFS, err = NewGitIgnoreFS(filterFS, NewGitIgnoreMatcher(baseFS))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	snapshot := Snapshot{}
+	err = filterFS.Walk(ctx, "", func(path string, entry gofs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		state := fileState{mode: info.Mode()}
+		if !info.IsDir() {
+			// directory sizes and times change along with their entries,
+			// which are compared already
+			state.size = info.Size()
+			state.modTime = info.ModTime()
+		}
+		snapshot[path] = state
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "walk %s", root)
+	}
+	return snapshot, nil
+}
+
+// Changes returns the sorted paths that were added, removed or modified
+// between the snapshots.
+func (snapshot Snapshot) Changes(other Snapshot) []string {
+	var changes []string
+	for path, state := range snapshot {
+		otherState, ok := other[path]
+		if !ok || !otherState.modTime.Equal(state.modTime) || otherState.size != state.size || otherState.mode != state.mode {
+			changes = append(changes, path)
+		}
+	}
+	for path := range other {
+		if _, ok := snapshot[path]; !ok {
+			changes = append(changes, path)
+		}
+	}
+	slices.Sort(changes)
+	return changes
+}
+
+// WaitForChanges polls the watched files under root every interval until some
+// differ from the given snapshot, returning the new snapshot and the changed
+// paths.
+func WaitForChanges(ctx context.Context, root string, opt WatchOpt, since Snapshot, interval time.Duration) (Snapshot, []string, error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, nil, context.Cause(ctx)
+		case <-ticker.C:
+		}
+		snapshot, err := TakeSnapshot(ctx, root, opt)
+		if err != nil {
+			return nil, nil, err
+		}
+		if changes := since.Changes(snapshot); len(changes) > 0 {
+			return snapshot, changes, nil
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package fsxutil
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshotChanges(t *testing.T) {
+	d, err := tmpDir(changeStream([]string{
+		`ADD .gitignore file "*.log"`,
+		`ADD foo.txt file`,
+		`ADD bar.log file`,
+		`ADD sub dir`,
+		`ADD sub/nested.txt file`,
+		`ADD other dir`,
+		`ADD other/file.txt file`,
+	}))
+	require.NoError(t, err)
+	defer os.RemoveAll(d)
+
+	ctx := context.Background()
+	opt := WatchOpt{
+		ExcludePatterns: []string{"other"},
+		GitIgnore:       true,
+	}
+	before, err := TakeSnapshot(ctx, d, opt)
+	require.NoError(t, err)
+	require.Empty(t, before.Changes(before))
+
+	// ignored changes
+	require.NoError(t, os.WriteFile(filepath.Join(d, "bar.log"), []byte("changed"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(d, "other", "file.txt"), []byte("changed"), 0o600))
+	after, err := TakeSnapshot(ctx, d, opt)
+	require.NoError(t, err)
+	require.Empty(t, before.Changes(after))
+
+	// watched changes
+	require.NoError(t, os.WriteFile(filepath.Join(d, "sub", "nested.txt"), []byte("changed"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(d, "new.txt"), nil, 0o600))
+	require.NoError(t, os.Remove(filepath.Join(d, "foo.txt")))
+	after, err = TakeSnapshot(ctx, d, opt)
+	require.NoError(t, err)
+	require.Equal(t, []string{"foo.txt", "new.txt", "sub/nested.txt"}, before.Changes(after))
+}
+
+func TestWaitForChanges(t *testing.T) {
+	d := t.TempDir()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	before, err := TakeSnapshot(ctx, d, WatchOpt{})
+	require.NoError(t, err)
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		os.WriteFile(filepath.Join(d, "foo.txt"), []byte("foo"), 0o600)
+	}()
+	after, changes, err := WaitForChanges(ctx, d, WatchOpt{}, before, 10*time.Millisecond)
+	require.NoError(t, err)
+	require.Equal(t, []string{"foo.txt"}, changes)
+	require.Contains(t, after, "foo.txt")
+
+	ctx, cancel = context.WithCancel(ctx)
+	cancel()
+	_, _, err = WaitForChanges(ctx, d, WatchOpt{}, after, 10*time.Millisecond)
+	require.ErrorIs(t, err, context.Canceled)
+}